@@ -206,6 +206,10 @@ include "nginx-ingress.image-digest-or-tag" (dict "image" .Values.controller.ima
 {{- printf "%s-%s" (include "nginx-ingress.fullname" .) "prometheus-service"  -}}
 {{- end -}}
 
+{{- define "nginx-ingress.admissionWebhook.serviceName" -}}
+{{- printf "%s-%s" (include "nginx-ingress.fullname" .) "admission-webhook"  -}}
+{{- end -}}
+
 {{/*
 return if readOnlyRootFilesystem is enabled or not.
 */}}
@@ -341,6 +345,9 @@ Build the args for the service binary.
 - -enable-service-insight={{ .Values.serviceInsight.create }}
 - -service-insight-listen-port={{ .Values.serviceInsight.port }}
 - -service-insight-tls-secret={{ .Values.serviceInsight.secret }}
+- -enable-admission-webhook={{ .Values.admissionWebhook.enable }}
+- -admission-webhook-listen-port={{ .Values.admissionWebhook.port }}
+- -admission-webhook-tls-secret={{ .Values.admissionWebhook.secret }}
 - -enable-custom-resources={{ .Values.controller.enableCustomResources }}
 - -enable-snippets={{ .Values.controller.enableSnippets }}
 - -disable-ipv6={{ .Values.controller.disableIPV6 }}
@@ -355,6 +362,7 @@ Build the args for the service binary.
 - -enable-cert-manager={{ .Values.controller.enableCertManager }}
 - -enable-oidc={{ .Values.controller.enableOIDC }}
 - -enable-external-dns={{ .Values.controller.enableExternalDNS }}
+- -enable-gateway-api={{ .Values.controller.enableGatewayAPI }}
 - -default-http-listener-port={{ .Values.controller.defaultHTTPListenerPort}}
 - -default-https-listener-port={{ .Values.controller.defaultHTTPSListenerPort}}
 - -allow-empty-ingress-host={{ .Values.controller.allowEmptyIngressHost }}
@@ -367,6 +375,9 @@ Build the args for the service binary.
 - -ready-status={{ .Values.controller.readyStatus.enable }}
 - -ready-status-port={{ .Values.controller.readyStatus.port }}
 - -enable-latency-metrics={{ .Values.controller.enableLatencyMetrics }}
+- -enable-host-metrics={{ .Values.controller.enableHostMetrics }}
+- -certificate-expiry-thresholds={{ .Values.controller.certificateExpiryThresholds }}
+- -enable-outlier-detection={{ .Values.controller.enableOutlierDetection }}
 - -ssl-dynamic-reload={{ .Values.controller.enableSSLDynamicReload }}
 - -enable-telemetry-reporting={{ .Values.controller.telemetryReporting.enable}}
 - -weight-changes-dynamic-reload={{ .Values.controller.enableWeightChangesDynamicReload}}
@@ -8,6 +8,7 @@ import (
 	"os"
 	"regexp"
 	"strings"
+	"time"
 
 	internalValidation "github.com/nginx/kubernetes-ingress/internal/validation"
 	api_v1 "k8s.io/api/core/v1"
@@ -175,6 +176,15 @@ var (
 	serviceInsightListenPort = flag.Int("service-insight-listen-port", 9114,
 		"Set the port where the Service Insight stats are exposed. Requires -nginx-plus. [1024 - 65535]")
 
+	enableAdmissionWebhook = flag.Bool("enable-admission-webhook", false,
+		`Enable the validating admission webhook endpoint for the Ingress resources and the custom resources. Requires -admission-webhook-tls-secret`)
+
+	admissionWebhookTLSSecretName = flag.String("admission-webhook-tls-secret", "",
+		`A Secret with a TLS certificate and key for TLS termination of the admission webhook endpoint. Format: <namespace>/<name>`)
+
+	admissionWebhookListenPort = flag.Int("admission-webhook-listen-port", 8443,
+		"Set the port where the admission webhook endpoint is exposed. [1024 - 65535]")
+
 	enableCustomResources = flag.Bool("enable-custom-resources", true,
 		"Enable custom resources")
 
@@ -206,12 +216,26 @@ var (
 	enableLatencyMetrics = flag.Bool("enable-latency-metrics", false,
 		"Enable collection of latency metrics for upstreams. Requires -enable-prometheus-metrics")
 
+	enableHostMetrics = flag.Bool("enable-host-metrics", false,
+		"Enable collection of the request metrics of the Ingress, VirtualServer and VirtualServerRoute resources for NGINX. Requires -enable-prometheus-metrics")
+
+	certificateExpiryThresholdsFlag = flag.String("certificate-expiry-thresholds", "720h,168h,24h",
+		`Set the durations before the expiry of the TLS and CA certificates of the secrets at which the Ingress Controller emits Warning events on the referencing Ingress, VirtualServer and TransportServer resources and adds warnings to their statuses. Separate multiple durations by commas. Expired certificates are always reported`)
+
+	certificateExpiryThresholds []time.Duration
+
+	enableOutlierDetection = flag.Bool("enable-outlier-detection", false,
+		"Enable the outlier detection of VirtualServer and VirtualServerRoute upstreams for NGINX. NGINX Plus implements the outlier detection with active health checks and doesn't require the flag")
+
 	enableCertManager = flag.Bool("enable-cert-manager", false,
 		"Enable cert-manager controller for VirtualServer resources. Requires -enable-custom-resources")
 
 	enableExternalDNS = flag.Bool("enable-external-dns", false,
 		"Enable external-dns controller for VirtualServer resources. Requires -enable-custom-resources")
 
+	enableGatewayAPI = flag.Bool("enable-gateway-api", false,
+		`Enable support for the Gateway API resources (Gateway, HTTPRoute, GRPCRoute, TLSRoute and TCPRoute). The Ingress Controller handles the Gateways of the GatewayClass with the name of the -ingress-class and the controllerName "nginx.org/ingress-controller". Requires -enable-custom-resources`)
+
 	disableIPV6 = flag.Bool("disable-ipv6", false,
 		`Disable IPV6 listeners explicitly for nodes that do not support the IPV6 stack`)
 
@@ -268,6 +292,21 @@ func initValidate(ctx context.Context) {
 		*enableLatencyMetrics = false
 	}
 
+	if *enableHostMetrics && !*enablePrometheusMetrics {
+		nl.Warn(l, "enable-host-metrics flag requires enable-prometheus-metrics, host metrics will not be collected")
+		*enableHostMetrics = false
+	}
+
+	if *enableHostMetrics && *nginxPlus {
+		nl.Warn(l, "enable-host-metrics flag support is for NGINX, NGINX Plus exposes the metrics of the server zones of the resources")
+		*enableHostMetrics = false
+	}
+
+	if *enableOutlierDetection && *nginxPlus {
+		nl.Warn(l, "enable-outlier-detection flag support is for NGINX, NGINX Plus implements the outlier detection with active health checks")
+		*enableOutlierDetection = false
+	}
+
 	if *enableServiceInsight && !*nginxPlus {
 		nl.Warn(l, "enable-service-insight flag support is for NGINX Plus, service insight endpoint will not be exposed")
 		*enableServiceInsight = false
@@ -380,12 +419,26 @@ func mustValidateFlags(ctx context.Context) {
 		nl.Fatalf(l, "Invalid value for service-insight-listen-port: %v", metricsPortValidationError)
 	}
 
+	admissionWebhookPortValidationError := internalValidation.ValidateUnprivilegedPort(*admissionWebhookListenPort)
+	if admissionWebhookPortValidationError != nil {
+		nl.Fatalf(l, "Invalid value for admission-webhook-listen-port: %v", admissionWebhookPortValidationError)
+	}
+
+	if *enableAdmissionWebhook && *admissionWebhookTLSSecretName == "" {
+		nl.Fatal(l, "enable-admission-webhook flag requires -admission-webhook-tls-secret")
+	}
+
 	var err error
 	allowedCIDRs, err = parseNginxStatusAllowCIDRs(*nginxStatusAllowCIDRs)
 	if err != nil {
 		nl.Fatalf(l, "Invalid value for nginx-status-allow-cidrs: %v", err)
 	}
 
+	certificateExpiryThresholds, err = parseCertificateExpiryThresholds(*certificateExpiryThresholdsFlag)
+	if err != nil {
+		nl.Fatalf(l, "Invalid value for certificate-expiry-thresholds: %v", err)
+	}
+
 	if *appProtectLogLevel != appProtectLogLevelDefault && *appProtect && *nginxPlus {
 		appProtectLogLevelValidationError := validateLogLevel(*appProtectLogLevel)
 		if appProtectLogLevelValidationError != nil {
@@ -437,6 +490,10 @@ func mustValidateFlags(ctx context.Context) {
 		nl.Fatal(l, "enable-external-dns flag requires -enable-custom-resources")
 	}
 
+	if *enableGatewayAPI && !*enableCustomResources {
+		nl.Fatal(l, "enable-gateway-api flag requires -enable-custom-resources")
+	}
+
 	if *ingressLink != "" && *externalService != "" {
 		nl.Fatal(l, "ingresslink and external-service cannot both be set")
 	}
@@ -514,6 +571,26 @@ func parseNginxStatusAllowCIDRs(input string) (cidrs []string, err error) {
 	return cidrs, nil
 }
 
+// parseCertificateExpiryThresholds converts a comma separated string of durations into an array of positive durations.
+// An empty string results in no thresholds.
+func parseCertificateExpiryThresholds(input string) ([]time.Duration, error) {
+	var thresholds []time.Duration
+	if strings.TrimSpace(input) == "" {
+		return thresholds, nil
+	}
+	for _, d := range strings.Split(input, ",") {
+		threshold, err := time.ParseDuration(strings.TrimSpace(d))
+		if err != nil {
+			return nil, err
+		}
+		if threshold <= 0 {
+			return nil, fmt.Errorf("threshold %v must be positive", threshold)
+		}
+		thresholds = append(thresholds, threshold)
+	}
+	return thresholds, nil
+}
+
 // validateCIDRorIP makes sure a given string is either a valid CIDR block or IP address.
 // It an error if it is not valid.
 func validateCIDRorIP(cidr string) error {
@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestParseNginxStatusAllowCIDRs(t *testing.T) {
@@ -63,6 +64,44 @@ func TestParseNginxStatusAllowCIDRs(t *testing.T) {
 	}
 }
 
+func TestParseCertificateExpiryThresholds(t *testing.T) {
+	badThresholds := []string{"30d", "720h,,24h", "-24h", "0s"}
+	for _, input := range badThresholds {
+		_, err := parseCertificateExpiryThresholds(input)
+		if err == nil {
+			t.Errorf("parseCertificateExpiryThresholds(%q) returned no error when it should have returned an error", input)
+		}
+	}
+
+	goodThresholds := []struct {
+		input    string
+		expected []time.Duration
+	}{
+		{
+			"",
+			nil,
+		},
+		{
+			"24h",
+			[]time.Duration{24 * time.Hour},
+		},
+		{
+			"720h, 168h,1h30m",
+			[]time.Duration{720 * time.Hour, 168 * time.Hour, 90 * time.Minute},
+		},
+	}
+	for _, goodThreshold := range goodThresholds {
+		result, err := parseCertificateExpiryThresholds(goodThreshold.input)
+		if err != nil {
+			t.Errorf("parseCertificateExpiryThresholds(%q) returned an error when it should have returned no error: %q", goodThreshold.input, err)
+		}
+
+		if !reflect.DeepEqual(result, goodThreshold.expected) {
+			t.Errorf("parseCertificateExpiryThresholds(%q) returned %v expected %v", goodThreshold.input, result, goodThreshold.expected)
+		}
+	}
+}
+
 func TestValidateCIDRorIP(t *testing.T) {
 	badCIDRs := []string{"localhost", "thing", "~", "!!!", "", " ", "-1"}
 	for _, badCIDR := range badCIDRs {
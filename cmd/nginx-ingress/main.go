@@ -28,6 +28,7 @@ import (
 	"github.com/nginx/kubernetes-ingress/internal/metrics"
 	"github.com/nginx/kubernetes-ingress/internal/metrics/collectors"
 	"github.com/nginx/kubernetes-ingress/internal/nginx"
+	"github.com/nginx/kubernetes-ingress/internal/webhook"
 	cr_validation "github.com/nginx/kubernetes-ingress/pkg/apis/configuration/validation"
 	k8s_nginx "github.com/nginx/kubernetes-ingress/pkg/client/clientset/versioned"
 	conf_scheme "github.com/nginx/kubernetes-ingress/pkg/client/clientset/versioned/scheme"
@@ -46,6 +47,8 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 	"k8s.io/client-go/tools/record"
+	gateway_client "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
+	gateway_scheme "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/scheme"
 
 	nl "github.com/nginx/kubernetes-ingress/internal/logger"
 	nic_glog "github.com/nginx/kubernetes-ingress/internal/logger/glog"
@@ -82,6 +85,11 @@ const (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == renderCommand {
+		runRender(os.Args[2:])
+		return
+	}
+
 	commitHash, commitTime, dirtyBuild := getBuildInfo()
 	fmt.Printf("NGINX Ingress Controller Version=%v Commit=%v Date=%v DirtyState=%v Arch=%v/%v Go=%v\n", version, commitHash, commitTime, dirtyBuild, runtime.GOOS, runtime.GOARCH, runtime.Version())
 	parseFlags()
@@ -121,6 +129,8 @@ func main() {
 
 	dynClient, confClient := createCustomClients(ctx, config)
 
+	gatewayClient := createGatewayClient(ctx, config)
+
 	constLabels := map[string]string{"class": *ingressClass}
 
 	managerCollector, controllerCollector, registry := createManagerAndControllerCollectors(ctx, constLabels)
@@ -203,36 +213,7 @@ func main() {
 	cfgParams := configs.NewDefaultConfigParams(ctx, *nginxPlus)
 	cfgParams = processConfigMaps(kubeClient, cfgParams, nginxManager, templateExecutor, eventRecorder)
 
-	staticCfgParams := &configs.StaticConfigParams{
-		DisableIPV6:                    *disableIPV6,
-		DefaultHTTPListenerPort:        *defaultHTTPListenerPort,
-		DefaultHTTPSListenerPort:       *defaultHTTPSListenerPort,
-		HealthStatus:                   *healthStatus,
-		HealthStatusURI:                *healthStatusURI,
-		NginxStatus:                    *nginxStatus,
-		NginxStatusAllowCIDRs:          allowedCIDRs,
-		NginxStatusPort:                *nginxStatusPort,
-		StubStatusOverUnixSocketForOSS: *enablePrometheusMetrics,
-		TLSPassthrough:                 *enableTLSPassthrough,
-		TLSPassthroughPort:             *tlsPassthroughPort,
-		EnableSnippets:                 *enableSnippets,
-		NginxServiceMesh:               *spireAgentAddress != "",
-		MainAppProtectLoadModule:       *appProtect,
-		MainAppProtectV5LoadModule:     appProtectV5,
-		MainAppProtectDosLoadModule:    *appProtectDos,
-		MainAppProtectV5EnforcerAddr:   *appProtectEnforcerAddress,
-		EnableLatencyMetrics:           *enableLatencyMetrics,
-		EnableOIDC:                     *enableOIDC,
-		SSLRejectHandshake:             sslRejectHandshake,
-		EnableCertManager:              *enableCertManager,
-		DynamicSSLReload:               *enableDynamicSSLReload,
-		DynamicWeightChangesReload:     *enableDynamicWeightChangesReload,
-		IsDirectiveAutoadjustEnabled:   *enableDirectiveAutoadjust,
-		StaticSSLPath:                  staticSSLPath,
-		NginxVersion:                   nginxVersion,
-		AppProtectBundlePath:           appProtectBundlePath,
-		DefaultCABundle:                caBundlePath,
-	}
+	staticCfgParams := createStaticConfigParams(nginxVersion, sslRejectHandshake, staticSSLPath, caBundlePath, appProtectV5, appProtectBundlePath)
 
 	if *nginxPlus {
 		if cfgParams.ZoneSync.Enable && cfgParams.ZoneSync.Port != 0 {
@@ -257,7 +238,12 @@ func main() {
 		licenseReporter.Config.PlusClient = plusClient
 	}
 
-	plusCollector, syslogListener, latencyCollector := createPlusAndLatencyCollectors(ctx, registry, constLabels, kubeClient, plusClient, staticCfgParams.NginxServiceMesh)
+	var outlierDetector *metrics.OutlierDetector
+	if *enableOutlierDetection {
+		outlierDetector = metrics.NewOutlierDetector(ctx)
+	}
+
+	plusCollector, syslogListener, latencyCollector, hostCollector := createPlusAndLatencyCollectors(ctx, registry, constLabels, kubeClient, plusClient, staticCfgParams.NginxServiceMesh, outlierDetector)
 	cnf := configs.NewConfigurator(configs.ConfiguratorParams{
 		NginxManager:                        nginxManager,
 		StaticCfgParams:                     staticCfgParams,
@@ -266,32 +252,34 @@ func main() {
 		TemplateExecutor:                    templateExecutor,
 		TemplateExecutorV2:                  templateExecutorV2,
 		LatencyCollector:                    latencyCollector,
+		HostCollector:                       hostCollector,
+		OutlierDetector:                     outlierDetector,
 		LabelUpdater:                        plusCollector,
 		IsPlus:                              *nginxPlus,
 		IsWildcardEnabled:                   isWildcardEnabled,
 		IsPrometheusEnabled:                 *enablePrometheusMetrics,
 		IsLatencyMetricsEnabled:             *enableLatencyMetrics,
+		IsHostMetricsEnabled:                *enableHostMetrics,
 		IsDynamicSSLReloadEnabled:           *enableDynamicSSLReload,
 		IsDynamicWeightChangesReloadEnabled: *enableDynamicWeightChangesReload,
 		NginxVersion:                        nginxVersion,
 	})
 
 	transportServerValidator := cr_validation.NewTransportServerValidator(*enableTLSPassthrough, *enableSnippets, *nginxPlus)
-	virtualServerValidator := cr_validation.NewVirtualServerValidator(
-		cr_validation.IsPlus(*nginxPlus),
-		cr_validation.IsDosEnabled(*appProtectDos),
-		cr_validation.IsCertManagerEnabled(*enableCertManager),
-		cr_validation.IsExternalDNSEnabled(*enableExternalDNS),
-		cr_validation.IsDirectiveAutoadjustEnabled(*enableDirectiveAutoadjust),
-	)
+	virtualServerValidator := createVirtualServerValidator()
 
 	if *enableServiceInsight {
 		createHealthProbeEndpoint(kubeClient, plusClient, cnf)
 	}
 
+	if *enableAdmissionWebhook {
+		createAdmissionWebhookEndpoint(kubeClient, virtualServerValidator, transportServerValidator, globalConfigurationValidator, l)
+	}
+
 	lbcInput := k8s.NewLoadBalancerControllerInput{
 		KubeClient:                   kubeClient,
 		ConfClient:                   confClient,
+		GatewayClient:                gatewayClient,
 		DynClient:                    dynClient,
 		RestConfig:                   config,
 		Recorder:                     eventRecorder,
@@ -318,6 +306,7 @@ func main() {
 		MGMTConfigMap:                *mgmtConfigMap,
 		GlobalConfiguration:          *globalConfiguration,
 		AreCustomResourcesEnabled:    *enableCustomResources,
+		EnableGatewayAPI:             *enableGatewayAPI,
 		EnableOIDC:                   *enableOIDC,
 		MetricsCollector:             controllerCollector,
 		GlobalConfigurationValidator: globalConfigurationValidator,
@@ -327,6 +316,8 @@ func main() {
 		InternalRoutesEnabled:        *enableInternalRoutes,
 		IsPrometheusEnabled:          *enablePrometheusMetrics,
 		IsLatencyMetricsEnabled:      *enableLatencyMetrics,
+		OutlierDetector:              outlierDetector,
+		PlusClient:                   plusClient,
 		IsTLSPassthroughEnabled:      *enableTLSPassthrough,
 		TLSPassthroughPort:           *tlsPassthroughPort,
 		SnippetsEnabled:              *enableSnippets,
@@ -343,6 +334,7 @@ func main() {
 		DynamicWeightChangesReload:   *enableDynamicWeightChangesReload,
 		InstallationFlags:            parsedFlags,
 		ShuttingDown:                 false,
+		CertificateExpiryThresholds:  certificateExpiryThresholds,
 	}
 
 	lbc := k8s.NewLoadBalancerController(lbcInput)
@@ -527,6 +519,26 @@ func createCustomClients(ctx context.Context, config *rest.Config) (dynamic.Inte
 	return dynClient, confClient
 }
 
+func createGatewayClient(ctx context.Context, config *rest.Config) gateway_client.Interface {
+	if !*enableGatewayAPI {
+		return nil
+	}
+
+	l := nl.LoggerFromContext(ctx)
+	gatewayClient, err := gateway_client.NewForConfig(config)
+	if err != nil {
+		nl.Fatalf(l, "Failed to create a gateway client: %v", err)
+	}
+
+	// required for emitting Events for Gateway
+	err = gateway_scheme.AddToScheme(scheme.Scheme)
+	if err != nil {
+		nl.Fatalf(l, "Failed to add gateway types to the scheme: %v", err)
+	}
+
+	return gatewayClient
+}
+
 func createPlusClient(ctx context.Context, nginxPlus bool, useFakeNginxManager bool, nginxManager nginx.Manager) *client.NginxClient {
 	l := nl.LoggerFromContext(ctx)
 	var plusClient *client.NginxClient
@@ -740,6 +752,16 @@ func processLicenseSecret(kubeClient *kubernetes.Clientset, nginxManager nginx.M
 	return nil
 }
 
+func createVirtualServerValidator() *cr_validation.VirtualServerValidator {
+	return cr_validation.NewVirtualServerValidator(
+		cr_validation.IsPlus(*nginxPlus),
+		cr_validation.IsDosEnabled(*appProtectDos),
+		cr_validation.IsCertManagerEnabled(*enableCertManager),
+		cr_validation.IsExternalDNSEnabled(*enableExternalDNS),
+		cr_validation.IsDirectiveAutoadjustEnabled(*enableDirectiveAutoadjust),
+	)
+}
+
 func createGlobalConfigurationValidator() *cr_validation.GlobalConfigurationValidator {
 	forbiddenListenerPorts := map[int]bool{
 		80:  true,
@@ -761,9 +783,50 @@ func createGlobalConfigurationValidator() *cr_validation.GlobalConfigurationVali
 		forbiddenListenerPorts[*tlsPassthroughPort] = true
 	}
 
+	if *enableAdmissionWebhook {
+		forbiddenListenerPorts[*admissionWebhookListenPort] = true
+	}
+
 	return cr_validation.NewGlobalConfigurationValidator(forbiddenListenerPorts)
 }
 
+// createStaticConfigParams creates the StaticConfigParams from the flags.
+func createStaticConfigParams(nginxVersion nginx.Version, sslRejectHandshake bool, staticSSLPath string, caBundlePath string, appProtectV5 bool, appProtectBundlePath string) *configs.StaticConfigParams {
+	return &configs.StaticConfigParams{
+		DisableIPV6:                    *disableIPV6,
+		DefaultHTTPListenerPort:        *defaultHTTPListenerPort,
+		DefaultHTTPSListenerPort:       *defaultHTTPSListenerPort,
+		HealthStatus:                   *healthStatus,
+		HealthStatusURI:                *healthStatusURI,
+		NginxStatus:                    *nginxStatus,
+		NginxStatusAllowCIDRs:          allowedCIDRs,
+		NginxStatusPort:                *nginxStatusPort,
+		StubStatusOverUnixSocketForOSS: *enablePrometheusMetrics,
+		TLSPassthrough:                 *enableTLSPassthrough,
+		TLSPassthroughPort:             *tlsPassthroughPort,
+		EnableSnippets:                 *enableSnippets,
+		NginxServiceMesh:               *spireAgentAddress != "",
+		MainAppProtectLoadModule:       *appProtect,
+		MainAppProtectV5LoadModule:     appProtectV5,
+		MainAppProtectDosLoadModule:    *appProtectDos,
+		MainAppProtectV5EnforcerAddr:   *appProtectEnforcerAddress,
+		EnableLatencyMetrics:           *enableLatencyMetrics,
+		EnableHostMetrics:              *enableHostMetrics,
+		EnablePolicyDryRunMetrics:      *enablePrometheusMetrics,
+		EnableOutlierDetection:         *enableOutlierDetection,
+		EnableOIDC:                     *enableOIDC,
+		SSLRejectHandshake:             sslRejectHandshake,
+		EnableCertManager:              *enableCertManager,
+		DynamicSSLReload:               *enableDynamicSSLReload,
+		DynamicWeightChangesReload:     *enableDynamicWeightChangesReload,
+		IsDirectiveAutoadjustEnabled:   *enableDirectiveAutoadjust,
+		StaticSSLPath:                  staticSSLPath,
+		NginxVersion:                   nginxVersion,
+		AppProtectBundlePath:           appProtectBundlePath,
+		DefaultCABundle:                caBundlePath,
+	}
+}
+
 // mustWriteInitialNginxConfig calls internally os.Exit
 // if it can't generate valid initial NGINX configs.
 func mustWriteInitialNginxConfig(staticCfgParams *configs.StaticConfigParams, cfgParams *configs.ConfigParams, mgmtCfgParams *configs.MGMTConfigParams, templateExecutor *version1.TemplateExecutor, nginxManager nginx.Manager) {
@@ -951,14 +1014,18 @@ func createPlusAndLatencyCollectors(
 	kubeClient *kubernetes.Clientset,
 	plusClient *client.NginxClient,
 	isMesh bool,
-) (*nginxCollector.NginxPlusCollector, metrics.SyslogListener, collectors.LatencyCollector) {
+	outlierDetector *metrics.OutlierDetector,
+) (*nginxCollector.NginxPlusCollector, metrics.SyslogListener, collectors.LatencyCollector, collectors.HostCollector) {
 	l := nl.LoggerFromContext(ctx)
 	var prometheusSecret *api_v1.Secret
 	var err error
 	var lc collectors.LatencyCollector
 	lc = collectors.NewLatencyFakeCollector()
+	var hc collectors.HostCollector
+	hc = collectors.NewHostFakeCollector()
 	var syslogListener metrics.SyslogListener
 	syslogListener = metrics.NewSyslogFakeServer()
+	var syslogHandlers []metrics.SyslogMessageHandler
 
 	if *prometheusTLSSecretName != "" {
 		prometheusSecret, err = getAndValidateSecret(kubeClient, *prometheusTLSSecretName, api_v1.SecretTypeTLS)
@@ -994,12 +1061,32 @@ func createPlusAndLatencyCollectors(
 			if err := lc.Register(registry); err != nil {
 				nl.Errorf(l, "Error registering Latency Prometheus metrics: %v", err)
 			}
-			syslogListener = metrics.NewLatencyMetricsListener(ctx, filepath.Join(socketPath, "nginx-syslog.sock"), lc)
-			go syslogListener.Run()
+			syslogHandlers = append(syslogHandlers, lc.RecordLatency)
+		}
+		if *enableHostMetrics {
+			hc = collectors.NewHostMetricsCollector(ctx, constLabels)
+			if err := hc.Register(registry); err != nil {
+				nl.Errorf(l, "Error registering Host Prometheus metrics: %v", err)
+			}
+			syslogHandlers = append(syslogHandlers, hc.RecordRequest)
 		}
+		pdrc := collectors.NewPolicyDryRunMetricsCollector(ctx, constLabels)
+		if err := pdrc.Register(registry); err != nil {
+			nl.Errorf(l, "Error registering Policy Dry Run Prometheus metrics: %v", err)
+		}
+		syslogHandlers = append(syslogHandlers, pdrc.RecordRejections)
+	}
+
+	if outlierDetector != nil {
+		syslogHandlers = append(syslogHandlers, outlierDetector.HandleMessage)
+	}
+
+	if len(syslogHandlers) > 0 {
+		syslogListener = metrics.NewNginxSyslogListener(ctx, filepath.Join(socketPath, "nginx-syslog.sock"), syslogHandlers...)
+		go syslogListener.Run()
 	}
 
-	return plusCollector, syslogListener, lc
+	return plusCollector, syslogListener, lc, hc
 }
 
 func createHealthProbeEndpoint(kubeClient *kubernetes.Clientset, plusClient *client.NginxClient, cnf *configs.Configurator) {
@@ -1019,6 +1106,37 @@ func createHealthProbeEndpoint(kubeClient *kubernetes.Clientset, plusClient *cli
 	go healthcheck.RunHealthCheck(*serviceInsightListenPort, plusClient, cnf, serviceInsightSecret)
 }
 
+func createAdmissionWebhookEndpoint(
+	kubeClient *kubernetes.Clientset,
+	virtualServerValidator *cr_validation.VirtualServerValidator,
+	transportServerValidator *cr_validation.TransportServerValidator,
+	globalConfigurationValidator *cr_validation.GlobalConfigurationValidator,
+	l *slog.Logger,
+) {
+	admissionWebhookSecret, err := getAndValidateSecret(kubeClient, *admissionWebhookTLSSecretName, api_v1.SecretTypeTLS)
+	if err != nil {
+		nl.Fatalf(l, "Error trying to get the admission webhook TLS secret %v: %v", *admissionWebhookTLSSecretName, err)
+	}
+
+	validator := k8s.NewAdmissionValidator(k8s.AdmissionValidatorInput{
+		IngressClass:                 *ingressClass,
+		GlobalConfiguration:          *globalConfiguration,
+		IsNginxPlus:                  *nginxPlus,
+		EnableOIDC:                   *enableOIDC,
+		AppProtectEnabled:            *appProtect,
+		AppProtectDosEnabled:         *appProtectDos,
+		InternalRoutesEnabled:        *enableInternalRoutes,
+		SnippetsEnabled:              *enableSnippets,
+		IsDirectiveAutoadjustEnabled: *enableDirectiveAutoadjust,
+		AllowEmptyIngressHost:        *allowEmptyIngressHost,
+		VirtualServerValidator:       virtualServerValidator,
+		TransportServerValidator:     transportServerValidator,
+		GlobalConfigurationValidator: globalConfigurationValidator,
+		Logger:                       l,
+	})
+	go webhook.RunServer(*admissionWebhookListenPort, validator, admissionWebhookSecret, l)
+}
+
 // mustProcessGlobalConfiguration calls internally os.Exit
 // if unable to parse provided global configuration.
 func mustProcessGlobalConfiguration(ctx context.Context) {
@@ -1046,26 +1164,33 @@ func processConfigMaps(kubeClient *kubernetes.Clientset, cfgParams *configs.Conf
 		if err != nil {
 			nl.Fatalf(l, "Error when getting %v: %v", *nginxConfigMaps, err)
 		}
-		cfgParams, _ = configs.ParseConfigMap(cfgParams.Context, cfm, *nginxPlus, *appProtect, *appProtectDos, *enableTLSPassthrough, *enableDirectiveAutoadjust, eventLog)
-		if cfgParams.MainServerSSLDHParamFileContent != nil {
-			fileName, err := nginxManager.CreateDHParam(*cfgParams.MainServerSSLDHParamFileContent)
-			if err != nil {
-				nl.Fatalf(l, "Configmap %s/%s: Could not update dhparams: %v", ns, name, err)
-			} else {
-				cfgParams.MainServerSSLDHParam = fileName
-			}
+		cfgParams = processConfigMap(cfm, cfgParams, nginxManager, templateExecutor, eventLog)
+	}
+	return cfgParams
+}
+
+// processConfigMap parses the ConfigMap and applies the dhparam file and the templates it defines.
+func processConfigMap(cfm *api_v1.ConfigMap, cfgParams *configs.ConfigParams, nginxManager nginx.Manager, templateExecutor *version1.TemplateExecutor, eventLog record.EventRecorder) *configs.ConfigParams {
+	l := nl.LoggerFromContext(cfgParams.Context)
+	cfgParams, _ = configs.ParseConfigMap(cfgParams.Context, cfm, *nginxPlus, *appProtect, *appProtectDos, *enableTLSPassthrough, *enableDirectiveAutoadjust, eventLog)
+	if cfgParams.MainServerSSLDHParamFileContent != nil {
+		fileName, err := nginxManager.CreateDHParam(*cfgParams.MainServerSSLDHParamFileContent)
+		if err != nil {
+			nl.Fatalf(l, "Configmap %s/%s: Could not update dhparams: %v", cfm.Namespace, cfm.Name, err)
+		} else {
+			cfgParams.MainServerSSLDHParam = fileName
 		}
-		if cfgParams.MainTemplate != nil {
-			err = templateExecutor.UpdateMainTemplate(cfgParams.MainTemplate)
-			if err != nil {
-				nl.Fatalf(l, "Error updating NGINX main template: %v", err)
-			}
+	}
+	if cfgParams.MainTemplate != nil {
+		err := templateExecutor.UpdateMainTemplate(cfgParams.MainTemplate)
+		if err != nil {
+			nl.Fatalf(l, "Error updating NGINX main template: %v", err)
 		}
-		if cfgParams.IngressTemplate != nil {
-			err = templateExecutor.UpdateIngressTemplate(cfgParams.IngressTemplate)
-			if err != nil {
-				nl.Fatalf(l, "Error updating ingress template: %v", err)
-			}
+	}
+	if cfgParams.IngressTemplate != nil {
+		err := templateExecutor.UpdateIngressTemplate(cfgParams.IngressTemplate)
+		if err != nil {
+			nl.Fatalf(l, "Error updating ingress template: %v", err)
 		}
 	}
 	return cfgParams
@@ -0,0 +1,307 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nginx/kubernetes-ingress/internal/configs"
+	"github.com/nginx/kubernetes-ingress/internal/k8s"
+	nl "github.com/nginx/kubernetes-ingress/internal/logger"
+	"github.com/nginx/kubernetes-ingress/internal/nginx"
+	cr_validation "github.com/nginx/kubernetes-ingress/pkg/apis/configuration/validation"
+	conf_scheme "github.com/nginx/kubernetes-ingress/pkg/client/clientset/versioned/scheme"
+	api_v1 "k8s.io/api/core/v1"
+	pkg_runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+)
+
+const (
+	renderCommand      = "render"
+	renderWarningsFile = "warnings.txt"
+
+	renderDefaultNginxVersion = "nginx version: nginx/1.31.2"
+)
+
+var renderDecoder = func() pkg_runtime.Decoder {
+	s := pkg_runtime.NewScheme()
+	utilruntime.Must(scheme.AddToScheme(s))
+	utilruntime.Must(conf_scheme.AddToScheme(s))
+	return serializer.NewCodecFactory(s).UniversalDeserializer()
+}()
+
+// runRender renders the NGINX configuration for the resources in manifest files without connecting to a cluster.
+// It accepts the flags of the Ingress Controller, so that the configuration is rendered for the same settings.
+func runRender(args []string) {
+	renderFlags := flag.NewFlagSet(renderCommand, flag.ExitOnError)
+	manifests := renderFlags.String("manifests", "",
+		`A comma-separated list of files and directories with the manifests of the resources to render. Directories are read recursively.
+	Only the .yaml, .yml and .json files are read. Resources of kinds the Ingress Controller doesn't watch are ignored.`)
+	configMapFile := renderFlags.String("configmap", "",
+		`A file with the ConfigMap with the NGINX configuration. If not set, the default configuration is used.`)
+	outputDir := renderFlags.String("output-dir", "",
+		`The directory to write the NGINX configuration files and the `+renderWarningsFile+` file with the validation warnings to. Required.`)
+	nginxVersionOutput := renderFlags.String("nginx-version", "",
+		fmt.Sprintf(`The output of "nginx -v" of the NGINX binary to render the configuration for. Required with -nginx-plus. Default is %q.`,
+			renderDefaultNginxVersion))
+	flag.CommandLine.VisitAll(func(f *flag.Flag) {
+		renderFlags.Var(f.Value, f.Name, f.Usage)
+	})
+	renderFlags.Usage = func() {
+		fmt.Fprintf(renderFlags.Output(), "Usage: %s %s -manifests <paths> -output-dir <dir> [flags]\n", filepath.Base(os.Args[0]), renderCommand)
+		renderFlags.PrintDefaults()
+	}
+	_ = renderFlags.Parse(args) // exits on error
+
+	ctx := initLogger(*logFormat, logLevels[*logLevel], os.Stderr)
+	l := nl.LoggerFromContext(ctx)
+
+	if *outputDir == "" {
+		nl.Fatal(l, "output-dir flag is required")
+	}
+	if renderFlags.NArg() > 0 {
+		nl.Warnf(l, "Ignoring unhandled arguments: %+q", renderFlags.Args())
+	}
+
+	var err error
+	allowedCIDRs, err = parseNginxStatusAllowCIDRs(*nginxStatusAllowCIDRs)
+	if err != nil {
+		nl.Fatalf(l, "Invalid value for nginx-status-allow-cidrs: %v", err)
+	}
+
+	var objects []pkg_runtime.Object
+	if *manifests != "" {
+		objects, err = loadManifests(strings.Split(*manifests, ","))
+		if err != nil {
+			nl.Fatalf(l, "Error loading manifests: %v", err)
+		}
+	}
+
+	var cfm *api_v1.ConfigMap
+	if *configMapFile != "" {
+		cfm, err = loadConfigMap(*configMapFile)
+		if err != nil {
+			nl.Fatalf(l, "Error loading ConfigMap: %v", err)
+		}
+	}
+
+	if *nginxVersionOutput == "" {
+		if *nginxPlus {
+			nl.Fatal(l, "nginx-plus flag requires -nginx-version")
+		}
+		*nginxVersionOutput = renderDefaultNginxVersion
+	}
+	nginxVersion := nginx.NewVersion(*nginxVersionOutput)
+	if *nginxPlus != nginxVersion.IsPlus {
+		nl.Fatalf(l, "NGINX version %q doesn't match the -nginx-plus flag", *nginxVersionOutput)
+	}
+
+	nginxManager := nginx.NewRenderManager(*outputDir, l)
+	templateExecutor, templateExecutorV2 := createTemplateExecutors(ctx)
+
+	cfgParams := configs.NewDefaultConfigParams(ctx, *nginxPlus)
+	if cfm != nil {
+		// The events of the ConfigMap are reported by k8s.Render.
+		cfgParams = processConfigMap(cfm, cfgParams, nginxManager, templateExecutor, &record.FakeRecorder{})
+	}
+	var mgmtCfgParams *configs.MGMTConfigParams
+	if *nginxPlus {
+		mgmtCfgParams = configs.NewDefaultMGMTConfigParams(ctx)
+	}
+
+	caBundlePath, _ := nginxManager.GetOSCABundlePath()
+	staticCfgParams := createStaticConfigParams(nginxVersion, *defaultServerSecret == "", nginxManager.GetSecretsDir(), caBundlePath, false, appProtectv4BundleFolder)
+
+	mustWriteInitialNginxConfig(staticCfgParams, cfgParams, mgmtCfgParams, templateExecutor, nginxManager)
+	if *enableTLSPassthrough {
+		var emptyFile []byte
+		nginxManager.CreateTLSPassthroughHostsConfig(emptyFile)
+	}
+
+	cnf := configs.NewConfigurator(configs.ConfiguratorParams{
+		NginxManager:                        nginxManager,
+		StaticCfgParams:                     staticCfgParams,
+		Config:                              cfgParams,
+		MGMTCfgParams:                       mgmtCfgParams,
+		TemplateExecutor:                    templateExecutor,
+		TemplateExecutorV2:                  templateExecutorV2,
+		IsPlus:                              *nginxPlus,
+		IsWildcardEnabled:                   *wildcardTLSSecret != "",
+		IsPrometheusEnabled:                 *enablePrometheusMetrics,
+		IsLatencyMetricsEnabled:             *enableLatencyMetrics,
+		IsDynamicSSLReloadEnabled:           *enableDynamicSSLReload,
+		IsDynamicWeightChangesReloadEnabled: *enableDynamicWeightChangesReload,
+		NginxVersion:                        nginxVersion,
+	})
+
+	warnings, err := k8s.Render(k8s.RenderInput{
+		NginxConfigurator:            cnf,
+		Objects:                      objects,
+		ConfigMap:                    cfm,
+		IngressClass:                 *ingressClass,
+		IsNginxPlus:                  *nginxPlus,
+		EnableOIDC:                   *enableOIDC,
+		InternalRoutesEnabled:        *enableInternalRoutes,
+		IsTLSPassthroughEnabled:      *enableTLSPassthrough,
+		SnippetsEnabled:              *enableSnippets,
+		CertManagerEnabled:           *enableCertManager,
+		IsIPV6Disabled:               *disableIPV6,
+		IsDirectiveAutoadjustEnabled: *enableDirectiveAutoadjust,
+		AllowEmptyIngressHost:        *allowEmptyIngressHost,
+		VirtualServerValidator:       createVirtualServerValidator(),
+		GlobalConfigurationValidator: createGlobalConfigurationValidator(),
+		TransportServerValidator:     cr_validation.NewTransportServerValidator(*enableTLSPassthrough, *enableSnippets, *nginxPlus),
+		Logger:                       l,
+	})
+	if err != nil {
+		nl.Fatalf(l, "Error rendering the configuration: %v", err)
+	}
+	if err := nginxManager.Err(); err != nil {
+		nl.Fatalf(l, "Error writing the configuration: %v", err)
+	}
+
+	var content bytes.Buffer
+	for _, w := range warnings {
+		nl.Warn(l, w.String())
+		fmt.Fprintln(&content, w.String())
+	}
+	if err := os.WriteFile(filepath.Join(*outputDir, renderWarningsFile), content.Bytes(), 0o644); err != nil { //nolint:gosec // the warnings don't include secrets
+		nl.Fatalf(l, "Error writing the warnings: %v", err)
+	}
+
+	nl.Infof(l, "Rendered the configuration to %s with %d warnings", *outputDir, len(warnings))
+}
+
+// loadManifests decodes the Kubernetes objects in the files and directories of paths.
+func loadManifests(paths []string) ([]pkg_runtime.Object, error) {
+	var objects []pkg_runtime.Object
+
+	for _, p := range paths {
+		err := filepath.WalkDir(strings.TrimSpace(p), func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+			// the files passed explicitly are always read
+			if path != strings.TrimSpace(p) {
+				switch filepath.Ext(path) {
+				case ".yaml", ".yml", ".json":
+				default:
+					return nil
+				}
+			}
+
+			objs, err := decodeManifestFile(path)
+			if err != nil {
+				return err
+			}
+			objects = append(objects, objs...)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return objects, nil
+}
+
+func loadConfigMap(path string) (*api_v1.ConfigMap, error) {
+	objects, err := decodeManifestFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(objects) != 1 {
+		return nil, fmt.Errorf("%s must contain exactly one ConfigMap, found %d objects", path, len(objects))
+	}
+	cfm, ok := objects[0].(*api_v1.ConfigMap)
+	if !ok {
+		return nil, fmt.Errorf("%s must contain a ConfigMap, found %T", path, objects[0])
+	}
+	return cfm, nil
+}
+
+func decodeManifestFile(path string) ([]pkg_runtime.Object, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	objects, err := decodeManifests(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return objects, nil
+}
+
+// decodeManifests decodes the objects of a multi-document YAML or JSON stream. The items of Lists are decoded as
+// separate objects. The documents of the kinds that aren't registered in the scheme, like other custom resources, are skipped.
+func decodeManifests(r io.Reader) ([]pkg_runtime.Object, error) {
+	var objects []pkg_runtime.Object
+
+	reader := yaml.NewYAMLReader(bufio.NewReader(r))
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		jsonDoc, err := yaml.ToJSON(doc)
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(jsonDoc)) == 0 || string(bytes.TrimSpace(jsonDoc)) == "null" {
+			continue
+		}
+
+		objs, err := decodeManifest(jsonDoc)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, objs...)
+	}
+
+	return objects, nil
+}
+
+func decodeManifest(data []byte) ([]pkg_runtime.Object, error) {
+	obj, _, err := renderDecoder.Decode(data, nil, nil)
+	if pkg_runtime.IsNotRegisteredError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	list, ok := obj.(*api_v1.List)
+	if !ok {
+		return []pkg_runtime.Object{obj}, nil
+	}
+
+	var objects []pkg_runtime.Object
+	for _, item := range list.Items {
+		objs, err := decodeManifest(item.Raw)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, objs...)
+	}
+	return objects, nil
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	conf_v1 "github.com/nginx/kubernetes-ingress/pkg/apis/configuration/v1"
+	api_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDecodeManifests(t *testing.T) {
+	t.Parallel()
+
+	manifests := `# the first document only has comments
+---
+apiVersion: k8s.nginx.org/v1
+kind: VirtualServer
+metadata:
+  name: cafe
+  namespace: default
+spec:
+  host: cafe.example.com
+---
+apiVersion: cert-manager.io/v1
+kind: Certificate
+metadata:
+  name: cafe
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: Service
+  metadata:
+    name: tea-svc
+    namespace: default
+- apiVersion: k8s.nginx.org/v1
+  kind: Policy
+  metadata:
+    name: rate-limit
+    namespace: default
+---
+`
+
+	objects, err := decodeManifests(strings.NewReader(manifests))
+	if err != nil {
+		t.Fatalf("decodeManifests() returned unexpected error: %v", err)
+	}
+
+	var names []string
+	for _, obj := range objects {
+		meta := obj.(meta_v1.Object)
+		names = append(names, meta.GetNamespace()+"/"+meta.GetName())
+	}
+	if len(objects) != 3 {
+		t.Fatalf("decodeManifests() returned %d objects %v, expected 3", len(objects), names)
+	}
+	if _, ok := objects[0].(*conf_v1.VirtualServer); !ok {
+		t.Errorf("decodeManifests() returned %T for the first object, expected *VirtualServer", objects[0])
+	}
+	if _, ok := objects[1].(*api_v1.Service); !ok {
+		t.Errorf("decodeManifests() returned %T for the second object, expected *Service", objects[1])
+	}
+	if _, ok := objects[2].(*conf_v1.Policy); !ok {
+		t.Errorf("decodeManifests() returned %T for the third object, expected *Policy", objects[2])
+	}
+}
+
+func TestDecodeManifestsFails(t *testing.T) {
+	t.Parallel()
+
+	manifests := `apiVersion: k8s.nginx.org/v1
+kind: VirtualServer
+metadata:
+  name: cafe
+spec:
+  host: [invalid
+`
+
+	_, err := decodeManifests(strings.NewReader(manifests))
+	if err == nil {
+		t.Error("decodeManifests() returned no error for an invalid manifest")
+	}
+}
@@ -17,6 +17,9 @@ const PoliciesAnnotation = "nginx.org/policies"
 // PoliciesAnnotationPlus is the plus-only annotation where the list of policies to apply to an Ingress is specified.
 const PoliciesAnnotationPlus = "nginx.com/policies"
 
+// DisableDefaultPoliciesAnnotation is the annotation where an Ingress opts out of the default policies of the GlobalConfiguration.
+const DisableDefaultPoliciesAnnotation = "nginx.org/disable-default-policies"
+
 // JWTKeyAnnotation is the annotation where the Secret with a JWK is specified.
 const JWTKeyAnnotation = "nginx.com/jwt-key"
 
@@ -37,6 +37,7 @@ type ConfigParams struct {
 	MainKeepaliveTimeout                   string
 	MainLogFormat                          []string
 	MainLogFormatEscaping                  string
+	MainLogFormatPreset                    string
 	MainMainSnippets                       []string
 	MainOtelLoadModule                     bool
 	MainOtelTraceInHTTP                    bool
@@ -44,10 +45,13 @@ type ConfigParams struct {
 	MainOtelExporterHeaderName             string
 	MainOtelExporterHeaderValue            string
 	MainOtelServiceName                    string
+	MainGeoIP2CountryDB                    string
+	MainGeoIP2ASNDB                        string
 	MainServerNamesHashBucketSize          string
 	MainServerNamesHashMaxSize             string
 	MainStreamLogFormat                    []string
 	MainStreamLogFormatEscaping            string
+	MainStreamLogFormatPreset              string
 	MainStreamSnippets                     []string
 	MainMapHashBucketSize                  string
 	MainMapHashMaxSize                     string
@@ -172,6 +176,9 @@ type StaticConfigParams struct {
 	MainAppProtectV5EnforcerAddr   string
 	InternalRouteServerName        string
 	EnableLatencyMetrics           bool
+	EnableHostMetrics              bool
+	EnablePolicyDryRunMetrics      bool
+	EnableOutlierDetection         bool
 	EnableOIDC                     bool
 	SSLRejectHandshake             bool
 	EnableCertManager              bool
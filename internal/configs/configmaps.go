@@ -335,6 +335,17 @@ func ParseConfigMap(ctx context.Context, cfgm *v1.ConfigMap, nginxPlus bool, has
 		}
 	}
 
+	if logFormatPreset, exists := cfgm.Data["log-format-preset"]; exists {
+		if parsedPreset, err := ParseLogFormatPreset(logFormatPreset); err != nil {
+			errorText := fmt.Sprintf("ConfigMap %s/%s: invalid value for 'log-format-preset': %q: %v, ignoring", cfgm.GetNamespace(), cfgm.GetName(), logFormatPreset, err)
+			nl.Error(l, errorText)
+			eventLog.Event(cfgm, v1.EventTypeWarning, nl.EventReasonInvalidValue, errorText)
+			configOk = false
+		} else {
+			cfgParams.MainLogFormatPreset = parsedPreset
+		}
+	}
+
 	if streamLogFormat, exists := GetMapKeyAsStringSlice(cfgm.Data, "stream-log-format", cfgm, "\n"); exists {
 		cfgParams.MainStreamLogFormat = streamLogFormat
 	}
@@ -346,6 +357,17 @@ func ParseConfigMap(ctx context.Context, cfgm *v1.ConfigMap, nginxPlus bool, has
 		}
 	}
 
+	if streamLogFormatPreset, exists := cfgm.Data["stream-log-format-preset"]; exists {
+		if parsedPreset, err := ParseLogFormatPreset(streamLogFormatPreset); err != nil {
+			errorText := fmt.Sprintf("ConfigMap %s/%s: invalid value for 'stream-log-format-preset': %q: %v, ignoring", cfgm.GetNamespace(), cfgm.GetName(), streamLogFormatPreset, err)
+			nl.Error(l, errorText)
+			eventLog.Event(cfgm, v1.EventTypeWarning, nl.EventReasonInvalidValue, errorText)
+			configOk = false
+		} else {
+			cfgParams.MainStreamLogFormatPreset = parsedPreset
+		}
+	}
+
 	if defaultServerAccessLogOff, exists, err := GetMapKeyAsBool(cfgm.Data, "default-server-access-log-off", cfgm); exists {
 		if err != nil {
 			nl.Error(l, err)
@@ -594,6 +616,28 @@ func ParseConfigMap(ctx context.Context, cfgm *v1.ConfigMap, nginxPlus bool, has
 		}
 	}
 
+	for _, geoIP2DB := range []struct {
+		key  string
+		dest *string
+	}{
+		{"geoip2-country-db", &cfgParams.MainGeoIP2CountryDB},
+		{"geoip2-asn-db", &cfgParams.MainGeoIP2ASNDB},
+	} {
+		path, exists := cfgm.Data[geoIP2DB.key]
+		if !exists {
+			continue
+		}
+		path = strings.TrimSpace(path)
+		if !pathRegexp.MatchString(path) {
+			errorText := fmt.Sprintf("ConfigMap %s/%s key %s must be an absolute path to a MaxMind database file, ignoring: %q", cfgm.Namespace, cfgm.Name, geoIP2DB.key, path)
+			nl.Error(l, errorText)
+			eventLog.Event(cfgm, v1.EventTypeWarning, nl.EventReasonInvalidValue, errorText)
+			configOk = false
+			continue
+		}
+		*geoIP2DB.dest = path
+	}
+
 	if keepaliveTimeout, exists := cfgm.Data["keepalive-timeout"]; exists {
 		cfgParams.MainKeepaliveTimeout = keepaliveTimeout
 	}
@@ -1212,6 +1256,7 @@ func GenerateNginxMainConfig(staticCfgParams *StaticConfigParams, config *Config
 		KeepaliveTimeout:                   config.MainKeepaliveTimeout,
 		LogFormat:                          config.MainLogFormat,
 		LogFormatEscaping:                  config.MainLogFormatEscaping,
+		LogFormatPreset:                    config.MainLogFormatPreset,
 		MainSnippets:                       config.MainMainSnippets,
 		MGMTConfig:                         mgmtConfig,
 		NginxStatus:                        staticCfgParams.NginxStatus,
@@ -1223,6 +1268,8 @@ func GenerateNginxMainConfig(staticCfgParams *StaticConfigParams, config *Config
 		MainOtelExporterHeaderName:         config.MainOtelExporterHeaderName,
 		MainOtelExporterHeaderValue:        config.MainOtelExporterHeaderValue,
 		MainOtelServiceName:                config.MainOtelServiceName,
+		MainGeoIP2CountryDB:                config.MainGeoIP2CountryDB,
+		MainGeoIP2ASNDB:                    config.MainGeoIP2ASNDB,
 		ProxyProtocol:                      config.ProxyProtocol,
 		ResolverAddresses:                  config.ResolverAddresses,
 		ResolverIPV6:                       config.ResolverIPV6,
@@ -1242,6 +1289,7 @@ func GenerateNginxMainConfig(staticCfgParams *StaticConfigParams, config *Config
 		TLSPassthroughPort:                 staticCfgParams.TLSPassthroughPort,
 		StreamLogFormat:                    config.MainStreamLogFormat,
 		StreamLogFormatEscaping:            config.MainStreamLogFormatEscaping,
+		StreamLogFormatPreset:              config.MainStreamLogFormatPreset,
 		StreamSnippets:                     config.MainStreamSnippets,
 		StubStatusOverUnixSocketForOSS:     staticCfgParams.StubStatusOverUnixSocketForOSS,
 		WorkerCPUAffinity:                  config.MainWorkerCPUAffinity,
@@ -1267,6 +1315,9 @@ func GenerateNginxMainConfig(staticCfgParams *StaticConfigParams, config *Config
 		InternalRouteServer:                staticCfgParams.EnableInternalRoutes,
 		InternalRouteServerName:            staticCfgParams.InternalRouteServerName,
 		LatencyMetrics:                     staticCfgParams.EnableLatencyMetrics,
+		HostMetrics:                        staticCfgParams.EnableHostMetrics,
+		PolicyDryRunMetrics:                staticCfgParams.EnablePolicyDryRunMetrics,
+		OutlierDetection:                   staticCfgParams.EnableOutlierDetection,
 		OIDC: version1.OIDCConfig{
 			Enable:          staticCfgParams.EnableOIDC,
 			PKCETimeout:     config.OIDC.PKCETimeout,
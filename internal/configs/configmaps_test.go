@@ -297,6 +297,68 @@ func TestParseConfigMapAccessLogDefault(t *testing.T) {
 	}
 }
 
+func TestParseConfigMapLogFormatPresets(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		logFormatPreset           string
+		streamLogFormatPreset     string
+		wantLogFormatPreset       string
+		wantStreamLogFormatPreset string
+		wantConfigOk              bool
+		msg                       string
+	}{
+		{
+			logFormatPreset:           "json",
+			streamLogFormatPreset:     " logfmt ",
+			wantLogFormatPreset:       "json",
+			wantStreamLogFormatPreset: "logfmt",
+			wantConfigOk:              true,
+			msg:                       "valid presets",
+		},
+		{
+			logFormatPreset:           "ecs",
+			streamLogFormatPreset:     "otel",
+			wantLogFormatPreset:       "ecs",
+			wantStreamLogFormatPreset: "otel",
+			wantConfigOk:              true,
+			msg:                       "ecs and otel presets",
+		},
+		{
+			logFormatPreset:           "xml",
+			streamLogFormatPreset:     "",
+			wantLogFormatPreset:       "",
+			wantStreamLogFormatPreset: "",
+			wantConfigOk:              false,
+			msg:                       "invalid presets",
+		},
+	}
+	nginxPlus := false
+	hasAppProtect := false
+	hasAppProtectDos := false
+	hasTLSPassthrough := false
+	directiveAutoadjustEnabled := false
+	for _, test := range tests {
+		t.Run(test.msg, func(t *testing.T) {
+			cm := &v1.ConfigMap{
+				Data: map[string]string{
+					"log-format-preset":        test.logFormatPreset,
+					"stream-log-format-preset": test.streamLogFormatPreset,
+				},
+			}
+			result, configOk := ParseConfigMap(context.Background(), cm, nginxPlus, hasAppProtect, hasAppProtectDos, hasTLSPassthrough, directiveAutoadjustEnabled, makeEventLogger())
+			if configOk != test.wantConfigOk {
+				t.Errorf("want configOk %t, got %t", test.wantConfigOk, configOk)
+			}
+			if result.MainLogFormatPreset != test.wantLogFormatPreset {
+				t.Errorf("want %q, got %q", test.wantLogFormatPreset, result.MainLogFormatPreset)
+			}
+			if result.MainStreamLogFormatPreset != test.wantStreamLogFormatPreset {
+				t.Errorf("want %q, got %q", test.wantStreamLogFormatPreset, result.MainStreamLogFormatPreset)
+			}
+		})
+	}
+}
+
 func TestParseConfigMapOIDC(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -2987,6 +3049,76 @@ func TestParseConfigMapWithHTTPRedirectCode(t *testing.T) {
 	}
 }
 
+func TestParseConfigMapGeoIP2DBs(t *testing.T) {
+	t.Parallel()
+	hasAppProtect := false
+	hasAppProtectDos := false
+	hasTLSPassthrough := false
+	directiveAutoadjustEnabled := false
+
+	tests := []struct {
+		configMap       map[string]string
+		nginxPlus       bool
+		expectedCountry string
+		expectedASN     string
+		expectError     bool
+		msg             string
+	}{
+		{
+			configMap: map[string]string{
+				"geoip2-country-db": "/etc/nginx/geoip/GeoLite2-Country.mmdb",
+				"geoip2-asn-db":     "/etc/nginx/geoip/GeoLite2-ASN.mmdb",
+			},
+			nginxPlus:       true,
+			expectedCountry: "/etc/nginx/geoip/GeoLite2-Country.mmdb",
+			expectedASN:     "/etc/nginx/geoip/GeoLite2-ASN.mmdb",
+			msg:             "valid databases",
+		},
+		{
+			configMap: map[string]string{
+				"geoip2-country-db": "GeoLite2-Country.mmdb",
+			},
+			nginxPlus:   true,
+			expectError: true,
+			msg:         "relative path",
+		},
+		{
+			configMap: map[string]string{
+				"geoip2-country-db": "/etc/nginx/geoip/GeoLite2-Country.mmdb; load_module x",
+			},
+			nginxPlus:   true,
+			expectError: true,
+			msg:         "path with a directive",
+		},
+		{
+			configMap: map[string]string{
+				"geoip2-country-db": "/etc/nginx/geoip/GeoLite2-Country.mmdb",
+			},
+			nginxPlus:       false,
+			expectedCountry: "/etc/nginx/geoip/GeoLite2-Country.mmdb",
+			msg:             "database with NGINX OSS",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.msg, func(t *testing.T) {
+			configMap := &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "nginx-config",
+					Namespace: "nginx-ingress",
+				},
+				Data: test.configMap,
+			}
+
+			result, configOK := ParseConfigMap(context.Background(), configMap, test.nginxPlus, hasAppProtect, hasAppProtectDos, hasTLSPassthrough, directiveAutoadjustEnabled, makeEventLogger())
+
+			assert.Equal(t, !test.expectError, configOK, test.msg)
+			assert.Equal(t, test.expectedCountry, result.MainGeoIP2CountryDB, test.msg)
+			assert.Equal(t, test.expectedASN, result.MainGeoIP2ASNDB, test.msg)
+		})
+	}
+}
+
 // TestParseAndValidateAddHeaders unit-tests the helper directly, covering all
 // validation branches without going through ParseConfigMap.
 func TestParseAndValidateAddHeaders(t *testing.T) {
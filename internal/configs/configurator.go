@@ -2,10 +2,14 @@ package configs
 
 import (
 	"bytes"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"os"
+	"slices"
 	"strings"
+	"sync/atomic"
 
 	nl "github.com/nginx/kubernetes-ingress/internal/logger"
 
@@ -27,6 +31,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
+	"github.com/nginx/kubernetes-ingress/internal/metrics"
 	latCollector "github.com/nginx/kubernetes-ingress/internal/metrics/collectors"
 )
 
@@ -118,6 +123,8 @@ type metricLabelsIndex struct {
 	ingressUpstreamPeers         map[string][]string
 	virtualServerUpstreamPeers   map[string][]string
 	transportServerUpstreamPeers map[string][]string
+	ingressResources             map[string][]string
+	virtualServerResources       map[string][]string
 }
 
 // Configurator configures NGINX.
@@ -145,9 +152,15 @@ type Configurator struct {
 	isPrometheusEnabled       bool
 	latencyCollector          latCollector.LatencyCollector
 	isLatencyMetricsEnabled   bool
+	hostCollector             latCollector.HostCollector
+	isHostMetricsEnabled      bool
+	outlierDetector           *metrics.OutlierDetector
+	outlierDetectionUpstreams map[string][]string
 	isReloadsEnabled          bool
 	isDynamicSSLReloadEnabled bool
 	ingressControllerReplicas int
+	// reloads counts the successful reloads of NGINX
+	reloads atomic.Uint64
 }
 
 // ConfiguratorParams is a collection of parameters used for the
@@ -161,10 +174,13 @@ type ConfiguratorParams struct {
 	TemplateExecutorV2                  *version2.TemplateExecutor
 	LabelUpdater                        collector.LabelUpdater
 	LatencyCollector                    latCollector.LatencyCollector
+	HostCollector                       latCollector.HostCollector
+	OutlierDetector                     *metrics.OutlierDetector
 	IsPlus                              bool
 	IsPrometheusEnabled                 bool
 	IsWildcardEnabled                   bool
 	IsLatencyMetricsEnabled             bool
+	IsHostMetricsEnabled                bool
 	IsDynamicSSLReloadEnabled           bool
 	IsDynamicWeightChangesReloadEnabled bool
 	NginxVersion                        nginx.Version
@@ -182,6 +198,8 @@ func NewConfigurator(p ConfiguratorParams) *Configurator {
 		ingressUpstreamPeers:         make(map[string][]string),
 		virtualServerUpstreamPeers:   make(map[string][]string),
 		transportServerUpstreamPeers: make(map[string][]string),
+		ingressResources:             make(map[string][]string),
+		virtualServerResources:       make(map[string][]string),
 	}
 
 	cnf := Configurator{
@@ -204,6 +222,10 @@ func NewConfigurator(p ConfiguratorParams) *Configurator {
 		isPrometheusEnabled:       p.IsPrometheusEnabled,
 		latencyCollector:          p.LatencyCollector,
 		isLatencyMetricsEnabled:   p.IsLatencyMetricsEnabled,
+		hostCollector:             p.HostCollector,
+		isHostMetricsEnabled:      p.IsHostMetricsEnabled,
+		outlierDetector:           p.OutlierDetector,
+		outlierDetectionUpstreams: make(map[string][]string),
 		isDynamicSSLReloadEnabled: p.IsDynamicSSLReloadEnabled,
 		isReloadsEnabled:          false,
 	}
@@ -300,6 +322,50 @@ func (cnf *Configurator) deleteIngressMetricsLabels(key string) {
 	delete(cnf.metricLabelsIndex.ingressUpstreamPeers, key)
 }
 
+// updateIngressHostMetricsResources updates the resources of the host metrics of the Ingress, which include the minions
+// of a mergeable Ingress, and deletes the host metrics of the removed resources.
+func (cnf *Configurator) updateIngressHostMetricsResources(ingEx *IngressEx, upstreams []version1.Upstream) {
+	resources := map[string]bool{
+		fmt.Sprintf("ingress/%v/%v", ingEx.Ingress.Namespace, ingEx.Ingress.Name): true,
+	}
+	for _, u := range upstreams {
+		if u.UpstreamLabels.ResourceType != "" {
+			resources[fmt.Sprintf("%v/%v/%v", u.UpstreamLabels.ResourceType, u.UpstreamLabels.ResourceNamespace, u.UpstreamLabels.ResourceName)] = true
+		}
+	}
+	key := fmt.Sprintf("%v/%v", ingEx.Ingress.Namespace, ingEx.Ingress.Name)
+	cnf.updateHostMetricsResources(cnf.metricLabelsIndex.ingressResources, key, resources)
+}
+
+// updateVirtualServerHostMetricsResources updates the resources of the host metrics of the VirtualServer and its
+// VirtualServerRoutes, and deletes the host metrics of the removed resources.
+func (cnf *Configurator) updateVirtualServerHostMetricsResources(virtualServerEx *VirtualServerEx) {
+	vs := virtualServerEx.VirtualServer
+	resources := map[string]bool{
+		fmt.Sprintf("virtualserver/%v/%v", vs.Namespace, vs.Name): true,
+	}
+	for _, vsr := range virtualServerEx.VirtualServerRoutes {
+		resources[fmt.Sprintf("virtualserverroute/%v/%v", vsr.Namespace, vsr.Name)] = true
+	}
+	key := fmt.Sprintf("%v/%v", vs.Namespace, vs.Name)
+	cnf.updateHostMetricsResources(cnf.metricLabelsIndex.virtualServerResources, key, resources)
+}
+
+func (cnf *Configurator) updateHostMetricsResources(index map[string][]string, key string, resources map[string]bool) {
+	var newResources []string
+	for r := range resources {
+		newResources = append(newResources, r)
+	}
+	removedResources := findRemovedKeys(index[key], resources)
+	index[key] = newResources
+	cnf.hostCollector.DeleteMetrics(removedResources)
+}
+
+func (cnf *Configurator) deleteHostMetricsResources(index map[string][]string, key string) {
+	cnf.hostCollector.DeleteMetrics(index[key])
+	delete(index, key)
+}
+
 // AddOrUpdateIngress adds or updates NGINX configuration for the Ingress resource.
 func (cnf *Configurator) AddOrUpdateIngress(ingEx *IngressEx) (Warnings, error) {
 	_, warnings, err := cnf.addOrUpdateIngress(ingEx)
@@ -540,6 +606,9 @@ func (cnf *Configurator) addOrUpdateIngress(ingEx *IngressEx) (bool, Warnings, e
 	if (cnf.isPlus && cnf.isPrometheusEnabled) || cnf.isLatencyMetricsEnabled {
 		cnf.updateIngressMetricsLabels(ingEx, nginxCfg.Upstreams)
 	}
+	if cnf.isHostMetricsEnabled {
+		cnf.updateIngressHostMetricsResources(ingEx, nginxCfg.Upstreams)
+	}
 	if err := cnf.syncDefaultServerConfig(); err != nil {
 		return false, warnings, fmt.Errorf("error syncing default server config for ingress %v: %w", name, err)
 	}
@@ -629,6 +698,9 @@ func (cnf *Configurator) addOrUpdateMergeableIngress(mergeableIngs *MergeableIng
 	if (cnf.isPlus && cnf.isPrometheusEnabled) || cnf.isLatencyMetricsEnabled {
 		cnf.updateIngressMetricsLabels(mergeableIngs.Master, nginxCfg.Upstreams)
 	}
+	if cnf.isHostMetricsEnabled {
+		cnf.updateIngressHostMetricsResources(mergeableIngs.Master, nginxCfg.Upstreams)
+	}
 	if err := cnf.syncDefaultServerConfig(); err != nil {
 		return false, warnings, fmt.Errorf("error syncing default server config for mergeable ingress %v: %w", name, err)
 	}
@@ -754,6 +826,9 @@ func (cnf *Configurator) addOrUpdateVirtualServer(virtualServerEx *VirtualServer
 	vsc := newVirtualServerConfigurator(cnf.CfgParams, cnf.isPlus, cnf.IsResolverConfigured(), cnf.staticCfgParams, cnf.isWildcardEnabled, nil)
 	vsc.IngressControllerReplicas = cnf.ingressControllerReplicas
 	vsCfg, warnings := vsc.GenerateVirtualServerConfig(virtualServerEx, apResources, dosResources)
+	if cnf.outlierDetector != nil {
+		cnf.updateOutlierDetection(name, vsCfg.Upstreams)
+	}
 	content, err := cnf.templateExecutorV2.ExecuteVirtualServerTemplate(&vsCfg)
 	if err != nil {
 		return false, warnings, weightUpdates, fmt.Errorf("error generating VirtualServer config: %v: %w", name, err)
@@ -763,10 +838,10 @@ func (cnf *Configurator) addOrUpdateVirtualServer(virtualServerEx *VirtualServer
 		return false, warnings, weightUpdates, fmt.Errorf("error validating VirtualServer config %v: %w", name, err)
 	}
 
-	if vsCfg.Server.OIDC != nil {
+	if len(vsCfg.Server.OIDCProviders) > 0 {
 		name := getFileNameForOIDCVirtualServer(virtualServerEx.VirtualServer)
 
-		content, err := cnf.templateExecutorV2.ExecuteOIDCTemplate(vsCfg.Server.OIDC)
+		content, err := cnf.templateExecutorV2.ExecuteOIDCTemplate(vsCfg.Server.OIDCProviders)
 		if err != nil {
 			return false, warnings, weightUpdates, fmt.Errorf("error generating VirtualServer OIDC config: %v: %w", name, err)
 		}
@@ -780,18 +855,104 @@ func (cnf *Configurator) addOrUpdateVirtualServer(virtualServerEx *VirtualServer
 	if (cnf.isPlus && cnf.isPrometheusEnabled) || cnf.isLatencyMetricsEnabled {
 		cnf.updateVirtualServerMetricsLabels(virtualServerEx, vsCfg.Upstreams)
 	}
+	if cnf.isHostMetricsEnabled {
+		cnf.updateVirtualServerHostMetricsResources(virtualServerEx)
+	}
 
-	if cnf.staticCfgParams.DynamicWeightChangesReload && len(vsCfg.TwoWaySplitClients) > 0 {
-		for _, splitClient := range vsCfg.TwoWaySplitClients {
-			if len(splitClient.Weights) != 2 {
-				continue
-			}
-			variableNamer := *NewVSVariableNamer(virtualServerEx.VirtualServer)
-			value := variableNamer.GetNameOfKeyOfMapForWeights(splitClient.SplitClientsIndex, splitClient.Weights[0], splitClient.Weights[1])
-			weightUpdates = append(weightUpdates, WeightUpdate{Zone: splitClient.ZoneName, Key: splitClient.Key, Value: value})
+	weightUpdates = generateWeightUpdates(virtualServerEx.VirtualServer, vsCfg.TwoWaySplitClients)
+	return changed, warnings, weightUpdates, nil
+}
+
+func generateWeightUpdates(vs *conf_v1.VirtualServer, twoWaySplitClients []version2.TwoWaySplitClients) []WeightUpdate {
+	var weightUpdates []WeightUpdate
+	for _, splitClient := range twoWaySplitClients {
+		if len(splitClient.Weights) != 2 {
+			continue
 		}
+		variableNamer := *NewVSVariableNamer(vs)
+		value := variableNamer.GetNameOfKeyOfMapForWeights(splitClient.SplitClientsIndex, splitClient.Weights[0], splitClient.Weights[1])
+		weightUpdates = append(weightUpdates, WeightUpdate{Zone: splitClient.ZoneName, Key: splitClient.Key, Value: value})
 	}
-	return changed, warnings, weightUpdates, nil
+	return weightUpdates
+}
+
+// UpdateVirtualServerRolloutWeights updates NGINX configuration for the weights of the rollouts of the VirtualServer.
+// The splits of the routes with rollouts always use dynamic weight changes, so the weights are changed in the
+// key-value zones of the split clients without a reload.
+func (cnf *Configurator) UpdateVirtualServerRolloutWeights(virtualServerEx *VirtualServerEx) (Warnings, error) {
+	vsc := newVirtualServerConfigurator(cnf.CfgParams, cnf.isPlus, cnf.IsResolverConfigured(), cnf.staticCfgParams, cnf.isWildcardEnabled, nil)
+	vsc.IngressControllerReplicas = cnf.ingressControllerReplicas
+	vsCfg, warnings := vsc.GenerateVirtualServerConfig(virtualServerEx, newAppProtectPolicyResources(), nil)
+	cnf.virtualServers[getFileNameForVirtualServer(virtualServerEx.VirtualServer)] = virtualServerEx
+
+	for _, weightUpdate := range generateWeightUpdates(virtualServerEx.VirtualServer, vsCfg.TwoWaySplitClients) {
+		cnf.nginxManager.UpsertSplitClientsKeyVal(weightUpdate.Zone, weightUpdate.Key, weightUpdate.Value)
+	}
+
+	return warnings, nil
+}
+
+// updateOutlierDetection updates the upstreams with outlier detection of the VirtualServer in the outlier detector,
+// marks the ejected servers of the upstreams as down and sets the weights of the servers that are being re-admitted.
+func (cnf *Configurator) updateOutlierDetection(name string, upstreams []version2.Upstream) {
+	var upstreamNames []string
+	for i := range upstreams {
+		ups := &upstreams[i]
+		if ups.OutlierDetection == nil {
+			continue
+		}
+
+		var servers []string
+		for _, s := range ups.Servers {
+			servers = append(servers, s.Address)
+		}
+		cnf.outlierDetector.UpdateUpstream(ups.Name, metrics.OutlierDetectionConfig{
+			ConsecutiveErrors:  ups.OutlierDetection.ConsecutiveErrors,
+			EjectionTime:       ups.OutlierDetection.EjectionTime,
+			MaxEjectionPercent: ups.OutlierDetection.MaxEjectionPercent,
+			Servers:            servers,
+		})
+
+		ejected := cnf.outlierDetector.EjectedServers(ups.Name)
+		weights := cnf.outlierDetector.ServerWeights(ups.Name)
+		for j := range ups.Servers {
+			ups.Servers[j].Down = slices.Contains(ejected, ups.Servers[j].Address)
+			ups.Servers[j].Weight = weights[ups.Servers[j].Address]
+		}
+		upstreamNames = append(upstreamNames, ups.Name)
+	}
+
+	for _, upstream := range cnf.outlierDetectionUpstreams[name] {
+		if !slices.Contains(upstreamNames, upstream) {
+			cnf.outlierDetector.DeleteUpstream(upstream)
+		}
+	}
+
+	if len(upstreamNames) == 0 {
+		delete(cnf.outlierDetectionUpstreams, name)
+		return
+	}
+	cnf.outlierDetectionUpstreams[name] = upstreamNames
+}
+
+// UpdateOutlierEjections updates NGINX configuration for the VirtualServers of the upstreams
+// after the outlier detector ejected or re-admitted servers of the upstreams.
+// The VirtualServers are updated with a single reload.
+func (cnf *Configurator) UpdateOutlierEjections(upstreams []string) (Warnings, error) {
+	var virtualServerExes []*VirtualServerEx
+	for _, name := range slices.Sorted(maps.Keys(cnf.outlierDetectionUpstreams)) {
+		if !slices.ContainsFunc(cnf.outlierDetectionUpstreams[name], func(u string) bool { return slices.Contains(upstreams, u) }) {
+			continue
+		}
+		if vsEx, exists := cnf.virtualServers[name]; exists {
+			virtualServerExes = append(virtualServerExes, vsEx)
+		}
+	}
+
+	if len(virtualServerExes) == 0 {
+		return newWarnings(), nil
+	}
+	return cnf.UpdateEndpointsForVirtualServers(virtualServerExes)
 }
 
 // AddOrUpdateVirtualServers adds or updates NGINX configuration for multiple VirtualServer resources.
@@ -899,6 +1060,25 @@ func (cnf *Configurator) AddOrUpdateTransportServer(transportServerEx *Transport
 	return warnings, nil
 }
 
+// AddOrUpdateTransportServers adds or updates NGINX configuration for multiple TransportServer resources.
+func (cnf *Configurator) AddOrUpdateTransportServers(transportServerExes []*TransportServerEx) (Warnings, error) {
+	allWarnings := newWarnings()
+
+	for _, tsEx := range transportServerExes {
+		_, warnings, err := cnf.addOrUpdateTransportServer(tsEx)
+		if err != nil {
+			return allWarnings, err
+		}
+		allWarnings.Add(warnings)
+	}
+
+	if err := cnf.Reload(nginx.ReloadForOtherUpdate); err != nil {
+		return allWarnings, fmt.Errorf("error when reloading NGINX when updating Policy: %w", err)
+	}
+
+	return allWarnings, nil
+}
+
 func (cnf *Configurator) addOrUpdateTransportServer(transportServerEx *TransportServerEx) (bool, Warnings, error) {
 	name := getFileNameForTransportServer(transportServerEx.TransportServer)
 	tsCfg, warnings := generateTransportServerConfig(transportServerConfigParams{
@@ -993,6 +1173,21 @@ func (cnf *Configurator) addOrUpdateHtpasswdSecret(secret *api_v1.Secret) string
 	return cnf.nginxManager.CreateSecret(name, data, nginx.HtpasswdSecretFileMode)
 }
 
+func (cnf *Configurator) addOrUpdateHMACSecret(secret *api_v1.Secret) string {
+	name := objectMetaToFileName(&secret.ObjectMeta)
+	data := generateHMACKeysFileContent(secret.Data)
+	return cnf.nginxManager.CreateSecret(name, data, nginx.ReadWriteOnlyFileMode)
+}
+
+// generateHMACKeysFileContent returns the key IDs with the base64 encoded keys sorted by the key IDs, one per line.
+func generateHMACKeysFileContent(secretData map[string][]byte) []byte {
+	var b bytes.Buffer
+	for _, keyID := range slices.Sorted(maps.Keys(secretData)) {
+		b.WriteString(keyID + ":" + base64.StdEncoding.EncodeToString(secretData[keyID]) + "\n")
+	}
+	return b.Bytes()
+}
+
 // AddOrUpdateResources adds or updates configuration for resources.
 func (cnf *Configurator) AddOrUpdateResources(resources ExtendedResources, reloadIfUnchanged bool) (Warnings, error) {
 	allWarnings := newWarnings()
@@ -1158,6 +1353,9 @@ func (cnf *Configurator) DeleteIngress(key string, skipReload bool) error {
 	if (cnf.isPlus && cnf.isPrometheusEnabled) || cnf.isLatencyMetricsEnabled {
 		cnf.deleteIngressMetricsLabels(key)
 	}
+	if cnf.isHostMetricsEnabled {
+		cnf.deleteHostMetricsResources(cnf.metricLabelsIndex.ingressResources, key)
+	}
 
 	if !skipReload {
 		if err := cnf.Reload(nginx.ReloadForOtherUpdate); err != nil {
@@ -1189,6 +1387,12 @@ func (cnf *Configurator) DeleteVirtualServer(key string, skipReload bool) error
 	if (cnf.isPlus && cnf.isPrometheusEnabled) || cnf.isLatencyMetricsEnabled {
 		cnf.deleteVirtualServerMetricsLabels(key)
 	}
+	if cnf.isHostMetricsEnabled {
+		cnf.deleteHostMetricsResources(cnf.metricLabelsIndex.virtualServerResources, key)
+	}
+	if cnf.outlierDetector != nil {
+		cnf.updateOutlierDetection(name, nil)
+	}
 
 	if !skipReload {
 		if err := cnf.Reload(nginx.ReloadForOtherUpdate); err != nil {
@@ -1354,10 +1558,10 @@ func (cnf *Configurator) updatePlusEndpointsForVirtualServer(virtualServerEx *Vi
 	// These are generated from policies referenced by the VirtualServer
 	// and are not part of the VirtualServer spec upstreams, so they need separate handling.
 	for _, pol := range virtualServerEx.Policies {
-		if pol.Spec.ExternalAuth == nil || pol.Spec.ExternalAuth.AuthServiceName == "" {
+		exAuth := GetExternalAuthForPolicy(pol)
+		if exAuth == nil || exAuth.AuthServiceName == "" {
 			continue
 		}
-		exAuth := pol.Spec.ExternalAuth
 		upstreamName := fmt.Sprintf("vs_exauth_%s_%s", pol.Namespace, pol.Name)
 		port := getExternalAuthPort(exAuth)
 		ns, svcName := ParseServiceReference(exAuth.AuthServiceName, pol.Namespace)
@@ -1527,7 +1731,16 @@ func (cnf *Configurator) Reload(isEndpointsUpdate bool) error {
 		return nil
 	}
 
-	return cnf.nginxManager.Reload(isEndpointsUpdate)
+	if err := cnf.nginxManager.Reload(isEndpointsUpdate); err != nil {
+		return err
+	}
+	cnf.reloads.Add(1)
+	return nil
+}
+
+// ReloadsCount returns the number of the successful reloads of NGINX performed by the Configurator.
+func (cnf *Configurator) ReloadsCount() uint64 {
+	return cnf.reloads.Load()
 }
 
 func (cnf *Configurator) updateServersInPlus(upstream string, servers []string, config nginx.ServerConfig) error {
@@ -2296,6 +2509,8 @@ func (cnf *Configurator) AddOrUpdateSecret(secret *api_v1.Secret) string {
 	case secrets.SecretTypeAPIKey:
 		// APIKey ClientSecret is not required on the filesystem, it is written directly to the config file.
 		return ""
+	case secrets.SecretTypeHMAC:
+		return cnf.addOrUpdateHMACSecret(secret)
 	case secrets.SecretTypeLicense:
 		return ""
 	default:
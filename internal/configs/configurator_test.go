@@ -8,6 +8,7 @@ import (
 	"os"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/prometheus/client_golang/prometheus"
@@ -18,6 +19,7 @@ import (
 	"github.com/nginx/kubernetes-ingress/internal/configs/version1"
 	"github.com/nginx/kubernetes-ingress/internal/configs/version2"
 	"github.com/nginx/kubernetes-ingress/internal/k8s/secrets"
+	"github.com/nginx/kubernetes-ingress/internal/metrics"
 	"github.com/nginx/kubernetes-ingress/internal/nginx"
 	conf_v1 "github.com/nginx/kubernetes-ingress/pkg/apis/configuration/v1"
 	"github.com/nginx/kubernetes-ingress/pkg/apis/dos/v1beta1"
@@ -324,6 +326,47 @@ func TestAddOrUpdateLicenseSecret(t *testing.T) {
 	}
 }
 
+type secretRecordingManager struct {
+	*nginx.FakeManager
+	content []byte
+	mode    os.FileMode
+}
+
+func (m *secretRecordingManager) CreateSecret(name string, content []byte, mode os.FileMode) string {
+	m.content = content
+	m.mode = mode
+	return m.FakeManager.CreateSecret(name, content, mode)
+}
+
+func TestAddOrUpdateHMACSecret(t *testing.T) {
+	t.Parallel()
+	manager := &secretRecordingManager{FakeManager: nginx.NewFakeManager("/etc/nginx")}
+	cnf := createTestConfiguratorWithManager(t, manager)
+	secret := api_v1.Secret{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "hmac-secret",
+			Namespace: "default",
+		},
+		Data: map[string][]byte{
+			"partner2": []byte("secret2"),
+			"partner1": []byte("secret1"),
+		},
+		Type: secrets.SecretTypeHMAC,
+	}
+
+	path := cnf.AddOrUpdateSecret(&secret)
+
+	if want := "/etc/nginx/secrets/default-hmac-secret"; path != want {
+		t.Errorf("AddOrUpdateSecret() returned path %q, want %q", path, want)
+	}
+	if want := "partner1:c2VjcmV0MQ==\npartner2:c2VjcmV0Mg==\n"; string(manager.content) != want {
+		t.Errorf("AddOrUpdateSecret() wrote %q, want %q", manager.content, want)
+	}
+	if manager.mode != nginx.ReadWriteOnlyFileMode {
+		t.Errorf("AddOrUpdateSecret() wrote the secret with mode %o, want %o", manager.mode, nginx.ReadWriteOnlyFileMode)
+	}
+}
+
 func TestAddOrUpdateIngress(t *testing.T) {
 	t.Parallel()
 	cnf := createTestConfigurator(t)
@@ -2070,6 +2113,128 @@ func TestGetVitualServerCountsNotExistingVS(t *testing.T) {
 	}
 }
 
+func TestUpdateOutlierDetection(t *testing.T) {
+	t.Parallel()
+
+	cnf := createTestConfigurator(t)
+	cnf.outlierDetector = metrics.NewOutlierDetector(context.Background())
+
+	newUpstreams := func() []version2.Upstream {
+		return []version2.Upstream{
+			{
+				Name:    "vs_default_cafe_tea",
+				Servers: []version2.UpstreamServer{{Address: "10.0.0.1:80"}, {Address: "10.0.0.2:80"}},
+				OutlierDetection: &version2.OutlierDetection{
+					ConsecutiveErrors:  1,
+					EjectionTime:       time.Hour,
+					MaxEjectionPercent: 50,
+				},
+			},
+			{
+				Name:    "vs_default_cafe_coffee",
+				Servers: []version2.UpstreamServer{{Address: "10.0.0.3:80"}},
+			},
+		}
+	}
+
+	cnf.updateOutlierDetection("vs_default_cafe", newUpstreams())
+	cnf.outlierDetector.HandleMessage(`nginx: {"upstreamAddress":"10.0.0.2:80", "upstreamResponseTime":"0.1", "proxyHost":"vs_default_cafe_tea", "upstreamStatus": "503"}`)
+
+	upstreams := newUpstreams()
+	cnf.updateOutlierDetection("vs_default_cafe", upstreams)
+
+	expectedServers := []version2.UpstreamServer{{Address: "10.0.0.1:80"}, {Address: "10.0.0.2:80", Down: true}}
+	if diff := cmp.Diff(expectedServers, upstreams[0].Servers); diff != "" {
+		t.Errorf("updateOutlierDetection() servers mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(map[string][]string{"vs_default_cafe": {"vs_default_cafe_tea"}}, cnf.outlierDetectionUpstreams); diff != "" {
+		t.Errorf("updateOutlierDetection() upstreams mismatch (-want +got):\n%s", diff)
+	}
+
+	cnf.updateOutlierDetection("vs_default_cafe", nil)
+
+	if len(cnf.outlierDetectionUpstreams) != 0 {
+		t.Errorf("updateOutlierDetection() kept upstreams %v of a deleted VirtualServer", cnf.outlierDetectionUpstreams)
+	}
+	if ejected := cnf.outlierDetector.EjectedServers("vs_default_cafe_tea"); len(ejected) != 0 {
+		t.Errorf("updateOutlierDetection() kept ejected servers %v of a deleted upstream", ejected)
+	}
+}
+
+type splitClientsKeyValRecordingManager struct {
+	*nginx.FakeManager
+	keyVals map[string]string
+	configs []string
+}
+
+func (m *splitClientsKeyValRecordingManager) UpsertSplitClientsKeyVal(_ string, key string, value string) {
+	m.keyVals[key] = value
+}
+
+func (m *splitClientsKeyValRecordingManager) CreateConfig(name string, content []byte) (bool, error) {
+	m.configs = append(m.configs, name)
+	return m.FakeManager.CreateConfig(name, content)
+}
+
+func TestUpdateVirtualServerRolloutWeights(t *testing.T) {
+	t.Parallel()
+
+	manager := &splitClientsKeyValRecordingManager{FakeManager: nginx.NewFakeManager("/etc/nginx"), keyVals: make(map[string]string)}
+	cnf := createTestConfiguratorWithManager(t, manager)
+
+	vs := &conf_v1.VirtualServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "cafe",
+			Namespace: "default",
+		},
+		Spec: conf_v1.VirtualServerSpec{
+			Host: "cafe.example.com",
+			Upstreams: []conf_v1.Upstream{
+				{Name: "tea-v1", Service: "tea-svc-v1", Port: 80},
+				{Name: "tea-v2", Service: "tea-svc-v2", Port: 80},
+			},
+			Routes: []conf_v1.Route{
+				{
+					Path: "/tea",
+					Splits: []conf_v1.Split{
+						{Weight: 100, Action: &conf_v1.Action{Pass: "tea-v1"}},
+						{Weight: 0, Action: &conf_v1.Action{Pass: "tea-v2"}},
+					},
+					Rollout: &conf_v1.Rollout{
+						Steps: []conf_v1.RolloutStep{{Weight: 10, Duration: "1m"}, {Weight: 100}},
+					},
+				},
+			},
+		},
+	}
+	vsEx := &VirtualServerEx{
+		VirtualServer:  vs,
+		RolloutWeights: map[string]int{"/tea": 10},
+	}
+
+	_, err := cnf.UpdateVirtualServerRolloutWeights(vsEx)
+	if err != nil {
+		t.Fatalf("UpdateVirtualServerRolloutWeights() returned unexpected error: %v", err)
+	}
+
+	variableNamer := NewVSVariableNamer(vs)
+	expectedKeyVals := map[string]string{
+		variableNamer.GetNameOfKeyvalKeyForSplitClientIndex(0): variableNamer.GetNameOfKeyOfMapForWeights(0, 90, 10),
+	}
+	if diff := cmp.Diff(expectedKeyVals, manager.keyVals); diff != "" {
+		t.Errorf("UpdateVirtualServerRolloutWeights() key-values mismatch (-want +got):\n%s", diff)
+	}
+	if len(manager.configs) != 0 {
+		t.Errorf("UpdateVirtualServerRolloutWeights() created configs %v", manager.configs)
+	}
+	if reloads := cnf.ReloadsCount(); reloads != 0 {
+		t.Errorf("UpdateVirtualServerRolloutWeights() reloaded NGINX %d times", reloads)
+	}
+	if cnf.virtualServers["vs_default_cafe"] != vsEx {
+		t.Error("UpdateVirtualServerRolloutWeights() didn't store the VirtualServer")
+	}
+}
+
 func TestAddOrUpdateTransportServer(t *testing.T) {
 	t.Parallel()
 	cnf := createTestConfigurator(t)
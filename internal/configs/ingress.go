@@ -59,6 +59,8 @@ type IngressEx struct {
 	DosEx            *DosEx
 	SecretRefs       map[string]*secrets.SecretReference
 	ZoneSync         bool
+	// DefaultPolicies are the default policies of the GlobalConfiguration applied to the Ingress.
+	DefaultPolicies []conf_v1.PolicyReference
 }
 
 // DosEx holds a DosProtectedResource and the dos policy and log confs it references.
@@ -181,8 +183,8 @@ func filterIngressPolicyRefs(policyRefs []conf_v1.PolicyReference, ingEx *Ingres
 	return filteredPolicyRefs, warnings, false
 }
 
-// getIngressPolicyRefs returns the de-duplicated Policy references from Ingress policy annotations
-// while preserving the annotation order: nginx.org/policies first, then nginx.com/policies.
+// getIngressPolicyRefs returns the de-duplicated Policy references from the default policies and the Ingress policy
+// annotations while preserving the order: the default policies first, then nginx.org/policies and nginx.com/policies.
 func getIngressPolicyRefs(ingEx *IngressEx) []conf_v1.PolicyReference {
 	if ingEx == nil || ingEx.Ingress == nil {
 		return nil
@@ -190,6 +192,14 @@ func getIngressPolicyRefs(ingEx *IngressEx) []conf_v1.PolicyReference {
 
 	var policyRefs []conf_v1.PolicyReference
 	seenPolicyRefs := make(map[string]bool)
+	for _, ref := range ingEx.DefaultPolicies {
+		key := fmt.Sprintf("%s/%s", ref.Namespace, ref.Name)
+		if seenPolicyRefs[key] {
+			continue
+		}
+		seenPolicyRefs[key] = true
+		policyRefs = append(policyRefs, ref)
+	}
 	for _, annotation := range []string{PoliciesAnnotation, PoliciesAnnotationPlus} {
 		policyNames, exists := ingEx.Ingress.Annotations[annotation]
 		if !exists {
@@ -344,7 +354,7 @@ func generateNginxCfg(ncp NginxCfgParams) (version1.IngressNginxConfig, Warnings
 				apResources:     policyAppProtectResources,
 				defaultCABundle: ncp.staticParams.DefaultCABundle,
 				replicas:        ncp.ingressControllerReplicas,
-				oidcPolicyName:  "",
+				otelEnabled:     ncp.BaseCfgParams.MainOtelLoadModule,
 			},
 			bundleValidator,
 		)
@@ -358,6 +368,19 @@ func generateNginxCfg(ncp NginxCfgParams) (version1.IngressNginxConfig, Warnings
 		// CORS origin validation map is rendered at http{} level and consumed by location headers.
 		maps = append(maps, *policyCfg.CORSMap)
 	}
+	if policyCfg.GeoMap != nil {
+		maps = append(maps, *policyCfg.GeoMap)
+	}
+
+	var geos []version2.Geo
+	if policyCfg.DryRun.AccessControl != nil {
+		geos = append(geos, *policyCfg.DryRun.AccessControl)
+	}
+
+	var splitClients []version2.SplitClient
+	if policyCfg.TracingSampler != nil {
+		splitClients = append(splitClients, *policyCfg.TracingSampler)
+	}
 
 	for _, rule := range ncp.ingEx.Ingress.Spec.Rules {
 		// skipping invalid hosts
@@ -417,6 +440,9 @@ func generateNginxCfg(ncp NginxCfgParams) (version1.IngressNginxConfig, Warnings
 			AppRoot:                cfgParams.AppRoot,
 			Allow:                  policyCfg.Allow,
 			Deny:                   policyCfg.Deny,
+			PolicyDryRun:           generatePolicyDryRun(policyCfg),
+			GeoRestriction:         policyCfg.Geo,
+			Tracing:                policyCfg.Tracing,
 			WAF:                    policyCfg.WAF,
 			EgressMTLS:             policyCfg.EgressMTLS,
 			PoliciesErrorReturn:    policyCfg.ErrorReturn,
@@ -587,6 +613,9 @@ func generateNginxCfg(ncp NginxCfgParams) (version1.IngressNginxConfig, Warnings
 				if policyCfg.Deny != nil {
 					loc.Deny = policyCfg.Deny
 				}
+				loc.PolicyDryRun = generatePolicyDryRun(policyCfg)
+				loc.GeoRestriction = policyCfg.Geo
+				loc.Tracing = policyCfg.Tracing
 
 				if policyCfg.WAF != nil {
 					loc.WAF = policyCfg.WAF
@@ -728,6 +757,8 @@ func generateNginxCfg(ncp NginxCfgParams) (version1.IngressNginxConfig, Warnings
 		StaticSSLPath:           ncp.staticParams.StaticSSLPath,
 		LimitReqZones:           limitReqZones,
 		Maps:                    removeDuplicateMaps(maps),
+		Geos:                    geos,
+		SplitClients:            splitClients,
 	}, allWarnings
 }
 
@@ -1222,6 +1253,8 @@ func generateNginxCfgForMergeableIngresses(ncp NginxCfgParams) (version1.Ingress
 
 	upstreams = append(upstreams, masterNginxCfg.Upstreams...)
 	maps = append(maps, masterNginxCfg.Maps...)
+	geos := masterNginxCfg.Geos
+	splitClients := masterNginxCfg.SplitClients
 
 	if masterNginxCfg.Keepalive != "" {
 		keepalive = masterNginxCfg.Keepalive
@@ -1344,6 +1377,8 @@ func generateNginxCfgForMergeableIngresses(ncp NginxCfgParams) (version1.Ingress
 		upstreams = append(upstreams, minionNginxCfg.Upstreams...)
 		limitReqZones = append(limitReqZones, minionNginxCfg.LimitReqZones...)
 		maps = append(maps, minionNginxCfg.Maps...)
+		geos = append(geos, minionNginxCfg.Geos...)
+		splitClients = append(splitClients, minionNginxCfg.SplitClients...)
 	}
 
 	masterServer.HealthChecks = healthChecks
@@ -1361,6 +1396,8 @@ func generateNginxCfgForMergeableIngresses(ncp NginxCfgParams) (version1.Ingress
 		StaticSSLPath:           ncp.staticParams.StaticSSLPath,
 		LimitReqZones:           limitReqZones,
 		Maps:                    removeDuplicateMaps(maps),
+		Geos:                    geos,
+		SplitClients:            splitClients,
 	}, warnings
 }
 
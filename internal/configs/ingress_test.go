@@ -562,6 +562,23 @@ func TestGetIngressPolicyRefs(t *testing.T) {
 				{Name: "waf-policy", Namespace: "waf-ns"},
 			},
 		},
+		{
+			name: "puts default policies first and de duplicates them",
+			ingEx: func() *IngressEx {
+				ingEx := createCafeIngressEx()
+				ingEx.Ingress.Annotations[PoliciesAnnotation] = "cors-policy, security/rate-limit"
+				ingEx.DefaultPolicies = []conf_v1.PolicyReference{
+					{Name: "rate-limit", Namespace: "security"},
+					{Name: "access-control", Namespace: "security"},
+				}
+				return &ingEx
+			}(),
+			expectedRefs: []conf_v1.PolicyReference{
+				{Name: "rate-limit", Namespace: "security"},
+				{Name: "access-control", Namespace: "security"},
+				{Name: "cors-policy", Namespace: "default"},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -6,15 +6,60 @@ function hash(r) {
     return hashed_value;
 }
 
+// metadata returns the metadata of the client of the API key, or null if the client has no metadata.
+// The metadata map holds "<expiry time in seconds since the epoch or 0> <comma separated path prefixes> <name>".
+function metadata(r) {
+    const value = r.variables[r.variables['apikey_auth_local_map'] + '_metadata'];
+    if (!value) {
+        return null;
+    }
+    const parts = value.split(' ');
+    return {
+        expires: Number(parts[0]),
+        routes: parts[1].split(','),
+        name: parts.slice(2).join(' '),
+    };
+}
+
+// isAllowed checks the expiry time and the routes of the client of the API key.
+// The routes are matched against $apikey_auth_uri, the normalized URI of the request before any internal redirects,
+// so that paths like /public/../admin or /public/..%2Fadmin are matched as /admin.
+function isAllowed(r) {
+    const m = metadata(r);
+    if (!m) {
+        return true;
+    }
+    if (m.expires && Date.now() / 1000 >= m.expires) {
+        return false;
+    }
+    const path = r.variables.apikey_auth_uri;
+    if (!path) {
+        return false;
+    }
+    return m.routes.some(function (route) {
+        return path === route || path.startsWith(route.endsWith('/') ? route : route + '/');
+    });
+}
+
+function clientDisplayName(r) {
+    const m = metadata(r);
+    return m && m.name ? m.name : (r.variables.apikey_client_name || '');
+}
+
 function validate(r) {
     const client_name_map = r.variables['apikey_auth_local_map'];
     const client_name = r.variables[client_name_map];
     const header_query_value = r.variables.header_query_value;
+    const dry_run_policy = r.variables.apikey_auth_dry_run;
 
-    if (!header_query_value) {
+    if (dry_run_policy && (!header_query_value || !client_name || !isAllowed(r))) {
+        r.headersOut['X-Policy-Dry-Run'] = dry_run_policy;
+        r.return(204, "204");
+    }
+    else if (!header_query_value) {
         r.return(401, "401")
     }
-    else if (!client_name) {
+    else if (!client_name || !isAllowed(r)) {
         r.return(403, "403")
     }
     else {
@@ -23,4 +68,4 @@ function validate(r) {
 
 }
 
-export default { validate, hash };
+export default { validate, hash, clientDisplayName };
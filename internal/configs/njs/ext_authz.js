@@ -0,0 +1,224 @@
+// The check requests and responses of the Envoy envoy.service.auth.v3.Authorization gRPC service
+// are encoded as protocol buffers messages with the field numbers below.
+
+function varint(n) {
+    const bytes = [];
+    while (n > 0x7f) {
+        bytes.push((n & 0x7f) | 0x80);
+        n = Math.floor(n / 128);
+    }
+    bytes.push(n);
+    return Buffer.from(bytes);
+}
+
+function bytesField(number, value) {
+    const data = typeof value == 'string' ? Buffer.from(value) : value;
+    return Buffer.concat([varint(number * 8 + 2), varint(data.length), data]);
+}
+
+function varintField(number, value) {
+    return Buffer.concat([varint(number * 8), varint(value)]);
+}
+
+function decode(buf) {
+    const fields = {};
+    let pos = 0;
+
+    function readVarint() {
+        let value = 0;
+        let multiplier = 1;
+        let b;
+        do {
+            if (pos >= buf.length) {
+                throw new Error('truncated varint');
+            }
+            b = buf[pos++];
+            value += (b & 0x7f) * multiplier;
+            multiplier *= 128;
+        } while (b & 0x80);
+        return value;
+    }
+
+    while (pos < buf.length) {
+        const key = readVarint();
+        const number = Math.floor(key / 8);
+        let value;
+        switch (key & 7) {
+        case 0:
+            value = readVarint();
+            break;
+        case 1:
+            pos += 8;
+            continue;
+        case 2: {
+            const length = readVarint();
+            if (pos + length > buf.length) {
+                throw new Error('truncated field');
+            }
+            value = buf.slice(pos, pos + length);
+            pos += length;
+            break;
+        }
+        case 5:
+            pos += 4;
+            continue;
+        default:
+            throw new Error(`unsupported wire type ${key & 7}`);
+        }
+        fields[number] = fields[number] || [];
+        fields[number].push(value);
+    }
+    return fields;
+}
+
+function field(fields, number) {
+    return fields[number] ? fields[number][0] : undefined;
+}
+
+function peer(address, port) {
+    // Peer.address.socket_address
+    const socketAddress = Buffer.concat([bytesField(2, address || ''), varintField(3, Number(port) || 0)]);
+    return bytesField(1, bytesField(1, socketAddress));
+}
+
+function checkRequest(r) {
+    const headers = {};
+    r.rawHeadersIn.forEach(([name, value]) => {
+        const key = name.toLowerCase();
+        headers[key] = key in headers ? `${headers[key]},${value}` : value;
+    });
+
+    const http = [
+        bytesField(1, r.variables.request_id),
+        bytesField(2, r.method),
+    ];
+    for (const key in headers) {
+        http.push(bytesField(3, Buffer.concat([bytesField(1, key), bytesField(2, headers[key])])));
+    }
+    http.push(
+        bytesField(4, r.variables.request_uri),
+        bytesField(5, r.variables.host),
+        bytesField(6, r.variables.scheme),
+        bytesField(10, r.variables.server_protocol || ''),
+    );
+
+    // CheckRequest.attributes
+    const attributes = Buffer.concat([
+        bytesField(1, peer(r.variables.remote_addr, r.variables.remote_port)),
+        bytesField(2, peer(r.variables.server_addr, r.variables.server_port)),
+        bytesField(4, bytesField(2, Buffer.concat(http))),
+    ]);
+    return bytesField(1, attributes);
+}
+
+function grpcFrame(message) {
+    const prefix = Buffer.alloc(5);
+    prefix.writeUInt32BE(message.length, 1);
+    return Buffer.concat([prefix, message]);
+}
+
+function grpcMessage(frame) {
+    if (!frame || frame.length < 5) {
+        throw new Error('empty gRPC response');
+    }
+    if (frame[0] != 0) {
+        throw new Error('compressed gRPC responses are not supported');
+    }
+    const length = frame.readUInt32BE(1);
+    if (frame.length < 5 + length) {
+        throw new Error('truncated gRPC message');
+    }
+    return frame.slice(5, 5 + length);
+}
+
+// deniedStatus returns 401 for the unauthenticated requests and 403 for other denied requests.
+function deniedStatus(response, code) {
+    const denied = field(response, 2);
+    if (denied) {
+        const status = field(decode(denied), 1);
+        if (status && field(decode(status), 1) == 401) {
+            return 401;
+        }
+    }
+    // UNAUTHENTICATED
+    return code == 16 ? 401 : 403;
+}
+
+// setHeaders returns the allowed headers named by $ext_authz_headers in the X-Ext-Authz-Header-<index> headers.
+// The headers set by the OK response take their values, the removed headers are cleared,
+// and other headers keep the values of the request.
+function setHeaders(r, ok) {
+    const set = {};
+    (ok[2] || []).forEach((option) => {
+        const header = field(decode(option), 1);
+        if (!header) {
+            return;
+        }
+        const h = decode(header);
+        const key = field(h, 1);
+        const value = field(h, 2) || field(h, 3);
+        if (key) {
+            set[key.toString().toLowerCase()] = value ? value.toString() : '';
+        }
+    });
+    const removed = (ok[5] || []).map((name) => name.toString().toLowerCase());
+
+    const names = (r.variables.ext_authz_headers || '').split(' ');
+    names.forEach((name, i) => {
+        if (!name) {
+            return;
+        }
+        const key = name.toLowerCase();
+        let value = r.headersIn[name] || '';
+        if (key in set) {
+            value = set[key];
+        } else if (removed.includes(key)) {
+            value = '';
+        }
+        r.headersOut[`X-Ext-Authz-Header-${i}`] = value;
+    });
+}
+
+// check sends the attributes of the request to the external authorization server through the location
+// named by $ext_authz_endpoint and applies the result of the check response.
+async function check(r) {
+    let reply;
+    try {
+        reply = await r.subrequest(r.variables.ext_authz_endpoint, {
+            method: 'POST',
+            body: grpcFrame(checkRequest(r)),
+        });
+    } catch (e) {
+        r.error(`external authorization subrequest failed: ${e}`);
+        r.return(500);
+        return;
+    }
+
+    const grpcStatus = reply.headersOut['grpc-status'];
+    if (reply.status != 200 || (grpcStatus !== undefined && grpcStatus != '0')) {
+        r.error(`external authorization server returned status ${reply.status}, gRPC status ${grpcStatus}`);
+        r.return(500);
+        return;
+    }
+
+    let status;
+    try {
+        const response = decode(grpcMessage(reply.responseBuffer));
+        const rpcStatus = field(response, 1);
+        const code = rpcStatus ? field(decode(rpcStatus), 1) || 0 : 0;
+        if (code != 0) {
+            status = deniedStatus(response, code);
+        } else {
+            const ok = field(response, 3);
+            setHeaders(r, ok ? decode(ok) : {});
+            status = 204;
+        }
+    } catch (e) {
+        r.error(`external authorization server returned an invalid response: ${e}`);
+        r.return(500);
+        return;
+    }
+    r.return(status);
+}
+
+export default { check };
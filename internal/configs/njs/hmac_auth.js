@@ -0,0 +1,99 @@
+const c = require('crypto')
+const fs = require('fs')
+
+function keys(r) {
+    const keyIDHeader = r.variables.hmac_auth_key_id_header;
+    const keyID = keyIDHeader ? r.headersIn[keyIDHeader] : '';
+
+    let entries;
+    try {
+        entries = fs.readFileSync(r.variables.hmac_auth_keys_file, 'utf8').split('\n');
+    } catch (e) {
+        r.error(`failed to read the HMAC keys: ${e}`);
+        return [];
+    }
+
+    const result = [];
+    entries.forEach((entry) => {
+        const i = entry.indexOf(':');
+        if (i < 1) {
+            return;
+        }
+        if (keyIDHeader && entry.substring(0, i) != keyID) {
+            return;
+        }
+        result.push(Buffer.from(entry.substring(i + 1), 'base64'));
+    });
+    return result;
+}
+
+// decodeSignature returns the signature from the hex or base64 encoded value, optionally prefixed with sha256=.
+function decodeSignature(value) {
+    const signature = value.startsWith('sha256=') ? value.substring(7) : value;
+    if (/^[0-9a-fA-F]{64}$/.test(signature)) {
+        return Buffer.from(signature, 'hex');
+    }
+    return Buffer.from(signature, 'base64');
+}
+
+function sign(r, key, timestamp) {
+    const hmac = c.createHmac('sha256', key);
+    r.variables.hmac_auth_canonical_string.split(/(\{(?:method|uri|timestamp|body)\})/).forEach((part) => {
+        switch (part) {
+        case '{method}':
+            hmac.update(r.method);
+            break;
+        case '{uri}':
+            hmac.update(r.variables.request_uri);
+            break;
+        case '{timestamp}':
+            hmac.update(timestamp);
+            break;
+        case '{body}':
+            hmac.update(r.requestBuffer || '');
+            break;
+        default:
+            hmac.update(part);
+        }
+    });
+    return hmac.digest();
+}
+
+function equal(a, b) {
+    if (a.length != b.length) {
+        return false;
+    }
+    let diff = 0;
+    for (let i = 0; i < a.length; i++) {
+        diff |= a[i] ^ b[i];
+    }
+    return diff === 0;
+}
+
+// verify verifies the signature of the request and redirects the valid requests to $hmac_auth_destination.
+function verify(r) {
+    const rejectCode = Number(r.variables.hmac_auth_reject_code);
+
+    const timestamp = r.headersIn[r.variables.hmac_auth_timestamp_header] || '';
+    const skew = Math.abs(Date.now() / 1000 - Number(timestamp));
+    if (!/^[0-9]+$/.test(timestamp) || skew > Number(r.variables.hmac_auth_clock_skew)) {
+        r.return(rejectCode);
+        return;
+    }
+
+    const value = r.headersIn[r.variables.hmac_auth_signature_header];
+    if (!value) {
+        r.return(rejectCode);
+        return;
+    }
+    const signature = decodeSignature(value);
+
+    if (!keys(r).some((key) => equal(sign(r, key, timestamp), signature))) {
+        r.return(rejectCode);
+        return;
+    }
+
+    r.internalRedirect(r.variables.hmac_auth_destination);
+}
+
+export default { verify };
@@ -0,0 +1,103 @@
+const c = require('crypto')
+
+function bearerToken(r) {
+    const authorization = r.headersIn['Authorization'] || '';
+    const parts = authorization.split(' ');
+    if (parts.length != 2 || parts[0].toLowerCase() != 'bearer' || !parts[1]) {
+        return '';
+    }
+    return parts[1];
+}
+
+function tokenHash(r) {
+    const token = bearerToken(r);
+    if (!token) {
+        return '';
+    }
+    return c.createHash('sha256').update(token).digest('hex');
+}
+
+function claimValue(claims, name) {
+    let value = claims;
+    for (const part of name.split('.')) {
+        if (value === null || typeof value != 'object') {
+            return '';
+        }
+        value = value[part];
+    }
+    if (value === undefined || value === null) {
+        return '';
+    }
+    if (Array.isArray(value)) {
+        return value.join(',');
+    }
+    if (typeof value == 'object') {
+        return JSON.stringify(value);
+    }
+    return String(value);
+}
+
+function setClaimHeaders(r, claims) {
+    const names = (r.variables.introspection_claims || '').split(' ');
+    names.forEach((name, i) => {
+        if (name) {
+            r.headersOut[`X-Introspection-Claim-${i}`] = claimValue(claims, name);
+        }
+    });
+}
+
+// validate sends the bearer token to the introspection endpoint and caches the claims of the active tokens
+// in the keyval variable named by $introspection_cache.
+async function validate(r) {
+    const token = bearerToken(r);
+    if (!token) {
+        r.return(401);
+        return;
+    }
+
+    const cache = r.variables.introspection_cache;
+    const cached = r.variables[cache];
+    if (cached) {
+        setClaimHeaders(r, JSON.parse(cached));
+        r.return(204);
+        return;
+    }
+
+    let reply;
+    try {
+        reply = await r.subrequest(r.variables.introspection_endpoint, {
+            method: 'POST',
+            body: `token=${encodeURIComponent(token)}&token_type_hint=access_token`,
+        });
+    } catch (e) {
+        r.error(`introspection subrequest failed: ${e}`);
+        r.return(500);
+        return;
+    }
+
+    if (reply.status != 200) {
+        r.error(`introspection endpoint returned status ${reply.status}`);
+        r.return(500);
+        return;
+    }
+
+    let claims;
+    try {
+        claims = JSON.parse(reply.responseText);
+    } catch (e) {
+        r.error(`introspection endpoint returned an invalid response: ${e}`);
+        r.return(500);
+        return;
+    }
+
+    if (claims.active !== true) {
+        r.return(401);
+        return;
+    }
+
+    r.variables[cache] = JSON.stringify(claims);
+    setClaimHeaders(r, claims);
+    r.return(204);
+}
+
+export default { validate, tokenHash };
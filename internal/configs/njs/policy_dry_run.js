@@ -0,0 +1,11 @@
+function rejections(r) {
+    return [r.variables.policy_dry_run_access_control, r.variables.policy_dry_run_auth]
+        .filter(function (policy) { return policy; })
+        .join(' ');
+}
+
+function pass(r) {
+    r.return(204);
+}
+
+export default { rejections, pass };
@@ -0,0 +1,129 @@
+// proxy_protocol.js sends the PROXY protocol v2 header with the TLS details of the client to the upstream servers
+// of a TransportServer.
+// See https://www.haproxy.org/download/2.9/doc/proxy-protocol.txt for the format of the header.
+
+const SIGNATURE = [0x0D, 0x0A, 0x0D, 0x0A, 0x00, 0x0D, 0x0A, 0x51, 0x55, 0x49, 0x54, 0x0A];
+const VERSION_PROXY = 0x21;
+const FAMILY_UNSPEC = 0x00;
+const FAMILY_TCP4 = 0x11;
+const FAMILY_TCP6 = 0x21;
+
+const PP2_TYPE_AUTHORITY = 0x02;
+const PP2_TYPE_SSL = 0x20;
+const PP2_SUBTYPE_SSL_VERSION = 0x21;
+const PP2_SUBTYPE_SSL_CN = 0x22;
+const PP2_SUBTYPE_SSL_CIPHER = 0x23;
+
+const PP2_CLIENT_SSL = 0x01;
+const PP2_CLIENT_CERT_CONN = 0x02;
+const PP2_CLIENT_CERT_SESS = 0x04;
+
+function uint16(n) {
+    return [(n >> 8) & 0xFF, n & 0xFF];
+}
+
+function uint32(n) {
+    return [(n >>> 24) & 0xFF, (n >> 16) & 0xFF, (n >> 8) & 0xFF, n & 0xFF];
+}
+
+function tlv(type, value) {
+    const v = typeof value === 'string' ? Buffer.from(value) : value;
+    return Buffer.concat([Buffer.from([type].concat(uint16(v.length))), v]);
+}
+
+function ipv4(addr) {
+    if (!/^\d+\.\d+\.\d+\.\d+$/.test(addr)) {
+        return null;
+    }
+    return addr.split('.').map(Number);
+}
+
+function ipv6(addr) {
+    if (addr.indexOf(':') === -1) {
+        return null;
+    }
+    const halves = addr.split('::');
+    const head = halves[0] ? halves[0].split(':') : [];
+    const tail = halves.length > 1 && halves[1] ? halves[1].split(':') : [];
+    let bytes = [];
+    const groups = function (list) {
+        list.forEach(function (group) {
+            if (group.indexOf('.') !== -1) {
+                bytes = bytes.concat(ipv4(group));
+            } else {
+                bytes = bytes.concat(uint16(parseInt(group, 16)));
+            }
+        });
+    };
+    groups(head);
+    const headLength = bytes.length;
+    groups(tail);
+    const zeros = new Array(16 - bytes.length).fill(0);
+    return bytes.slice(0, headLength).concat(zeros, bytes.slice(headLength));
+}
+
+// addresses returns the family and the addresses of the connection, or the UNSPEC family if the addresses of the
+// client and the server are not of the same IP family, for example, for UNIX-domain sockets.
+function addresses(s) {
+    const ports = uint16(Number(s.variables.remote_port)).concat(uint16(Number(s.variables.server_port)));
+    const src4 = ipv4(s.variables.remote_addr);
+    const dst4 = ipv4(s.variables.server_addr);
+    if (src4 && dst4) {
+        return {family: FAMILY_TCP4, bytes: src4.concat(dst4, ports)};
+    }
+    const src6 = ipv6(s.variables.remote_addr);
+    const dst6 = ipv6(s.variables.server_addr);
+    if (src6 && dst6) {
+        return {family: FAMILY_TCP6, bytes: src6.concat(dst6, ports)};
+    }
+    return {family: FAMILY_UNSPEC, bytes: []};
+}
+
+// commonName returns the CN attribute of a distinguished name in the RFC 2253 format of $ssl_client_s_dn.
+function commonName(dn) {
+    const m = /(?:^|,)CN=((?:\\.|[^,\\])*)/.exec(dn || '');
+    return m ? m[1].replace(/\\(.)/g, '$1') : '';
+}
+
+function sslTLV(s) {
+    const verify = s.variables.ssl_client_verify;
+    let client = PP2_CLIENT_SSL;
+    if (verify && verify !== 'NONE') {
+        client |= PP2_CLIENT_CERT_CONN | PP2_CLIENT_CERT_SESS;
+    }
+
+    const subTLVs = [Buffer.from([client].concat(uint32(verify === 'SUCCESS' ? 0 : 1)))];
+    if (s.variables.ssl_protocol) {
+        subTLVs.push(tlv(PP2_SUBTYPE_SSL_VERSION, s.variables.ssl_protocol));
+    }
+    const cn = commonName(s.variables.ssl_client_s_dn);
+    if (cn) {
+        subTLVs.push(tlv(PP2_SUBTYPE_SSL_CN, cn));
+    }
+    if (s.variables.ssl_cipher) {
+        subTLVs.push(tlv(PP2_SUBTYPE_SSL_CIPHER, s.variables.ssl_cipher));
+    }
+    return tlv(PP2_TYPE_SSL, Buffer.concat(subTLVs));
+}
+
+function header(s) {
+    const addr = addresses(s);
+    const tlvs = [sslTLV(s)];
+    if (s.variables.ssl_server_name) {
+        tlvs.push(tlv(PP2_TYPE_AUTHORITY, s.variables.ssl_server_name));
+    }
+    const body = Buffer.concat([Buffer.from(addr.bytes)].concat(tlvs));
+    return Buffer.concat([Buffer.from(SIGNATURE.concat([VERSION_PROXY, addr.family], uint16(body.length))), body]);
+}
+
+// send sends the header before the first data of the client, because the upstream connection is not available
+// to njs before the client sends data.
+function send(s) {
+    s.on('upstream', function (data, flags) {
+        s.send(header(s), {flush: false});
+        s.send(data, flags);
+        s.off('upstream');
+    });
+}
+
+export default { send };
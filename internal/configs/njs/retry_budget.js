@@ -0,0 +1,47 @@
+// The window of the retry budgets in seconds. It must match the timeout of the shared dictionary zone.
+const window = 10;
+
+function counters(r) {
+    return {
+        zone: ngx.shared[r.variables.retry_budget_zone],
+        key: r.variables.retry_budget_key,
+        now: Math.floor(Date.now() / 1000),
+    };
+}
+
+function allow(r) {
+    const c = counters(r);
+    let requests = 1;
+    let retries = 0;
+
+    for (let i = 0; i < window; i++) {
+        requests += c.zone.get(`${c.key}:requests:${c.now - i}`) || 0;
+        retries += c.zone.get(`${c.key}:retries:${c.now - i}`) || 0;
+    }
+    c.zone.incr(`${c.key}:requests:${c.now}`, 1, 0);
+
+    const percent = Number(r.variables.retry_budget_percent);
+    const minRetries = Number(r.variables.retry_budget_min_retries);
+
+    if (retries < Math.max(minRetries, requests * percent / 100)) {
+        return '1';
+    }
+    return '0';
+}
+
+function account(r) {
+    // $upstream_status lists the statuses of all tries separated by commas.
+    // The statuses of internal redirects are separated by colons.
+    const statuses = r.variables.upstream_status;
+    if (!statuses) {
+        return;
+    }
+
+    const tries = statuses.split(' : ').pop().split(', ').length;
+    if (tries > 1) {
+        const c = counters(r);
+        c.zone.incr(`${c.key}:retries:${c.now}`, tries - 1, 0);
+    }
+}
+
+export default { allow, account };
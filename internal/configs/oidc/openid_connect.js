@@ -9,13 +9,25 @@ export default {
     codeExchange,
     extractTokenClaims,
     logout,
-    handleFrontChannelLogout
+    handleFrontChannelLogout,
+    sessionCookie
 };
 
+// Returns the name of the session cookie of the OIDC provider of the location.
+// The nonce and the redirect cookies are named after it.
+function sessionCookieName(r) {
+    return r.variables.oidc_session_cookie || "auth_token";
+}
+
+// Returns the value of the session cookie, used as the key of the session keyvals.
+function sessionCookie(r) {
+    return r.variables["cookie_" + sessionCookieName(r)];
+}
+
 // The main authentication flow, called before serving a protected resource.
 async function auth(r, afterSyncCheck) {
     // If there's a session cookie but session not synced, wait for sync
-    if (r.variables.cookie_auth_token && !r.variables.session_jwt &&
+    if (sessionCookie(r) && !r.variables.session_jwt &&
         !afterSyncCheck && r.variables.zone_sync_leeway > 0) {
         waitForSessionSync(r, r.variables.zone_sync_leeway);
         return;
@@ -92,8 +104,9 @@ async function codeExchange(r) {
     r.log("OIDC success, creating session " + sessionId);
 
     // Set cookie and redirect to the originally requested URI
-    r.headersOut["Set-Cookie"] = "auth_token=" + sessionId + "; " + r.variables.oidc_cookie_flags;
-    r.return(302, r.variables.redirect_base + decodeURIComponent(r.variables.cookie_auth_redir));
+    const cookieName = sessionCookieName(r);
+    r.headersOut["Set-Cookie"] = cookieName + "=" + sessionId + "; " + r.variables.oidc_cookie_flags;
+    r.return(302, r.variables.redirect_base + decodeURIComponent(r.variables["cookie_" + cookieName + "_redir"]));
 }
 
 // Extracts claims from token by calling the internal endpoint.
@@ -151,10 +164,11 @@ function validateIdTokenClaims(r, claims) {
 
     // Nonce validation for initial authentication
     if (claims.nonce) {
-        const clientNonceHash = r.variables.cookie_auth_nonce
+        const clientNonce = r.variables["cookie_" + sessionCookieName(r) + "_nonce"];
+        const clientNonceHash = clientNonce
             ? require('crypto')
                 .createHmac('sha256', r.variables.oidc_hmac_key)
-                .update(r.variables.cookie_auth_nonce)
+                .update(clientNonce)
                 .digest('base64url')
             : '';
 
@@ -210,7 +224,7 @@ function extractTokenClaims(r) {
 
 // Determine the session ID depending on whether it's a new auth or a refresh
 function getSessionId(r, isNewSession) {
-    return isNewSession ? r.variables.request_id : r.variables.cookie_auth_token;
+    return isNewSession ? r.variables.request_id : sessionCookie(r);
 }
 
 // Check for existing session using refresh token
@@ -228,7 +242,7 @@ async function exchangeCodeForTokens(r) {
         return null;
     }
     const reply = await new Promise((resolve) => {
-        r.subrequest("/_token", params, resolve);
+        r.subrequest("/_token_" + r.variables.oidc_provider, params, resolve);
     });
 
     const ref = getRefId(r, "token.exchange");
@@ -283,7 +297,7 @@ async function refreshTokens(r) {
         return null;
     }
     const reply = await new Promise((resolve) => {
-        r.subrequest("/_refresh", params, resolve);
+        r.subrequest("/_refresh_" + r.variables.oidc_provider, params, resolve);
     });
 
     if (reply.status !== 200) {
@@ -308,7 +322,7 @@ async function refreshTokens(r) {
 
 // Logout handler
 async function logout(r) {
-    r.log("OIDC RP-Initiated Logout for " + (r.variables.cookie_auth_token || "unknown"));
+    r.log("OIDC RP-Initiated Logout for " + (sessionCookie(r) || "unknown"));
 
     function getLogoutRedirectUrl(base, redirect) {
         return redirect.match(/^(http|https):\/\//) ? redirect : base + redirect;
@@ -388,7 +402,7 @@ async function handleFrontChannelLogout(r) {
         return;
     }
 
-    /* TODO: Since we cannot use the cookie_auth_token var as a key (it does not exist if cookies
+    /* TODO: Since we cannot use the session cookie var as a key (it does not exist if cookies
        are absent), we use the request_id as a workaround. */
     r.variables.request_id = clientSid;
     var sessionJwt = r.variables.new_session;
@@ -465,9 +479,10 @@ function getAuthZArgs(r) {
     }
 
     var encodedRequestUri = encodeURIComponent(r.variables.request_uri);
+    var cookieName = sessionCookieName(r);
     r.headersOut['Set-Cookie'] = [
-        "auth_redir=" + encodedRequestUri + "; " + r.variables.oidc_cookie_flags,
-        "auth_nonce=" + noncePlain + "; " + r.variables.oidc_cookie_flags
+        cookieName + "_redir=" + encodedRequestUri + "; " + r.variables.oidc_cookie_flags,
+        cookieName + "_nonce=" + noncePlain + "; " + r.variables.oidc_cookie_flags
     ];
 
     if (r.variables.oidc_pkce_enable == 1) {
@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -161,6 +162,24 @@ func validateHashLBMethod(method string) (string, error) {
 	return "", fmt.Errorf("invalid load balancing method: %q", method)
 }
 
+var logFormatPresetValidInput = map[string]bool{
+	"json":   true,
+	"logfmt": true,
+	"ecs":    true,
+	"otel":   true,
+}
+
+// ParseLogFormatPreset ensures that the string value is a valid access log format preset
+func ParseLogFormatPreset(preset string) (string, error) {
+	preset = strings.TrimSpace(preset)
+
+	if _, exists := logFormatPresetValidInput[preset]; exists {
+		return preset, nil
+	}
+
+	return "", fmt.Errorf("invalid log format preset: %q, must be one of json, logfmt, ecs or otel", preset)
+}
+
 // ParseBool ensures that the string value is a valid bool
 func ParseBool(s string) (bool, error) {
 	return strconv.ParseBool(s)
@@ -226,6 +245,39 @@ func ParseTime(s string) (string, error) {
 	return fmt.Sprintf("%s%s%s%s%s%s%s%s", years, months, weeks, days, hours, mins, secs, millis), nil
 }
 
+// timeUnits are the durations of the units of the timeRegexp groups, as defined by http://nginx.org/en/docs/syntax.html
+var timeUnits = []time.Duration{
+	365 * 24 * time.Hour,
+	30 * 24 * time.Hour,
+	7 * 24 * time.Hour,
+	24 * time.Hour,
+	time.Hour,
+	time.Minute,
+	time.Second,
+	time.Millisecond,
+}
+
+// ParseTimeDuration converts the time string to a time.Duration
+func ParseTimeDuration(s string) (time.Duration, error) {
+	if s == "" || strings.TrimSpace(s) == "" || !timeRegexp.MatchString(s) {
+		return 0, errors.New("invalid time string")
+	}
+	units := timeRegexp.FindStringSubmatch(s)
+	var d time.Duration
+	for i, unit := range timeUnits {
+		value := strings.TrimRight(units[i+1], "yMwdhms")
+		if value == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid time string: %w", err)
+		}
+		d += time.Duration(n) * unit
+	}
+	return d, nil
+}
+
 // OffsetFmt http://nginx.org/en/docs/syntax.html
 const OffsetFmt = `\d+[kKmMgG]?`
 
@@ -3,6 +3,7 @@ package configs
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	v1 "k8s.io/api/core/v1"
@@ -478,6 +479,44 @@ func TestParseLBMethodForPlus(t *testing.T) {
 	}
 }
 
+func TestParseLogFormatPreset(t *testing.T) {
+	t.Parallel()
+	testsWithValidInput := []struct {
+		input    string
+		expected string
+	}{
+		{"json", "json"},
+		{"logfmt", "logfmt"},
+		{"ecs", "ecs"},
+		{" otel ", "otel"},
+	}
+
+	invalidInput := []string{
+		"",
+		"JSON",
+		"main",
+		"otlp",
+	}
+
+	for _, test := range testsWithValidInput {
+		result, err := ParseLogFormatPreset(test.input)
+		if err != nil {
+			t.Fatalf("ParseLogFormatPreset(%q) returned an error for valid input", test.input)
+		}
+
+		if result != test.expected {
+			t.Errorf("ParseLogFormatPreset(%q) returned %q expected %q", test.input, result, test.expected)
+		}
+	}
+
+	for _, input := range invalidInput {
+		_, err := ParseLogFormatPreset(input)
+		if err == nil {
+			t.Errorf("ParseLogFormatPreset(%q) does not return an error for invalid input", input)
+		}
+	}
+}
+
 func TestParseTime(t *testing.T) {
 	t.Parallel()
 	testsWithValidInput := []struct {
@@ -518,6 +557,40 @@ func TestParseTime(t *testing.T) {
 	}
 }
 
+func TestParseTimeDuration(t *testing.T) {
+	t.Parallel()
+	testsWithValidInput := []struct {
+		input    string
+		expected time.Duration
+	}{
+		{"1h30m 5 100ms", time.Hour + 30*time.Minute + 5*time.Second + 100*time.Millisecond},
+		{"10ms", 10 * time.Millisecond},
+		{"30", 30 * time.Second},
+		{"5m 30s", 5*time.Minute + 30*time.Second},
+		{"1w", 7 * 24 * time.Hour},
+		{"2d", 48 * time.Hour},
+	}
+	invalidInput := []string{"5s 5s", "ss", "-5s", "", " "}
+
+	for _, test := range testsWithValidInput {
+		result, err := ParseTimeDuration(test.input)
+		if err != nil {
+			t.Fatalf("ParseTimeDuration(%q) returned an error for valid input", test.input)
+		}
+
+		if result != test.expected {
+			t.Errorf("ParseTimeDuration(%q) returned %v expected %v", test.input, result, test.expected)
+		}
+	}
+
+	for _, test := range invalidInput {
+		result, err := ParseTimeDuration(test)
+		if err == nil {
+			t.Errorf("ParseTimeDuration(%q) didn't return error. Returned: %v", test, result)
+		}
+	}
+}
+
 func TestParseOffset(t *testing.T) {
 	t.Parallel()
 	testsWithValidInput := []string{"1", "2k", "2K", "3m", "3M", "4g", "4G"}
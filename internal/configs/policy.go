@@ -2,13 +2,15 @@ package configs
 
 import (
 	"context"
-	"crypto/sha256"
 	"encoding/base64"
-	"encoding/hex"
 	"fmt"
+	"maps"
 	"net/url"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/nginx/kubernetes-ingress/internal/configs/version2"
 	"github.com/nginx/kubernetes-ingress/internal/k8s/secrets"
@@ -24,6 +26,9 @@ import (
 // that handles sign-in redirect requests (e.g. oauth2-proxy expects /oauth2).
 const DefaultSigninRedirectBasePath = "/oauth2"
 
+// externalAuthGRPCCheckPath is the path of the Check method of the Envoy external authorization gRPC service.
+const externalAuthGRPCCheckPath = "/envoy.service.auth.v3.Authorization/Check"
+
 // rateLimit hold the configuration for the ratelimiting Policy
 type rateLimit struct {
 	Reqs             []version2.LimitReq
@@ -39,11 +44,16 @@ type jwtAuth struct {
 	Auth        *version2.JWTAuth
 	List        map[string]*version2.JWTAuth
 	JWKSEnabled bool
+	ClaimSets   []version2.AuthJWTClaimSet
+	Maps        []version2.Map
 }
 
 type apiKeyClient struct {
 	ClientID  string
 	HashedKey string
+	Name      string
+	Expires   time.Time
+	Routes    []string
 }
 
 // apiKeyAuth hold the configuration for the APIKey Policy
@@ -54,6 +64,27 @@ type apiKeyAuth struct {
 	ClientMap map[string][]apiKeyClient
 }
 
+// policyDryRun holds the configuration of the policies in the dry run mode.
+type policyDryRun struct {
+	AccessControl *version2.Geo
+	Auth          *version2.PolicyDryRunAuth
+}
+
+// headersPolicy holds the header modifications of the headers policies.
+type headersPolicy struct {
+	Request  []headerModifier
+	Response []headerModifier
+	Maps     []version2.Map
+}
+
+// headerModifier holds the directives that modify a request or a response header.
+type headerModifier struct {
+	Name      string
+	SetHeader *version2.Header    // proxy_set_header of a request header
+	Hide      bool                // proxy_hide_header of a response header
+	AddHeader *version2.AddHeader // add_header of a response header
+}
+
 type policiesCfg struct {
 	Allow           []string
 	Context         context.Context
@@ -66,10 +97,17 @@ type policiesCfg struct {
 	EgressMTLS      *version2.EgressMTLS
 	OIDC            *version2.OIDC
 	APIKey          apiKeyAuth
+	HMAC            *version2.HMAC
 	WAF             *version2.WAF
 	Cache           *version2.Cache
 	CORSHeaders     []version2.AddHeader
 	CORSMap         *version2.Map
+	Headers         *headersPolicy
+	Geo             *version2.GeoRestriction
+	GeoMap          *version2.Map
+	Tracing         *version2.Tracing
+	TracingSampler  *version2.SplitClient
+	DryRun          policyDryRun
 	ErrorReturn     *version2.Return
 	BundleValidator bundleValidator
 }
@@ -90,11 +128,18 @@ type policyOptions struct {
 	apResources     *appProtectPolicyResources
 	defaultCABundle string
 	replicas        int
-	oidcPolicyName  string
-	// oidcConfig holds the already-built OIDC config from the first route or spec that defined
-	// this OIDC policy. It is reused by addOIDCConfig() when the same policy name is encountered
+	otelEnabled     bool // The OpenTelemetry exporter is configured
+	// oidcProviders holds the already-built OIDC configs of the VirtualServer and its VirtualServerRoutes,
+	// keyed by the policy. A config is reused by addOIDCConfig() when the same policy is encountered
 	// on subsequent routes.
-	oidcConfig *version2.OIDC
+	oidcProviders map[string]*oidcProvider
+}
+
+// oidcProvider holds the configuration of an OIDC policy of a VirtualServer and its VirtualServerRoutes
+type oidcProvider struct {
+	Config    *version2.OIDC
+	ClaimSets []version2.AuthJWTClaimSet
+	Maps      []version2.Map
 }
 
 func newPoliciesConfig(bv bundleValidator) *policiesCfg {
@@ -109,18 +154,62 @@ func newPoliciesConfig(bv bundleValidator) *policiesCfg {
 // in sync with any callers that filter policies for Ingress resources (e.g. syncPolicy).
 // To add support for a new policy type on Ingress, add its Spec field to this function.
 // Also ensure that createIngressEx() in controller.go loads any required secret or service
-// references for that policy type.
+// references for that policy type. The gRPC mode of the external auth is not supported on Ingress.
 func IsPolicySupportedOnIngress(pol *conf_v1.Policy) bool {
 	return pol.Spec.AccessControl != nil ||
 		pol.Spec.CORS != nil ||
-		pol.Spec.ExternalAuth != nil ||
+		(pol.Spec.ExternalAuth != nil && pol.Spec.ExternalAuth.GRPC == nil) ||
 		pol.Spec.IngressMTLS != nil ||
 		pol.Spec.EgressMTLS != nil ||
-		pol.Spec.WAF != nil
+		pol.Spec.WAF != nil ||
+		pol.Spec.Geo != nil ||
+		pol.Spec.Tracing != nil
+}
+
+// IsPolicySupportedOnTransportServer returns true if the policy type is supported on TransportServer resources.
+// To add support for a new policy type on TransportServer, add its Spec field to this function
+// and generate its configuration in generateTransportServerPolicies().
+func IsPolicySupportedOnTransportServer(pol *conf_v1.Policy) bool {
+	return pol.Spec.AccessControl != nil ||
+		pol.Spec.ConnectionLimit != nil
+}
+
+// IntrospectionExternalAuth returns the external auth that sends the requests of the introspection policy
+// to the introspection endpoint. It allows the introspection policy to reuse the external auth upstreams and locations.
+func IntrospectionExternalAuth(introspection *conf_v1.Introspection) *conf_v1.ExternalAuth {
+	return &conf_v1.ExternalAuth{
+		AuthURI:           introspection.AuthURI,
+		AuthServiceName:   introspection.AuthServiceName,
+		AuthServicePorts:  introspection.AuthServicePorts,
+		SSLEnabled:        introspection.SSLEnabled,
+		SSLVerify:         introspection.SSLVerify,
+		SSLVerifyDepth:    introspection.SSLVerifyDepth,
+		TrustedCertSecret: introspection.TrustedCertSecret,
+		SNIName:           introspection.SNIName,
+	}
 }
 
-func (p *policiesCfg) addAccessControlConfig(accessControl *conf_v1.AccessControl) *validationResults {
+// GetExternalAuthForPolicy returns the external auth of the ExternalAuth or the Introspection policy, or nil for other policies.
+func GetExternalAuthForPolicy(pol *conf_v1.Policy) *conf_v1.ExternalAuth {
+	if pol.Spec.ExternalAuth != nil {
+		return pol.Spec.ExternalAuth
+	}
+	if pol.Spec.Introspection != nil {
+		return IntrospectionExternalAuth(pol.Spec.Introspection)
+	}
+	return nil
+}
+
+func (p *policiesCfg) addAccessControlConfig(accessControl *conf_v1.AccessControl, polKey string, ownerDetails policyOwnerDetails) *validationResults {
 	res := newValidationResults()
+	if accessControl.DryRun {
+		if p.DryRun.AccessControl != nil {
+			res.addWarningf("Multiple AccessControl policies in the dry run mode in the same context is not valid. AccessControl policy %s will be ignored", polKey)
+			return res
+		}
+		p.DryRun.AccessControl = generateAccessControlDryRunGeo(accessControl, polKey, ownerDetails)
+		return res
+	}
 	p.Allow = append(p.Allow, accessControl.Allow...)
 	p.Deny = append(p.Deny, accessControl.Deny...)
 	if len(p.Allow) > 0 && len(p.Deny) > 0 {
@@ -189,9 +278,14 @@ func (p *policiesCfg) addJWTAuthConfig(
 	jwtAuth *conf_v1.JWTAuth,
 	polKey string,
 	polNamespace string,
+	polName string,
 	secretRefs map[string]*secrets.SecretReference,
+	ownerDetails policyOwnerDetails,
 ) *validationResults {
 	res := newValidationResults()
+	if jwtAuth.DryRun {
+		return p.addJWTAuthDryRunConfig(jwtAuth, polKey, polNamespace, polName, secretRefs, ownerDetails)
+	}
 	if p.JWTAuth.Auth != nil {
 		res.addWarningf("Multiple jwt policies in the same context is not valid. JWT policy %s will be ignored", polKey)
 		return res
@@ -218,6 +312,7 @@ func (p *policiesCfg) addJWTAuthConfig(
 			Realm:  jwtAuth.Realm,
 			Token:  jwtAuth.Token,
 		}
+		p.addJWTRequireConfig(jwtAuth.Require, polNamespace, polName, ownerDetails)
 		return res
 	} else if jwtAuth.JwksURI != "" {
 		uri, _ := url.Parse(jwtAuth.JwksURI)
@@ -280,11 +375,92 @@ func (p *policiesCfg) addJWTAuthConfig(
 			KeyCache: jwtAuth.KeyCache,
 		}
 		p.JWTAuth.JWKSEnabled = true
+		p.addJWTRequireConfig(jwtAuth.Require, polNamespace, polName, ownerDetails)
+		return res
+	}
+	return res
+}
+
+// addJWTAuthDryRunConfig adds the check of the JWT policy in the dry run mode. The check is generated
+// like the JWT auth of an enforced policy, but it is done by the policy dry run server.
+func (p *policiesCfg) addJWTAuthDryRunConfig(
+	jwtAuth *conf_v1.JWTAuth,
+	polKey string,
+	polNamespace string,
+	polName string,
+	secretRefs map[string]*secrets.SecretReference,
+	ownerDetails policyOwnerDetails,
+) *validationResults {
+	enforced := *jwtAuth
+	enforced.DryRun = false
+
+	cfg := newPoliciesConfig(p.BundleValidator)
+	res := cfg.addJWTAuthConfig(&enforced, polKey, polNamespace, polName, secretRefs, ownerDetails)
+	if res.isError || cfg.JWTAuth.Auth == nil {
 		return res
 	}
+
+	p.JWTAuth.ClaimSets = append(p.JWTAuth.ClaimSets, cfg.JWTAuth.ClaimSets...)
+	p.JWTAuth.Maps = append(p.JWTAuth.Maps, cfg.JWTAuth.Maps...)
+	p.addPolicyDryRunAuth(res, &version2.PolicyDryRunAuth{JWTAuth: cfg.JWTAuth.Auth}, polKey, ownerDetails)
 	return res
 }
 
+// addJWTRequireConfig generates a claim variable and a map for every claim requirement of the JWT policy.
+// The map of a claim requirement evaluates to 1 when the claim matches and to 0 otherwise.
+// The elements of array claims are separated by commas in the claim variable.
+func (p *policiesCfg) addJWTRequireConfig(require *conf_v1.JWTRequire, polNamespace string, polName string, ownerDetails policyOwnerDetails) {
+	if require == nil {
+		return
+	}
+
+	code := 403
+	if require.Code != nil {
+		code = *require.Code
+	}
+	p.JWTAuth.Auth.Require = &version2.JWTRequire{Code: code}
+
+	for i, claim := range require.Claims {
+		suffix := rfc1123ToSnake(fmt.Sprintf(
+			"%s_%s_%s_%s_%s_%d",
+			ownerDetails.parentNamespace,
+			ownerDetails.parentName,
+			ownerDetails.parentType,
+			polNamespace,
+			polName,
+			i,
+		))
+		claimVariable := fmt.Sprintf("$jwt_claim_%s", suffix)
+		requireVariable := fmt.Sprintf("$jwt_require_%s", suffix)
+
+		p.JWTAuth.ClaimSets = append(p.JWTAuth.ClaimSets, version2.AuthJWTClaimSet{
+			Variable: claimVariable,
+			Claim:    generateAuthJwtClaimSetClaim(claim.Name),
+		})
+
+		params := []version2.Parameter{{Value: "default", Result: "0"}}
+		if claim.Regex != "" {
+			params = append(params, version2.Parameter{
+				Value:  fmt.Sprintf("\"~%s\"", claim.Regex),
+				Result: "1",
+			})
+		}
+		for _, value := range claim.Values {
+			params = append(params, version2.Parameter{
+				Value:  fmt.Sprintf("\"~(^|,)%s(,|$)\"", regexp.QuoteMeta(value)),
+				Result: "1",
+			})
+		}
+
+		p.JWTAuth.Maps = append(p.JWTAuth.Maps, version2.Map{
+			Source:     claimVariable,
+			Variable:   requireVariable,
+			Parameters: params,
+		})
+		p.JWTAuth.Auth.Require.Variables = append(p.JWTAuth.Auth.Require.Variables, requireVariable)
+	}
+}
+
 func (p *policiesCfg) addExternalAuthConfig(
 	externalAuth *conf_v1.ExternalAuth,
 	polKey string,
@@ -325,6 +501,9 @@ func (p *policiesCfg) addExternalAuthConfig(
 	if externalAuth.AuthSnippets != "" {
 		p.ExternalAuth.Snippets = externalAuth.AuthSnippets
 	}
+	if externalAuth.GRPC != nil {
+		p.addExternalAuthGRPCConfig(externalAuth.GRPC, polNamespace, polName, ownerDetails)
+	}
 
 	// Handle SSL verification for external auth
 	if externalAuth.SSLEnabled && externalAuth.SSLVerify {
@@ -339,6 +518,139 @@ func (p *policiesCfg) addExternalAuthConfig(
 	return res
 }
 
+// addExternalAuthGRPCConfig configures the gRPC mode of the external auth. The requests are validated by the njs handler,
+// which sends the check requests to the external authorization server through the external auth location
+// and returns the allowed headers of the check responses.
+func (p *policiesCfg) addExternalAuthGRPCConfig(grpc *conf_v1.ExternalAuthGRPC, polNamespace string, polName string, ownerDetails policyOwnerDetails) {
+	suffix := rfc1123ToSnake(fmt.Sprintf(
+		"%s_%s_%s_%s_%s",
+		ownerDetails.parentNamespace,
+		ownerDetails.parentName,
+		ownerDetails.parentType,
+		polNamespace,
+		polName,
+	))
+
+	p.ExternalAuth.URI.InternalPath = fmt.Sprintf("/_external_authz_grpc_%s", suffix)
+	p.ExternalAuth.GRPC = &version2.ExternalAuthGRPC{
+		Path:         fmt.Sprintf("/_external_authz_%s", suffix),
+		EndpointPath: p.ExternalAuth.URI.InternalPath,
+		Headers:      strings.Join(grpc.AllowedUpstreamHeaders, " "),
+	}
+	p.ExternalAuth.AuthRequestPath = p.ExternalAuth.GRPC.Path
+
+	for i, h := range grpc.AllowedUpstreamHeaders {
+		p.ExternalAuth.AuthRequestHeaders = append(p.ExternalAuth.AuthRequestHeaders, version2.AuthRequestHeader{
+			Name:     h,
+			Variable: fmt.Sprintf("$ext_authz_header_%s_%d", suffix, i),
+			Value:    fmt.Sprintf("$sent_http_x_ext_authz_header_%d", i),
+		})
+	}
+}
+
+// addIntrospectionConfig configures the introspection policy as an external auth whose requests are validated by njs.
+// The njs handler sends the bearer token to the introspection endpoint through the external auth location
+// and caches the responses for the active tokens in a keyval zone.
+func (p *policiesCfg) addIntrospectionConfig(
+	introspection *conf_v1.Introspection,
+	polKey string,
+	polNamespace string,
+	polName string,
+	secretRefs map[string]*secrets.SecretReference,
+	policyOpts policyOptions,
+	ownerDetails policyOwnerDetails,
+) *validationResults {
+	res := newValidationResults()
+	if p.ExternalAuth != nil {
+		res.addWarningf("Multiple external auth or introspection policies in the same context is not valid. Introspection policy %s will be ignored", polKey)
+		return res
+	}
+
+	secretKey := fmt.Sprintf("%v/%v", polNamespace, introspection.ClientSecret)
+	secretRef := secretRefs[secretKey]
+	if secretRef == nil {
+		res.addWarningf("Introspection policy %s references a non-existent client secret %s", polKey, secretKey)
+		res.isError = true
+		return res
+	}
+	var secretType api_v1.SecretType
+	if secretRef.Secret != nil {
+		secretType = secretRef.Secret.Type
+	}
+	if secretType != "" && secretType != secrets.SecretTypeOIDC {
+		res.addWarningf("Introspection policy %s references a secret %s of a wrong type '%s', must be '%s'", polKey, secretKey, secretType, secrets.SecretTypeOIDC)
+		res.isError = true
+		return res
+	} else if secretRef.Error != nil {
+		res.addWarningf("Introspection policy %s references an invalid secret %s: %v", polKey, secretKey, secretRef.Error)
+		res.isError = true
+		return res
+	}
+
+	exAuthRes := p.addExternalAuthConfig(IntrospectionExternalAuth(introspection), polKey, polNamespace, polName, secretRefs, policyOpts, ownerDetails)
+	res.warnings = append(res.warnings, exAuthRes.warnings...)
+	if exAuthRes.isError {
+		res.isError = true
+		return res
+	}
+
+	suffix := rfc1123ToSnake(fmt.Sprintf(
+		"%s_%s_%s_%s_%s",
+		ownerDetails.parentNamespace,
+		ownerDetails.parentName,
+		ownerDetails.parentType,
+		polNamespace,
+		polName,
+	))
+	zoneName := fmt.Sprintf("introspection_%s", suffix)
+	cacheVariable := fmt.Sprintf("introspection_cache_%s", suffix)
+
+	cacheTimeout := "1m"
+	if introspection.CacheTimeout != "" {
+		cacheTimeout = introspection.CacheTimeout
+	}
+	cacheZoneSize := "1m"
+	if introspection.CacheZoneSize != "" {
+		cacheZoneSize = introspection.CacheZoneSize
+	}
+
+	clientAuth := fmt.Sprintf("%s:%s", introspection.ClientID, secretRef.Secret.Data[ClientSecretKey])
+
+	// The external auth location becomes the introspection endpoint location, and auth_request is sent to the njs handler.
+	p.ExternalAuth.URI.InternalPath = fmt.Sprintf("/_introspection_endpoint_%s", suffix)
+	p.ExternalAuth.Introspection = &version2.Introspection{
+		Path:          fmt.Sprintf("/_introspection_%s", suffix),
+		EndpointPath:  p.ExternalAuth.URI.InternalPath,
+		ClientAuth:    base64.StdEncoding.EncodeToString([]byte(clientAuth)),
+		CacheVariable: cacheVariable,
+		CacheZone: version2.KeyValZone{
+			Name:    zoneName,
+			Size:    cacheZoneSize,
+			Timeout: cacheTimeout,
+		},
+		CacheKeyVal: version2.KeyVal{
+			Key:      "$introspection_token_hash",
+			Variable: "$" + cacheVariable,
+			ZoneName: zoneName,
+		},
+	}
+
+	p.ExternalAuth.AuthRequestPath = p.ExternalAuth.Introspection.Path
+
+	var claims []string
+	for i, h := range introspection.Headers {
+		claims = append(claims, h.Claim)
+		p.ExternalAuth.AuthRequestHeaders = append(p.ExternalAuth.AuthRequestHeaders, version2.AuthRequestHeader{
+			Name:     h.Name,
+			Variable: fmt.Sprintf("$introspection_claim_%s_%d", suffix, i),
+			Value:    fmt.Sprintf("$sent_http_x_introspection_claim_%d", i),
+		})
+	}
+	p.ExternalAuth.Introspection.Claims = strings.Join(claims, " ")
+
+	return res
+}
+
 // configureExternalAuthSSL configures SSL verification settings for external auth.
 func (p *policiesCfg) configureExternalAuthSSL(
 	externalAuth *conf_v1.ExternalAuth,
@@ -403,10 +715,11 @@ func (p *policiesCfg) addBasicAuthConfig(
 	basicAuth *conf_v1.BasicAuth,
 	polKey string,
 	polNamespace string,
+	ownerDetails policyOwnerDetails,
 	secretRefs map[string]*secrets.SecretReference,
 ) *validationResults {
 	res := newValidationResults()
-	if p.BasicAuth != nil {
+	if p.BasicAuth != nil && !basicAuth.DryRun {
 		res.addWarningf("Multiple basic auth policies in the same context is not valid. Basic auth policy %s will be ignored", polKey)
 		return res
 	}
@@ -427,10 +740,16 @@ func (p *policiesCfg) addBasicAuthConfig(
 		return res
 	}
 
-	p.BasicAuth = &version2.BasicAuth{
+	auth := &version2.BasicAuth{
 		Secret: secretRef.Path,
 		Realm:  basicAuth.Realm,
 	}
+	if basicAuth.DryRun {
+		p.addPolicyDryRunAuth(res, &version2.PolicyDryRunAuth{BasicAuth: auth}, polKey, ownerDetails)
+		return res
+	}
+
+	p.BasicAuth = auth
 	return res
 }
 
@@ -615,6 +934,8 @@ func (p *policiesCfg) addOIDCConfig(
 	oidc *conf_v1.OIDC,
 	polKey string,
 	polNamespace string,
+	polName string,
+	ownerDetails policyOwnerDetails,
 	policyOpts policyOptions,
 ) *validationResults {
 	secretRefs := policyOpts.secretRefs
@@ -628,19 +949,10 @@ func (p *policiesCfg) addOIDCConfig(
 	}
 
 	var policy *version2.OIDC
-	if policyOpts.oidcPolicyName != "" {
-		if policyOpts.oidcPolicyName != polKey {
-			res.addWarningf(
-				"Only one oidc policy is allowed in a VirtualServer and its VirtualServerRoutes. Can't use %s. Use %s",
-				polKey,
-				policyOpts.oidcPolicyName,
-			)
-			res.isError = true
-			return res
-		}
+	if provider, exists := policyOpts.oidcProviders[polKey]; exists {
 		// Same policy seen again on a subsequent route: reuse the already-built config so that
-		// location.OIDC is set to true for every route that references the policy.
-		p.OIDC = policyOpts.oidcConfig
+		// location.OIDC is set for every route that references the policy.
+		p.OIDC = provider.Config
 		return res
 	} else {
 		secretKey := fmt.Sprintf("%v/%v", polNamespace, oidc.ClientSecret)
@@ -677,6 +989,36 @@ func (p *policiesCfg) addOIDCConfig(
 		if redirectURI == "" {
 			redirectURI = "/_codexch"
 		}
+		logoutURI := oidc.LogoutURI
+		if logoutURI == "" {
+			logoutURI = "/logout"
+		}
+		sessionCookie := oidc.SessionCookie
+		if sessionCookie == "" {
+			sessionCookie = "auth_token"
+		}
+		for _, key := range slices.Sorted(maps.Keys(policyOpts.oidcProviders)) {
+			other := policyOpts.oidcProviders[key].Config
+			var conflict string
+			switch {
+			case other.RedirectURI == redirectURI:
+				conflict = fmt.Sprintf("redirect URI %s", redirectURI)
+			case other.LogoutURI == logoutURI:
+				conflict = fmt.Sprintf("logout URI %s", logoutURI)
+			case other.SessionCookie == sessionCookie:
+				conflict = fmt.Sprintf("session cookie %s", sessionCookie)
+			default:
+				continue
+			}
+			res.addWarningf(
+				"OIDC policy %s uses the same %s as OIDC policy %s. OIDC policies of a VirtualServer and its VirtualServerRoutes must use different redirect URIs, logout URIs and session cookies",
+				polKey,
+				conflict,
+				key,
+			)
+			res.isError = true
+			return res
+		}
 		postLogoutRedirectURI := oidc.PostLogoutRedirectURI
 		if postLogoutRedirectURI == "" {
 			postLogoutRedirectURI = "/_logout"
@@ -738,7 +1080,9 @@ func (p *policiesCfg) addOIDCConfig(
 			ClientSecret:          string(clientSecret),
 			Scope:                 scope,
 			RedirectURI:           redirectURI,
+			LogoutURI:             logoutURI,
 			PostLogoutRedirectURI: postLogoutRedirectURI,
+			SessionCookie:         sessionCookie,
 			ZoneSyncLeeway:        generateIntFromPointer(oidc.ZoneSyncLeeway, 200),
 			AccessTokenEnable:     oidc.AccessTokenEnable,
 			PKCEEnable:            oidc.PKCEEnable,
@@ -746,14 +1090,72 @@ func (p *policiesCfg) addOIDCConfig(
 			VerifyDepth:           sslVerifyDepth,
 			CAFile:                trustedCertPath,
 			PolicyName:            polKey,
+			ID:                    rfc1123ToSnake(fmt.Sprintf("%s_%s", polNamespace, polName)),
 		}
 	}
 
+	provider := &oidcProvider{Config: policy}
+	provider.addClaims(oidc, polNamespace, polName, ownerDetails)
+	if policyOpts.oidcProviders != nil {
+		policyOpts.oidcProviders[polKey] = provider
+	}
 	p.OIDC = policy
 
 	return res
 }
 
+// addClaims configures the claim headers and the group restriction of the OIDC provider.
+// The claims are read from the ID token of the session.
+func (o *oidcProvider) addClaims(oidc *conf_v1.OIDC, polNamespace string, polName string, ownerDetails policyOwnerDetails) {
+	claimVariable := func(i int) string {
+		return fmt.Sprintf("$oidc_claim_%s", rfc1123ToSnake(fmt.Sprintf(
+			"%s_%s_%s_%s_%s_%d",
+			ownerDetails.parentNamespace,
+			ownerDetails.parentName,
+			ownerDetails.parentType,
+			polNamespace,
+			polName,
+			i,
+		)))
+	}
+
+	for _, ch := range oidc.ClaimHeaders {
+		variable := claimVariable(len(o.ClaimSets))
+		o.ClaimSets = append(o.ClaimSets, version2.AuthJWTClaimSet{
+			Variable: variable,
+			Claim:    generateAuthJwtClaimSetClaim(ch.Claim),
+		})
+		o.Config.ClaimHeaders = append(o.Config.ClaimHeaders, version2.Header{Name: ch.Header, Value: variable})
+	}
+
+	if oidc.Groups == nil {
+		return
+	}
+	groupsClaim := oidc.Groups.Claim
+	if groupsClaim == "" {
+		groupsClaim = "groups"
+	}
+	variable := claimVariable(len(o.ClaimSets))
+	o.ClaimSets = append(o.ClaimSets, version2.AuthJWTClaimSet{
+		Variable: variable,
+		Claim:    generateAuthJwtClaimSetClaim(groupsClaim),
+	})
+	groupsVariable := fmt.Sprintf("$oidc_groups_%s", strings.TrimPrefix(variable, "$oidc_claim_"))
+	params := []version2.Parameter{{Value: "default", Result: "0"}}
+	for _, group := range oidc.Groups.Allow {
+		params = append(params, version2.Parameter{
+			Value:  fmt.Sprintf("\"~(^|,)%s(,|$)\"", regexp.QuoteMeta(group)),
+			Result: "1",
+		})
+	}
+	o.Maps = append(o.Maps, version2.Map{
+		Source:     variable,
+		Variable:   groupsVariable,
+		Parameters: params,
+	})
+	o.Config.Require = &version2.JWTRequire{Variables: []string{groupsVariable}, Code: 403}
+}
+
 func (p *policiesCfg) addAPIKeyConfig(
 	apiKey *conf_v1.APIKey,
 	polKey string,
@@ -787,7 +1189,13 @@ func (p *policiesCfg) addAPIKeyConfig(
 		return res
 	}
 
-	p.APIKey.Clients = generateAPIKeyClients(secretRef.Secret.Data)
+	clients, err := generateAPIKeyClients(secretRef.Secret.Data)
+	if err != nil {
+		res.addWarningf("API Key %s references an invalid secret %s: %v", polKey, secretKey, err)
+		res.isError = true
+		return res
+	}
+	p.APIKey.Clients = clients
 
 	mapName := fmt.Sprintf(
 		"apikey_auth_client_name_%s_%s_%s_%s",
@@ -797,14 +1205,83 @@ func (p *policiesCfg) addAPIKeyConfig(
 		strings.Split(rfc1123ToSnake(polKey), "/")[1],
 	)
 	p.APIKey.Key = &version2.APIKey{
-		Header:  apiKey.SuppliedIn.Header,
-		Query:   apiKey.SuppliedIn.Query,
-		MapName: mapName,
+		Header:         apiKey.SuppliedIn.Header,
+		Query:          apiKey.SuppliedIn.Query,
+		MapName:        mapName,
+		ClientIDHeader: apiKey.ClientIDHeader,
+	}
+	if apiKey.DryRun {
+		p.APIKey.Key.DryRunPolicy = polKey
 	}
 	p.APIKey.Enabled = true
 	return res
 }
 
+const (
+	defaultHMACSignatureHeader = "X-Signature"
+	defaultHMACTimestampHeader = "X-Timestamp"
+	defaultHMACCanonicalString = "{timestamp}.{body}"
+	defaultHMACClockSkew       = 5 * time.Minute
+	defaultHMACMaxBodySize     = "1m"
+	defaultHMACRejectCode      = 401
+)
+
+func (p *policiesCfg) addHMACConfig(
+	hmac *conf_v1.HMAC,
+	polKey string,
+	polNamespace string,
+	secretRefs map[string]*secrets.SecretReference,
+) *validationResults {
+	res := newValidationResults()
+	if p.HMAC != nil {
+		res.addWarningf(
+			"Multiple HMAC policies in the same context is not valid. HMAC policy %s will be ignored",
+			polKey,
+		)
+		res.isError = true
+		return res
+	}
+
+	secretKey := fmt.Sprintf("%v/%v", polNamespace, hmac.Secret)
+	secretRef := secretRefs[secretKey]
+	var secretType api_v1.SecretType
+	if secretRef.Secret != nil {
+		secretType = secretRef.Secret.Type
+	}
+	if secretType != "" && secretType != secrets.SecretTypeHMAC {
+		res.addWarningf("HMAC policy %s references a secret %s of a wrong type '%s', must be '%s'", polKey, secretKey, secretType, secrets.SecretTypeHMAC)
+		res.isError = true
+		return res
+	} else if secretRef.Error != nil {
+		res.addWarningf("HMAC policy %s references an invalid secret %s: %v", polKey, secretKey, secretRef.Error)
+		res.isError = true
+		return res
+	}
+
+	clockSkew := defaultHMACClockSkew
+	if hmac.ClockSkew != "" {
+		d, err := ParseTimeDuration(hmac.ClockSkew)
+		if err != nil {
+			res.addWarningf("HMAC policy %s has an invalid clock skew %s: %v", polKey, hmac.ClockSkew, err)
+			res.isError = true
+			return res
+		}
+		clockSkew = d
+	}
+
+	p.HMAC = &version2.HMAC{
+		KeysFile:        secretRef.Path,
+		KeyIDHeader:     hmac.KeyIDHeader,
+		SignatureHeader: generateString(hmac.SignatureHeader, defaultHMACSignatureHeader),
+		TimestampHeader: generateString(hmac.TimestampHeader, defaultHMACTimestampHeader),
+		CanonicalString: generateString(hmac.CanonicalString, defaultHMACCanonicalString),
+		ClockSkew:       int64(clockSkew.Seconds()),
+		MaxBodySize:     generateString(hmac.MaxBodySize, defaultHMACMaxBodySize),
+		RejectCode:      generateIntFromPointer(hmac.RejectCode, defaultHMACRejectCode),
+	}
+	return res
+}
+
 // nolint:gocyclo
 func (p *policiesCfg) addWAFConfig(
 	ctx context.Context,
@@ -1132,6 +1609,317 @@ func (p *policiesCfg) addCORSConfig(
 	return res
 }
 
+const defaultGeoRejectCode = 403
+
+func (p *policiesCfg) addGeoConfig(
+	geo *conf_v1.Geo,
+	polKey string,
+	ownerDetails policyOwnerDetails,
+) *validationResults {
+	res := newValidationResults()
+	if p.Geo != nil {
+		res.addWarningf(
+			"Multiple geo policies in the same context is not valid. Geo policy %s will be ignored",
+			polKey,
+		)
+		return res
+	}
+
+	rejectCode := defaultGeoRejectCode
+	if geo.RejectCode != nil {
+		rejectCode = *geo.RejectCode
+	}
+
+	p.GeoMap = generateGeoMap(geo, generateGeoVariableName(polKey, ownerDetails))
+	p.Geo = &version2.GeoRestriction{
+		Variable:   p.GeoMap.Variable,
+		RejectCode: rejectCode,
+	}
+	return res
+}
+
+func generateGeoVariableName(polKey string, ownerDetails policyOwnerDetails) string {
+	polNamespace, polName, _ := strings.Cut(polKey, "/")
+	return rfc1123ToSnake(fmt.Sprintf(
+		"geo_policy_%s_%s_%s_%s_%s",
+		ownerDetails.parentNamespace,
+		ownerDetails.parentName,
+		ownerDetails.parentType,
+		polNamespace,
+		polName,
+	))
+}
+
+// generateGeoMap generates the map that sets the variable to 1 for the requests that the geo policy rejects.
+// The source of the map is "<country code>:<continent code>:<autonomous system number>" of the client IP address.
+func generateGeoMap(geo *conf_v1.Geo, variableName string) *version2.Map {
+	match, matched, unmatched := geo.Deny, `"1"`, `""`
+	if geo.Allow != nil {
+		match, matched, unmatched = geo.Allow, `""`, `"1"`
+	}
+
+	params := []version2.Parameter{{Value: "default", Result: unmatched}}
+	if len(match.Countries) > 0 {
+		params = append(params, version2.Parameter{
+			Value:  fmt.Sprintf(`"~^(%s):"`, strings.Join(match.Countries, "|")),
+			Result: matched,
+		})
+	}
+	if len(match.Continents) > 0 {
+		params = append(params, version2.Parameter{
+			Value:  fmt.Sprintf(`"~^[^:]*:(%s):"`, strings.Join(match.Continents, "|")),
+			Result: matched,
+		})
+	}
+	if len(match.ASNs) > 0 {
+		asns := make([]string, 0, len(match.ASNs))
+		for _, asn := range match.ASNs {
+			asns = append(asns, strconv.Itoa(asn))
+		}
+		params = append(params, version2.Parameter{
+			Value:  fmt.Sprintf(`"~:(%s)$"`, strings.Join(asns, "|")),
+			Result: matched,
+		})
+	}
+
+	return &version2.Map{
+		Source:     `"$geoip2_country_code:$geoip2_continent_code:$geoip2_asn"`,
+		Variable:   fmt.Sprintf("$%s", variableName),
+		Parameters: params,
+	}
+}
+
+func (p *policiesCfg) addTracingConfig(
+	tracing *conf_v1.Tracing,
+	polKey string,
+	ownerDetails policyOwnerDetails,
+	otelEnabled bool,
+) *validationResults {
+	res := newValidationResults()
+	if p.Tracing != nil {
+		res.addWarningf(
+			"Multiple tracing policies in the same context is not valid. Tracing policy %s will be ignored",
+			polKey,
+		)
+		return res
+	}
+	if !otelEnabled {
+		res.addWarningf(
+			"Tracing policy %s requires the OpenTelemetry exporter configured with the otel-exporter-endpoint ConfigMap key. The policy will be ignored",
+			polKey,
+		)
+		return res
+	}
+
+	trace := "on"
+	ratio, _ := strconv.ParseFloat(strings.TrimSuffix(tracing.SampleRatio, "%"), 64)
+	switch {
+	case tracing.SampleRatio == "" || ratio >= 100:
+	case ratio == 0:
+		trace = "off"
+	default:
+		polNamespace, polName, _ := strings.Cut(polKey, "/")
+		p.TracingSampler = &version2.SplitClient{
+			Source: "$otel_trace_id",
+			Variable: fmt.Sprintf("$%s", rfc1123ToSnake(fmt.Sprintf(
+				"tracing_policy_%s_%s_%s_%s_%s",
+				ownerDetails.parentNamespace,
+				ownerDetails.parentName,
+				ownerDetails.parentType,
+				polNamespace,
+				polName,
+			))),
+			Distributions: []version2.Distribution{
+				{Weight: tracing.SampleRatio, Value: "on"},
+				{Weight: "*", Value: "off"},
+			},
+		}
+		trace = p.TracingSampler.Variable
+	}
+
+	traceContext := "propagate"
+	switch tracing.Propagation {
+	case "b3":
+		traceContext = "ignore"
+	case "extract":
+		traceContext = "extract"
+	}
+
+	var attrs []version2.SpanAttribute
+	for _, attr := range tracing.SpanAttributes {
+		attrs = append(attrs, version2.SpanAttribute{Name: attr.Name, Value: attr.Value})
+	}
+
+	p.Tracing = &version2.Tracing{
+		Trace:          trace,
+		Context:        traceContext,
+		SpanName:       tracing.SpanName,
+		SpanAttributes: attrs,
+		B3:             tracing.Propagation == "b3",
+	}
+	return res
+}
+
+// addHeadersConfig adds the header modifications of the headers policy. The modifications of a later policy
+// replace the modifications of the same headers by the earlier policies.
+func (p *policiesCfg) addHeadersConfig(
+	headers *conf_v1.Headers,
+	polNamespace string,
+	polName string,
+	ownerDetails policyOwnerDetails,
+) *validationResults {
+	res := newValidationResults()
+
+	varPrefix := rfc1123ToSnake(fmt.Sprintf(
+		"headers_%s_%s_%s_%s_%s",
+		ownerDetails.parentNamespace,
+		ownerDetails.parentName,
+		ownerDetails.parentType,
+		polNamespace,
+		polName,
+	))
+
+	cfg := &headersPolicy{}
+	if r := headers.Request; r != nil {
+		for _, h := range r.Set {
+			cfg.Request = append(cfg.Request, headerModifier{Name: h.Name, SetHeader: &version2.Header{Name: h.Name, Value: h.Value}})
+		}
+		for i, h := range r.Add {
+			// the value is appended to the value of the request header, if the header is present
+			variable := fmt.Sprintf("$%s_request_%d", varPrefix, i)
+			source := "$http_" + rfc1123ToSnake(strings.ToLower(h.Name))
+			cfg.Maps = append(cfg.Maps, version2.Map{
+				Source:   source,
+				Variable: variable,
+				Parameters: []version2.Parameter{
+					{Value: `""`, Result: fmt.Sprintf("%q", h.Value)},
+					{Value: "default", Result: fmt.Sprintf("%q", source+", "+h.Value)},
+				},
+			})
+			cfg.Request = append(cfg.Request, headerModifier{Name: h.Name, SetHeader: &version2.Header{Name: h.Name, Value: variable}})
+		}
+		for _, name := range r.Remove {
+			cfg.Request = append(cfg.Request, headerModifier{Name: name, SetHeader: &version2.Header{Name: name, Value: ""}})
+		}
+	}
+
+	if r := headers.Response; r != nil {
+		for i, h := range r.Set {
+			// the header of the upstream response is kept for the responses with other status codes
+			upstreamValue := fmt.Sprintf("%q", "$upstream_http_"+rfc1123ToSnake(strings.ToLower(h.Name)))
+			m := cfg.addResponseHeader(h, fmt.Sprintf("$%s_response_set_%d", varPrefix, i), upstreamValue)
+			m.Hide = true
+			cfg.Response = append(cfg.Response, m)
+		}
+		for i, h := range r.Add {
+			cfg.Response = append(cfg.Response, cfg.addResponseHeader(h, fmt.Sprintf("$%s_response_add_%d", varPrefix, i), `""`))
+		}
+		for _, name := range r.Remove {
+			cfg.Response = append(cfg.Response, headerModifier{Name: name, Hide: true})
+		}
+	}
+
+	p.Headers = mergeHeadersPolicies(p.Headers, cfg)
+
+	return res
+}
+
+// addResponseHeader returns the modifier that adds the response header. The header with status codes is added with
+// the map of the status codes to the value of the header, in which the default value is used for other status codes.
+func (h *headersPolicy) addResponseHeader(header conf_v1.ResponseHeader, variable string, defaultValue string) headerModifier {
+	if len(header.Codes) == 0 {
+		return headerModifier{
+			Name: header.Name,
+			AddHeader: &version2.AddHeader{
+				Header: version2.Header{Name: header.Name, Value: header.Value},
+				Always: header.Always,
+			},
+		}
+	}
+
+	var params []version2.Parameter
+	for _, code := range header.Codes {
+		value := code
+		if strings.HasSuffix(code, "xx") {
+			value = "~^" + strings.TrimSuffix(code, "xx")
+		}
+		params = append(params, version2.Parameter{Value: value, Result: fmt.Sprintf("%q", header.Value)})
+	}
+	params = append(params, version2.Parameter{Value: "default", Result: defaultValue})
+	h.Maps = append(h.Maps, version2.Map{
+		Source:     "$status",
+		Variable:   variable,
+		Parameters: params,
+	})
+
+	return headerModifier{
+		Name: header.Name,
+		AddHeader: &version2.AddHeader{
+			Header: version2.Header{Name: header.Name, Value: variable},
+			Always: true,
+		},
+	}
+}
+
+// mergeHeadersPolicies returns the header modifications of the base followed by the overrides.
+// The overrides replace the modifications of the same headers by the base.
+func mergeHeadersPolicies(base *headersPolicy, overrides *headersPolicy) *headersPolicy {
+	if base == nil {
+		return overrides
+	}
+	if overrides == nil {
+		return base
+	}
+
+	return &headersPolicy{
+		Request:  mergeHeaderModifiers(base.Request, overrides.Request),
+		Response: mergeHeaderModifiers(base.Response, overrides.Response),
+		Maps:     append(slices.Clone(base.Maps), overrides.Maps...),
+	}
+}
+
+func mergeHeaderModifiers(base []headerModifier, overrides []headerModifier) []headerModifier {
+	overridden := make(map[string]bool)
+	for _, m := range overrides {
+		overridden[strings.ToLower(m.Name)] = true
+	}
+
+	var result []headerModifier
+	for _, m := range base {
+		if !overridden[strings.ToLower(m.Name)] {
+			result = append(result, m)
+		}
+	}
+
+	return append(result, overrides...)
+}
+
+// withDefaultPolicies returns the default policies followed by the policies of the owner. The default policies
+// the owner references itself are left out, so that every policy is applied once.
+func withDefaultPolicies(defaultPolicies []conf_v1.PolicyReference, policies []conf_v1.PolicyReference, ownerNamespace string) []conf_v1.PolicyReference {
+	if len(defaultPolicies) == 0 {
+		return policies
+	}
+
+	referenced := make(map[string]bool)
+	for _, p := range policies {
+		namespace := p.Namespace
+		if namespace == "" {
+			namespace = ownerNamespace
+		}
+		referenced[fmt.Sprintf("%s/%s", namespace, p.Name)] = true
+	}
+
+	var result []conf_v1.PolicyReference
+	for _, p := range defaultPolicies {
+		if !referenced[fmt.Sprintf("%s/%s", p.Namespace, p.Name)] {
+			result = append(result, p)
+		}
+	}
+
+	return append(result, policies...)
+}
+
 // nolint:gocyclo
 func generatePolicies(
 	ctx context.Context,
@@ -1169,7 +1957,7 @@ func generatePolicies(
 			var res *validationResults
 			switch {
 			case pol.Spec.AccessControl != nil:
-				res = config.addAccessControlConfig(pol.Spec.AccessControl)
+				res = config.addAccessControlConfig(pol.Spec.AccessControl, key, ownerDetails)
 			case pol.Spec.RateLimit != nil:
 				res = config.addRateLimitConfig(
 					pol,
@@ -1180,11 +1968,13 @@ func generatePolicies(
 					path,
 				)
 			case pol.Spec.JWTAuth != nil:
-				res = config.addJWTAuthConfig(pol.Spec.JWTAuth, key, polNamespace, policyOpts.secretRefs)
+				res = config.addJWTAuthConfig(pol.Spec.JWTAuth, key, polNamespace, p.Name, policyOpts.secretRefs, ownerDetails)
 			case pol.Spec.ExternalAuth != nil:
 				res = config.addExternalAuthConfig(pol.Spec.ExternalAuth, key, polNamespace, p.Name, policyOpts.secretRefs, policyOpts, ownerDetails)
+			case pol.Spec.Introspection != nil:
+				res = config.addIntrospectionConfig(pol.Spec.Introspection, key, polNamespace, p.Name, policyOpts.secretRefs, policyOpts, ownerDetails)
 			case pol.Spec.BasicAuth != nil:
-				res = config.addBasicAuthConfig(pol.Spec.BasicAuth, key, polNamespace, policyOpts.secretRefs)
+				res = config.addBasicAuthConfig(pol.Spec.BasicAuth, key, polNamespace, ownerDetails, policyOpts.secretRefs)
 			case pol.Spec.IngressMTLS != nil:
 				res = config.addIngressMTLSConfig(
 					pol.Spec.IngressMTLS,
@@ -1197,15 +1987,27 @@ func generatePolicies(
 			case pol.Spec.EgressMTLS != nil:
 				res = config.addEgressMTLSConfig(pol.Spec.EgressMTLS, key, polNamespace, policyOpts.secretRefs)
 			case pol.Spec.OIDC != nil:
-				res = config.addOIDCConfig(pol.Spec.OIDC, key, polNamespace, policyOpts)
+				res = config.addOIDCConfig(pol.Spec.OIDC, key, polNamespace, p.Name, ownerDetails, policyOpts)
 			case pol.Spec.APIKey != nil:
 				res = config.addAPIKeyConfig(pol.Spec.APIKey, key, polNamespace, ownerDetails, policyOpts.secretRefs)
+			case pol.Spec.HMAC != nil:
+				res = config.addHMACConfig(pol.Spec.HMAC, key, polNamespace, policyOpts.secretRefs)
 			case pol.Spec.WAF != nil:
 				res = config.addWAFConfig(ctx, pol.Spec.WAF, key, polNamespace, policyOpts.apResources)
 			case pol.Spec.Cache != nil:
 				res = config.addCacheConfig(pol.Spec.Cache, key, ownerDetails)
 			case pol.Spec.CORS != nil:
 				res = config.addCORSConfig(pol.Spec.CORS, key, ownerDetails)
+			case pol.Spec.Headers != nil:
+				res = config.addHeadersConfig(pol.Spec.Headers, polNamespace, p.Name, ownerDetails)
+			case pol.Spec.Geo != nil:
+				res = config.addGeoConfig(pol.Spec.Geo, key, ownerDetails)
+			case pol.Spec.Tracing != nil:
+				res = config.addTracingConfig(pol.Spec.Tracing, key, ownerDetails, policyOpts.otelEnabled)
+			case pol.Spec.ConnectionLimit != nil:
+				res = newValidationResults()
+				res.addWarningf("ConnectionLimit policy %s is only supported on TransportServer resources", key)
+				res.isError = true
 			default:
 				res = newValidationResults()
 			}
@@ -1225,6 +2027,12 @@ func generatePolicies(
 		}
 	}
 
+	// The auth of the dry run policies is checked in an auth subrequest, and a location can have only one auth subrequest.
+	if config.DryRun.Auth != nil && (config.ExternalAuth != nil || config.APIKey.Key != nil) {
+		warnings.AddWarningf(ownerDetails.owner, "Policy %s in the dry run mode cannot be combined with external auth or API key policies in the same context and will be ignored", config.DryRun.Auth.Policy)
+		config.DryRun.Auth = nil
+	}
+
 	if len(config.RateLimit.PolicyGroupMaps) > 0 {
 		for _, v := range generateLRZGroupMaps(config.RateLimit.Zones) {
 			if hasDuplicateMapDefaults(v) {
@@ -1240,16 +2048,89 @@ func generatePolicies(
 	return *config, warnings
 }
 
-func generateAPIKeyClients(secretData map[string][]byte) []apiKeyClient {
-	var clients []apiKeyClient
-	for clientID, apiKey := range secretData {
+// addPolicyDryRunAuth adds the check of a JWT or a basic auth policy in the dry run mode.
+// Only one such policy is checked in a context.
+func (p *policiesCfg) addPolicyDryRunAuth(res *validationResults, auth *version2.PolicyDryRunAuth, polKey string, ownerDetails policyOwnerDetails) {
+	if p.DryRun.Auth != nil {
+		res.addWarningf("Multiple JWT or basic auth policies in the dry run mode in the same context is not valid. Policy %s will be ignored", polKey)
+		return
+	}
+
+	name := generatePolicyDryRunName(polKey, ownerDetails)
+	auth.Policy = polKey
+	auth.Path = fmt.Sprintf("/_%s", name)
+	auth.ServerName = name
+	p.DryRun.Auth = auth
+}
 
-		h := sha256.New()
-		h.Write(apiKey)
-		sha256Hash := hex.EncodeToString(h.Sum(nil))
-		clients = append(clients, apiKeyClient{ClientID: clientID, HashedKey: sha256Hash}) //
+// generateAccessControlDryRunGeo returns the geo block that sets the variable to the key of the access control policy
+// for the client IP addresses that the policy would reject.
+func generateAccessControlDryRunGeo(accessControl *conf_v1.AccessControl, polKey string, ownerDetails policyOwnerDetails) *version2.Geo {
+	rejected := fmt.Sprintf("%q", polKey)
+	geo := &version2.Geo{
+		Variable: fmt.Sprintf("$%s", generatePolicyDryRunName(polKey, ownerDetails)),
+	}
+
+	if accessControl.Allow != nil {
+		geo.Parameters = append(geo.Parameters, version2.Parameter{Value: "default", Result: rejected})
+		for _, a := range accessControl.Allow {
+			geo.Parameters = append(geo.Parameters, version2.Parameter{Value: a, Result: `""`})
+		}
+		return geo
+	}
+
+	geo.Parameters = append(geo.Parameters, version2.Parameter{Value: "default", Result: `""`})
+	for _, d := range accessControl.Deny {
+		geo.Parameters = append(geo.Parameters, version2.Parameter{Value: d, Result: rejected})
+	}
+	return geo
+}
+
+func generatePolicyDryRunName(polKey string, ownerDetails policyOwnerDetails) string {
+	polNamespace, polName, _ := strings.Cut(polKey, "/")
+	return rfc1123ToSnake(fmt.Sprintf(
+		"policy_dry_run_%s_%s_%s_%s_%s",
+		ownerDetails.parentNamespace,
+		ownerDetails.parentName,
+		ownerDetails.parentType,
+		polNamespace,
+		polName,
+	))
+}
+
+// generatePolicyDryRun returns the reporting of the requests that the policies in the dry run mode would reject,
+// or nil if there are no policies in the dry run mode.
+func generatePolicyDryRun(cfg policiesCfg) *version2.PolicyDryRun {
+	if cfg.DryRun.AccessControl == nil && cfg.DryRun.Auth == nil {
+		return nil
+	}
+
+	dryRun := &version2.PolicyDryRun{
+		Auth: cfg.DryRun.Auth,
+	}
+	if cfg.DryRun.AccessControl != nil {
+		dryRun.AccessControl = cfg.DryRun.AccessControl.Variable
+	}
+	return dryRun
+}
+
+func generateAPIKeyClients(secretData map[string][]byte) ([]apiKeyClient, error) {
+	secretClients, err := secrets.ParseAPIKeyClients(secretData)
+	if err != nil {
+		return nil, err
+	}
+
+	var clients []apiKeyClient
+	for _, c := range secretClients {
+		clients = append(clients, apiKeyClient{
+			ClientID:  c.ID,
+			HashedKey: c.HashedKey,
+			Name:      c.Name,
+			Expires:   c.Expires,
+			Routes:    c.Routes,
+		})
 	}
-	return clients
+	return clients, nil
 }
 
 func generateLimitReq(zoneName string, rateLimitPol *conf_v1.RateLimit) version2.LimitReq {
@@ -32,10 +32,10 @@ func TestGeneratePolicies(t *testing.T) {
 	mTLSCrlPath := "/etc/nginx/secrets/default-ingress-mtls-secret-ca.crl"
 	mTLSCertAndCrlPath := fmt.Sprintf("%s %s", mTLSCertPath, mTLSCrlPath)
 	policyOpts := policyOptions{
-		tls:            true,
-		zoneSync:       false,
-		replicas:       2,
-		oidcPolicyName: "",
+		tls:         true,
+		zoneSync:    false,
+		replicas:    2,
+		otelEnabled: true,
 		secretRefs: map[string]*secrets.SecretReference{
 			"default/ingress-mtls-secret": {
 				Secret: &api_v1.Secret{
@@ -106,6 +106,16 @@ func TestGeneratePolicies(t *testing.T) {
 					},
 				},
 			},
+			"default/hmac-secret": {
+				Secret: &api_v1.Secret{
+					Type: secrets.SecretTypeHMAC,
+					Data: map[string][]byte{
+						"partner2": []byte("secret2"),
+						"partner1": []byte("secret1"),
+					},
+				},
+				Path: "/etc/nginx/secrets/default-hmac-secret",
+			},
 		},
 		defaultCABundle: "/etc/ssl/certs/ca-certificate.crt",
 		apResources: &appProtectPolicyResources{
@@ -533,6 +543,81 @@ func TestGeneratePolicies(t *testing.T) {
 			},
 			msg: "jwt reference",
 		},
+		{
+			policyRefs: []conf_v1.PolicyReference{
+				{
+					Name:      "jwt-policy-require",
+					Namespace: "default",
+				},
+			},
+			policies: map[string]*conf_v1.Policy{
+				"default/jwt-policy-require": {
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      "jwt-policy-require",
+						Namespace: "default",
+					},
+					Spec: conf_v1.PolicySpec{
+						JWTAuth: &conf_v1.JWTAuth{
+							Realm:  "My Test API",
+							Secret: "jwt-secret",
+							Require: &conf_v1.JWTRequire{
+								Claims: []conf_v1.JWTClaimRequirement{
+									{Name: "realm_access.roles", Values: []string{"admin", "api.write"}},
+									{Name: "iss", Regex: "^https://idp\\.example\\.com/"},
+								},
+								Code: new(401),
+							},
+						},
+					},
+				},
+			},
+			expected: policiesCfg{
+				Context: ctx,
+				JWTAuth: jwtAuth{
+					Auth: &version2.JWTAuth{
+						Secret: "/etc/nginx/secrets/default-jwt-secret",
+						Realm:  "My Test API",
+						Require: &version2.JWTRequire{
+							Variables: []string{
+								"$jwt_require_default_test_vs_default_jwt_policy_require_0",
+								"$jwt_require_default_test_vs_default_jwt_policy_require_1",
+							},
+							Code: 401,
+						},
+					},
+					ClaimSets: []version2.AuthJWTClaimSet{
+						{
+							Variable: "$jwt_claim_default_test_vs_default_jwt_policy_require_0",
+							Claim:    "realm_access roles",
+						},
+						{
+							Variable: "$jwt_claim_default_test_vs_default_jwt_policy_require_1",
+							Claim:    "iss",
+						},
+					},
+					Maps: []version2.Map{
+						{
+							Source:   "$jwt_claim_default_test_vs_default_jwt_policy_require_0",
+							Variable: "$jwt_require_default_test_vs_default_jwt_policy_require_0",
+							Parameters: []version2.Parameter{
+								{Value: "default", Result: "0"},
+								{Value: `"~(^|,)admin(,|$)"`, Result: "1"},
+								{Value: `"~(^|,)api\.write(,|$)"`, Result: "1"},
+							},
+						},
+						{
+							Source:   "$jwt_claim_default_test_vs_default_jwt_policy_require_1",
+							Variable: "$jwt_require_default_test_vs_default_jwt_policy_require_1",
+							Parameters: []version2.Parameter{
+								{Value: "default", Result: "0"},
+								{Value: `"~^https://idp\.example\.com/"`, Result: "1"},
+							},
+						},
+					},
+				},
+			},
+			msg: "jwt reference with claim requirements",
+		},
 		{
 			policyRefs: []conf_v1.PolicyReference{
 				{
@@ -655,6 +740,75 @@ func TestGeneratePolicies(t *testing.T) {
 			},
 			msg: "basic auth reference",
 		},
+		{
+			policyRefs: []conf_v1.PolicyReference{
+				{
+					Name:      "allow-policy",
+					Namespace: "default",
+				},
+			},
+			policies: map[string]*conf_v1.Policy{
+				"default/allow-policy": {
+					Spec: conf_v1.PolicySpec{
+						AccessControl: &conf_v1.AccessControl{
+							Allow:  []string{"127.0.0.1"},
+							DryRun: true,
+						},
+					},
+				},
+			},
+			expected: policiesCfg{
+				Context: ctx,
+				DryRun: policyDryRun{
+					AccessControl: &version2.Geo{
+						Variable: "$policy_dry_run_default_test_vs_default_allow_policy",
+						Parameters: []version2.Parameter{
+							{Value: "default", Result: `"default/allow-policy"`},
+							{Value: "127.0.0.1", Result: `""`},
+						},
+					},
+				},
+			},
+			msg: "access control dry run reference",
+		},
+		{
+			policyRefs: []conf_v1.PolicyReference{
+				{
+					Name:      "basic-auth-policy",
+					Namespace: "default",
+				},
+			},
+			policies: map[string]*conf_v1.Policy{
+				"default/basic-auth-policy": {
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      "basic-auth-policy",
+						Namespace: "default",
+					},
+					Spec: conf_v1.PolicySpec{
+						BasicAuth: &conf_v1.BasicAuth{
+							Realm:  "My Test API",
+							Secret: "htpasswd-secret",
+							DryRun: true,
+						},
+					},
+				},
+			},
+			expected: policiesCfg{
+				Context: ctx,
+				DryRun: policyDryRun{
+					Auth: &version2.PolicyDryRunAuth{
+						Policy:     "default/basic-auth-policy",
+						Path:       "/_policy_dry_run_default_test_vs_default_basic_auth_policy",
+						ServerName: "policy_dry_run_default_test_vs_default_basic_auth_policy",
+						BasicAuth: &version2.BasicAuth{
+							Secret: "/etc/nginx/secrets/default-htpasswd-secret",
+							Realm:  "My Test API",
+						},
+					},
+				},
+			},
+			msg: "basic auth dry run reference",
+		},
 		{
 			policyRefs: []conf_v1.PolicyReference{
 				{
@@ -869,12 +1023,15 @@ func TestGeneratePolicies(t *testing.T) {
 					Scope:                 "scope",
 					RedirectURI:           "/redirect",
 					EndSessionEndpoint:    "http://example.com/logout",
+					LogoutURI:             "/logout",
 					PostLogoutRedirectURI: "/_logout",
+					SessionCookie:         "auth_token",
 					ZoneSyncLeeway:        20,
 					AccessTokenEnable:     true,
 					VerifyDepth:           1,
 					CAFile:                "/etc/ssl/certs/ca-certificate.crt",
 					PolicyName:            "default/oidc-policy",
+					ID:                    "default_oidc_policy",
 				},
 			},
 			msg: "oidc reference",
@@ -967,6 +1124,231 @@ func TestGeneratePolicies(t *testing.T) {
 			},
 			msg: "api key same secrets for different policies",
 		},
+		{
+			policyRefs: []conf_v1.PolicyReference{
+				{
+					Name:      "hmac-policy",
+					Namespace: "default",
+				},
+			},
+			policies: map[string]*conf_v1.Policy{
+				"default/hmac-policy": {
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      "hmac-policy",
+						Namespace: "default",
+					},
+					Spec: conf_v1.PolicySpec{
+						HMAC: &conf_v1.HMAC{
+							Secret:      "hmac-secret",
+							KeyIDHeader: "X-Key-Id",
+							ClockSkew:   "1m",
+						},
+					},
+				},
+			},
+			expected: policiesCfg{
+				Context: ctx,
+				HMAC: &version2.HMAC{
+					KeysFile:        "/etc/nginx/secrets/default-hmac-secret",
+					KeyIDHeader:     "X-Key-Id",
+					SignatureHeader: "X-Signature",
+					TimestampHeader: "X-Timestamp",
+					CanonicalString: "{timestamp}.{body}",
+					ClockSkew:       60,
+					MaxBodySize:     "1m",
+					RejectCode:      401,
+				},
+			},
+			msg: "hmac reference",
+		},
+		{
+			policyRefs: []conf_v1.PolicyReference{
+				{
+					Name:      "geo-policy",
+					Namespace: "default",
+				},
+			},
+			policies: map[string]*conf_v1.Policy{
+				"default/geo-policy": {
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      "geo-policy",
+						Namespace: "default",
+					},
+					Spec: conf_v1.PolicySpec{
+						Geo: &conf_v1.Geo{
+							Allow: &conf_v1.GeoMatch{
+								Countries:  []string{"US", "CA"},
+								Continents: []string{"EU"},
+								ASNs:       []int{13335},
+							},
+							RejectCode: new(451),
+						},
+					},
+				},
+			},
+			expected: policiesCfg{
+				Context: ctx,
+				Geo: &version2.GeoRestriction{
+					Variable:   "$geo_policy_default_test_vs_default_geo_policy",
+					RejectCode: 451,
+				},
+				GeoMap: &version2.Map{
+					Source:   `"$geoip2_country_code:$geoip2_continent_code:$geoip2_asn"`,
+					Variable: "$geo_policy_default_test_vs_default_geo_policy",
+					Parameters: []version2.Parameter{
+						{Value: "default", Result: `"1"`},
+						{Value: `"~^(US|CA):"`, Result: `""`},
+						{Value: `"~^[^:]*:(EU):"`, Result: `""`},
+						{Value: `"~:(13335)$"`, Result: `""`},
+					},
+				},
+			},
+			msg: "geo allow reference",
+		},
+		{
+			policyRefs: []conf_v1.PolicyReference{
+				{
+					Name:      "geo-policy",
+					Namespace: "default",
+				},
+			},
+			policies: map[string]*conf_v1.Policy{
+				"default/geo-policy": {
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      "geo-policy",
+						Namespace: "default",
+					},
+					Spec: conf_v1.PolicySpec{
+						Geo: &conf_v1.Geo{
+							Deny: &conf_v1.GeoMatch{
+								Countries: []string{"KP"},
+							},
+						},
+					},
+				},
+			},
+			expected: policiesCfg{
+				Context: ctx,
+				Geo: &version2.GeoRestriction{
+					Variable:   "$geo_policy_default_test_vs_default_geo_policy",
+					RejectCode: 403,
+				},
+				GeoMap: &version2.Map{
+					Source:   `"$geoip2_country_code:$geoip2_continent_code:$geoip2_asn"`,
+					Variable: "$geo_policy_default_test_vs_default_geo_policy",
+					Parameters: []version2.Parameter{
+						{Value: "default", Result: `""`},
+						{Value: `"~^(KP):"`, Result: `"1"`},
+					},
+				},
+			},
+			msg: "geo deny reference",
+		},
+		{
+			policyRefs: []conf_v1.PolicyReference{
+				{
+					Name:      "tracing-policy",
+					Namespace: "default",
+				},
+			},
+			policies: map[string]*conf_v1.Policy{
+				"default/tracing-policy": {
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      "tracing-policy",
+						Namespace: "default",
+					},
+					Spec: conf_v1.PolicySpec{
+						Tracing: &conf_v1.Tracing{
+							SpanName: "${request_method} ${request_uri}",
+						},
+					},
+				},
+			},
+			expected: policiesCfg{
+				Context: ctx,
+				Tracing: &version2.Tracing{
+					Trace:    "on",
+					Context:  "propagate",
+					SpanName: "${request_method} ${request_uri}",
+				},
+			},
+			msg: "tracing reference",
+		},
+		{
+			policyRefs: []conf_v1.PolicyReference{
+				{
+					Name:      "tracing-policy",
+					Namespace: "default",
+				},
+			},
+			policies: map[string]*conf_v1.Policy{
+				"default/tracing-policy": {
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      "tracing-policy",
+						Namespace: "default",
+					},
+					Spec: conf_v1.PolicySpec{
+						Tracing: &conf_v1.Tracing{
+							SampleRatio: "12.5%",
+							SpanAttributes: []conf_v1.TracingSpanAttribute{
+								{Name: "tenant", Value: "${http_x_tenant}"},
+							},
+							Propagation: "b3",
+						},
+					},
+				},
+			},
+			expected: policiesCfg{
+				Context: ctx,
+				Tracing: &version2.Tracing{
+					Trace:   "$tracing_policy_default_test_vs_default_tracing_policy",
+					Context: "ignore",
+					SpanAttributes: []version2.SpanAttribute{
+						{Name: "tenant", Value: "${http_x_tenant}"},
+					},
+					B3: true,
+				},
+				TracingSampler: &version2.SplitClient{
+					Source:   "$otel_trace_id",
+					Variable: "$tracing_policy_default_test_vs_default_tracing_policy",
+					Distributions: []version2.Distribution{
+						{Weight: "12.5%", Value: "on"},
+						{Weight: "*", Value: "off"},
+					},
+				},
+			},
+			msg: "tracing reference with sample ratio and b3 propagation",
+		},
+		{
+			policyRefs: []conf_v1.PolicyReference{
+				{
+					Name:      "tracing-policy",
+					Namespace: "default",
+				},
+			},
+			policies: map[string]*conf_v1.Policy{
+				"default/tracing-policy": {
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      "tracing-policy",
+						Namespace: "default",
+					},
+					Spec: conf_v1.PolicySpec{
+						Tracing: &conf_v1.Tracing{
+							SampleRatio: "0%",
+							Propagation: "extract",
+						},
+					},
+				},
+			},
+			expected: policiesCfg{
+				Context: ctx,
+				Tracing: &version2.Tracing{
+					Trace:   "off",
+					Context: "extract",
+				},
+			},
+			msg: "tracing reference with zero sample ratio",
+		},
 		{
 			policyRefs: []conf_v1.PolicyReference{
 				{
@@ -1475,6 +1857,194 @@ func TestAddCORSConfig(t *testing.T) {
 	}
 }
 
+func TestAddHeadersConfig(t *testing.T) {
+	t.Parallel()
+
+	ownerDetails := policyOwnerDetails{
+		parentNamespace: "default",
+		parentName:      "test",
+		ownerNamespace:  "default",
+		ownerName:       "test",
+		parentType:      "vs",
+	}
+
+	tests := []struct {
+		name     string
+		headers  *conf_v1.Headers
+		expected *headersPolicy
+	}{
+		{
+			name: "request headers",
+			headers: &conf_v1.Headers{
+				Request: &conf_v1.RequestHeadersModifier{
+					Set:    []conf_v1.Header{{Name: "X-Client", Value: "${remote_addr}"}},
+					Add:    []conf_v1.Header{{Name: "X-Tags", Value: "internal"}},
+					Remove: []string{"X-Debug"},
+				},
+			},
+			expected: &headersPolicy{
+				Request: []headerModifier{
+					{Name: "X-Client", SetHeader: &version2.Header{Name: "X-Client", Value: "${remote_addr}"}},
+					{Name: "X-Tags", SetHeader: &version2.Header{Name: "X-Tags", Value: "$headers_default_test_vs_default_headers_policy_request_0"}},
+					{Name: "X-Debug", SetHeader: &version2.Header{Name: "X-Debug", Value: ""}},
+				},
+				Maps: []version2.Map{
+					{
+						Source:   "$http_x_tags",
+						Variable: "$headers_default_test_vs_default_headers_policy_request_0",
+						Parameters: []version2.Parameter{
+							{Value: `""`, Result: `"internal"`},
+							{Value: "default", Result: `"$http_x_tags, internal"`},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "response headers",
+			headers: &conf_v1.Headers{
+				Response: &conf_v1.ResponseHeadersModifier{
+					Set: []conf_v1.ResponseHeader{
+						{Header: conf_v1.Header{Name: "X-Frame-Options", Value: "DENY"}, Always: true},
+						{Header: conf_v1.Header{Name: "Cache-Control", Value: "no-store"}, Codes: []string{"404", "5xx"}},
+					},
+					Add:    []conf_v1.ResponseHeader{{Header: conf_v1.Header{Name: "X-Error", Value: "true"}, Codes: []string{"4xx"}}},
+					Remove: []string{"Server"},
+				},
+			},
+			expected: &headersPolicy{
+				Response: []headerModifier{
+					{
+						Name:      "X-Frame-Options",
+						Hide:      true,
+						AddHeader: &version2.AddHeader{Header: version2.Header{Name: "X-Frame-Options", Value: "DENY"}, Always: true},
+					},
+					{
+						Name: "Cache-Control",
+						Hide: true,
+						AddHeader: &version2.AddHeader{
+							Header: version2.Header{Name: "Cache-Control", Value: "$headers_default_test_vs_default_headers_policy_response_set_1"},
+							Always: true,
+						},
+					},
+					{
+						Name: "X-Error",
+						AddHeader: &version2.AddHeader{
+							Header: version2.Header{Name: "X-Error", Value: "$headers_default_test_vs_default_headers_policy_response_add_0"},
+							Always: true,
+						},
+					},
+					{Name: "Server", Hide: true},
+				},
+				Maps: []version2.Map{
+					{
+						Source:   "$status",
+						Variable: "$headers_default_test_vs_default_headers_policy_response_set_1",
+						Parameters: []version2.Parameter{
+							{Value: "404", Result: `"no-store"`},
+							{Value: "~^5", Result: `"no-store"`},
+							{Value: "default", Result: `"$upstream_http_cache_control"`},
+						},
+					},
+					{
+						Source:   "$status",
+						Variable: "$headers_default_test_vs_default_headers_policy_response_add_0",
+						Parameters: []version2.Parameter{
+							{Value: "~^4", Result: `"true"`},
+							{Value: "default", Result: `""`},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		config := &policiesCfg{}
+		res := config.addHeadersConfig(test.headers, "default", "headers-policy", ownerDetails)
+		if len(res.warnings) > 0 {
+			t.Errorf("addHeadersConfig() returned unexpected warnings %v for the case of %s", res.warnings, test.name)
+		}
+		if diff := cmp.Diff(test.expected, config.Headers); diff != "" {
+			t.Errorf("addHeadersConfig() mismatch for the case of %s (-want +got):\n%s", test.name, diff)
+		}
+	}
+}
+
+func TestMergeHeadersPolicies(t *testing.T) {
+	t.Parallel()
+
+	base := &headersPolicy{
+		Request: []headerModifier{
+			{Name: "X-Client", SetHeader: &version2.Header{Name: "X-Client", Value: "spec"}},
+			{Name: "X-Debug", SetHeader: &version2.Header{Name: "X-Debug", Value: ""}},
+		},
+		Response: []headerModifier{{Name: "Server", Hide: true}},
+	}
+	overrides := &headersPolicy{
+		Request: []headerModifier{
+			{Name: "x-client", SetHeader: &version2.Header{Name: "x-client", Value: "route"}},
+		},
+		Response: []headerModifier{
+			{Name: "X-Frame-Options", AddHeader: &version2.AddHeader{Header: version2.Header{Name: "X-Frame-Options", Value: "DENY"}}},
+		},
+	}
+
+	expected := &headersPolicy{
+		Request: []headerModifier{
+			{Name: "X-Debug", SetHeader: &version2.Header{Name: "X-Debug", Value: ""}},
+			{Name: "x-client", SetHeader: &version2.Header{Name: "x-client", Value: "route"}},
+		},
+		Response: []headerModifier{
+			{Name: "Server", Hide: true},
+			{Name: "X-Frame-Options", AddHeader: &version2.AddHeader{Header: version2.Header{Name: "X-Frame-Options", Value: "DENY"}}},
+		},
+	}
+
+	result := mergeHeadersPolicies(base, overrides)
+	if diff := cmp.Diff(expected, result, cmpopts.EquateEmpty()); diff != "" {
+		t.Errorf("mergeHeadersPolicies() mismatch (-want +got):\n%s", diff)
+	}
+	if mergeHeadersPolicies(nil, overrides) != overrides {
+		t.Error("mergeHeadersPolicies() didn't return the overrides for a nil base")
+	}
+}
+
+func TestWithDefaultPolicies(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		defaultPolicies []conf_v1.PolicyReference
+		policies        []conf_v1.PolicyReference
+		expected        []conf_v1.PolicyReference
+		msg             string
+	}{
+		{
+			policies: []conf_v1.PolicyReference{{Name: "jwt"}},
+			expected: []conf_v1.PolicyReference{{Name: "jwt"}},
+			msg:      "no default policies",
+		},
+		{
+			defaultPolicies: []conf_v1.PolicyReference{{Name: "waf", Namespace: "security"}},
+			policies:        []conf_v1.PolicyReference{{Name: "jwt"}},
+			expected:        []conf_v1.PolicyReference{{Name: "waf", Namespace: "security"}, {Name: "jwt"}},
+			msg:             "default policies before the policies",
+		},
+		{
+			defaultPolicies: []conf_v1.PolicyReference{{Name: "rate-limit", Namespace: "default"}, {Name: "waf", Namespace: "security"}},
+			policies:        []conf_v1.PolicyReference{{Name: "rate-limit"}},
+			expected:        []conf_v1.PolicyReference{{Name: "waf", Namespace: "security"}, {Name: "rate-limit"}},
+			msg:             "default policy also referenced by the owner",
+		},
+	}
+
+	for _, test := range tests {
+		result := withDefaultPolicies(test.defaultPolicies, test.policies, "default")
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("withDefaultPolicies() returned unexpected result for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
 func TestGenerateCORSPolicy(t *testing.T) {
 	t.Parallel()
 
@@ -2088,6 +2658,127 @@ func TestAddExternalAuthConfig(t *testing.T) {
 	}
 }
 
+func TestAddIntrospectionConfig(t *testing.T) {
+	t.Parallel()
+
+	ownerDetails := policyOwnerDetails{
+		parentNamespace: "default",
+		parentName:      "cafe",
+		parentType:      "vs",
+	}
+	introspection := &conf_v1.Introspection{
+		AuthURI:         "/oauth2/introspect",
+		AuthServiceName: "idp",
+		ClientID:        "client",
+		ClientSecret:    "introspection-secret",
+		CacheTimeout:    "30s",
+		Headers: []conf_v1.IntrospectionHeader{
+			{Name: "X-User", Claim: "sub"},
+			{Name: "X-Roles", Claim: "realm_access.roles"},
+		},
+	}
+	validSecretRefs := map[string]*secrets.SecretReference{
+		"default/introspection-secret": {
+			Secret: &api_v1.Secret{
+				Type: secrets.SecretTypeOIDC,
+				Data: map[string][]byte{ClientSecretKey: []byte("secret")},
+			},
+		},
+	}
+
+	tests := []struct {
+		secretRefs   map[string]*secrets.SecretReference
+		existing     *version2.ExternalAuth
+		expected     *version2.ExternalAuth
+		wantError    bool
+		wantWarnings bool
+		msg          string
+	}{
+		{
+			secretRefs: validSecretRefs,
+			expected: &version2.ExternalAuth{
+				URI: &version2.AuthURI{
+					Service:      "idp",
+					Upstream:     "vs_exauth_default_introspection-policy",
+					Path:         "/oauth2/introspect",
+					InternalPath: "/_introspection_endpoint_default_cafe_vs_default_introspection_policy",
+				},
+				Introspection: &version2.Introspection{
+					Path:          "/_introspection_default_cafe_vs_default_introspection_policy",
+					EndpointPath:  "/_introspection_endpoint_default_cafe_vs_default_introspection_policy",
+					ClientAuth:    "Y2xpZW50OnNlY3JldA==",
+					CacheVariable: "introspection_cache_default_cafe_vs_default_introspection_policy",
+					CacheZone: version2.KeyValZone{
+						Name:    "introspection_default_cafe_vs_default_introspection_policy",
+						Size:    "1m",
+						Timeout: "30s",
+					},
+					CacheKeyVal: version2.KeyVal{
+						Key:      "$introspection_token_hash",
+						Variable: "$introspection_cache_default_cafe_vs_default_introspection_policy",
+						ZoneName: "introspection_default_cafe_vs_default_introspection_policy",
+					},
+					Claims: "sub realm_access.roles",
+				},
+				AuthRequestPath: "/_introspection_default_cafe_vs_default_introspection_policy",
+				AuthRequestHeaders: []version2.AuthRequestHeader{
+					{Name: "X-User", Variable: "$introspection_claim_default_cafe_vs_default_introspection_policy_0", Value: "$sent_http_x_introspection_claim_0"},
+					{Name: "X-Roles", Variable: "$introspection_claim_default_cafe_vs_default_introspection_policy_1", Value: "$sent_http_x_introspection_claim_1"},
+				},
+			},
+			msg: "valid introspection",
+		},
+		{
+			secretRefs:   map[string]*secrets.SecretReference{},
+			wantError:    true,
+			wantWarnings: true,
+			msg:          "missing client secret",
+		},
+		{
+			secretRefs: map[string]*secrets.SecretReference{
+				"default/introspection-secret": {
+					Secret: &api_v1.Secret{
+						Type: secrets.SecretTypeCA,
+					},
+				},
+			},
+			wantError:    true,
+			wantWarnings: true,
+			msg:          "client secret of a wrong type",
+		},
+		{
+			secretRefs: validSecretRefs,
+			existing: &version2.ExternalAuth{
+				URI: &version2.AuthURI{Service: "auth-svc", Upstream: "vs_exauth_default_ext-auth-policy", Path: "/auth"},
+			},
+			expected: &version2.ExternalAuth{
+				URI: &version2.AuthURI{Service: "auth-svc", Upstream: "vs_exauth_default_ext-auth-policy", Path: "/auth"},
+			},
+			wantWarnings: true,
+			msg:          "external auth in the same context",
+		},
+	}
+
+	for _, test := range tests {
+		config := &policiesCfg{ExternalAuth: test.existing}
+
+		res := config.addIntrospectionConfig(introspection, "default/introspection-policy", "default", "introspection-policy", test.secretRefs, policyOptions{}, ownerDetails)
+
+		if res.isError != test.wantError {
+			t.Errorf("addIntrospectionConfig() returned isError %v, expected %v for the case of %s", res.isError, test.wantError, test.msg)
+		}
+		if (len(res.warnings) > 0) != test.wantWarnings {
+			t.Errorf("addIntrospectionConfig() returned warnings %v for the case of %s", res.warnings, test.msg)
+		}
+		if test.wantError {
+			continue
+		}
+		if diff := cmp.Diff(test.expected, config.ExternalAuth); diff != "" {
+			t.Errorf("addIntrospectionConfig() mismatch for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
 func TestGenerateExternalAuthPolicy(t *testing.T) {
 	t.Parallel()
 
@@ -2139,6 +2830,55 @@ func TestGenerateExternalAuthPolicy(t *testing.T) {
 			},
 			msg: "VirtualServer with basic external auth URI",
 		},
+		{
+			name: "VirtualServer with gRPC external auth policy",
+			owner: policyOwnerDetails{
+				ownerNamespace:  "default",
+				ownerName:       "test-vs",
+				parentNamespace: "default",
+				parentName:      "test-vs",
+				parentType:      "vs",
+			},
+			path: "/",
+			policyRefs: []conf_v1.PolicyReference{
+				{Name: "grpc-ext-auth", Namespace: "default"},
+			},
+			policies: map[string]*conf_v1.Policy{
+				"default/grpc-ext-auth": {
+					Spec: conf_v1.PolicySpec{
+						ExternalAuth: &conf_v1.ExternalAuth{
+							AuthURI:         "/",
+							AuthServiceName: "opa",
+							GRPC: &conf_v1.ExternalAuthGRPC{
+								AllowedUpstreamHeaders: []string{"X-User", "X-Roles"},
+							},
+						},
+					},
+				},
+			},
+			expected: policiesCfg{
+				Context: ctx,
+				ExternalAuth: &version2.ExternalAuth{
+					URI: &version2.AuthURI{
+						Service:      "opa",
+						Upstream:     "vs_exauth_default_grpc-ext-auth",
+						Path:         "/",
+						InternalPath: "/_external_authz_grpc_default_test_vs_vs_default_grpc_ext_auth",
+					},
+					AuthRequestPath: "/_external_authz_default_test_vs_vs_default_grpc_ext_auth",
+					AuthRequestHeaders: []version2.AuthRequestHeader{
+						{Name: "X-User", Variable: "$ext_authz_header_default_test_vs_vs_default_grpc_ext_auth_0", Value: "$sent_http_x_ext_authz_header_0"},
+						{Name: "X-Roles", Variable: "$ext_authz_header_default_test_vs_vs_default_grpc_ext_auth_1", Value: "$sent_http_x_ext_authz_header_1"},
+					},
+					GRPC: &version2.ExternalAuthGRPC{
+						Path:         "/_external_authz_default_test_vs_vs_default_grpc_ext_auth",
+						EndpointPath: "/_external_authz_grpc_default_test_vs_vs_default_grpc_ext_auth",
+						Headers:      "X-User X-Roles",
+					},
+				},
+			},
+			msg: "VirtualServer with gRPC external auth",
+		},
 		{
 			name: "VirtualServer with full external auth policy",
 			owner: policyOwnerDetails{
@@ -2409,7 +3149,7 @@ func TestGeneratePolicies_GeneratesWAFPolicyOnValidApBundle(t *testing.T) {
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			res, warnings := generatePolicies(ctx, ownerDetails, tc.policyRefs, tc.policies, tc.context, tc.path, policyOptions{apResources: &appProtectPolicyResources{}, replicas: 1, oidcPolicyName: ""}, &fakeBV)
+			res, warnings := generatePolicies(ctx, ownerDetails, tc.policyRefs, tc.policies, tc.context, tc.path, policyOptions{apResources: &appProtectPolicyResources{}, replicas: 1}, &fakeBV)
 			res.BundleValidator = nil
 			if diff := cmp.Diff(tc.want, res, cmpopts.IgnoreFields(policiesCfg{}, "Context")); diff != "" {
 				t.Error(diff)
@@ -2505,6 +3245,53 @@ func TestGeneratePoliciesFails(t *testing.T) {
 			},
 			msg: "conflicting policies",
 		},
+		{
+			policyRefs: []conf_v1.PolicyReference{
+				{
+					Name: "allow-policy",
+				},
+				{
+					Name: "deny-policy",
+				},
+			},
+			policies: map[string]*conf_v1.Policy{
+				"default/allow-policy": {
+					Spec: conf_v1.PolicySpec{
+						AccessControl: &conf_v1.AccessControl{
+							Allow:  []string{"127.0.0.1"},
+							DryRun: true,
+						},
+					},
+				},
+				"default/deny-policy": {
+					Spec: conf_v1.PolicySpec{
+						AccessControl: &conf_v1.AccessControl{
+							Deny:   []string{"127.0.0.2"},
+							DryRun: true,
+						},
+					},
+				},
+			},
+			policyOpts: policyOptions{},
+			expected: policiesCfg{
+				Context: ctx,
+				DryRun: policyDryRun{
+					AccessControl: &version2.Geo{
+						Variable: "$policy_dry_run_default_test_vs_default_allow_policy",
+						Parameters: []version2.Parameter{
+							{Value: "default", Result: `"default/allow-policy"`},
+							{Value: "127.0.0.1", Result: `""`},
+						},
+					},
+				},
+			},
+			expectedWarnings: Warnings{
+				nil: {
+					"Multiple AccessControl policies in the dry run mode in the same context is not valid. AccessControl policy default/deny-policy will be ignored",
+				},
+			},
+			msg: "multi access control dry run",
+		},
 		{
 			policyRefs: []conf_v1.PolicyReference{
 				{
@@ -3741,7 +4528,15 @@ func TestGeneratePoliciesFails(t *testing.T) {
 						},
 					},
 				},
-				oidcPolicyName: "default/oidc-policy-1",
+				oidcProviders: map[string]*oidcProvider{
+					"default/oidc-policy-1": {
+						Config: &version2.OIDC{
+							RedirectURI:   "/_codexch",
+							LogoutURI:     "/admin/logout",
+							SessionCookie: "admin_token",
+						},
+					},
+				},
 			},
 			context: "route",
 			expected: policiesCfg{
@@ -3751,10 +4546,10 @@ func TestGeneratePoliciesFails(t *testing.T) {
 			},
 			expectedWarnings: Warnings{
 				nil: {
-					`Only one oidc policy is allowed in a VirtualServer and its VirtualServerRoutes. Can't use default/oidc-policy-2. Use default/oidc-policy-1`,
+					`OIDC policy default/oidc-policy-2 uses the same redirect URI /_codexch as OIDC policy default/oidc-policy-1. OIDC policies of a VirtualServer and its VirtualServerRoutes must use different redirect URIs, logout URIs and session cookies`,
 				},
 			},
-			msg: "multiple oidc policies",
+			msg: "multiple oidc policies with the same redirect URI",
 		},
 		{
 			policyRefs: []conf_v1.PolicyReference{
@@ -3830,10 +4625,13 @@ func TestGeneratePoliciesFails(t *testing.T) {
 					RedirectURI:           "/_codexch",
 					Scope:                 "openid",
 					EndSessionEndpoint:    "https://foo.com/logout",
+					LogoutURI:             "/logout",
 					PostLogoutRedirectURI: "/_logout",
+					SessionCookie:         "auth_token",
 					AccessTokenEnable:     true,
 					ZoneSyncLeeway:        200,
 					PolicyName:            "default/oidc-policy",
+					ID:                    "default_oidc_policy",
 				},
 			},
 			expectedWarnings: Warnings{
@@ -3977,6 +4775,185 @@ func TestGeneratePoliciesFails(t *testing.T) {
 			},
 			msg: "api key referencing wrong secret type",
 		},
+		{
+			policyRefs: []conf_v1.PolicyReference{
+				{
+					Name:      "hmac-policy",
+					Namespace: "default",
+				},
+			},
+			policies: map[string]*conf_v1.Policy{
+				"default/hmac-policy": {
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      "hmac-policy",
+						Namespace: "default",
+					},
+					Spec: conf_v1.PolicySpec{
+						HMAC: &conf_v1.HMAC{
+							Secret: "hmac-secret",
+						},
+					},
+				},
+			},
+			policyOpts: policyOptions{
+				secretRefs: map[string]*secrets.SecretReference{
+					"default/hmac-secret": {
+						Secret: &api_v1.Secret{
+							Type: secrets.SecretTypeAPIKey,
+							Data: map[string][]byte{
+								"partner1": []byte("secret1"),
+							},
+						},
+					},
+				},
+			},
+			expected: policiesCfg{
+				ErrorReturn: &version2.Return{
+					Code: 500,
+				},
+			},
+			expectedWarnings: Warnings{
+				nil: {
+					`HMAC policy default/hmac-policy references a secret default/hmac-secret of a wrong type 'nginx.org/apikey', must be 'nginx.org/hmac'`,
+				},
+			},
+			msg: "hmac referencing wrong secret type",
+		},
+		{
+			policyRefs: []conf_v1.PolicyReference{
+				{
+					Name:      "geo-policy",
+					Namespace: "default",
+				},
+				{
+					Name:      "geo-policy-2",
+					Namespace: "default",
+				},
+			},
+			policies: map[string]*conf_v1.Policy{
+				"default/geo-policy": {
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      "geo-policy",
+						Namespace: "default",
+					},
+					Spec: conf_v1.PolicySpec{
+						Geo: &conf_v1.Geo{
+							Deny: &conf_v1.GeoMatch{
+								Continents: []string{"AN"},
+							},
+						},
+					},
+				},
+				"default/geo-policy-2": {
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      "geo-policy-2",
+						Namespace: "default",
+					},
+					Spec: conf_v1.PolicySpec{
+						Geo: &conf_v1.Geo{
+							Deny: &conf_v1.GeoMatch{
+								Countries: []string{"KP"},
+							},
+						},
+					},
+				},
+			},
+			expected: policiesCfg{
+				Geo: &version2.GeoRestriction{
+					Variable:   "$geo_policy_default_test_vs_default_geo_policy",
+					RejectCode: 403,
+				},
+				GeoMap: &version2.Map{
+					Source:   `"$geoip2_country_code:$geoip2_continent_code:$geoip2_asn"`,
+					Variable: "$geo_policy_default_test_vs_default_geo_policy",
+					Parameters: []version2.Parameter{
+						{Value: "default", Result: `""`},
+						{Value: `"~^[^:]*:(AN):"`, Result: `"1"`},
+					},
+				},
+			},
+			expectedWarnings: Warnings{
+				nil: {
+					"Multiple geo policies in the same context is not valid. Geo policy default/geo-policy-2 will be ignored",
+				},
+			},
+			msg: "multi geo",
+		},
+		{
+			policyRefs: []conf_v1.PolicyReference{
+				{
+					Name:      "tracing-policy",
+					Namespace: "default",
+				},
+			},
+			policies: map[string]*conf_v1.Policy{
+				"default/tracing-policy": {
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      "tracing-policy",
+						Namespace: "default",
+					},
+					Spec: conf_v1.PolicySpec{
+						Tracing: &conf_v1.Tracing{},
+					},
+				},
+			},
+			expected: policiesCfg{},
+			expectedWarnings: Warnings{
+				nil: {
+					"Tracing policy default/tracing-policy requires the OpenTelemetry exporter configured with the otel-exporter-endpoint ConfigMap key. The policy will be ignored",
+				},
+			},
+			msg: "tracing without otel exporter",
+		},
+		{
+			policyRefs: []conf_v1.PolicyReference{
+				{
+					Name:      "tracing-policy",
+					Namespace: "default",
+				},
+				{
+					Name:      "tracing-policy-2",
+					Namespace: "default",
+				},
+			},
+			policies: map[string]*conf_v1.Policy{
+				"default/tracing-policy": {
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      "tracing-policy",
+						Namespace: "default",
+					},
+					Spec: conf_v1.PolicySpec{
+						Tracing: &conf_v1.Tracing{},
+					},
+				},
+				"default/tracing-policy-2": {
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      "tracing-policy-2",
+						Namespace: "default",
+					},
+					Spec: conf_v1.PolicySpec{
+						Tracing: &conf_v1.Tracing{
+							Propagation: "extract",
+						},
+					},
+				},
+			},
+			policyOpts: policyOptions{
+				otelEnabled: true,
+			},
+			expected: policiesCfg{
+				Tracing: &version2.Tracing{
+					Trace:   "on",
+					Context: "propagate",
+				},
+			},
+			expectedWarnings: Warnings{
+				nil: {
+					"Multiple tracing policies in the same context is not valid. Tracing policy default/tracing-policy-2 will be ignored",
+				},
+			},
+			msg: "multi tracing",
+		},
 		{
 			policyRefs: []conf_v1.PolicyReference{
 				{
@@ -4137,8 +5114,7 @@ func TestGeneratePoliciesFails(t *testing.T) {
 				},
 			},
 			policyOpts: policyOptions{
-				replicas:       1,
-				oidcPolicyName: "",
+				replicas: 1,
 				secretRefs: map[string]*secrets.SecretReference{
 					"default/oidc-secret": {
 						Secret: &api_v1.Secret{
@@ -4251,6 +5227,16 @@ func TestIsPolicySupportedOnIngress(t *testing.T) {
 			policy:   &conf_v1.Policy{Spec: conf_v1.PolicySpec{EgressMTLS: &conf_v1.EgressMTLS{}}},
 			expected: true,
 		},
+		{
+			name:     "ExternalAuth is supported",
+			policy:   &conf_v1.Policy{Spec: conf_v1.PolicySpec{ExternalAuth: &conf_v1.ExternalAuth{}}},
+			expected: true,
+		},
+		{
+			name:     "ExternalAuth in gRPC mode is not supported",
+			policy:   &conf_v1.Policy{Spec: conf_v1.PolicySpec{ExternalAuth: &conf_v1.ExternalAuth{GRPC: &conf_v1.ExternalAuthGRPC{}}}},
+			expected: false,
+		},
 		{
 			name:     "RateLimit is not supported",
 			policy:   &conf_v1.Policy{Spec: conf_v1.PolicySpec{RateLimit: &conf_v1.RateLimit{}}},
@@ -4276,11 +5262,26 @@ func TestIsPolicySupportedOnIngress(t *testing.T) {
 			policy:   &conf_v1.Policy{Spec: conf_v1.PolicySpec{APIKey: &conf_v1.APIKey{}}},
 			expected: false,
 		},
+		{
+			name:     "HMAC is not supported",
+			policy:   &conf_v1.Policy{Spec: conf_v1.PolicySpec{HMAC: &conf_v1.HMAC{}}},
+			expected: false,
+		},
 		{
 			name:     "WAF is supported",
 			policy:   &conf_v1.Policy{Spec: conf_v1.PolicySpec{WAF: &conf_v1.WAF{}}},
 			expected: true,
 		},
+		{
+			name:     "Geo is supported",
+			policy:   &conf_v1.Policy{Spec: conf_v1.PolicySpec{Geo: &conf_v1.Geo{}}},
+			expected: true,
+		},
+		{
+			name:     "Tracing is supported",
+			policy:   &conf_v1.Policy{Spec: conf_v1.PolicySpec{Tracing: &conf_v1.Tracing{}}},
+			expected: true,
+		},
 		{
 			name:     "Cache is not supported",
 			policy:   &conf_v1.Policy{Spec: conf_v1.PolicySpec{Cache: &conf_v1.Cache{}}},
@@ -23,6 +23,7 @@ type TransportServerEx struct {
 	ExternalNameSvcs map[string]bool
 	DisableIPV6      bool
 	SecretRefs       map[string]*secrets.SecretReference
+	Policies         map[string]*conf_v1.Policy
 	IPv4             string
 	IPv6             string
 }
@@ -94,6 +95,20 @@ func generateTransportServerConfig(p transportServerConfigParams) (*version2.Tra
 
 	streamSnippets := generateSnippets(true, p.transportServerEx.TransportServer.Spec.StreamSnippets, []string{})
 
+	policiesCfg, w := generateTransportServerPolicies(p.transportServerEx)
+	warnings.Add(w)
+
+	if sslConfig.Enabled && p.transportServerEx.TransportServer.Spec.TLS.MTLS != nil {
+		ingressMTLS, w := generateStreamIngressMTLS(p.transportServerEx.TransportServer, p.transportServerEx.TransportServer.Spec.TLS.MTLS, p.transportServerEx.SecretRefs)
+		warnings.Add(w)
+		if ingressMTLS == nil {
+			policiesCfg.Allow = nil
+			policiesCfg.Deny = []string{"all"}
+		}
+		sslConfig.IngressMTLS = ingressMTLS
+		sslConfig.ProxyProtocol = ingressMTLS != nil && p.transportServerEx.TransportServer.Spec.TLS.MTLS.ProxyProtocol
+	}
+
 	statusZone := p.transportServerEx.TransportServer.Spec.Listener.Name
 	if p.transportServerEx.TransportServer.Spec.Listener.Name == conf_v1.TLSPassthroughListenerName {
 		statusZone = p.transportServerEx.TransportServer.Spec.Host
@@ -127,16 +142,104 @@ func generateTransportServerConfig(p transportServerConfigParams) (*version2.Tra
 			SSL:                      sslConfig,
 			IPv4:                     p.transportServerEx.IPv4,
 			IPv6:                     p.transportServerEx.IPv6,
+			Allow:                    policiesCfg.Allow,
+			Deny:                     policiesCfg.Deny,
+			LimitConnections:         policiesCfg.LimitConnections,
+			LimitConnOptions:         policiesCfg.LimitConnOptions,
 		},
 		Match:                   match,
 		Upstreams:               upstreams,
 		StreamSnippets:          streamSnippets,
+		LimitConnZones:          policiesCfg.LimitConnZones,
 		DynamicSSLReloadEnabled: p.isDynamicReloadEnabled,
 		StaticSSLPath:           p.staticSSLPath,
 	}
 	return tsConfig, warnings
 }
 
+// transportServerPoliciesCfg holds the configuration of the policies of a TransportServer.
+type transportServerPoliciesCfg struct {
+	Allow            []string
+	Deny             []string
+	LimitConnZones   []version2.StreamLimitConnZone
+	LimitConnections []version2.StreamLimitConn
+	LimitConnOptions version2.StreamLimitConnOptions
+}
+
+// generateTransportServerPolicies generates the configuration of the policies referenced by a TransportServer.
+// If a policy is missing, invalid or not supported, all connections are denied.
+func generateTransportServerPolicies(transportServerEx *TransportServerEx) (transportServerPoliciesCfg, Warnings) {
+	warnings := newWarnings()
+	ts := transportServerEx.TransportServer
+	denyAll := transportServerPoliciesCfg{Deny: []string{"all"}}
+
+	var cfg transportServerPoliciesCfg
+	for _, p := range ts.Spec.Policies {
+		polNamespace := p.Namespace
+		if polNamespace == "" {
+			polNamespace = ts.Namespace
+		}
+
+		key := fmt.Sprintf("%s/%s", polNamespace, p.Name)
+
+		pol, exists := transportServerEx.Policies[key]
+		if !exists {
+			warnings.AddWarningf(ts, "Policy %s is missing or invalid", key)
+			return denyAll, warnings
+		}
+
+		switch {
+		case pol.Spec.AccessControl != nil && pol.Spec.AccessControl.DryRun:
+			warnings.AddWarningf(ts, "The dry run mode of AccessControl policy %s is not supported on TransportServer resources. The policy will be ignored", key)
+		case pol.Spec.AccessControl != nil:
+			cfg.Allow = append(cfg.Allow, pol.Spec.AccessControl.Allow...)
+			cfg.Deny = append(cfg.Deny, pol.Spec.AccessControl.Deny...)
+		case pol.Spec.ConnectionLimit != nil:
+			cfg.addConnectionLimitConfig(pol, ts, warnings)
+		default:
+			warnings.AddWarningf(ts, "Policy %s is not supported on TransportServer resources", key)
+			return denyAll, warnings
+		}
+	}
+
+	if len(cfg.Allow) > 0 && len(cfg.Deny) > 0 {
+		warnings.AddWarning(ts, "AccessControl policy (or policies) with deny rules is overridden by policy (or policies) with allow rules")
+	}
+
+	return cfg, warnings
+}
+
+func (p *transportServerPoliciesCfg) addConnectionLimitConfig(policy *conf_v1.Policy, ts *conf_v1.TransportServer, warnings Warnings) {
+	connectionLimit := policy.Spec.ConnectionLimit
+	polKey := fmt.Sprintf("%v/%v", policy.Namespace, policy.Name)
+	zoneName := rfc1123ToSnake(fmt.Sprintf("pol_cl_%v_%v_ts_%v_%v", policy.Namespace, policy.Name, ts.Namespace, ts.Name))
+
+	p.LimitConnZones = append(p.LimitConnZones, version2.StreamLimitConnZone{
+		Key:      "$remote_addr",
+		ZoneName: zoneName,
+		ZoneSize: connectionLimit.ZoneSize,
+	})
+	p.LimitConnections = append(p.LimitConnections, version2.StreamLimitConn{
+		ZoneName:    zoneName,
+		Connections: connectionLimit.Connections,
+	})
+
+	options := version2.StreamLimitConnOptions{
+		DryRun:   generateBool(connectionLimit.DryRun, false),
+		LogLevel: connectionLimit.LogLevel,
+	}
+	if len(p.LimitConnections) == 1 {
+		p.LimitConnOptions = options
+		return
+	}
+	if options.DryRun != p.LimitConnOptions.DryRun {
+		warnings.AddWarningf(ts, "ConnectionLimit policy %s with option dryRun='%v' is overridden to dryRun='%v' by the first policy reference", polKey, options.DryRun, p.LimitConnOptions.DryRun)
+	}
+	if options.LogLevel != p.LimitConnOptions.LogLevel {
+		warnings.AddWarningf(ts, "ConnectionLimit policy %s with option logLevel='%v' is overridden to logLevel='%v' by the first policy reference", polKey, options.LogLevel, p.LimitConnOptions.LogLevel)
+	}
+}
+
 func generateUnixSocket(transportServerEx *TransportServerEx) string {
 	if transportServerEx.TransportServer.Spec.Listener.Name == conf_v1.TLSPassthroughListenerName {
 		return fmt.Sprintf("unix:/var/lib/nginx/passthrough-%s_%s.sock", transportServerEx.TransportServer.Namespace, transportServerEx.TransportServer.Name)
@@ -177,6 +280,59 @@ func generateSSLConfig(ts *conf_v1.TransportServer, tls *conf_v1.TransportServer
 	return &ssl, warnings
 }
 
+// generateStreamIngressMTLS generates the client certificate verification of a TransportServer.
+// It returns nil if the CA secret is missing or invalid, in which case all connections must be denied.
+func generateStreamIngressMTLS(ts *conf_v1.TransportServer, mtls *conf_v1.TransportServerMTLS, secretRefs map[string]*secrets.SecretReference) (*version2.IngressMTLS, Warnings) {
+	warnings := newWarnings()
+
+	secretKey := fmt.Sprintf("%s/%s", ts.Namespace, mtls.ClientCertSecret)
+	secretRef := secretRefs[secretKey]
+	if secretRef == nil {
+		warnings.AddWarningf(ts, "Client certificate secret %s does not exist. All connections will be denied", secretKey)
+		return nil, warnings
+	}
+	var secretType api_v1.SecretType
+	if secretRef.Secret != nil {
+		secretType = secretRef.Secret.Type
+	}
+	if secretType != "" && secretType != secrets.SecretTypeCA {
+		warnings.AddWarningf(ts, "Client certificate secret %s is of a wrong type '%s', must be '%s'. All connections will be denied", secretKey, secretType, secrets.SecretTypeCA)
+		return nil, warnings
+	} else if secretRef.Error != nil {
+		warnings.AddWarningf(ts, "Client certificate secret %s is invalid: %v. All connections will be denied", secretKey, secretRef.Error)
+		return nil, warnings
+	}
+
+	ingressMTLS := &version2.IngressMTLS{
+		VerifyClient: "on",
+		VerifyDepth:  1,
+	}
+	if mtls.VerifyClient != "" {
+		ingressMTLS.VerifyClient = mtls.VerifyClient
+	}
+	if mtls.VerifyDepth != nil {
+		ingressMTLS.VerifyDepth = *mtls.VerifyDepth
+	}
+
+	caFields := strings.Fields(secretRef.Path)
+	ingressMTLS.ClientCert = caFields[0]
+
+	var hasCrlKey bool
+	if secretRef.Secret != nil {
+		_, hasCrlKey = secretRef.Secret.Data[CACrlKey]
+	}
+	if hasCrlKey && mtls.CrlFileName != "" {
+		warnings.AddWarningf(ts, "Both ca.crl in the Secret and tls.mtls.crlFileName fields cannot be used. ca.crl in %s will be ignored and %s will be applied", secretKey, mtls.CrlFileName)
+	}
+	if mtls.CrlFileName != "" {
+		ingressMTLS.ClientCrl = fmt.Sprintf("%s/%s", DefaultSecretPath, mtls.CrlFileName)
+	} else if hasCrlKey && len(caFields) > 1 {
+		ingressMTLS.ClientCrl = caFields[1]
+	}
+
+	return ingressMTLS, warnings
+}
+
 func generateStreamUpstreams(transportServerEx *TransportServerEx, upstreamNamer *upstreamNamer, isPlus bool, isResolverConfigured bool) ([]version2.StreamUpstream, Warnings) {
 	warnings := newWarnings()
 	var upstreams []version2.StreamUpstream
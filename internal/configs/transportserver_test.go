@@ -1897,3 +1897,236 @@ func TestGenerateTsSSLConfig(t *testing.T) {
 		}
 	}
 }
+
+func TestGenerateTransportServerPolicies(t *testing.T) {
+	t.Parallel()
+
+	ts := &conf_v1.TransportServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "tcp-server",
+			Namespace: "default",
+		},
+	}
+	policies := map[string]*conf_v1.Policy{
+		"default/allow-list": {
+			ObjectMeta: meta_v1.ObjectMeta{Name: "allow-list", Namespace: "default"},
+			Spec: conf_v1.PolicySpec{
+				AccessControl: &conf_v1.AccessControl{Allow: []string{"10.0.0.0/8"}},
+			},
+		},
+		"policies/connection-limit": {
+			ObjectMeta: meta_v1.ObjectMeta{Name: "connection-limit", Namespace: "policies"},
+			Spec: conf_v1.PolicySpec{
+				ConnectionLimit: &conf_v1.ConnectionLimit{
+					Connections: 10,
+					ZoneSize:    "10m",
+					DryRun:      new(true),
+					LogLevel:    "warn",
+				},
+			},
+		},
+		"default/rate-limit": {
+			ObjectMeta: meta_v1.ObjectMeta{Name: "rate-limit", Namespace: "default"},
+			Spec: conf_v1.PolicySpec{
+				RateLimit: &conf_v1.RateLimit{Rate: "10r/s", ZoneSize: "10m", Key: "${binary_remote_addr}"},
+			},
+		},
+	}
+
+	tests := []struct {
+		policyRefs       []conf_v1.PolicyReference
+		expected         transportServerPoliciesCfg
+		expectedWarnings int
+		msg              string
+	}{
+		{
+			policyRefs: nil,
+			expected:   transportServerPoliciesCfg{},
+			msg:        "no policies",
+		},
+		{
+			policyRefs: []conf_v1.PolicyReference{
+				{Name: "allow-list"},
+				{Name: "connection-limit", Namespace: "policies"},
+			},
+			expected: transportServerPoliciesCfg{
+				Allow: []string{"10.0.0.0/8"},
+				LimitConnZones: []version2.StreamLimitConnZone{
+					{
+						Key:      "$remote_addr",
+						ZoneName: "pol_cl_policies_connection_limit_ts_default_tcp_server",
+						ZoneSize: "10m",
+					},
+				},
+				LimitConnections: []version2.StreamLimitConn{
+					{
+						ZoneName:    "pol_cl_policies_connection_limit_ts_default_tcp_server",
+						Connections: 10,
+					},
+				},
+				LimitConnOptions: version2.StreamLimitConnOptions{
+					DryRun:   true,
+					LogLevel: "warn",
+				},
+			},
+			msg: "access control and connection limit",
+		},
+		{
+			policyRefs: []conf_v1.PolicyReference{
+				{Name: "allow-list"},
+				{Name: "missing"},
+			},
+			expected:         transportServerPoliciesCfg{Deny: []string{"all"}},
+			expectedWarnings: 1,
+			msg:              "missing policy",
+		},
+		{
+			policyRefs: []conf_v1.PolicyReference{
+				{Name: "rate-limit"},
+			},
+			expected:         transportServerPoliciesCfg{Deny: []string{"all"}},
+			expectedWarnings: 1,
+			msg:              "unsupported policy",
+		},
+	}
+
+	for _, test := range tests {
+		tsCopy := ts.DeepCopy()
+		tsCopy.Spec.Policies = test.policyRefs
+
+		result, warnings := generateTransportServerPolicies(&TransportServerEx{
+			TransportServer: tsCopy,
+			Policies:        policies,
+		})
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("generateTransportServerPolicies() mismatch for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+		if len(warnings[tsCopy]) != test.expectedWarnings {
+			t.Errorf("generateTransportServerPolicies() returned warnings %v for the case of %s, expected %d warnings", warnings, test.msg, test.expectedWarnings)
+		}
+	}
+}
+
+func TestGenerateStreamIngressMTLS(t *testing.T) {
+	t.Parallel()
+
+	ts := &conf_v1.TransportServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "tcp-server",
+			Namespace: "default",
+		},
+	}
+	secretRefs := map[string]*secrets.SecretReference{
+		"default/ca-secret": {
+			Secret: &api_v1.Secret{
+				Type: secrets.SecretTypeCA,
+			},
+			Path: "/etc/nginx/secrets/default-ca-secret-ca.crt",
+		},
+		"default/ca-secret-crl": {
+			Secret: &api_v1.Secret{
+				Type: secrets.SecretTypeCA,
+				Data: map[string][]byte{
+					"ca.crl": []byte("base64crl"),
+				},
+			},
+			Path: "/etc/nginx/secrets/default-ca-secret-crl-ca.crt /etc/nginx/secrets/default-ca-secret-crl-ca.crl",
+		},
+		"default/tls-secret": {
+			Secret: &api_v1.Secret{
+				Type: api_v1.SecretTypeTLS,
+			},
+			Path: "/etc/nginx/secrets/default-tls-secret",
+		},
+		"default/invalid-ca-secret": {
+			Secret: &api_v1.Secret{
+				Type: secrets.SecretTypeCA,
+			},
+			Error: errors.New("invalid secret"),
+		},
+	}
+
+	tests := []struct {
+		mtls             *conf_v1.TransportServerMTLS
+		expected         *version2.IngressMTLS
+		expectedWarnings int
+		msg              string
+	}{
+		{
+			mtls: &conf_v1.TransportServerMTLS{ClientCertSecret: "ca-secret"},
+			expected: &version2.IngressMTLS{
+				ClientCert:   "/etc/nginx/secrets/default-ca-secret-ca.crt",
+				VerifyClient: "on",
+				VerifyDepth:  1,
+			},
+			msg: "default options",
+		},
+		{
+			mtls: &conf_v1.TransportServerMTLS{
+				ClientCertSecret: "ca-secret",
+				CrlFileName:      "default-ca-secret-crl.crl",
+				VerifyClient:     "optional",
+				VerifyDepth:      new(2),
+			},
+			expected: &version2.IngressMTLS{
+				ClientCert:   "/etc/nginx/secrets/default-ca-secret-ca.crt",
+				ClientCrl:    "/etc/nginx/secrets/default-ca-secret-crl.crl",
+				VerifyClient: "optional",
+				VerifyDepth:  2,
+			},
+			msg: "crl file name and custom options",
+		},
+		{
+			mtls: &conf_v1.TransportServerMTLS{ClientCertSecret: "ca-secret-crl"},
+			expected: &version2.IngressMTLS{
+				ClientCert:   "/etc/nginx/secrets/default-ca-secret-crl-ca.crt",
+				ClientCrl:    "/etc/nginx/secrets/default-ca-secret-crl-ca.crl",
+				VerifyClient: "on",
+				VerifyDepth:  1,
+			},
+			msg: "crl in the secret",
+		},
+		{
+			mtls: &conf_v1.TransportServerMTLS{
+				ClientCertSecret: "ca-secret-crl",
+				CrlFileName:      "default-ca-secret-crl.crl",
+			},
+			expected: &version2.IngressMTLS{
+				ClientCert:   "/etc/nginx/secrets/default-ca-secret-crl-ca.crt",
+				ClientCrl:    "/etc/nginx/secrets/default-ca-secret-crl.crl",
+				VerifyClient: "on",
+				VerifyDepth:  1,
+			},
+			expectedWarnings: 1,
+			msg:              "crl in the secret and crl file name",
+		},
+		{
+			mtls:             &conf_v1.TransportServerMTLS{ClientCertSecret: "missing-secret"},
+			expected:         nil,
+			expectedWarnings: 1,
+			msg:              "missing secret",
+		},
+		{
+			mtls:             &conf_v1.TransportServerMTLS{ClientCertSecret: "tls-secret"},
+			expected:         nil,
+			expectedWarnings: 1,
+			msg:              "secret of a wrong type",
+		},
+		{
+			mtls:             &conf_v1.TransportServerMTLS{ClientCertSecret: "invalid-ca-secret"},
+			expected:         nil,
+			expectedWarnings: 1,
+			msg:              "invalid secret",
+		},
+	}
+
+	for _, test := range tests {
+		result, warnings := generateStreamIngressMTLS(ts, test.mtls, secretRefs)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("generateStreamIngressMTLS() mismatch for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+		if len(warnings[ts]) != test.expectedWarnings {
+			t.Errorf("generateStreamIngressMTLS() returned warnings %v for the case of %s, expected %d warnings", warnings, test.msg, test.expectedWarnings)
+		}
+	}
+}
@@ -19,6 +19,8 @@ type IngressNginxConfig struct {
 	Servers                 []Server
 	Keepalive               string
 	Maps                    []version2.Map
+	Geos                    []version2.Geo
+	SplitClients            []version2.SplitClient
 	CORSHeaders             []version2.AddHeader
 	Ingress                 Ingress
 	SpiffeClientCerts       bool
@@ -116,6 +118,9 @@ type Server struct {
 	AddHeaders             []version2.AddHeader
 	Allow                  []string
 	Deny                   []string
+	PolicyDryRun           *version2.PolicyDryRun
+	GeoRestriction         *version2.GeoRestriction
+	Tracing                *version2.Tracing
 	PoliciesErrorReturn    *version2.Return
 
 	HealthChecks map[string]HealthCheck
@@ -237,6 +242,9 @@ type Location struct {
 	ProxySSLTrustedCertificate string
 	Allow                      []string
 	Deny                       []string
+	PolicyDryRun               *version2.PolicyDryRun
+	GeoRestriction             *version2.GeoRestriction
+	Tracing                    *version2.Tracing
 	WAF                        *version2.WAF
 	EgressMTLS                 *version2.EgressMTLS
 	PoliciesErrorReturn        *version2.Return
@@ -298,6 +306,7 @@ type MainConfig struct {
 	KeepaliveTimeout                   string
 	LogFormat                          []string
 	LogFormatEscaping                  string
+	LogFormatPreset                    string
 	MainSnippets                       []string
 	MGMTConfig                         MGMTConfig
 	NginxStatus                        bool
@@ -309,6 +318,8 @@ type MainConfig struct {
 	MainOtelExporterHeaderName         string
 	MainOtelExporterHeaderValue        string
 	MainOtelServiceName                string
+	MainGeoIP2CountryDB                string
+	MainGeoIP2ASNDB                    string
 	ProxyProtocol                      bool
 	ResolverAddresses                  []string
 	ResolverIPV6                       bool
@@ -326,6 +337,7 @@ type MainConfig struct {
 	SSLProtocols                       string
 	StreamLogFormat                    []string
 	StreamLogFormatEscaping            string
+	StreamLogFormatPreset              string
 	StreamSnippets                     []string
 	StubStatusOverUnixSocketForOSS     bool
 	TLSPassthrough                     bool
@@ -353,6 +365,9 @@ type MainConfig struct {
 	InternalRouteServer                bool
 	InternalRouteServerName            string
 	LatencyMetrics                     bool
+	HostMetrics                        bool
+	PolicyDryRunMetrics                bool
+	OutlierDetection                   bool
 	ZoneSyncConfig                     ZoneSyncConfig
 	OIDC                               OIDCConfig
 	DynamicSSLReloadEnabled            bool
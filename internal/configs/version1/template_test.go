@@ -925,6 +925,68 @@ func TestExecuteTemplate_ForIngressForNGINXPlusWithIngressMTLSPolicy(t *testing.
 	t.Log(bufString)
 }
 
+func TestExecuteTemplate_ForIngressForNGINXWithTracingPolicy(t *testing.T) {
+	t.Parallel()
+
+	tmpl := newNGINXIngressTmpl(t)
+	buf := &bytes.Buffer{}
+
+	err := tmpl.Execute(buf, ingressCfgWithPolicyAnnotationForTracing)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bufString := buf.String()
+	wantedStrings := []string{
+		"split_clients $otel_trace_id $tracing_policy_default_cafe_ingress_ing_default_tracing_policy {",
+		"10% on;",
+		"otel_trace $tracing_policy_default_cafe_ingress_ing_default_tracing_policy;",
+		"otel_trace_context ignore;",
+		`otel_span_attr tenant "${http_x_tenant}";`,
+		`proxy_set_header b3 "$otel_trace_id-$otel_span_id";`,
+	}
+
+	for _, want := range wantedStrings {
+		if !strings.Contains(bufString, want) {
+			t.Errorf("want %q in generated config", want)
+		}
+	}
+
+	snaps.MatchSnapshot(t, bufString)
+	t.Log(bufString)
+}
+
+func TestExecuteTemplate_ForIngressForNGINXPlusWithTracingPolicy(t *testing.T) {
+	t.Parallel()
+
+	tmpl := newNGINXPlusIngressTmpl(t)
+	buf := &bytes.Buffer{}
+
+	err := tmpl.Execute(buf, ingressCfgWithPolicyAnnotationForTracing)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bufString := buf.String()
+	wantedStrings := []string{
+		"split_clients $otel_trace_id $tracing_policy_default_cafe_ingress_ing_default_tracing_policy {",
+		"10% on;",
+		"otel_trace $tracing_policy_default_cafe_ingress_ing_default_tracing_policy;",
+		"otel_trace_context ignore;",
+		`otel_span_attr tenant "${http_x_tenant}";`,
+		`proxy_set_header b3 "$otel_trace_id-$otel_span_id";`,
+	}
+
+	for _, want := range wantedStrings {
+		if !strings.Contains(bufString, want) {
+			t.Errorf("want %q in generated config", want)
+		}
+	}
+
+	snaps.MatchSnapshot(t, bufString)
+	t.Log(bufString)
+}
+
 func TestExecuteTemplate_ForIngressForNGINXWithHTTPRedirectCode(t *testing.T) {
 	t.Parallel()
 
@@ -2227,6 +2289,34 @@ func TestExecuteTemplate_ForMainForNGINXWithZoneSyncEnabledCustomResolverAddress
 	snaps.MatchSnapshot(t, buf.String())
 }
 
+func TestExecuteTemplate_ForMainForNGINXWithHostMetrics(t *testing.T) {
+	t.Parallel()
+
+	tmpl := newNGINXMainTmpl(t)
+	buf := &bytes.Buffer{}
+
+	err := tmpl.Execute(buf, mainCfgWithHostMetrics)
+	t.Log(buf.String())
+
+	if err != nil {
+		t.Fatalf("Failed to write template %v", err)
+	}
+
+	wantDirectives := []string{
+		`log_format host_metrics escape=json '{"resourceType":"$resource_type", "resourceNamespace":"$resource_namespace", "resourceName":"$resource_name", "upstream":"$proxy_host", "route":"$route", "status":"$status", "requestTime":"$request_time"}';`,
+		"access_log syslog:server=unix:/var/lib/nginx/nginx-syslog.sock,nohostname,tag=nginx_host_metrics host_metrics if=$resource_type;",
+		"map $http_host $route {",
+	}
+
+	mainConf := buf.String()
+	for _, want := range wantDirectives {
+		if !strings.Contains(mainConf, want) {
+			t.Errorf("want %q in generated config", want)
+		}
+	}
+	snaps.MatchSnapshot(t, buf.String())
+}
+
 func TestExecuteTemplate_ForMainForNGINXWithOtel(t *testing.T) {
 	t.Parallel()
 
@@ -2257,6 +2347,156 @@ func TestExecuteTemplate_ForMainForNGINXWithOtel(t *testing.T) {
 	snaps.MatchSnapshot(t, buf.String())
 }
 
+func TestExecuteTemplate_ForMainWithLogFormatPresets(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		cfg              MainConfig
+		wantDirectives   []string
+		unwantDirectives []string
+		msg              string
+	}{
+		{
+			cfg: MainConfig{LogFormatPreset: "json", StreamLogFormatPreset: "json"},
+			wantDirectives: []string{
+				`log_format  main escape=json '{"time":"$time_iso8601"`,
+				`'"service":"$service","route":"$route","upstream_name":"$upstream_name"}';`,
+				`log_format  stream-main escape=json '{"time":"$time_iso8601"`,
+				"map $protocol $upstream_name {",
+			},
+			msg: "json preset",
+		},
+		{
+			cfg: MainConfig{LogFormatPreset: "logfmt", StreamLogFormatPreset: "logfmt"},
+			wantDirectives: []string{
+				`log_format  main 'time=$time_iso8601 remote_addr=$remote_addr`,
+				`'service=$service route="$route" upstream_name=$upstream_name';`,
+				`log_format  stream-main 'time=$time_iso8601 remote_addr=$remote_addr`,
+			},
+			msg: "logfmt preset",
+		},
+		{
+			cfg: MainConfig{LogFormatPreset: "ecs", StreamLogFormatPreset: "ecs"},
+			wantDirectives: []string{
+				`log_format  main escape=json '{"@timestamp":"$time_iso8601","ecs.version":"8.11.0","event.dataset":"nginx.access",'`,
+				`'"labels":{"resource_type":"$resource_type","resource_namespace":"$resource_namespace","resource_name":"$resource_name",'`,
+				`log_format  stream-main escape=json '{"@timestamp":"$time_iso8601","ecs.version":"8.11.0","event.dataset":"nginx.stream",'`,
+			},
+			msg: "ecs preset",
+		},
+		{
+			cfg: MainConfig{LogFormatPreset: "otel", StreamLogFormatPreset: "otel", MainOtelLoadModule: true},
+			wantDirectives: []string{
+				`log_format  main escape=json '{"timestamp":"$time_iso8601","severity_text":"INFO","body":"$request",'`,
+				`'"trace_id":"$otel_trace_id","span_id":"$otel_span_id",'`,
+				`"http.route":"$route",`,
+				`log_format  stream-main escape=json '{"timestamp":"$time_iso8601","severity_text":"INFO","body":"$protocol $status",'`,
+			},
+			msg: "otel preset with the OpenTelemetry module",
+		},
+		{
+			cfg: MainConfig{LogFormat: []string{"$remote_addr", "$upstream_name"}, LogFormatPreset: "json"},
+			wantDirectives: []string{
+				"'$remote_addr'",
+				"' $upstream_name'",
+			},
+			unwantDirectives: []string{
+				`log_format  main escape=json '{"time":"$time_iso8601"`,
+			},
+			msg: "custom log format with a preset",
+		},
+	}
+
+	for _, tmpl := range []*template.Template{newNGINXMainTmpl(t), newNGINXPlusMainTmpl(t)} {
+		for _, test := range tests {
+			buf := &bytes.Buffer{}
+			if err := tmpl.Execute(buf, test.cfg); err != nil {
+				t.Fatalf("Failed to write template %v for the case of %s", err, test.msg)
+			}
+
+			mainConf := buf.String()
+			for _, want := range test.wantDirectives {
+				if !strings.Contains(mainConf, want) {
+					t.Errorf("want %q in generated config of %s for the case of %s", want, tmpl.Name(), test.msg)
+				}
+			}
+			for _, unwant := range test.unwantDirectives {
+				if strings.Contains(mainConf, unwant) {
+					t.Errorf("unwant %q in generated config of %s for the case of %s", unwant, tmpl.Name(), test.msg)
+				}
+			}
+		}
+	}
+}
+
+func TestExecuteTemplate_ForMainForNGINXPlusWithGeoIP2(t *testing.T) {
+	t.Parallel()
+
+	tmpl := newNGINXPlusMainTmpl(t)
+	buf := &bytes.Buffer{}
+
+	err := tmpl.Execute(buf, mainCfgWithGeoIP2)
+	t.Log(buf.String())
+
+	if err != nil {
+		t.Fatalf("Failed to write template %v", err)
+	}
+
+	wantDirectives := []string{
+		"load_module modules/ngx_http_geoip2_module.so;",
+		"geoip2 /etc/nginx/geoip/GeoLite2-Country.mmdb {",
+		"$geoip2_country_code country iso_code;",
+		"$geoip2_continent_code continent code;",
+		"geoip2 /etc/nginx/geoip/GeoLite2-ASN.mmdb {",
+		"$geoip2_asn autonomous_system_number;",
+	}
+
+	mainConf := buf.String()
+	for _, want := range wantDirectives {
+		if !strings.Contains(mainConf, want) {
+			t.Errorf("want %q in generated config", want)
+		}
+	}
+	if strings.Contains(mainConf, "map $remote_addr $geoip2_") {
+		t.Errorf("want no fallback geoip2 maps in generated config")
+	}
+	snaps.MatchSnapshot(t, buf.String())
+}
+
+func TestExecuteTemplate_ForMainForNGINXWithGeoIP2(t *testing.T) {
+	t.Parallel()
+
+	tmpl := newNGINXMainTmpl(t)
+	buf := &bytes.Buffer{}
+
+	err := tmpl.Execute(buf, mainCfgWithGeoIP2)
+	t.Log(buf.String())
+
+	if err != nil {
+		t.Fatalf("Failed to write template %v", err)
+	}
+
+	wantDirectives := []string{
+		"load_module modules/ngx_http_geoip2_module.so;",
+		"geoip2 /etc/nginx/geoip/GeoLite2-Country.mmdb {",
+		"$geoip2_country_code country iso_code;",
+		"$geoip2_continent_code continent code;",
+		"geoip2 /etc/nginx/geoip/GeoLite2-ASN.mmdb {",
+		"$geoip2_asn autonomous_system_number;",
+	}
+
+	mainConf := buf.String()
+	for _, want := range wantDirectives {
+		if !strings.Contains(mainConf, want) {
+			t.Errorf("want %q in generated config", want)
+		}
+	}
+	if strings.Contains(mainConf, "map $remote_addr $geoip2_") {
+		t.Errorf("want no fallback geoip2 maps in generated config")
+	}
+	snaps.MatchSnapshot(t, buf.String())
+}
+
 func TestExecuteTemplate_ForIngressForNGINXWithProxySetHeadersAnnotationWithDefaultValue(t *testing.T) {
 	t.Parallel()
 
@@ -3915,6 +4155,50 @@ var (
 		},
 	}
 
+	// Ingress Config example with Tracing Policy via annotation
+	ingressCfgWithPolicyAnnotationForTracing = IngressNginxConfig{
+		Servers: []Server{
+			{
+				Name:         "test.example.com",
+				ServerTokens: "off",
+				StatusZone:   "test.example.com",
+				Locations: []Location{
+					{
+						Path:      "/tea",
+						Upstream:  testUpstream,
+						ProxyPass: "http://test",
+					},
+				},
+				Tracing: &version2.Tracing{
+					Trace:   "$tracing_policy_default_cafe_ingress_ing_default_tracing_policy",
+					Context: "ignore",
+					SpanAttributes: []version2.SpanAttribute{
+						{Name: "tenant", Value: "${http_x_tenant}"},
+					},
+					B3: true,
+				},
+			},
+		},
+		Upstreams: []Upstream{testUpstream},
+		SplitClients: []version2.SplitClient{
+			{
+				Source:   "$otel_trace_id",
+				Variable: "$tracing_policy_default_cafe_ingress_ing_default_tracing_policy",
+				Distributions: []version2.Distribution{
+					{Weight: "10%", Value: "on"},
+					{Weight: "*", Value: "off"},
+				},
+			},
+		},
+		Ingress: Ingress{
+			Name:      "cafe-ingress",
+			Namespace: "default",
+			Annotations: map[string]string{
+				"nginx.org/policies": "tracing-policy",
+			},
+		},
+	}
+
 	// Ingress Config example with custom headers only (CORSEnabled=false)
 	ingressCfgWithHeadersOnlyNoCORS = IngressNginxConfig{
 		Servers: []Server{
@@ -4965,6 +5249,15 @@ var (
 		MainOtelServiceName:         "nginx-ingress-controller:nginx",
 	}
 
+	mainCfgWithHostMetrics = MainConfig{
+		HostMetrics: true,
+	}
+
+	mainCfgWithGeoIP2 = MainConfig{
+		MainGeoIP2CountryDB: "/etc/nginx/geoip/GeoLite2-Country.mmdb",
+		MainGeoIP2ASNDB:     "/etc/nginx/geoip/GeoLite2-ASN.mmdb",
+	}
+
 	mainCfgWithOIDCTimeoutDefault = MainConfig{
 		OIDC: OIDCConfig{
 			Enable:          true,
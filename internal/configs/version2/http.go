@@ -3,6 +3,7 @@ package version2
 import (
 	"bytes"
 	"fmt"
+	"time"
 )
 
 // UpstreamLabels describes the Prometheus labels for an NGINX upstream.
@@ -21,6 +22,7 @@ type VirtualServerConfig struct {
 	KeyVals                 []KeyVal
 	LimitReqZones           []LimitReqZone
 	Maps                    []Map
+	Geos                    []Geo
 	AuthJWTClaimSets        []AuthJWTClaimSet
 	CacheZones              []CacheZone
 	Server                  Server
@@ -31,6 +33,14 @@ type VirtualServerConfig struct {
 	Upstreams               []Upstream
 	DynamicSSLReloadEnabled bool
 	StaticSSLPath           string
+	RetryBudgetZone         *RetryBudgetZone
+}
+
+// RetryBudgetZone defines a shared dictionary zone for counting the requests and the retries of retry budgets.
+type RetryBudgetZone struct {
+	Name    string
+	Size    string
+	Timeout string
 }
 
 // AuthJWTClaimSet defines the values for the `auth_jwt_claim_set` directive
@@ -56,11 +66,23 @@ type Upstream struct {
 	UpstreamLabels   UpstreamLabels
 	NTLM             bool
 	BackupServers    []UpstreamServer
+	OutlierDetection *OutlierDetection
 }
 
 // UpstreamServer defines an upstream server.
 type UpstreamServer struct {
 	Address string
+	Down    bool
+	// Weight is set for the servers of an upstream while a server ejected by the outlier detection is re-admitted.
+	Weight int
+}
+
+// OutlierDetection defines the ejection of upstream servers by the Ingress Controller.
+// It is not rendered in the template: the ejected servers are marked as down.
+type OutlierDetection struct {
+	ConsecutiveErrors  int
+	EjectionTime       time.Duration
+	MaxEjectionPercent int
 }
 
 // Server defines a server.
@@ -96,13 +118,20 @@ type Server struct {
 	JWTAuthList               map[string]*JWTAuth
 	JWKSAuthEnabled           bool
 	ExternalAuth              *ExternalAuth
+	Introspections            []Introspection
+	ExternalAuthGRPCs         []ExternalAuthGRPC
 	ErrorPages                []ErrorPage
 	BasicAuth                 *BasicAuth
 	IngressMTLS               *IngressMTLS
 	EgressMTLS                *EgressMTLS
-	OIDC                      *OIDC
+	OIDCProviders             []*OIDC
+	OIDC                      *OIDC // The provider of a server with a single OIDC provider, for custom templates
 	APIKey                    *APIKey
 	APIKeyEnabled             bool
+	PolicyDryRun              *PolicyDryRun
+	GeoRestriction            *GeoRestriction
+	Tracing                   *Tracing
+	PolicyDryRunAuths         []PolicyDryRunAuth
 	WAF                       *WAF
 	Dos                       *Dos
 	Cache                     *Cache
@@ -155,7 +184,9 @@ type OIDC struct {
 	TokenEndpoint         string
 	EndSessionEndpoint    string
 	RedirectURI           string
+	LogoutURI             string
 	PostLogoutRedirectURI string
+	SessionCookie         string
 	ZoneSyncLeeway        int
 	AuthExtraArgs         string
 	AccessTokenEnable     bool
@@ -164,13 +195,30 @@ type OIDC struct {
 	VerifyDepth           int
 	CAFile                string
 	PolicyName            string
+	ID                    string // Identifies the internal locations of the policy
+	ClaimHeaders          []Header
+	Require               *JWTRequire
 }
 
 // APIKey holds API key configuration.
 type APIKey struct {
-	Header  []string
-	Query   []string
-	MapName string
+	Header         []string
+	Query          []string
+	MapName        string
+	ClientIDHeader string
+	DryRunPolicy   string // Key of the policy, set when the policy is in the dry run mode
+}
+
+// HMAC holds the configuration of the verification of the HMAC signatures of the requests.
+type HMAC struct {
+	KeysFile        string // the file with the key IDs and base64 encoded keys in the format id:key, one per line
+	KeyIDHeader     string
+	SignatureHeader string
+	TimestampHeader string
+	CanonicalString string
+	ClockSkew       int64 // seconds
+	MaxBodySize     string
+	RejectCode      int
 }
 
 // WAF defines WAF configuration.
@@ -238,13 +286,17 @@ type Location struct {
 	ExternalAuth               *ExternalAuth
 	BasicAuth                  *BasicAuth
 	EgressMTLS                 *EgressMTLS
-	OIDC                       bool
+	OIDC                       *OIDC
 	APIKey                     *APIKey
+	PolicyDryRun               *PolicyDryRun
+	GeoRestriction             *GeoRestriction
+	Tracing                    *Tracing
 	WAF                        *WAF
 	Dos                        *Dos
 	PoliciesErrorReturn        *Return
 	Cache                      *Cache
 	ServiceName                string
+	UpstreamName               string
 	IsVSR                      bool
 	VSRName                    string
 	VSRNamespace               string
@@ -254,6 +306,29 @@ type Location struct {
 	ProxySSLVerify             bool
 	ProxySSLVerifyDepth        int
 	ProxySSLTrustedCertificate string
+	Mirror                     *Mirror
+	MirrorSampleVariable       string
+	RetryBudget                *RetryBudget
+}
+
+// RetryBudget defines the retry budget of a Location. When the budget is exhausted,
+// requests are passed to the location with the Path, which doesn't retry requests.
+type RetryBudget struct {
+	Zone              string
+	Key               string
+	Percent           int
+	MinRetries        int
+	Path              string
+	FallbackProxyPass string
+	FallbackRewrites  []string
+}
+
+// Mirror defines the mirroring of requests in a Location.
+type Mirror struct {
+	Path        string
+	Upstream    string
+	Percentage  int
+	RequestBody bool
 }
 
 // ReturnLocation defines a location for returning a fixed response.
@@ -353,9 +428,11 @@ type Distribution struct {
 }
 
 // InternalRedirectLocation defines a location for internally redirecting requests to named locations.
+// If HMAC is set, the requests are redirected only after the verification of their HMAC signatures.
 type InternalRedirectLocation struct {
 	Path        string
 	Destination string
+	HMAC        *HMAC
 }
 
 // Map defines a map.
@@ -381,6 +458,50 @@ type Parameter struct {
 	Result string
 }
 
+// Geo defines a geo block, which sets the variable depending on the client IP address.
+type Geo struct {
+	Variable   string
+	Parameters []Parameter
+}
+
+// GeoRestriction defines the rejection of the requests by a geo policy.
+type GeoRestriction struct {
+	Variable   string // Set to a non-empty value for the requests that the policy rejects
+	RejectCode int
+}
+
+// Tracing defines the OpenTelemetry tracing of the requests by a tracing policy.
+type Tracing struct {
+	Trace          string // on or the variable of the sampler of the requests
+	Context        string // Value of otel_trace_context
+	SpanName       string
+	SpanAttributes []SpanAttribute
+	B3             bool // Passes the trace context to the upstream in the b3 header
+}
+
+// SpanAttribute defines a custom attribute of the spans.
+type SpanAttribute struct {
+	Name  string
+	Value string
+}
+
+// PolicyDryRun defines the reporting of the requests that the policies in the dry run mode would reject.
+type PolicyDryRun struct {
+	AccessControl string // Value of the $policy_dry_run_access_control variable
+	Auth          *PolicyDryRunAuth
+}
+
+// PolicyDryRunAuth defines the check of a JWT or a basic auth policy in the dry run mode.
+// NGINX doesn't check the access of subrequests, so the auth subrequest is passed over the policy dry run socket
+// to the server with the ServerName, which checks the access and reports the rejected requests in a response header.
+type PolicyDryRunAuth struct {
+	Policy     string // Key of the policy
+	Path       string // Path of the location that passes the auth subrequests to the server
+	ServerName string
+	JWTAuth    *JWTAuth
+	BasicAuth  *BasicAuth
+}
+
 // StatusMatch defines a Match block for status codes.
 type StatusMatch struct {
 	Name string
@@ -456,6 +577,13 @@ type JWTAuth struct {
 	Token    string
 	KeyCache string
 	JwksURI  JwksURI
+	Require  *JWTRequire
+}
+
+// JWTRequire defines the variables that must not be empty or "0" for the JWT to be accepted.
+type JWTRequire struct {
+	Variables []string
+	Code      int
 }
 
 // JwksURI defines the components of a JwksURI
@@ -483,6 +611,37 @@ type ExternalAuth struct {
 	SSLVerifyDepth         int
 	SSLTrustedCert         string // Path to the CA certificate file for upstream verification
 	SNIName                string // Server name for SNI and certificate verification
+	AuthRequestPath        string // Path of the njs handler that validates the requests through the external auth location
+	AuthRequestHeaders     []AuthRequestHeader
+	Introspection          *Introspection
+	GRPC                   *ExternalAuthGRPC
+}
+
+// AuthRequestHeader defines a request header set to a header of the auth_request response.
+type AuthRequestHeader struct {
+	Name     string
+	Variable string
+	Value    string // Variable of the header of the auth_request response
+}
+
+// Introspection holds the OAuth 2.0 token introspection configuration of an external auth.
+// The requests are validated by the njs handler, which sends the token to the external auth location.
+type Introspection struct {
+	Path          string // Path of the location of the njs handler
+	EndpointPath  string // Path of the external auth location that sends the requests to the introspection endpoint
+	ClientAuth    string // Base64-encoded client credentials for the introspection endpoint
+	CacheVariable string
+	CacheZone     KeyValZone
+	CacheKeyVal   KeyVal
+	Claims        string // Space-separated claims returned in the X-Introspection-Claim-<index> headers
+}
+
+// ExternalAuthGRPC holds the configuration of an external auth that implements the Envoy external authorization gRPC API.
+// The requests are validated by the njs handler, which sends the check requests to the external auth location.
+type ExternalAuthGRPC struct {
+	Path         string // Path of the location of the njs handler
+	EndpointPath string // Path of the external auth location that sends the check requests to the external auth server
+	Headers      string // Space-separated headers of the check responses returned in the X-Ext-Authz-Header-<index> headers
 }
 
 // AuthURI defines the components of an AuthURI
@@ -502,9 +661,10 @@ type BasicAuth struct {
 
 // KeyValZone defines a keyval zone.
 type KeyValZone struct {
-	Name  string
-	Size  string
-	State string
+	Name    string
+	Size    string
+	State   string
+	Timeout string
 }
 
 // KeyVal defines a keyval.
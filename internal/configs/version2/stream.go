@@ -5,6 +5,7 @@ type TransportServerConfig struct {
 	Server                  StreamServer
 	Upstreams               []StreamUpstream
 	StreamSnippets          []string
+	LimitConnZones          []StreamLimitConnZone
 	Match                   *Match
 	DisableIPV6             bool
 	DynamicSSLReloadEnabled bool
@@ -59,6 +60,29 @@ type StreamServer struct {
 	SSL                      *StreamSSL
 	IPv4                     string
 	IPv6                     string
+	Allow                    []string
+	Deny                     []string
+	LimitConnections         []StreamLimitConn
+	LimitConnOptions         StreamLimitConnOptions
+}
+
+// StreamLimitConnZone defines a shared memory zone for limiting the number of connections.
+type StreamLimitConnZone struct {
+	Key      string
+	ZoneName string
+	ZoneSize string
+}
+
+// StreamLimitConn defines the maximum number of connections for a zone.
+type StreamLimitConn struct {
+	ZoneName    string
+	Connections int
+}
+
+// StreamLimitConnOptions defines the options of connection limiting.
+type StreamLimitConnOptions struct {
+	DryRun   bool
+	LogLevel string
 }
 
 // StreamSSL defines SSL configuration for a server.
@@ -66,6 +90,9 @@ type StreamSSL struct {
 	Enabled        bool
 	Certificate    string
 	CertificateKey string
+	IngressMTLS    *IngressMTLS
+	// ProxyProtocol sends the PROXY protocol v2 header with the TLS details of the client to the upstream servers.
+	ProxyProtocol bool
 }
 
 // StreamHealthCheck defines a health check for a StreamUpstream in a StreamServer.
@@ -13,6 +13,30 @@ const tlsPassthroughHostsTemplateString = `# mapping between TLS Passthrough hos
 {{ end }}
 `
 
+// oidcProviderVarsTemplateString defines the variables of an OIDC provider for the locations of the OIDC flow.
+// #nosec G101
+const oidcProviderVarsTemplateString = `{{- define "oidcProviderVars" }}
+        set $oidc_provider "{{ .ID }}";
+        set $oidc_session_cookie "{{ .SessionCookie }}";
+        set $redir_location "{{ .RedirectURI }}";
+        set $oidc_pkce_enable {{ boolToInteger .PKCEEnable }};
+        set $oidc_logout_redirect "{{ .PostLogoutRedirectURI }}";
+        set $zone_sync_leeway {{ .ZoneSyncLeeway }};
+        set $oidc_authz_endpoint "{{ .AuthEndpoint }}";
+        set $oidc_authz_extra_args "{{ .AuthExtraArgs }}";
+        set $oidc_token_endpoint "{{ .TokenEndpoint }}";
+        set $oidc_end_session_endpoint "{{ .EndSessionEndpoint }}";
+        set $oidc_jwt_keyfile "{{ .JwksURI }}";
+        set $oidc_scopes "{{ .Scope }}";
+        set $oidc_client "{{ .ClientID }}";
+        set $oidc_client_secret "{{ .ClientSecret }}";
+{{- end }}`
+
+// newTemplateWithDefinitions returns a template with the helper functions and the templates shared by the VirtualServer and OIDC templates.
+func newTemplateWithDefinitions(name string) *template.Template {
+	return template.Must(template.New(name).Funcs(helperFunctions).Parse(oidcProviderVarsTemplateString))
+}
+
 // TemplateExecutor executes NGINX configuration templates.
 type TemplateExecutor struct {
 	originalVirtualServerTemplate  *template.Template
@@ -27,7 +51,7 @@ type TemplateExecutor struct {
 func NewTemplateExecutor(virtualServerTemplatePath string, transportServerTemplatePath string, oidcTemplatePath string) (*TemplateExecutor, error) {
 	// template names  must be the base name of the template file https://golang.org/pkg/text/template/#Template.ParseFiles
 
-	vsTemplate, err := template.New(path.Base(virtualServerTemplatePath)).Funcs(helperFunctions).ParseFiles(virtualServerTemplatePath)
+	vsTemplate, err := newTemplateWithDefinitions(path.Base(virtualServerTemplatePath)).ParseFiles(virtualServerTemplatePath)
 	if err != nil {
 		return nil, err
 	}
@@ -44,7 +68,7 @@ func NewTemplateExecutor(virtualServerTemplatePath string, transportServerTempla
 
 	var oidcTemplate *template.Template
 	if oidcTemplatePath != "" {
-		oidcTemplate, err = template.New(path.Base(oidcTemplatePath)).Funcs(helperFunctions).ParseFiles(oidcTemplatePath)
+		oidcTemplate, err = newTemplateWithDefinitions(path.Base(oidcTemplatePath)).ParseFiles(oidcTemplatePath)
 		if err != nil {
 			return nil, err
 		}
@@ -61,7 +85,7 @@ func NewTemplateExecutor(virtualServerTemplatePath string, transportServerTempla
 
 // UpdateVirtualServerTemplate updates the VirtualServer template.
 func (te *TemplateExecutor) UpdateVirtualServerTemplate(templateString *string) error {
-	newTemplate, err := template.New("virtualServerTemplate").Funcs(helperFunctions).Parse(*templateString)
+	newTemplate, err := newTemplateWithDefinitions("virtualServerTemplate").Parse(*templateString)
 	if err != nil {
 		return err
 	}
@@ -119,8 +143,8 @@ func (te *TemplateExecutor) ExecuteTLSPassthroughHostsTemplate(cfg *TLSPassthrou
 	return configBuffer.Bytes(), nil
 }
 
-// ExecuteOIDCTemplate generates the content of an OIDC configuration file.
-func (te *TemplateExecutor) ExecuteOIDCTemplate(cfg *OIDC) ([]byte, error) {
+// ExecuteOIDCTemplate generates the content of an OIDC configuration file for the OIDC providers of a host.
+func (te *TemplateExecutor) ExecuteOIDCTemplate(cfg []*OIDC) ([]byte, error) {
 	var configBuffer bytes.Buffer
 	if err := te.oidcTemplate.Execute(&configBuffer, cfg); err != nil {
 		return nil, err
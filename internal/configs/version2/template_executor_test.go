@@ -50,6 +50,32 @@ func TestTemplateExecutorUsesOriginalVStemplate(t *testing.T) {
 	t.Logf("\n%s\n", string(vsConfig))
 }
 
+func TestTemplateExecutorSharesOIDCProviderVarsWithCustomVStemplate(t *testing.T) {
+	t.Parallel()
+
+	te := newTestTemplateExecutor(t)
+	customTemplate := `{{ range $oidc := .Server.OIDCProviders }}{{ template "oidcProviderVars" $oidc }}{{ end }}`
+	if err := te.UpdateVirtualServerTemplate(&customTemplate); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := VirtualServerConfig{
+		Server: Server{
+			OIDCProviders: []*OIDC{{ID: "default-oidc-policy", ClientID: "nginx-plus"}},
+		},
+	}
+	vsConfig, err := te.ExecuteVirtualServerTemplate(&cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{`set $oidc_provider "default-oidc-policy";`, `set $oidc_client "nginx-plus";`} {
+		if !strings.Contains(string(vsConfig), want) {
+			t.Errorf("Virtual Server config doesn't contain %s", want)
+		}
+	}
+}
+
 func TestTemplateExecutorUsesOriginalTStemplate(t *testing.T) {
 	t.Parallel()
 
@@ -222,10 +248,13 @@ map $request_method $cache_purge_{{ replaceAll $l.Cache.ZoneName "-" "_" }} {
 {{- end }}
 {{- end }}
 
-{{- if $s.OIDC  }}
+{{- if $s.OIDCProviders }}
 keyval $idp_sid $client_sid              zone=oidc_sids;
-{{- if $s.OIDC.PKCEEnable }}
+{{- range $oidc := $s.OIDCProviders }}
+{{- if $oidc.PKCEEnable }}
 keyval $pkce_id $pkce_code_verifier zone=oidc_pkce;
+{{- break }}
+{{- end }}
 {{- end }}
 {{- end }}
 
@@ -242,27 +271,15 @@ server {
     set $resource_namespace "{{$s.VSNamespace}}";
     set $service "-";
 
-    {{- with $oidc := $s.OIDC }}
+    {{- if $s.OIDCProviders }}
     include oidc-conf.d/oidc_{{$s.VSNamespace}}_{{$s.VSName}}.conf;
 
     {{- if eq $s.NGINXDebugLevel "debug" }}
     set $oidc_debug true;
     {{- end }}
 
-    set $oidc_pkce_enable {{ boolToInteger $oidc.PKCEEnable }};
     set $oidc_client_auth_method "client_secret_post";
-    set $oidc_logout_redirect "{{ $oidc.PostLogoutRedirectURI }}";
     set $oidc_hmac_key "{{ $s.VSName }}";
-    set $zone_sync_leeway {{ $oidc.ZoneSyncLeeway }};
-
-    set $oidc_authz_endpoint "{{ $oidc.AuthEndpoint }}";
-    set $oidc_authz_extra_args "{{ $oidc.AuthExtraArgs }}";
-    set $oidc_token_endpoint "{{ $oidc.TokenEndpoint }}";
-    set $oidc_end_session_endpoint "{{ $oidc.EndSessionEndpoint }}";
-    set $oidc_jwt_keyfile "{{ $oidc.JwksURI }}";
-    set $oidc_scopes "{{ $oidc.Scope }}";
-    set $oidc_client "{{ $oidc.ClientID }}";
-    set $oidc_client_secret "{{ $oidc.ClientSecret }}";
     {{- end }}
 
     {{- with $ssl := $s.SSL }}
@@ -682,14 +699,34 @@ server {
         {{ $proxyOrGRPC }}_ssl_name {{ .SSLName }};
         {{- end }}
 
-        {{- if $l.OIDC }}
+        {{- with $oidc := $l.OIDC }}
         auth_jwt "" token=$session_jwt;
         error_page 401 = @do_oidc_flow;
-        auth_jwt_key_request /_jwks_uri;
+        auth_jwt_key_request /_jwks_uri_{{ $oidc.ID }};
         {{- $proxyOrGRPC }}_set_header username $jwt_claim_sub;
-            {{- if $s.OIDC.AccessTokenEnable }}
+            {{- if $oidc.AccessTokenEnable }}
         {{ $proxyOrGRPC }}_set_header Authorization "Bearer $access_token";
             {{- end }}
+            {{- range $h := $oidc.ClaimHeaders }}
+        {{ $proxyOrGRPC }}_set_header {{ $h.Name }} {{ $h.Value }};
+            {{- end }}
+            {{- with $oidc.Require }}
+        auth_jwt_require{{ range .Variables }} {{ . }}{{ end }} error={{ .Code }};
+            {{- end }}
+        set $oidc_provider "{{ $oidc.ID }}";
+        set $oidc_session_cookie "{{ $oidc.SessionCookie }}";
+        set $redir_location "{{ $oidc.RedirectURI }}";
+        set $oidc_pkce_enable {{ boolToInteger $oidc.PKCEEnable }};
+        set $oidc_logout_redirect "{{ $oidc.PostLogoutRedirectURI }}";
+        set $zone_sync_leeway {{ $oidc.ZoneSyncLeeway }};
+        set $oidc_authz_endpoint "{{ $oidc.AuthEndpoint }}";
+        set $oidc_authz_extra_args "{{ $oidc.AuthExtraArgs }}";
+        set $oidc_token_endpoint "{{ $oidc.TokenEndpoint }}";
+        set $oidc_end_session_endpoint "{{ $oidc.EndSessionEndpoint }}";
+        set $oidc_jwt_keyfile "{{ $oidc.JwksURI }}";
+        set $oidc_scopes "{{ $oidc.Scope }}";
+        set $oidc_client "{{ $oidc.ClientID }}";
+        set $oidc_client_secret "{{ $oidc.ClientSecret }}";
         {{- end }}
 
 
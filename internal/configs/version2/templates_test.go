@@ -144,7 +144,6 @@ func TestExecuteVirtualServerTemplate_RendersTemplateWithServerGunzipOn(t *testi
 		t.Error("want `gunzip on` directive, got no directive")
 	}
 	snaps.MatchSnapshot(t, string(got))
-	t.Log(string(got))
 }
 
 func TestExecuteVirtualServerTemplate_RendersTemplateWithServerGunzipOff(t *testing.T) {
@@ -601,6 +600,196 @@ func TestExecuteVirtualServerTemplate_RendersOSSTemplateWithHTTP2Off(t *testing.
 	t.Log(string(got))
 }
 
+func TestExecuteVirtualServerTemplate_RendersTemplateWithRetryBudget(t *testing.T) {
+	t.Parallel()
+
+	cfg := VirtualServerConfig{
+		RetryBudgetZone: &RetryBudgetZone{
+			Name:    "vs_default_cafe_retry_budget",
+			Size:    "1m",
+			Timeout: "10s",
+		},
+		Server: Server{
+			ServerName:  "cafe.example.com",
+			StatusZone:  "cafe.example.com",
+			VSNamespace: "default",
+			VSName:      "cafe",
+			Locations: []Location{
+				{
+					Path:                     "/tea",
+					ProxyPass:                "http://vs_default_cafe_tea",
+					ProxyNextUpstream:        "error timeout http_502 http_503 http_504",
+					ProxyNextUpstreamTimeout: "5s",
+					ProxyNextUpstreamTries:   3,
+					RetryBudget: &RetryBudget{
+						Zone:       "vs_default_cafe_retry_budget",
+						Key:        "retry_budget_0",
+						Percent:    20,
+						MinRetries: 3,
+						Path:       "/internal_location_retry_budget_0",
+					},
+				},
+				{
+					Path:                     "/internal_location_retry_budget_0",
+					Internal:                 true,
+					ProxyPass:                "http://vs_default_cafe_tea$request_uri",
+					ProxyNextUpstream:        "off",
+					ProxyNextUpstreamTimeout: "0s",
+				},
+			},
+		},
+	}
+
+	want := []string{
+		"js_shared_dict_zone zone=vs_default_cafe_retry_budget:1m type=number timeout=10s evict;",
+		`set $retry_budget_zone "vs_default_cafe_retry_budget";`,
+		`set $retry_budget_key "retry_budget_0";`,
+		"set $retry_budget_percent 20;",
+		"set $retry_budget_min_retries 3;",
+		"rewrite ^ /internal_location_retry_budget_0 last;",
+		"js_header_filter retry_budget.account;",
+		"proxy_next_upstream error timeout http_502 http_503 http_504;",
+		"proxy_next_upstream off;",
+	}
+
+	for _, executor := range []*TemplateExecutor{newTmplExecutorNGINX(t), newTmplExecutorNGINXPlus(t)} {
+		got, err := executor.ExecuteVirtualServerTemplate(&cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, w := range want {
+			if !bytes.Contains(got, []byte(w)) {
+				t.Errorf("want %q in generated template", w)
+			}
+		}
+		if n := bytes.Count(got, []byte("js_header_filter")); n != 1 {
+			t.Errorf("want 1 js_header_filter directive in generated template, got %d", n)
+		}
+		t.Log(string(got))
+	}
+}
+
+func TestExecuteVirtualServerTemplate_RendersTemplateWithResourceIdentityVariables(t *testing.T) {
+	t.Parallel()
+
+	cfg := VirtualServerConfig{
+		Server: Server{
+			ServerName:  "cafe.example.com",
+			StatusZone:  "cafe.example.com",
+			VSNamespace: "default",
+			VSName:      "cafe",
+			Locations: []Location{
+				{
+					Path:         "/tea",
+					ProxyPass:    "http://vs_default_cafe_tea",
+					ServiceName:  "tea-svc",
+					UpstreamName: "vs_default_cafe_tea",
+				},
+				{
+					Path:         "~ ^/coffee$",
+					ProxyPass:    "http://vs_default_cafe_vsr_default_coffee_coffee",
+					ServiceName:  "coffee-svc",
+					UpstreamName: "vs_default_cafe_vsr_default_coffee_coffee",
+					IsVSR:        true,
+					VSRName:      "coffee",
+					VSRNamespace: "default",
+				},
+				{
+					Path:         "/internal_location_splits_0_split_0",
+					Internal:     true,
+					ProxyPass:    "http://vs_default_cafe_tea-v2$request_uri",
+					ServiceName:  "tea-v2-svc",
+					UpstreamName: "vs_default_cafe_tea-v2",
+				},
+			},
+		},
+	}
+
+	want := []string{
+		`set $resource_type "virtualserver";`,
+		`set $resource_name "cafe";`,
+		`set $resource_namespace "default";`,
+		`set $route "/tea";`,
+		`set $upstream_name "vs_default_cafe_tea";`,
+		`set $route "~ ^/coffee";`,
+		`set $upstream_name "vs_default_cafe_vsr_default_coffee_coffee";`,
+		`set $resource_type "virtualserverroute";`,
+		`set $upstream_name "vs_default_cafe_tea-v2";`,
+	}
+
+	for _, executor := range []*TemplateExecutor{newTmplExecutorNGINX(t), newTmplExecutorNGINXPlus(t)} {
+		got, err := executor.ExecuteVirtualServerTemplate(&cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, w := range want {
+			if !bytes.Contains(got, []byte(w)) {
+				t.Errorf("want %q in generated template", w)
+			}
+		}
+		if n := bytes.Count(got, []byte("set $route ")); n != 2 {
+			t.Errorf("want 2 set $route directives in generated template, got %d", n)
+		}
+		t.Log(string(got))
+	}
+}
+
+func TestExecuteVirtualServerTemplate_RendersTemplateWithEjectedServers(t *testing.T) {
+	t.Parallel()
+
+	cfg := VirtualServerConfig{
+		Upstreams: []Upstream{
+			{
+				Name: "vs_default_cafe_tea",
+				Servers: []UpstreamServer{
+					{Address: "10.0.0.1:80"},
+					{Address: "10.0.0.2:80", Down: true},
+				},
+				MaxFails:         1,
+				MaxConns:         0,
+				FailTimeout:      "10s",
+				UpstreamZoneSize: "256k",
+			},
+			{
+				Name: "vs_default_cafe_coffee",
+				Servers: []UpstreamServer{
+					{Address: "10.0.0.3:80", Weight: 4},
+					{Address: "10.0.0.4:80", Weight: 1},
+				},
+				MaxFails:         1,
+				MaxConns:         0,
+				FailTimeout:      "10s",
+				UpstreamZoneSize: "256k",
+			},
+		},
+		Server: Server{
+			ServerName:  "cafe.example.com",
+			StatusZone:  "cafe.example.com",
+			VSNamespace: "default",
+			VSName:      "cafe",
+		},
+	}
+
+	executor := newTmplExecutorNGINX(t)
+	got, err := executor.ExecuteVirtualServerTemplate(&cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"server 10.0.0.1:80 max_fails=1 fail_timeout=10s max_conns=0;",
+		"server 10.0.0.2:80 max_fails=1 fail_timeout=10s max_conns=0 down;",
+		"server 10.0.0.3:80 weight=4 max_fails=1 fail_timeout=10s max_conns=0;",
+		"server 10.0.0.4:80 weight=1 max_fails=1 fail_timeout=10s max_conns=0;",
+	}
+	for _, w := range want {
+		if !bytes.Contains(got, []byte(w)) {
+			t.Errorf("want %q in generated template", w)
+		}
+	}
+	t.Log(string(got))
+}
+
 func TestVirtualServerForNginxPlusWithWAFApBundle(t *testing.T) {
 	t.Parallel()
 	executor := newTmplExecutorNGINXPlus(t)
@@ -664,6 +853,86 @@ func TestExecuteTemplateForNGINXPlusTransportServerWithSNI(t *testing.T) {
 	snaps.MatchSnapshot(t, string(got))
 }
 
+func TestExecuteTemplateForTransportServerWithPolicies(t *testing.T) {
+	t.Parallel()
+	policiesTransportServerCfg := transportServerCfg
+	policiesTransportServerCfg.LimitConnZones = []StreamLimitConnZone{
+		{
+			Key:      "$remote_addr",
+			ZoneName: "pol_cl_default_connection_limit_ts_default_tcp_server",
+			ZoneSize: "10m",
+		},
+	}
+	policiesTransportServerCfg.Server.Allow = []string{"10.0.0.0/8"}
+	policiesTransportServerCfg.Server.LimitConnections = []StreamLimitConn{
+		{
+			ZoneName:    "pol_cl_default_connection_limit_ts_default_tcp_server",
+			Connections: 10,
+		},
+	}
+	policiesTransportServerCfg.Server.LimitConnOptions = StreamLimitConnOptions{
+		DryRun:   true,
+		LogLevel: "warn",
+	}
+
+	wantDirectives := []string{
+		"limit_conn_zone $remote_addr zone=pol_cl_default_connection_limit_ts_default_tcp_server:10m;",
+		"allow 10.0.0.0/8;",
+		"deny all;",
+		"limit_conn pol_cl_default_connection_limit_ts_default_tcp_server 10;",
+		"limit_conn_dry_run on;",
+		"limit_conn_log_level warn;",
+	}
+
+	for _, executor := range []*TemplateExecutor{newTmplExecutorNGINX(t), newTmplExecutorNGINXPlus(t)} {
+		got, err := executor.ExecuteTransportServerTemplate(&policiesTransportServerCfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range wantDirectives {
+			if !bytes.Contains(got, []byte(want)) {
+				t.Errorf("want %q in generated config", want)
+			}
+		}
+		t.Log(string(got))
+	}
+}
+
+func TestExecuteTemplateForTransportServerWithIngressMTLS(t *testing.T) {
+	t.Parallel()
+	mtlsTransportServerCfg := transportServerCfgWithSSL
+	mtlsSSL := *transportServerCfgWithSSL.Server.SSL
+	mtlsSSL.IngressMTLS = &IngressMTLS{
+		ClientCert:   "/etc/nginx/secrets/default-ca-secret-ca.crt",
+		ClientCrl:    "/etc/nginx/secrets/default-ca-secret-ca.crl",
+		VerifyClient: "optional",
+		VerifyDepth:  2,
+	}
+	mtlsSSL.ProxyProtocol = true
+	mtlsTransportServerCfg.Server.SSL = &mtlsSSL
+
+	wantDirectives := []string{
+		"ssl_client_certificate /etc/nginx/secrets/default-ca-secret-ca.crt;",
+		"ssl_crl /etc/nginx/secrets/default-ca-secret-ca.crl;",
+		"ssl_verify_client optional;",
+		"ssl_verify_depth 2;",
+		"js_filter proxy_protocol.send;",
+	}
+
+	for _, executor := range []*TemplateExecutor{newTmplExecutorNGINX(t), newTmplExecutorNGINXPlus(t)} {
+		got, err := executor.ExecuteTransportServerTemplate(&mtlsTransportServerCfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range wantDirectives {
+			if !bytes.Contains(got, []byte(want)) {
+				t.Errorf("want %q in generated config", want)
+			}
+		}
+		t.Log(string(got))
+	}
+}
+
 func TestTransportServerForNginx(t *testing.T) {
 	t.Parallel()
 	executor := newTmplExecutorNGINX(t)
@@ -908,7 +1177,7 @@ func TestExecuteVirtualServerTemplateWithAPIKeyPolicyNGINXPlus(t *testing.T) {
 func TestExecuteVirtualServerTemplate_WithCustomOIDCRedirectLocation(t *testing.T) {
 	t.Parallel()
 	executor := newTmplExecutorNGINXPlus(t)
-	got, err := executor.ExecuteOIDCTemplate(virtualServerCfgWithOIDCAndCustomRedirectURI.Server.OIDC)
+	got, err := executor.ExecuteOIDCTemplate(virtualServerCfgWithOIDCAndCustomRedirectURI.Server.OIDCProviders)
 	if err != nil {
 		t.Error(err)
 	}
@@ -941,7 +1210,7 @@ func TestExecuteVirtualServerTemplate_WithCustomOIDCRedirectLocation(t *testing.
 func TestExecuteVirtualServerTemplate_WithOIDCTLSVerify(t *testing.T) {
 	t.Parallel()
 	executor := newTmplExecutorNGINXPlus(t)
-	got, err := executor.ExecuteOIDCTemplate(virtualServerCfgWithOIDCAndTLSVerify.Server.OIDC)
+	got, err := executor.ExecuteOIDCTemplate(virtualServerCfgWithOIDCAndTLSVerify.Server.OIDCProviders)
 	if err != nil {
 		t.Error(err)
 	}
@@ -961,6 +1230,75 @@ func TestExecuteVirtualServerTemplate_WithOIDCTLSVerify(t *testing.T) {
 	t.Log(string(got))
 }
 
+func TestExecuteVirtualServerTemplateWithMultipleOIDCProvidersNGINXPlus(t *testing.T) {
+	t.Parallel()
+
+	e := newTmplExecutorNGINXPlus(t)
+	got, err := e.ExecuteVirtualServerTemplate(&virtualServerCfgWithMultipleOIDCProviders)
+	if err != nil {
+		t.Error(err)
+	}
+
+	wantDirectives := []string{
+		"keyval $pkce_id $pkce_code_verifier zone=oidc_pkce;",
+		"auth_jwt_key_request /_jwks_uri_default_oidc_admin;",
+		"auth_jwt_key_request /_jwks_uri_default_oidc_users;",
+		`set $oidc_provider "default_oidc_admin";`,
+		`set $oidc_session_cookie "admin_token";`,
+		`set $redir_location "/admin/_codexch";`,
+		"proxy_set_header X-Email $oidc_claim_default_cafe_vs_default_oidc_admin_0;",
+		"auth_jwt_require $oidc_groups_default_cafe_vs_default_oidc_admin_1 error=403;",
+		`set $oidc_client "users-client";`,
+	}
+	for _, want := range wantDirectives {
+		if !bytes.Contains(got, []byte(want)) {
+			t.Errorf("want %q in generated template", want)
+		}
+	}
+
+	if n := bytes.Count(got, []byte("keyval $idp_sid $client_sid")); n != 1 {
+		t.Errorf("want the oidc_sids keyval once in generated template, got %d", n)
+	}
+
+	snaps.MatchSnapshot(t, string(got))
+	t.Log(string(got))
+}
+
+func TestExecuteOIDCTemplateWithMultipleOIDCProviders(t *testing.T) {
+	t.Parallel()
+
+	e := newTmplExecutorNGINXPlus(t)
+	got, err := e.ExecuteOIDCTemplate(virtualServerCfgWithMultipleOIDCProviders.Server.OIDCProviders)
+	if err != nil {
+		t.Error(err)
+	}
+
+	wantDirectives := []string{
+		"location = /_jwks_uri_default_oidc_admin {",
+		"location = /_token_default_oidc_admin {",
+		"location = /_refresh_default_oidc_admin {",
+		"location = /admin/_codexch {",
+		"location = /admin/logout {",
+		`add_header Set-Cookie "admin_token_nonce=; $oidc_cookie_flags";`,
+		"location = /_jwks_uri_default_oidc_users {",
+		"location = /_codexch {",
+		"location = /logout {",
+		`add_header Set-Cookie "auth_token=; $oidc_cookie_flags";`,
+	}
+	for _, want := range wantDirectives {
+		if !bytes.Contains(got, []byte(want)) {
+			t.Errorf("want %q in generated template", want)
+		}
+	}
+
+	if n := bytes.Count(got, []byte("location @do_oidc_flow {")); n != 1 {
+		t.Errorf("want the @do_oidc_flow location once in generated template, got %d", n)
+	}
+
+	snaps.MatchSnapshot(t, string(got))
+	t.Log(string(got))
+}
+
 func TestExecuteVirtualServerTemplateWithOIDCAndPKCEPolicyNGINXPlus(t *testing.T) {
 	t.Parallel()
 
@@ -1697,16 +2035,18 @@ var (
 				Realm:  "My Api",
 				Secret: "jwk-secret",
 			},
-			OIDC: &OIDC{
-				AuthEndpoint:          "https://idp.example.com/auth",
-				ClientID:              "test-client",
-				ClientSecret:          "test-secret",
-				JwksURI:               "https://idp.example.com/jwks",
-				TokenEndpoint:         "https://idp.example.com/token",
-				EndSessionEndpoint:    "https://idp.example.com/logout",
-				PostLogoutRedirectURI: "https://example.com/logout",
-				ZoneSyncLeeway:        0,
-				Scope:                 "openid+profile+email",
+			OIDCProviders: []*OIDC{
+				{
+					AuthEndpoint:          "https://idp.example.com/auth",
+					ClientID:              "test-client",
+					ClientSecret:          "test-secret",
+					JwksURI:               "https://idp.example.com/jwks",
+					TokenEndpoint:         "https://idp.example.com/token",
+					EndSessionEndpoint:    "https://idp.example.com/logout",
+					PostLogoutRedirectURI: "https://example.com/logout",
+					ZoneSyncLeeway:        0,
+					Scope:                 "openid+profile+email",
+				},
 			},
 			IngressMTLS: &IngressMTLS{
 				ClientCert:   "ingress-mtls-secret",
@@ -2972,8 +3312,10 @@ var (
 			VSNamespace:   "default",
 			VSName:        "exampleVS",
 			ProxyProtocol: true,
-			OIDC: &OIDC{
-				PKCEEnable: true,
+			OIDCProviders: []*OIDC{
+				{
+					PKCEEnable: true,
+				},
 			},
 			Locations: []Location{
 				{
@@ -2990,8 +3332,10 @@ var (
 			ProxyProtocol: true,
 			VSNamespace:   "default",
 			VSName:        "exampleVS",
-			OIDC: &OIDC{
-				PKCEEnable: true,
+			OIDCProviders: []*OIDC{
+				{
+					PKCEEnable: true,
+				},
 			},
 			NGINXDebugLevel: "debug",
 			Locations: []Location{
@@ -3009,8 +3353,10 @@ var (
 			ProxyProtocol: true,
 			VSNamespace:   "default",
 			VSName:        "exampleVS",
-			OIDC: &OIDC{
-				PKCEEnable: true,
+			OIDCProviders: []*OIDC{
+				{
+					PKCEEnable: true,
+				},
 			},
 			NGINXDebugLevel: "error",
 			Locations: []Location{
@@ -3026,8 +3372,13 @@ var (
 			ServerName:    "example.com",
 			StatusZone:    "example.com",
 			ProxyProtocol: true,
-			OIDC: &OIDC{
-				RedirectURI: "/custom-location",
+			OIDCProviders: []*OIDC{
+				{
+					RedirectURI:   "/custom-location",
+					LogoutURI:     "/logout",
+					SessionCookie: "auth_token",
+					ID:            "default_oidc_policy",
+				},
 			},
 			NGINXDebugLevel: "error",
 			Locations: []Location{
@@ -3043,22 +3394,91 @@ var (
 			ServerName:    "example.com",
 			StatusZone:    "example.com",
 			ProxyProtocol: true,
-			OIDC: &OIDC{
-				TLSVerify:             true,
-				VerifyDepth:           1,
-				CAFile:                "/etc/ssl/certs/ca-certificate.crt",
-				RedirectURI:           "/_codexch",
-				PostLogoutRedirectURI: "/_logout",
+			OIDCProviders: []*OIDC{
+				{
+					TLSVerify:             true,
+					VerifyDepth:           1,
+					CAFile:                "/etc/ssl/certs/ca-certificate.crt",
+					RedirectURI:           "/_codexch",
+					LogoutURI:             "/logout",
+					PostLogoutRedirectURI: "/_logout",
+					SessionCookie:         "auth_token",
+					ID:                    "default_oidc_policy",
+				},
+			},
+			NGINXDebugLevel: "error",
+			Locations: []Location{
+				{
+					Path: "/",
+				},
+			},
+		},
+	}
+
+	virtualServerCfgWithMultipleOIDCProviders = VirtualServerConfig{
+		AuthJWTClaimSets: []AuthJWTClaimSet{
+			{Variable: "$oidc_claim_default_cafe_vs_default_oidc_admin_0", Claim: "email"},
+			{Variable: "$oidc_claim_default_cafe_vs_default_oidc_admin_1", Claim: "groups"},
+		},
+		Server: Server{
+			ServerName:    "example.com",
+			StatusZone:    "example.com",
+			VSNamespace:   "default",
+			VSName:        "cafe",
+			ProxyProtocol: true,
+			OIDCProviders: []*OIDC{
+				oidcProviderAdmin,
+				oidcProviderUsers,
 			},
 			NGINXDebugLevel: "error",
 			Locations: []Location{
+				{
+					Path: "/admin",
+					OIDC: oidcProviderAdmin,
+				},
 				{
 					Path: "/",
+					OIDC: oidcProviderUsers,
 				},
 			},
 		},
 	}
 
+	oidcProviderAdmin = &OIDC{
+		AuthEndpoint:          "https://admin-idp.example.com/auth",
+		TokenEndpoint:         "https://admin-idp.example.com/token",
+		JwksURI:               "https://admin-idp.example.com/jwks",
+		ClientID:              "admin-client",
+		ClientSecret:          "admin-secret",
+		Scope:                 "openid",
+		RedirectURI:           "/admin/_codexch",
+		LogoutURI:             "/admin/logout",
+		PostLogoutRedirectURI: "/_logout",
+		SessionCookie:         "admin_token",
+		ID:                    "default_oidc_admin",
+		ClaimHeaders: []Header{
+			{Name: "X-Email", Value: "$oidc_claim_default_cafe_vs_default_oidc_admin_0"},
+		},
+		Require: &JWTRequire{
+			Variables: []string{"$oidc_groups_default_cafe_vs_default_oidc_admin_1"},
+			Code:      403,
+		},
+	}
+
+	oidcProviderUsers = &OIDC{
+		AuthEndpoint:          "https://idp.example.com/auth",
+		TokenEndpoint:         "https://idp.example.com/token",
+		JwksURI:               "https://idp.example.com/jwks",
+		ClientID:              "users-client",
+		Scope:                 "openid",
+		RedirectURI:           "/_codexch",
+		LogoutURI:             "/logout",
+		PostLogoutRedirectURI: "/_logout",
+		SessionCookie:         "auth_token",
+		PKCEEnable:            true,
+		ID:                    "default_oidc_users",
+	}
+
 	virtualServerCfgWithCachePolicyNGINXPlus = VirtualServerConfig{
 		CacheZones: []CacheZone{
 			{
@@ -3519,6 +3939,422 @@ var (
 	}
 )
 
+func TestExecuteVirtualServerTemplate_RendersTemplateWithJWTRequire(t *testing.T) {
+	t.Parallel()
+	executor := newTmplExecutorNGINXPlus(t)
+	jwtRequireCfg := virtualServerCfg
+	jwtRequireCfg.AuthJWTClaimSets = []AuthJWTClaimSet{
+		{
+			Variable: "$jwt_claim_default_cafe_vs_default_jwt_policy_0",
+			Claim:    "realm_access roles",
+		},
+	}
+	jwtRequireCfg.Maps = []Map{
+		{
+			Source:   "$jwt_claim_default_cafe_vs_default_jwt_policy_0",
+			Variable: "$jwt_require_default_cafe_vs_default_jwt_policy_0",
+			Parameters: []Parameter{
+				{Value: "default", Result: "0"},
+				{Value: `"~(^|,)admin(,|$)"`, Result: "1"},
+			},
+		},
+	}
+	jwtRequireCfg.Server.JWTAuth = &JWTAuth{
+		Secret: "/etc/nginx/secrets/default-jwt-secret",
+		Realm:  "My API",
+		Require: &JWTRequire{
+			Variables: []string{"$jwt_require_default_cafe_vs_default_jwt_policy_0"},
+			Code:      403,
+		},
+	}
+
+	got, err := executor.ExecuteVirtualServerTemplate(&jwtRequireCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantedStrings := []string{
+		"auth_jwt_claim_set $jwt_claim_default_cafe_vs_default_jwt_policy_0 realm_access roles;",
+		`"~(^|,)admin(,|$)" 1;`,
+		"auth_jwt_require $jwt_require_default_cafe_vs_default_jwt_policy_0 error=403;",
+	}
+	for _, value := range wantedStrings {
+		if !bytes.Contains(got, []byte(value)) {
+			t.Errorf("didn't get `%s`", value)
+		}
+	}
+}
+
+func TestExecuteVirtualServerTemplate_RendersTemplateWithIntrospection(t *testing.T) {
+	t.Parallel()
+	executor := newTmplExecutorNGINXPlus(t)
+	introspection := Introspection{
+		Path:          "/_introspection_default_cafe_vs_default_introspection_policy",
+		EndpointPath:  "/_introspection_endpoint_default_cafe_vs_default_introspection_policy",
+		ClientAuth:    "Y2xpZW50OnNlY3JldA==",
+		CacheVariable: "introspection_cache_default_cafe_vs_default_introspection_policy",
+		Claims:        "sub",
+	}
+	introspectionCfg := virtualServerCfg
+	introspectionCfg.KeyValZones = []KeyValZone{
+		{
+			Name:    "introspection_default_cafe_vs_default_introspection_policy",
+			Size:    "1m",
+			Timeout: "30s",
+		},
+	}
+	introspectionCfg.KeyVals = []KeyVal{
+		{
+			Key:      "$introspection_token_hash",
+			Variable: "$introspection_cache_default_cafe_vs_default_introspection_policy",
+			ZoneName: "introspection_default_cafe_vs_default_introspection_policy",
+		},
+	}
+	introspectionCfg.Server.ExternalAuth = &ExternalAuth{
+		URI: &AuthURI{
+			Service:      "idp",
+			Upstream:     "vs_exauth_default_introspection-policy",
+			Path:         "/oauth2/introspect",
+			InternalPath: "/_introspection_endpoint_default_cafe_vs_default_introspection_policy",
+		},
+		AuthRequestPath: introspection.Path,
+		AuthRequestHeaders: []AuthRequestHeader{
+			{Name: "X-User", Variable: "$introspection_claim_default_cafe_vs_default_introspection_policy_0", Value: "$sent_http_x_introspection_claim_0"},
+		},
+		Introspection: &introspection,
+	}
+	introspectionCfg.Server.Introspections = []Introspection{introspection}
+
+	got, err := executor.ExecuteVirtualServerTemplate(&introspectionCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantedStrings := []string{
+		"keyval_zone zone=introspection_default_cafe_vs_default_introspection_policy:1m timeout=30s;",
+		"keyval $introspection_token_hash $introspection_cache_default_cafe_vs_default_introspection_policy zone=introspection_default_cafe_vs_default_introspection_policy;",
+		"location = /_introspection_default_cafe_vs_default_introspection_policy {",
+		"set $introspection_endpoint /_introspection_endpoint_default_cafe_vs_default_introspection_policy;",
+		"set $introspection_cache introspection_cache_default_cafe_vs_default_introspection_policy;",
+		`set $introspection_claims "sub";`,
+		"js_content introspection.validate;",
+		"auth_request /_introspection_default_cafe_vs_default_introspection_policy;",
+		"auth_request_set $introspection_claim_default_cafe_vs_default_introspection_policy_0 $sent_http_x_introspection_claim_0;",
+		"proxy_set_header X-User $introspection_claim_default_cafe_vs_default_introspection_policy_0;",
+	}
+	for _, value := range wantedStrings {
+		if !bytes.Contains(got, []byte(value)) {
+			t.Errorf("didn't get `%s`", value)
+		}
+	}
+	if bytes.Contains(got, []byte("auth_request /_introspection_endpoint_")) {
+		t.Error("got auth_request to the introspection endpoint location")
+	}
+}
+
+func TestExecuteVirtualServerTemplate_RendersTemplateWithExternalAuthGRPC(t *testing.T) {
+	t.Parallel()
+	executor := newTmplExecutorNGINX(t)
+	grpc := ExternalAuthGRPC{
+		Path:         "/_external_authz_default_cafe_vs_default_grpc_ext_auth",
+		EndpointPath: "/_external_authz_grpc_default_cafe_vs_default_grpc_ext_auth",
+		Headers:      "X-User",
+	}
+	grpcCfg := virtualServerCfg
+	grpcCfg.Server.ExternalAuth = &ExternalAuth{
+		URI: &AuthURI{
+			Service:      "opa",
+			Upstream:     "vs_exauth_default_grpc-ext-auth",
+			Path:         "/",
+			InternalPath: grpc.EndpointPath,
+		},
+		AuthRequestPath: grpc.Path,
+		AuthRequestHeaders: []AuthRequestHeader{
+			{Name: "X-User", Variable: "$ext_authz_header_default_cafe_vs_default_grpc_ext_auth_0", Value: "$sent_http_x_ext_authz_header_0"},
+		},
+		GRPC: &grpc,
+	}
+	grpcCfg.Server.ExternalAuthGRPCs = []ExternalAuthGRPC{grpc}
+
+	got, err := executor.ExecuteVirtualServerTemplate(&grpcCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantedStrings := []string{
+		"location = /_external_authz_default_cafe_vs_default_grpc_ext_auth {",
+		"set $ext_authz_endpoint /_external_authz_grpc_default_cafe_vs_default_grpc_ext_auth;",
+		`set $ext_authz_headers "X-User";`,
+		"js_content ext_authz.check;",
+		"auth_request /_external_authz_default_cafe_vs_default_grpc_ext_auth;",
+		"auth_request_set $ext_authz_header_default_cafe_vs_default_grpc_ext_auth_0 $sent_http_x_ext_authz_header_0;",
+		"proxy_set_header X-User $ext_authz_header_default_cafe_vs_default_grpc_ext_auth_0;",
+	}
+	for _, value := range wantedStrings {
+		if !bytes.Contains(got, []byte(value)) {
+			t.Errorf("didn't get `%s`", value)
+		}
+	}
+	if bytes.Contains(got, []byte("auth_request /_external_authz_grpc_")) {
+		t.Error("got auth_request to the external auth gRPC location")
+	}
+}
+
+func TestExecuteVirtualServerTemplate_RendersTemplateWithHMAC(t *testing.T) {
+	t.Parallel()
+	executor := newTmplExecutorNGINX(t)
+	hmacCfg := virtualServerCfg
+	hmacCfg.Server.InternalRedirectLocations = []InternalRedirectLocation{
+		{
+			Path:        "/tea",
+			Destination: "/internal_location_hmac_0",
+			HMAC: &HMAC{
+				KeysFile:        "/etc/nginx/secrets/default-hmac-secret",
+				KeyIDHeader:     "X-Key-Id",
+				SignatureHeader: "X-Signature",
+				TimestampHeader: "X-Timestamp",
+				CanonicalString: "{timestamp}.{body}",
+				ClockSkew:       300,
+				MaxBodySize:     "1m",
+				RejectCode:      401,
+			},
+		},
+		{
+			Path:        "/coffee",
+			Destination: "$vs_default_cafe_splits_0",
+		},
+	}
+
+	got, err := executor.ExecuteVirtualServerTemplate(&hmacCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantedStrings := []string{
+		"client_body_buffer_size 1m;",
+		`set $hmac_auth_keys_file /etc/nginx/secrets/default-hmac-secret;`,
+		`set $hmac_auth_key_id_header "X-Key-Id";`,
+		`set $hmac_auth_canonical_string "{timestamp}.{body}";`,
+		"set $hmac_auth_clock_skew 300;",
+		"set $hmac_auth_reject_code 401;",
+		"set $hmac_auth_destination /internal_location_hmac_0;",
+		"js_content hmac_auth.verify;",
+		"rewrite ^ $vs_default_cafe_splits_0 last;",
+	}
+	for _, value := range wantedStrings {
+		if !bytes.Contains(got, []byte(value)) {
+			t.Errorf("didn't get `%s`", value)
+		}
+	}
+	if bytes.Contains(got, []byte("rewrite ^ /internal_location_hmac_0 last;")) {
+		t.Error("got the redirect to the internal location without the HMAC verification")
+	}
+}
+
+func TestExecuteVirtualServerTemplate_RendersTemplateWithPolicyDryRun(t *testing.T) {
+	t.Parallel()
+	executor := newTmplExecutorNGINXPlus(t)
+	dryRunAuth := PolicyDryRunAuth{
+		Policy:     "default/jwt-policy",
+		Path:       "/_policy_dry_run_default_cafe_vs_default_jwt_policy",
+		ServerName: "policy_dry_run_default_cafe_vs_default_jwt_policy",
+		JWTAuth: &JWTAuth{
+			Key:   "default/jwt-policy",
+			Realm: "My Api",
+			JwksURI: JwksURI{
+				JwksScheme: "https",
+				JwksHost:   "idp.example.com",
+				JwksPath:   "/keys",
+			},
+		},
+	}
+	dryRunCfg := virtualServerCfg
+	dryRunCfg.Geos = []Geo{
+		{
+			Variable: "$policy_dry_run_default_cafe_vs_default_allow_policy",
+			Parameters: []Parameter{
+				{Value: "default", Result: `"default/allow-policy"`},
+				{Value: "10.0.0.0/8", Result: `""`},
+			},
+		},
+	}
+	dryRunCfg.Server.PolicyDryRun = &PolicyDryRun{
+		AccessControl: "$policy_dry_run_default_cafe_vs_default_allow_policy",
+		Auth:          &dryRunAuth,
+	}
+	dryRunCfg.Server.PolicyDryRunAuths = []PolicyDryRunAuth{dryRunAuth}
+
+	got, err := executor.ExecuteVirtualServerTemplate(&dryRunCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantedStrings := []string{
+		"geo $policy_dry_run_default_cafe_vs_default_allow_policy {",
+		`default "default/allow-policy";`,
+		"set $policy_dry_run_access_control $policy_dry_run_default_cafe_vs_default_allow_policy;",
+		"auth_request /_policy_dry_run_default_cafe_vs_default_jwt_policy;",
+		"auth_request_set $policy_dry_run_auth $upstream_http_x_policy_dry_run;",
+		"proxy_pass http://unix:/var/lib/nginx/nginx-policy-dry-run.sock:$request_uri;",
+		"proxy_set_header Host policy_dry_run_default_cafe_vs_default_jwt_policy;",
+		"server_name policy_dry_run_default_cafe_vs_default_jwt_policy;",
+		`auth_jwt "My Api";`,
+		"auth_jwt_key_request /_jwks_uri_server_default/jwt-policy;",
+		"location = /_jwks_uri_server_default/jwt-policy {",
+		`add_header X-Policy-Dry-Run "default/jwt-policy" always;`,
+		"js_content policy_dry_run.pass;",
+	}
+	for _, value := range wantedStrings {
+		if !bytes.Contains(got, []byte(value)) {
+			t.Errorf("didn't get `%s`", value)
+		}
+	}
+}
+
+func TestExecuteVirtualServerTemplate_RendersTemplateWithAPIKeyClientIDHeader(t *testing.T) {
+	t.Parallel()
+	executor := newTmplExecutorNGINX(t)
+	apiKeyCfg := virtualServerCfg
+	apiKeyCfg.Server.APIKeyEnabled = true
+	apiKeyCfg.Server.APIKey = &APIKey{
+		Header:         []string{"X-API-Key"},
+		MapName:        "apikey_auth_client_name_default_cafe_vs_api_key_policy",
+		ClientIDHeader: "X-Client-Id",
+	}
+
+	got, err := executor.ExecuteVirtualServerTemplate(&apiKeyCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "proxy_set_header X-Client-Id $apikey_client_name;"
+	if !bytes.Contains(got, []byte(want)) {
+		t.Errorf("didn't get `%s`", want)
+	}
+}
+
+func TestExecuteVirtualServerTemplate_RendersAPIKeyAuthURIOfRoutes(t *testing.T) {
+	t.Parallel()
+
+	apiKeyCfg := virtualServerCfg
+	apiKeyCfg.Server.APIKeyEnabled = true
+	apiKeyCfg.Server.APIKey = &APIKey{
+		Header:  []string{"X-API-Key"},
+		MapName: "apikey_auth_client_name_default_cafe_vs_api_key_policy",
+	}
+
+	// the routes of the clients are matched against the normalized URI of the request, not $request_uri,
+	// so that paths like /split/../admin or /split/..%2Fadmin don't match the /split route
+	wantedStrings := []string{
+		"location /split {\n        set $apikey_auth_uri $uri;\n        rewrite ^ @split_0 last;",
+		"location /coffee {\n        set $apikey_auth_uri $uri;\n        rewrite ^ @match last;",
+		"set $route \"/return\";\n        set $apikey_auth_uri $uri;",
+	}
+
+	for _, executor := range []*TemplateExecutor{newTmplExecutorNGINX(t), newTmplExecutorNGINXPlus(t)} {
+		got, err := executor.ExecuteVirtualServerTemplate(&apiKeyCfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, value := range wantedStrings {
+			if !bytes.Contains(got, []byte(value)) {
+				t.Errorf("didn't get `%s`", value)
+			}
+		}
+		// the subrequests to the validation location must not overwrite the URI of the request
+		_, validateLocation, _ := bytes.Cut(got, []byte("location = /_validate_apikey_njs {"))
+		validateLocation, _, _ = bytes.Cut(validateLocation, []byte("}"))
+		if bytes.Contains(validateLocation, []byte("$apikey_auth_uri")) {
+			t.Errorf("got `$apikey_auth_uri` in the validation location:%s", validateLocation)
+		}
+	}
+
+	got, err := newTmplExecutorNGINX(t).ExecuteVirtualServerTemplate(&virtualServerCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(got, []byte("$apikey_auth_uri")) {
+		t.Error("got `$apikey_auth_uri` without API Key policies")
+	}
+}
+
+func TestExecuteVirtualServerTemplate_RendersTemplateWithGeoRestriction(t *testing.T) {
+	t.Parallel()
+	geoCfg := virtualServerCfg
+	geoCfg.Maps = []Map{
+		{
+			Source:   `"$geoip2_country_code:$geoip2_continent_code:$geoip2_asn"`,
+			Variable: "$geo_policy_default_cafe_vs_default_geo_policy",
+			Parameters: []Parameter{
+				{Value: "default", Result: `""`},
+				{Value: `"~^(KP|IR):"`, Result: `"1"`},
+			},
+		},
+	}
+	geoCfg.Server.GeoRestriction = &GeoRestriction{
+		Variable:   "$geo_policy_default_cafe_vs_default_geo_policy",
+		RejectCode: 451,
+	}
+	wantedStrings := []string{
+		`map "$geoip2_country_code:$geoip2_continent_code:$geoip2_asn" $geo_policy_default_cafe_vs_default_geo_policy {`,
+		`"~^(KP|IR):" "1";`,
+		"if ($geo_policy_default_cafe_vs_default_geo_policy) {",
+		"return 451;",
+	}
+
+	for name, executor := range map[string]*TemplateExecutor{
+		"NGINX Plus": newTmplExecutorNGINXPlus(t),
+		"NGINX":      newTmplExecutorNGINX(t),
+	} {
+		got, err := executor.ExecuteVirtualServerTemplate(&geoCfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, value := range wantedStrings {
+			if !bytes.Contains(got, []byte(value)) {
+				t.Errorf("didn't get `%s` for the case of %s", value, name)
+			}
+		}
+	}
+}
+
+func TestExecuteVirtualServerTemplate_RendersTemplateWithTracing(t *testing.T) {
+	t.Parallel()
+	executor := newTmplExecutorNGINX(t)
+	tracingCfg := virtualServerCfg
+	tracingCfg.SplitClients = []SplitClient{
+		{
+			Source:   "$otel_trace_id",
+			Variable: "$tracing_policy_default_cafe_vs_default_tracing_policy",
+			Distributions: []Distribution{
+				{Weight: "12.5%", Value: "on"},
+				{Weight: "*", Value: "off"},
+			},
+		},
+	}
+	tracingCfg.Server.Tracing = &Tracing{
+		Trace:    "$tracing_policy_default_cafe_vs_default_tracing_policy",
+		Context:  "ignore",
+		SpanName: "${request_method} ${request_uri}",
+		SpanAttributes: []SpanAttribute{
+			{Name: "tenant", Value: "${http_x_tenant}"},
+		},
+		B3: true,
+	}
+
+	got, err := executor.ExecuteVirtualServerTemplate(&tracingCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantedStrings := []string{
+		"split_clients $otel_trace_id $tracing_policy_default_cafe_vs_default_tracing_policy {",
+		"otel_trace $tracing_policy_default_cafe_vs_default_tracing_policy;",
+		"otel_trace_context ignore;",
+		`otel_span_name "${request_method} ${request_uri}";`,
+		`otel_span_attr tenant "${http_x_tenant}";`,
+		`proxy_set_header b3 "$otel_trace_id-$otel_span_id";`,
+	}
+	for _, value := range wantedStrings {
+		if !bytes.Contains(got, []byte(value)) {
+			t.Errorf("didn't get `%s`", value)
+		}
+	}
+}
+
 func TestJWTSSLVerificationDefaultCert(t *testing.T) {
 	t.Parallel()
 	executor := newTmplExecutorNGINXPlus(t)
@@ -3,9 +3,11 @@ package configs
 import (
 	"fmt"
 	"math"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 	"unicode"
 
 	"github.com/nginx/kubernetes-ingress/internal/configs/version2"
@@ -32,6 +34,15 @@ const (
 	splitClientsKeyValZoneSize                      = "100k"
 	splitClientAmountWhenWeightChangesDynamicReload = 101
 	defaultLogOutput                                = "syslog:server=localhost:514"
+	retryBudgetZoneSize                             = "1m"
+	defaultRetryBudgetMinRetries                    = 3
+	defaultOutlierDetectionConsecutiveErrors        = 5
+	defaultOutlierDetectionEjectionTime             = 30 * time.Second
+	defaultOutlierDetectionMaxEjectionPercent       = 10
+	outlierDetectionStatusMatch                     = "! 500-599"
+	defaultOutlierDetectionSlowStart                = "30s"
+	// retryBudgetWindow must match the window of the retry budgets in retry_budget.js.
+	retryBudgetWindow = "10s"
 )
 
 var grpcConflictingErrors = map[int]bool{
@@ -102,6 +113,14 @@ type VirtualServerEx struct {
 	DosProtectedRefs            map[string]*unstructured.Unstructured
 	DosProtectedEx              map[string]*DosEx
 	ZoneSync                    bool
+	// DefaultPolicies are the default policies of the GlobalConfiguration applied to the VirtualServer.
+	DefaultPolicies []conf_v1.PolicyReference
+	// VirtualServerRouteDefaultPolicies are the default policies of the GlobalConfiguration applied to the subroutes of
+	// the VirtualServerRoutes, keyed by the namespace/name of the VirtualServerRoute.
+	VirtualServerRouteDefaultPolicies map[string][]conf_v1.PolicyReference
+	// RolloutWeights are the weights of the second splits of the routes with progressive rollouts, keyed by the paths
+	// of the routes. They override the weights of the splits in the spec of the VirtualServer.
+	RolloutWeights map[string]int
 }
 
 func (vsx *VirtualServerEx) String() string {
@@ -252,6 +271,21 @@ func (namer *VariableNamer) GetNameForSplitClientVariable(index int) string {
 	return fmt.Sprintf("$vs_%s_splits_%d", namer.safeNsName, index)
 }
 
+// GetNameForMirrorVariable gets the name of a variable used for sampling mirrored requests for a particular mirror index.
+func (namer *VariableNamer) GetNameForMirrorVariable(index int) string {
+	return fmt.Sprintf("$vs_%s_mirror_%d", namer.safeNsName, index)
+}
+
+// GetNameOfRetryBudgetZone gets the name of the shared dictionary zone for retry budgets.
+func (namer *VariableNamer) GetNameOfRetryBudgetZone() string {
+	return fmt.Sprintf("vs_%s_retry_budget", namer.safeNsName)
+}
+
+// GetNameOfRetryBudgetKey gets the name of the key of a retry budget in the shared dictionary zone for a particular index.
+func (namer *VariableNamer) GetNameOfRetryBudgetKey(index int) string {
+	return fmt.Sprintf("retry_budget_%d", index)
+}
+
 // GetNameForVariableForMatchesRouteMap gets the name of a matches route map
 func (namer *VariableNamer) GetNameForVariableForMatchesRouteMap(
 	matchesIndex int,
@@ -302,6 +336,7 @@ type virtualServerConfigurator struct {
 	spiffeCerts                bool
 	enableInternalRoutes       bool
 	isIPV6Disabled             bool
+	enableOutlierDetection     bool
 	DynamicSSLReloadEnabled    bool
 	StaticSSLPath              string
 	CABundlePath               string
@@ -347,6 +382,7 @@ func newVirtualServerConfigurator(
 		spiffeCerts:                staticParams.NginxServiceMesh,
 		enableInternalRoutes:       staticParams.EnableInternalRoutes,
 		isIPV6Disabled:             staticParams.DisableIPV6,
+		enableOutlierDetection:     staticParams.EnableOutlierDetection,
 		DynamicSSLReloadEnabled:    staticParams.DynamicSSLReload,
 		StaticSSLPath:              staticParams.StaticSSLPath,
 		CABundlePath:               staticParams.DefaultCABundle,
@@ -432,6 +468,8 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 		apResources:     apResources,
 		defaultCABundle: vsc.CABundlePath,
 		replicas:        vsc.IngressControllerReplicas,
+		otelEnabled:     vsc.cfgParams.MainOtelLoadModule,
+		oidcProviders:   map[string]*oidcProvider{},
 	}
 
 	ownerDetails := policyOwnerDetails{
@@ -442,16 +480,11 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 		parentName:      vsEx.VirtualServer.Name,
 		parentType:      "vs",
 	}
-	policiesCfg, warnings := generatePolicies(vsc.cfgParams.Context, ownerDetails, vsEx.VirtualServer.Spec.Policies, vsEx.Policies, specContext, "/", policyOpts, vsc.bundleValidator)
+	specPolicies := withDefaultPolicies(vsEx.DefaultPolicies, vsEx.VirtualServer.Spec.Policies, vsEx.VirtualServer.Namespace)
+	policiesCfg, warnings := generatePolicies(vsc.cfgParams.Context, ownerDetails, specPolicies, vsEx.Policies, specContext, "/", policyOpts, vsc.bundleValidator)
 	if len(warnings) > 0 {
 		vsc.mergeWarnings(warnings)
 	}
-	if policiesCfg.OIDC != nil {
-		// Store the OIDC policy name and built config for reuse in further calls to generatePolicies
-		// for routes and subroutes.
-		policyOpts.oidcPolicyName = policiesCfg.OIDC.PolicyName
-		policyOpts.oidcConfig = policiesCfg.OIDC
-	}
 	if policiesCfg.JWTAuth.JWKSEnabled {
 		jwtAuthKey := policiesCfg.JWTAuth.Auth.Key
 		policiesCfg.JWTAuth.List = make(map[string]*version2.JWTAuth)
@@ -475,6 +508,21 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 	if policiesCfg.CORSMap != nil {
 		maps = append(maps, *policiesCfg.CORSMap)
 	}
+	if policiesCfg.GeoMap != nil {
+		maps = append(maps, *policiesCfg.GeoMap)
+	}
+	var tracingSamplers []version2.SplitClient
+	addTracingSampler(&tracingSamplers, policiesCfg.TracingSampler)
+
+	if policiesCfg.Headers != nil {
+		maps = append(maps, policiesCfg.Headers.Maps...)
+	}
+
+	maps = append(maps, policiesCfg.JWTAuth.Maps...)
+
+	var geos []version2.Geo
+	var policyDryRunAuths []version2.PolicyDryRunAuth
+	addPolicyDryRunConfig(&geos, &policyDryRunAuths, policiesCfg)
 
 	dosCfg := generateDosCfg(dosResources[""])
 
@@ -499,6 +547,7 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 
 	limitReqZones = append(limitReqZones, policiesCfg.RateLimit.Zones...)
 	authJWTClaimSets = append(authJWTClaimSets, policiesCfg.RateLimit.AuthJWTClaimSets...)
+	authJWTClaimSets = append(authJWTClaimSets, policiesCfg.JWTAuth.ClaimSets...)
 
 	// Add cache zone from global policy if present
 	addCacheZone(&cacheZones, policiesCfg.Cache)
@@ -547,6 +596,8 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 	var keyValZones []version2.KeyValZone
 	var keyVals []version2.KeyVal
 	var twoWaySplitClients []version2.TwoWaySplitClients
+	var introspections []version2.Introspection
+	var externalAuthGRPCs []version2.ExternalAuthGRPC
 	vsrErrorPagesFromVs := make(map[string][]conf_v1.ErrorPage)
 	vsrErrorPagesRouteIndex := make(map[string]int)
 	vsrLocationSnippetsFromVs := make(map[string]string)
@@ -578,6 +629,16 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 
 		locations = append(locations, vsc.generateExternalAuthLocation(policiesCfg, proxyPassUpstream))
 
+		// the introspection handler validates the requests through the external auth location
+		if in := policiesCfg.ExternalAuth.Introspection; in != nil {
+			introspections = append(introspections, *in)
+			keyValZones = append(keyValZones, in.CacheZone)
+			keyVals = append(keyVals, in.CacheKeyVal)
+		}
+		if g := policiesCfg.ExternalAuth.GRPC; g != nil {
+			externalAuthGRPCs = append(externalAuthGRPCs, *g)
+		}
+
 		upstreams, healthChecks, statusMatches = generateUpstreams(
 			sslConfig,
 			vsc,
@@ -603,13 +664,9 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 		}
 	}
 
-	// specHasOIDC records whether the VirtualServer spec itself carries an OIDC policy.
-	// It is used below to inherit spec-level OIDC to routes that don't define their own,
-	// without allowing a route-level OIDC assignment to bleed into subsequent routes.
-	specHasOIDC := policiesCfg.OIDC != nil
-
 	// generates config for VirtualServer routes
 	for _, r := range vsEx.VirtualServer.Spec.Routes {
+		r = applyRolloutWeight(r, vsEx.RolloutWeights)
 		errorPages := generateErrorPageDetails(r.ErrorPages, errorPageLocations, vsEx.VirtualServer)
 		errorPageLocations = append(errorPageLocations, generateErrorPageLocations(errorPages.index, errorPages.pages)...)
 
@@ -704,20 +761,21 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 			routePoliciesCfg.CORSHeaders = policiesCfg.CORSHeaders
 		}
 
+		// The route headers policies are applied after the spec-level headers policies
+		routePoliciesCfg.Headers = mergeHeadersPolicies(policiesCfg.Headers, routePoliciesCfg.Headers)
+
+		// Inherit spec-level HMAC if route doesn't have its own HMAC policy
+		if routePoliciesCfg.HMAC == nil {
+			routePoliciesCfg.HMAC = policiesCfg.HMAC
+		}
+
+		vsc.removeConflictingPolicyDryRunAuth(ownerDetails.owner, policiesCfg, &routePoliciesCfg)
+
 		if len(warnings) > 0 {
 			vsc.mergeWarnings(warnings)
 		}
-		if routePoliciesCfg.OIDC != nil {
-			// Store the OIDC policy name and built config for reuse in further calls to generatePolicies for subroutes.
-			policyOpts.oidcPolicyName = routePoliciesCfg.OIDC.PolicyName
-			policyOpts.oidcConfig = routePoliciesCfg.OIDC
-			// Keep policiesCfg.OIDC up to date so Server.OIDC is populated for server-block helper generation.
-			policiesCfg.OIDC = routePoliciesCfg.OIDC
-		} else if specHasOIDC {
-			// Inherit the spec-level OIDC to routes that don't define their own.
-			// Using the specHasOIDC boolean (set before the loop) avoids reading the potentially
-			// mutated policiesCfg.OIDC, which would otherwise cause a route-level OIDC to leak
-			// into subsequent routes that do not reference the policy.
+		// Inherit spec-level OIDC if route doesn't have its own OIDC policy
+		if routePoliciesCfg.OIDC == nil {
 			routePoliciesCfg.OIDC = policiesCfg.OIDC
 		}
 		if routePoliciesCfg.JWTAuth.JWKSEnabled {
@@ -759,6 +817,15 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 
 				locations = append(locations, vsc.generateExternalAuthLocation(routePoliciesCfg, proxyPassUpstream))
 
+				if in := routePoliciesCfg.ExternalAuth.Introspection; in != nil {
+					introspections = append(introspections, *in)
+					keyValZones = append(keyValZones, in.CacheZone)
+					keyVals = append(keyVals, in.CacheKeyVal)
+				}
+				if g := routePoliciesCfg.ExternalAuth.GRPC; g != nil {
+					externalAuthGRPCs = append(externalAuthGRPCs, *g)
+				}
+
 				upstreams, healthChecks, statusMatches = generateUpstreams(
 					sslConfig,
 					vsc,
@@ -798,16 +865,30 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 		if routePoliciesCfg.CORSMap != nil {
 			maps = append(maps, *routePoliciesCfg.CORSMap)
 		}
+		if routePoliciesCfg.GeoMap != nil {
+			maps = append(maps, *routePoliciesCfg.GeoMap)
+		}
+		addTracingSampler(&tracingSamplers, routePoliciesCfg.TracingSampler)
+		if routePoliciesCfg.Headers != nil {
+			maps = append(maps, routePoliciesCfg.Headers.Maps...)
+		}
+
+		maps = append(maps, routePoliciesCfg.JWTAuth.Maps...)
+		addPolicyDryRunConfig(&geos, &policyDryRunAuths, routePoliciesCfg)
 
 		limitReqZones = append(limitReqZones, routePoliciesCfg.RateLimit.Zones...)
 
 		authJWTClaimSets = append(authJWTClaimSets, routePoliciesCfg.RateLimit.AuthJWTClaimSets...)
+		authJWTClaimSets = append(authJWTClaimSets, routePoliciesCfg.JWTAuth.ClaimSets...)
 
 		// Add cache zone from route policy if present
 		addCacheZone(&cacheZones, routePoliciesCfg.Cache)
 
 		dosRouteCfg := generateDosCfg(dosResources[r.Path])
 
+		// the steps of a rollout change the weights of the splits without reloads
+		weightChangesDynamicReload := vsc.DynamicWeightChangesReload || r.Rollout != nil
+
 		if len(r.Matches) > 0 {
 			cfg := generateMatchesConfig(
 				r,
@@ -824,7 +905,7 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 				isVSR,
 				"", "",
 				vsc.warnings,
-				vsc.DynamicWeightChangesReload,
+				weightChangesDynamicReload,
 			)
 			addPoliciesCfgToLocations(routePoliciesCfg, cfg.Locations)
 			addDosConfigToLocations(dosRouteCfg, cfg.Locations)
@@ -832,6 +913,7 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 
 			maps = append(maps, cfg.Maps...)
 			locations = append(locations, cfg.Locations...)
+			cfg.InternalRedirectLocation.HMAC = routePoliciesCfg.HMAC
 			internalRedirectLocations = append(internalRedirectLocations, cfg.InternalRedirectLocation)
 			returnLocations = append(returnLocations, cfg.ReturnLocations...)
 			splitClients = append(splitClients, cfg.SplitClients...)
@@ -841,12 +923,13 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 			matchesRoutes++
 		} else if len(r.Splits) > 0 {
 			cfg := generateDefaultSplitsConfig(r, virtualServerUpstreamNamer, crUpstreams, VariableNamer, len(splitClients),
-				vsc.cfgParams, errorPages, r.Path, vsLocSnippets, vsc.enableSnippets, len(returnLocations), isVSR, "", "", vsc.warnings, vsc.DynamicWeightChangesReload)
+				vsc.cfgParams, errorPages, r.Path, vsLocSnippets, vsc.enableSnippets, len(returnLocations), isVSR, "", "", vsc.warnings, weightChangesDynamicReload)
 			addPoliciesCfgToLocations(routePoliciesCfg, cfg.Locations)
 			addDosConfigToLocations(dosRouteCfg, cfg.Locations)
 			addAddHeaderInheritToLocations(r.AddHeaderInherit, cfg.Locations)
 			splitClients = append(splitClients, cfg.SplitClients...)
 			locations = append(locations, cfg.Locations...)
+			cfg.InternalRedirectLocation.HMAC = routePoliciesCfg.HMAC
 			internalRedirectLocations = append(internalRedirectLocations, cfg.InternalRedirectLocation)
 			returnLocations = append(returnLocations, cfg.ReturnLocations...)
 			maps = append(maps, cfg.Maps...)
@@ -860,12 +943,28 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 			serviceNamespace, serviceName := ParseServiceReference(upstream.Service, vsEx.VirtualServer.Namespace)
 			proxySSLName := generateProxySSLName(serviceName, serviceNamespace)
 
-			loc, returnLoc := generateLocation(r.Path, upstreamName, upstream, r.Action, vsc.cfgParams, errorPages, false,
-				proxySSLName, r.Path, vsLocSnippets, vsc.enableSnippets, len(returnLocations), isVSR, "", "", vsc.warnings)
+			// the route with the HMAC policy is served by an internal location, to which the requests are redirected
+			// after the verification of their signatures
+			path, internal := r.Path, false
+			if routePoliciesCfg.HMAC != nil {
+				path, internal = generateHMACLocationPath(len(internalRedirectLocations)), true
+			}
+
+			loc, returnLoc := generateLocation(path, upstreamName, upstream, r.Action, vsc.cfgParams, errorPages, internal,
+				proxySSLName, r.Path, vsLocSnippets, vsc.enableSnippets, len(returnLocations), isVSR, "", "", vsc.warnings, virtualServerUpstreamNamer)
 			addPoliciesCfgToLocation(routePoliciesCfg, &loc)
 			loc.Dos = dosRouteCfg
 			loc.AddHeaderInherit = r.AddHeaderInherit
 
+			if internal {
+				loc.Internal = true
+				internalRedirectLocations = append(internalRedirectLocations, version2.InternalRedirectLocation{
+					Path:        r.Path,
+					Destination: path,
+					HMAC:        routePoliciesCfg.HMAC,
+				})
+			}
+
 			locations = append(locations, loc)
 			if returnLoc != nil {
 				returnLocations = append(returnLocations, *returnLoc)
@@ -930,6 +1029,7 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 				policyRefs = r.Policies
 				context = subRouteContext
 			}
+			policyRefs = withDefaultPolicies(vsEx.VirtualServerRouteDefaultPolicies[vsrNamespaceName], policyRefs, ownerDetails.ownerNamespace)
 			routePoliciesCfg, warnings := generatePolicies(vsc.cfgParams.Context, ownerDetails, policyRefs, vsEx.Policies, context, r.Path, policyOpts, vsc.bundleValidator)
 			if len(warnings) > 0 {
 				vsc.mergeWarnings(warnings)
@@ -940,17 +1040,18 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 				routePoliciesCfg.CORSHeaders = policiesCfg.CORSHeaders
 			}
 
-			if routePoliciesCfg.OIDC != nil {
-				// Store the OIDC policy name and built config for reuse in further calls to generatePolicies for subroutes.
-				policyOpts.oidcPolicyName = routePoliciesCfg.OIDC.PolicyName
-				policyOpts.oidcConfig = routePoliciesCfg.OIDC
-				// Keep policiesCfg.OIDC up to date so Server.OIDC is populated for server-block helper generation.
-				policiesCfg.OIDC = routePoliciesCfg.OIDC
-			} else if specHasOIDC {
-				// Inherit the spec-level OIDC to subroutes that don't define their own.
-				// Using the specHasOIDC boolean (set before the route loop) avoids reading the potentially
-				// mutated policiesCfg.OIDC, which would otherwise cause a route-level OIDC to leak
-				// into subsequent subroutes that do not reference the policy.
+			// The subroute headers policies are applied after the spec-level headers policies
+			routePoliciesCfg.Headers = mergeHeadersPolicies(policiesCfg.Headers, routePoliciesCfg.Headers)
+
+			// Inherit spec-level HMAC if subroute doesn't have its own HMAC policy
+			if routePoliciesCfg.HMAC == nil {
+				routePoliciesCfg.HMAC = policiesCfg.HMAC
+			}
+
+			vsc.removeConflictingPolicyDryRunAuth(ownerDetails.owner, policiesCfg, &routePoliciesCfg)
+
+			// Inherit spec-level OIDC if subroute doesn't have its own OIDC policy
+			if routePoliciesCfg.OIDC == nil {
 				routePoliciesCfg.OIDC = policiesCfg.OIDC
 			}
 			if routePoliciesCfg.JWTAuth.JWKSEnabled {
@@ -992,6 +1093,15 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 
 					locations = append(locations, vsc.generateExternalAuthLocation(routePoliciesCfg, proxyPassUpstream))
 
+					if in := routePoliciesCfg.ExternalAuth.Introspection; in != nil {
+						introspections = append(introspections, *in)
+						keyValZones = append(keyValZones, in.CacheZone)
+						keyVals = append(keyVals, in.CacheKeyVal)
+					}
+					if g := routePoliciesCfg.ExternalAuth.GRPC; g != nil {
+						externalAuthGRPCs = append(externalAuthGRPCs, *g)
+					}
+
 					upstreams, healthChecks, statusMatches = generateUpstreams(
 						sslConfig,
 						vsc,
@@ -1030,10 +1140,21 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 			if routePoliciesCfg.CORSMap != nil {
 				maps = append(maps, *routePoliciesCfg.CORSMap)
 			}
+			if routePoliciesCfg.GeoMap != nil {
+				maps = append(maps, *routePoliciesCfg.GeoMap)
+			}
+			addTracingSampler(&tracingSamplers, routePoliciesCfg.TracingSampler)
+			if routePoliciesCfg.Headers != nil {
+				maps = append(maps, routePoliciesCfg.Headers.Maps...)
+			}
+
+			maps = append(maps, routePoliciesCfg.JWTAuth.Maps...)
+			addPolicyDryRunConfig(&geos, &policyDryRunAuths, routePoliciesCfg)
 
 			limitReqZones = append(limitReqZones, routePoliciesCfg.RateLimit.Zones...)
 
 			authJWTClaimSets = append(authJWTClaimSets, routePoliciesCfg.RateLimit.AuthJWTClaimSets...)
+			authJWTClaimSets = append(authJWTClaimSets, routePoliciesCfg.JWTAuth.ClaimSets...)
 
 			// Add cache zone from subroute policy if present
 			addCacheZone(&cacheZones, routePoliciesCfg.Cache)
@@ -1065,6 +1186,7 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 
 				maps = append(maps, cfg.Maps...)
 				locations = append(locations, cfg.Locations...)
+				cfg.InternalRedirectLocation.HMAC = routePoliciesCfg.HMAC
 				internalRedirectLocations = append(internalRedirectLocations, cfg.InternalRedirectLocation)
 				returnLocations = append(returnLocations, cfg.ReturnLocations...)
 				splitClients = append(splitClients, cfg.SplitClients...)
@@ -1081,6 +1203,7 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 
 				splitClients = append(splitClients, cfg.SplitClients...)
 				locations = append(locations, cfg.Locations...)
+				cfg.InternalRedirectLocation.HMAC = routePoliciesCfg.HMAC
 				internalRedirectLocations = append(internalRedirectLocations, cfg.InternalRedirectLocation)
 				returnLocations = append(returnLocations, cfg.ReturnLocations...)
 				keyValZones = append(keyValZones, cfg.KeyValZones...)
@@ -1093,12 +1216,28 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 				serviceNamespace, serviceName := ParseServiceReference(upstream.Service, vsr.Namespace)
 				proxySSLName := generateProxySSLName(serviceName, serviceNamespace)
 
-				loc, returnLoc := generateLocation(r.Path, upstreamName, upstream, r.Action, vsc.cfgParams, errorPages, false,
-					proxySSLName, r.Path, locSnippets, vsc.enableSnippets, len(returnLocations), isVSR, vsr.Name, vsr.Namespace, vsc.warnings)
+				// the subroute with the HMAC policy is served by an internal location, to which the requests are redirected
+				// after the verification of their signatures
+				path, internal := r.Path, false
+				if routePoliciesCfg.HMAC != nil {
+					path, internal = generateHMACLocationPath(len(internalRedirectLocations)), true
+				}
+
+				loc, returnLoc := generateLocation(path, upstreamName, upstream, r.Action, vsc.cfgParams, errorPages, internal,
+					proxySSLName, r.Path, locSnippets, vsc.enableSnippets, len(returnLocations), isVSR, vsr.Name, vsr.Namespace, vsc.warnings, upstreamNamer)
 				addPoliciesCfgToLocation(routePoliciesCfg, &loc)
 				loc.Dos = dosRouteCfg
 				loc.AddHeaderInherit = addHeaderInherit
 
+				if internal {
+					loc.Internal = true
+					internalRedirectLocations = append(internalRedirectLocations, version2.InternalRedirectLocation{
+						Path:        r.Path,
+						Destination: path,
+						HMAC:        routePoliciesCfg.HMAC,
+					})
+				}
+
 				locations = append(locations, loc)
 				if returnLoc != nil {
 					returnLocations = append(returnLocations, *returnLoc)
@@ -1109,6 +1248,42 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 
 	for mapName, apiKeyClients := range policiesCfg.APIKey.ClientMap {
 		maps = append(maps, *generateAPIKeyClientMap(mapName, apiKeyClients))
+		if metadataMap := generateAPIKeyClientMetadataMap(mapName, apiKeyClients); metadataMap != nil {
+			maps = append(maps, *metadataMap)
+		}
+	}
+
+	oidcProviderKeys := make([]string, 0, len(policyOpts.oidcProviders))
+	for key := range policyOpts.oidcProviders {
+		oidcProviderKeys = append(oidcProviderKeys, key)
+	}
+	sort.Strings(oidcProviderKeys)
+	var oidcProviders []*version2.OIDC
+	for _, key := range oidcProviderKeys {
+		provider := policyOpts.oidcProviders[key]
+		oidcProviders = append(oidcProviders, provider.Config)
+		authJWTClaimSets = append(authJWTClaimSets, provider.ClaimSets...)
+		maps = append(maps, provider.Maps...)
+	}
+	var oidcProvider *version2.OIDC
+	if len(oidcProviders) == 1 {
+		oidcProvider = oidcProviders[0]
+	}
+
+	mirrorLocations, mirrorSplitClients := vsc.generateMirrorLocations(locations, crUpstreams, VariableNamer, vsEx.VirtualServer.Namespace)
+	locations = append(locations, mirrorLocations...)
+	splitClients = append(splitClients, mirrorSplitClients...)
+	splitClients = append(splitClients, tracingSamplers...)
+
+	retryBudgetLocations := generateRetryBudgetLocations(locations, VariableNamer)
+	locations = append(locations, retryBudgetLocations...)
+	var retryBudgetZone *version2.RetryBudgetZone
+	if len(retryBudgetLocations) > 0 {
+		retryBudgetZone = &version2.RetryBudgetZone{
+			Name:    VariableNamer.GetNameOfRetryBudgetZone(),
+			Size:    retryBudgetZoneSize,
+			Timeout: retryBudgetWindow,
+		}
 	}
 
 	httpSnippets := generateSnippets(vsc.enableSnippets, vsEx.VirtualServer.Spec.HTTPSnippets, []string{})
@@ -1126,6 +1301,7 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 		Upstreams:        upstreams,
 		SplitClients:     splitClients,
 		Maps:             removeDuplicateMaps(maps),
+		Geos:             geos,
 		StatusMatches:    statusMatches,
 		LimitReqZones:    removeDuplicateLimitReqZones(limitReqZones),
 		AuthJWTClaimSets: removeDuplicateAuthJWTClaimSets(authJWTClaimSets),
@@ -1158,11 +1334,15 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 			ErrorPageLocations:        errorPageLocations,
 			TLSPassthrough:            vsc.isTLSPassthrough,
 			Allow:                     policiesCfg.Allow,
+			GeoRestriction:            policiesCfg.Geo,
+			Tracing:                   policiesCfg.Tracing,
 			Deny:                      policiesCfg.Deny,
 			LimitReqOptions:           policiesCfg.RateLimit.Options,
 			LimitReqs:                 policiesCfg.RateLimit.Reqs,
 			JWTAuth:                   policiesCfg.JWTAuth.Auth,
 			ExternalAuth:              policiesCfg.ExternalAuth,
+			Introspections:            introspections,
+			ExternalAuthGRPCs:         externalAuthGRPCs,
 			ErrorPages:                getServerErrorPages(policiesCfg),
 			BasicAuth:                 policiesCfg.BasicAuth,
 			JWTAuthList:               policiesCfg.JWTAuth.List,
@@ -1171,7 +1351,10 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 			EgressMTLS:                policiesCfg.EgressMTLS,
 			APIKey:                    policiesCfg.APIKey.Key,
 			APIKeyEnabled:             policiesCfg.APIKey.Enabled,
-			OIDC:                      policiesCfg.OIDC,
+			PolicyDryRun:              generatePolicyDryRun(policiesCfg),
+			PolicyDryRunAuths:         policyDryRunAuths,
+			OIDCProviders:             oidcProviders,
+			OIDC:                      oidcProvider,
 			WAF:                       policiesCfg.WAF,
 			Dos:                       dosCfg,
 			Cache:                     policiesCfg.Cache,
@@ -1188,6 +1371,7 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 		KeyValZones:             keyValZones,
 		KeyVals:                 keyVals,
 		TwoWaySplitClients:      twoWaySplitClients,
+		RetryBudgetZone:         retryBudgetZone,
 	}
 
 	return vsCfg, vsc.warnings
@@ -1217,6 +1401,26 @@ func (vsc *virtualServerConfigurator) generateExternalAuthLocation(policiesCfg p
 		ServiceName:              svcName,
 		IsVSR:                    false,
 	}
+	if in := policiesCfg.ExternalAuth.Introspection; in != nil {
+		// the njs handler sends the token in the body of a POST request
+		loc.ProxyPassRequestHeaders = false
+		loc.ProxyPassRequestBody = ""
+		loc.ProxySetHeaders = []version2.Header{
+			{Name: "Authorization", Value: "Basic " + in.ClientAuth},
+			{Name: "Content-Type", Value: "application/x-www-form-urlencoded"},
+			{Name: "Accept", Value: "application/json"},
+		}
+	}
+	if policiesCfg.ExternalAuth.GRPC != nil {
+		// the njs handler sends the gRPC message of the check request in the body of the subrequest
+		loc.ProxyPass = ""
+		loc.GRPCPass = generateGRPCPass(true, policiesCfg.ExternalAuth.SSLEnabled, proxyURLUpstreamName)
+		loc.Rewrites = []string{fmt.Sprintf("^ %s break", externalAuthGRPCCheckPath)}
+		loc.ProxySetHeaders = []version2.Header{
+			{Name: "Content-Type", Value: "application/grpc"},
+			{Name: "Content-Length", Value: ""},
+		}
+	}
 	if policiesCfg.ExternalAuth.SSLVerify {
 		loc.ProxySSLVerify = true
 		loc.ProxySSLVerifyDepth = policiesCfg.ExternalAuth.SSLVerifyDepth
@@ -1326,12 +1530,24 @@ func generateUpstreams(
 	u.TLS.Enable = isTLSEnabled(u, vsc.spiffeCerts, vsEx.VirtualServer.Spec.InternalRoute)
 	crUpstreams[upstreamName] = u
 
-	if hc := generateHealthCheck(u, upstreamName, vsc.cfgParams); hc != nil {
+	if !vsc.isPlus && u.OutlierDetection != nil && !vsc.enableOutlierDetection {
+		vsc.addWarningf(owner, "Outlier detection for upstream %s requires the -enable-outlier-detection command-line argument", u.Name)
+	}
+
+	hc := generateHealthCheck(u, upstreamName, vsc.cfgParams)
+	if vsc.isPlus && u.OutlierDetection != nil {
+		hc = generateOutlierDetectionHealthCheck(hc, u, upstreamName, vsc.cfgParams)
+	}
+	if hc != nil {
 		healthChecks = append(healthChecks, *hc)
-		if u.HealthCheck.StatusMatch != "" {
+		if hc.Match != "" {
+			statusMatch := outlierDetectionStatusMatch
+			if u.HealthCheck != nil && u.HealthCheck.StatusMatch != "" {
+				statusMatch = u.HealthCheck.StatusMatch
+			}
 			statusMatches = append(
 				statusMatches,
-				generateUpstreamStatusMatch(upstreamName, u.HealthCheck.StatusMatch),
+				generateUpstreamStatusMatch(upstreamName, statusMatch),
 			)
 		}
 	}
@@ -1361,6 +1577,53 @@ func generateAPIKeyClientMap(mapName string, apiKeyClients []apiKeyClient) *vers
 	}
 }
 
+// generateAPIKeyClientMetadataMap generates the map of the hashed API keys to the metadata of the clients
+// in the format "<expiry time in seconds since the epoch or 0> <comma separated path prefixes> <name>",
+// which is checked by apikey_auth.js. It returns nil if none of the clients has metadata.
+func generateAPIKeyClientMetadataMap(mapName string, apiKeyClients []apiKeyClient) *version2.Map {
+	var params []version2.Parameter
+	for _, client := range apiKeyClients {
+		if client.Name == "" && client.Expires.IsZero() && len(client.Routes) == 0 {
+			continue
+		}
+		var expires int64
+		if !client.Expires.IsZero() {
+			expires = client.Expires.Unix()
+		}
+		routes := "/"
+		if len(client.Routes) > 0 {
+			routes = strings.Join(client.Routes, ",")
+		}
+		params = append(params, version2.Parameter{
+			Value:  fmt.Sprintf("\"%s\"", client.HashedKey),
+			Result: fmt.Sprintf("\"%d %s %s\"", expires, routes, client.Name),
+		})
+	}
+	if params == nil {
+		return nil
+	}
+
+	return &version2.Map{
+		Source:     "$apikey_auth_token",
+		Variable:   fmt.Sprintf("$%s_metadata", mapName),
+		Parameters: append([]version2.Parameter{{Value: "default", Result: "\"\""}}, params...),
+	}
+}
+
+// addTracingSampler adds the split_clients that samples the requests of a tracing policy,
+// unless the policy is already referenced by another route.
+func addTracingSampler(samplers *[]version2.SplitClient, sampler *version2.SplitClient) {
+	if sampler == nil {
+		return
+	}
+	for _, existing := range *samplers {
+		if existing.Variable == sampler.Variable {
+			return
+		}
+	}
+	*samplers = append(*samplers, *sampler)
+}
+
 func addCacheZone(cacheZones *[]version2.CacheZone, cache *version2.Cache) {
 	if cache == nil {
 		return
@@ -1395,6 +1658,28 @@ func addCacheZone(cacheZones *[]version2.CacheZone, cache *version2.Cache) {
 	*cacheZones = append(*cacheZones, cacheZone)
 }
 
+// removeConflictingPolicyDryRunAuth removes the auth check of the route policy in the dry run mode when the server
+// has an external auth or an API key policy, because the auth subrequest of the route would replace the one of the server.
+func (vsc *virtualServerConfigurator) removeConflictingPolicyDryRunAuth(owner runtime.Object, specCfg policiesCfg, routeCfg *policiesCfg) {
+	if routeCfg.DryRun.Auth == nil || (specCfg.ExternalAuth == nil && specCfg.APIKey.Key == nil) {
+		return
+	}
+
+	vsc.addWarningf(owner, "Policy %s in the dry run mode cannot be combined with external auth or API key policies of the VirtualServer and will be ignored", routeCfg.DryRun.Auth.Policy)
+	routeCfg.DryRun.Auth = nil
+}
+
+// addPolicyDryRunConfig adds the geo of the access control policy and the auth check of the policies in the dry run mode,
+// unless they were already added for another route.
+func addPolicyDryRunConfig(geos *[]version2.Geo, auths *[]version2.PolicyDryRunAuth, cfg policiesCfg) {
+	if geo := cfg.DryRun.AccessControl; geo != nil && !slices.ContainsFunc(*geos, func(g version2.Geo) bool { return g.Variable == geo.Variable }) {
+		*geos = append(*geos, *geo)
+	}
+	if auth := cfg.DryRun.Auth; auth != nil && !slices.ContainsFunc(*auths, func(a version2.PolicyDryRunAuth) bool { return a.Path == auth.Path }) {
+		*auths = append(*auths, *auth)
+	}
+}
+
 func removeDuplicateLimitReqZones(rlz []version2.LimitReqZone) []version2.LimitReqZone {
 	encountered := make(map[string]bool)
 	result := []version2.LimitReqZone{}
@@ -1462,11 +1747,12 @@ func addPoliciesCfgToLocation(cfg policiesCfg, location *version2.Location) {
 	location.ExternalAuth = cfg.ExternalAuth
 	location.BasicAuth = cfg.BasicAuth
 	location.EgressMTLS = cfg.EgressMTLS
-	if cfg.OIDC != nil {
-		location.OIDC = true
-	}
+	location.OIDC = cfg.OIDC
 	location.WAF = cfg.WAF
 	location.APIKey = cfg.APIKey.Key
+	location.PolicyDryRun = generatePolicyDryRun(cfg)
+	location.GeoRestriction = cfg.Geo
+	location.Tracing = cfg.Tracing
 	location.Cache = cfg.Cache
 	location.PoliciesErrorReturn = cfg.ErrorReturn
 
@@ -1479,6 +1765,10 @@ func addPoliciesCfgToLocation(cfg policiesCfg, location *version2.Location) {
 		location.ProxyInterceptErrors = true
 	}
 
+	if cfg.Headers != nil {
+		addHeadersPolicyToLocation(cfg.Headers, location)
+	}
+
 	// Add CORS headers if present
 	if len(cfg.CORSHeaders) > 0 {
 		location.AddHeaders = append(location.AddHeaders, cfg.CORSHeaders...)
@@ -1486,6 +1776,53 @@ func addPoliciesCfgToLocation(cfg policiesCfg, location *version2.Location) {
 	}
 }
 
+// addHeadersPolicyToLocation adds the header modifications of the headers policies to the location.
+// The requestHeaders and responseHeaders of the action proxy of the location take precedence over the headers policies.
+func addHeadersPolicyToLocation(headers *headersPolicy, location *version2.Location) {
+	requestHeaders := make(map[string]bool)
+	for _, h := range location.ProxySetHeaders {
+		requestHeaders[strings.ToLower(h.Name)] = true
+	}
+	responseHeaders := make(map[string]bool)
+	for _, h := range location.ProxyHideHeaders {
+		responseHeaders[strings.ToLower(h)] = true
+	}
+	for _, h := range location.AddHeaders {
+		responseHeaders[strings.ToLower(h.Name)] = true
+	}
+
+	// the headers of the location can be shared with the action proxy, so new slices are created
+	setHeaders := slices.Clone(location.ProxySetHeaders)
+	for _, m := range headers.Request {
+		if !requestHeaders[strings.ToLower(m.Name)] && m.SetHeader != nil {
+			setHeaders = append(setHeaders, *m.SetHeader)
+		}
+	}
+	location.ProxySetHeaders = setHeaders
+
+	hideHeaders := slices.Clone(location.ProxyHideHeaders)
+	addHeaders := slices.Clone(location.AddHeaders)
+	for _, m := range headers.Response {
+		if responseHeaders[strings.ToLower(m.Name)] {
+			continue
+		}
+		if m.Hide {
+			hideHeaders = append(hideHeaders, m.Name)
+		}
+		if m.AddHeader != nil {
+			addHeaders = append(addHeaders, *m.AddHeader)
+		}
+	}
+	location.ProxyHideHeaders = hideHeaders
+	location.AddHeaders = addHeaders
+}
+
+// generateHMACLocationPath returns the path of the internal location of a route with the HMAC policy.
+// The index of the internal redirect location of the route makes the path unique.
+func generateHMACLocationPath(index int) string {
+	return fmt.Sprintf("/%vhmac_%d", internalLocationPrefix, index)
+}
+
 func addPoliciesCfgToLocations(cfg policiesCfg, locations []version2.Location) {
 	for i := range locations {
 		addPoliciesCfgToLocation(cfg, &locations[i])
@@ -1573,6 +1910,7 @@ func (vsc *virtualServerConfigurator) generateUpstream(
 		MaxConns:         generateIntFromPointer(upstream.MaxConns, vsc.cfgParams.MaxConns),
 		UpstreamZoneSize: vsc.cfgParams.UpstreamZoneSize,
 		BackupServers:    upsBackupServers,
+		OutlierDetection: generateOutlierDetection(upstream.OutlierDetection),
 	}
 
 	if vsc.isPlus {
@@ -1589,7 +1927,12 @@ func (vsc *virtualServerConfigurator) generateSlowStartForPlus(
 	upstream conf_v1.Upstream,
 	lbMethod string,
 ) string {
-	if upstream.SlowStart == "" {
+	slowStart := upstream.SlowStart
+	if slowStart == "" && upstream.OutlierDetection != nil {
+		// the servers ejected by the outlier detection are re-admitted gradually
+		slowStart = defaultOutlierDetectionSlowStart
+	}
+	if slowStart == "" {
 		return ""
 	}
 
@@ -1597,11 +1940,14 @@ func (vsc *virtualServerConfigurator) generateSlowStartForPlus(
 	isHash := strings.HasPrefix(lbMethod, "hash")
 	if isIncompatible || isHash {
 		msgFmt := "Slow start will be disabled for upstream %v because lb method '%v' is incompatible with slow start"
+		if upstream.SlowStart == "" {
+			msgFmt = "Ejected servers of upstream %v will not be re-admitted gradually because lb method '%v' is incompatible with slow start"
+		}
 		vsc.addWarningf(owner, msgFmt, upstream.Name, lbMethod)
 		return ""
 	}
 
-	return generateTime(upstream.SlowStart)
+	return generateTime(slowStart)
 }
 
 func generateHealthCheck(
@@ -1697,6 +2043,60 @@ func generateStatusMatchName(upstreamName string) string {
 	return fmt.Sprintf("%s_match", upstreamName)
 }
 
+// generateOutlierDetectionHealthCheck generates the active health check that implements the outlier detection for NGINX Plus.
+// An upstream server is considered unhealthy after the consecutive errors and
+// is not considered healthy again until the health checks pass for the ejection time.
+func generateOutlierDetectionHealthCheck(
+	hc *version2.HealthCheck,
+	upstream conf_v1.Upstream,
+	upstreamName string,
+	cfgParams *ConfigParams,
+) *version2.HealthCheck {
+	if hc == nil {
+		hc = newHealthCheckWithDefaults(upstream, upstreamName, cfgParams)
+	}
+
+	od := generateOutlierDetection(upstream.OutlierDetection)
+	hc.Fails = od.ConsecutiveErrors
+
+	if upstream.HealthCheck == nil || upstream.HealthCheck.Passes == 0 {
+		hc.Passes = 1
+		interval, err := ParseTimeDuration(hc.Interval)
+		if err == nil && interval > 0 {
+			hc.Passes = max(1, int((od.EjectionTime+interval-1)/interval))
+		}
+	}
+
+	if hc.Match == "" && !hc.IsGRPC {
+		hc.Match = generateStatusMatchName(upstreamName)
+	}
+
+	return hc
+}
+
+func generateOutlierDetection(od *conf_v1.OutlierDetection) *version2.OutlierDetection {
+	if od == nil {
+		return nil
+	}
+
+	ejectionTime := defaultOutlierDetectionEjectionTime
+	if od.EjectionTime != "" {
+		// it is expected that the value has been validated
+		ejectionTime, _ = ParseTimeDuration(od.EjectionTime)
+	}
+
+	consecutiveErrors := od.ConsecutiveErrors
+	if consecutiveErrors == 0 {
+		consecutiveErrors = defaultOutlierDetectionConsecutiveErrors
+	}
+
+	return &version2.OutlierDetection{
+		ConsecutiveErrors:  consecutiveErrors,
+		EjectionTime:       ejectionTime,
+		MaxEjectionPercent: generateIntFromPointer(od.MaxEjectionPercent, defaultOutlierDetectionMaxEjectionPercent),
+	}
+}
+
 func generateUpstreamStatusMatch(upstreamName string, status string) version2.StatusMatch {
 	return version2.StatusMatch{
 		Name: generateStatusMatchName(upstreamName),
@@ -1903,7 +2303,7 @@ type errorPageDetails struct {
 func generateLocation(path string, upstreamName string, upstream conf_v1.Upstream, action *conf_v1.Action,
 	cfgParams *ConfigParams, errorPages errorPageDetails, internal bool, proxySSLName string,
 	originalPath string, locSnippets string, enableSnippets bool, retLocIndex int, isVSR bool, vsrName string,
-	vsrNamespace string, vscWarnings Warnings,
+	vsrNamespace string, vscWarnings Warnings, upstreamNamer *upstreamNamer,
 ) (version2.Location, *version2.ReturnLocation) {
 	locationSnippets := generateSnippets(enableSnippets, locSnippets, cfgParams.LocationSnippets)
 
@@ -1919,8 +2319,177 @@ func generateLocation(path string, upstreamName string, upstream conf_v1.Upstrea
 
 	_, serviceName := ParseServiceReference(upstream.Service, "")
 
-	return generateLocationForProxying(path, upstreamName, upstream, cfgParams, errorPages.pages, internal,
-		errorPages.index, proxySSLName, action.Proxy, originalPath, locationSnippets, isVSR, vsrName, vsrNamespace, serviceName), nil
+	loc := generateLocationForProxying(path, upstreamName, upstream, cfgParams, errorPages.pages, internal,
+		errorPages.index, proxySSLName, action.Proxy, originalPath, locationSnippets, isVSR, vsrName, vsrNamespace, serviceName)
+	loc.Mirror = generateMirror(action.Proxy, upstreamNamer)
+	applyRetryPolicy(&loc, path, originalPath, upstreamName, upstream, action.Proxy)
+
+	return loc, nil
+}
+
+// retryConditions maps the conditions of a retry policy to the parameters of the proxy_next_upstream directive.
+var retryConditions = map[string][]string{
+	"error":          {"error"},
+	"timeout":        {"timeout"},
+	"5xx":            {"http_500", "http_502", "http_503", "http_504"},
+	"gateway-error":  {"http_502", "http_503", "http_504"},
+	"non-idempotent": {"non_idempotent"},
+}
+
+// generateRetryConditions converts the conditions of a retry policy into the parameters of the proxy_next_upstream directive.
+func generateRetryConditions(conditions []string) string {
+	if len(conditions) == 0 {
+		return "error timeout"
+	}
+
+	var params []string
+	seen := make(map[string]bool)
+	for _, c := range conditions {
+		for _, p := range retryConditions[c] {
+			if !seen[p] {
+				seen[p] = true
+				params = append(params, p)
+			}
+		}
+	}
+
+	return strings.Join(params, " ")
+}
+
+// applyRetryPolicy overrides the retries of the location with the retry policy of the proxy action.
+func applyRetryPolicy(loc *version2.Location, path string, originalPath string, upstreamName string, upstream conf_v1.Upstream, proxy *conf_v1.ActionProxy) {
+	if proxy == nil || proxy.Retry == nil {
+		return
+	}
+	retry := proxy.Retry
+
+	loc.ProxyNextUpstream = generateRetryConditions(retry.Conditions)
+	loc.ProxyNextUpstreamTries = retry.Tries
+	loc.ProxyNextUpstreamTimeout = generateTimeWithDefault(retry.Timeout, "0s")
+	if retry.PerTryTimeout != "" {
+		perTryTimeout := generateTime(retry.PerTryTimeout)
+		loc.ProxyConnectTimeout = perTryTimeout
+		loc.ProxySendTimeout = perTryTimeout
+		loc.ProxyReadTimeout = perTryTimeout
+	}
+
+	if retry.Budget == nil {
+		return
+	}
+
+	// The fallback location is internal, so it passes the original URI of the request to the upstream.
+	loc.RetryBudget = &version2.RetryBudget{
+		Percent:           retry.Budget.PercentOfRecentRequests,
+		MinRetries:        generateIntFromPointer(retry.Budget.MinRetries, defaultRetryBudgetMinRetries),
+		FallbackProxyPass: generateProxyPass(upstream.TLS.Enable, upstreamName, true, proxy),
+		FallbackRewrites:  generateRewrites(path, proxy, true, originalPath, isGRPC(upstream.Type)),
+	}
+}
+
+// generateRetryBudgetLocations generates the internal locations which receive the requests of the given locations
+// when their retry budgets are exhausted. The internal locations don't retry requests.
+// It sets the zone, the key and the path of the internal location in the RetryBudget of each location.
+func generateRetryBudgetLocations(locations []version2.Location, variableNamer *VariableNamer) []version2.Location {
+	var retryBudgetLocations []version2.Location
+
+	for i := range locations {
+		budget := locations[i].RetryBudget
+		if budget == nil {
+			continue
+		}
+
+		index := len(retryBudgetLocations)
+		budget.Zone = variableNamer.GetNameOfRetryBudgetZone()
+		budget.Key = variableNamer.GetNameOfRetryBudgetKey(index)
+		budget.Path = fmt.Sprintf("/%vretry_budget_%d", internalLocationPrefix, index)
+
+		loc := locations[i]
+		loc.Path = budget.Path
+		loc.Internal = true
+		loc.RetryBudget = nil
+		loc.ProxyPass = budget.FallbackProxyPass
+		loc.ProxyPassRewrite = ""
+		loc.Rewrites = budget.FallbackRewrites
+		loc.ProxyNextUpstream = "off"
+		loc.ProxyNextUpstreamTimeout = "0s"
+		loc.ProxyNextUpstreamTries = 0
+
+		retryBudgetLocations = append(retryBudgetLocations, loc)
+	}
+
+	return retryBudgetLocations
+}
+
+func generateMirror(proxy *conf_v1.ActionProxy, upstreamNamer *upstreamNamer) *version2.Mirror {
+	if proxy == nil || proxy.Mirror == nil {
+		return nil
+	}
+
+	percentage := generateIntFromPointer(proxy.Mirror.Percentage, 100)
+	if percentage == 0 {
+		return nil
+	}
+
+	return &version2.Mirror{
+		Upstream:    upstreamNamer.GetNameForUpstream(proxy.Mirror.Upstream),
+		Percentage:  percentage,
+		RequestBody: generateBool(proxy.Mirror.RequestBody, true),
+	}
+}
+
+// generateMirrorLocations generates the internal locations which receive the requests mirrored by the given locations.
+// It sets the path of the mirror location in the Mirror of each location.
+func (vsc *virtualServerConfigurator) generateMirrorLocations(
+	locations []version2.Location,
+	crUpstreams map[string]conf_v1.Upstream,
+	variableNamer *VariableNamer,
+	vsNamespace string,
+) ([]version2.Location, []version2.SplitClient) {
+	var mirrorLocations []version2.Location
+	var splitClients []version2.SplitClient
+
+	for i := range locations {
+		mirror := locations[i].Mirror
+		if mirror == nil {
+			continue
+		}
+
+		index := len(mirrorLocations)
+		mirror.Path = fmt.Sprintf("/%vmirror_%d", internalLocationPrefix, index)
+
+		namespace := vsNamespace
+		if locations[i].IsVSR {
+			namespace = locations[i].VSRNamespace
+		}
+		upstream := crUpstreams[mirror.Upstream]
+		serviceNamespace, serviceName := ParseServiceReference(upstream.Service, namespace)
+		proxySSLName := generateProxySSLName(serviceName, serviceNamespace)
+
+		loc := generateLocationForProxying(mirror.Path, mirror.Upstream, upstream, vsc.cfgParams, nil, true, 0,
+			proxySSLName, nil, "", nil, locations[i].IsVSR, locations[i].VSRName, locations[i].VSRNamespace, serviceName)
+		// the mirror subrequests share the variables with the main request, which is logged with its own upstream
+		loc.UpstreamName = ""
+		if !mirror.RequestBody {
+			loc.ProxyPassRequestBody = "off"
+			loc.ProxySetHeaders = append(loc.ProxySetHeaders, version2.Header{Name: "Content-Length", Value: ""})
+		}
+
+		if mirror.Percentage < 100 {
+			loc.MirrorSampleVariable = variableNamer.GetNameForMirrorVariable(index)
+			splitClients = append(splitClients, version2.SplitClient{
+				Source:   "$request_id",
+				Variable: loc.MirrorSampleVariable,
+				Distributions: []version2.Distribution{
+					{Weight: fmt.Sprintf("%d%%", mirror.Percentage), Value: "1"},
+					{Weight: "*", Value: `""`},
+				},
+			})
+		}
+
+		mirrorLocations = append(mirrorLocations, loc)
+	}
+
+	return mirrorLocations, splitClients
 }
 
 func generateProxySetHeaders(proxy *conf_v1.ActionProxy) []version2.Header {
@@ -2038,6 +2607,7 @@ func generateLocationForProxying(path string, upstreamName string, upstream conf
 		ErrorPages:               generateErrorPages(errPageIndex, errorPages),
 		ProxySSLName:             proxySSLName,
 		ServiceName:              serviceName,
+		UpstreamName:             upstreamName,
 		IsVSR:                    isVSR,
 		VSRName:                  vsrName,
 		VSRNamespace:             vsrNamespace,
@@ -2131,6 +2701,20 @@ type routingCfg struct {
 	TwoWaySplitClients       []version2.TwoWaySplitClients
 }
 
+// applyRolloutWeight returns the route with the weights of its splits set to the weight of the rollout of the route.
+func applyRolloutWeight(route conf_v1.Route, rolloutWeights map[string]int) conf_v1.Route {
+	weight, exists := rolloutWeights[route.Path]
+	if !exists || len(route.Splits) != 2 {
+		return route
+	}
+
+	splits := slices.Clone(route.Splits)
+	splits[0].Weight = 100 - weight
+	splits[1].Weight = weight
+	route.Splits = splits
+	return route
+}
+
 func generateSplits(
 	splits []conf_v1.Split,
 	upstreamNamer *upstreamNamer,
@@ -2212,7 +2796,7 @@ func generateSplits(
 		proxySSLName := generateProxySSLName(serviceName, serviceNamespace)
 		newRetLocIndex := retLocIndex + len(returnLocations)
 		loc, returnLoc := generateLocation(path, upstreamName, upstream, s.Action, cfgParams, errorPages, true,
-			proxySSLName, originalPath, locSnippets, enableSnippets, newRetLocIndex, isVSR, vsrName, vsrNamespace, vscWarnings)
+			proxySSLName, originalPath, locSnippets, enableSnippets, newRetLocIndex, isVSR, vsrName, vsrNamespace, vscWarnings, upstreamNamer)
 		locations = append(locations, loc)
 		if returnLoc != nil {
 			returnLocations = append(returnLocations, *returnLoc)
@@ -2446,7 +3030,7 @@ func generateMatchesConfig(route conf_v1.Route, upstreamNamer *upstreamNamer, cr
 			proxySSLName := generateProxySSLName(serviceName, serviceNamespace)
 			newRetLocIndex := retLocIndex + len(returnLocations)
 			loc, returnLoc := generateLocation(path, upstreamName, upstream, m.Action, cfgParams, errorPages, true,
-				proxySSLName, route.Path, locSnippets, enableSnippets, newRetLocIndex, isVSR, vsrName, vsrNamespace, vscWarnings)
+				proxySSLName, route.Path, locSnippets, enableSnippets, newRetLocIndex, isVSR, vsrName, vsrNamespace, vscWarnings, upstreamNamer)
 			locations = append(locations, loc)
 			if returnLoc != nil {
 				returnLocations = append(returnLocations, *returnLoc)
@@ -2490,7 +3074,7 @@ func generateMatchesConfig(route conf_v1.Route, upstreamNamer *upstreamNamer, cr
 		proxySSLName := generateProxySSLName(serviceName, serviceNamespace)
 		newRetLocIndex := retLocIndex + len(returnLocations)
 		loc, returnLoc := generateLocation(path, upstreamName, upstream, route.Action, cfgParams, errorPages, true,
-			proxySSLName, route.Path, locSnippets, enableSnippets, newRetLocIndex, isVSR, vsrName, vsrNamespace, vscWarnings)
+			proxySSLName, route.Path, locSnippets, enableSnippets, newRetLocIndex, isVSR, vsrName, vsrNamespace, vscWarnings, upstreamNamer)
 		locations = append(locations, loc)
 		if returnLoc != nil {
 			returnLocations = append(returnLocations, *returnLoc)
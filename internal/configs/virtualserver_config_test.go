@@ -137,6 +137,7 @@ func TestGenerateVSConfig_GeneratesConfigWithGunzipOn(t *testing.T) {
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "tea-svc",
+					UpstreamName:             "vs_default_cafe_tea",
 				},
 				{
 					Path:                     "/tea-latest",
@@ -149,6 +150,7 @@ func TestGenerateVSConfig_GeneratesConfigWithGunzipOn(t *testing.T) {
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "tea-svc",
+					UpstreamName:             "vs_default_cafe_tea-latest",
 				},
 				// Order changes here because we generate first all the VS Routes and then all the VSR Subroutes (separated for loops)
 				{
@@ -170,6 +172,7 @@ func TestGenerateVSConfig_GeneratesConfigWithGunzipOn(t *testing.T) {
 					ProxyPassRequestHeaders: true,
 					ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:             "coffee-svc",
+					UpstreamName:            "vs_default_cafe_coffee",
 				},
 				{
 					Path:                     "/coffee",
@@ -182,6 +185,7 @@ func TestGenerateVSConfig_GeneratesConfigWithGunzipOn(t *testing.T) {
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "coffee-svc",
+					UpstreamName:             "vs_default_cafe_vsr_default_coffee_coffee",
 					IsVSR:                    true,
 					VSRName:                  "coffee",
 					VSRNamespace:             "default",
@@ -197,6 +201,7 @@ func TestGenerateVSConfig_GeneratesConfigWithGunzipOn(t *testing.T) {
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "sub-tea-svc",
+					UpstreamName:             "vs_default_cafe_vsr_default_subtea_subtea",
 					IsVSR:                    true,
 					VSRName:                  "subtea",
 					VSRNamespace:             "default",
@@ -221,6 +226,7 @@ func TestGenerateVSConfig_GeneratesConfigWithGunzipOn(t *testing.T) {
 					ProxyPassRequestHeaders: true,
 					ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:             "coffee-svc",
+					UpstreamName:            "vs_default_cafe_vsr_default_subcoffee_coffee",
 					IsVSR:                   true,
 					VSRName:                 "subcoffee",
 					VSRNamespace:            "default",
@@ -244,6 +250,7 @@ func TestGenerateVSConfig_GeneratesConfigWithGunzipOn(t *testing.T) {
 					ProxyPassRequestHeaders: true,
 					ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:             "coffee-svc",
+					UpstreamName:            "vs_default_cafe_vsr_default_subcoffee_coffee",
 					IsVSR:                   true,
 					VSRName:                 "subcoffee",
 					VSRNamespace:            "default",
@@ -395,6 +402,7 @@ func TestGenerateVSConfig_GeneratesConfigWithGunzipOff(t *testing.T) {
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "tea-svc",
+					UpstreamName:             "vs_default_cafe_tea",
 				},
 				{
 					Path:                     "/tea-latest",
@@ -407,6 +415,7 @@ func TestGenerateVSConfig_GeneratesConfigWithGunzipOff(t *testing.T) {
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "tea-svc",
+					UpstreamName:             "vs_default_cafe_tea-latest",
 				},
 				// Order changes here because we generate first all the VS Routes and then all the VSR Subroutes (separated for loops)
 				{
@@ -428,6 +437,7 @@ func TestGenerateVSConfig_GeneratesConfigWithGunzipOff(t *testing.T) {
 					ProxyPassRequestHeaders: true,
 					ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:             "coffee-svc",
+					UpstreamName:            "vs_default_cafe_coffee",
 				},
 				{
 					Path:                     "/coffee",
@@ -440,6 +450,7 @@ func TestGenerateVSConfig_GeneratesConfigWithGunzipOff(t *testing.T) {
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "coffee-svc",
+					UpstreamName:             "vs_default_cafe_vsr_default_coffee_coffee",
 					IsVSR:                    true,
 					VSRName:                  "coffee",
 					VSRNamespace:             "default",
@@ -455,6 +466,7 @@ func TestGenerateVSConfig_GeneratesConfigWithGunzipOff(t *testing.T) {
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "sub-tea-svc",
+					UpstreamName:             "vs_default_cafe_vsr_default_subtea_subtea",
 					IsVSR:                    true,
 					VSRName:                  "subtea",
 					VSRNamespace:             "default",
@@ -479,6 +491,7 @@ func TestGenerateVSConfig_GeneratesConfigWithGunzipOff(t *testing.T) {
 					ProxyPassRequestHeaders: true,
 					ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:             "coffee-svc",
+					UpstreamName:            "vs_default_cafe_vsr_default_subcoffee_coffee",
 					IsVSR:                   true,
 					VSRName:                 "subcoffee",
 					VSRNamespace:            "default",
@@ -502,6 +515,7 @@ func TestGenerateVSConfig_GeneratesConfigWithGunzipOff(t *testing.T) {
 					ProxyPassRequestHeaders: true,
 					ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:             "coffee-svc",
+					UpstreamName:            "vs_default_cafe_vsr_default_subcoffee_coffee",
 					IsVSR:                   true,
 					VSRName:                 "subcoffee",
 					VSRNamespace:            "default",
@@ -800,6 +814,7 @@ func TestGenerateVSConfig_GeneratesConfigWithNoGunzip(t *testing.T) {
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "tea-svc",
+					UpstreamName:             "vs_default_cafe_tea",
 				},
 				{
 					Path:                     "/tea-latest",
@@ -812,6 +827,7 @@ func TestGenerateVSConfig_GeneratesConfigWithNoGunzip(t *testing.T) {
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "tea-svc",
+					UpstreamName:             "vs_default_cafe_tea-latest",
 				},
 				// Order changes here because we generate first all the VS Routes and then all the VSR Subroutes (separated for loops)
 				{
@@ -833,6 +849,7 @@ func TestGenerateVSConfig_GeneratesConfigWithNoGunzip(t *testing.T) {
 					ProxyPassRequestHeaders: true,
 					ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:             "coffee-svc",
+					UpstreamName:            "vs_default_cafe_coffee",
 				},
 				{
 					Path:                     "/coffee",
@@ -845,6 +862,7 @@ func TestGenerateVSConfig_GeneratesConfigWithNoGunzip(t *testing.T) {
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "coffee-svc",
+					UpstreamName:             "vs_default_cafe_vsr_default_coffee_coffee",
 					IsVSR:                    true,
 					VSRName:                  "coffee",
 					VSRNamespace:             "default",
@@ -860,6 +878,7 @@ func TestGenerateVSConfig_GeneratesConfigWithNoGunzip(t *testing.T) {
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "sub-tea-svc",
+					UpstreamName:             "vs_default_cafe_vsr_default_subtea_subtea",
 					IsVSR:                    true,
 					VSRName:                  "subtea",
 					VSRNamespace:             "default",
@@ -884,6 +903,7 @@ func TestGenerateVSConfig_GeneratesConfigWithNoGunzip(t *testing.T) {
 					ProxyPassRequestHeaders: true,
 					ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:             "coffee-svc",
+					UpstreamName:            "vs_default_cafe_vsr_default_subcoffee_coffee",
 					IsVSR:                   true,
 					VSRName:                 "subcoffee",
 					VSRNamespace:            "default",
@@ -907,6 +927,7 @@ func TestGenerateVSConfig_GeneratesConfigWithNoGunzip(t *testing.T) {
 					ProxyPassRequestHeaders: true,
 					ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:             "coffee-svc",
+					UpstreamName:            "vs_default_cafe_vsr_default_subcoffee_coffee",
 					IsVSR:                   true,
 					VSRName:                 "subcoffee",
 					VSRNamespace:            "default",
@@ -1097,6 +1118,7 @@ func TestGenerateVirtualServerConfigWithBackupForNGINXPlus(t *testing.T) {
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "tea-svc",
+					UpstreamName:             "vs_default_cafe_tea",
 				},
 				{
 					Path:                     "/tea-latest",
@@ -1109,6 +1131,7 @@ func TestGenerateVirtualServerConfigWithBackupForNGINXPlus(t *testing.T) {
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "tea-svc",
+					UpstreamName:             "vs_default_cafe_tea-latest",
 				},
 				// Order changes here because we generate first all the VS Routes and then all the VSR Subroutes (separated for loops)
 				{
@@ -1130,6 +1153,7 @@ func TestGenerateVirtualServerConfigWithBackupForNGINXPlus(t *testing.T) {
 					ProxyPassRequestHeaders: true,
 					ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:             "coffee-svc",
+					UpstreamName:            "vs_default_cafe_coffee",
 				},
 				{
 					Path:                     "/coffee",
@@ -1142,6 +1166,7 @@ func TestGenerateVirtualServerConfigWithBackupForNGINXPlus(t *testing.T) {
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "coffee-svc",
+					UpstreamName:             "vs_default_cafe_vsr_default_coffee_coffee",
 					IsVSR:                    true,
 					VSRName:                  "coffee",
 					VSRNamespace:             "default",
@@ -1157,6 +1182,7 @@ func TestGenerateVirtualServerConfigWithBackupForNGINXPlus(t *testing.T) {
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "sub-tea-svc",
+					UpstreamName:             "vs_default_cafe_vsr_default_subtea_subtea",
 					IsVSR:                    true,
 					VSRName:                  "subtea",
 					VSRNamespace:             "default",
@@ -1181,6 +1207,7 @@ func TestGenerateVirtualServerConfigWithBackupForNGINXPlus(t *testing.T) {
 					ProxyPassRequestHeaders: true,
 					ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:             "coffee-svc",
+					UpstreamName:            "vs_default_cafe_vsr_default_subcoffee_coffee",
 					IsVSR:                   true,
 					VSRName:                 "subcoffee",
 					VSRNamespace:            "default",
@@ -1204,6 +1231,7 @@ func TestGenerateVirtualServerConfigWithBackupForNGINXPlus(t *testing.T) {
 					ProxyPassRequestHeaders: true,
 					ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:             "coffee-svc",
+					UpstreamName:            "vs_default_cafe_vsr_default_subcoffee_coffee",
 					IsVSR:                   true,
 					VSRName:                 "subcoffee",
 					VSRNamespace:            "default",
@@ -1405,6 +1433,7 @@ func TestGenerateVirtualServerConfig_DoesNotGenerateBackupOnMissingBackupNameFor
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "tea-svc",
+					UpstreamName:             "vs_default_cafe_tea",
 				},
 				{
 					Path:                     "/tea-latest",
@@ -1417,6 +1446,7 @@ func TestGenerateVirtualServerConfig_DoesNotGenerateBackupOnMissingBackupNameFor
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "tea-svc",
+					UpstreamName:             "vs_default_cafe_tea-latest",
 				},
 				// Order changes here because we generate first all the VS Routes and then all the VSR Subroutes (separated for loops)
 				{
@@ -1438,6 +1468,7 @@ func TestGenerateVirtualServerConfig_DoesNotGenerateBackupOnMissingBackupNameFor
 					ProxyPassRequestHeaders: true,
 					ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:             "coffee-svc",
+					UpstreamName:            "vs_default_cafe_coffee",
 				},
 				{
 					Path:                     "/coffee",
@@ -1450,6 +1481,7 @@ func TestGenerateVirtualServerConfig_DoesNotGenerateBackupOnMissingBackupNameFor
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "coffee-svc",
+					UpstreamName:             "vs_default_cafe_vsr_default_coffee_coffee",
 					IsVSR:                    true,
 					VSRName:                  "coffee",
 					VSRNamespace:             "default",
@@ -1465,6 +1497,7 @@ func TestGenerateVirtualServerConfig_DoesNotGenerateBackupOnMissingBackupNameFor
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "sub-tea-svc",
+					UpstreamName:             "vs_default_cafe_vsr_default_subtea_subtea",
 					IsVSR:                    true,
 					VSRName:                  "subtea",
 					VSRNamespace:             "default",
@@ -1489,6 +1522,7 @@ func TestGenerateVirtualServerConfig_DoesNotGenerateBackupOnMissingBackupNameFor
 					ProxyPassRequestHeaders: true,
 					ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:             "coffee-svc",
+					UpstreamName:            "vs_default_cafe_vsr_default_subcoffee_coffee",
 					IsVSR:                   true,
 					VSRName:                 "subcoffee",
 					VSRNamespace:            "default",
@@ -1512,6 +1546,7 @@ func TestGenerateVirtualServerConfig_DoesNotGenerateBackupOnMissingBackupNameFor
 					ProxyPassRequestHeaders: true,
 					ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:             "coffee-svc",
+					UpstreamName:            "vs_default_cafe_vsr_default_subcoffee_coffee",
 					IsVSR:                   true,
 					VSRName:                 "subcoffee",
 					VSRNamespace:            "default",
@@ -1712,6 +1747,7 @@ func TestGenerateVirtualServerConfig_DoesNotGenerateBackupOnMissingBackupPortFor
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "tea-svc",
+					UpstreamName:             "vs_default_cafe_tea",
 				},
 				{
 					Path:                     "/tea-latest",
@@ -1724,6 +1760,7 @@ func TestGenerateVirtualServerConfig_DoesNotGenerateBackupOnMissingBackupPortFor
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "tea-svc",
+					UpstreamName:             "vs_default_cafe_tea-latest",
 				},
 				// Order changes here because we generate first all the VS Routes and then all the VSR Subroutes (separated for loops)
 				{
@@ -1745,6 +1782,7 @@ func TestGenerateVirtualServerConfig_DoesNotGenerateBackupOnMissingBackupPortFor
 					ProxyPassRequestHeaders: true,
 					ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:             "coffee-svc",
+					UpstreamName:            "vs_default_cafe_coffee",
 				},
 				{
 					Path:                     "/coffee",
@@ -1757,6 +1795,7 @@ func TestGenerateVirtualServerConfig_DoesNotGenerateBackupOnMissingBackupPortFor
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "coffee-svc",
+					UpstreamName:             "vs_default_cafe_vsr_default_coffee_coffee",
 					IsVSR:                    true,
 					VSRName:                  "coffee",
 					VSRNamespace:             "default",
@@ -1772,6 +1811,7 @@ func TestGenerateVirtualServerConfig_DoesNotGenerateBackupOnMissingBackupPortFor
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "sub-tea-svc",
+					UpstreamName:             "vs_default_cafe_vsr_default_subtea_subtea",
 					IsVSR:                    true,
 					VSRName:                  "subtea",
 					VSRNamespace:             "default",
@@ -1796,6 +1836,7 @@ func TestGenerateVirtualServerConfig_DoesNotGenerateBackupOnMissingBackupPortFor
 					ProxyPassRequestHeaders: true,
 					ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:             "coffee-svc",
+					UpstreamName:            "vs_default_cafe_vsr_default_subcoffee_coffee",
 					IsVSR:                   true,
 					VSRName:                 "subcoffee",
 					VSRNamespace:            "default",
@@ -1819,6 +1860,7 @@ func TestGenerateVirtualServerConfig_DoesNotGenerateBackupOnMissingBackupPortFor
 					ProxyPassRequestHeaders: true,
 					ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:             "coffee-svc",
+					UpstreamName:            "vs_default_cafe_vsr_default_subcoffee_coffee",
 					IsVSR:                   true,
 					VSRName:                 "subcoffee",
 					VSRNamespace:            "default",
@@ -2017,6 +2059,7 @@ func TestGenerateVirtualServerConfig_DoesNotGenerateBackupOnMissingBackupPortAnd
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "tea-svc",
+					UpstreamName:             "vs_default_cafe_tea",
 				},
 				{
 					Path:                     "/tea-latest",
@@ -2029,6 +2072,7 @@ func TestGenerateVirtualServerConfig_DoesNotGenerateBackupOnMissingBackupPortAnd
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "tea-svc",
+					UpstreamName:             "vs_default_cafe_tea-latest",
 				},
 				// Order changes here because we generate first all the VS Routes and then all the VSR Subroutes (separated for loops)
 				{
@@ -2050,6 +2094,7 @@ func TestGenerateVirtualServerConfig_DoesNotGenerateBackupOnMissingBackupPortAnd
 					ProxyPassRequestHeaders: true,
 					ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:             "coffee-svc",
+					UpstreamName:            "vs_default_cafe_coffee",
 				},
 				{
 					Path:                     "/coffee",
@@ -2062,6 +2107,7 @@ func TestGenerateVirtualServerConfig_DoesNotGenerateBackupOnMissingBackupPortAnd
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "coffee-svc",
+					UpstreamName:             "vs_default_cafe_vsr_default_coffee_coffee",
 					IsVSR:                    true,
 					VSRName:                  "coffee",
 					VSRNamespace:             "default",
@@ -2077,6 +2123,7 @@ func TestGenerateVirtualServerConfig_DoesNotGenerateBackupOnMissingBackupPortAnd
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "sub-tea-svc",
+					UpstreamName:             "vs_default_cafe_vsr_default_subtea_subtea",
 					IsVSR:                    true,
 					VSRName:                  "subtea",
 					VSRNamespace:             "default",
@@ -2101,6 +2148,7 @@ func TestGenerateVirtualServerConfig_DoesNotGenerateBackupOnMissingBackupPortAnd
 					ProxyPassRequestHeaders: true,
 					ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:             "coffee-svc",
+					UpstreamName:            "vs_default_cafe_vsr_default_subcoffee_coffee",
 					IsVSR:                   true,
 					VSRName:                 "subcoffee",
 					VSRNamespace:            "default",
@@ -2124,6 +2172,7 @@ func TestGenerateVirtualServerConfig_DoesNotGenerateBackupOnMissingBackupPortAnd
 					ProxyPassRequestHeaders: true,
 					ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:             "coffee-svc",
+					UpstreamName:            "vs_default_cafe_vsr_default_subcoffee_coffee",
 					IsVSR:                   true,
 					VSRName:                 "subcoffee",
 					VSRNamespace:            "default",
@@ -2492,6 +2541,7 @@ func TestGenerateVirtualServerConfig(t *testing.T) {
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "tea-svc",
+					UpstreamName:             "vs_default_cafe_tea",
 				},
 				{
 					Path:                     "/tea-latest",
@@ -2504,6 +2554,7 @@ func TestGenerateVirtualServerConfig(t *testing.T) {
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "tea-svc",
+					UpstreamName:             "vs_default_cafe_tea-latest",
 				},
 				// Order changes here because we generate first all the VS Routes and then all the VSR Subroutes (separated for loops)
 				{
@@ -2525,6 +2576,7 @@ func TestGenerateVirtualServerConfig(t *testing.T) {
 					ProxyPassRequestHeaders: true,
 					ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:             "coffee-svc",
+					UpstreamName:            "vs_default_cafe_coffee",
 				},
 				{
 					Path:                     "/coffee",
@@ -2537,6 +2589,7 @@ func TestGenerateVirtualServerConfig(t *testing.T) {
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "coffee-svc",
+					UpstreamName:             "vs_default_cafe_vsr_default_coffee_coffee",
 					IsVSR:                    true,
 					VSRName:                  "coffee",
 					VSRNamespace:             "default",
@@ -2552,6 +2605,7 @@ func TestGenerateVirtualServerConfig(t *testing.T) {
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "sub-tea-svc",
+					UpstreamName:             "vs_default_cafe_vsr_default_subtea_subtea",
 					IsVSR:                    true,
 					VSRName:                  "subtea",
 					VSRNamespace:             "default",
@@ -2576,6 +2630,7 @@ func TestGenerateVirtualServerConfig(t *testing.T) {
 					ProxyPassRequestHeaders: true,
 					ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:             "coffee-svc",
+					UpstreamName:            "vs_default_cafe_vsr_default_subcoffee_coffee",
 					IsVSR:                   true,
 					VSRName:                 "subcoffee",
 					VSRNamespace:            "default",
@@ -2599,6 +2654,7 @@ func TestGenerateVirtualServerConfig(t *testing.T) {
 					ProxyPassRequestHeaders: true,
 					ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:             "coffee-svc",
+					UpstreamName:            "vs_default_cafe_vsr_default_subcoffee_coffee",
 					IsVSR:                   true,
 					VSRName:                 "subcoffee",
 					VSRNamespace:            "default",
@@ -3103,6 +3159,7 @@ func TestGenerateVirtualServerConfigIPV6Disabled(t *testing.T) {
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "tea-svc",
+					UpstreamName:             "vs_default_cafe_tea",
 				},
 				{
 					Path:                     "/coffee",
@@ -3113,6 +3170,7 @@ func TestGenerateVirtualServerConfigIPV6Disabled(t *testing.T) {
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "coffee-svc",
+					UpstreamName:             "vs_default_cafe_coffee",
 				},
 			},
 		},
@@ -3382,6 +3440,7 @@ func TestGenerateVirtualServerConfigGrpcErrorPageWarning(t *testing.T) {
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "grpc-svc",
+					UpstreamName:             "vs_default_cafe_grpc-app-1",
 					GRPCPass:                 "grpcs://vs_default_cafe_grpc-app-1",
 				},
 				{
@@ -3398,6 +3457,7 @@ func TestGenerateVirtualServerConfigGrpcErrorPageWarning(t *testing.T) {
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "grpc-svc2",
+					UpstreamName:             "vs_default_cafe_grpc-app-2",
 					GRPCPass:                 "grpcs://vs_default_cafe_grpc-app-2",
 				},
 				{
@@ -3413,6 +3473,7 @@ func TestGenerateVirtualServerConfigGrpcErrorPageWarning(t *testing.T) {
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "tea-svc",
+					UpstreamName:             "vs_default_cafe_tea",
 				},
 				{
 					Path:                     "/internal_location_splits_0_split_0",
@@ -3429,6 +3490,7 @@ func TestGenerateVirtualServerConfigGrpcErrorPageWarning(t *testing.T) {
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "grpc-svc",
+					UpstreamName:             "vs_default_cafe_grpc-app-1",
 					GRPCPass:                 "grpcs://vs_default_cafe_grpc-app-1",
 				},
 				{
@@ -3446,6 +3508,7 @@ func TestGenerateVirtualServerConfigGrpcErrorPageWarning(t *testing.T) {
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "grpc-svc2",
+					UpstreamName:             "vs_default_cafe_grpc-app-2",
 					GRPCPass:                 "grpcs://vs_default_cafe_grpc-app-2",
 				},
 			},
@@ -3596,6 +3659,7 @@ func TestGenerateVirtualServerConfigWithSpiffeCerts(t *testing.T) {
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "tea-svc",
+					UpstreamName:             "vs_default_cafe_tea",
 				},
 			},
 		},
@@ -3709,6 +3773,7 @@ func TestGenerateVirtualServerConfigWithInternalRoutes(t *testing.T) {
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "tea-svc",
+					UpstreamName:             "vs_default_cafe_tea",
 				},
 			},
 		},
@@ -3823,6 +3888,7 @@ func TestGenerateVirtualServerConfigWithInternalRoutesWarning(t *testing.T) {
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "tea-svc",
+					UpstreamName:             "vs_default_cafe_tea",
 				},
 			},
 		},
@@ -3934,6 +4000,7 @@ func TestGenerateVirtualServerConfigWithForeignNamespaceService(t *testing.T) {
 					HasKeepalive: true,
 					ProxySSLName: "coffee-svc.coffee.svc",
 					ServiceName:  "coffee-svc",
+					UpstreamName: "vs_default_cafe_coffee",
 				},
 			},
 		},
@@ -4062,6 +4129,7 @@ func TestGenerateVirtualServerConfigWithForeignNamespaceServiceInVSR(t *testing.
 					HasKeepalive: true,
 					ProxySSLName: "tea-svc.tea.svc",
 					ServiceName:  "tea-svc",
+					UpstreamName: "vs_default_cafe_vsr_default_tea_tea",
 					IsVSR:        true,
 					VSRName:      "tea",
 					VSRNamespace: "default",
@@ -5,7 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/nginx/kubernetes-ingress/internal/configs/version2"
@@ -1004,6 +1006,47 @@ func TestGenerateAuthJwtClaimSetVariable(t *testing.T) {
 	}
 }
 
+func TestGenerateAPIKeyClientMetadataMap(t *testing.T) {
+	t.Parallel()
+	clients := []apiKeyClient{
+		{
+			ClientID:  "client1",
+			HashedKey: "5e884898da28047151d0e56f8dc6292773603d0d6aabbdd62a11ef721d1542d8",
+		},
+		{
+			ClientID:  "client2",
+			HashedKey: "a3c024f01cccb3b63457d848b0d2f89c1f744a3d0c3f8e9d5b2b3d2f6c1a7a4e",
+			Name:      "Partner Two",
+			Expires:   time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC),
+			Routes:    []string{"/coffee", "/tea"},
+		},
+		{
+			ClientID:  "client3",
+			HashedKey: "c1a7a4ea3c024f01cccb3b63457d848b0d2f89c1f744a3d0c3f8e9d5b2b3d2f6",
+			Name:      "Partner Three",
+		},
+	}
+	expected := &version2.Map{
+		Source:   "$apikey_auth_token",
+		Variable: "$apikey_auth_client_name_default_cafe_vs_api_key_policy_metadata",
+		Parameters: []version2.Parameter{
+			{Value: "default", Result: `""`},
+			{Value: `"a3c024f01cccb3b63457d848b0d2f89c1f744a3d0c3f8e9d5b2b3d2f6c1a7a4e"`, Result: `"1893456000 /coffee,/tea Partner Two"`},
+			{Value: `"c1a7a4ea3c024f01cccb3b63457d848b0d2f89c1f744a3d0c3f8e9d5b2b3d2f6"`, Result: `"0 / Partner Three"`},
+		},
+	}
+
+	result := generateAPIKeyClientMetadataMap("apikey_auth_client_name_default_cafe_vs_api_key_policy", clients)
+	if diff := cmp.Diff(expected, result); diff != "" {
+		t.Errorf("generateAPIKeyClientMetadataMap() returned unexpected result (-want +got):\n%s", diff)
+	}
+
+	result = generateAPIKeyClientMetadataMap("apikey_auth_client_name_default_cafe_vs_api_key_policy", clients[:1])
+	if result != nil {
+		t.Errorf("generateAPIKeyClientMetadataMap() returned %v for the clients without metadata, expected nil", result)
+	}
+}
+
 func TestGenerateAuthJwtClaimSetClaim(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -1140,6 +1183,7 @@ func TestGenerateLocationForProxying(t *testing.T) {
 		ProxyPassRequestHeaders:  true,
 		ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 		ServiceName:              "",
+		UpstreamName:             "test-upstream",
 		IsVSR:                    false,
 		VSRName:                  "",
 		VSRNamespace:             "",
@@ -1192,6 +1236,7 @@ func TestGenerateLocationForGrpcProxying(t *testing.T) {
 		ProxyPassRequestHeaders:  true,
 		ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 		GRPCPass:                 "grpc://test-upstream",
+		UpstreamName:             "test-upstream",
 	}
 
 	result := generateLocationForProxying(path, upstreamName, conf_v1.Upstream{Type: "grpc"}, &cfgParams, nil, false, 0, "", nil, "", vsLocSnippets, false, "", "", "")
@@ -2159,6 +2204,148 @@ func TestGenerateHealthCheck(t *testing.T) {
 	}
 }
 
+func TestGenerateOutlierDetectionHealthCheck(t *testing.T) {
+	t.Parallel()
+	upstreamName := "test-upstream"
+	tests := []struct {
+		upstream conf_v1.Upstream
+		expected *version2.HealthCheck
+		msg      string
+	}{
+		{
+			upstream: conf_v1.Upstream{
+				OutlierDetection: &conf_v1.OutlierDetection{},
+			},
+			expected: &version2.HealthCheck{
+				Name:                upstreamName,
+				ProxyConnectTimeout: "5s",
+				ProxySendTimeout:    "5s",
+				ProxyReadTimeout:    "5s",
+				ProxyPass:           fmt.Sprintf("http://%v", upstreamName),
+				URI:                 "/",
+				Interval:            "5s",
+				Jitter:              "0s",
+				KeepaliveTime:       "60s",
+				Fails:               5,
+				Passes:              6,
+				Headers:             make(map[string]string),
+				Match:               fmt.Sprintf("%v_match", upstreamName),
+			},
+			msg: "outlier detection with default parameters",
+		},
+		{
+			upstream: conf_v1.Upstream{
+				OutlierDetection: &conf_v1.OutlierDetection{
+					ConsecutiveErrors: 3,
+					EjectionTime:      "1m",
+				},
+				HealthCheck: &conf_v1.HealthCheck{
+					Enable:   true,
+					Path:     "/healthz",
+					Interval: "20s",
+				},
+			},
+			expected: &version2.HealthCheck{
+				Name:                upstreamName,
+				ProxyConnectTimeout: "5s",
+				ProxySendTimeout:    "5s",
+				ProxyReadTimeout:    "5s",
+				ProxyPass:           fmt.Sprintf("http://%v", upstreamName),
+				URI:                 "/healthz",
+				Interval:            "20s",
+				Jitter:              "0s",
+				KeepaliveTime:       "60s",
+				Fails:               3,
+				Passes:              3,
+				Headers:             make(map[string]string),
+				Match:               fmt.Sprintf("%v_match", upstreamName),
+			},
+			msg: "outlier detection with health check",
+		},
+		{
+			upstream: conf_v1.Upstream{
+				OutlierDetection: &conf_v1.OutlierDetection{
+					EjectionTime: "1s",
+				},
+				HealthCheck: &conf_v1.HealthCheck{
+					Enable: true,
+					Passes: 2,
+				},
+			},
+			expected: &version2.HealthCheck{
+				Name:                upstreamName,
+				ProxyConnectTimeout: "5s",
+				ProxySendTimeout:    "5s",
+				ProxyReadTimeout:    "5s",
+				ProxyPass:           fmt.Sprintf("http://%v", upstreamName),
+				URI:                 "/",
+				Interval:            "5s",
+				Jitter:              "0s",
+				KeepaliveTime:       "60s",
+				Fails:               5,
+				Passes:              2,
+				Headers:             make(map[string]string),
+				Match:               fmt.Sprintf("%v_match", upstreamName),
+			},
+			msg: "outlier detection with health check passes",
+		},
+	}
+
+	baseCfgParams := &ConfigParams{
+		ProxySendTimeout:    "5s",
+		ProxyReadTimeout:    "5s",
+		ProxyConnectTimeout: "5s",
+	}
+
+	for _, test := range tests {
+		hc := generateHealthCheck(test.upstream, upstreamName, baseCfgParams)
+		result := generateOutlierDetectionHealthCheck(hc, test.upstream, upstreamName, baseCfgParams)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("generateOutlierDetectionHealthCheck() mismatch for case: %v (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestGenerateOutlierDetection(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		od       *conf_v1.OutlierDetection
+		expected *version2.OutlierDetection
+	}{
+		{
+			od:       nil,
+			expected: nil,
+		},
+		{
+			od: &conf_v1.OutlierDetection{},
+			expected: &version2.OutlierDetection{
+				ConsecutiveErrors:  5,
+				EjectionTime:       30 * time.Second,
+				MaxEjectionPercent: 10,
+			},
+		},
+		{
+			od: &conf_v1.OutlierDetection{
+				ConsecutiveErrors:  2,
+				EjectionTime:       "1m 30s",
+				MaxEjectionPercent: new(0),
+			},
+			expected: &version2.OutlierDetection{
+				ConsecutiveErrors:  2,
+				EjectionTime:       90 * time.Second,
+				MaxEjectionPercent: 0,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		result := generateOutlierDetection(test.od)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("generateOutlierDetection(%+v) mismatch (-want +got):\n%s", test.od, diff)
+		}
+	}
+}
+
 func TestGenerateGrpcHealthCheck(t *testing.T) {
 	t.Parallel()
 	upstreamName := "test-upstream"
@@ -2521,6 +2708,25 @@ func TestGenerateSlowStartForPlusWithInCompatibleLBMethods(t *testing.T) {
 	}
 }
 
+func TestGenerateSlowStartForPlusWithOutlierDetectionAndInCompatibleLBMethod(t *testing.T) {
+	t.Parallel()
+	upstream := conf_v1.Upstream{Service: "test-slowstart-outlier-detection", Port: 80, OutlierDetection: &conf_v1.OutlierDetection{}}
+
+	vsc := newVirtualServerConfigurator(&ConfigParams{Context: context.Background()}, true, false, &StaticConfigParams{}, false, &fakeBV)
+	result := vsc.generateSlowStartForPlus(&conf_v1.VirtualServer{}, upstream, "ip_hash")
+	if result != "" {
+		t.Errorf("generateSlowStartForPlus returned %v, but expected no slow start", result)
+	}
+
+	var warnings []string
+	for _, w := range vsc.warnings {
+		warnings = append(warnings, w...)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "will not be re-admitted gradually") {
+		t.Errorf("generateSlowStartForPlus returned warnings %v, expected the warning about the re-admission", warnings)
+	}
+}
+
 func TestGenerateSlowStartForPlus(t *testing.T) {
 	serviceName := "test-slowstart"
 
@@ -2539,6 +2745,16 @@ func TestGenerateSlowStartForPlus(t *testing.T) {
 			lbMethod: "least_conn",
 			expected: "10s",
 		},
+		{
+			upstream: conf_v1.Upstream{Service: serviceName, Port: 80, LBMethod: "least_conn", OutlierDetection: &conf_v1.OutlierDetection{}},
+			lbMethod: "least_conn",
+			expected: "30s",
+		},
+		{
+			upstream: conf_v1.Upstream{Service: serviceName, Port: 80, SlowStart: "10s", LBMethod: "least_conn", OutlierDetection: &conf_v1.OutlierDetection{}},
+			lbMethod: "least_conn",
+			expected: "10s",
+		},
 	}
 
 	for _, test := range tests {
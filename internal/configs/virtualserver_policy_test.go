@@ -217,6 +217,7 @@ func TestGenerateVirtualServerConfigJWKSPolicy(t *testing.T) {
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "tea-svc",
+					UpstreamName:             "vs_default_cafe_tea",
 					JWTAuth: &version2.JWTAuth{
 						Key:      "default/jwt-policy-route",
 						Realm:    "Route Realm API",
@@ -243,6 +244,7 @@ func TestGenerateVirtualServerConfigJWKSPolicy(t *testing.T) {
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "coffee-svc",
+					UpstreamName:             "vs_default_cafe_coffee",
 					JWTAuth: &version2.JWTAuth{
 						Key:      "default/jwt-policy-route",
 						Realm:    "Route Realm API",
@@ -484,6 +486,7 @@ func TestGenerateVirtualServerConfigExternalAuthPolicyPlusRoute(t *testing.T) {
 					ProxyPassRequestHeaders: true,
 					ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:             "tea-svc",
+					UpstreamName:            "vs_default_cafe_tea",
 					ExternalAuth: &version2.ExternalAuth{
 						URI: &version2.AuthURI{
 							Service:      "auth-server",
@@ -508,6 +511,7 @@ func TestGenerateVirtualServerConfigExternalAuthPolicyPlusRoute(t *testing.T) {
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "coffee-svc",
+					UpstreamName:             "vs_default_cafe_coffee",
 				},
 			},
 		},
@@ -886,6 +890,7 @@ func TestGenerateVirtualServerConfigExternalAuthPolicyPlusSubroute(t *testing.T)
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "coffee-svc",
+					UpstreamName:             "vs_default_cafe_coffee",
 				},
 				{
 					Path:                    "/_external_auth/auth",
@@ -938,6 +943,7 @@ func TestGenerateVirtualServerConfigExternalAuthPolicyPlusSubroute(t *testing.T)
 					ProxyPassRequestHeaders: true,
 					ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:             "tea-v1-svc",
+					UpstreamName:            "vs_default_cafe_vsr_default_tea-vsr_tea-v1",
 					IsVSR:                   true,
 					VSRName:                 "tea-vsr",
 					VSRNamespace:            "default",
@@ -965,6 +971,7 @@ func TestGenerateVirtualServerConfigExternalAuthPolicyPlusSubroute(t *testing.T)
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "tea-v2-svc",
+					UpstreamName:             "vs_default_cafe_vsr_default_tea-vsr_tea-v2",
 					IsVSR:                    true,
 					VSRName:                  "tea-vsr",
 					VSRNamespace:             "default",
@@ -1161,6 +1168,7 @@ func TestGenerateVirtualServerConfigJWTSSLVerifyDepth(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "tea-svc",
+							UpstreamName:             "vs_default_cafe_tea",
 						},
 					},
 				},
@@ -1390,6 +1398,7 @@ func TestGenerateVirtualServerConfigAPIKeyPolicy(t *testing.T) {
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "tea-svc",
+					UpstreamName:             "vs_default_cafe_tea",
 				},
 				{
 					Path:                     "/coffee",
@@ -1402,6 +1411,7 @@ func TestGenerateVirtualServerConfigAPIKeyPolicy(t *testing.T) {
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "coffee-svc",
+					UpstreamName:             "vs_default_cafe_coffee",
 					APIKey: &version2.APIKey{
 						MapName: "apikey_auth_client_name_default_cafe_vs_api_key_policy_route",
 						Query:   []string{"api-key"},
@@ -1890,6 +1900,7 @@ func TestGenerateVirtualServerConfigRateLimit(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "tea-svc",
+							UpstreamName:             "vs_default_cafe_tea",
 						},
 						{
 							Path:                     "/coffee",
@@ -1901,6 +1912,7 @@ func TestGenerateVirtualServerConfigRateLimit(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "coffee-svc",
+							UpstreamName:             "vs_default_cafe_coffee",
 						},
 					},
 				},
@@ -2039,6 +2051,7 @@ func TestGenerateVirtualServerConfigRateLimit(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "tea-svc",
+							UpstreamName:             "vs_default_cafe_tea",
 						},
 						{
 							Path:                     "/coffee",
@@ -2050,6 +2063,7 @@ func TestGenerateVirtualServerConfigRateLimit(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "coffee-svc",
+							UpstreamName:             "vs_default_cafe_coffee",
 						},
 					},
 				},
@@ -2237,6 +2251,7 @@ func TestGenerateVirtualServerConfigCache(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "tea-svc",
+							UpstreamName:             "vs_default_cafe_tea",
 						},
 						{
 							Path:                     "/coffee",
@@ -2248,6 +2263,7 @@ func TestGenerateVirtualServerConfigCache(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "coffee-svc",
+							UpstreamName:             "vs_default_cafe_coffee",
 						},
 					},
 				},
@@ -2382,6 +2398,7 @@ func TestGenerateVirtualServerConfigCache(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "tea-svc",
+							UpstreamName:             "vs_default_cafe_tea",
 							Cache: &version2.Cache{
 								ZoneName:              "default_cafe_vs_route-cache",
 								ZoneSize:              "5m",
@@ -2404,6 +2421,7 @@ func TestGenerateVirtualServerConfigCache(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "coffee-svc",
+							UpstreamName:             "vs_default_cafe_coffee",
 						},
 					},
 				},
@@ -2587,6 +2605,7 @@ func TestGenerateVirtualServerConfigCache(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "tea-v1-svc",
+							UpstreamName:             "vs_default_cafe_vsr_default_tea-vsr_tea-v1",
 							IsVSR:                    true,
 							VSRName:                  "tea-vsr",
 							VSRNamespace:             "default",
@@ -2617,6 +2636,7 @@ func TestGenerateVirtualServerConfigCache(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "tea-v2-svc",
+							UpstreamName:             "vs_default_cafe_vsr_default_tea-vsr_tea-v2",
 							IsVSR:                    true,
 							VSRName:                  "tea-vsr",
 							VSRNamespace:             "default",
@@ -2781,6 +2801,7 @@ func TestGenerateVirtualServerConfigCache(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "backend-svc",
+							UpstreamName:             "vs_default_extended-cache_backend",
 						},
 					},
 				},
@@ -2819,6 +2840,26 @@ func TestGenerateVirtualServerConfigCache(t *testing.T) {
 func TestGenerateVirtualServerConfigWithOIDCTLSVerifyOn(t *testing.T) {
 	t.Parallel()
 
+	expectedOIDC := &version2.OIDC{
+		AuthEndpoint:          "https://auth.example.com",
+		TokenEndpoint:         "https://token.example.com",
+		JwksURI:               "https://jwks.example.com",
+		EndSessionEndpoint:    "https://logout.example.com",
+		ClientID:              "example-client-id",
+		ClientSecret:          "c2VjcmV0",
+		Scope:                 "openid+profile+email",
+		TLSVerify:             true,
+		VerifyDepth:           1,
+		CAFile:                "/etc/ssl/certs/ca-certificate.crt",
+		ZoneSyncLeeway:        200,
+		RedirectURI:           "/_codexch",
+		LogoutURI:             "/logout",
+		PostLogoutRedirectURI: "/_logout",
+		SessionCookie:         "auth_token",
+		PolicyName:            "default/oidc-policy",
+		ID:                    "default_oidc_policy",
+	}
+
 	tests := []struct {
 		msg             string
 		virtualServerEx VirtualServerEx
@@ -2957,7 +2998,8 @@ func TestGenerateVirtualServerConfigWithOIDCTLSVerifyOn(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "tea-svc",
-							OIDC:                     true,
+							UpstreamName:             "vs_default_cafe_tea",
+							OIDC:                     expectedOIDC,
 						},
 						{
 							Path:                     "/coffee",
@@ -2969,25 +3011,12 @@ func TestGenerateVirtualServerConfigWithOIDCTLSVerifyOn(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "coffee-svc",
-							OIDC:                     true,
+							UpstreamName:             "vs_default_cafe_coffee",
+							OIDC:                     expectedOIDC,
 						},
 					},
-					OIDC: &version2.OIDC{
-						AuthEndpoint:          "https://auth.example.com",
-						TokenEndpoint:         "https://token.example.com",
-						JwksURI:               "https://jwks.example.com",
-						EndSessionEndpoint:    "https://logout.example.com",
-						ClientID:              "example-client-id",
-						ClientSecret:          "c2VjcmV0",
-						Scope:                 "openid+profile+email",
-						TLSVerify:             true,
-						VerifyDepth:           1,
-						CAFile:                "/etc/ssl/certs/ca-certificate.crt",
-						ZoneSyncLeeway:        200,
-						RedirectURI:           "/_codexch",
-						PostLogoutRedirectURI: "/_logout",
-						PolicyName:            "default/oidc-policy",
-					},
+					OIDCProviders: []*version2.OIDC{expectedOIDC},
+					OIDC:          expectedOIDC,
 				},
 			},
 		},
@@ -3034,6 +3063,26 @@ func TestGenerateVirtualServerConfigWithOIDCTLSVerifyOn(t *testing.T) {
 func TestGenerateVirtualServerConfigWithOIDCTLSCASecret(t *testing.T) {
 	t.Parallel()
 
+	expectedOIDC := &version2.OIDC{
+		AuthEndpoint:          "https://auth.example.com",
+		TokenEndpoint:         "https://token.example.com",
+		JwksURI:               "https://jwks.example.com",
+		EndSessionEndpoint:    "https://logout.example.com",
+		ClientID:              "example-client-id",
+		ClientSecret:          "c2VjcmV0",
+		Scope:                 "openid+profile+email",
+		TLSVerify:             true,
+		VerifyDepth:           1,
+		CAFile:                "/etc/nginx/secrets/default-example-ca-secret-ca.crt",
+		ZoneSyncLeeway:        200,
+		RedirectURI:           "/_codexch",
+		LogoutURI:             "/logout",
+		PostLogoutRedirectURI: "/_logout",
+		SessionCookie:         "auth_token",
+		PolicyName:            "default/oidc-policy",
+		ID:                    "default_oidc_policy",
+	}
+
 	tests := []struct {
 		msg             string
 		virtualServerEx VirtualServerEx
@@ -3182,7 +3231,8 @@ func TestGenerateVirtualServerConfigWithOIDCTLSCASecret(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "tea-svc",
-							OIDC:                     true,
+							UpstreamName:             "vs_default_cafe_tea",
+							OIDC:                     expectedOIDC,
 						},
 						{
 							Path:                     "/coffee",
@@ -3194,25 +3244,12 @@ func TestGenerateVirtualServerConfigWithOIDCTLSCASecret(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "coffee-svc",
-							OIDC:                     true,
+							UpstreamName:             "vs_default_cafe_coffee",
+							OIDC:                     expectedOIDC,
 						},
 					},
-					OIDC: &version2.OIDC{
-						AuthEndpoint:          "https://auth.example.com",
-						TokenEndpoint:         "https://token.example.com",
-						JwksURI:               "https://jwks.example.com",
-						EndSessionEndpoint:    "https://logout.example.com",
-						ClientID:              "example-client-id",
-						ClientSecret:          "c2VjcmV0",
-						Scope:                 "openid+profile+email",
-						TLSVerify:             true,
-						VerifyDepth:           1,
-						CAFile:                "/etc/nginx/secrets/default-example-ca-secret-ca.crt",
-						ZoneSyncLeeway:        200,
-						RedirectURI:           "/_codexch",
-						PostLogoutRedirectURI: "/_logout",
-						PolicyName:            "default/oidc-policy",
-					},
+					OIDCProviders: []*version2.OIDC{expectedOIDC},
+					OIDC:          expectedOIDC,
 				},
 			},
 		},
@@ -3354,8 +3391,8 @@ func TestGenerateVirtualServerConfigOIDCRouteDoesNotLeakToSubsequentRoutes(t *te
 	}
 
 	// Server block must carry the OIDC helper config for the protected route.
-	if result.Server.OIDC == nil {
-		t.Fatal("expected Server.OIDC to be non-nil so the OIDC helper locations are generated")
+	if len(result.Server.OIDCProviders) != 1 {
+		t.Fatalf("expected one Server.OIDCProviders entry so the OIDC helper locations are generated, got %d", len(result.Server.OIDCProviders))
 	}
 
 	// Build a map of path -> Location for easy assertion.
@@ -3370,14 +3407,14 @@ func TestGenerateVirtualServerConfigOIDCRouteDoesNotLeakToSubsequentRoutes(t *te
 		}
 	}
 
-	if loc, ok := locByPath["/public-before"]; ok && loc.OIDC {
-		t.Errorf("/public-before should NOT have OIDC=true")
+	if loc, ok := locByPath["/public-before"]; ok && loc.OIDC != nil {
+		t.Errorf("/public-before should NOT have OIDC set")
 	}
-	if loc, ok := locByPath["/protected"]; ok && !loc.OIDC {
-		t.Errorf("/protected should have OIDC=true")
+	if loc, ok := locByPath["/protected"]; ok && loc.OIDC == nil {
+		t.Errorf("/protected should have OIDC set")
 	}
-	if loc, ok := locByPath["/public-after"]; ok && loc.OIDC {
-		t.Errorf("/public-after should NOT have OIDC=true")
+	if loc, ok := locByPath["/public-after"]; ok && loc.OIDC != nil {
+		t.Errorf("/public-after should NOT have OIDC set")
 	}
 }
 
@@ -3478,19 +3515,19 @@ func TestGenerateVirtualServerConfigOIDCAtSpecLevelAppliesToAllRoutes(t *testing
 		t.Errorf("GenerateVirtualServerConfig returned unexpected warnings: %v", warnings)
 	}
 
-	if result.Server.OIDC == nil {
-		t.Fatal("expected Server.OIDC to be non-nil")
+	if len(result.Server.OIDCProviders) != 1 {
+		t.Fatalf("expected one Server.OIDCProviders entry, got %d", len(result.Server.OIDCProviders))
 	}
 
 	for _, loc := range result.Server.Locations {
-		if !loc.OIDC {
-			t.Errorf("location %q should have OIDC=true because the VS spec carries the OIDC policy, but got OIDC=false", loc.Path)
+		if loc.OIDC == nil {
+			t.Errorf("location %q should have OIDC set because the VS spec carries the OIDC policy", loc.Path)
 		}
 	}
 }
 
 // TestGenerateVirtualServerConfigOIDCMultipleRoutesWithSamePolicy verifies that multiple routes
-// referencing the same OIDC policy all receive location.OIDC=true.
+// referencing the same OIDC policy all receive location.OIDC.
 func TestGenerateVirtualServerConfigOIDCMultipleRoutesWithSamePolicy(t *testing.T) {
 	t.Parallel()
 
@@ -3579,8 +3616,8 @@ func TestGenerateVirtualServerConfigOIDCMultipleRoutesWithSamePolicy(t *testing.
 		t.Errorf("GenerateVirtualServerConfig returned unexpected warnings: %v", warnings)
 	}
 
-	if result.Server.OIDC == nil {
-		t.Fatal("expected Server.OIDC to be non-nil")
+	if len(result.Server.OIDCProviders) != 1 {
+		t.Fatalf("expected one Server.OIDCProviders entry, got %d", len(result.Server.OIDCProviders))
 	}
 
 	locByPath := make(map[string]version2.Location)
@@ -3594,14 +3631,174 @@ func TestGenerateVirtualServerConfigOIDCMultipleRoutesWithSamePolicy(t *testing.
 		}
 	}
 
-	if loc, ok := locByPath["/route1"]; ok && !loc.OIDC {
-		t.Errorf("/route1 should have OIDC=true")
+	if loc, ok := locByPath["/route1"]; ok && loc.OIDC == nil {
+		t.Errorf("/route1 should have OIDC set")
 	}
-	if loc, ok := locByPath["/public"]; ok && loc.OIDC {
-		t.Errorf("/public should NOT have OIDC=true")
+	if loc, ok := locByPath["/public"]; ok && loc.OIDC != nil {
+		t.Errorf("/public should NOT have OIDC set")
 	}
-	if loc, ok := locByPath["/route2"]; ok && !loc.OIDC {
-		t.Errorf("/route2 should have OIDC=true (same policy referenced on a subsequent route)")
+	if loc, ok := locByPath["/route2"]; ok && loc.OIDC == nil {
+		t.Errorf("/route2 should have OIDC set (same policy referenced on a subsequent route)")
+	}
+}
+
+// TestGenerateVirtualServerConfigOIDCDifferentPoliciesOnRoutes verifies that routes of a VirtualServer
+// can use different OIDC policies, and that the claims of the policies are configured.
+func TestGenerateVirtualServerConfigOIDCDifferentPoliciesOnRoutes(t *testing.T) {
+	t.Parallel()
+
+	virtualServerEx := VirtualServerEx{
+		VirtualServer: &conf_v1.VirtualServer{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "cafe",
+				Namespace: "default",
+			},
+			Spec: conf_v1.VirtualServerSpec{
+				Host: "cafe.example.com",
+				Upstreams: []conf_v1.Upstream{
+					{
+						Name:    "app",
+						Service: "app-svc",
+						Port:    80,
+					},
+				},
+				Routes: []conf_v1.Route{
+					{
+						Path:     "/admin",
+						Policies: []conf_v1.PolicyReference{{Name: "oidc-admin"}},
+						Action:   &conf_v1.Action{Pass: "app"},
+					},
+					{
+						Path:     "/",
+						Policies: []conf_v1.PolicyReference{{Name: "oidc-users"}},
+						Action:   &conf_v1.Action{Pass: "app"},
+					},
+				},
+			},
+		},
+		Policies: map[string]*conf_v1.Policy{
+			"default/oidc-admin": {
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "oidc-admin",
+					Namespace: "default",
+				},
+				Spec: conf_v1.PolicySpec{
+					OIDC: &conf_v1.OIDC{
+						AuthEndpoint:  "https://admin-idp.example.com/auth",
+						TokenEndpoint: "https://admin-idp.example.com/token",
+						JWKSURI:       "https://admin-idp.example.com/jwks",
+						ClientID:      "admin-client",
+						ClientSecret:  "example-client-secret",
+						RedirectURI:   "/admin/_codexch",
+						LogoutURI:     "/admin/logout",
+						SessionCookie: "admin_token",
+						ClaimHeaders: []conf_v1.OIDCClaimHeader{
+							{Claim: "email", Header: "X-Email"},
+						},
+						Groups: &conf_v1.OIDCGroups{
+							Allow: []string{"admins"},
+						},
+					},
+				},
+			},
+			"default/oidc-users": {
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "oidc-users",
+					Namespace: "default",
+				},
+				Spec: conf_v1.PolicySpec{
+					OIDC: &conf_v1.OIDC{
+						AuthEndpoint:  "https://idp.example.com/auth",
+						TokenEndpoint: "https://idp.example.com/token",
+						JWKSURI:       "https://idp.example.com/jwks",
+						ClientID:      "users-client",
+						PKCEEnable:    true,
+					},
+				},
+			},
+		},
+		Endpoints: map[string][]string{
+			"default/app-svc:80": {"10.0.0.10:80"},
+		},
+		SecretRefs: map[string]*secrets.SecretReference{
+			"default/example-client-secret": {
+				Secret: &api_v1.Secret{
+					Type: secrets.SecretTypeOIDC,
+					Data: map[string][]byte{
+						"client-secret": []byte("c2VjcmV0"),
+					},
+				},
+			},
+		},
+	}
+
+	baseCfgParams := ConfigParams{
+		Context:      context.Background(),
+		ServerTokens: "off",
+	}
+
+	vsc := newVirtualServerConfigurator(
+		&baseCfgParams,
+		false,
+		false,
+		&StaticConfigParams{},
+		false,
+		&fakeBV,
+	)
+
+	result, warnings := vsc.GenerateVirtualServerConfig(&virtualServerEx, nil, nil)
+	if len(warnings) != 0 {
+		t.Errorf("GenerateVirtualServerConfig returned unexpected warnings: %v", warnings)
+	}
+
+	if len(result.Server.OIDCProviders) != 2 {
+		t.Fatalf("expected two Server.OIDCProviders entries, got %d", len(result.Server.OIDCProviders))
+	}
+	if result.Server.OIDC != nil {
+		t.Errorf("expected no Server.OIDC with multiple OIDC providers, got %+v", result.Server.OIDC)
+	}
+
+	locByPath := make(map[string]version2.Location)
+	for _, loc := range result.Server.Locations {
+		locByPath[loc.Path] = loc
+	}
+
+	admin := locByPath["/admin"].OIDC
+	if admin == nil || admin.ID != "default_oidc_admin" {
+		t.Fatalf("/admin should use the OIDC policy default/oidc-admin, got %+v", admin)
+	}
+	if users := locByPath["/"].OIDC; users == nil || users.ID != "default_oidc_users" {
+		t.Errorf("/ should use the OIDC policy default/oidc-users, got %+v", users)
+	}
+
+	expectedClaimHeaders := []version2.Header{{Name: "X-Email", Value: "$oidc_claim_default_cafe_vs_default_oidc_admin_0"}}
+	if diff := cmp.Diff(expectedClaimHeaders, admin.ClaimHeaders); diff != "" {
+		t.Errorf("ClaimHeaders mismatch (-want +got):\n%s", diff)
+	}
+	expectedRequire := &version2.JWTRequire{Variables: []string{"$oidc_groups_default_cafe_vs_default_oidc_admin_1"}, Code: 403}
+	if diff := cmp.Diff(expectedRequire, admin.Require); diff != "" {
+		t.Errorf("Require mismatch (-want +got):\n%s", diff)
+	}
+
+	expectedClaimSets := []version2.AuthJWTClaimSet{
+		{Variable: "$oidc_claim_default_cafe_vs_default_oidc_admin_0", Claim: "email"},
+		{Variable: "$oidc_claim_default_cafe_vs_default_oidc_admin_1", Claim: "groups"},
+	}
+	if diff := cmp.Diff(expectedClaimSets, result.AuthJWTClaimSets); diff != "" {
+		t.Errorf("AuthJWTClaimSets mismatch (-want +got):\n%s", diff)
+	}
+	expectedMaps := []version2.Map{
+		{
+			Source:   "$oidc_claim_default_cafe_vs_default_oidc_admin_1",
+			Variable: "$oidc_groups_default_cafe_vs_default_oidc_admin_1",
+			Parameters: []version2.Parameter{
+				{Value: "default", Result: "0"},
+				{Value: `"~(^|,)admins(,|$)"`, Result: "1"},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedMaps, result.Maps); diff != "" {
+		t.Errorf("Maps mismatch (-want +got):\n%s", diff)
 	}
 }
 
@@ -3709,6 +3906,7 @@ func TestGenerateVirtualServerConfigWithRouteSelector(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "coffee-svc",
+							UpstreamName:             "vs_default_cafe_vsr_default_coffee_coffee",
 							IsVSR:                    true,
 							VSRName:                  "coffee",
 							VSRNamespace:             "default",
@@ -3857,6 +4055,7 @@ func TestGenerateVirtualServerConfigWithRouteSelector(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "coffee-svc",
+							UpstreamName:             "vs_default_cafe_vsr_coffee_coffee_coffee",
 							IsVSR:                    true,
 							VSRName:                  "coffee",
 							VSRNamespace:             "coffee",
@@ -3871,6 +4070,7 @@ func TestGenerateVirtualServerConfigWithRouteSelector(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "tea-svc",
+							UpstreamName:             "vs_default_cafe_vsr_tea_tea_tea",
 							IsVSR:                    true,
 							VSRName:                  "tea",
 							VSRNamespace:             "tea",
@@ -4100,6 +4300,7 @@ func TestGenerateVirtualServerConfigWithRouteSelector(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "coffee-svc",
+							UpstreamName:             "vs_default_cafe_vsr_coffee_coffee_coffee",
 							IsVSR:                    true,
 							VSRName:                  "coffee",
 							VSRNamespace:             "coffee",
@@ -4122,6 +4323,7 @@ func TestGenerateVirtualServerConfigWithRouteSelector(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "tea-svc",
+							UpstreamName:             "vs_default_cafe_vsr_tea_tea_tea",
 							IsVSR:                    true,
 							VSRName:                  "tea",
 							VSRNamespace:             "tea",
@@ -4828,6 +5030,7 @@ func TestGenerateVirtualServerConfigExternalAuthPolicy(t *testing.T) {
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "tea-svc",
+					UpstreamName:             "vs_default_cafe_tea",
 				},
 				{
 					Path:                     "/coffee",
@@ -4840,6 +5043,7 @@ func TestGenerateVirtualServerConfigExternalAuthPolicy(t *testing.T) {
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "coffee-svc",
+					UpstreamName:             "vs_default_cafe_coffee",
 				},
 			},
 		},
@@ -4875,3 +5079,364 @@ func TestGenerateVirtualServerConfigExternalAuthPolicy(t *testing.T) {
 		t.Errorf("GenerateVirtualServerConfig returned warnings: %v", vsc.warnings)
 	}
 }
+
+func TestGenerateExternalAuthLocationGRPC(t *testing.T) {
+	t.Parallel()
+
+	vsc := newVirtualServerConfigurator(&baseCfgParams, false, false, &StaticConfigParams{}, false, &fakeBV)
+	cfg := policiesCfg{
+		ExternalAuth: &version2.ExternalAuth{
+			URI: &version2.AuthURI{
+				Service:      "opa",
+				Upstream:     "vs_exauth_default_grpc-ext-auth",
+				Path:         "/",
+				InternalPath: "/_external_authz_grpc_default_cafe_vs_default_grpc_ext_auth",
+			},
+			SSLEnabled: true,
+			GRPC: &version2.ExternalAuthGRPC{
+				Path:         "/_external_authz_default_cafe_vs_default_grpc_ext_auth",
+				EndpointPath: "/_external_authz_grpc_default_cafe_vs_default_grpc_ext_auth",
+			},
+		},
+	}
+
+	loc := vsc.generateExternalAuthLocation(cfg, "vs_default_cafe_vs_exauth_default_grpc-ext-auth")
+
+	if loc.ProxyPass != "" {
+		t.Errorf("generateExternalAuthLocation() returned ProxyPass %q, expected no ProxyPass", loc.ProxyPass)
+	}
+	expectedGRPCPass := "grpcs://vs_default_cafe_vs_exauth_default_grpc-ext-auth"
+	if loc.GRPCPass != expectedGRPCPass {
+		t.Errorf("generateExternalAuthLocation() returned GRPCPass %q, expected %q", loc.GRPCPass, expectedGRPCPass)
+	}
+	expectedRewrites := []string{"^ /envoy.service.auth.v3.Authorization/Check break"}
+	if diff := cmp.Diff(expectedRewrites, loc.Rewrites); diff != "" {
+		t.Errorf("generateExternalAuthLocation() Rewrites mismatch (-want +got):\n%s", diff)
+	}
+	expectedHeaders := []version2.Header{
+		{Name: "Content-Type", Value: "application/grpc"},
+		{Name: "Content-Length", Value: ""},
+	}
+	if diff := cmp.Diff(expectedHeaders, loc.ProxySetHeaders); diff != "" {
+		t.Errorf("generateExternalAuthLocation() ProxySetHeaders mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestAddHeadersPolicyToLocation(t *testing.T) {
+	t.Parallel()
+
+	proxySetHeaders := []version2.Header{{Name: "Host", Value: "$host"}, {Name: "X-User", Value: "action"}}
+	addHeaders := []version2.AddHeader{{Header: version2.Header{Name: "X-Frame-Options", Value: "SAMEORIGIN"}}}
+	location := &version2.Location{
+		ProxySetHeaders: proxySetHeaders,
+		AddHeaders:      addHeaders,
+	}
+	headers := &headersPolicy{
+		Request: []headerModifier{
+			{Name: "x-user", SetHeader: &version2.Header{Name: "x-user", Value: "policy"}},
+			{Name: "X-Debug", SetHeader: &version2.Header{Name: "X-Debug", Value: ""}},
+		},
+		Response: []headerModifier{
+			{Name: "x-frame-options", Hide: true, AddHeader: &version2.AddHeader{Header: version2.Header{Name: "x-frame-options", Value: "DENY"}}},
+			{Name: "Server", Hide: true},
+		},
+	}
+
+	addHeadersPolicyToLocation(headers, location)
+
+	expectedSetHeaders := []version2.Header{
+		{Name: "Host", Value: "$host"},
+		{Name: "X-User", Value: "action"},
+		{Name: "X-Debug", Value: ""},
+	}
+	if diff := cmp.Diff(expectedSetHeaders, location.ProxySetHeaders); diff != "" {
+		t.Errorf("addHeadersPolicyToLocation() ProxySetHeaders mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"Server"}, location.ProxyHideHeaders); diff != "" {
+		t.Errorf("addHeadersPolicyToLocation() ProxyHideHeaders mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(addHeaders, location.AddHeaders); diff != "" {
+		t.Errorf("addHeadersPolicyToLocation() AddHeaders mismatch (-want +got):\n%s", diff)
+	}
+	if len(proxySetHeaders) != 2 {
+		t.Errorf("addHeadersPolicyToLocation() modified the headers of the action proxy: %v", proxySetHeaders)
+	}
+}
+
+func TestGenerateVirtualServerConfigHMACPolicy(t *testing.T) {
+	t.Parallel()
+
+	virtualServerEx := VirtualServerEx{
+		VirtualServer: &conf_v1.VirtualServer{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "cafe",
+				Namespace: "default",
+			},
+			Spec: conf_v1.VirtualServerSpec{
+				Host: "cafe.example.com",
+				Policies: []conf_v1.PolicyReference{
+					{
+						Name: "hmac-policy",
+					},
+				},
+				Upstreams: []conf_v1.Upstream{
+					{
+						Name:    "tea",
+						Service: "tea-svc",
+						Port:    80,
+					},
+					{
+						Name:    "coffee-v1",
+						Service: "coffee-svc-v1",
+						Port:    80,
+					},
+					{
+						Name:    "coffee-v2",
+						Service: "coffee-svc-v2",
+						Port:    80,
+					},
+				},
+				Routes: []conf_v1.Route{
+					{
+						Path: "/tea",
+						Action: &conf_v1.Action{
+							Pass: "tea",
+						},
+					},
+					{
+						Path: "/coffee",
+						Splits: []conf_v1.Split{
+							{
+								Weight: 90,
+								Action: &conf_v1.Action{Pass: "coffee-v1"},
+							},
+							{
+								Weight: 10,
+								Action: &conf_v1.Action{Pass: "coffee-v2"},
+							},
+						},
+					},
+				},
+			},
+		},
+		Policies: map[string]*conf_v1.Policy{
+			"default/hmac-policy": {
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "hmac-policy",
+					Namespace: "default",
+				},
+				Spec: conf_v1.PolicySpec{
+					HMAC: &conf_v1.HMAC{
+						Secret: "hmac-secret",
+					},
+				},
+			},
+		},
+		SecretRefs: map[string]*secrets.SecretReference{
+			"default/hmac-secret": {
+				Secret: &api_v1.Secret{
+					Type: secrets.SecretTypeHMAC,
+					Data: map[string][]byte{
+						"partner": []byte("secret"),
+					},
+				},
+				Path: "/etc/nginx/secrets/default-hmac-secret",
+			},
+		},
+		Endpoints: map[string][]string{
+			"default/tea-svc:80": {
+				"10.0.0.20:80",
+			},
+			"default/coffee-svc-v1:80": {
+				"10.0.0.30:80",
+			},
+			"default/coffee-svc-v2:80": {
+				"10.0.0.31:80",
+			},
+		},
+	}
+
+	expectedHMAC := &version2.HMAC{
+		KeysFile:        "/etc/nginx/secrets/default-hmac-secret",
+		SignatureHeader: "X-Signature",
+		TimestampHeader: "X-Timestamp",
+		CanonicalString: "{timestamp}.{body}",
+		ClockSkew:       300,
+		MaxBodySize:     "1m",
+		RejectCode:      401,
+	}
+	expectedInternalRedirectLocations := []version2.InternalRedirectLocation{
+		{
+			Path:        "/tea",
+			Destination: "/internal_location_hmac_0",
+			HMAC:        expectedHMAC,
+		},
+		{
+			Path:        "/coffee",
+			Destination: "$vs_default_cafe_splits_0",
+			HMAC:        expectedHMAC,
+		},
+	}
+
+	vsc := newVirtualServerConfigurator(&baseCfgParams, false, false, &StaticConfigParams{}, false, &fakeBV)
+	result, warnings := vsc.GenerateVirtualServerConfig(&virtualServerEx, nil, nil)
+	if len(warnings) != 0 {
+		t.Errorf("GenerateVirtualServerConfig() returned warnings: %v", warnings)
+	}
+
+	if diff := cmp.Diff(expectedInternalRedirectLocations, result.Server.InternalRedirectLocations); diff != "" {
+		t.Errorf("GenerateVirtualServerConfig() InternalRedirectLocations mismatch (-want +got):\n%s", diff)
+	}
+
+	var teaLocation *version2.Location
+	for i, l := range result.Server.Locations {
+		if l.Path == "/tea" {
+			t.Errorf("GenerateVirtualServerConfig() returned the location /tea, expected only the internal location of the route")
+		}
+		if l.Path == "/internal_location_hmac_0" {
+			teaLocation = &result.Server.Locations[i]
+		}
+	}
+	if teaLocation == nil {
+		t.Fatal("GenerateVirtualServerConfig() didn't return the internal location /internal_location_hmac_0")
+	}
+	if !teaLocation.Internal {
+		t.Error("GenerateVirtualServerConfig() returned the location /internal_location_hmac_0 that is not internal")
+	}
+	expectedProxyPass := "http://vs_default_cafe_tea$request_uri"
+	if teaLocation.ProxyPass != expectedProxyPass {
+		t.Errorf("GenerateVirtualServerConfig() returned ProxyPass %q for the internal location, expected %q", teaLocation.ProxyPass, expectedProxyPass)
+	}
+}
+
+func TestGenerateVirtualServerConfigDefaultPolicies(t *testing.T) {
+	t.Parallel()
+
+	virtualServerEx := VirtualServerEx{
+		VirtualServer: &conf_v1.VirtualServer{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "cafe",
+				Namespace: "default",
+			},
+			Spec: conf_v1.VirtualServerSpec{
+				Host: "cafe.example.com",
+				Upstreams: []conf_v1.Upstream{
+					{
+						Name:    "coffee",
+						Service: "coffee-svc",
+						Port:    80,
+					},
+				},
+				Routes: []conf_v1.Route{
+					{
+						Path:  "/tea",
+						Route: "tenant/tea-vsr",
+					},
+					{
+						Path: "/coffee",
+						Action: &conf_v1.Action{
+							Pass: "coffee",
+						},
+					},
+				},
+			},
+		},
+		VirtualServerRoutes: []*conf_v1.VirtualServerRoute{
+			{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "tea-vsr",
+					Namespace: "tenant",
+				},
+				Spec: conf_v1.VirtualServerRouteSpec{
+					Host: "cafe.example.com",
+					Upstreams: []conf_v1.Upstream{
+						{
+							Name:    "tea",
+							Service: "tea-svc",
+							Port:    80,
+						},
+					},
+					Subroutes: []conf_v1.Route{
+						{
+							Path: "/tea",
+							Action: &conf_v1.Action{
+								Pass: "tea",
+							},
+						},
+					},
+				},
+			},
+		},
+		DefaultPolicies: []conf_v1.PolicyReference{
+			{
+				Name:      "allow-policy",
+				Namespace: "security",
+			},
+		},
+		VirtualServerRouteDefaultPolicies: map[string][]conf_v1.PolicyReference{
+			"tenant/tea-vsr": {
+				{
+					Name:      "deny-policy",
+					Namespace: "security",
+				},
+			},
+		},
+		Policies: map[string]*conf_v1.Policy{
+			"security/allow-policy": {
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "allow-policy",
+					Namespace: "security",
+				},
+				Spec: conf_v1.PolicySpec{
+					AccessControl: &conf_v1.AccessControl{
+						Allow: []string{"10.0.0.0/8"},
+					},
+				},
+			},
+			"security/deny-policy": {
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "deny-policy",
+					Namespace: "security",
+				},
+				Spec: conf_v1.PolicySpec{
+					AccessControl: &conf_v1.AccessControl{
+						Deny: []string{"10.0.0.1"},
+					},
+				},
+			},
+		},
+		Endpoints: map[string][]string{
+			"default/coffee-svc:80": {
+				"10.0.0.30:80",
+			},
+			"tenant/tea-svc:80": {
+				"10.0.0.20:80",
+			},
+		},
+	}
+
+	vsc := newVirtualServerConfigurator(&baseCfgParams, false, false, &StaticConfigParams{}, false, &fakeBV)
+	result, warnings := vsc.GenerateVirtualServerConfig(&virtualServerEx, nil, nil)
+	if len(warnings) != 0 {
+		t.Errorf("GenerateVirtualServerConfig() returned warnings: %v", warnings)
+	}
+
+	if diff := cmp.Diff([]string{"10.0.0.0/8"}, result.Server.Allow); diff != "" {
+		t.Errorf("GenerateVirtualServerConfig() Server.Allow mismatch (-want +got):\n%s", diff)
+	}
+
+	foundSubroute := false
+	for _, l := range result.Server.Locations {
+		var expectedDeny []string
+		if l.Path == "/tea" {
+			foundSubroute = true
+			expectedDeny = []string{"10.0.0.1"}
+		}
+		if diff := cmp.Diff(expectedDeny, l.Deny); diff != "" {
+			t.Errorf("GenerateVirtualServerConfig() Deny mismatch for the location %s (-want +got):\n%s", l.Path, diff)
+		}
+	}
+	if !foundSubroute {
+		t.Error("GenerateVirtualServerConfig() didn't return the location /tea of the VirtualServerRoute subroute")
+	}
+}
@@ -246,6 +246,7 @@ func TestGenerateVirtualServerConfigRateLimitGroups(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "tea-svc",
+							UpstreamName:             "vs_default_cafe_tea",
 						},
 						{
 							Path:                     "/coffee",
@@ -257,6 +258,7 @@ func TestGenerateVirtualServerConfigRateLimitGroups(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "coffee-svc",
+							UpstreamName:             "vs_default_cafe_coffee",
 						},
 					},
 				},
@@ -484,6 +486,7 @@ func TestGenerateVirtualServerConfigRateLimitGroups(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "tea-svc",
+							UpstreamName:             "vs_default_cafe_tea",
 						},
 						{
 							Path:                     "/coffee",
@@ -495,6 +498,7 @@ func TestGenerateVirtualServerConfigRateLimitGroups(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "coffee-svc",
+							UpstreamName:             "vs_default_cafe_coffee",
 						},
 					},
 				},
@@ -728,6 +732,7 @@ func TestGenerateVirtualServerConfigRateLimitGroups(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "tea-svc",
+							UpstreamName:             "vs_default_cafe_tea",
 						},
 						{
 							Path:                     "/coffee",
@@ -739,6 +744,7 @@ func TestGenerateVirtualServerConfigRateLimitGroups(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "coffee-svc",
+							UpstreamName:             "vs_default_cafe_coffee",
 						},
 					},
 				},
@@ -963,6 +969,7 @@ func TestGenerateVirtualServerConfigRateLimitGroups(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "tea-svc",
+							UpstreamName:             "vs_default_cafe_tea",
 							LimitReqs: []version2.LimitReq{
 								{ZoneName: "pol_rl_default_premium_rate_limit_policy_default_cafe_vs", Burst: 0, NoDelay: false, Delay: 0},
 								{ZoneName: "pol_rl_default_basic_rate_limit_policy_default_cafe_vs", Burst: 0, NoDelay: false, Delay: 0},
@@ -983,6 +990,7 @@ func TestGenerateVirtualServerConfigRateLimitGroups(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "coffee-svc",
+							UpstreamName:             "vs_default_cafe_coffee",
 						},
 					},
 				},
@@ -1261,6 +1269,7 @@ func TestGenerateVirtualServerConfigRateLimitGroups(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "tea-svc",
+							UpstreamName:             "vs_default_cafe_tea",
 							LimitReqs: []version2.LimitReq{
 								{ZoneName: "pol_rl_default_premium_rate_limit_policy_default_cafe_vs", Burst: 0, NoDelay: false, Delay: 0},
 								{ZoneName: "pol_rl_default_basic_rate_limit_policy_default_cafe_vs", Burst: 0, NoDelay: false, Delay: 0},
@@ -1281,6 +1290,7 @@ func TestGenerateVirtualServerConfigRateLimitGroups(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "coffee-svc",
+							UpstreamName:             "vs_default_cafe_coffee",
 							LimitReqs: []version2.LimitReq{
 								{ZoneName: "pol_rl_default_premium_rate_limit_policy_default_cafe_vs", Burst: 0, NoDelay: false, Delay: 0},
 								{ZoneName: "pol_rl_default_basic_rate_limit_policy_default_cafe_vs", Burst: 0, NoDelay: false, Delay: 0},
@@ -1533,6 +1543,7 @@ func TestGenerateVirtualServerConfigRateLimitGroups(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "coffee-svc",
+							UpstreamName:             "vs_default_cafe_coffee",
 						},
 						{
 							Path:                     "/tea",
@@ -1544,6 +1555,7 @@ func TestGenerateVirtualServerConfigRateLimitGroups(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "tea-svc",
+							UpstreamName:             "vs_default_cafe_vsr_default_tea_tea",
 							IsVSR:                    true,
 							VSRName:                  "tea",
 							VSRNamespace:             "default",
@@ -1975,6 +1987,7 @@ func TestGenerateVirtualServerConfigRateLimitGroups(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "coffee-svc",
+							UpstreamName:             "vs_default_cafe_coffee",
 						},
 						{
 							Path:                     "/tea",
@@ -1986,6 +1999,7 @@ func TestGenerateVirtualServerConfigRateLimitGroups(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "tea-svc",
+							UpstreamName:             "vs_default_cafe_vsr_default_tea_tea",
 							IsVSR:                    true,
 							VSRName:                  "tea",
 							VSRNamespace:             "default",
@@ -2279,6 +2293,7 @@ func TestGenerateVirtualServerConfigRateLimitGroups(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "tea-svc",
+							UpstreamName:             "vs_default_cafe_tea",
 						},
 						{
 							Path:                     "/coffee",
@@ -2290,6 +2305,7 @@ func TestGenerateVirtualServerConfigRateLimitGroups(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "coffee-svc",
+							UpstreamName:             "vs_default_cafe_coffee",
 						},
 					},
 					APIKeyEnabled: true,
@@ -2573,6 +2589,7 @@ func TestGenerateVirtualServerConfigRateLimitGroups(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "tea-svc",
+							UpstreamName:             "vs_default_cafe_tea",
 						},
 						{
 							Path:                     "/coffee",
@@ -2584,6 +2601,7 @@ func TestGenerateVirtualServerConfigRateLimitGroups(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "coffee-svc",
+							UpstreamName:             "vs_default_cafe_coffee",
 						},
 					},
 					APIKeyEnabled: true,
@@ -2873,6 +2891,7 @@ func TestGenerateVirtualServerConfigRateLimitGroups(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "tea-svc",
+							UpstreamName:             "vs_default_cafe_tea",
 						},
 						{
 							Path:                     "/coffee",
@@ -2884,6 +2903,7 @@ func TestGenerateVirtualServerConfigRateLimitGroups(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "coffee-svc",
+							UpstreamName:             "vs_default_cafe_coffee",
 						},
 					},
 					APIKeyEnabled: true,
@@ -3167,6 +3187,7 @@ func TestGenerateVirtualServerConfigRateLimitGroups(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "tea-svc",
+							UpstreamName:             "vs_default_cafe_tea",
 							LimitReqs: []version2.LimitReq{
 								{ZoneName: "pol_rl_default_premium_rate_limit_policy_default_cafe_vs", Burst: 0, NoDelay: false, Delay: 0},
 								{ZoneName: "pol_rl_default_basic_rate_limit_policy_default_cafe_vs", Burst: 0, NoDelay: false, Delay: 0},
@@ -3187,6 +3208,7 @@ func TestGenerateVirtualServerConfigRateLimitGroups(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "coffee-svc",
+							UpstreamName:             "vs_default_cafe_coffee",
 						},
 					},
 					APIKeyEnabled: true,
@@ -3524,6 +3546,7 @@ func TestGenerateVirtualServerConfigRateLimitGroups(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "tea-svc",
+							UpstreamName:             "vs_default_cafe_tea",
 							LimitReqs: []version2.LimitReq{
 								{ZoneName: "pol_rl_default_premium_rate_limit_policy_default_cafe_vs", Burst: 0, NoDelay: false, Delay: 0},
 								{ZoneName: "pol_rl_default_basic_rate_limit_policy_default_cafe_vs", Burst: 0, NoDelay: false, Delay: 0},
@@ -3544,6 +3567,7 @@ func TestGenerateVirtualServerConfigRateLimitGroups(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "coffee-svc",
+							UpstreamName:             "vs_default_cafe_coffee",
 							LimitReqs: []version2.LimitReq{
 								{ZoneName: "pol_rl_default_premium_rate_limit_policy_default_cafe_vs", Burst: 0, NoDelay: false, Delay: 0},
 								{ZoneName: "pol_rl_default_basic_rate_limit_policy_default_cafe_vs", Burst: 0, NoDelay: false, Delay: 0},
@@ -3855,6 +3879,7 @@ func TestGenerateVirtualServerConfigRateLimitGroups(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "coffee-svc",
+							UpstreamName:             "vs_default_cafe_coffee",
 						},
 						{
 							Path:                     "/tea",
@@ -3866,6 +3891,7 @@ func TestGenerateVirtualServerConfigRateLimitGroups(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "tea-svc",
+							UpstreamName:             "vs_default_cafe_vsr_default_tea_tea",
 							LimitReqs: []version2.LimitReq{
 								{ZoneName: "pol_rl_default_premium_rate_limit_policy_default_cafe_vs", Burst: 0, NoDelay: false, Delay: 0},
 								{ZoneName: "pol_rl_default_basic_rate_limit_policy_default_cafe_vs", Burst: 0, NoDelay: false, Delay: 0},
@@ -4234,6 +4260,7 @@ func TestGenerateVirtualServerConfigRateLimitGroups(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "coffee-svc",
+							UpstreamName:             "vs_default_cafe_coffee",
 							LimitReqs: []version2.LimitReq{
 								{ZoneName: "pol_rl_default_premium_rate_limit_policy_default_cafe_vs", Burst: 0, NoDelay: false, Delay: 0},
 								{ZoneName: "pol_rl_default_basic_rate_limit_policy_default_cafe_vs", Burst: 0, NoDelay: false, Delay: 0},
@@ -4254,6 +4281,7 @@ func TestGenerateVirtualServerConfigRateLimitGroups(t *testing.T) {
 							ProxyPassRequestHeaders:  true,
 							ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 							ServiceName:              "tea-svc",
+							UpstreamName:             "vs_default_cafe_vsr_default_tea_tea",
 							LimitReqs: []version2.LimitReq{
 								{ZoneName: "pol_rl_default_premium_rate_limit_policy_default_cafe_vs", Burst: 0, NoDelay: false, Delay: 0},
 								{ZoneName: "pol_rl_default_basic_rate_limit_policy_default_cafe_vs", Burst: 0, NoDelay: false, Delay: 0},
@@ -4546,6 +4574,7 @@ func TestGenerateVirtualServerConfigWithRateLimitGroupsWarning(t *testing.T) {
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "tea-svc",
+					UpstreamName:             "vs_default_cafe_tea",
 				},
 				{
 					Path:                     "/coffee",
@@ -4557,6 +4586,7 @@ func TestGenerateVirtualServerConfigWithRateLimitGroupsWarning(t *testing.T) {
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "coffee-svc",
+					UpstreamName:             "vs_default_cafe_coffee",
 				},
 			},
 		},
@@ -237,6 +237,7 @@ func TestGenerateVirtualServerConfigForVirtualServerWithSplits(t *testing.T) {
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "tea-svc-v1",
+					UpstreamName:             "vs_default_cafe_tea-v1",
 				},
 				{
 					Path:                     "/internal_location_splits_0_split_1",
@@ -249,6 +250,7 @@ func TestGenerateVirtualServerConfigForVirtualServerWithSplits(t *testing.T) {
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "tea-svc-v2",
+					UpstreamName:             "vs_default_cafe_tea-v2",
 				},
 				{
 					Path:                     "/internal_location_splits_1_split_0",
@@ -261,6 +263,7 @@ func TestGenerateVirtualServerConfigForVirtualServerWithSplits(t *testing.T) {
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "coffee-svc-v1",
+					UpstreamName:             "vs_default_cafe_vsr_default_coffee_coffee-v1",
 					IsVSR:                    true,
 					VSRName:                  "coffee",
 					VSRNamespace:             "default",
@@ -276,6 +279,7 @@ func TestGenerateVirtualServerConfigForVirtualServerWithSplits(t *testing.T) {
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "coffee-svc-v2",
+					UpstreamName:             "vs_default_cafe_vsr_default_coffee_coffee-v2",
 					IsVSR:                    true,
 					VSRName:                  "coffee",
 					VSRNamespace:             "default",
@@ -556,6 +560,7 @@ func TestGenerateVirtualServerConfigForVirtualServerWithMatches(t *testing.T) {
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "tea-svc-v2",
+					UpstreamName:             "vs_default_cafe_tea-v2",
 				},
 				{
 					Path:                     "/internal_location_matches_0_default",
@@ -568,6 +573,7 @@ func TestGenerateVirtualServerConfigForVirtualServerWithMatches(t *testing.T) {
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "tea-svc-v1",
+					UpstreamName:             "vs_default_cafe_tea-v1",
 				},
 				{
 					Path:                     "/internal_location_matches_1_match_0",
@@ -580,6 +586,7 @@ func TestGenerateVirtualServerConfigForVirtualServerWithMatches(t *testing.T) {
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "coffee-svc-v2",
+					UpstreamName:             "vs_default_cafe_vsr_default_coffee_coffee-v2",
 					IsVSR:                    true,
 					VSRName:                  "coffee",
 					VSRNamespace:             "default",
@@ -595,6 +602,7 @@ func TestGenerateVirtualServerConfigForVirtualServerWithMatches(t *testing.T) {
 					ProxyPassRequestHeaders:  true,
 					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 					ServiceName:              "coffee-svc-v1",
+					UpstreamName:             "vs_default_cafe_vsr_default_coffee_coffee-v1",
 					IsVSR:                    true,
 					VSRName:                  "coffee",
 					VSRNamespace:             "default",
@@ -835,6 +843,7 @@ func TestGenerateVirtualServerConfigForVirtualServerRoutesWithDos(t *testing.T)
 			ProxyPassRequestHeaders:  true,
 			ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 			ServiceName:              "coffee-svc-v2",
+			UpstreamName:             "vs_default_cafe_vsr_default_coffee_coffee-v2",
 			IsVSR:                    true,
 			VSRName:                  "coffee",
 			VSRNamespace:             "default",
@@ -858,6 +867,7 @@ func TestGenerateVirtualServerConfigForVirtualServerRoutesWithDos(t *testing.T)
 			ProxyPassRequestHeaders:  true,
 			ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 			ServiceName:              "coffee-svc-v1",
+			UpstreamName:             "vs_default_cafe_vsr_default_coffee_coffee-v1",
 			IsVSR:                    true,
 			VSRName:                  "coffee",
 			VSRNamespace:             "default",
@@ -881,6 +891,7 @@ func TestGenerateVirtualServerConfigForVirtualServerRoutesWithDos(t *testing.T)
 			ProxyPassRequestHeaders:  true,
 			ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 			ServiceName:              "tea-svc-v1",
+			UpstreamName:             "vs_default_cafe_vsr_default_tea_tea-v1",
 			IsVSR:                    true,
 			VSRName:                  "tea",
 			VSRNamespace:             "default",
@@ -911,6 +922,7 @@ func TestGenerateVirtualServerConfigForVirtualServerRoutesWithDos(t *testing.T)
 				AllowListPath:        "/etc/nginx/dos/allowlist/default_juice",
 			},
 			ServiceName:  "juice-svc-v1",
+			UpstreamName: "vs_default_cafe_vsr_default_juice_juice-v1",
 			IsVSR:        true,
 			VSRName:      "juice",
 			VSRNamespace: "default",
@@ -933,6 +945,7 @@ func TestGenerateVirtualServerConfigForVirtualServerRoutesWithDos(t *testing.T)
 				AllowListPath:        "/etc/nginx/dos/allowlist/default_juice",
 			},
 			ServiceName:  "juice-svc-v2",
+			UpstreamName: "vs_default_cafe_vsr_default_juice_juice-v2",
 			IsVSR:        true,
 			VSRName:      "juice",
 			VSRNamespace: "default",
@@ -1505,6 +1518,86 @@ func TestGenerateVirtualServerConfigForVirtualServerWithReturns(t *testing.T) {
 	}
 }
 
+func TestApplyRolloutWeight(t *testing.T) {
+	t.Parallel()
+
+	route := conf_v1.Route{
+		Path: "/tea",
+		Splits: []conf_v1.Split{
+			{Weight: 100, Action: &conf_v1.Action{Pass: "tea-v1"}},
+			{Weight: 0, Action: &conf_v1.Action{Pass: "tea-v2"}},
+		},
+	}
+
+	result := applyRolloutWeight(route, map[string]int{"/tea": 10})
+	expected := []conf_v1.Split{
+		{Weight: 90, Action: &conf_v1.Action{Pass: "tea-v1"}},
+		{Weight: 10, Action: &conf_v1.Action{Pass: "tea-v2"}},
+	}
+	if diff := cmp.Diff(expected, result.Splits); diff != "" {
+		t.Errorf("applyRolloutWeight() mismatch (-want +got):\n%s", diff)
+	}
+	if route.Splits[1].Weight != 0 {
+		t.Errorf("applyRolloutWeight() changed the weight of the split of the original route to %d", route.Splits[1].Weight)
+	}
+
+	result = applyRolloutWeight(route, map[string]int{"/coffee": 10})
+	if diff := cmp.Diff(route, result); diff != "" {
+		t.Errorf("applyRolloutWeight() mismatch for the case of a route without a rollout weight (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerateVirtualServerConfigForRolloutWithoutDynamicWeightChanges(t *testing.T) {
+	t.Parallel()
+	virtualServerEx := VirtualServerEx{
+		VirtualServer: &conf_v1.VirtualServer{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "cafe",
+				Namespace: "default",
+			},
+			Spec: conf_v1.VirtualServerSpec{
+				Host: "cafe.example.com",
+				Upstreams: []conf_v1.Upstream{
+					{Name: "tea-v1", Service: "tea-svc-v1", Port: 80},
+					{Name: "tea-v2", Service: "tea-svc-v2", Port: 80},
+				},
+				Routes: []conf_v1.Route{
+					{
+						Path: "/tea",
+						Splits: []conf_v1.Split{
+							{Weight: 100, Action: &conf_v1.Action{Pass: "tea-v1"}},
+							{Weight: 0, Action: &conf_v1.Action{Pass: "tea-v2"}},
+						},
+						Rollout: &conf_v1.Rollout{
+							Steps: []conf_v1.RolloutStep{{Weight: 10, Duration: "1m"}, {Weight: 100}},
+						},
+					},
+				},
+			},
+		},
+		RolloutWeights: map[string]int{"/tea": 10},
+	}
+
+	vsc := newVirtualServerConfigurator(&baseCfgParams, true, false, &StaticConfigParams{}, false, &fakeBV)
+	result, _ := vsc.GenerateVirtualServerConfig(&virtualServerEx, nil, nil)
+
+	expected := []version2.TwoWaySplitClients{
+		{
+			Key:               `"vs_default_cafe_keyval_key_split_clients_0"`,
+			Variable:          "$vs_default_cafe_keyval_split_clients_0",
+			ZoneName:          "vs_default_cafe_keyval_zone_split_clients_0",
+			Weights:           []int{90, 10},
+			SplitClientsIndex: 0,
+		},
+	}
+	if diff := cmp.Diff(expected, result.TwoWaySplitClients); diff != "" {
+		t.Errorf("GenerateVirtualServerConfig() TwoWaySplitClients mismatch (-want +got):\n%s", diff)
+	}
+	if len(result.KeyValZones) != 1 {
+		t.Errorf("GenerateVirtualServerConfig() returned %d keyval zones, expected 1", len(result.KeyValZones))
+	}
+}
+
 func TestGenerateSplits(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -1686,6 +1779,7 @@ func TestGenerateSplits(t *testing.T) {
 			ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
 			Snippets:                []string{locSnippet},
 			ServiceName:             "coffee-v1",
+			UpstreamName:            "vs_default_cafe_coffee-v1",
 			IsVSR:                   true,
 			VSRName:                 "coffee",
 			VSRNamespace:            "default",
@@ -1715,6 +1809,7 @@ func TestGenerateSplits(t *testing.T) {
 			ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
 			Snippets:                []string{locSnippet},
 			ServiceName:             "coffee-v2",
+			UpstreamName:            "vs_default_cafe_coffee-v2",
 			IsVSR:                   true,
 			VSRName:                 "coffee",
 			VSRNamespace:            "default",
@@ -3258,6 +3353,7 @@ func TestGenerateSplitsWeightChangesDynamicReload(t *testing.T) {
 			ProxyPassRequestHeaders:  true,
 			ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 			ServiceName:              "coffee-v1",
+			UpstreamName:             "vs_default_cafe_coffee-v1",
 			IsVSR:                    true,
 			VSRName:                  "coffee",
 			VSRNamespace:             "default",
@@ -3273,6 +3369,7 @@ func TestGenerateSplitsWeightChangesDynamicReload(t *testing.T) {
 			ProxyPassRequestHeaders:  true,
 			ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 			ServiceName:              "coffee-v2",
+			UpstreamName:             "vs_default_cafe_coffee-v2",
 			IsVSR:                    true,
 			VSRName:                  "coffee",
 			VSRNamespace:             "default",
@@ -3527,6 +3624,7 @@ func TestGenerateDefaultSplitsConfig(t *testing.T) {
 				ProxyPassRequestHeaders:  true,
 				ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 				ServiceName:              "coffee-v1",
+				UpstreamName:             "vs_default_cafe_coffee-v1",
 				IsVSR:                    true,
 				VSRName:                  "coffee",
 				VSRNamespace:             "default",
@@ -3542,6 +3640,7 @@ func TestGenerateDefaultSplitsConfig(t *testing.T) {
 				ProxyPassRequestHeaders:  true,
 				ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 				ServiceName:              "coffee-v2",
+				UpstreamName:             "vs_default_cafe_coffee-v2",
 				IsVSR:                    true,
 				VSRName:                  "coffee",
 				VSRNamespace:             "default",
@@ -3844,6 +3943,7 @@ func TestGenerateMatchesConfig(t *testing.T) {
 				ProxyPassRequestHeaders: true,
 				ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
 				ServiceName:             "coffee-v1",
+				UpstreamName:            "vs_default_cafe_coffee-v1",
 				IsVSR:                   false,
 				VSRName:                 "",
 				VSRNamespace:            "",
@@ -3872,6 +3972,7 @@ func TestGenerateMatchesConfig(t *testing.T) {
 				ProxyPassRequestHeaders: true,
 				ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
 				ServiceName:             "coffee-v1",
+				UpstreamName:            "vs_default_cafe_coffee-v1",
 				IsVSR:                   false,
 				VSRName:                 "",
 				VSRNamespace:            "",
@@ -3900,6 +4001,7 @@ func TestGenerateMatchesConfig(t *testing.T) {
 				ProxyPassRequestHeaders: true,
 				ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
 				ServiceName:             "coffee-v2",
+				UpstreamName:            "vs_default_cafe_coffee-v2",
 				IsVSR:                   false,
 				VSRName:                 "",
 				VSRNamespace:            "",
@@ -3928,6 +4030,7 @@ func TestGenerateMatchesConfig(t *testing.T) {
 				ProxyPassRequestHeaders: true,
 				ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
 				ServiceName:             "tea",
+				UpstreamName:            "vs_default_cafe_tea",
 				IsVSR:                   false,
 				VSRName:                 "",
 				VSRNamespace:            "",
@@ -4173,6 +4276,7 @@ func TestGenerateMatchesConfigWithMultipleSplits(t *testing.T) {
 				ProxyPassRequestHeaders: true,
 				ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
 				ServiceName:             "coffee-v1",
+				UpstreamName:            "vs_default_cafe_coffee-v1",
 				IsVSR:                   true,
 				VSRName:                 "coffee",
 				VSRNamespace:            "default",
@@ -4201,6 +4305,7 @@ func TestGenerateMatchesConfigWithMultipleSplits(t *testing.T) {
 				ProxyPassRequestHeaders: true,
 				ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
 				ServiceName:             "coffee-v2",
+				UpstreamName:            "vs_default_cafe_coffee-v2",
 				IsVSR:                   true,
 				VSRName:                 "coffee",
 				VSRNamespace:            "default",
@@ -4229,6 +4334,7 @@ func TestGenerateMatchesConfigWithMultipleSplits(t *testing.T) {
 				ProxyPassRequestHeaders: true,
 				ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
 				ServiceName:             "coffee-v2",
+				UpstreamName:            "vs_default_cafe_coffee-v2",
 				IsVSR:                   true,
 				VSRName:                 "coffee",
 				VSRNamespace:            "default",
@@ -4257,6 +4363,7 @@ func TestGenerateMatchesConfigWithMultipleSplits(t *testing.T) {
 				ProxyPassRequestHeaders: true,
 				ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
 				ServiceName:             "coffee-v1",
+				UpstreamName:            "vs_default_cafe_coffee-v1",
 				IsVSR:                   true,
 				VSRName:                 "coffee",
 				VSRNamespace:            "default",
@@ -4285,6 +4392,7 @@ func TestGenerateMatchesConfigWithMultipleSplits(t *testing.T) {
 				ProxyPassRequestHeaders: true,
 				ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
 				ServiceName:             "coffee-v1",
+				UpstreamName:            "vs_default_cafe_coffee-v1",
 				IsVSR:                   true,
 				VSRName:                 "coffee",
 				VSRNamespace:            "default",
@@ -4313,6 +4421,7 @@ func TestGenerateMatchesConfigWithMultipleSplits(t *testing.T) {
 				ProxyPassRequestHeaders: true,
 				ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
 				ServiceName:             "coffee-v2",
+				UpstreamName:            "vs_default_cafe_coffee-v2",
 				IsVSR:                   true,
 				VSRName:                 "coffee",
 				VSRNamespace:            "default",
@@ -4635,6 +4744,7 @@ func TestGenerateVirtualServerConfigForVSRWithMultipleRegexSubroutes(t *testing.
 			ProxyPassRequestHeaders:  true,
 			ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 			ServiceName:              "api-svc",
+			UpstreamName:             "vs_default_cafe_vsr_default_api_api-svc",
 			IsVSR:                    true,
 			VSRName:                  "api",
 			VSRNamespace:             "default",
@@ -4650,6 +4760,7 @@ func TestGenerateVirtualServerConfigForVSRWithMultipleRegexSubroutes(t *testing.
 			ProxyPassRequestHeaders:  true,
 			ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
 			ServiceName:              "api-svc",
+			UpstreamName:             "vs_default_cafe_vsr_default_api_api-svc",
 			IsVSR:                    true,
 			VSRName:                  "api",
 			VSRNamespace:             "default",
@@ -4669,3 +4780,363 @@ func TestGenerateVirtualServerConfigForVSRWithMultipleRegexSubroutes(t *testing.
 		t.Errorf("GenerateVirtualServerConfig returned unexpected warnings: %v", warnings)
 	}
 }
+
+func TestGenerateVirtualServerConfigWithMirror(t *testing.T) {
+	t.Parallel()
+	virtualServerEx := VirtualServerEx{
+		VirtualServer: &conf_v1.VirtualServer{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "cafe",
+				Namespace: "default",
+			},
+			Spec: conf_v1.VirtualServerSpec{
+				Host: "cafe.example.com",
+				Upstreams: []conf_v1.Upstream{
+					{
+						Name:    "tea",
+						Service: "tea-svc",
+						Port:    80,
+					},
+					{
+						Name:    "tea-shadow",
+						Service: "tea-shadow-svc",
+						Port:    80,
+					},
+				},
+				Routes: []conf_v1.Route{
+					{
+						Path: "/tea",
+						Action: &conf_v1.Action{
+							Proxy: &conf_v1.ActionProxy{
+								Upstream: "tea",
+								Mirror: &conf_v1.ActionMirror{
+									Upstream:    "tea-shadow",
+									Percentage:  new(25),
+									RequestBody: new(false),
+								},
+							},
+						},
+					},
+					{
+						Path: "/tea-all",
+						Action: &conf_v1.Action{
+							Proxy: &conf_v1.ActionProxy{
+								Upstream: "tea",
+								Mirror: &conf_v1.ActionMirror{
+									Upstream: "tea-shadow",
+								},
+							},
+						},
+					},
+					{
+						Path: "/tea-none",
+						Action: &conf_v1.Action{
+							Proxy: &conf_v1.ActionProxy{
+								Upstream: "tea",
+								Mirror: &conf_v1.ActionMirror{
+									Upstream:   "tea-shadow",
+									Percentage: new(0),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Endpoints: map[string][]string{
+			"default/tea-svc:80": {
+				"10.0.0.20:80",
+			},
+			"default/tea-shadow-svc:80": {
+				"10.0.0.30:80",
+			},
+		},
+		VirtualServerRoutes: []*conf_v1.VirtualServerRoute{},
+	}
+
+	vsc := newVirtualServerConfigurator(&baseCfgParams, false, false, &StaticConfigParams{}, false, nil)
+	result, warnings := vsc.GenerateVirtualServerConfig(&virtualServerEx, nil, nil)
+	if len(warnings) != 0 {
+		t.Errorf("GenerateVirtualServerConfig returned warnings: %v", warnings)
+	}
+
+	expectedSplitClients := []version2.SplitClient{
+		{
+			Source:   "$request_id",
+			Variable: "$vs_default_cafe_mirror_0",
+			Distributions: []version2.Distribution{
+				{
+					Weight: "25%",
+					Value:  "1",
+				},
+				{
+					Weight: "*",
+					Value:  `""`,
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedSplitClients, result.SplitClients); diff != "" {
+		t.Errorf("GenerateVirtualServerConfig() SplitClients mismatch (-want +got):\n%s", diff)
+	}
+
+	expectedLocations := []version2.Location{
+		{
+			Path:                     "/tea",
+			ProxyPass:                "http://vs_default_cafe_tea",
+			ProxyNextUpstream:        "error timeout",
+			ProxyNextUpstreamTimeout: "0s",
+			ProxyPassRequestHeaders:  true,
+			ProxySetHeaders: []version2.Header{
+				{
+					Name:  "Host",
+					Value: "$host",
+				},
+			},
+			HasKeepalive: true,
+			ProxySSLName: "tea-svc.default.svc",
+			ServiceName:  "tea-svc",
+			UpstreamName: "vs_default_cafe_tea",
+			Mirror: &version2.Mirror{
+				Path:        "/internal_location_mirror_0",
+				Upstream:    "vs_default_cafe_tea-shadow",
+				Percentage:  25,
+				RequestBody: false,
+			},
+		},
+		{
+			Path:                     "/tea-all",
+			ProxyPass:                "http://vs_default_cafe_tea",
+			ProxyNextUpstream:        "error timeout",
+			ProxyNextUpstreamTimeout: "0s",
+			ProxyPassRequestHeaders:  true,
+			ProxySetHeaders: []version2.Header{
+				{
+					Name:  "Host",
+					Value: "$host",
+				},
+			},
+			HasKeepalive: true,
+			ProxySSLName: "tea-svc.default.svc",
+			ServiceName:  "tea-svc",
+			UpstreamName: "vs_default_cafe_tea",
+			Mirror: &version2.Mirror{
+				Path:        "/internal_location_mirror_1",
+				Upstream:    "vs_default_cafe_tea-shadow",
+				Percentage:  100,
+				RequestBody: true,
+			},
+		},
+		{
+			Path:                     "/tea-none",
+			ProxyPass:                "http://vs_default_cafe_tea",
+			ProxyNextUpstream:        "error timeout",
+			ProxyNextUpstreamTimeout: "0s",
+			ProxyPassRequestHeaders:  true,
+			ProxySetHeaders: []version2.Header{
+				{
+					Name:  "Host",
+					Value: "$host",
+				},
+			},
+			HasKeepalive: true,
+			ProxySSLName: "tea-svc.default.svc",
+			ServiceName:  "tea-svc",
+			UpstreamName: "vs_default_cafe_tea",
+		},
+		{
+			Path:                     "/internal_location_mirror_0",
+			Internal:                 true,
+			ProxyPass:                "http://vs_default_cafe_tea-shadow$request_uri",
+			ProxyNextUpstream:        "error timeout",
+			ProxyNextUpstreamTimeout: "0s",
+			ProxyPassRequestHeaders:  true,
+			ProxyPassRequestBody:     "off",
+			ProxySetHeaders: []version2.Header{
+				{
+					Name:  "Host",
+					Value: "$host",
+				},
+				{
+					Name:  "Content-Length",
+					Value: "",
+				},
+			},
+			HasKeepalive:         true,
+			ProxySSLName:         "tea-shadow-svc.default.svc",
+			ServiceName:          "tea-shadow-svc",
+			MirrorSampleVariable: "$vs_default_cafe_mirror_0",
+		},
+		{
+			Path:                     "/internal_location_mirror_1",
+			Internal:                 true,
+			ProxyPass:                "http://vs_default_cafe_tea-shadow$request_uri",
+			ProxyNextUpstream:        "error timeout",
+			ProxyNextUpstreamTimeout: "0s",
+			ProxyPassRequestHeaders:  true,
+			ProxySetHeaders: []version2.Header{
+				{
+					Name:  "Host",
+					Value: "$host",
+				},
+			},
+			HasKeepalive: true,
+			ProxySSLName: "tea-shadow-svc.default.svc",
+			ServiceName:  "tea-shadow-svc",
+		},
+	}
+	if diff := cmp.Diff(expectedLocations, result.Server.Locations); diff != "" {
+		t.Errorf("GenerateVirtualServerConfig() Locations mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerateVirtualServerConfigWithRetryPolicy(t *testing.T) {
+	t.Parallel()
+	virtualServerEx := VirtualServerEx{
+		VirtualServer: &conf_v1.VirtualServer{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "cafe",
+				Namespace: "default",
+			},
+			Spec: conf_v1.VirtualServerSpec{
+				Host: "cafe.example.com",
+				Upstreams: []conf_v1.Upstream{
+					{
+						Name:    "tea",
+						Service: "tea-svc",
+						Port:    80,
+					},
+				},
+				Routes: []conf_v1.Route{
+					{
+						Path: "/tea",
+						Action: &conf_v1.Action{
+							Proxy: &conf_v1.ActionProxy{
+								Upstream:    "tea",
+								RewritePath: "/",
+								Retry: &conf_v1.RetryPolicy{
+									Conditions:    []string{"error", "5xx", "gateway-error", "non-idempotent"},
+									Tries:         3,
+									Timeout:       "10s",
+									PerTryTimeout: "2s",
+									Budget: &conf_v1.RetryBudget{
+										PercentOfRecentRequests: 20,
+									},
+								},
+							},
+						},
+					},
+					{
+						Path: "/coffee",
+						Action: &conf_v1.Action{
+							Proxy: &conf_v1.ActionProxy{
+								Upstream: "tea",
+								Retry: &conf_v1.RetryPolicy{
+									Tries: 2,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Endpoints: map[string][]string{
+			"default/tea-svc:80": {
+				"10.0.0.20:80",
+			},
+		},
+		VirtualServerRoutes: []*conf_v1.VirtualServerRoute{},
+	}
+
+	vsc := newVirtualServerConfigurator(&baseCfgParams, false, false, &StaticConfigParams{}, false, nil)
+	result, warnings := vsc.GenerateVirtualServerConfig(&virtualServerEx, nil, nil)
+	if len(warnings) != 0 {
+		t.Errorf("GenerateVirtualServerConfig returned warnings: %v", warnings)
+	}
+
+	expectedRetryBudgetZone := &version2.RetryBudgetZone{
+		Name:    "vs_default_cafe_retry_budget",
+		Size:    "1m",
+		Timeout: "10s",
+	}
+	if diff := cmp.Diff(expectedRetryBudgetZone, result.RetryBudgetZone); diff != "" {
+		t.Errorf("GenerateVirtualServerConfig() RetryBudgetZone mismatch (-want +got):\n%s", diff)
+	}
+
+	expectedLocations := []version2.Location{
+		{
+			Path:                     "/tea",
+			ProxyPass:                "http://vs_default_cafe_tea",
+			ProxyPassRewrite:         "/",
+			ProxyConnectTimeout:      "2s",
+			ProxyReadTimeout:         "2s",
+			ProxySendTimeout:         "2s",
+			ProxyNextUpstream:        "error http_500 http_502 http_503 http_504 non_idempotent",
+			ProxyNextUpstreamTimeout: "10s",
+			ProxyNextUpstreamTries:   3,
+			ProxyPassRequestHeaders:  true,
+			ProxySetHeaders: []version2.Header{
+				{
+					Name:  "Host",
+					Value: "$host",
+				},
+			},
+			HasKeepalive: true,
+			ProxySSLName: "tea-svc.default.svc",
+			ServiceName:  "tea-svc",
+			UpstreamName: "vs_default_cafe_tea",
+			RetryBudget: &version2.RetryBudget{
+				Zone:              "vs_default_cafe_retry_budget",
+				Key:               "retry_budget_0",
+				Percent:           20,
+				MinRetries:        3,
+				Path:              "/internal_location_retry_budget_0",
+				FallbackProxyPass: "http://vs_default_cafe_tea",
+				FallbackRewrites:  []string{"^ $request_uri_no_args", `"^/tea(.*)$" "/$1" break`},
+			},
+		},
+		{
+			Path:                     "/coffee",
+			ProxyPass:                "http://vs_default_cafe_tea",
+			ProxyNextUpstream:        "error timeout",
+			ProxyNextUpstreamTimeout: "0s",
+			ProxyNextUpstreamTries:   2,
+			ProxyPassRequestHeaders:  true,
+			ProxySetHeaders: []version2.Header{
+				{
+					Name:  "Host",
+					Value: "$host",
+				},
+			},
+			HasKeepalive: true,
+			ProxySSLName: "tea-svc.default.svc",
+			ServiceName:  "tea-svc",
+			UpstreamName: "vs_default_cafe_tea",
+		},
+		{
+			Path:                     "/internal_location_retry_budget_0",
+			Internal:                 true,
+			ProxyPass:                "http://vs_default_cafe_tea",
+			Rewrites:                 []string{"^ $request_uri_no_args", `"^/tea(.*)$" "/$1" break`},
+			ProxyConnectTimeout:      "2s",
+			ProxyReadTimeout:         "2s",
+			ProxySendTimeout:         "2s",
+			ProxyNextUpstream:        "off",
+			ProxyNextUpstreamTimeout: "0s",
+			ProxyPassRequestHeaders:  true,
+			ProxySetHeaders: []version2.Header{
+				{
+					Name:  "Host",
+					Value: "$host",
+				},
+			},
+			HasKeepalive: true,
+			ProxySSLName: "tea-svc.default.svc",
+			ServiceName:  "tea-svc",
+			UpstreamName: "vs_default_cafe_tea",
+		},
+	}
+	if diff := cmp.Diff(expectedLocations, result.Server.Locations); diff != "" {
+		t.Errorf("GenerateVirtualServerConfig() Locations mismatch (-want +got):\n%s", diff)
+	}
+}
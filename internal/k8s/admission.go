@@ -0,0 +1,128 @@
+package k8s
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	conf_v1 "github.com/nginx/kubernetes-ingress/pkg/apis/configuration/v1"
+	"github.com/nginx/kubernetes-ingress/pkg/apis/configuration/validation"
+	admission_v1 "k8s.io/api/admission/v1"
+	networking "k8s.io/api/networking/v1"
+)
+
+// AdmissionValidatorInput holds the input needed to call NewAdmissionValidator.
+// The fields must match the configuration of the Ingress Controller, so that the admission verdicts match
+// the validation done by the Ingress Controller.
+type AdmissionValidatorInput struct {
+	IngressClass                 string
+	GlobalConfiguration          string
+	IsNginxPlus                  bool
+	EnableOIDC                   bool
+	AppProtectEnabled            bool
+	AppProtectDosEnabled         bool
+	InternalRoutesEnabled        bool
+	SnippetsEnabled              bool
+	IsDirectiveAutoadjustEnabled bool
+	AllowEmptyIngressHost        bool
+	VirtualServerValidator       *validation.VirtualServerValidator
+	TransportServerValidator     *validation.TransportServerValidator
+	GlobalConfigurationValidator *validation.GlobalConfigurationValidator
+	Logger                       *slog.Logger
+}
+
+// AdmissionValidator validates the resources of admission requests with the validation of the Ingress Controller.
+type AdmissionValidator struct {
+	input AdmissionValidatorInput
+}
+
+// NewAdmissionValidator creates a new AdmissionValidator.
+func NewAdmissionValidator(input AdmissionValidatorInput) *AdmissionValidator {
+	return &AdmissionValidator{input: input}
+}
+
+// Validate validates the object of the admission request. The objects of other kinds, the objects of other
+// Ingress classes and the requests for deleting objects are always allowed.
+func (av *AdmissionValidator) Validate(req *admission_v1.AdmissionRequest) error {
+	if req.Operation != admission_v1.Create && req.Operation != admission_v1.Update {
+		return nil
+	}
+
+	switch req.Kind.Group {
+	case networking.GroupName:
+		if req.Kind.Kind != "Ingress" {
+			return nil
+		}
+		var ing networking.Ingress
+		if err := json.Unmarshal(req.Object.Raw, &ing); err != nil {
+			return fmt.Errorf("failed to decode Ingress: %w", err)
+		}
+		if !hasCorrectIngressClass(&ing, av.input.IngressClass, av.input.Logger) {
+			return nil
+		}
+		return validateIngress(&ing, av.input.IsNginxPlus, av.input.AppProtectEnabled, av.input.AppProtectDosEnabled,
+			av.input.InternalRoutesEnabled, av.input.SnippetsEnabled, av.input.IsDirectiveAutoadjustEnabled, av.input.AllowEmptyIngressHost).ToAggregate()
+	case conf_v1.SchemeGroupVersion.Group:
+		return av.validateCustomResource(req)
+	default:
+		return nil
+	}
+}
+
+func (av *AdmissionValidator) validateCustomResource(req *admission_v1.AdmissionRequest) error {
+	switch req.Kind.Kind {
+	case "VirtualServer":
+		var vs conf_v1.VirtualServer
+		if err := json.Unmarshal(req.Object.Raw, &vs); err != nil {
+			return fmt.Errorf("failed to decode VirtualServer: %w", err)
+		}
+		if !hasCorrectIngressClass(&vs, av.input.IngressClass, av.input.Logger) {
+			return nil
+		}
+		return av.input.VirtualServerValidator.ValidateVirtualServer(&vs)
+	case "VirtualServerRoute":
+		var vsr conf_v1.VirtualServerRoute
+		if err := json.Unmarshal(req.Object.Raw, &vsr); err != nil {
+			return fmt.Errorf("failed to decode VirtualServerRoute: %w", err)
+		}
+		if !hasCorrectIngressClass(&vsr, av.input.IngressClass, av.input.Logger) {
+			return nil
+		}
+		return av.input.VirtualServerValidator.ValidateVirtualServerRoute(&vsr)
+	case "TransportServer":
+		var ts conf_v1.TransportServer
+		if err := json.Unmarshal(req.Object.Raw, &ts); err != nil {
+			return fmt.Errorf("failed to decode TransportServer: %w", err)
+		}
+		if !hasCorrectIngressClass(&ts, av.input.IngressClass, av.input.Logger) {
+			return nil
+		}
+		return av.input.TransportServerValidator.ValidateTransportServer(&ts)
+	case "Policy":
+		var pol conf_v1.Policy
+		if err := json.Unmarshal(req.Object.Raw, &pol); err != nil {
+			return fmt.Errorf("failed to decode Policy: %w", err)
+		}
+		if !hasCorrectIngressClass(&pol, av.input.IngressClass, av.input.Logger) {
+			return nil
+		}
+		return validation.ValidatePolicy(&pol, validation.PolicyValidationConfig{
+			IsPlus:           av.input.IsNginxPlus,
+			EnableOIDC:       av.input.EnableOIDC,
+			EnableAppProtect: av.input.AppProtectEnabled,
+			EnableSnippets:   av.input.SnippetsEnabled,
+		})
+	case "GlobalConfiguration":
+		// only the GlobalConfiguration of the Ingress Controller is validated, because the validation depends on its listeners
+		if fmt.Sprintf("%s/%s", req.Namespace, req.Name) != av.input.GlobalConfiguration {
+			return nil
+		}
+		var gc conf_v1.GlobalConfiguration
+		if err := json.Unmarshal(req.Object.Raw, &gc); err != nil {
+			return fmt.Errorf("failed to decode GlobalConfiguration: %w", err)
+		}
+		return av.input.GlobalConfigurationValidator.ValidateGlobalConfiguration(&gc)
+	default:
+		return nil
+	}
+}
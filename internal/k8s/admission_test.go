@@ -0,0 +1,149 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	nl "github.com/nginx/kubernetes-ingress/internal/logger"
+	conf_v1 "github.com/nginx/kubernetes-ingress/pkg/apis/configuration/v1"
+	"github.com/nginx/kubernetes-ingress/pkg/apis/configuration/validation"
+	admission_v1 "k8s.io/api/admission/v1"
+	networking "k8s.io/api/networking/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func createTestAdmissionValidator() *AdmissionValidator {
+	return NewAdmissionValidator(AdmissionValidatorInput{
+		IngressClass:                 "nginx",
+		GlobalConfiguration:          "nginx-ingress/nginx-configuration",
+		VirtualServerValidator:       validation.NewVirtualServerValidator(),
+		TransportServerValidator:     validation.NewTransportServerValidator(false, false, false),
+		GlobalConfigurationValidator: validation.NewGlobalConfigurationValidator(map[int]bool{80: true, 443: true}),
+		Logger:                       nl.LoggerFromContext(context.Background()),
+	})
+}
+
+func createTestAdmissionRequest(t *testing.T, kind meta_v1.GroupVersionKind, obj runtime.Object, op admission_v1.Operation) *admission_v1.AdmissionRequest {
+	t.Helper()
+
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta := obj.(meta_v1.Object)
+
+	return &admission_v1.AdmissionRequest{
+		Kind:      kind,
+		Namespace: meta.GetNamespace(),
+		Name:      meta.GetName(),
+		Operation: op,
+		Object:    runtime.RawExtension{Raw: raw},
+	}
+}
+
+func TestAdmissionValidatorValidate(t *testing.T) {
+	t.Parallel()
+
+	vsKind := meta_v1.GroupVersionKind{Group: "k8s.nginx.org", Version: "v1", Kind: "VirtualServer"}
+	policyKind := meta_v1.GroupVersionKind{Group: "k8s.nginx.org", Version: "v1", Kind: "Policy"}
+	gcKind := meta_v1.GroupVersionKind{Group: "k8s.nginx.org", Version: "v1", Kind: "GlobalConfiguration"}
+	ingressKind := meta_v1.GroupVersionKind{Group: "networking.k8s.io", Version: "v1", Kind: "Ingress"}
+
+	validVS := &conf_v1.VirtualServer{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "cafe", Namespace: "default"},
+		Spec:       conf_v1.VirtualServerSpec{Host: "cafe.example.com"},
+	}
+	invalidVS := &conf_v1.VirtualServer{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "cafe", Namespace: "default"},
+		Spec:       conf_v1.VirtualServerSpec{Host: "cafe..example.com"},
+	}
+	invalidVSOtherClass := &conf_v1.VirtualServer{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "cafe", Namespace: "default"},
+		Spec:       conf_v1.VirtualServerSpec{Host: "cafe..example.com", IngressClass: "other"},
+	}
+	snippetsPolicy := &conf_v1.Policy{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "waf", Namespace: "default"},
+		Spec: conf_v1.PolicySpec{
+			WAF: &conf_v1.WAF{Enable: true},
+		},
+	}
+	invalidGC := &conf_v1.GlobalConfiguration{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "nginx-configuration", Namespace: "nginx-ingress"},
+		Spec: conf_v1.GlobalConfigurationSpec{
+			Listeners: []conf_v1.Listener{{Name: "http", Port: 80, Protocol: "HTTP"}},
+		},
+	}
+	otherInvalidGC := invalidGC.DeepCopy()
+	otherInvalidGC.Name = "other"
+	invalidIngress := &networking.Ingress{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:        "cafe",
+			Namespace:   "default",
+			Annotations: map[string]string{"nginx.org/lb-method": "invalid"},
+		},
+		Spec: networking.IngressSpec{
+			IngressClassName: new("nginx"),
+			Rules:            []networking.IngressRule{{Host: "cafe.example.com"}},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		req       *admission_v1.AdmissionRequest
+		expectErr bool
+	}{
+		{
+			name: "valid VirtualServer",
+			req:  createTestAdmissionRequest(t, vsKind, validVS, admission_v1.Create),
+		},
+		{
+			name:      "invalid VirtualServer",
+			req:       createTestAdmissionRequest(t, vsKind, invalidVS, admission_v1.Update),
+			expectErr: true,
+		},
+		{
+			name: "invalid VirtualServer of another Ingress class",
+			req:  createTestAdmissionRequest(t, vsKind, invalidVSOtherClass, admission_v1.Create),
+		},
+		{
+			name: "deleted invalid VirtualServer",
+			req:  createTestAdmissionRequest(t, vsKind, invalidVS, admission_v1.Delete),
+		},
+		{
+			name:      "Policy for NGINX Plus with NGINX",
+			req:       createTestAdmissionRequest(t, policyKind, snippetsPolicy, admission_v1.Create),
+			expectErr: true,
+		},
+		{
+			name:      "invalid GlobalConfiguration of the Ingress Controller",
+			req:       createTestAdmissionRequest(t, gcKind, invalidGC, admission_v1.Create),
+			expectErr: true,
+		},
+		{
+			name: "invalid GlobalConfiguration of another Ingress Controller",
+			req:  createTestAdmissionRequest(t, gcKind, otherInvalidGC, admission_v1.Create),
+		},
+		{
+			name:      "invalid Ingress annotation",
+			req:       createTestAdmissionRequest(t, ingressKind, invalidIngress, admission_v1.Create),
+			expectErr: true,
+		},
+	}
+
+	av := createTestAdmissionValidator()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := av.Validate(test.req)
+			if test.expectErr && err == nil {
+				t.Error("Validate() returned no error, expected an error")
+			}
+			if !test.expectErr && err != nil {
+				t.Errorf("Validate() returned unexpected error: %v", err)
+			}
+		})
+	}
+}
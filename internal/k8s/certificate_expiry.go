@@ -0,0 +1,213 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/nginx/kubernetes-ingress/internal/configs"
+	nl "github.com/nginx/kubernetes-ingress/internal/logger"
+	conf_v1 "github.com/nginx/kubernetes-ingress/pkg/apis/configuration/v1"
+	api_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// certificateExpiryCheckInterval is the interval of the checks of the expiry of the certificates of the secrets
+const certificateExpiryCheckInterval = time.Hour
+
+// certificateExpiryWarning is the warning about an expired or expiring certificate of a secret
+type certificateExpiryWarning struct {
+	// threshold is the threshold the certificate reached, zero if the certificate expired
+	threshold time.Duration
+	message   string
+	// reported is true when the events for the threshold were emitted on the resources referencing the secret
+	reported bool
+}
+
+func (lbc *LoadBalancerController) runCertificateExpiryChecks(ctx context.Context) {
+	ticker := time.NewTicker(certificateExpiryCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			// the check runs in the sync queue, because the secret store is not safe for concurrent use
+			lbc.syncQueue.queue.Add(task{Kind: certificateExpiry})
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// syncCertificateExpiry checks the expiry of the certificates of all the secrets.
+func (lbc *LoadBalancerController) syncCertificateExpiry() {
+	now := time.Now()
+	expiries := lbc.certificateInspector.InspectAll()
+
+	for key := range lbc.certificateExpiryWarnings {
+		if _, exists := expiries[key]; !exists {
+			delete(lbc.certificateExpiryWarnings, key)
+		}
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(expiries)) {
+		lbc.checkCertificateExpiry(key, now, true)
+	}
+}
+
+// checkCertificateExpiry updates the expiry metrics of the certificate of the secret with the given key.
+// When the certificate reaches a threshold, the function emits warning events on the resources referencing the secret and,
+// if updateStatuses is true, updates their statuses. Before NGINX is ready, the warning is only stored,
+// so that the deferred statuses of the resources include it.
+func (lbc *LoadBalancerController) checkCertificateExpiry(key string, now time.Time, updateStatuses bool) {
+	expiry, ok := lbc.certificateInspector.Inspect(key)
+	if !ok {
+		lbc.metricsCollector.DeleteCertificateExpiry(key)
+		delete(lbc.certificateExpiryWarnings, key)
+		return
+	}
+
+	// it is safe to ignore the error
+	namespace, name, _ := ParseNamespaceName(key)
+	resources := lbc.findResourcesForSecret(namespace, name)
+
+	var resourceKeys []string
+	for _, r := range resources {
+		if resourceKey := getResourceTypeKey(r); resourceKey != "" {
+			resourceKeys = append(resourceKeys, resourceKey)
+		}
+	}
+	lbc.metricsCollector.SetCertificateExpiry(key, expiry.NotAfter, resourceKeys)
+
+	threshold, expiring := lbc.certificateInspector.Threshold(expiry, now)
+	if !expiring {
+		delete(lbc.certificateExpiryWarnings, key)
+		return
+	}
+
+	reason := nl.EventReasonCertificateExpiring
+	message := fmt.Sprintf("certificate %q of the Secret %s expires at %s, in less than %s", expiry.Subject, key, expiry.NotAfter.UTC().Format(time.RFC3339), threshold)
+	if threshold == 0 {
+		reason = nl.EventReasonCertificateExpired
+		message = fmt.Sprintf("certificate %q of the Secret %s expired at %s", expiry.Subject, key, expiry.NotAfter.UTC().Format(time.RFC3339))
+	}
+
+	prev, exists := lbc.certificateExpiryWarnings[key]
+	if exists && prev.threshold == threshold && prev.reported {
+		return
+	}
+
+	warning := certificateExpiryWarning{threshold: threshold, message: message}
+	if !lbc.isNginxReady {
+		lbc.certificateExpiryWarnings[key] = warning
+		return
+	}
+	warning.reported = true
+	lbc.certificateExpiryWarnings[key] = warning
+
+	nl.Warnf(lbc.Logger, "The %s", message)
+	for _, r := range resources {
+		if obj := getResourceObject(r); obj != nil {
+			lbc.recorder.Eventf(obj, api_v1.EventTypeWarning, reason, "The %s", message)
+		}
+	}
+
+	// the statuses stored or deferred with the previous warning are up to date
+	if updateStatuses && (!exists || prev.threshold != threshold) {
+		lbc.updateCertificateExpiryStatuses(resources, reason, message)
+	}
+}
+
+// updateCertificateExpiryStatuses sets the Warning state on the VirtualServers and TransportServers that are not invalid.
+// The next status update of a resource reports the warning together with its other warnings.
+func (lbc *LoadBalancerController) updateCertificateExpiryStatuses(resources []Resource, reason string, message string) {
+	if !lbc.reportCustomResourceStatusEnabled() {
+		return
+	}
+
+	for _, r := range resources {
+		switch impl := r.(type) {
+		case *VirtualServerConfiguration:
+			if impl.Gateway != nil || impl.VirtualServer.Status.State == conf_v1.StateInvalid {
+				continue
+			}
+			err := lbc.statusUpdater.UpdateVirtualServerStatus(impl.VirtualServer, conf_v1.StateWarning, reason, message)
+			if err != nil {
+				nl.Errorf(lbc.Logger, "Error when updating the status for VirtualServer %v/%v: %v", impl.VirtualServer.Namespace, impl.VirtualServer.Name, err)
+			}
+		case *TransportServerConfiguration:
+			if impl.Gateway != nil || impl.TransportServer.Status.State == conf_v1.StateInvalid {
+				continue
+			}
+			err := lbc.statusUpdater.UpdateTransportServerStatus(impl.TransportServer, conf_v1.StateWarning, reason, message)
+			if err != nil {
+				nl.Errorf(lbc.Logger, "Error when updating the status for TransportServer %v/%v: %v", impl.TransportServer.Namespace, impl.TransportServer.Name, err)
+			}
+		}
+	}
+}
+
+// withCertificateExpiryWarnings returns the warnings with the warnings about the expired and expiring certificates
+// referenced by the resource added for the object of the resource.
+func (lbc *LoadBalancerController) withCertificateExpiryWarnings(warnings configs.Warnings, resource Resource, obj runtime.Object) configs.Warnings {
+	var messages []string
+	for _, secretKey := range slices.Sorted(maps.Keys(lbc.certificateExpiryWarnings)) {
+		// it is safe to ignore the error
+		namespace, name, _ := ParseNamespaceName(secretKey)
+		for _, r := range lbc.findResourcesForSecret(namespace, name) {
+			if r.GetKeyWithKind() == resource.GetKeyWithKind() {
+				messages = append(messages, lbc.certificateExpiryWarnings[secretKey].message)
+				break
+			}
+		}
+	}
+
+	if len(messages) == 0 {
+		return warnings
+	}
+
+	result := make(configs.Warnings, len(warnings)+1)
+	result.Add(warnings)
+	result[obj] = append(slices.Clone(warnings[obj]), messages...)
+	return result
+}
+
+// getResourceTypeKey returns the key of the resource in the resource_type/namespace/name format.
+// The VirtualServers and TransportServers generated for the Gateway API resources have no key.
+func getResourceTypeKey(r Resource) string {
+	switch impl := r.(type) {
+	case *VirtualServerConfiguration:
+		if impl.Gateway != nil {
+			return ""
+		}
+		return "virtualserver/" + getResourceKey(&impl.VirtualServer.ObjectMeta)
+	case *IngressConfiguration:
+		return "ingress/" + getResourceKey(&impl.Ingress.ObjectMeta)
+	case *TransportServerConfiguration:
+		if impl.Gateway != nil {
+			return ""
+		}
+		return "transportserver/" + getResourceKey(&impl.TransportServer.ObjectMeta)
+	}
+	return ""
+}
+
+// getResourceObject returns the Kubernetes object of the resource.
+// The VirtualServers and TransportServers generated for the Gateway API resources have no object.
+func getResourceObject(r Resource) runtime.Object {
+	switch impl := r.(type) {
+	case *VirtualServerConfiguration:
+		if impl.Gateway == nil {
+			return impl.VirtualServer
+		}
+	case *IngressConfiguration:
+		return impl.Ingress
+	case *TransportServerConfiguration:
+		if impl.Gateway == nil {
+			return impl.TransportServer
+		}
+	}
+	return nil
+}
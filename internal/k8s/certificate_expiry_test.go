@@ -0,0 +1,113 @@
+package k8s
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nginx/kubernetes-ingress/internal/configs"
+	"github.com/nginx/kubernetes-ingress/internal/k8s/secrets"
+	nl "github.com/nginx/kubernetes-ingress/internal/logger"
+	"github.com/nginx/kubernetes-ingress/internal/metrics/collectors"
+	api_v1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func createTestTLSSecret(t *testing.T, name string, notAfter time.Time) *api_v1.Secret {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "cafe.example.com"},
+		NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	return &api_v1.Secret{
+		ObjectMeta: meta_v1.ObjectMeta{Name: name, Namespace: "default"},
+		Type:       api_v1.SecretTypeTLS,
+		Data: map[string][]byte{
+			api_v1.TLSCertKey: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		},
+	}
+}
+
+func TestCheckCertificateExpiry(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+
+	ing := createTestIngress("cafe-ingress", "cafe.example.com")
+	ing.Spec.TLS = []networking.IngressTLS{{Hosts: []string{"cafe.example.com"}, SecretName: "cafe-secret"}}
+	configuration := createTestConfiguration()
+	configuration.AddOrUpdateIngress(ing)
+
+	secretStore := secrets.NewEmptyFakeSecretsStore()
+	secretStore.AddOrUpdateSecret(createTestTLSSecret(t, "cafe-secret", now.Add(100*time.Hour)))
+
+	recorder := record.NewFakeRecorder(10)
+	lbc := LoadBalancerController{
+		configuration:             configuration,
+		recorder:                  recorder,
+		secretStore:               secretStore,
+		metricsCollector:          collectors.NewControllerFakeCollector(),
+		certificateInspector:      secrets.NewCertificateInspector(secretStore, []time.Duration{720 * time.Hour, 24 * time.Hour}),
+		certificateExpiryWarnings: make(map[string]certificateExpiryWarning),
+		isNginxReady:              true,
+		Logger:                    nl.LoggerFromContext(context.Background()),
+	}
+
+	lbc.checkCertificateExpiry("default/cafe-secret", now, false)
+	lbc.checkCertificateExpiry("default/cafe-secret", now.Add(time.Hour), false)
+	if len(recorder.Events) != 1 {
+		t.Fatalf("checkCertificateExpiry() emitted %d events, expected 1 event for the 720h threshold", len(recorder.Events))
+	}
+	if event := <-recorder.Events; !strings.HasPrefix(event, "Warning CertificateExpiring The certificate \"CN=cafe.example.com\" of the Secret default/cafe-secret expires") {
+		t.Errorf("checkCertificateExpiry() emitted the unexpected event %q", event)
+	}
+
+	resources := configuration.FindResourcesForSecret("default", "cafe-secret")
+	if len(resources) != 1 {
+		t.Fatalf("FindResourcesForSecret() returned %d resources, expected 1", len(resources))
+	}
+	warnings := lbc.withCertificateExpiryWarnings(configs.Warnings{}, resources[0], ing)
+	if len(warnings[ing]) != 1 || !strings.Contains(warnings[ing][0], "in less than 720h0m0s") {
+		t.Errorf("withCertificateExpiryWarnings() returned %v, expected the warning about the 720h threshold", warnings[ing])
+	}
+
+	lbc.checkCertificateExpiry("default/cafe-secret", now.Add(90*time.Hour), false)
+	lbc.checkCertificateExpiry("default/cafe-secret", now.Add(101*time.Hour), false)
+	if len(recorder.Events) != 2 {
+		t.Fatalf("checkCertificateExpiry() emitted %d events, expected 2 events for the 24h threshold and the expiry", len(recorder.Events))
+	}
+	<-recorder.Events
+	if event := <-recorder.Events; !strings.HasPrefix(event, "Warning CertificateExpired") {
+		t.Errorf("checkCertificateExpiry() emitted the unexpected event %q", event)
+	}
+
+	secretStore.AddOrUpdateSecret(createTestTLSSecret(t, "cafe-secret", now.Add(1000*time.Hour)))
+	lbc.checkCertificateExpiry("default/cafe-secret", now, false)
+	if len(lbc.certificateExpiryWarnings) != 0 {
+		t.Errorf("checkCertificateExpiry() kept the warnings %v for the renewed certificate", lbc.certificateExpiryWarnings)
+	}
+	if warnings := lbc.withCertificateExpiryWarnings(nil, resources[0], ing); warnings != nil {
+		t.Errorf("withCertificateExpiryWarnings() returned %v, expected no warnings for the renewed certificate", warnings)
+	}
+}
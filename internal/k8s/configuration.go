@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"maps"
 	"reflect"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -19,6 +20,8 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 )
 
 const (
@@ -220,6 +223,8 @@ type VirtualServerConfiguration struct {
 	HTTPIPv6                    string
 	HTTPSIPv4                   string
 	HTTPSIPv6                   string
+	// Gateway is set when the VirtualServer is generated from Gateway API resources.
+	Gateway *gatewayv1.Gateway
 }
 
 // NewVirtualServerConfiguration creates a VirtualServerConfiguration.
@@ -265,6 +270,11 @@ func (vsc *VirtualServerConfiguration) IsEqual(resource Resource) bool {
 		return false
 	}
 
+	// the generation of a generated VirtualServer doesn't change when the routes attached to the Gateway change
+	if vsc.Gateway != nil && !reflect.DeepEqual(vsc.VirtualServer.Spec, vsConfig.VirtualServer.Spec) {
+		return false
+	}
+
 	if len(vsc.VirtualServerRoutes) != len(vsConfig.VirtualServerRoutes) {
 		return false
 	}
@@ -306,6 +316,15 @@ func (vsc *VirtualServerConfiguration) IsEqual(resource Resource) bool {
 	return true
 }
 
+// problemObject returns the object to report the problems of the VirtualServer for.
+// Problems of generated VirtualServers are reported for their Gateway.
+func (vsc *VirtualServerConfiguration) problemObject() runtime.Object {
+	if vsc.Gateway != nil {
+		return vsc.Gateway
+	}
+	return vsc.VirtualServer
+}
+
 // TransportServerConfiguration holds a TransportServer resource.
 type TransportServerConfiguration struct {
 	ListenerPort    int
@@ -313,6 +332,8 @@ type TransportServerConfiguration struct {
 	IPv6            string
 	TransportServer *conf_v1.TransportServer
 	Warnings        []string
+	// Gateway is set when the TransportServer is generated from Gateway API resources.
+	Gateway *gatewayv1.Gateway
 }
 
 // NewTransportServerConfiguration creates a new TransportServerConfiguration.
@@ -351,9 +372,22 @@ func (tsc *TransportServerConfiguration) IsEqual(resource Resource) bool {
 		return false
 	}
 
+	if tsc.Gateway != nil && !reflect.DeepEqual(tsc.TransportServer.Spec, tsConfig.TransportServer.Spec) {
+		return false
+	}
+
 	return compareObjectMetas(tsc.GetObjectMeta(), resource.GetObjectMeta()) && tsc.ListenerPort == tsConfig.ListenerPort
 }
 
+// problemObject returns the object to report the problems of the TransportServer for.
+// Problems of generated TransportServers are reported for their Gateway.
+func (tsc *TransportServerConfiguration) problemObject() runtime.Object {
+	if tsc.Gateway != nil {
+		return tsc.Gateway
+	}
+	return tsc.TransportServer
+}
+
 func compareObjectMetas(meta1 *metav1.ObjectMeta, meta2 *metav1.ObjectMeta) bool {
 	return meta1.Namespace == meta2.Namespace &&
 		meta1.Name == meta2.Name &&
@@ -387,12 +421,21 @@ type Configuration struct {
 	virtualServerRoutes map[string]*conf_v1.VirtualServerRoute
 	transportServers    map[string]*conf_v1.TransportServer
 
+	// Gateway API resources. Only Gateways with the matching GatewayClass are stored.
+	gateways           map[string]*gatewayv1.Gateway
+	httpRoutes         map[string]*gatewayv1.HTTPRoute
+	grpcRoutes         map[string]*gatewayv1.GRPCRoute
+	tlsRoutes          map[string]*gatewayv1.TLSRoute
+	tcpRoutes          map[string]*gatewayv1alpha2.TCPRoute
+	gatewayTranslation *gatewayTranslation
+
 	// minionsByHost indexes minion Ingresses by their host for O(1) lookup.
 	// Outer key: host string, inner key: ingress resource key (namespace/name).
 	// Maintained by AddOrUpdateIngress/DeleteIngress; consumed by buildMinionConfigs.
 	minionsByHost map[string]map[string]bool
 
 	globalConfiguration *conf_v1.GlobalConfiguration
+	defaultPolicies     *defaultPolicies
 
 	hostProblems     map[string]ConfigurationProblem
 	listenerProblems map[string]ConfigurationProblem
@@ -448,6 +491,7 @@ func NewConfiguration(
 	allowEmptyIngressHost bool,
 ) *Configuration {
 	policyServiceRefs := make(map[string]string)
+	defaultPolicies := newDefaultPolicies()
 	return &Configuration{
 		hosts:                        make(map[string]Resource),
 		listenerHosts:                make(map[listenerHostKey]*TransportServerConfiguration),
@@ -455,6 +499,12 @@ func NewConfiguration(
 		virtualServers:               make(map[string]*conf_v1.VirtualServer),
 		virtualServerRoutes:          make(map[string]*conf_v1.VirtualServerRoute),
 		transportServers:             make(map[string]*conf_v1.TransportServer),
+		gateways:                     make(map[string]*gatewayv1.Gateway),
+		httpRoutes:                   make(map[string]*gatewayv1.HTTPRoute),
+		grpcRoutes:                   make(map[string]*gatewayv1.GRPCRoute),
+		tlsRoutes:                    make(map[string]*gatewayv1.TLSRoute),
+		tcpRoutes:                    make(map[string]*gatewayv1alpha2.TCPRoute),
+		gatewayTranslation:           newGatewayTranslation(),
 		minionsByHost:                make(map[string]map[string]bool),
 		hostProblems:                 make(map[string]ConfigurationProblem),
 		hasCorrectIngressClass:       hasCorrectIngressClass,
@@ -464,7 +514,8 @@ func NewConfiguration(
 		secretReferenceChecker:       newSecretReferenceChecker(isPlus),
 		serviceReferenceChecker:      newServiceReferenceChecker(false, policyServiceRefs),
 		endpointReferenceChecker:     newServiceReferenceChecker(true, policyServiceRefs),
-		policyReferenceChecker:       newPolicyReferenceChecker(),
+		defaultPolicies:              defaultPolicies,
+		policyReferenceChecker:       newPolicyReferenceChecker(defaultPolicies),
 		appPolicyReferenceChecker:    newAppProtectResourceReferenceChecker(configs.AppProtectPolicyAnnotation),
 		appLogConfReferenceChecker:   newAppProtectResourceReferenceChecker(configs.AppProtectLogConfAnnotation),
 		appDosProtectedChecker:       newDosResourceReferenceChecker(configs.AppProtectDosProtectedAnnotation),
@@ -731,7 +782,9 @@ func (c *Configuration) AddOrUpdateGlobalConfiguration(gc *conf_v1.GlobalConfigu
 	validationErr := c.globalConfigurationValidator.ValidateGlobalConfiguration(gc)
 
 	c.globalConfiguration = gc
+	c.defaultPolicies.policies = gc.Spec.DefaultPolicies
 	c.setGlobalConfigListenerMap()
+	c.gatewayTranslation = c.translateGatewayResources()
 
 	listenerChanges, listenerProblems := c.rebuildListenerHosts()
 
@@ -756,7 +809,9 @@ func (c *Configuration) DeleteGlobalConfiguration() ([]ResourceChange, []Configu
 	var problems []ConfigurationProblem
 
 	c.globalConfiguration = nil
+	c.defaultPolicies.policies = nil
 	c.setGlobalConfigListenerMap()
+	c.gatewayTranslation = c.translateGatewayResources()
 	listenerChanges, listenerProblems := c.rebuildListenerHosts()
 	changes = append(changes, listenerChanges...)
 	problems = append(problems, listenerProblems...)
@@ -939,15 +994,12 @@ func (c *Configuration) buildListenerHostsAndTSConfigurations() (map[listenerHos
 	newListenerHosts := make(map[listenerHostKey]*TransportServerConfiguration)
 	newTSConfigs := make(map[string]*TransportServerConfiguration)
 
-	for key, ts := range c.transportServers {
-		if ts.Spec.Listener.Protocol == conf_v1.TLSPassthroughListenerProtocol {
-			continue
-		}
-		tsc := NewTransportServerConfiguration(ts)
+	addTSConfiguration := func(key string, tsc *TransportServerConfiguration) {
+		ts := tsc.TransportServer
 		newTSConfigs[key] = tsc
 
 		if c.globalConfiguration == nil {
-			continue
+			return
 		}
 
 		found := false
@@ -961,7 +1013,7 @@ func (c *Configuration) buildListenerHostsAndTSConfigurations() (map[listenerHos
 		}
 
 		if !found {
-			continue
+			return
 		}
 
 		tsc.ListenerPort = listener.Port
@@ -974,7 +1026,7 @@ func (c *Configuration) buildListenerHostsAndTSConfigurations() (map[listenerHos
 		holder, exists := newListenerHosts[listenerKey]
 		if !exists {
 			newListenerHosts[listenerKey] = tsc
-			continue
+			return
 		}
 
 		// another TransportServer exists with the same listener and host
@@ -988,6 +1040,17 @@ func (c *Configuration) buildListenerHostsAndTSConfigurations() (map[listenerHos
 		}
 	}
 
+	for key, ts := range c.transportServers {
+		if ts.Spec.Listener.Protocol == conf_v1.TLSPassthroughListenerProtocol {
+			continue
+		}
+		addTSConfiguration(key, NewTransportServerConfiguration(ts))
+	}
+
+	for _, gts := range c.gatewayTranslation.listenerTSs {
+		addTSConfiguration(getResourceKey(&gts.ts.ObjectMeta), newGatewayTransportServerConfiguration(gts))
+	}
+
 	return newListenerHosts, newTSConfigs
 }
 
@@ -1111,6 +1174,67 @@ func (c *Configuration) FindResourcesForPolicy(policyNamespace string, policyNam
 	return c.findResourcesForResourceReference(policyNamespace, policyName, c.policyReferenceChecker)
 }
 
+// GetDefaultPolicies returns a copy of the default policies of the GlobalConfiguration.
+func (c *Configuration) GetDefaultPolicies() *defaultPolicies {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.defaultPolicies.clone()
+}
+
+// FindResourcesForDefaultPolicies finds resources whose default policies differ from the ones resolved with the previous default policies.
+func (c *Configuration) FindResourcesForDefaultPolicies(previous *defaultPolicies) []Resource {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.findResourcesForDefaultPolicies(previous)
+}
+
+// UpdateNamespaceLabels updates the namespace labels used to select the namespaces of the default policies.
+// It returns the resources whose default policies changed. nil labels remove the namespace.
+func (c *Configuration) UpdateNamespaceLabels(namespace string, nsLabels map[string]string) []Resource {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	previous := c.defaultPolicies.clone()
+
+	if nsLabels == nil {
+		delete(c.defaultPolicies.namespaceLabels, namespace)
+	} else {
+		c.defaultPolicies.namespaceLabels[namespace] = nsLabels
+	}
+
+	return c.findResourcesForDefaultPolicies(previous)
+}
+
+func (c *Configuration) findResourcesForDefaultPolicies(previous *defaultPolicies) []Resource {
+	var result []Resource
+
+	for _, h := range getSortedResourceKeys(c.hosts) {
+		r := c.hosts[h]
+
+		changed := false
+		switch impl := r.(type) {
+		case *IngressConfiguration:
+			changed = !slices.Equal(previous.forIngress(impl.Ingress), c.defaultPolicies.forIngress(impl.Ingress))
+			for _, fm := range impl.Minions {
+				changed = changed || !slices.Equal(previous.forIngress(fm.Ingress), c.defaultPolicies.forIngress(fm.Ingress))
+			}
+		case *VirtualServerConfiguration:
+			changed = !slices.Equal(previous.forVirtualServer(impl.VirtualServer), c.defaultPolicies.forVirtualServer(impl.VirtualServer))
+			for _, vsr := range impl.VirtualServerRoutes {
+				changed = changed || !slices.Equal(previous.forVirtualServerRoute(vsr), c.defaultPolicies.forVirtualServerRoute(vsr))
+			}
+		}
+
+		if changed {
+			result = append(result, r)
+		}
+	}
+
+	return result
+}
+
 // UpdatePolicyServiceRef tracks an external auth service reference for a policy.
 // This allows service/endpoint changes to be correlated back to VirtualServers
 // that reference the auth service via the policy.
@@ -1292,7 +1416,7 @@ func (c *Configuration) addProblemsForTSConfigsWithoutActiveListener(
 		holder, exists := c.listenerHosts[key]
 		if !exists {
 			p := ConfigurationProblem{
-				Object:  tsc.TransportServer,
+				Object:  tsc.problemObject(),
 				IsError: false,
 				Reason:  nl.EventReasonRejected,
 				Message: fmt.Sprintf("Listener %s doesn't exist", listenerName),
@@ -1303,7 +1427,7 @@ func (c *Configuration) addProblemsForTSConfigsWithoutActiveListener(
 
 		if !tsc.IsEqual(holder) {
 			p := ConfigurationProblem{
-				Object:  tsc.TransportServer,
+				Object:  tsc.problemObject(),
 				IsError: false,
 				Reason:  nl.EventReasonRejected,
 				Message: fmt.Sprintf("Listener %s with host %s is taken by another resource", listenerName, hostDescription),
@@ -1338,7 +1462,7 @@ func (c *Configuration) addProblemsForResourcesWithoutActiveHost(resources map[s
 
 			if res.GetKeyWithKind() != r.GetKeyWithKind() {
 				p := ConfigurationProblem{
-					Object:  impl.VirtualServer,
+					Object:  impl.problemObject(),
 					IsError: false,
 					Reason:  nl.EventReasonRejected,
 					Message: "Host is taken by another resource",
@@ -1350,7 +1474,7 @@ func (c *Configuration) addProblemsForResourcesWithoutActiveHost(resources map[s
 
 			if res.GetKeyWithKind() != r.GetKeyWithKind() {
 				p := ConfigurationProblem{
-					Object:  impl.TransportServer,
+					Object:  impl.problemObject(),
 					IsError: false,
 					Reason:  nl.EventReasonRejected,
 					Message: "Host is taken by another resource",
@@ -1736,6 +1860,45 @@ func (c *Configuration) buildHostsAndResources() (newHosts map[string]Resource,
 		}
 	}
 
+	// Step 4 - Build hosts from the VirtualServers and TransportServers generated from Gateway API resources
+
+	var gatewayResources []Resource
+	for _, gvs := range c.gatewayTranslation.virtualServers {
+		resource := newGatewayVirtualServerConfiguration(gvs)
+		c.buildListenersForVSConfiguration(resource)
+		gatewayResources = append(gatewayResources, resource)
+	}
+	for _, gts := range c.gatewayTranslation.passthroughTSs {
+		gatewayResources = append(gatewayResources, newGatewayTransportServerConfiguration(gts))
+	}
+
+	for _, resource := range gatewayResources {
+		newResources[resource.GetKeyWithKind()] = resource
+
+		var host string
+		switch impl := resource.(type) {
+		case *VirtualServerConfiguration:
+			host = impl.VirtualServer.Spec.Host
+		case *TransportServerConfiguration:
+			host = impl.TransportServer.Spec.Host
+		}
+
+		holder, exists := newHosts[host]
+		if !exists {
+			newHosts[host] = resource
+			continue
+		}
+
+		warning := fmt.Sprintf("host %s is taken by another resource", host)
+
+		if !holder.Wins(resource) {
+			newHosts[host] = resource
+			holder.AddWarning(warning)
+		} else {
+			resource.AddWarning(warning)
+		}
+	}
+
 	return newHosts, newResources
 }
 
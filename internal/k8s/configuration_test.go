@@ -6945,3 +6945,64 @@ func TestBuildVirtualServerRoutesRegexSelector(t *testing.T) {
 		}
 	})
 }
+
+func TestFindResourcesForDefaultPolicies(t *testing.T) {
+	t.Parallel()
+	ing := createTestIngress("ingress", "foo.example.com")
+	vs := createTestVirtualServer("virtualserver", "bar.example.com")
+	tenantVS := createTestVirtualServer("virtualserver", "tenant.example.com")
+	tenantVS.Namespace = "tenant"
+
+	configuration := createTestConfiguration()
+	configuration.AddOrUpdateIngress(ing)
+	configuration.AddOrUpdateVirtualServer(vs)
+	configuration.AddOrUpdateVirtualServer(tenantVS)
+
+	gc := createTestGlobalConfiguration(nil)
+	gc.Spec.DefaultPolicies = []conf_v1.DefaultPolicies{
+		{
+			Policies:   []conf_v1.PolicyReference{{Name: "waf", Namespace: "security"}},
+			Namespaces: []string{"default"},
+		},
+		{
+			Policies:          []conf_v1.PolicyReference{{Name: "rate-limit"}},
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "true"}},
+		},
+	}
+
+	previous := configuration.GetDefaultPolicies()
+	_, _, err := configuration.AddOrUpdateGlobalConfiguration(gc)
+	if err != nil {
+		t.Fatalf("AddOrUpdateGlobalConfiguration() returned unexpected error %v", err)
+	}
+
+	expected := []Resource{
+		configuration.hosts["bar.example.com"],
+		configuration.hosts["foo.example.com"],
+	}
+	result := configuration.FindResourcesForDefaultPolicies(previous)
+	if diff := cmp.Diff(expected, result); diff != "" {
+		t.Errorf("FindResourcesForDefaultPolicies() returned unexpected result for the case of added default policies (-want +got):\n%s", diff)
+	}
+
+	expected = []Resource{
+		configuration.hosts["tenant.example.com"],
+	}
+	result = configuration.UpdateNamespaceLabels("tenant", map[string]string{"tenant": "true"})
+	if diff := cmp.Diff(expected, result); diff != "" {
+		t.Errorf("UpdateNamespaceLabels() returned unexpected result for the case of a namespace matching the selector (-want +got):\n%s", diff)
+	}
+
+	result = configuration.UpdateNamespaceLabels("tenant", map[string]string{"tenant": "true", "team": "a"})
+	if len(result) != 0 {
+		t.Errorf("UpdateNamespaceLabels() returned %v but expected no resources for the case of unchanged default policies", result)
+	}
+
+	expected = []Resource{
+		configuration.hosts["tenant.example.com"],
+	}
+	result = configuration.UpdateNamespaceLabels("tenant", nil)
+	if diff := cmp.Diff(expected, result); diff != "" {
+		t.Errorf("UpdateNamespaceLabels() returned unexpected result for the case of a removed namespace (-want +got):\n%s", diff)
+	}
+}
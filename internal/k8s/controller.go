@@ -38,6 +38,7 @@ import (
 
 	k8spolicies "github.com/nginx/kubernetes-ingress/internal/k8s/policies"
 	"github.com/nginx/kubernetes-ingress/internal/k8s/secrets"
+	"github.com/nginx/nginx-plus-go-client/v3/client"
 	"github.com/nginxinc/nginx-service-mesh/pkg/spiffe"
 	"github.com/spiffe/go-spiffe/v2/workloadapi"
 
@@ -53,6 +54,7 @@ import (
 	cm_controller "github.com/nginx/kubernetes-ingress/internal/certmanager"
 	"github.com/nginx/kubernetes-ingress/internal/configs"
 	ed_controller "github.com/nginx/kubernetes-ingress/internal/externaldns"
+	"github.com/nginx/kubernetes-ingress/internal/metrics"
 	"github.com/nginx/kubernetes-ingress/internal/metrics/collectors"
 
 	api_v1 "k8s.io/api/core/v1"
@@ -71,6 +73,9 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/dynamic/dynamicinformer"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gateway_client "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
+	gateway_informers "sigs.k8s.io/gateway-api/pkg/client/informers/externalversions"
 )
 
 const (
@@ -164,6 +169,7 @@ type pendingPolicyStatus struct {
 type LoadBalancerController struct {
 	client                        kubernetes.Interface
 	confClient                    k8s_nginx.Interface
+	gatewayClient                 gateway_client.Interface
 	dynClient                     dynamic.Interface
 	restConfig                    *rest.Config
 	cacheSyncs                    []cache.InformerSynced
@@ -175,8 +181,11 @@ type LoadBalancerController struct {
 	configMapLister               storeToConfigMapLister
 	mgmtConfigMapLister           storeToConfigMapLister
 	globalConfigurationLister     cache.Store
+	gatewayClassController        cache.Controller
+	gatewayClassLister            cache.Store
 	ingressLinkLister             cache.Store
 	namespaceLabeledLister        cache.Store
+	namespaceLister               cache.Store
 	syncQueue                     *taskQueue
 	ctx                           context.Context
 	Logger                        *slog.Logger
@@ -202,6 +211,8 @@ type LoadBalancerController struct {
 	secretNamespaceList           []string
 	metadata                      controllerMetadata
 	areCustomResourcesEnabled     bool
+	enableGatewayAPI              bool
+	gatewayProgrammingErrors      map[string]map[string]string // Gateway key -> generated resource key -> error
 	enableOIDC                    bool
 	metricsCollector              collectors.ControllerCollector
 	globalConfigurationValidator  *validation.GlobalConfigurationValidator
@@ -212,6 +223,10 @@ type LoadBalancerController struct {
 	isNginxReady                  bool
 	isPrometheusEnabled           bool
 	isLatencyMetricsEnabled       bool
+	isOutlierDetectionEnabled     bool
+	rolloutStatsGetter            upstreamStatsGetter
+	rolloutStats                  map[string]upstreamStats
+	rolloutStatuses               map[string][]conf_v1.RolloutStatus
 	configuration                 *Configuration
 	secretStore                   secrets.SecretStore
 	appProtectConfiguration       appprotect.Configuration
@@ -232,6 +247,9 @@ type LoadBalancerController struct {
 	mgmtConfigMapName             string
 	ShuttingDown                  bool
 	endpointSliceWarnings         map[string]bool // see updateEndpointSliceWarningState
+	syncMetrics                   syncMetrics     // see startSyncMetrics
+	certificateInspector          *secrets.CertificateInspector
+	certificateExpiryWarnings     map[string]certificateExpiryWarning // by the keys of the secrets, see checkCertificateExpiry
 
 	// Startup status deferral: pending slices accumulate status updates
 	// during the initial queue drain (!isNginxReady). They are snapshotted
@@ -250,6 +268,7 @@ var keyFunc = cache.DeletionHandlingMetaNamespaceKeyFunc
 type NewLoadBalancerControllerInput struct {
 	KubeClient                   kubernetes.Interface
 	ConfClient                   k8s_nginx.Interface
+	GatewayClient                gateway_client.Interface
 	DynClient                    dynamic.Interface
 	RestConfig                   *rest.Config
 	Recorder                     record.EventRecorder
@@ -276,6 +295,7 @@ type NewLoadBalancerControllerInput struct {
 	MGMTConfigMap                string
 	GlobalConfiguration          string
 	AreCustomResourcesEnabled    bool
+	EnableGatewayAPI             bool
 	EnableOIDC                   bool
 	MetricsCollector             collectors.ControllerCollector
 	GlobalConfigurationValidator *validation.GlobalConfigurationValidator
@@ -285,6 +305,8 @@ type NewLoadBalancerControllerInput struct {
 	InternalRoutesEnabled        bool
 	IsPrometheusEnabled          bool
 	IsLatencyMetricsEnabled      bool
+	OutlierDetector              *metrics.OutlierDetector
+	PlusClient                   *client.NginxClient
 	IsTLSPassthroughEnabled      bool
 	TLSPassthroughPort           int
 	SnippetsEnabled              bool
@@ -301,6 +323,7 @@ type NewLoadBalancerControllerInput struct {
 	DynamicWeightChangesReload   bool
 	InstallationFlags            []string
 	ShuttingDown                 bool
+	CertificateExpiryThresholds  []time.Duration
 }
 
 // NewLoadBalancerController creates a controller
@@ -317,6 +340,7 @@ func NewLoadBalancerController(input NewLoadBalancerControllerInput) *LoadBalanc
 	lbc := &LoadBalancerController{
 		client:                       input.KubeClient,
 		confClient:                   input.ConfClient,
+		gatewayClient:                input.GatewayClient,
 		dynClient:                    input.DynClient,
 		restConfig:                   input.RestConfig,
 		recorder:                     input.Recorder,
@@ -335,6 +359,8 @@ func NewLoadBalancerController(input NewLoadBalancerControllerInput) *LoadBalanc
 		secretNamespaceList:          input.SecretNamespace,
 		metadata:                     controllerMetadata{namespace: input.ControllerNamespace, pod: input.Pod},
 		areCustomResourcesEnabled:    input.AreCustomResourcesEnabled,
+		enableGatewayAPI:             input.EnableGatewayAPI,
+		gatewayProgrammingErrors:     make(map[string]map[string]string),
 		enableOIDC:                   input.EnableOIDC,
 		metricsCollector:             input.MetricsCollector,
 		globalConfigurationValidator: input.GlobalConfigurationValidator,
@@ -348,6 +374,7 @@ func NewLoadBalancerController(input NewLoadBalancerControllerInput) *LoadBalanc
 		mgmtConfigMapName:            input.MGMTConfigMap,
 		ShuttingDown:                 input.ShuttingDown,
 		endpointSliceWarnings:        make(map[string]bool),
+		certificateExpiryWarnings:    make(map[string]certificateExpiryWarning),
 	}
 
 	lbc.syncQueue = newTaskQueue(lbc.Logger, lbc.sync)
@@ -359,6 +386,16 @@ func NewLoadBalancerController(input NewLoadBalancerControllerInput) *LoadBalanc
 		}
 	}
 
+	if input.OutlierDetector != nil {
+		lbc.isOutlierDetectionEnabled = true
+		input.OutlierDetector.SetUpdateHandler(lbc.syncOutlierEjections)
+	}
+
+	if input.PlusClient != nil {
+		lbc.rolloutStatsGetter = &plusUpstreamStatsGetter{plusClient: input.PlusClient}
+		lbc.rolloutStatuses = make(map[string][]conf_v1.RolloutStatus)
+	}
+
 	isDynamicNs := input.WatchNamespaceLabel != ""
 
 	if isDynamicNs {
@@ -401,9 +438,19 @@ func NewLoadBalancerController(input NewLoadBalancerControllerInput) *LoadBalanc
 			lbc.watchGlobalConfiguration = true
 			ns, name, _ := ParseNamespaceName(input.GlobalConfiguration)
 			lbc.addGlobalConfigurationHandler(createGlobalConfigurationHandlers(lbc), ns, name)
+
+			// The namespace labels select the namespaces of the default policies.
+			// Otherwise, the labels of all namespaces are watched once the default policies have a namespace selector.
+			if isDynamicNs {
+				lbc.namespaceLister = lbc.namespaceLabeledLister
+			}
 		}
 	}
 
+	if lbc.enableGatewayAPI {
+		lbc.addGatewayClassHandler(createGatewayAPIHandlers[*gatewayv1.GatewayClass](lbc, gatewayClassKind), lbc.ingressClass)
+	}
+
 	if input.ConfigMaps != "" {
 		nginxConfigMapsNS, nginxConfigMapsName, err := ParseNamespaceName(input.ConfigMaps)
 		if err != nil {
@@ -467,6 +514,7 @@ func NewLoadBalancerController(input NewLoadBalancerControllerInput) *LoadBalanc
 	lbc.dosConfiguration = appprotectdos.NewConfiguration(input.AppProtectDosEnabled)
 
 	lbc.secretStore = secrets.NewLocalSecretStore(lbc.configurator)
+	lbc.certificateInspector = secrets.NewCertificateInspector(lbc.secretStore, input.CertificateExpiryThresholds)
 
 	// NIC Telemetry Reporting
 	if input.EnableTelemetryReporting {
@@ -539,8 +587,15 @@ type namespacedInformer struct {
 	appProtectUserSigLister      cache.Store
 	transportServerLister        cache.Store
 	policyLister                 cache.Store
+	gatewaySharedInformerFactory gateway_informers.SharedInformerFactory
+	gatewayLister                cache.Store
+	httpRouteLister              cache.Store
+	grpcRouteLister              cache.Store
+	tlsRouteLister               cache.Store
+	tcpRouteLister               cache.Store
 	isSecretsEnabledNamespace    bool
 	areCustomResourcesEnabled    bool
+	isGatewayAPIEnabled          bool
 	appProtectEnabled            bool
 	appProtectDosEnabled         bool
 	stopCh                       chan struct{}
@@ -594,6 +649,10 @@ func (lbc *LoadBalancerController) newNamespacedInformer(ns string) (*namespaced
 		return nil, err
 	}
 
+	if err := lbc.addGatewayAPIHandlers(nsi, ns); err != nil {
+		return nil, err
+	}
+
 	if err := lbc.addAppProtectHandlers(nsi, ns); err != nil {
 		return nil, err
 	}
@@ -766,6 +825,12 @@ func (lbc *LoadBalancerController) Run() {
 		go lbc.leaderElector.Run(lbc.ctx)
 	}
 
+	if lbc.rolloutStatsGetter != nil {
+		go lbc.runRollouts(lbc.ctx, rolloutSyncInterval)
+	}
+
+	go lbc.runCertificateExpiryChecks(lbc.ctx)
+
 	if lbc.telemetryCollector != nil {
 		go func(ctx context.Context) {
 			select {
@@ -792,6 +857,9 @@ func (lbc *LoadBalancerController) Run() {
 	if lbc.watchGlobalConfiguration {
 		go lbc.globalConfigurationController.Run(lbc.ctx.Done())
 	}
+	if lbc.enableGatewayAPI {
+		go lbc.gatewayClassController.Run(lbc.ctx.Done())
+	}
 	if lbc.watchIngressLink {
 		go lbc.ingressLinkInformer.Run(lbc.ctx.Done())
 	}
@@ -836,6 +904,10 @@ func (nsi *namespacedInformer) start() {
 		go nsi.confSharedInformerFactory.Start(nsi.stopCh)
 	}
 
+	if nsi.isGatewayAPIEnabled {
+		go nsi.gatewaySharedInformerFactory.Start(nsi.stopCh)
+	}
+
 	if nsi.appProtectEnabled || nsi.appProtectDosEnabled {
 		go nsi.dynInformerFactory.Start(nsi.stopCh)
 	}
@@ -937,8 +1009,8 @@ func (lbc *LoadBalancerController) virtualServerRequiresEndpointsUpdate(vsEx *co
 
 	// Check external auth services referenced by policies
 	for _, p := range vsEx.Policies {
-		if p.Spec.ExternalAuth != nil && p.Spec.ExternalAuth.AuthServiceName != "" {
-			_, resolvedName := configs.ParseServiceReference(p.Spec.ExternalAuth.AuthServiceName, p.Namespace)
+		if exAuth := configs.GetExternalAuthForPolicy(p); exAuth != nil && exAuth.AuthServiceName != "" {
+			_, resolvedName := configs.ParseServiceReference(exAuth.AuthServiceName, p.Namespace)
 			if resolvedName == serviceName {
 				return true
 			}
@@ -973,8 +1045,8 @@ func (lbc *LoadBalancerController) ingressRequiresEndpointsUpdate(ingressEx *con
 
 	// Check external auth services referenced by policies
 	for _, p := range ingressEx.Policies {
-		if p.Spec.ExternalAuth != nil && p.Spec.ExternalAuth.AuthServiceName != "" {
-			_, resolvedName := configs.ParseServiceReference(p.Spec.ExternalAuth.AuthServiceName, ingressEx.Ingress.Namespace)
+		if exAuth := configs.GetExternalAuthForPolicy(p); exAuth != nil && exAuth.AuthServiceName != "" {
+			_, resolvedName := configs.ParseServiceReference(exAuth.AuthServiceName, ingressEx.Ingress.Namespace)
 			if resolvedName == serviceName {
 				return true
 			}
@@ -1177,6 +1249,12 @@ func (lbc *LoadBalancerController) preSyncSecrets() {
 			lbc.secretStore.AddOrUpdateSecret(secret)
 		}
 	}
+
+	// the warnings about the expiring certificates must be known before the resources referencing the secrets are synced
+	now := time.Now()
+	for key := range lbc.certificateInspector.InspectAll() {
+		lbc.checkCertificateExpiry(key, now, false)
+	}
 }
 
 func (lbc *LoadBalancerController) sync(task task) {
@@ -1187,10 +1265,12 @@ func (lbc *LoadBalancerController) sync(task task) {
 		nl.Debugf(lbc.Logger, "Batch processing %v items", lbc.syncQueue.Len())
 	}
 	nl.Debugf(lbc.Logger, "Syncing %v", task.Key)
-	if lbc.spiffeCertFetcher != nil {
+	if lbc.spiffeCertFetcher != nil || lbc.isOutlierDetectionEnabled {
 		lbc.syncLock.Lock()
 		defer lbc.syncLock.Unlock()
 	}
+	lbc.startSyncMetrics()
+	defer lbc.finishSyncMetrics(task)
 	if lbc.batchSyncEnabled && task.Kind != endpointslice {
 		nl.Debug(lbc.Logger, "Task is not endpointslice - enabling batch reload")
 		lbc.enableBatchReload = true
@@ -1247,6 +1327,37 @@ func (lbc *LoadBalancerController) sync(task task) {
 		lbc.syncDosProtectedResource(task)
 	case ingressLink:
 		lbc.syncIngressLink(task)
+	case gatewayClass:
+		lbc.syncGatewayClass(task)
+	case gateway:
+		lbc.syncGateway(task)
+	case httpRoute:
+		lbc.syncHTTPRoute(task)
+	case grpcRoute:
+		lbc.syncGRPCRoute(task)
+	case tlsRoute:
+		lbc.syncTLSRoute(task)
+	case tcpRoute:
+		lbc.syncTCPRoute(task)
+	case certificateExpiry:
+		lbc.syncCertificateExpiry()
+	case rollout:
+		lbc.syncRollouts()
+	}
+
+	switch task.Kind {
+	case endpointslice:
+		lbc.recordSyncReloads(reloadTriggerEndpoints)
+	case secret:
+		lbc.recordSyncReloads(reloadTriggerSecret)
+	default:
+		lbc.recordSyncReloads(reloadTriggerConfig)
+	}
+
+	// the statuses of the Gateway API resources depend on the hosts and listeners taken by the other resources
+	switch task.Kind {
+	case ingress, service, virtualserver, transportserver, globalConfiguration, gateway, httpRoute, grpcRoute, tlsRoute, tcpRoute:
+		lbc.updateGatewayAPIStatuses()
 	}
 
 	if lbc.isNginxPlus && lbc.isNginxReady {
@@ -1300,6 +1411,13 @@ func (lbc *LoadBalancerController) sync(task task) {
 		// the pending slices and nil the fields so the main goroutine can
 		// safely append new statuses for resources arriving after startup.
 		lbc.flushPendingStatusesAsync()
+
+		// Step 5: Report the statuses of the Gateway API resources, which are derived
+		// from the whole Configuration rather than from a single resource.
+		lbc.updateGatewayAPIStatuses()
+
+		// Step 6: Emit the events for the certificates that expired or reached a threshold before the startup.
+		lbc.syncCertificateExpiry()
 	}
 
 	if lbc.batchSyncEnabled && lbc.syncQueue.Len() == 0 {
@@ -1316,6 +1434,9 @@ func (lbc *LoadBalancerController) sync(task task) {
 		lbc.enableBatchReload = false
 		nl.Debug(lbc.Logger, "Batch sync completed - disabling batch reload")
 	}
+
+	// the reloads of the startup and of the end of a batch cover all the tasks synced before
+	lbc.recordSyncReloads(reloadTriggerBatch)
 }
 
 func (lbc *LoadBalancerController) removeNamespacedInformer(nsi *namespacedInformer, key string) {
@@ -1427,6 +1548,7 @@ func (lbc *LoadBalancerController) syncVirtualServer(task task) {
 		nl.Debugf(lbc.Logger, "Deleting VirtualServer: %v\n", key)
 
 		changes, problems = lbc.configuration.DeleteVirtualServer(key)
+		lbc.deleteResourceState(virtualserver, key)
 	} else {
 		nl.Debugf(lbc.Logger, "Adding or Updating VirtualServer: %v\n", key)
 
@@ -1445,11 +1567,23 @@ func (lbc *LoadBalancerController) processProblems(problems []ConfigurationProbl
 		eventType := api_v1.EventTypeWarning
 		lbc.recorder.Event(p.Object, eventType, p.Reason, p.Message)
 
+		state := conf_v1.StateWarning
+		if p.IsError {
+			state = conf_v1.StateInvalid
+		}
+
+		switch obj := p.Object.(type) {
+		case *networking.Ingress:
+			lbc.recordResourceState(ingress, getResourceKey(&obj.ObjectMeta), state, nil)
+		case *conf_v1.VirtualServer:
+			lbc.recordResourceState(virtualserver, getResourceKey(&obj.ObjectMeta), state, nil)
+		case *conf_v1.TransportServer:
+			lbc.recordResourceState(transportserver, getResourceKey(&obj.ObjectMeta), state, nil)
+		case *conf_v1.VirtualServerRoute:
+			lbc.recordResourceState(virtualServerRoute, getResourceKey(&obj.ObjectMeta), state, nil)
+		}
+
 		if lbc.reportCustomResourceStatusEnabled() {
-			state := conf_v1.StateWarning
-			if p.IsError {
-				state = conf_v1.StateInvalid
-			}
 
 			// Problem resources (conflicts, orphans, validation failures) are never
 			// deferred into the pending slices, even during startup. The number of
@@ -1527,6 +1661,12 @@ func (lbc *LoadBalancerController) processChanges(changes []ResourceChange) {
 				if deleteErr != nil {
 					nl.Errorf(lbc.Logger, "Error when deleting configuration for VirtualServer %v: %v", key, deleteErr)
 				}
+				lbc.deleteResourceState(virtualserver, key)
+
+				if impl.Gateway != nil {
+					lbc.clearGatewayProgrammingError(getResourceKey(&impl.Gateway.ObjectMeta), key)
+					continue
+				}
 
 				var vsExists bool
 				var err error
@@ -1549,6 +1689,7 @@ func (lbc *LoadBalancerController) processChanges(changes []ResourceChange) {
 				if deleteErr != nil {
 					nl.Errorf(lbc.Logger, "Error when deleting configuration for Ingress %v: %v", key, deleteErr)
 				}
+				lbc.deleteResourceState(ingress, key)
 
 				var ingExists bool
 				var err error
@@ -1570,6 +1711,12 @@ func (lbc *LoadBalancerController) processChanges(changes []ResourceChange) {
 				if deleteErr != nil {
 					nl.Errorf(lbc.Logger, "Error when deleting configuration for TransportServer %v: %v", key, deleteErr)
 				}
+				lbc.deleteResourceState(transportserver, key)
+
+				if impl.Gateway != nil {
+					lbc.clearGatewayProgrammingError(getResourceKey(&impl.Gateway.ObjectMeta), key)
+					continue
+				}
 
 				var tsExists bool
 				var err error
@@ -1616,6 +1763,7 @@ func (lbc *LoadBalancerController) UpdateVirtualServerStatusAndEventsOnDelete(vs
 
 		msg := fmt.Sprintf("VirtualServer %s was rejected %s", getResourceKey(&vsConfig.VirtualServer.ObjectMeta), eventWarningMessage)
 		lbc.recorder.Event(vsConfig.VirtualServer, eventType, eventTitle, msg)
+		lbc.recordResourceState(virtualserver, getResourceKey(&vsConfig.VirtualServer.ObjectMeta), state, deleteErr)
 
 		if lbc.reportCustomResourceStatusEnabled() {
 			err := lbc.statusUpdater.UpdateVirtualServerStatus(vsConfig.VirtualServer, state, eventTitle, msg)
@@ -1633,12 +1781,15 @@ func (lbc *LoadBalancerController) UpdateVirtualServerStatusAndEventsOnDelete(vs
 func (lbc *LoadBalancerController) UpdateIngressStatusAndEventsOnDelete(ingConfig *IngressConfiguration, changeError string, deleteErr error) {
 	eventTitle := nl.EventReasonRejected
 	eventWarningMessage := ""
+	state := ""
 
 	// Ingress either became invalid or lost all its hosts
 	if changeError != "" {
 		eventWarningMessage = fmt.Sprintf("with error: %s", changeError)
+		state = conf_v1.StateInvalid
 	} else if len(ingConfig.Warnings) > 0 {
 		eventWarningMessage = fmt.Sprintf("with warning(s): %s", formatWarningMessages(ingConfig.Warnings))
+		state = conf_v1.StateWarning
 	}
 
 	// we don't need to report anything if eventWarningMessage is empty
@@ -1648,9 +1799,11 @@ func (lbc *LoadBalancerController) UpdateIngressStatusAndEventsOnDelete(ingConfi
 		if deleteErr != nil {
 			eventTitle = nl.EventReasonRejectedWithError
 			eventWarningMessage = fmt.Sprintf("%s; but was not applied: %v", eventWarningMessage, deleteErr)
+			state = conf_v1.StateInvalid
 		}
 
 		lbc.recorder.Eventf(ingConfig.Ingress, api_v1.EventTypeWarning, eventTitle, "%v was rejected: %v", getResourceKey(&ingConfig.Ingress.ObjectMeta), eventWarningMessage)
+		lbc.recordResourceState(ingress, getResourceKey(&ingConfig.Ingress.ObjectMeta), state, deleteErr)
 		if lbc.reportStatusEnabled() {
 			err := lbc.statusUpdater.ClearIngressStatus(*ingConfig.Ingress)
 			if err != nil {
@@ -1681,6 +1834,7 @@ func (lbc *LoadBalancerController) updateResourcesStatusAndEvents(resources []Re
 }
 
 func (lbc *LoadBalancerController) updateMergeableIngressStatusAndEvents(ingConfig *IngressConfiguration, warnings configs.Warnings, operationErr error) {
+	warnings = lbc.withCertificateExpiryWarnings(warnings, ingConfig, ingConfig.Ingress)
 	eventType := api_v1.EventTypeNormal
 	eventTitle := nl.EventReasonAddedOrUpdated
 	eventWarningMessage := ""
@@ -1713,6 +1867,7 @@ func (lbc *LoadBalancerController) updateMergeableIngressStatusAndEvents(ingConf
 
 	msg := fmt.Sprintf("Configuration for %v was added or updated%s", getResourceKey(&ingConfig.Ingress.ObjectMeta), eventWarningPrefixed)
 	lbc.recorder.Event(ingConfig.Ingress, eventType, eventTitle, msg)
+	lbc.recordResourceState(ingress, getResourceKey(&ingConfig.Ingress.ObjectMeta), getStatusFromEventTitle(eventTitle), operationErr)
 
 	for _, fm := range ingConfig.Minions {
 		minionEventType := api_v1.EventTypeNormal
@@ -1749,6 +1904,7 @@ func (lbc *LoadBalancerController) updateMergeableIngressStatusAndEvents(ingConf
 		}
 		minionMsg := fmt.Sprintf("Configuration for %v/%v was added or updated%s", fm.Ingress.Namespace, fm.Ingress.Name, minionEventWarningPrefixed)
 		lbc.recorder.Event(fm.Ingress, minionEventType, minionEventTitle, minionMsg)
+		lbc.recordResourceState(ingress, getResourceKey(&fm.Ingress.ObjectMeta), getStatusFromEventTitle(minionEventTitle), operationErr)
 	}
 
 	if lbc.reportStatusEnabled() {
@@ -1773,6 +1929,7 @@ func (lbc *LoadBalancerController) updateMergeableIngressStatusAndEvents(ingConf
 }
 
 func (lbc *LoadBalancerController) updateRegularIngressStatusAndEvents(ingConfig *IngressConfiguration, warnings configs.Warnings, operationErr error) {
+	warnings = lbc.withCertificateExpiryWarnings(warnings, ingConfig, ingConfig.Ingress)
 	eventType := api_v1.EventTypeNormal
 	eventTitle := nl.EventReasonAddedOrUpdated
 	eventWarningMessage := ""
@@ -1797,6 +1954,7 @@ func (lbc *LoadBalancerController) updateRegularIngressStatusAndEvents(ingConfig
 
 	msg := fmt.Sprintf("Configuration for %v was added or updated %s", getResourceKey(&ingConfig.Ingress.ObjectMeta), eventWarningMessage)
 	lbc.recorder.Event(ingConfig.Ingress, eventType, eventTitle, msg)
+	lbc.recordResourceState(ingress, getResourceKey(&ingConfig.Ingress.ObjectMeta), getStatusFromEventTitle(eventTitle), operationErr)
 
 	if lbc.reportStatusEnabled() {
 		// Defer status updates during startup to avoid serial API calls
@@ -1814,6 +1972,14 @@ func (lbc *LoadBalancerController) updateRegularIngressStatusAndEvents(ingConfig
 }
 
 func (lbc *LoadBalancerController) updateVirtualServerStatusAndEvents(vsConfig *VirtualServerConfiguration, warnings configs.Warnings, operationErr error) {
+	if vsConfig.Gateway != nil {
+		key := getResourceKey(&vsConfig.VirtualServer.ObjectMeta)
+		lbc.updateGatewayResourceStatusAndEvents(vsConfig.Gateway, key, vsConfig.Warnings, warnings[vsConfig.VirtualServer], operationErr)
+		return
+	}
+
+	warnings = lbc.withCertificateExpiryWarnings(warnings, vsConfig, vsConfig.VirtualServer)
+
 	eventType := api_v1.EventTypeNormal
 	eventTitle := nl.EventReasonAddedOrUpdated
 	eventWarningMessage := ""
@@ -1840,8 +2006,11 @@ func (lbc *LoadBalancerController) updateVirtualServerStatusAndEvents(vsConfig *
 		state = conf_v1.StateInvalid
 	}
 
-	msg := fmt.Sprintf("Configuration for %v was added or updated %s", getResourceKey(&vsConfig.VirtualServer.ObjectMeta), eventWarningMessage)
+	defaultPolicies := lbc.getDefaultPolicies()
+
+	msg := fmt.Sprintf("Configuration for %v was added or updated%s %s", getResourceKey(&vsConfig.VirtualServer.ObjectMeta), defaultPoliciesMessage(defaultPolicies.forVirtualServer(vsConfig.VirtualServer)), eventWarningMessage)
 	lbc.recorder.Event(vsConfig.VirtualServer, eventType, eventTitle, msg)
+	lbc.recordResourceState(virtualserver, getResourceKey(&vsConfig.VirtualServer.ObjectMeta), state, operationErr)
 
 	if lbc.reportCustomResourceStatusEnabled() {
 		// Defer VS status updates during startup to avoid serial API calls
@@ -1878,8 +2047,9 @@ func (lbc *LoadBalancerController) updateVirtualServerStatusAndEvents(vsConfig *
 			vsrState = conf_v1.StateInvalid
 		}
 
-		msg := fmt.Sprintf("Configuration for %v/%v was added or updated%s", vsr.Namespace, vsr.Name, vsrEventWarningMessage)
+		msg := fmt.Sprintf("Configuration for %v/%v was added or updated%s%s", vsr.Namespace, vsr.Name, defaultPoliciesMessage(defaultPolicies.forVirtualServerRoute(vsr)), vsrEventWarningMessage)
 		lbc.recorder.Event(vsr, vsrEventType, vsrEventTitle, msg)
+		lbc.recordResourceState(virtualServerRoute, getResourceKey(&vsr.ObjectMeta), vsrState, operationErr)
 
 		if lbc.reportCustomResourceStatusEnabled() {
 			vss := []*conf_v1.VirtualServer{vsConfig.VirtualServer}
@@ -1918,6 +2088,7 @@ func (lbc *LoadBalancerController) syncVirtualServerRoute(task task) {
 		nl.Debugf(lbc.Logger, "Deleting VirtualServerRoute: %v", key)
 
 		changes, problems = lbc.configuration.DeleteVirtualServerRoute(key)
+		lbc.deleteResourceState(virtualServerRoute, key)
 	} else {
 		nl.Debugf(lbc.Logger, "Adding or Updating VirtualServerRoute: %v", key)
 
@@ -1949,6 +2120,7 @@ func (lbc *LoadBalancerController) syncIngress(task task) {
 		nl.Debugf(lbc.Logger, "Deleting Ingress: %v", key)
 
 		changes, problems = lbc.configuration.DeleteIngress(key)
+		lbc.deleteResourceState(ingress, key)
 	} else {
 		nl.Debugf(lbc.Logger, "Adding or Updating Ingress: %v", key)
 
@@ -2198,21 +2370,13 @@ func (lbc *LoadBalancerController) syncSecret(task task) {
 		return
 	}
 
-	resources := lbc.configuration.FindResourcesForSecret(namespace, name)
-
-	if lbc.areCustomResourcesEnabled {
-		secretPols := lbc.getPoliciesForSecret(namespace, name)
-		for _, pol := range secretPols {
-			resources = append(resources, lbc.configuration.FindResourcesForPolicy(pol.Namespace, pol.Name)...)
-		}
-
-		resources = removeDuplicateResources(resources)
-	}
+	resources := lbc.findResourcesForSecret(namespace, name)
 
 	nl.Debugf(lbc.Logger, "Found %v Resources with Secret %v", len(resources), key)
 
 	if !secretWatched {
 		lbc.secretStore.DeleteSecret(key)
+		lbc.checkCertificateExpiry(key, time.Now(), false)
 
 		nl.Debugf(lbc.Logger, "Deleting Secret: %v", key)
 
@@ -2231,6 +2395,8 @@ func (lbc *LoadBalancerController) syncSecret(task task) {
 	secret := obj.(*api_v1.Secret)
 
 	lbc.secretStore.AddOrUpdateSecret(secret)
+	// the statuses of the resources are updated with the warnings about the certificate below
+	lbc.checkCertificateExpiry(key, time.Now(), false)
 
 	if lbc.isSpecialSecret(key) {
 		reloadNginx := true
@@ -2243,6 +2409,22 @@ func (lbc *LoadBalancerController) syncSecret(task task) {
 	}
 }
 
+// findResourcesForSecret finds the resources that reference the secret directly or through their policies.
+func (lbc *LoadBalancerController) findResourcesForSecret(namespace string, name string) []Resource {
+	resources := lbc.configuration.FindResourcesForSecret(namespace, name)
+
+	if lbc.areCustomResourcesEnabled {
+		secretPols := lbc.getPoliciesForSecret(namespace, name)
+		for _, pol := range secretPols {
+			resources = append(resources, lbc.configuration.FindResourcesForPolicy(pol.Namespace, pol.Name)...)
+		}
+
+		resources = removeDuplicateResources(resources)
+	}
+
+	return resources
+}
+
 func removeDuplicateResources(resources []Resource) []Resource {
 	encountered := make(map[string]bool)
 	var uniqueResources []Resource
@@ -2726,6 +2908,22 @@ func (lbc *LoadBalancerController) createIngressEx(ing *networking.Ingress, vali
 				ingEx.PolicyWarnings = append(ingEx.PolicyWarnings, msg)
 			}
 		}
+
+		defaultPolicies, defaultPolicyErrors := lbc.getPolicies(lbc.getDefaultPolicies().forIngress(ing), ing.Namespace)
+		for _, err := range defaultPolicyErrors {
+			msg := fmt.Sprintf("Default policy error for Ingress %v/%v: %v", ing.Namespace, ing.Name, err)
+			nl.Warnf(lbc.Logger, "%s", msg)
+			ingEx.PolicyWarnings = append(ingEx.PolicyWarnings, msg)
+		}
+		for _, pol := range defaultPolicies {
+			// The default policies of the types not supported on Ingress only apply to VirtualServers and VirtualServerRoutes
+			if !configs.IsPolicySupportedOnIngress(pol) {
+				nl.Debugf(lbc.Logger, "Skipping default policy %v/%v of a type not supported on Ingress %v/%v", pol.Namespace, pol.Name, ing.Namespace, ing.Name)
+				continue
+			}
+			ingEx.DefaultPolicies = append(ingEx.DefaultPolicies, conf_v1.PolicyReference{Name: pol.Name, Namespace: pol.Namespace})
+			policies = append(policies, pol)
+		}
 		if err := lbc.addIngressMTLSSecretRefs(ingEx.SecretRefs, policies); err != nil {
 			msg := fmt.Sprintf("Policy error for Ingress %v/%v: %v", ing.Namespace, ing.Name, err)
 			nl.Warnf(lbc.Logger, "%s", msg)
@@ -2948,13 +3146,17 @@ func (lbc *LoadBalancerController) createVirtualServerEx(virtualServer *conf_v1.
 		ApPolRefs:                   make(map[string]*unstructured.Unstructured),
 		LogConfRefs:                 make(map[string]*unstructured.Unstructured),
 		DosProtectedEx:              make(map[string]*configs.DosEx),
+		RolloutWeights:              lbc.getRolloutWeights(virtualServer),
 	}
 	if lbc.configurator != nil && lbc.configurator.CfgParams != nil {
 		virtualServerEx.ZoneSync = lbc.configurator.CfgParams.ZoneSync.Enable
 	}
 
+	// The default policies of the GlobalConfiguration are not applied to the VirtualServers generated from Gateway API resources.
+	isGateway := false
 	resource := lbc.configuration.hosts[virtualServer.Spec.Host]
 	if vsc, ok := resource.(*VirtualServerConfiguration); ok {
+		isGateway = vsc.Gateway != nil
 		virtualServerEx.HTTPPort = vsc.HTTPPort
 		virtualServerEx.HTTPSPort = vsc.HTTPSPort
 		virtualServerEx.HTTPIPv4 = vsc.HTTPIPv4
@@ -2974,7 +3176,13 @@ func (lbc *LoadBalancerController) createVirtualServerEx(virtualServer *conf_v1.
 		virtualServerEx.SecretRefs[scrtKey] = scrtRef
 	}
 
-	policies, policyErrors := lbc.getPolicies(virtualServer.Spec.Policies, virtualServer.Namespace)
+	var defaultPolicies *defaultPolicies
+	if !isGateway {
+		defaultPolicies = lbc.getDefaultPolicies()
+		virtualServerEx.DefaultPolicies = defaultPolicies.forVirtualServer(virtualServer)
+	}
+
+	policies, policyErrors := lbc.getPolicies(append(slices.Clone(virtualServerEx.DefaultPolicies), virtualServer.Spec.Policies...), virtualServer.Namespace)
 	for _, err := range policyErrors {
 		nl.Warnf(lbc.Logger, "Error getting policy for VirtualServer %s/%s: %v", virtualServer.Namespace, virtualServer.Name, err)
 	}
@@ -3015,6 +3223,14 @@ func (lbc *LoadBalancerController) createVirtualServerEx(virtualServer *conf_v1.
 	if err != nil {
 		nl.Warnf(lbc.Logger, "Error getting APIKey secrets for VirtualServer %v/%v: %v", virtualServer.Namespace, virtualServer.Name, err)
 	}
+	err = lbc.addIntrospectionSecretRefs(virtualServerEx.SecretRefs, policies)
+	if err != nil {
+		nl.Warnf(lbc.Logger, "Error getting introspection secrets for VirtualServer %v/%v: %v", virtualServer.Namespace, virtualServer.Name, err)
+	}
+	err = lbc.addHMACSecretRefs(virtualServerEx.SecretRefs, policies)
+	if err != nil {
+		nl.Warnf(lbc.Logger, "Error getting HMAC secrets for VirtualServer %v/%v: %v", virtualServer.Namespace, virtualServer.Name, err)
+	}
 
 	err = lbc.addWAFPolicyRefs(virtualServerEx.ApPolRefs, virtualServerEx.LogConfRefs, policies)
 	if err != nil {
@@ -3159,11 +3375,30 @@ func (lbc *LoadBalancerController) createVirtualServerEx(virtualServer *conf_v1.
 			nl.Warnf(lbc.Logger, "Error getting APIKey secrets for VirtualServer %v/%v: %v", virtualServer.Namespace, virtualServer.Name, err)
 		}
 
+		err = lbc.addIntrospectionSecretRefs(virtualServerEx.SecretRefs, vsRoutePolicies)
+		if err != nil {
+			nl.Warnf(lbc.Logger, "Error getting introspection secrets for VirtualServer %v/%v: %v", virtualServer.Namespace, virtualServer.Name, err)
+		}
+
+		err = lbc.addHMACSecretRefs(virtualServerEx.SecretRefs, vsRoutePolicies)
+		if err != nil {
+			nl.Warnf(lbc.Logger, "Error getting HMAC secrets for VirtualServer %v/%v: %v", virtualServer.Namespace, virtualServer.Name, err)
+		}
+
 	}
 
 	for _, vsr := range virtualServerRoutes {
+		// The default policies of the VirtualServer are applied to the whole server, including the VirtualServerRoute subroutes
+		vsrDefaultPolicies := excludePolicies(defaultPolicies.forVirtualServerRoute(vsr), virtualServerEx.DefaultPolicies, virtualServer.Namespace)
+		if len(vsrDefaultPolicies) > 0 {
+			if virtualServerEx.VirtualServerRouteDefaultPolicies == nil {
+				virtualServerEx.VirtualServerRouteDefaultPolicies = make(map[string][]conf_v1.PolicyReference)
+			}
+			virtualServerEx.VirtualServerRouteDefaultPolicies[getResourceKey(&vsr.ObjectMeta)] = vsrDefaultPolicies
+		}
+
 		for _, sr := range vsr.Spec.Subroutes {
-			vsrSubroutePolicies, policyErrors := lbc.getPolicies(sr.Policies, vsr.Namespace)
+			vsrSubroutePolicies, policyErrors := lbc.getPolicies(append(slices.Clone(vsrDefaultPolicies), sr.Policies...), vsr.Namespace)
 			for _, err := range policyErrors {
 				nl.Warnf(lbc.Logger, "Error getting policy for VirtualServerRoute %s/%s: %v", vsr.Namespace, vsr.Name, err)
 			}
@@ -3208,6 +3443,16 @@ func (lbc *LoadBalancerController) createVirtualServerEx(virtualServer *conf_v1.
 				nl.Warnf(lbc.Logger, "Error getting APIKey secrets for VirtualServerRoute %v/%v: %v", vsr.Namespace, vsr.Name, err)
 			}
 
+			err = lbc.addIntrospectionSecretRefs(virtualServerEx.SecretRefs, vsrSubroutePolicies)
+			if err != nil {
+				nl.Warnf(lbc.Logger, "Error getting introspection secrets for VirtualServerRoute %v/%v: %v", vsr.Namespace, vsr.Name, err)
+			}
+
+			err = lbc.addHMACSecretRefs(virtualServerEx.SecretRefs, vsrSubroutePolicies)
+			if err != nil {
+				nl.Warnf(lbc.Logger, "Error getting HMAC secrets for VirtualServerRoute %v/%v: %v", vsr.Namespace, vsr.Name, err)
+			}
+
 			err = lbc.addWAFPolicyRefs(virtualServerEx.ApPolRefs, virtualServerEx.LogConfRefs, vsrSubroutePolicies)
 			if err != nil {
 				nl.Warnf(lbc.Logger, "Error getting WAF policies for VirtualServerRoute %v/%v: %v", vsr.Namespace, vsr.Name, err)
@@ -3282,32 +3527,33 @@ func (lbc *LoadBalancerController) createVirtualServerEx(virtualServer *conf_v1.
 
 func (lbc *LoadBalancerController) generateExternalAuthEndpoints(policies []*conf_v1.Policy, endpoints map[string][]string) {
 	for _, p := range policies {
-		if p.Spec.ExternalAuth == nil || p.Spec.ExternalAuth.AuthServiceName == "" {
+		exAuth := configs.GetExternalAuthForPolicy(p)
+		if exAuth == nil || exAuth.AuthServiceName == "" {
 			continue
 		}
 
-		ns, name := configs.ParseServiceReference(p.Spec.ExternalAuth.AuthServiceName, p.Namespace)
+		ns, name := configs.ParseServiceReference(exAuth.AuthServiceName, p.Namespace)
 		svc, err := lbc.getServiceFromInformer(ns, name)
 		if err != nil {
-			nl.Warnf(lbc.Logger, "Error getting Service for ExternalAuth %v in policy %v/%v: %v", p.Spec.ExternalAuth.AuthServiceName, p.Namespace, p.Name, err)
+			nl.Warnf(lbc.Logger, "Error getting Service for ExternalAuth %v in policy %v/%v: %v", exAuth.AuthServiceName, p.Namespace, p.Name, err)
 			// Explicitly mark endpoint keys as empty so the warning propagates
 			// to VS/VSR status. The external auth service is required; its
 			// absence must surface as a user-visible warning.
-			for _, port := range externalAuthFallbackPorts(p) {
+			for _, port := range externalAuthFallbackPorts(exAuth) {
 				key := fmt.Sprintf("%s/%s:%d", ns, name, port)
 				endpoints[key] = []string{}
 			}
 			continue
 		}
 
-		ports := collectAuthPorts(p, svc)
+		ports := collectAuthPorts(exAuth, svc)
 		for _, port := range ports {
 			if port <= 0 || port > math.MaxUint16 {
 				continue
 			}
 			endps, _, err := lbc.getEndpointsForUpstream(ns, name, uint16(port))
 			if err != nil {
-				nl.Warnf(lbc.Logger, "Error getting Endpoints for ExternalAuth service %v in policy %v/%v: %v", p.Spec.ExternalAuth.AuthServiceName, p.Namespace, p.Name, err)
+				nl.Warnf(lbc.Logger, "Error getting Endpoints for ExternalAuth service %v in policy %v/%v: %v", exAuth.AuthServiceName, p.Namespace, p.Name, err)
 				// Service exists but has no ready endpoints; mark empty so
 				// the warning propagates to VS/VSR status.
 				endpoints[fmt.Sprintf("%s/%s:%d", ns, name, port)] = []string{}
@@ -3322,17 +3568,17 @@ func (lbc *LoadBalancerController) generateExternalAuthEndpoints(policies []*con
 // when the referenced Service cannot be found. It uses AuthServicePorts if
 // specified; otherwise it falls back to 443 (SSL) or 80 (default), matching
 // the logic in virtualServerConfigurator.getExAuthServicePort.
-func externalAuthFallbackPorts(p *conf_v1.Policy) []int32 {
-	if len(p.Spec.ExternalAuth.AuthServicePorts) > 0 {
-		ports := make([]int32, 0, len(p.Spec.ExternalAuth.AuthServicePorts))
-		for _, port := range p.Spec.ExternalAuth.AuthServicePorts {
+func externalAuthFallbackPorts(exAuth *conf_v1.ExternalAuth) []int32 {
+	if len(exAuth.AuthServicePorts) > 0 {
+		ports := make([]int32, 0, len(exAuth.AuthServicePorts))
+		for _, port := range exAuth.AuthServicePorts {
 			if port > 0 && port <= math.MaxInt32 {
 				ports = append(ports, int32(port))
 			}
 		}
 		return ports
 	}
-	if p.Spec.ExternalAuth.SSLEnabled {
+	if exAuth.SSLEnabled {
 		return []int32{443}
 	}
 	return []int32{80}
@@ -3341,10 +3587,10 @@ func externalAuthFallbackPorts(p *conf_v1.Policy) []int32 {
 // collectAuthPorts returns the list of ports to resolve for an ExternalAuth policy.
 // If AuthServicePorts is specified on the policy, those are used; otherwise the ports
 // are read from the Kubernetes Service definition.
-func collectAuthPorts(p *conf_v1.Policy, svc *api_v1.Service) []int32 {
-	if len(p.Spec.ExternalAuth.AuthServicePorts) > 0 {
-		ports := make([]int32, 0, len(p.Spec.ExternalAuth.AuthServicePorts))
-		for _, port := range p.Spec.ExternalAuth.AuthServicePorts {
+func collectAuthPorts(exAuth *conf_v1.ExternalAuth, svc *api_v1.Service) []int32 {
+	if len(exAuth.AuthServicePorts) > 0 {
+		ports := make([]int32, 0, len(exAuth.AuthServicePorts))
+		for _, port := range exAuth.AuthServicePorts {
 			if port > 0 && port <= math.MaxInt32 {
 				ports = append(ports, int32(port))
 			}
@@ -3401,6 +3647,14 @@ func (lbc *LoadBalancerController) getAllPolicies() []*conf_v1.Policy {
 	return policies
 }
 
+// getDefaultPolicies returns the default policies of the GlobalConfiguration.
+func (lbc *LoadBalancerController) getDefaultPolicies() *defaultPolicies {
+	if lbc.configuration == nil {
+		return nil
+	}
+	return lbc.configuration.GetDefaultPolicies()
+}
+
 func (lbc *LoadBalancerController) getPolicies(policies []conf_v1.PolicyReference, ownerNamespace string) ([]*conf_v1.Policy, []error) {
 	var result []*conf_v1.Policy
 	var errors []error
@@ -3616,11 +3870,12 @@ func (lbc *LoadBalancerController) addOIDCTrustedCertSecretRefs(secretRefs map[s
 
 func (lbc *LoadBalancerController) addExternalAuthTrustedCertSecretRefs(secretRefs map[string]*secrets.SecretReference, policies []*conf_v1.Policy) error {
 	for _, pol := range policies {
-		if pol.Spec.ExternalAuth == nil {
+		exAuth := configs.GetExternalAuthForPolicy(pol)
+		if exAuth == nil {
 			continue
 		}
-		if pol.Spec.ExternalAuth.TrustedCertSecret != "" {
-			secretNS, secretName := configs.ParseServiceReference(pol.Spec.ExternalAuth.TrustedCertSecret, pol.Namespace)
+		if exAuth.TrustedCertSecret != "" {
+			secretNS, secretName := configs.ParseServiceReference(exAuth.TrustedCertSecret, pol.Namespace)
 			secretKey := fmt.Sprintf("%v/%v", secretNS, secretName)
 			secretRef := lbc.secretStore.GetSecret(secretKey)
 
@@ -3654,6 +3909,42 @@ func (lbc *LoadBalancerController) addAPIKeySecretRefs(secretRefs map[string]*se
 	return nil
 }
 
+func (lbc *LoadBalancerController) addIntrospectionSecretRefs(secretRefs map[string]*secrets.SecretReference, policies []*conf_v1.Policy) error {
+	for _, pol := range policies {
+		if pol.Spec.Introspection == nil {
+			continue
+		}
+
+		secretKey := fmt.Sprintf("%v/%v", pol.Namespace, pol.Spec.Introspection.ClientSecret)
+		secretRef := lbc.secretStore.GetSecret(secretKey)
+
+		secretRefs[secretKey] = secretRef
+
+		if secretRef.Error != nil {
+			return secretRef.Error
+		}
+	}
+	return nil
+}
+
+func (lbc *LoadBalancerController) addHMACSecretRefs(secretRefs map[string]*secrets.SecretReference, policies []*conf_v1.Policy) error {
+	for _, pol := range policies {
+		if pol.Spec.HMAC == nil {
+			continue
+		}
+
+		secretKey := fmt.Sprintf("%v/%v", pol.Namespace, pol.Spec.HMAC.Secret)
+		secretRef := lbc.secretStore.GetSecret(secretKey)
+
+		secretRefs[secretKey] = secretRef
+
+		if secretRef.Error != nil {
+			return secretRef.Error
+		}
+	}
+	return nil
+}
+
 func (lbc *LoadBalancerController) getPoliciesForSecret(secretNamespace string, secretName string) []*conf_v1.Policy {
 	return findPoliciesForSecret(lbc.getAllPolicies(), secretNamespace, secretName)
 }
@@ -3685,6 +3976,15 @@ func findPoliciesForSecret(policies []*conf_v1.Policy, secretNamespace string, s
 			}
 		} else if pol.Spec.APIKey != nil && pol.Spec.APIKey.ClientSecret == secretName && pol.Namespace == secretNamespace {
 			res = append(res, pol)
+		} else if pol.Spec.Introspection != nil && pol.Spec.Introspection.ClientSecret == secretName && pol.Namespace == secretNamespace {
+			res = append(res, pol)
+		} else if pol.Spec.HMAC != nil && pol.Spec.HMAC.Secret == secretName && pol.Namespace == secretNamespace {
+			res = append(res, pol)
+		} else if pol.Spec.Introspection != nil && pol.Spec.Introspection.TrustedCertSecret != "" {
+			introspectionNs, introspectionName := configs.ParseResourceReference(pol.Spec.Introspection.TrustedCertSecret, pol.Namespace)
+			if introspectionName == secretName && introspectionNs == secretNamespace {
+				res = append(res, pol)
+			}
 		}
 	}
 
@@ -4105,6 +4405,14 @@ func (lbc *LoadBalancerController) getServiceFromInformer(namespace, serviceName
 
 // HasCorrectIngressClass checks if resource ingress class annotation (if exists) or ingressClass string for VS/VSR is matching with Ingress Controller class
 func (lbc *LoadBalancerController) HasCorrectIngressClass(obj interface{}) bool {
+	if gw, ok := obj.(*gatewayv1.Gateway); ok {
+		// a Gateway is handled only if its GatewayClass references the Ingress Controller
+		return string(gw.Spec.GatewayClassName) == lbc.ingressClass && lbc.getGatewayClass() != nil
+	}
+	return hasCorrectIngressClass(obj, lbc.ingressClass, lbc.Logger)
+}
+
+func hasCorrectIngressClass(obj interface{}, ingressClass string, logger *slog.Logger) bool {
 	var class string
 	switch obj := obj.(type) {
 	case *conf_v1.VirtualServer:
@@ -4121,15 +4429,15 @@ func (lbc *LoadBalancerController) HasCorrectIngressClass(obj interface{}) bool
 			class = *obj.Spec.IngressClassName
 		} else if class != "" {
 			// the annotation takes precedence over the field
-			nl.Warnf(lbc.Logger, "Using the DEPRECATED annotation 'kubernetes.io/ingress.class'. The 'ingressClassName' field will be ignored.")
+			nl.Warnf(logger, "Using the DEPRECATED annotation 'kubernetes.io/ingress.class'. The 'ingressClassName' field will be ignored.")
 		}
-		return class == lbc.ingressClass
+		return class == ingressClass
 
 	default:
 		return false
 	}
 
-	return class == lbc.ingressClass || class == ""
+	return class == ingressClass || class == ""
 }
 
 // isHealthCheckEnabled checks if health checks are enabled so we can only query pods if enabled.
@@ -4157,6 +4465,15 @@ func (lbc *LoadBalancerController) syncSVIDRotation(svidResponse *workloadapi.X5
 	}
 }
 
+func (lbc *LoadBalancerController) syncOutlierEjections(upstreams []string) {
+	lbc.syncLock.Lock()
+	defer lbc.syncLock.Unlock()
+	nl.Debugf(lbc.Logger, "Updating ejected servers of upstreams %v", upstreams)
+	if _, err := lbc.configurator.UpdateOutlierEjections(upstreams); err != nil {
+		nl.Errorf(lbc.Logger, "failed to update ejected servers of upstreams %v: %v", upstreams, err)
+	}
+}
+
 // IsNginxReady returns ready status of NGINX
 func (lbc *LoadBalancerController) IsNginxReady() bool {
 	return lbc.isNginxReady
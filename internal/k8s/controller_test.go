@@ -119,9 +119,10 @@ func createIngressProcessChangesController(t *testing.T, manager nginx.Manager)
 	}
 
 	return &LoadBalancerController{
-		configurator: createTestPolicySyncConfigurator(t, manager),
-		recorder:     record.NewFakeRecorder(100),
-		secretStore:  secrets.NewEmptyFakeSecretsStore(),
+		configurator:     createTestPolicySyncConfigurator(t, manager),
+		recorder:         record.NewFakeRecorder(100),
+		secretStore:      secrets.NewEmptyFakeSecretsStore(),
+		metricsCollector: collectors.NewControllerFakeCollector(),
 		namespacedInformers: map[string]*namespacedInformer{
 			"default": {
 				ingressLister: storeToIngressLister{Store: &fakeStore{FakeCustomStore: *ingressStore}},
@@ -2188,7 +2189,7 @@ func TestGetPoliciesGlobalWatch(t *testing.T) {
 
 	expectedPolicies := []*conf_v1.Policy{validPolicy}
 	expectedErrors := []error{
-		errors.New("policy default/invalid-policy is invalid: spec: Invalid value: \"\": must specify exactly one of: `accessControl`, `rateLimit`, `ingressMTLS`, `egressMTLS`, `basicAuth`, `apiKey`, `cache`, `cors`, `externalAuth`, `jwt`, `oidc`, `waf`"),
+		errors.New("policy default/invalid-policy is invalid: spec: Invalid value: \"\": must specify exactly one of: `accessControl`, `rateLimit`, `ingressMTLS`, `egressMTLS`, `basicAuth`, `apiKey`, `hmac`, `cache`, `cors`, `headers`, `externalAuth`, `connectionLimit`, `tracing`, `jwt`, `oidc`, `waf`, `introspection`, `geo`"),
 		errors.New("policy nginx-ingress/valid-policy doesn't exist"),
 		errors.New("failed to get policy nginx-ingress/some-policy: GetByKey error"),
 		errors.New("referenced policy default/valid-policy-ingress-class has incorrect ingress class: test-class (controller ingress class: )"),
@@ -2286,7 +2287,7 @@ func TestGetPoliciesNamespacedWatch(t *testing.T) {
 
 	expectedPolicies := []*conf_v1.Policy{validPolicy}
 	expectedErrors := []error{
-		errors.New("policy default/invalid-policy is invalid: spec: Invalid value: \"\": must specify exactly one of: `accessControl`, `rateLimit`, `ingressMTLS`, `egressMTLS`, `basicAuth`, `apiKey`, `cache`, `cors`, `externalAuth`, `jwt`, `oidc`, `waf`"),
+		errors.New("policy default/invalid-policy is invalid: spec: Invalid value: \"\": must specify exactly one of: `accessControl`, `rateLimit`, `ingressMTLS`, `egressMTLS`, `basicAuth`, `apiKey`, `hmac`, `cache`, `cors`, `headers`, `externalAuth`, `connectionLimit`, `tracing`, `jwt`, `oidc`, `waf`, `introspection`, `geo`"),
 		errors.New("failed to get namespace nginx-ingress"),
 		errors.New("referenced policy default/valid-policy-ingress-class has incorrect ingress class: test-class (controller ingress class: )"),
 	}
@@ -2484,6 +2485,7 @@ func TestSyncPolicy_UpdatesMergeableIngressesWhenPolicyChanges(t *testing.T) {
 	cnf := createTestPolicySyncConfigurator(t, manager)
 
 	lbc := LoadBalancerController{
+		metricsCollector: collectors.NewControllerFakeCollector(),
 		namespacedInformers: map[string]*namespacedInformer{
 			"default": {
 				policyLister: policyLister,
@@ -2993,6 +2995,30 @@ func TestFindPoliciesForSecret(t *testing.T) {
 			},
 		},
 	}
+	introspectionPol := &conf_v1.Policy{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "introspection-policy",
+			Namespace: "default",
+		},
+		Spec: conf_v1.PolicySpec{
+			Introspection: &conf_v1.Introspection{
+				ClientSecret:      "introspection-secret",
+				TrustedCertSecret: "other-ns/introspection-ca",
+			},
+		},
+	}
+	hmacPol := &conf_v1.Policy{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "hmac-policy",
+			Namespace: "default",
+		},
+		Spec: conf_v1.PolicySpec{
+			HMAC: &conf_v1.HMAC{
+				Secret: "hmac-secret",
+			},
+		},
+	}
+
 	extAuthCrossNsPol := &conf_v1.Policy{
 		ObjectMeta: meta_v1.ObjectMeta{
 			Name:      "external-auth-cross-ns-policy",
@@ -3145,6 +3171,27 @@ func TestFindPoliciesForSecret(t *testing.T) {
 			expected:        []*conf_v1.Policy{extAuthCrossNsPol},
 			msg:             "Find cross-namespace external auth policy, ignore other types",
 		},
+		{
+			policies:        []*conf_v1.Policy{jwtPol1, introspectionPol},
+			secretNamespace: "default",
+			secretName:      "introspection-secret",
+			expected:        []*conf_v1.Policy{introspectionPol},
+			msg:             "Find introspection policy by client secret, ignore other types",
+		},
+		{
+			policies:        []*conf_v1.Policy{introspectionPol},
+			secretNamespace: "other-ns",
+			secretName:      "introspection-ca",
+			expected:        []*conf_v1.Policy{introspectionPol},
+			msg:             "Find introspection policy with cross-namespace trusted cert secret reference",
+		},
+		{
+			policies:        []*conf_v1.Policy{jwtPol1, hmacPol},
+			secretNamespace: "default",
+			secretName:      "hmac-secret",
+			expected:        []*conf_v1.Policy{hmacPol},
+			msg:             "Find HMAC policy, ignore other types",
+		},
 	}
 	for _, test := range tests {
 		result := findPoliciesForSecret(test.policies, test.secretNamespace, test.secretName)
@@ -3930,9 +3977,11 @@ func TestPreSyncSecrets(t *testing.T) {
 	lbc := LoadBalancerController{
 		isNginxPlus:         true,
 		secretStore:         secrets.NewEmptyFakeSecretsStore(),
+		metricsCollector:    collectors.NewControllerFakeCollector(),
 		namespacedInformers: nsi,
 		Logger:              nl.LoggerFromContext(context.Background()),
 	}
+	lbc.certificateInspector = secrets.NewCertificateInspector(lbc.secretStore, nil)
 
 	lbc.preSyncSecrets()
 
@@ -4744,3 +4793,56 @@ func TestGenerateExternalAuthEndpoints(t *testing.T) {
 		})
 	}
 }
+
+func TestRecordResourceStateKeepsMostSevereSyncOutcome(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		states   []string
+		errs     []error
+		expected string
+		msg      string
+	}{
+		{
+			states:   []string{conf_v1.StateValid, conf_v1.StateValid},
+			errs:     []error{nil, nil},
+			expected: syncOutcomeValid,
+			msg:      "valid resources",
+		},
+		{
+			states:   []string{conf_v1.StateWarning, conf_v1.StateValid},
+			errs:     []error{nil, nil},
+			expected: syncOutcomeWarning,
+			msg:      "warning before valid",
+		},
+		{
+			states:   []string{conf_v1.StateInvalid, conf_v1.StateWarning},
+			errs:     []error{nil, nil},
+			expected: syncOutcomeInvalid,
+			msg:      "invalid before warning",
+		},
+		{
+			states:   []string{conf_v1.StateInvalid, conf_v1.StateValid},
+			errs:     []error{errors.New("reload failed"), nil},
+			expected: syncOutcomeError,
+			msg:      "operation error",
+		},
+		{
+			states:   []string{""},
+			errs:     []error{nil},
+			expected: syncOutcomeValid,
+			msg:      "no state",
+		},
+	}
+	for _, test := range tests {
+		lbc := LoadBalancerController{
+			metricsCollector: collectors.NewControllerFakeCollector(),
+			syncMetrics:      syncMetrics{outcome: syncOutcomeValid},
+		}
+		for i, state := range test.states {
+			lbc.recordResourceState(virtualserver, "default/cafe", state, test.errs[i])
+		}
+		if lbc.syncMetrics.outcome != test.expected {
+			t.Errorf("recordResourceState() resulted in the outcome %q, expected %q for the case of %s", lbc.syncMetrics.outcome, test.expected, test.msg)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package k8s
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/nginx/kubernetes-ingress/internal/configs"
+	conf_v1 "github.com/nginx/kubernetes-ingress/pkg/apis/configuration/v1"
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// defaultPolicies resolves the default policies of the GlobalConfiguration for the resources of a namespace.
+type defaultPolicies struct {
+	policies []conf_v1.DefaultPolicies
+	// namespaceLabels are the labels of the namespaces, used to match the namespace selectors.
+	namespaceLabels map[string]labels.Set
+}
+
+func newDefaultPolicies() *defaultPolicies {
+	return &defaultPolicies{
+		namespaceLabels: make(map[string]labels.Set),
+	}
+}
+
+// clone returns a copy of the default policies that is not affected by the later updates.
+func (dp *defaultPolicies) clone() *defaultPolicies {
+	return &defaultPolicies{
+		policies:        dp.policies,
+		namespaceLabels: maps.Clone(dp.namespaceLabels),
+	}
+}
+
+// forNamespace returns the default policies for a resource in the namespace. The namespaces of the returned
+// policy references are always set. If optOut is true, the default policies that allow opting out are skipped.
+func (dp *defaultPolicies) forNamespace(namespace string, optOut bool) []conf_v1.PolicyReference {
+	if dp == nil {
+		return nil
+	}
+
+	var result []conf_v1.PolicyReference
+	seen := make(map[string]bool)
+
+	for _, p := range dp.policies {
+		if optOut && p.AllowOptOut {
+			continue
+		}
+		if !dp.matchesNamespace(p, namespace) {
+			continue
+		}
+
+		for _, ref := range p.Policies {
+			polNamespace := ref.Namespace
+			if polNamespace == "" {
+				polNamespace = namespace
+			}
+
+			key := fmt.Sprintf("%s/%s", polNamespace, ref.Name)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			result = append(result, conf_v1.PolicyReference{Name: ref.Name, Namespace: polNamespace})
+		}
+	}
+
+	return result
+}
+
+// hasNamespaceSelector returns true if any of the default policies selects the namespaces by their labels.
+func (dp *defaultPolicies) hasNamespaceSelector() bool {
+	return slices.ContainsFunc(dp.policies, func(p conf_v1.DefaultPolicies) bool {
+		return len(p.Namespaces) == 0 && p.NamespaceSelector != nil
+	})
+}
+
+func (dp *defaultPolicies) matchesNamespace(p conf_v1.DefaultPolicies, namespace string) bool {
+	if len(p.Namespaces) > 0 {
+		return slices.Contains(p.Namespaces, namespace)
+	}
+
+	if p.NamespaceSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(p.NamespaceSelector)
+		if err != nil {
+			return false
+		}
+		return selector.Matches(dp.namespaceLabels[namespace])
+	}
+
+	return true
+}
+
+func (dp *defaultPolicies) forVirtualServer(vs *conf_v1.VirtualServer) []conf_v1.PolicyReference {
+	return dp.forNamespace(vs.Namespace, vs.Spec.DisableDefaultPolicies)
+}
+
+func (dp *defaultPolicies) forVirtualServerRoute(vsr *conf_v1.VirtualServerRoute) []conf_v1.PolicyReference {
+	return dp.forNamespace(vsr.Namespace, vsr.Spec.DisableDefaultPolicies)
+}
+
+func (dp *defaultPolicies) forIngress(ing *networking.Ingress) []conf_v1.PolicyReference {
+	optOut := false
+	if value, exists := ing.Annotations[configs.DisableDefaultPoliciesAnnotation]; exists {
+		optOut, _ = configs.ParseBool(value)
+	}
+
+	return dp.forNamespace(ing.Namespace, optOut)
+}
+
+// excludePolicies returns the policy references that are not referenced in the excluded ones.
+func excludePolicies(policies []conf_v1.PolicyReference, excluded []conf_v1.PolicyReference, excludedNamespace string) []conf_v1.PolicyReference {
+	var result []conf_v1.PolicyReference
+
+	for _, p := range policies {
+		if !isPolicyReferenced(excluded, excludedNamespace, p.Namespace, p.Name) {
+			result = append(result, p)
+		}
+	}
+
+	return result
+}
+
+// defaultPoliciesMessage returns the part of the status message that lists the default policies applied to a resource.
+func defaultPoliciesMessage(policies []conf_v1.PolicyReference) string {
+	if len(policies) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(policies))
+	for _, p := range policies {
+		keys = append(keys, fmt.Sprintf("%s/%s", p.Namespace, p.Name))
+	}
+
+	return fmt.Sprintf(" with default policies %s", strings.Join(keys, ", "))
+}
@@ -0,0 +1,241 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	nl "github.com/nginx/kubernetes-ingress/internal/logger"
+	conf_v1 "github.com/nginx/kubernetes-ingress/pkg/apis/configuration/v1"
+	api_v1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestDefaultPoliciesForNamespace(t *testing.T) {
+	t.Parallel()
+	dp := &defaultPolicies{
+		policies: []conf_v1.DefaultPolicies{
+			{
+				Policies: []conf_v1.PolicyReference{{Name: "waf", Namespace: "security"}},
+			},
+			{
+				Policies:   []conf_v1.PolicyReference{{Name: "access-control"}},
+				Namespaces: []string{"tenant-a"},
+			},
+			{
+				Policies:          []conf_v1.PolicyReference{{Name: "rate-limit", Namespace: "security"}, {Name: "waf", Namespace: "security"}},
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "true"}},
+				AllowOptOut:       true,
+			},
+		},
+		namespaceLabels: map[string]labels.Set{
+			"tenant-a": {"tenant": "true"},
+			"tenant-b": {"tenant": "false"},
+		},
+	}
+
+	tests := []struct {
+		namespace string
+		optOut    bool
+		expected  []conf_v1.PolicyReference
+		msg       string
+	}{
+		{
+			namespace: "tenant-a",
+			expected: []conf_v1.PolicyReference{
+				{Name: "waf", Namespace: "security"},
+				{Name: "access-control", Namespace: "tenant-a"},
+				{Name: "rate-limit", Namespace: "security"},
+			},
+			msg: "namespace selected by name and labels",
+		},
+		{
+			namespace: "tenant-a",
+			optOut:    true,
+			expected: []conf_v1.PolicyReference{
+				{Name: "waf", Namespace: "security"},
+				{Name: "access-control", Namespace: "tenant-a"},
+			},
+			msg: "resource opted out",
+		},
+		{
+			namespace: "tenant-b",
+			expected: []conf_v1.PolicyReference{
+				{Name: "waf", Namespace: "security"},
+			},
+			msg: "namespace labels not matching the selector",
+		},
+		{
+			namespace: "default",
+			expected: []conf_v1.PolicyReference{
+				{Name: "waf", Namespace: "security"},
+			},
+			msg: "namespace without labels",
+		},
+	}
+
+	for _, test := range tests {
+		result := dp.forNamespace(test.namespace, test.optOut)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("forNamespace() returned unexpected result for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestDefaultPoliciesForResources(t *testing.T) {
+	t.Parallel()
+	dp := &defaultPolicies{
+		policies: []conf_v1.DefaultPolicies{
+			{
+				Policies:    []conf_v1.PolicyReference{{Name: "rate-limit"}},
+				AllowOptOut: true,
+			},
+		},
+	}
+	expected := []conf_v1.PolicyReference{{Name: "rate-limit", Namespace: "default"}}
+
+	vs := &conf_v1.VirtualServer{ObjectMeta: metav1.ObjectMeta{Namespace: "default"}}
+	if diff := cmp.Diff(expected, dp.forVirtualServer(vs)); diff != "" {
+		t.Errorf("forVirtualServer() returned unexpected result (-want +got):\n%s", diff)
+	}
+	vs.Spec.DisableDefaultPolicies = true
+	if result := dp.forVirtualServer(vs); result != nil {
+		t.Errorf("forVirtualServer() returned %v but expected nil for the case of an opted out VirtualServer", result)
+	}
+
+	vsr := &conf_v1.VirtualServerRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "default"}}
+	if diff := cmp.Diff(expected, dp.forVirtualServerRoute(vsr)); diff != "" {
+		t.Errorf("forVirtualServerRoute() returned unexpected result (-want +got):\n%s", diff)
+	}
+	vsr.Spec.DisableDefaultPolicies = true
+	if result := dp.forVirtualServerRoute(vsr); result != nil {
+		t.Errorf("forVirtualServerRoute() returned %v but expected nil for the case of an opted out VirtualServerRoute", result)
+	}
+
+	ing := &networking.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "default"}}
+	if diff := cmp.Diff(expected, dp.forIngress(ing)); diff != "" {
+		t.Errorf("forIngress() returned unexpected result (-want +got):\n%s", diff)
+	}
+	ing.Annotations = map[string]string{"nginx.org/disable-default-policies": "true"}
+	if result := dp.forIngress(ing); result != nil {
+		t.Errorf("forIngress() returned %v but expected nil for the case of an opted out Ingress", result)
+	}
+}
+
+func TestDefaultPoliciesMessage(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		policies []conf_v1.PolicyReference
+		expected string
+	}{
+		{
+			policies: nil,
+			expected: "",
+		},
+		{
+			policies: []conf_v1.PolicyReference{{Name: "waf", Namespace: "security"}, {Name: "rate-limit", Namespace: "default"}},
+			expected: " with default policies security/waf, default/rate-limit",
+		},
+	}
+
+	for _, test := range tests {
+		result := defaultPoliciesMessage(test.policies)
+		if result != test.expected {
+			t.Errorf("defaultPoliciesMessage(%v) returned %q but expected %q", test.policies, result, test.expected)
+		}
+	}
+}
+
+func TestDefaultPoliciesHasNamespaceSelector(t *testing.T) {
+	t.Parallel()
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "true"}}
+
+	tests := []struct {
+		policies []conf_v1.DefaultPolicies
+		expected bool
+		msg      string
+	}{
+		{
+			policies: nil,
+			expected: false,
+			msg:      "no default policies",
+		},
+		{
+			policies: []conf_v1.DefaultPolicies{
+				{Policies: []conf_v1.PolicyReference{{Name: "waf"}}, Namespaces: []string{"tenant-a"}},
+			},
+			expected: false,
+			msg:      "namespaces selected by name",
+		},
+		{
+			policies: []conf_v1.DefaultPolicies{
+				{Policies: []conf_v1.PolicyReference{{Name: "waf"}}, Namespaces: []string{"tenant-a"}, NamespaceSelector: selector},
+			},
+			expected: false,
+			msg:      "namespace selector ignored for namespaces selected by name",
+		},
+		{
+			policies: []conf_v1.DefaultPolicies{
+				{Policies: []conf_v1.PolicyReference{{Name: "waf"}}},
+				{Policies: []conf_v1.PolicyReference{{Name: "rate-limit"}}, NamespaceSelector: selector},
+			},
+			expected: true,
+			msg:      "namespaces selected by labels",
+		},
+	}
+
+	for _, test := range tests {
+		dp := &defaultPolicies{policies: test.policies}
+		if result := dp.hasNamespaceSelector(); result != test.expected {
+			t.Errorf("hasNamespaceSelector() returned %v but expected %v for the case of %s", result, test.expected, test.msg)
+		}
+	}
+}
+
+func TestStartDefaultPoliciesNamespaceWatcher(t *testing.T) {
+	t.Parallel()
+	ns := &api_v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "tenant-a", Labels: map[string]string{"tenant": "true"}}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	lbc := &LoadBalancerController{
+		client: fake.NewClientset(ns),
+		ctx:    ctx,
+		Logger: nl.LoggerFromContext(ctx),
+	}
+	lbc.syncQueue = newTaskQueue(lbc.Logger, func(task) {})
+
+	if err := lbc.startDefaultPoliciesNamespaceWatcher(createDefaultPoliciesNamespaceHandlers(lbc)); err != nil {
+		t.Fatal(err)
+	}
+	lister := lbc.namespaceLister
+	if lister == nil {
+		t.Fatal("startDefaultPoliciesNamespaceWatcher() didn't set the namespace lister")
+	}
+
+	if err := lbc.startDefaultPoliciesNamespaceWatcher(createDefaultPoliciesNamespaceHandlers(lbc)); err != nil {
+		t.Fatal(err)
+	}
+	if lbc.namespaceLister != lister {
+		t.Error("startDefaultPoliciesNamespaceWatcher() started a second watcher")
+	}
+
+	deadline := time.Now().Add(10 * time.Second)
+	for lbc.syncQueue.Len() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("the namespace was not added to the sync queue")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if _, exists, err := lister.GetByKey("tenant-a"); !exists || err != nil {
+		t.Errorf("namespace lister didn't return the namespace: exists %v, err %v", exists, err)
+	}
+
+	if len(lbc.cacheSyncs) != 0 {
+		t.Errorf("startDefaultPoliciesNamespaceWatcher() added %d cache syncs, expected none", len(lbc.cacheSyncs))
+	}
+}
@@ -0,0 +1,490 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	nl "github.com/nginx/kubernetes-ingress/internal/logger"
+	api_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gateway_informers "sigs.k8s.io/gateway-api/pkg/client/informers/externalversions"
+)
+
+// gatewayAPIObject is implemented by the pointers to the Gateway API resources handled by the Ingress Controller.
+type gatewayAPIObject interface {
+	runtime.Object
+	meta_v1.Object
+}
+
+func createGatewayAPIHandlers[T gatewayAPIObject](lbc *LoadBalancerController, kind string) cache.ResourceEventHandlerFuncs {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			res := obj.(T)
+			nl.Debugf(lbc.Logger, "Adding %s: %v", kind, res.GetName())
+			lbc.AddSyncQueue(res)
+		},
+		DeleteFunc: func(obj interface{}) {
+			res, isRes := obj.(T)
+			if !isRes {
+				deletedState, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					nl.Debugf(lbc.Logger, "Error received unexpected object: %v", obj)
+					return
+				}
+				res, ok = deletedState.Obj.(T)
+				if !ok {
+					nl.Debugf(lbc.Logger, "Error DeletedFinalStateUnknown contained non-%s object: %v", kind, deletedState.Obj)
+					return
+				}
+			}
+			nl.Debugf(lbc.Logger, "Removing %s: %v", kind, res.GetName())
+			lbc.AddSyncQueue(res)
+		},
+		UpdateFunc: func(old, cur interface{}) {
+			curRes := cur.(T)
+			if !reflect.DeepEqual(old, cur) {
+				nl.Debugf(lbc.Logger, "%s %v changed, syncing", kind, curRes.GetName())
+				lbc.AddSyncQueue(curRes)
+			}
+		},
+	}
+}
+
+// addGatewayAPIHandlers sets up informers and event handlers for the Gateway API resources
+// when the Gateway API support is enabled.
+func (lbc *LoadBalancerController) addGatewayAPIHandlers(nsi *namespacedInformer, ns string) error {
+	if !lbc.enableGatewayAPI {
+		return nil
+	}
+	nsi.isGatewayAPIEnabled = true
+	nsi.gatewaySharedInformerFactory = gateway_informers.NewSharedInformerFactoryWithOptions(lbc.gatewayClient, lbc.resync, gateway_informers.WithNamespace(ns))
+
+	informers := []struct {
+		kind     string
+		informer cache.SharedIndexInformer
+		handlers cache.ResourceEventHandlerFuncs
+		lister   *cache.Store
+	}{
+		{
+			kind:     gatewayKind,
+			informer: nsi.gatewaySharedInformerFactory.Gateway().V1().Gateways().Informer(),
+			handlers: createGatewayAPIHandlers[*gatewayv1.Gateway](lbc, gatewayKind),
+			lister:   &nsi.gatewayLister,
+		},
+		{
+			kind:     httpRouteKind,
+			informer: nsi.gatewaySharedInformerFactory.Gateway().V1().HTTPRoutes().Informer(),
+			handlers: createGatewayAPIHandlers[*gatewayv1.HTTPRoute](lbc, httpRouteKind),
+			lister:   &nsi.httpRouteLister,
+		},
+		{
+			kind:     grpcRouteKind,
+			informer: nsi.gatewaySharedInformerFactory.Gateway().V1().GRPCRoutes().Informer(),
+			handlers: createGatewayAPIHandlers[*gatewayv1.GRPCRoute](lbc, grpcRouteKind),
+			lister:   &nsi.grpcRouteLister,
+		},
+		{
+			kind:     tlsRouteKind,
+			informer: nsi.gatewaySharedInformerFactory.Gateway().V1().TLSRoutes().Informer(),
+			handlers: createGatewayAPIHandlers[*gatewayv1.TLSRoute](lbc, tlsRouteKind),
+			lister:   &nsi.tlsRouteLister,
+		},
+		{
+			kind:     tcpRouteKind,
+			informer: nsi.gatewaySharedInformerFactory.Gateway().V1alpha2().TCPRoutes().Informer(),
+			handlers: createGatewayAPIHandlers[*gatewayv1alpha2.TCPRoute](lbc, tcpRouteKind),
+			lister:   &nsi.tcpRouteLister,
+		},
+	}
+
+	for _, i := range informers {
+		if _, err := i.informer.AddEventHandler(i.handlers); err != nil {
+			return fmt.Errorf("failed to add %s event handler for namespace %s: %w", i.kind, ns, err)
+		}
+		*i.lister = i.informer.GetStore()
+		nsi.cacheSyncs = append(nsi.cacheSyncs, i.informer.HasSynced)
+	}
+
+	return nil
+}
+
+// addGatewayClassHandler watches the GatewayClass named after the ingress class of the Ingress Controller.
+func (lbc *LoadBalancerController) addGatewayClassHandler(handlers cache.ResourceEventHandlerFuncs, name string) {
+	options := cache.InformerOptions{
+		ListerWatcher: cache.NewListWatchFromClient(
+			lbc.gatewayClient.GatewayV1().RESTClient(),
+			"gatewayclasses",
+			"",
+			fields.Set{"metadata.name": name}.AsSelector(),
+		),
+		ObjectType:   &gatewayv1.GatewayClass{},
+		ResyncPeriod: lbc.resync,
+		Handler:      handlers,
+	}
+	lbc.gatewayClassLister, lbc.gatewayClassController = cache.NewInformerWithOptions(options)
+	lbc.cacheSyncs = append(lbc.cacheSyncs, lbc.gatewayClassController.HasSynced)
+}
+
+// getGatewayClass returns the GatewayClass of the Ingress Controller, if it exists and
+// references the Ingress Controller in its controllerName.
+func (lbc *LoadBalancerController) getGatewayClass() *gatewayv1.GatewayClass {
+	if lbc.gatewayClassLister == nil {
+		return nil
+	}
+
+	obj, exists, err := lbc.gatewayClassLister.GetByKey(lbc.ingressClass)
+	if err != nil || !exists {
+		return nil
+	}
+
+	gc := obj.(*gatewayv1.GatewayClass)
+	if gc.Spec.ControllerName != IngressControllerName {
+		return nil
+	}
+
+	return gc
+}
+
+func (lbc *LoadBalancerController) syncGatewayClass(task task) {
+	key := task.Key
+	_, gcExists, err := lbc.gatewayClassLister.GetByKey(key)
+	if err != nil {
+		lbc.syncQueue.Requeue(task, err)
+		return
+	}
+
+	nl.Debugf(lbc.Logger, "Syncing GatewayClass: %v, exists: %v", key, gcExists)
+
+	if gc := lbc.getGatewayClass(); gc != nil && lbc.reportCustomResourceStatusEnabled() {
+		gcCopy := gc.DeepCopy()
+		meta.SetStatusCondition(&gcCopy.Status.Conditions, newGatewayCondition(
+			string(gatewayv1.GatewayClassConditionStatusAccepted),
+			true,
+			string(gatewayv1.GatewayClassReasonAccepted),
+			"GatewayClass is accepted",
+			gc.Generation,
+		))
+
+		if !reflect.DeepEqual(gc.Status, gcCopy.Status) {
+			_, err := lbc.gatewayClient.GatewayV1().GatewayClasses().UpdateStatus(context.TODO(), gcCopy, meta_v1.UpdateOptions{})
+			if err != nil {
+				nl.Errorf(lbc.Logger, "Error when updating the status for GatewayClass %v: %v", gc.Name, err)
+			}
+		}
+	}
+
+	// the Gateways of the class are handled only when the GatewayClass exists
+	for _, nsi := range lbc.namespacedInformers {
+		if !nsi.isGatewayAPIEnabled {
+			continue
+		}
+		for _, obj := range nsi.gatewayLister.List() {
+			gw := obj.(*gatewayv1.Gateway)
+			if string(gw.Spec.GatewayClassName) == lbc.ingressClass {
+				lbc.AddSyncQueue(gw)
+			}
+		}
+	}
+}
+
+func (lbc *LoadBalancerController) syncGatewayAPIResource(
+	task task,
+	kind string,
+	getLister func(nsi *namespacedInformer) cache.Store,
+	addOrUpdate func(obj interface{}) ([]ResourceChange, []ConfigurationProblem),
+	deleteResource func(key string) ([]ResourceChange, []ConfigurationProblem),
+) {
+	key := task.Key
+
+	ns, _, _ := cache.SplitMetaNamespaceKey(key)
+	obj, exists, err := getLister(lbc.getNamespacedInformer(ns)).GetByKey(key)
+	if err != nil {
+		lbc.syncQueue.Requeue(task, err)
+		return
+	}
+
+	var changes []ResourceChange
+	var problems []ConfigurationProblem
+
+	if !exists {
+		nl.Debugf(lbc.Logger, "Deleting %s: %v\n", kind, key)
+		changes, problems = deleteResource(key)
+	} else {
+		nl.Debugf(lbc.Logger, "Adding or Updating %s: %v\n", kind, key)
+		changes, problems = addOrUpdate(obj)
+	}
+
+	lbc.processChanges(changes)
+	lbc.processProblems(problems)
+}
+
+func (lbc *LoadBalancerController) syncGateway(task task) {
+	lbc.syncGatewayAPIResource(task, gatewayKind,
+		func(nsi *namespacedInformer) cache.Store { return nsi.gatewayLister },
+		func(obj interface{}) ([]ResourceChange, []ConfigurationProblem) {
+			return lbc.configuration.AddOrUpdateGateway(obj.(*gatewayv1.Gateway))
+		},
+		lbc.configuration.DeleteGateway,
+	)
+}
+
+func (lbc *LoadBalancerController) syncHTTPRoute(task task) {
+	lbc.syncGatewayAPIResource(task, httpRouteKind,
+		func(nsi *namespacedInformer) cache.Store { return nsi.httpRouteLister },
+		func(obj interface{}) ([]ResourceChange, []ConfigurationProblem) {
+			return lbc.configuration.AddOrUpdateHTTPRoute(obj.(*gatewayv1.HTTPRoute))
+		},
+		lbc.configuration.DeleteHTTPRoute,
+	)
+}
+
+func (lbc *LoadBalancerController) syncGRPCRoute(task task) {
+	lbc.syncGatewayAPIResource(task, grpcRouteKind,
+		func(nsi *namespacedInformer) cache.Store { return nsi.grpcRouteLister },
+		func(obj interface{}) ([]ResourceChange, []ConfigurationProblem) {
+			return lbc.configuration.AddOrUpdateGRPCRoute(obj.(*gatewayv1.GRPCRoute))
+		},
+		lbc.configuration.DeleteGRPCRoute,
+	)
+}
+
+func (lbc *LoadBalancerController) syncTLSRoute(task task) {
+	lbc.syncGatewayAPIResource(task, tlsRouteKind,
+		func(nsi *namespacedInformer) cache.Store { return nsi.tlsRouteLister },
+		func(obj interface{}) ([]ResourceChange, []ConfigurationProblem) {
+			return lbc.configuration.AddOrUpdateTLSRoute(obj.(*gatewayv1.TLSRoute))
+		},
+		lbc.configuration.DeleteTLSRoute,
+	)
+}
+
+func (lbc *LoadBalancerController) syncTCPRoute(task task) {
+	lbc.syncGatewayAPIResource(task, tcpRouteKind,
+		func(nsi *namespacedInformer) cache.Store { return nsi.tcpRouteLister },
+		func(obj interface{}) ([]ResourceChange, []ConfigurationProblem) {
+			return lbc.configuration.AddOrUpdateTCPRoute(obj.(*gatewayv1alpha2.TCPRoute))
+		},
+		lbc.configuration.DeleteTCPRoute,
+	)
+}
+
+// updateGatewayResourceStatusAndEvents reports the result of applying a VirtualServer or TransportServer
+// generated from a Gateway. The events are emitted on the Gateway, while the errors are remembered
+// to be reported in the Programmed condition of the Gateway.
+func (lbc *LoadBalancerController) updateGatewayResourceStatusAndEvents(gw *gatewayv1.Gateway, resourceKey string, resourceWarnings []string, warnings []string, operationErr error) {
+	gwKey := getResourceKey(&gw.ObjectMeta)
+
+	if operationErr != nil {
+		if lbc.gatewayProgrammingErrors[gwKey] == nil {
+			lbc.gatewayProgrammingErrors[gwKey] = make(map[string]string)
+		}
+		lbc.gatewayProgrammingErrors[gwKey][resourceKey] = operationErr.Error()
+	} else {
+		lbc.clearGatewayProgrammingError(gwKey, resourceKey)
+	}
+
+	allWarnings := append(append([]string{}, resourceWarnings...), warnings...)
+	if len(allWarnings) == 0 && operationErr == nil {
+		return
+	}
+
+	eventType := api_v1.EventTypeWarning
+	eventTitle := nl.EventReasonAddedOrUpdatedWithWarning
+	msg := fmt.Sprintf("Configuration for %v was added or updated", resourceKey)
+	if len(allWarnings) > 0 {
+		msg = fmt.Sprintf("%s with warning(s): %s", msg, formatWarningMessages(allWarnings))
+	}
+	if operationErr != nil {
+		eventTitle = nl.EventReasonAddedOrUpdatedWithError
+		msg = fmt.Sprintf("%s; but was not applied: %v", msg, operationErr)
+	}
+
+	lbc.recorder.Event(gw, eventType, eventTitle, msg)
+}
+
+func (lbc *LoadBalancerController) clearGatewayProgrammingError(gwKey string, resourceKey string) {
+	delete(lbc.gatewayProgrammingErrors[gwKey], resourceKey)
+	if len(lbc.gatewayProgrammingErrors[gwKey]) == 0 {
+		delete(lbc.gatewayProgrammingErrors, gwKey)
+	}
+}
+
+// gatewayAddresses returns the addresses of the Ingress Controller to be reported in the status of the Gateways.
+func (lbc *LoadBalancerController) gatewayAddresses() []gatewayv1.GatewayStatusAddress {
+	var addresses []gatewayv1.GatewayStatusAddress
+	for _, s := range lbc.statusUpdater.status {
+		if s.IP != "" {
+			addresses = append(addresses, gatewayv1.GatewayStatusAddress{
+				Type:  new(gatewayv1.IPAddressType),
+				Value: s.IP,
+			})
+		} else if s.Hostname != "" {
+			addresses = append(addresses, gatewayv1.GatewayStatusAddress{
+				Type:  new(gatewayv1.HostnameAddressType),
+				Value: s.Hostname,
+			})
+		}
+	}
+	return addresses
+}
+
+// updateGatewayAPIStatuses updates the status of the Gateways and their routes from the current Configuration.
+// Only the resources with a changed status are updated.
+func (lbc *LoadBalancerController) updateGatewayAPIStatuses() {
+	if !lbc.enableGatewayAPI || !lbc.isNginxReady || !lbc.reportCustomResourceStatusEnabled() {
+		return
+	}
+
+	addresses := lbc.gatewayAddresses()
+
+	for key, status := range lbc.configuration.GetGatewayStatuses() {
+		ns, _, _ := cache.SplitMetaNamespaceKey(key)
+		obj, exists, err := lbc.getNamespacedInformer(ns).gatewayLister.GetByKey(key)
+		if err != nil || !exists {
+			continue
+		}
+		gw := obj.(*gatewayv1.Gateway)
+
+		gwCopy := gw.DeepCopy()
+		gwCopy.Status.Addresses = addresses
+		gwCopy.Status.Listeners = status.Listeners
+		for _, c := range status.Conditions {
+			meta.SetStatusCondition(&gwCopy.Status.Conditions, c)
+		}
+		meta.SetStatusCondition(&gwCopy.Status.Conditions, lbc.gatewayProgrammedCondition(gw, key))
+
+		if reflect.DeepEqual(gw.Status, gwCopy.Status) {
+			continue
+		}
+
+		_, err = lbc.gatewayClient.GatewayV1().Gateways(gw.Namespace).UpdateStatus(context.TODO(), gwCopy, meta_v1.UpdateOptions{})
+		if err != nil {
+			nl.Errorf(lbc.Logger, "Error when updating the status for Gateway %v: %v", key, err)
+		}
+	}
+
+	for key, parents := range lbc.configuration.GetGatewayRouteStatuses() {
+		if err := lbc.updateGatewayRouteStatus(key, parents); err != nil {
+			nl.Errorf(lbc.Logger, "Error when updating the status for %v: %v", key, err)
+		}
+	}
+}
+
+func (lbc *LoadBalancerController) gatewayProgrammedCondition(gw *gatewayv1.Gateway, key string) meta_v1.Condition {
+	errs := lbc.gatewayProgrammingErrors[key]
+	if len(errs) == 0 {
+		return newGatewayCondition(string(gatewayv1.GatewayConditionProgrammed), true, string(gatewayv1.GatewayReasonProgrammed), "Gateway is programmed", gw.Generation)
+	}
+
+	var messages []string
+	for resourceKey, msg := range errs {
+		messages = append(messages, fmt.Sprintf("%s: %s", resourceKey, msg))
+	}
+	sort.Strings(messages)
+
+	return newGatewayCondition(string(gatewayv1.GatewayConditionProgrammed), false, string(gatewayv1.GatewayReasonInvalid), strings.Join(messages, "; "), gw.Generation)
+}
+
+// mergeRouteParentStatuses replaces the parent statuses of the Ingress Controller, keeping the statuses
+// reported by other controllers.
+func mergeRouteParentStatuses(existing []gatewayv1.RouteParentStatus, ours []gatewayv1.RouteParentStatus) []gatewayv1.RouteParentStatus {
+	var result []gatewayv1.RouteParentStatus
+	for _, p := range existing {
+		if p.ControllerName != IngressControllerName {
+			result = append(result, p)
+		}
+	}
+
+	for _, p := range ours {
+		for _, e := range existing {
+			if e.ControllerName == IngressControllerName && reflect.DeepEqual(e.ParentRef, p.ParentRef) {
+				// keep the transition times of the conditions that didn't change
+				conditions := slices.Clone(e.Conditions)
+				for _, c := range p.Conditions {
+					meta.SetStatusCondition(&conditions, c)
+				}
+				p.Conditions = conditions
+				break
+			}
+		}
+		result = append(result, p)
+	}
+
+	return result
+}
+
+func (lbc *LoadBalancerController) updateGatewayRouteStatus(key string, parents []gatewayv1.RouteParentStatus) error {
+	kind, nsName, _ := strings.Cut(key, "/")
+	ns, _, _ := cache.SplitMetaNamespaceKey(nsName)
+	nsi := lbc.getNamespacedInformer(ns)
+	if nsi == nil || !nsi.isGatewayAPIEnabled {
+		return nil
+	}
+
+	var lister cache.Store
+	switch kind {
+	case httpRouteKind:
+		lister = nsi.httpRouteLister
+	case grpcRouteKind:
+		lister = nsi.grpcRouteLister
+	case tlsRouteKind:
+		lister = nsi.tlsRouteLister
+	case tcpRouteKind:
+		lister = nsi.tcpRouteLister
+	default:
+		return fmt.Errorf("unknown route kind %s", kind)
+	}
+
+	obj, exists, err := lister.GetByKey(nsName)
+	if err != nil || !exists {
+		return err
+	}
+
+	var updateErr error
+	switch route := obj.(type) {
+	case *gatewayv1.HTTPRoute:
+		merged := mergeRouteParentStatuses(route.Status.Parents, parents)
+		if reflect.DeepEqual(route.Status.Parents, merged) {
+			return nil
+		}
+		routeCopy := route.DeepCopy()
+		routeCopy.Status.Parents = merged
+		_, updateErr = lbc.gatewayClient.GatewayV1().HTTPRoutes(route.Namespace).UpdateStatus(context.TODO(), routeCopy, meta_v1.UpdateOptions{})
+	case *gatewayv1.GRPCRoute:
+		merged := mergeRouteParentStatuses(route.Status.Parents, parents)
+		if reflect.DeepEqual(route.Status.Parents, merged) {
+			return nil
+		}
+		routeCopy := route.DeepCopy()
+		routeCopy.Status.Parents = merged
+		_, updateErr = lbc.gatewayClient.GatewayV1().GRPCRoutes(route.Namespace).UpdateStatus(context.TODO(), routeCopy, meta_v1.UpdateOptions{})
+	case *gatewayv1.TLSRoute:
+		merged := mergeRouteParentStatuses(route.Status.Parents, parents)
+		if reflect.DeepEqual(route.Status.Parents, merged) {
+			return nil
+		}
+		routeCopy := route.DeepCopy()
+		routeCopy.Status.Parents = merged
+		_, updateErr = lbc.gatewayClient.GatewayV1().TLSRoutes(route.Namespace).UpdateStatus(context.TODO(), routeCopy, meta_v1.UpdateOptions{})
+	case *gatewayv1alpha2.TCPRoute:
+		merged := mergeRouteParentStatuses(route.Status.Parents, parents)
+		if reflect.DeepEqual(route.Status.Parents, merged) {
+			return nil
+		}
+		routeCopy := route.DeepCopy()
+		routeCopy.Status.Parents = merged
+		_, updateErr = lbc.gatewayClient.GatewayV1alpha2().TCPRoutes(route.Namespace).UpdateStatus(context.TODO(), routeCopy, meta_v1.UpdateOptions{})
+	}
+
+	return updateErr
+}
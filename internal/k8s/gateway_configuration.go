@@ -0,0 +1,1652 @@
+package k8s
+
+import (
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"sort"
+	"strings"
+
+	conf_v1 "github.com/nginx/kubernetes-ingress/pkg/apis/configuration/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+const (
+	gatewayKind      = "Gateway"
+	gatewayClassKind = "GatewayClass"
+	httpRouteKind    = "HTTPRoute"
+	grpcRouteKind    = "GRPCRoute"
+	tlsRouteKind     = "TLSRoute"
+	tcpRouteKind     = "TCPRoute"
+)
+
+const (
+	defaultGatewayHTTPPort  = 80
+	defaultGatewayHTTPSPort = 443
+)
+
+// gatewayTranslation holds the result of the translation of the Gateway API resources into
+// VirtualServer and TransportServer resources along with the statuses of the Gateway API resources.
+type gatewayTranslation struct {
+	virtualServers   []*gatewayVirtualServer
+	passthroughTSs   []*gatewayTransportServer
+	listenerTSs      []*gatewayTransportServer
+	gatewayStatuses  map[string]*gatewayv1.GatewayStatus
+	routeStatuses    map[string][]gatewayv1.RouteParentStatus
+	gatewayByKey     map[string]*gatewayv1.Gateway
+	listenersByIndex map[string]map[gatewayv1.SectionName]int
+}
+
+func newGatewayTranslation() *gatewayTranslation {
+	return &gatewayTranslation{
+		gatewayStatuses:  make(map[string]*gatewayv1.GatewayStatus),
+		routeStatuses:    make(map[string][]gatewayv1.RouteParentStatus),
+		gatewayByKey:     make(map[string]*gatewayv1.Gateway),
+		listenersByIndex: make(map[string]map[gatewayv1.SectionName]int),
+	}
+}
+
+// gatewayVirtualServer is a VirtualServer generated for a host of a Gateway.
+type gatewayVirtualServer struct {
+	gateway    *gatewayv1.Gateway
+	vs         *conf_v1.VirtualServer
+	warnings   []string
+	listeners  []gatewayv1.SectionName
+	paths      map[string]int
+	hasDefault map[string]bool
+	upstreams  map[string]bool
+	httpBound  bool
+	httpsBound bool
+	routes     map[string]bool
+}
+
+// gatewayTransportServer is a TransportServer generated for a TLSRoute or a TCPRoute attached to a Gateway.
+type gatewayTransportServer struct {
+	gateway  *gatewayv1.Gateway
+	ts       *conf_v1.TransportServer
+	warnings []string
+	listener gatewayv1.SectionName
+}
+
+// gatewayListener is a Gateway listener along with the result of its validation.
+type gatewayListener struct {
+	listener *gatewayv1.Listener
+	status   *gatewayv1.ListenerStatus
+	valid    bool
+	// gcListener is the name of the GlobalConfiguration listener used for non-default ports.
+	gcListener string
+	tlsSecret  string
+	kinds      map[gatewayv1.Kind]bool
+	attached   map[string]bool
+}
+
+// gatewayRoute holds the fields common to all the Gateway API route kinds.
+type gatewayRoute struct {
+	kind       string
+	meta       *metav1.ObjectMeta
+	parentRefs []gatewayv1.ParentReference
+	hostnames  []gatewayv1.Hostname
+}
+
+func (r *gatewayRoute) key() string {
+	return getResourceKeyWithKind(r.kind, r.meta)
+}
+
+// gatewayRouteFragment is the part of a VirtualServer generated from an HTTPRoute or a GRPCRoute.
+type gatewayRouteFragment struct {
+	routes     []conf_v1.Route
+	hasDefault map[string]bool
+	upstreams  []conf_v1.Upstream
+	// refReason and refMessage are set when some backend references can't be resolved.
+	refReason  gatewayv1.RouteConditionReason
+	refMessage string
+}
+
+// gatewayRouteError is an error of a route that can't be translated.
+type gatewayRouteError struct {
+	reason  gatewayv1.RouteConditionReason
+	message string
+}
+
+func (e *gatewayRouteError) Error() string {
+	return e.message
+}
+
+func newUnsupportedValueError(format string, args ...interface{}) *gatewayRouteError {
+	return &gatewayRouteError{
+		reason:  gatewayv1.RouteReasonUnsupportedValue,
+		message: fmt.Sprintf(format, args...),
+	}
+}
+
+// AddOrUpdateGateway adds or updates the Gateway.
+func (c *Configuration) AddOrUpdateGateway(gw *gatewayv1.Gateway) ([]ResourceChange, []ConfigurationProblem) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	key := getResourceKey(&gw.ObjectMeta)
+
+	if !c.hasCorrectIngressClass(gw) {
+		delete(c.gateways, key)
+	} else {
+		c.gateways[key] = gw
+	}
+
+	return c.rebuildGatewayResources()
+}
+
+// DeleteGateway deletes a Gateway by the key.
+func (c *Configuration) DeleteGateway(key string) ([]ResourceChange, []ConfigurationProblem) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if _, exists := c.gateways[key]; !exists {
+		return nil, nil
+	}
+
+	delete(c.gateways, key)
+
+	return c.rebuildGatewayResources()
+}
+
+// AddOrUpdateHTTPRoute adds or updates the HTTPRoute.
+func (c *Configuration) AddOrUpdateHTTPRoute(route *gatewayv1.HTTPRoute) ([]ResourceChange, []ConfigurationProblem) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.httpRoutes[getResourceKey(&route.ObjectMeta)] = route
+
+	return c.rebuildGatewayResources()
+}
+
+// DeleteHTTPRoute deletes an HTTPRoute by the key.
+func (c *Configuration) DeleteHTTPRoute(key string) ([]ResourceChange, []ConfigurationProblem) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if _, exists := c.httpRoutes[key]; !exists {
+		return nil, nil
+	}
+
+	delete(c.httpRoutes, key)
+
+	return c.rebuildGatewayResources()
+}
+
+// AddOrUpdateGRPCRoute adds or updates the GRPCRoute.
+func (c *Configuration) AddOrUpdateGRPCRoute(route *gatewayv1.GRPCRoute) ([]ResourceChange, []ConfigurationProblem) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.grpcRoutes[getResourceKey(&route.ObjectMeta)] = route
+
+	return c.rebuildGatewayResources()
+}
+
+// DeleteGRPCRoute deletes a GRPCRoute by the key.
+func (c *Configuration) DeleteGRPCRoute(key string) ([]ResourceChange, []ConfigurationProblem) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if _, exists := c.grpcRoutes[key]; !exists {
+		return nil, nil
+	}
+
+	delete(c.grpcRoutes, key)
+
+	return c.rebuildGatewayResources()
+}
+
+// AddOrUpdateTLSRoute adds or updates the TLSRoute.
+func (c *Configuration) AddOrUpdateTLSRoute(route *gatewayv1.TLSRoute) ([]ResourceChange, []ConfigurationProblem) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.tlsRoutes[getResourceKey(&route.ObjectMeta)] = route
+
+	return c.rebuildGatewayResources()
+}
+
+// DeleteTLSRoute deletes a TLSRoute by the key.
+func (c *Configuration) DeleteTLSRoute(key string) ([]ResourceChange, []ConfigurationProblem) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if _, exists := c.tlsRoutes[key]; !exists {
+		return nil, nil
+	}
+
+	delete(c.tlsRoutes, key)
+
+	return c.rebuildGatewayResources()
+}
+
+// AddOrUpdateTCPRoute adds or updates the TCPRoute.
+func (c *Configuration) AddOrUpdateTCPRoute(route *gatewayv1alpha2.TCPRoute) ([]ResourceChange, []ConfigurationProblem) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.tcpRoutes[getResourceKey(&route.ObjectMeta)] = route
+
+	return c.rebuildGatewayResources()
+}
+
+// DeleteTCPRoute deletes a TCPRoute by the key.
+func (c *Configuration) DeleteTCPRoute(key string) ([]ResourceChange, []ConfigurationProblem) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if _, exists := c.tcpRoutes[key]; !exists {
+		return nil, nil
+	}
+
+	delete(c.tcpRoutes, key)
+
+	return c.rebuildGatewayResources()
+}
+
+// GetGatewayStatuses returns the statuses of the Gateways handled by the Ingress Controller.
+// The key of the map is namespace/name of a Gateway.
+// Listeners that lost their host or port to another resource are reported as conflicted.
+func (c *Configuration) GetGatewayStatuses() map[string]gatewayv1.GatewayStatus {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	result := make(map[string]gatewayv1.GatewayStatus)
+	for key, status := range c.gatewayTranslation.gatewayStatuses {
+		result[key] = *status.DeepCopy()
+	}
+
+	markConflicted := func(gw *gatewayv1.Gateway, listener gatewayv1.SectionName, message string) {
+		gwKey := getResourceKey(&gw.ObjectMeta)
+		status, exists := result[gwKey]
+		if !exists {
+			return
+		}
+		idx, exists := c.gatewayTranslation.listenersByIndex[gwKey][listener]
+		if !exists {
+			return
+		}
+		meta.SetStatusCondition(&status.Listeners[idx].Conditions, metav1.Condition{
+			Type:               string(gatewayv1.ListenerConditionConflicted),
+			Status:             metav1.ConditionTrue,
+			Reason:             string(gatewayv1.ListenerReasonHostnameConflict),
+			Message:            message,
+			ObservedGeneration: gw.Generation,
+		})
+	}
+
+	for _, gvs := range c.gatewayTranslation.virtualServers {
+		host := gvs.vs.Spec.Host
+		holder, exists := c.hosts[host]
+		if exists && holder.GetKeyWithKind() == getResourceKeyWithKind(virtualServerKind, &gvs.vs.ObjectMeta) {
+			continue
+		}
+		for _, l := range gvs.listeners {
+			markConflicted(gvs.gateway, l, fmt.Sprintf("Host %s is taken by another resource", host))
+		}
+	}
+
+	for _, gts := range c.gatewayTranslation.passthroughTSs {
+		host := gts.ts.Spec.Host
+		holder, exists := c.hosts[host]
+		if exists && holder.GetKeyWithKind() == getResourceKeyWithKind(transportServerKind, &gts.ts.ObjectMeta) {
+			continue
+		}
+		markConflicted(gts.gateway, gts.listener, fmt.Sprintf("Host %s is taken by another resource", host))
+	}
+
+	for _, gts := range c.gatewayTranslation.listenerTSs {
+		key := listenerHostKey{ListenerName: gts.ts.Spec.Listener.Name, Host: gts.ts.Spec.Host}
+		holder, exists := c.listenerHosts[key]
+		if exists && holder.GetKeyWithKind() == getResourceKeyWithKind(transportServerKind, &gts.ts.ObjectMeta) {
+			continue
+		}
+		markConflicted(gts.gateway, gts.listener, fmt.Sprintf("Listener %s is taken by another resource", gts.ts.Spec.Listener.Name))
+	}
+
+	return result
+}
+
+// GetGatewayRouteStatuses returns the statuses of the routes attached to the Gateways handled by the Ingress Controller.
+// The key of the map is kind/namespace/name of a route, for example, HTTPRoute/default/cafe.
+// Routes that are stored in the Configuration, but are not attached to any such Gateway, have an empty list of parents.
+func (c *Configuration) GetGatewayRouteStatuses() map[string][]gatewayv1.RouteParentStatus {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	result := make(map[string][]gatewayv1.RouteParentStatus)
+	for key, parents := range c.gatewayTranslation.routeStatuses {
+		var copied []gatewayv1.RouteParentStatus
+		for _, p := range parents {
+			copied = append(copied, *p.DeepCopy())
+		}
+		result[key] = copied
+	}
+
+	return result
+}
+
+func (c *Configuration) rebuildGatewayResources() ([]ResourceChange, []ConfigurationProblem) {
+	c.gatewayTranslation = c.translateGatewayResources()
+
+	changes, problems := c.rebuildListenerHosts()
+
+	if c.startupComplete {
+		hostChanges, hostProblems := c.rebuildHosts()
+		changes = append(changes, hostChanges...)
+		problems = append(problems, hostProblems...)
+	}
+
+	return changes, problems
+}
+
+// newGatewayVirtualServerConfiguration creates a VirtualServerConfiguration for a VirtualServer generated from
+// the Gateway API resources. Every rebuild gets a new VirtualServerConfiguration, so that the warnings added during
+// a rebuild don't leak into the next one.
+func newGatewayVirtualServerConfiguration(gvs *gatewayVirtualServer) *VirtualServerConfiguration {
+	vsc := NewVirtualServerConfiguration(gvs.vs, []*conf_v1.VirtualServerRoute{}, map[string][]string{}, slices.Clone(gvs.warnings))
+	vsc.Gateway = gvs.gateway
+	return vsc
+}
+
+func newGatewayTransportServerConfiguration(gts *gatewayTransportServer) *TransportServerConfiguration {
+	tsc := NewTransportServerConfiguration(gts.ts)
+	tsc.Gateway = gts.gateway
+	tsc.Warnings = slices.Clone(gts.warnings)
+	return tsc
+}
+
+// translateGatewayResources translates the Gateways and their routes into VirtualServers and TransportServers.
+func (c *Configuration) translateGatewayResources() *gatewayTranslation {
+	t := newGatewayTranslation()
+
+	listeners := make(map[string][]*gatewayListener)
+
+	for _, key := range getSortedGatewayAPIKeys(c.gateways) {
+		gw := c.gateways[key]
+		t.gatewayByKey[key] = gw
+		listeners[key] = c.buildGatewayListeners(gw)
+
+		status := &gatewayv1.GatewayStatus{}
+		indexes := make(map[gatewayv1.SectionName]int)
+		validListeners := 0
+		for i, l := range listeners[key] {
+			status.Listeners = append(status.Listeners, *l.status)
+			indexes[l.listener.Name] = i
+			if l.valid {
+				validListeners++
+			}
+		}
+		t.listenersByIndex[key] = indexes
+
+		if validListeners == 0 && len(gw.Spec.Listeners) > 0 {
+			status.Conditions = []metav1.Condition{
+				newGatewayCondition(string(gatewayv1.GatewayConditionAccepted), false, string(gatewayv1.GatewayReasonListenersNotValid), "None of the listeners are valid", gw.Generation),
+			}
+		} else {
+			status.Conditions = []metav1.Condition{
+				newGatewayCondition(string(gatewayv1.GatewayConditionAccepted), true, string(gatewayv1.GatewayReasonAccepted), "Gateway is accepted", gw.Generation),
+			}
+		}
+
+		t.gatewayStatuses[key] = status
+	}
+
+	vsByHost := make(map[string]*gatewayVirtualServer)
+
+	addFragment := func(gw *gatewayv1.Gateway, l *gatewayListener, host string, route *gatewayRoute, fragment *gatewayRouteFragment) {
+		vsKey := fmt.Sprintf("%s|%s", getResourceKey(&gw.ObjectMeta), host)
+		gvs, exists := vsByHost[vsKey]
+		if !exists {
+			gvs = newGatewayVirtualServer(gw, host)
+			vsByHost[vsKey] = gvs
+			t.virtualServers = append(t.virtualServers, gvs)
+		}
+		gvs.bindListener(l)
+		gvs.addFragment(route, fragment)
+	}
+
+	for _, key := range getSortedGatewayAPIKeys(c.httpRoutes) {
+		route := c.httpRoutes[key]
+		gr := &gatewayRoute{
+			kind:       httpRouteKind,
+			meta:       &route.ObjectMeta,
+			parentRefs: route.Spec.ParentRefs,
+			hostnames:  route.Spec.Hostnames,
+		}
+		fragment, err := c.translateHTTPRoute(route)
+		c.attachGatewayRoute(t, listeners, gr, fragment, err, func(gw *gatewayv1.Gateway, l *gatewayListener, host string) {
+			addFragment(gw, l, host, gr, fragment)
+		})
+	}
+
+	for _, key := range getSortedGatewayAPIKeys(c.grpcRoutes) {
+		route := c.grpcRoutes[key]
+		gr := &gatewayRoute{
+			kind:       grpcRouteKind,
+			meta:       &route.ObjectMeta,
+			parentRefs: route.Spec.ParentRefs,
+			hostnames:  route.Spec.Hostnames,
+		}
+		fragment, err := c.translateGRPCRoute(route)
+		c.attachGatewayRoute(t, listeners, gr, fragment, err, func(gw *gatewayv1.Gateway, l *gatewayListener, host string) {
+			addFragment(gw, l, host, gr, fragment)
+		})
+	}
+
+	for _, gvs := range t.virtualServers {
+		gvs.finalize()
+	}
+
+	for _, key := range getSortedGatewayAPIKeys(c.tlsRoutes) {
+		route := c.tlsRoutes[key]
+		gr := &gatewayRoute{
+			kind:       tlsRouteKind,
+			meta:       &route.ObjectMeta,
+			parentRefs: route.Spec.ParentRefs,
+			hostnames:  route.Spec.Hostnames,
+		}
+		var refs []gatewayv1.BackendRef
+		if len(route.Spec.Rules) > 0 {
+			refs = route.Spec.Rules[0].BackendRefs
+		}
+		fragment, err := c.translateStreamRoute(gr, len(route.Spec.Rules), refs)
+		c.attachGatewayRoute(t, listeners, gr, fragment, err, func(gw *gatewayv1.Gateway, l *gatewayListener, host string) {
+			ts := newGatewayTransportServer(gw, l, gr, fragment, host, conf_v1.TLSPassthroughListenerName, conf_v1.TLSPassthroughListenerProtocol)
+			t.passthroughTSs = append(t.passthroughTSs, ts)
+		})
+	}
+
+	for _, key := range getSortedGatewayAPIKeys(c.tcpRoutes) {
+		route := c.tcpRoutes[key]
+		gr := &gatewayRoute{
+			kind:       tcpRouteKind,
+			meta:       &route.ObjectMeta,
+			parentRefs: route.Spec.ParentRefs,
+		}
+		var refs []gatewayv1.BackendRef
+		if len(route.Spec.Rules) > 0 {
+			refs = route.Spec.Rules[0].BackendRefs
+		}
+		fragment, err := c.translateStreamRoute(gr, len(route.Spec.Rules), refs)
+		c.attachGatewayRoute(t, listeners, gr, fragment, err, func(gw *gatewayv1.Gateway, l *gatewayListener, host string) {
+			ts := newGatewayTransportServer(gw, l, gr, fragment, host, l.gcListener, "TCP")
+			t.listenerTSs = append(t.listenerTSs, ts)
+		})
+	}
+
+	for key, ls := range listeners {
+		for i, l := range ls {
+			l.status.AttachedRoutes = int32(len(l.attached)) //nolint:gosec // the number of routes fits into int32
+			t.gatewayStatuses[key].Listeners[i] = *l.status
+		}
+	}
+
+	return t
+}
+
+// attachGatewayRoute attaches the route to the listeners of the Gateways referenced in its parentRefs and
+// computes the route status for each of those Gateways. For every accepted listener and hostname,
+// the attach function is called.
+func (c *Configuration) attachGatewayRoute(
+	t *gatewayTranslation,
+	listeners map[string][]*gatewayListener,
+	route *gatewayRoute,
+	fragment *gatewayRouteFragment,
+	routeErr *gatewayRouteError,
+	attach func(gw *gatewayv1.Gateway, l *gatewayListener, host string),
+) {
+	routeKey := route.key()
+	t.routeStatuses[routeKey] = nil
+
+	for _, ref := range route.parentRefs {
+		if ref.Group != nil && *ref.Group != gatewayv1.GroupName {
+			continue
+		}
+		if ref.Kind != nil && *ref.Kind != gatewayKind {
+			continue
+		}
+
+		namespace := route.meta.Namespace
+		if ref.Namespace != nil {
+			namespace = string(*ref.Namespace)
+		}
+		gwKey := fmt.Sprintf("%s/%s", namespace, ref.Name)
+
+		gw, exists := t.gatewayByKey[gwKey]
+		if !exists {
+			continue
+		}
+
+		parentStatus := gatewayv1.RouteParentStatus{
+			ParentRef:      ref,
+			ControllerName: IngressControllerName,
+		}
+
+		setConditions := func(accepted bool, acceptedReason gatewayv1.RouteConditionReason, acceptedMsg string) {
+			resolvedReason := gatewayv1.RouteReasonResolvedRefs
+			resolvedMsg := "All references are resolved"
+			if fragment != nil && fragment.refReason != "" {
+				resolvedReason = fragment.refReason
+				resolvedMsg = fragment.refMessage
+			}
+			parentStatus.Conditions = []metav1.Condition{
+				newGatewayCondition(string(gatewayv1.RouteConditionAccepted), accepted, string(acceptedReason), acceptedMsg, route.meta.Generation),
+				newGatewayCondition(string(gatewayv1.RouteConditionResolvedRefs), resolvedReason == gatewayv1.RouteReasonResolvedRefs, string(resolvedReason), resolvedMsg, route.meta.Generation),
+			}
+		}
+
+		if routeErr != nil {
+			setConditions(false, routeErr.reason, routeErr.message)
+			t.routeStatuses[routeKey] = append(t.routeStatuses[routeKey], parentStatus)
+			continue
+		}
+
+		var candidates []*gatewayListener
+		sectionFound := false
+		for _, l := range listeners[gwKey] {
+			if ref.SectionName != nil && *ref.SectionName != l.listener.Name {
+				continue
+			}
+			if ref.Port != nil && *ref.Port != l.listener.Port {
+				continue
+			}
+			sectionFound = true
+			if !l.valid || !l.kinds[gatewayv1.Kind(route.kind)] {
+				continue
+			}
+			if !isRouteNamespaceAllowed(l.listener, gw.Namespace, route.meta.Namespace) {
+				continue
+			}
+			candidates = append(candidates, l)
+		}
+
+		if !sectionFound {
+			setConditions(false, gatewayv1.RouteReasonNoMatchingParent, "No listener matches the parentRef")
+			t.routeStatuses[routeKey] = append(t.routeStatuses[routeKey], parentStatus)
+			continue
+		}
+
+		if len(candidates) == 0 {
+			setConditions(false, gatewayv1.RouteReasonNotAllowedByListeners, "The route is not allowed by any listener")
+			t.routeStatuses[routeKey] = append(t.routeStatuses[routeKey], parentStatus)
+			continue
+		}
+
+		attachedToListener := false
+		for _, l := range candidates {
+			var hosts []string
+			if route.kind == tcpRouteKind {
+				// TCP routes have no hostnames
+				hosts = []string{""}
+			} else {
+				hosts = intersectGatewayHostnames(l.listener.Hostname, route.hostnames)
+			}
+
+			if len(hosts) == 0 {
+				continue
+			}
+
+			attachedToListener = true
+			l.attached[routeKey] = true
+
+			for _, h := range hosts {
+				attach(gw, l, h)
+			}
+		}
+
+		if !attachedToListener {
+			setConditions(false, gatewayv1.RouteReasonNoMatchingListenerHostname, "No hostname of the route matches the hostnames of the listeners. Note that a hostname is required")
+		} else {
+			setConditions(true, gatewayv1.RouteReasonAccepted, "The route is accepted")
+		}
+
+		t.routeStatuses[routeKey] = append(t.routeStatuses[routeKey], parentStatus)
+	}
+}
+
+// buildGatewayListeners validates the listeners of the Gateway and computes their statuses.
+func (c *Configuration) buildGatewayListeners(gw *gatewayv1.Gateway) []*gatewayListener {
+	var result []*gatewayListener
+
+	for i := range gw.Spec.Listeners {
+		l := &gw.Spec.Listeners[i]
+		gl := &gatewayListener{
+			listener: l,
+			status:   &gatewayv1.ListenerStatus{Name: l.Name, SupportedKinds: []gatewayv1.RouteGroupKind{}},
+			kinds:    make(map[gatewayv1.Kind]bool),
+			attached: make(map[string]bool),
+		}
+		result = append(result, gl)
+
+		acceptedReason, acceptedMsg := c.validateGatewayListener(gw, gl)
+		resolvedReason, resolvedMsg := gatewayv1.ListenerReasonResolvedRefs, "All references are resolved"
+
+		if acceptedReason == gatewayv1.ListenerReasonAccepted {
+			var invalidKinds []string
+			supported := supportedRouteKinds(l.Protocol)
+			if l.AllowedRoutes != nil && len(l.AllowedRoutes.Kinds) > 0 {
+				for _, k := range l.AllowedRoutes.Kinds {
+					if (k.Group == nil || *k.Group == gatewayv1.GroupName) && slices.Contains(supported, k.Kind) {
+						gl.kinds[k.Kind] = true
+					} else {
+						invalidKinds = append(invalidKinds, string(k.Kind))
+					}
+				}
+			} else {
+				for _, k := range supported {
+					gl.kinds[k] = true
+				}
+			}
+
+			for _, k := range supported {
+				if gl.kinds[k] {
+					gl.status.SupportedKinds = append(gl.status.SupportedKinds, gatewayv1.RouteGroupKind{Group: new(gatewayv1.Group(gatewayv1.GroupName)), Kind: k})
+				}
+			}
+
+			if len(invalidKinds) > 0 {
+				resolvedReason = gatewayv1.ListenerReasonInvalidRouteKinds
+				resolvedMsg = fmt.Sprintf("Unsupported route kinds: %s", strings.Join(invalidKinds, ", "))
+			}
+
+			if l.Protocol == gatewayv1.HTTPSProtocolType && gl.tlsSecret == "" {
+				resolvedReason, resolvedMsg = c.resolveGatewayListenerCertificate(gw, gl)
+			}
+		}
+
+		gl.valid = acceptedReason == gatewayv1.ListenerReasonAccepted && (resolvedReason == gatewayv1.ListenerReasonResolvedRefs || len(gl.kinds) > 0 && resolvedReason == gatewayv1.ListenerReasonInvalidRouteKinds)
+
+		programmedReason := gatewayv1.ListenerReasonProgrammed
+		programmedMsg := "Listener is programmed"
+		if !gl.valid {
+			programmedReason = gatewayv1.ListenerReasonInvalid
+			programmedMsg = "Listener is invalid"
+		}
+
+		gl.status.Conditions = []metav1.Condition{
+			newGatewayCondition(string(gatewayv1.ListenerConditionAccepted), acceptedReason == gatewayv1.ListenerReasonAccepted, string(acceptedReason), acceptedMsg, gw.Generation),
+			newGatewayCondition(string(gatewayv1.ListenerConditionResolvedRefs), resolvedReason == gatewayv1.ListenerReasonResolvedRefs, string(resolvedReason), resolvedMsg, gw.Generation),
+			newGatewayCondition(string(gatewayv1.ListenerConditionProgrammed), gl.valid, string(programmedReason), programmedMsg, gw.Generation),
+			newGatewayCondition(string(gatewayv1.ListenerConditionConflicted), false, string(gatewayv1.ListenerReasonNoConflicts), "No conflicts", gw.Generation),
+		}
+	}
+
+	return result
+}
+
+// validateGatewayListener checks that the protocol, the port and the TLS mode of the listener are supported.
+// HTTP and HTTPS listeners on the ports 80 and 443 use the default listeners of NGINX. Other ports, as well as
+// the ports of TCP listeners, must be defined in the GlobalConfiguration.
+func (c *Configuration) validateGatewayListener(gw *gatewayv1.Gateway, gl *gatewayListener) (gatewayv1.ListenerConditionReason, string) {
+	l := gl.listener
+
+	if l.AllowedRoutes != nil && l.AllowedRoutes.Namespaces != nil && l.AllowedRoutes.Namespaces.From != nil &&
+		*l.AllowedRoutes.Namespaces.From == gatewayv1.NamespacesFromSelector {
+		return gatewayv1.ListenerReasonUnsupportedValue, "allowedRoutes.namespaces.from Selector is not supported"
+	}
+
+	switch l.Protocol {
+	case gatewayv1.HTTPProtocolType, gatewayv1.HTTPSProtocolType:
+		isSSL := l.Protocol == gatewayv1.HTTPSProtocolType
+		defaultPort := defaultGatewayHTTPPort
+		if isSSL {
+			defaultPort = defaultGatewayHTTPSPort
+			if l.TLS == nil || (l.TLS.Mode != nil && *l.TLS.Mode != gatewayv1.TLSModeTerminate) {
+				return gatewayv1.ListenerReasonUnsupportedValue, "HTTPS listeners require the tls field with the Terminate mode"
+			}
+		}
+		if int(l.Port) == defaultPort {
+			return gatewayv1.ListenerReasonAccepted, "Listener is accepted"
+		}
+		for _, gcl := range c.sortedGlobalConfigurationListeners() {
+			if gcl.Protocol == conf_v1.HTTPProtocol && gcl.Ssl == isSSL && gcl.Port == int(l.Port) {
+				gl.gcListener = gcl.Name
+				return gatewayv1.ListenerReasonAccepted, "Listener is accepted"
+			}
+		}
+		return gatewayv1.ListenerReasonPortUnavailable, fmt.Sprintf("Port %d is not defined in GlobalConfiguration", l.Port)
+	case gatewayv1.TLSProtocolType:
+		if l.TLS == nil || l.TLS.Mode == nil || *l.TLS.Mode != gatewayv1.TLSModePassthrough {
+			return gatewayv1.ListenerReasonUnsupportedValue, "TLS listeners support only the Passthrough mode"
+		}
+		if !c.isTLSPassthroughEnabled {
+			return gatewayv1.ListenerReasonUnsupportedProtocol, "TLS Passthrough is not enabled"
+		}
+		return gatewayv1.ListenerReasonAccepted, "Listener is accepted"
+	case gatewayv1.TCPProtocolType:
+		for _, gcl := range c.sortedGlobalConfigurationListeners() {
+			if gcl.Protocol == "TCP" && gcl.Port == int(l.Port) {
+				gl.gcListener = gcl.Name
+				return gatewayv1.ListenerReasonAccepted, "Listener is accepted"
+			}
+		}
+		return gatewayv1.ListenerReasonPortUnavailable, fmt.Sprintf("Port %d is not defined in GlobalConfiguration", l.Port)
+	}
+
+	return gatewayv1.ListenerReasonUnsupportedProtocol, fmt.Sprintf("Protocol %s is not supported", l.Protocol)
+}
+
+func (c *Configuration) resolveGatewayListenerCertificate(gw *gatewayv1.Gateway, gl *gatewayListener) (gatewayv1.ListenerConditionReason, string) {
+	if len(gl.listener.TLS.CertificateRefs) == 0 {
+		return gatewayv1.ListenerReasonInvalidCertificateRef, "A certificate reference is required"
+	}
+
+	ref := gl.listener.TLS.CertificateRefs[0]
+	if (ref.Group != nil && *ref.Group != "") || (ref.Kind != nil && *ref.Kind != "Secret") {
+		return gatewayv1.ListenerReasonInvalidCertificateRef, "Only Secrets are supported as certificate references"
+	}
+	if ref.Namespace != nil && string(*ref.Namespace) != gw.Namespace {
+		return gatewayv1.ListenerReasonRefNotPermitted, "Secrets from other namespaces are not supported"
+	}
+
+	gl.tlsSecret = string(ref.Name)
+
+	return gatewayv1.ListenerReasonResolvedRefs, "All references are resolved"
+}
+
+func (c *Configuration) sortedGlobalConfigurationListeners() []conf_v1.Listener {
+	if c.globalConfiguration == nil {
+		return nil
+	}
+
+	listeners := slices.Clone(c.globalConfiguration.Spec.Listeners)
+	sort.Slice(listeners, func(i, j int) bool {
+		return listeners[i].Name < listeners[j].Name
+	})
+
+	return listeners
+}
+
+func supportedRouteKinds(protocol gatewayv1.ProtocolType) []gatewayv1.Kind {
+	switch protocol {
+	case gatewayv1.HTTPProtocolType, gatewayv1.HTTPSProtocolType:
+		return []gatewayv1.Kind{httpRouteKind, grpcRouteKind}
+	case gatewayv1.TLSProtocolType:
+		return []gatewayv1.Kind{tlsRouteKind}
+	case gatewayv1.TCPProtocolType:
+		return []gatewayv1.Kind{tcpRouteKind}
+	}
+
+	return nil
+}
+
+func isRouteNamespaceAllowed(l *gatewayv1.Listener, gwNamespace string, routeNamespace string) bool {
+	from := gatewayv1.NamespacesFromSame
+	if l.AllowedRoutes != nil && l.AllowedRoutes.Namespaces != nil && l.AllowedRoutes.Namespaces.From != nil {
+		from = *l.AllowedRoutes.Namespaces.From
+	}
+
+	switch from {
+	case gatewayv1.NamespacesFromAll:
+		return true
+	case gatewayv1.NamespacesFromSame:
+		return gwNamespace == routeNamespace
+	}
+
+	return false
+}
+
+// intersectGatewayHostnames returns the hostnames that match both the listener hostname and the route hostnames.
+// When a wildcard hostname matches a more specific hostname, the more specific one is returned.
+func intersectGatewayHostnames(listenerHostname *gatewayv1.Hostname, routeHostnames []gatewayv1.Hostname) []string {
+	if listenerHostname == nil || *listenerHostname == "" {
+		var result []string
+		for _, h := range routeHostnames {
+			result = append(result, string(h))
+		}
+		return result
+	}
+
+	lh := string(*listenerHostname)
+	if len(routeHostnames) == 0 {
+		return []string{lh}
+	}
+
+	var result []string
+	for _, h := range routeHostnames {
+		rh := string(h)
+		switch {
+		case rh == lh:
+			result = append(result, rh)
+		case matchesWildcardHostname(lh, rh):
+			result = append(result, rh)
+		case matchesWildcardHostname(rh, lh):
+			result = append(result, lh)
+		}
+	}
+
+	return slices.Compact(result)
+}
+
+// matchesWildcardHostname tells if the wildcard hostname, like *.example.com, matches the hostname.
+func matchesWildcardHostname(wildcard string, hostname string) bool {
+	if !strings.HasPrefix(wildcard, wildcardHostnamePrefix) {
+		return false
+	}
+
+	suffix := strings.TrimPrefix(wildcard, "*")
+	return strings.HasSuffix(hostname, suffix) && len(hostname) > len(suffix)
+}
+
+const wildcardHostnamePrefix = "*."
+
+func newGatewayCondition(condType string, status bool, reason string, message string, generation int64) metav1.Condition {
+	condStatus := metav1.ConditionFalse
+	if status {
+		condStatus = metav1.ConditionTrue
+	}
+
+	return metav1.Condition{
+		Type:               condType,
+		Status:             condStatus,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: generation,
+	}
+}
+
+// gatewayResourceName generates a name of a VirtualServer or a TransportServer for Gateway API resources.
+// The name includes underscores, so it can't clash with the name of a real resource. Because the name is used
+// in NGINX variables, it only consists of letters, digits, dashes and underscores.
+func gatewayResourceName(parts ...string) string {
+	var sanitized []string
+	for _, p := range parts {
+		s := strings.ReplaceAll(p, "*", "wildcard")
+		s = strings.ReplaceAll(s, ".", "-")
+		sanitized = append(sanitized, s)
+	}
+
+	return fmt.Sprintf("%s_%s", strings.Join(sanitized, "_"), gatewayHash(strings.Join(parts, "/")))
+}
+
+func gatewayHash(s string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(s))
+	return fmt.Sprintf("%08x", h.Sum32())
+}
+
+func newGatewayVirtualServer(gw *gatewayv1.Gateway, host string) *gatewayVirtualServer {
+	return &gatewayVirtualServer{
+		gateway: gw,
+		vs: &conf_v1.VirtualServer{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              gatewayResourceName(gw.Name, host),
+				Namespace:         gw.Namespace,
+				CreationTimestamp: gw.CreationTimestamp,
+				UID:               types.UID(fmt.Sprintf("%s/%s", gw.UID, host)),
+				Generation:        gw.Generation,
+			},
+			Spec: conf_v1.VirtualServerSpec{
+				Host: host,
+			},
+		},
+		paths:      make(map[string]int),
+		hasDefault: make(map[string]bool),
+		upstreams:  make(map[string]bool),
+		routes:     make(map[string]bool),
+	}
+}
+
+// bindListener makes the VirtualServer serve the traffic of the listener.
+// A VirtualServer can only listen on one HTTP and one HTTPS port. Additional listeners of the same protocol
+// for the same host share the port of the first one.
+func (gvs *gatewayVirtualServer) bindListener(l *gatewayListener) {
+	if !slices.Contains(gvs.listeners, l.listener.Name) {
+		gvs.listeners = append(gvs.listeners, l.listener.Name)
+	}
+
+	spec := &gvs.vs.Spec
+
+	if l.listener.Protocol == gatewayv1.HTTPSProtocolType {
+		if gvs.httpsBound {
+			return
+		}
+		gvs.httpsBound = true
+		spec.TLS = &conf_v1.TLS{Secret: l.tlsSecret}
+		if l.gcListener != "" {
+			if spec.Listener == nil {
+				spec.Listener = &conf_v1.VirtualServerListener{}
+			}
+			spec.Listener.HTTPS = l.gcListener
+		}
+		return
+	}
+
+	if gvs.httpBound {
+		return
+	}
+	gvs.httpBound = true
+	if l.gcListener != "" {
+		if spec.Listener == nil {
+			spec.Listener = &conf_v1.VirtualServerListener{}
+		}
+		spec.Listener.HTTP = l.gcListener
+	}
+}
+
+// addFragment merges the routes and the upstreams of a route into the VirtualServer. When two routes define
+// the same path, their matches are combined; if both define the default action, the older route wins.
+func (gvs *gatewayVirtualServer) addFragment(route *gatewayRoute, fragment *gatewayRouteFragment) {
+	routeKey := route.key()
+	if gvs.routes[routeKey] {
+		// the route was already added through another listener
+		return
+	}
+	gvs.routes[routeKey] = true
+
+	for _, u := range fragment.upstreams {
+		if gvs.upstreams[u.Name] {
+			continue
+		}
+		gvs.upstreams[u.Name] = true
+		gvs.vs.Spec.Upstreams = append(gvs.vs.Spec.Upstreams, u)
+	}
+
+	for _, r := range fragment.routes {
+		idx, exists := gvs.paths[r.Path]
+		if !exists {
+			gvs.paths[r.Path] = len(gvs.vs.Spec.Routes)
+			gvs.hasDefault[r.Path] = fragment.hasDefault[r.Path]
+			gvs.vs.Spec.Routes = append(gvs.vs.Spec.Routes, *r.DeepCopy())
+			continue
+		}
+
+		existing := &gvs.vs.Spec.Routes[idx]
+		existing.Matches = append(existing.Matches, r.DeepCopy().Matches...)
+
+		if !fragment.hasDefault[r.Path] {
+			continue
+		}
+
+		if gvs.hasDefault[r.Path] {
+			gvs.warnings = append(gvs.warnings, fmt.Sprintf("path %s of %s is already defined by another route", r.Path, routeKey))
+			continue
+		}
+
+		gvs.hasDefault[r.Path] = true
+		existing.Action = r.Action.DeepCopy()
+		existing.Splits = nil
+		for _, s := range r.Splits {
+			existing.Splits = append(existing.Splits, *s.DeepCopy())
+		}
+	}
+}
+
+// finalize redirects HTTP traffic to HTTPS for hosts served only by HTTPS listeners.
+func (gvs *gatewayVirtualServer) finalize() {
+	if gvs.httpsBound && !gvs.httpBound {
+		gvs.vs.Spec.TLS.Redirect = &conf_v1.TLSRedirect{Enable: true}
+	}
+}
+
+func newGatewayTransportServer(
+	gw *gatewayv1.Gateway,
+	l *gatewayListener,
+	route *gatewayRoute,
+	fragment *gatewayRouteFragment,
+	host string,
+	listenerName string,
+	protocol string,
+) *gatewayTransportServer {
+	ts := &conf_v1.TransportServer{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              gatewayResourceName(gw.Namespace, gw.Name, route.meta.Name, host),
+			Namespace:         route.meta.Namespace,
+			CreationTimestamp: route.meta.CreationTimestamp,
+			UID:               types.UID(fmt.Sprintf("%s/%s/%s", route.meta.UID, gw.UID, host)),
+			Generation:        route.meta.Generation,
+		},
+		Spec: conf_v1.TransportServerSpec{
+			Listener: conf_v1.TransportServerListener{
+				Name:     listenerName,
+				Protocol: protocol,
+			},
+			Host: host,
+		},
+	}
+
+	ts.Spec.Upstreams = transportServerUpstreams(fragment.upstreams)
+	if len(ts.Spec.Upstreams) > 0 {
+		ts.Spec.Action = &conf_v1.TransportServerAction{Pass: ts.Spec.Upstreams[0].Name}
+	}
+
+	var warnings []string
+	if fragment.refMessage != "" {
+		warnings = append(warnings, fragment.refMessage)
+	}
+
+	return &gatewayTransportServer{
+		gateway:  gw,
+		ts:       ts,
+		warnings: warnings,
+		listener: l.listener.Name,
+	}
+}
+
+// translateStreamRoute translates a TLSRoute or a TCPRoute. TransportServers support only one upstream,
+// so only the first backend of the first rule is used.
+func (c *Configuration) translateStreamRoute(route *gatewayRoute, ruleCount int, refs []gatewayv1.BackendRef) (*gatewayRouteFragment, *gatewayRouteError) {
+	if ruleCount == 0 {
+		return nil, newUnsupportedValueError("The route must have a rule")
+	}
+	if ruleCount > 1 {
+		return nil, newUnsupportedValueError("Only one rule is supported")
+	}
+
+	fragment := &gatewayRouteFragment{hasDefault: make(map[string]bool)}
+
+	upstreams, weights, reason, message := resolveGatewayBackendRefs(route.meta.Namespace, refs, "")
+	if reason != "" {
+		fragment.refReason = reason
+		fragment.refMessage = message
+	}
+
+	for i, u := range upstreams {
+		if weights[i] > 0 {
+			fragment.upstreams = append(fragment.upstreams, u)
+		}
+	}
+
+	if len(fragment.upstreams) > 1 {
+		fragment.refReason = gatewayv1.RouteReasonUnsupportedValue
+		fragment.refMessage = "Only one backend is supported; the other backends are ignored"
+		fragment.upstreams = fragment.upstreams[:1]
+	}
+
+	if len(fragment.upstreams) == 0 {
+		return fragment, nil
+	}
+
+	ts := &conf_v1.TransportServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "validation", Namespace: route.meta.Namespace},
+		Spec: conf_v1.TransportServerSpec{
+			Listener:  conf_v1.TransportServerListener{Name: conf_v1.TLSPassthroughListenerName, Protocol: conf_v1.TLSPassthroughListenerProtocol},
+			Host:      "gateway.example.com",
+			Upstreams: transportServerUpstreams(fragment.upstreams),
+			Action:    &conf_v1.TransportServerAction{Pass: fragment.upstreams[0].Name},
+		},
+	}
+	if err := c.transportServerValidator.ValidateTransportServer(ts); err != nil {
+		return nil, newUnsupportedValueError("The route can't be translated: %v", err)
+	}
+
+	return fragment, nil
+}
+
+// transportServerUpstreams converts the upstreams of a route into TransportServer upstreams.
+// TransportServers can't reference Services from other namespaces, so the namespace of the Service is trimmed.
+func transportServerUpstreams(upstreams []conf_v1.Upstream) []conf_v1.TransportServerUpstream {
+	var result []conf_v1.TransportServerUpstream
+	for _, u := range upstreams {
+		_, name, _ := strings.Cut(u.Service, "/")
+		result = append(result, conf_v1.TransportServerUpstream{
+			Name:    u.Name,
+			Service: name,
+			Port:    int(u.Port),
+		})
+	}
+	return result
+}
+
+// resolveGatewayBackendRefs converts the backend references into upstreams. Only Services from the namespace of
+// the route are supported. The upstreams reference the Services as namespace/name, because the VirtualServer
+// might be in the namespace of the Gateway.
+func resolveGatewayBackendRefs(
+	namespace string,
+	refs []gatewayv1.BackendRef,
+	upstreamType string,
+) (upstreams []conf_v1.Upstream, weights []int32, reason gatewayv1.RouteConditionReason, message string) {
+	for _, ref := range refs {
+		if (ref.Group != nil && *ref.Group != "" && *ref.Group != "core") || (ref.Kind != nil && *ref.Kind != "Service") {
+			reason = gatewayv1.RouteReasonInvalidKind
+			message = fmt.Sprintf("Backend %s is not a Service", ref.Name)
+			continue
+		}
+		if ref.Namespace != nil && string(*ref.Namespace) != namespace {
+			reason = gatewayv1.RouteReasonRefNotPermitted
+			message = fmt.Sprintf("Backend %s/%s is in another namespace", *ref.Namespace, ref.Name)
+			continue
+		}
+		if ref.Port == nil {
+			reason = gatewayv1.RouteReasonUnsupportedValue
+			message = fmt.Sprintf("Backend %s must specify a port", ref.Name)
+			continue
+		}
+
+		service := fmt.Sprintf("%s/%s", namespace, ref.Name)
+		upstreams = append(upstreams, conf_v1.Upstream{
+			Name:    fmt.Sprintf("backend-%s", gatewayHash(fmt.Sprintf("%s:%d:%s", service, *ref.Port, upstreamType))),
+			Service: service,
+			Port:    uint16(*ref.Port), //nolint:gosec // the port is validated by the Gateway API CRDs
+			Type:    upstreamType,
+		})
+
+		weight := int32(1)
+		if ref.Weight != nil {
+			weight = *ref.Weight
+		}
+		weights = append(weights, weight)
+	}
+
+	return upstreams, weights, reason, message
+}
+
+// gatewayRuleAction holds the action of a route rule: either an action or weighted splits.
+type gatewayRuleAction struct {
+	action *conf_v1.Action
+	splits []conf_v1.Split
+}
+
+func (a *gatewayRuleAction) applyToRoute(r *conf_v1.Route) {
+	r.Action = a.action.DeepCopy()
+	r.Splits = nil
+	for _, s := range a.splits {
+		r.Splits = append(r.Splits, *s.DeepCopy())
+	}
+}
+
+func (a *gatewayRuleAction) toMatch(conditions []conf_v1.Condition) conf_v1.Match {
+	m := conf_v1.Match{
+		Conditions: conditions,
+		Action:     a.action.DeepCopy(),
+	}
+	for _, s := range a.splits {
+		m.Splits = append(m.Splits, *s.DeepCopy())
+	}
+	return m
+}
+
+// gatewayProxyModifiers holds the modifications of the proxied requests and responses defined by route filters.
+type gatewayProxyModifiers struct {
+	requestHeaders  []conf_v1.Header
+	responseHeaders *conf_v1.ProxyResponseHeaders
+	rewritePath     string
+	mirror          *conf_v1.ActionMirror
+}
+
+func (m *gatewayProxyModifiers) proxy(upstream string) *conf_v1.ActionProxy {
+	p := &conf_v1.ActionProxy{
+		Upstream:        upstream,
+		RewritePath:     m.rewritePath,
+		ResponseHeaders: m.responseHeaders.DeepCopy(),
+		Mirror:          m.mirror.DeepCopy(),
+	}
+	if len(m.requestHeaders) > 0 {
+		p.RequestHeaders = &conf_v1.ProxyRequestHeaders{Set: slices.Clone(m.requestHeaders)}
+	}
+	return p
+}
+
+func (m *gatewayProxyModifiers) isEmpty() bool {
+	return len(m.requestHeaders) == 0 && m.responseHeaders == nil && m.rewritePath == "" && m.mirror == nil
+}
+
+func (m *gatewayProxyModifiers) addHeaderFilters(request *gatewayv1.HTTPHeaderFilter, response *gatewayv1.HTTPHeaderFilter) {
+	if request != nil {
+		// NGINX can't append a value to a request header, so Add is handled like Set
+		for _, h := range append(slices.Clone(request.Set), request.Add...) {
+			m.requestHeaders = append(m.requestHeaders, conf_v1.Header{Name: string(h.Name), Value: h.Value})
+		}
+		for _, name := range request.Remove {
+			m.requestHeaders = append(m.requestHeaders, conf_v1.Header{Name: name, Value: ""})
+		}
+	}
+
+	if response != nil {
+		if m.responseHeaders == nil {
+			m.responseHeaders = &conf_v1.ProxyResponseHeaders{}
+		}
+		for _, h := range response.Set {
+			m.responseHeaders.Hide = append(m.responseHeaders.Hide, string(h.Name))
+			m.responseHeaders.Add = append(m.responseHeaders.Add, conf_v1.AddHeader{Header: conf_v1.Header{Name: string(h.Name), Value: h.Value}, Always: true})
+		}
+		for _, h := range response.Add {
+			m.responseHeaders.Add = append(m.responseHeaders.Add, conf_v1.AddHeader{Header: conf_v1.Header{Name: string(h.Name), Value: h.Value}, Always: true})
+		}
+		m.responseHeaders.Hide = append(m.responseHeaders.Hide, response.Remove...)
+	}
+}
+
+func (m *gatewayProxyModifiers) addMirror(namespace string, mirror *gatewayv1.HTTPRequestMirrorFilter, upstreamType string) ([]conf_v1.Upstream, *gatewayRouteError) {
+	if m.mirror != nil {
+		return nil, newUnsupportedValueError("Only one RequestMirror filter is supported")
+	}
+
+	upstreams, _, reason, message := resolveGatewayBackendRefs(namespace, []gatewayv1.BackendRef{{BackendObjectReference: mirror.BackendRef}}, upstreamType)
+	if reason != "" {
+		return nil, &gatewayRouteError{reason: reason, message: message}
+	}
+
+	m.mirror = &conf_v1.ActionMirror{Upstream: upstreams[0].Name}
+	if mirror.Percent != nil {
+		m.mirror.Percentage = new(int(*mirror.Percent))
+	} else if mirror.Fraction != nil {
+		denominator := int32(100)
+		if mirror.Fraction.Denominator != nil && *mirror.Fraction.Denominator > 0 {
+			denominator = *mirror.Fraction.Denominator
+		}
+		m.mirror.Percentage = new(int(mirror.Fraction.Numerator * 100 / denominator))
+	}
+
+	return upstreams, nil
+}
+
+// buildGatewayRuleAction builds the action for the backends of a rule. A single backend is proxied to directly,
+// multiple backends are translated into splits with the weights normalized to 100.
+func buildGatewayRuleAction(upstreams []conf_v1.Upstream, weights []int32, modifiers *gatewayProxyModifiers) *gatewayRuleAction {
+	var selected []conf_v1.Upstream
+	var selectedWeights []int32
+	var total int32
+	for i, u := range upstreams {
+		if weights[i] <= 0 {
+			continue
+		}
+		selected = append(selected, u)
+		selectedWeights = append(selectedWeights, weights[i])
+		total += weights[i]
+	}
+
+	if len(selected) == 0 {
+		return &gatewayRuleAction{
+			action: &conf_v1.Action{Return: &conf_v1.ActionReturn{Code: 500, Type: "text/plain", Body: "Internal Server Error"}},
+		}
+	}
+
+	newAction := func(upstream string) *conf_v1.Action {
+		if modifiers.isEmpty() {
+			return &conf_v1.Action{Pass: upstream}
+		}
+		return &conf_v1.Action{Proxy: modifiers.proxy(upstream)}
+	}
+
+	if len(selected) == 1 {
+		return &gatewayRuleAction{action: newAction(selected[0].Name)}
+	}
+
+	result := &gatewayRuleAction{}
+	remainder := 100
+	for i, u := range selected {
+		weight := int(selectedWeights[i] * 100 / total)
+		remainder -= weight
+		result.splits = append(result.splits, conf_v1.Split{Weight: weight, Action: newAction(u.Name)})
+	}
+	for i := 0; remainder > 0; i = (i + 1) % len(result.splits) {
+		result.splits[i].Weight++
+		remainder--
+	}
+
+	return result
+}
+
+// translateHTTPRoute translates the rules of an HTTPRoute into VirtualServer routes.
+func (c *Configuration) translateHTTPRoute(route *gatewayv1.HTTPRoute) (*gatewayRouteFragment, *gatewayRouteError) {
+	fragment := &gatewayRouteFragment{hasDefault: make(map[string]bool)}
+	paths := make(map[string]int)
+
+	for _, rule := range route.Spec.Rules {
+		modifiers := &gatewayProxyModifiers{}
+		var redirect *gatewayv1.HTTPRequestRedirectFilter
+		var rewrite *gatewayv1.HTTPURLRewriteFilter
+
+		for _, f := range rule.Filters {
+			switch f.Type {
+			case gatewayv1.HTTPRouteFilterRequestHeaderModifier:
+				modifiers.addHeaderFilters(f.RequestHeaderModifier, nil)
+			case gatewayv1.HTTPRouteFilterResponseHeaderModifier:
+				modifiers.addHeaderFilters(nil, f.ResponseHeaderModifier)
+			case gatewayv1.HTTPRouteFilterRequestRedirect:
+				redirect = f.RequestRedirect
+			case gatewayv1.HTTPRouteFilterURLRewrite:
+				rewrite = f.URLRewrite
+			case gatewayv1.HTTPRouteFilterRequestMirror:
+				upstreams, err := modifiers.addMirror(route.Namespace, f.RequestMirror, "")
+				if err != nil {
+					return nil, err
+				}
+				fragment.upstreams = append(fragment.upstreams, upstreams...)
+			default:
+				return nil, newUnsupportedValueError("Filter %s is not supported", f.Type)
+			}
+		}
+
+		if rewrite != nil && rewrite.Hostname != nil {
+			modifiers.requestHeaders = append(modifiers.requestHeaders, conf_v1.Header{Name: "Host", Value: string(*rewrite.Hostname)})
+		}
+
+		var refs []gatewayv1.BackendRef
+		for _, b := range rule.BackendRefs {
+			if len(b.Filters) > 0 {
+				return nil, newUnsupportedValueError("Filters of backendRefs are not supported")
+			}
+			refs = append(refs, b.BackendRef)
+		}
+
+		upstreams, weights, reason, message := resolveGatewayBackendRefs(route.Namespace, refs, "")
+		if reason != "" {
+			fragment.refReason = reason
+			fragment.refMessage = message
+		}
+		fragment.upstreams = append(fragment.upstreams, upstreams...)
+
+		matches := rule.Matches
+		if len(matches) == 0 {
+			matches = []gatewayv1.HTTPRouteMatch{{}}
+		}
+
+		for _, m := range matches {
+			path, pathType, err := httpRouteMatchPath(m.Path)
+			if err != nil {
+				return nil, err
+			}
+
+			ruleModifiers := *modifiers
+			if rewrite != nil && rewrite.Path != nil {
+				if rewrite.Path.Type != gatewayv1.PrefixMatchHTTPPathModifier || rewrite.Path.ReplacePrefixMatch == nil || pathType != gatewayv1.PathMatchPathPrefix {
+					return nil, newUnsupportedValueError("Only the ReplacePrefixMatch path rewrite of PathPrefix matches is supported")
+				}
+				ruleModifiers.rewritePath = *rewrite.Path.ReplacePrefixMatch
+			}
+
+			var action *gatewayRuleAction
+			if redirect != nil {
+				action, err = buildGatewayRedirectAction(redirect)
+				if err != nil {
+					return nil, err
+				}
+			} else {
+				action = buildGatewayRuleAction(upstreams, weights, &ruleModifiers)
+			}
+
+			conditions, err := httpRouteMatchConditions(m)
+			if err != nil {
+				return nil, err
+			}
+
+			fragment.addRule(paths, path, conditions, action)
+		}
+	}
+
+	if err := c.validateGatewayRouteFragment(route.Namespace, fragment); err != nil {
+		return nil, err
+	}
+
+	return fragment, nil
+}
+
+func (f *gatewayRouteFragment) addRule(paths map[string]int, path string, conditions []conf_v1.Condition, action *gatewayRuleAction) {
+	idx, exists := paths[path]
+	if !exists {
+		idx = len(f.routes)
+		paths[path] = idx
+		f.routes = append(f.routes, conf_v1.Route{Path: path})
+	}
+
+	r := &f.routes[idx]
+
+	if len(conditions) > 0 {
+		r.Matches = append(r.Matches, action.toMatch(conditions))
+		if !f.hasDefault[path] {
+			// a placeholder for the requests that don't match any conditions; it can be replaced by another rule or route
+			r.Action = &conf_v1.Action{Return: &conf_v1.ActionReturn{Code: 404, Type: "text/plain", Body: "Not Found"}}
+		}
+		return
+	}
+
+	if f.hasDefault[path] {
+		// the first rule wins
+		return
+	}
+
+	f.hasDefault[path] = true
+	action.applyToRoute(r)
+}
+
+func httpRouteMatchPath(p *gatewayv1.HTTPPathMatch) (string, gatewayv1.PathMatchType, *gatewayRouteError) {
+	if p == nil || p.Value == nil {
+		return "/", gatewayv1.PathMatchPathPrefix, nil
+	}
+
+	pathType := gatewayv1.PathMatchPathPrefix
+	if p.Type != nil {
+		pathType = *p.Type
+	}
+
+	switch pathType {
+	case gatewayv1.PathMatchPathPrefix:
+		return *p.Value, pathType, nil
+	case gatewayv1.PathMatchExact:
+		return fmt.Sprintf("=%s", *p.Value), pathType, nil
+	case gatewayv1.PathMatchRegularExpression:
+		return fmt.Sprintf("~ %s", *p.Value), pathType, nil
+	}
+
+	return "", pathType, newUnsupportedValueError("Path match type %s is not supported", pathType)
+}
+
+// conditionValue converts a match value into the value of a VirtualServer condition. Regular expressions are
+// prefixed with ~. Exact values starting with ~ or ! are not supported, because they have a special meaning in
+// VirtualServer conditions.
+func conditionValue(value string, regex bool) (string, *gatewayRouteError) {
+	if regex {
+		return fmt.Sprintf("~%s", value), nil
+	}
+	if strings.HasPrefix(value, "~") || strings.HasPrefix(value, "!") {
+		return "", newUnsupportedValueError("Match value %q is not supported", value)
+	}
+	return value, nil
+}
+
+func httpRouteMatchConditions(m gatewayv1.HTTPRouteMatch) ([]conf_v1.Condition, *gatewayRouteError) {
+	var conditions []conf_v1.Condition
+
+	for _, h := range m.Headers {
+		value, err := conditionValue(h.Value, h.Type != nil && *h.Type == gatewayv1.HeaderMatchRegularExpression)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, conf_v1.Condition{Header: string(h.Name), Value: value})
+	}
+
+	for _, q := range m.QueryParams {
+		value, err := conditionValue(q.Value, q.Type != nil && *q.Type == gatewayv1.QueryParamMatchRegularExpression)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, conf_v1.Condition{Argument: string(q.Name), Value: value})
+	}
+
+	if m.Method != nil {
+		conditions = append(conditions, conf_v1.Condition{Variable: "$request_method", Value: string(*m.Method)})
+	}
+
+	return conditions, nil
+}
+
+func buildGatewayRedirectAction(redirect *gatewayv1.HTTPRequestRedirectFilter) (*gatewayRuleAction, *gatewayRouteError) {
+	scheme := "${scheme}"
+	if redirect.Scheme != nil {
+		scheme = *redirect.Scheme
+	}
+
+	host := "${host}"
+	if redirect.Hostname != nil {
+		host = string(*redirect.Hostname)
+	}
+	if redirect.Port != nil {
+		host = fmt.Sprintf("%s:%d", host, *redirect.Port)
+	}
+
+	path := "${request_uri}"
+	if redirect.Path != nil {
+		if redirect.Path.Type != gatewayv1.FullPathHTTPPathModifier || redirect.Path.ReplaceFullPath == nil {
+			return nil, newUnsupportedValueError("Only the ReplaceFullPath path modifier is supported for redirects")
+		}
+		path = *redirect.Path.ReplaceFullPath
+	}
+
+	code := 302
+	if redirect.StatusCode != nil {
+		code = *redirect.StatusCode
+	}
+
+	return &gatewayRuleAction{
+		action: &conf_v1.Action{
+			Redirect: &conf_v1.ActionRedirect{
+				URL:  fmt.Sprintf("%s://%s%s", scheme, host, path),
+				Code: code,
+			},
+		},
+	}, nil
+}
+
+// translateGRPCRoute translates the rules of a GRPCRoute into VirtualServer routes.
+// The service and the method of a gRPC call are matched by the path of the HTTP/2 request.
+func (c *Configuration) translateGRPCRoute(route *gatewayv1.GRPCRoute) (*gatewayRouteFragment, *gatewayRouteError) {
+	fragment := &gatewayRouteFragment{hasDefault: make(map[string]bool)}
+	paths := make(map[string]int)
+
+	for _, rule := range route.Spec.Rules {
+		modifiers := &gatewayProxyModifiers{}
+
+		for _, f := range rule.Filters {
+			switch f.Type {
+			case gatewayv1.GRPCRouteFilterRequestHeaderModifier:
+				modifiers.addHeaderFilters(f.RequestHeaderModifier, nil)
+			case gatewayv1.GRPCRouteFilterResponseHeaderModifier:
+				modifiers.addHeaderFilters(nil, f.ResponseHeaderModifier)
+			case gatewayv1.GRPCRouteFilterRequestMirror:
+				upstreams, err := modifiers.addMirror(route.Namespace, f.RequestMirror, "grpc")
+				if err != nil {
+					return nil, err
+				}
+				fragment.upstreams = append(fragment.upstreams, upstreams...)
+			default:
+				return nil, newUnsupportedValueError("Filter %s is not supported", f.Type)
+			}
+		}
+
+		var refs []gatewayv1.BackendRef
+		for _, b := range rule.BackendRefs {
+			if len(b.Filters) > 0 {
+				return nil, newUnsupportedValueError("Filters of backendRefs are not supported")
+			}
+			refs = append(refs, b.BackendRef)
+		}
+
+		upstreams, weights, reason, message := resolveGatewayBackendRefs(route.Namespace, refs, "grpc")
+		if reason != "" {
+			fragment.refReason = reason
+			fragment.refMessage = message
+		}
+		fragment.upstreams = append(fragment.upstreams, upstreams...)
+		action := buildGatewayRuleAction(upstreams, weights, modifiers)
+
+		matches := rule.Matches
+		if len(matches) == 0 {
+			matches = []gatewayv1.GRPCRouteMatch{{}}
+		}
+
+		for _, m := range matches {
+			path, err := grpcRouteMatchPath(m.Method)
+			if err != nil {
+				return nil, err
+			}
+
+			var conditions []conf_v1.Condition
+			for _, h := range m.Headers {
+				value, err := conditionValue(h.Value, h.Type != nil && *h.Type == gatewayv1.GRPCHeaderMatchRegularExpression)
+				if err != nil {
+					return nil, err
+				}
+				conditions = append(conditions, conf_v1.Condition{Header: string(h.Name), Value: value})
+			}
+
+			fragment.addRule(paths, path, conditions, action)
+		}
+	}
+
+	if err := c.validateGatewayRouteFragment(route.Namespace, fragment); err != nil {
+		return nil, err
+	}
+
+	return fragment, nil
+}
+
+func grpcRouteMatchPath(m *gatewayv1.GRPCMethodMatch) (string, *gatewayRouteError) {
+	if m == nil || (m.Service == nil && m.Method == nil) {
+		return "/", nil
+	}
+
+	if m.Type != nil && *m.Type == gatewayv1.GRPCMethodMatchRegularExpression {
+		service := "[^/]+"
+		if m.Service != nil {
+			service = *m.Service
+		}
+		method := "[^/]+"
+		if m.Method != nil {
+			method = *m.Method
+		}
+		return fmt.Sprintf("~ ^/%s/%s$", service, method), nil
+	}
+
+	switch {
+	case m.Service != nil && m.Method != nil:
+		return fmt.Sprintf("=/%s/%s", *m.Service, *m.Method), nil
+	case m.Service != nil:
+		return fmt.Sprintf("/%s/", *m.Service), nil
+	default:
+		return fmt.Sprintf("~ ^/[^/]+/%s$", *m.Method), nil
+	}
+}
+
+// validateGatewayRouteFragment validates the generated routes with the VirtualServer validator, so that
+// an invalid route doesn't break the VirtualServer it is merged into.
+func (c *Configuration) validateGatewayRouteFragment(namespace string, fragment *gatewayRouteFragment) *gatewayRouteError {
+	vs := &conf_v1.VirtualServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "validation", Namespace: namespace},
+		Spec: conf_v1.VirtualServerSpec{
+			Host:   "gateway.example.com",
+			Routes: fragment.routes,
+		},
+	}
+
+	seen := make(map[string]bool)
+	for _, u := range fragment.upstreams {
+		if seen[u.Name] {
+			continue
+		}
+		seen[u.Name] = true
+		vs.Spec.Upstreams = append(vs.Spec.Upstreams, u)
+	}
+	fragment.upstreams = vs.Spec.Upstreams
+
+	if err := c.virtualServerValidator.ValidateVirtualServer(vs); err != nil {
+		return newUnsupportedValueError("The route can't be translated: %v", err)
+	}
+
+	return nil
+}
+
+func getSortedGatewayAPIKeys[T metav1.Object](m map[string]T) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		ti := m[keys[i]].GetCreationTimestamp()
+		tj := m[keys[j]].GetCreationTimestamp()
+		if !ti.Equal(&tj) {
+			return ti.Before(&tj)
+		}
+		return keys[i] < keys[j]
+	})
+
+	return keys
+}
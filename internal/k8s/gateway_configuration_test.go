@@ -0,0 +1,517 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	nl "github.com/nginx/kubernetes-ingress/internal/logger"
+	conf_v1 "github.com/nginx/kubernetes-ingress/pkg/apis/configuration/v1"
+	"github.com/nginx/kubernetes-ingress/pkg/apis/configuration/validation"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+// createTestGatewayConfiguration creates a Configuration that handles the Gateways of the GatewayClass "nginx".
+func createTestGatewayConfiguration(t *testing.T) *Configuration {
+	t.Helper()
+
+	lbc := LoadBalancerController{
+		ingressClass:       "nginx",
+		Logger:             nl.LoggerFromContext(context.Background()),
+		gatewayClassLister: cache.NewStore(cache.MetaNamespaceKeyFunc),
+	}
+	err := lbc.gatewayClassLister.Add(&gatewayv1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "nginx"},
+		Spec:       gatewayv1.GatewayClassSpec{ControllerName: IngressControllerName},
+	})
+	if err != nil {
+		t.Fatalf("failed to add GatewayClass: %v", err)
+	}
+
+	isTLSPassthroughEnabled := true
+	c := NewConfiguration(
+		lbc.HasCorrectIngressClass,
+		false,
+		false,
+		false,
+		false,
+		validation.NewVirtualServerValidator(validation.IsPlus(false)),
+		validation.NewGlobalConfigurationValidator(map[int]bool{
+			80:  true,
+			443: true,
+		}),
+		validation.NewTransportServerValidator(isTLSPassthroughEnabled, false, false),
+		isTLSPassthroughEnabled,
+		false,
+		false,
+		false,
+		false,
+		true,
+	)
+	c.CompleteStartup()
+
+	return c
+}
+
+func createTestGateway(name string, listeners ...gatewayv1.Listener) *gatewayv1.Gateway {
+	return &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "default",
+			Name:              name,
+			UID:               "gateway-uid",
+			CreationTimestamp: metav1.Now(),
+		},
+		Spec: gatewayv1.GatewaySpec{
+			GatewayClassName: "nginx",
+			Listeners:        listeners,
+		},
+	}
+}
+
+func createTestHTTPRoute(namespace string, name string, hostname string, path string, backends ...gatewayv1.HTTPBackendRef) *gatewayv1.HTTPRoute {
+	return &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         namespace,
+			Name:              name,
+			CreationTimestamp: metav1.Now(),
+		},
+		Spec: gatewayv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{
+					{
+						Namespace: new(gatewayv1.Namespace("default")),
+						Name:      "gateway",
+					},
+				},
+			},
+			Hostnames: []gatewayv1.Hostname{gatewayv1.Hostname(hostname)},
+			Rules: []gatewayv1.HTTPRouteRule{
+				{
+					Matches: []gatewayv1.HTTPRouteMatch{
+						{
+							Path: &gatewayv1.HTTPPathMatch{
+								Type:  new(gatewayv1.PathMatchPathPrefix),
+								Value: new(path),
+							},
+						},
+					},
+					BackendRefs: backends,
+				},
+			},
+		},
+	}
+}
+
+func createTestHTTPBackendRef(service string, port int32, weight int32) gatewayv1.HTTPBackendRef {
+	return gatewayv1.HTTPBackendRef{
+		BackendRef: gatewayv1.BackendRef{
+			BackendObjectReference: gatewayv1.BackendObjectReference{
+				Name: gatewayv1.ObjectName(service),
+				Port: new(gatewayv1.PortNumber(port)),
+			},
+			Weight: new(weight),
+		},
+	}
+}
+
+func createTestBackendRef(service string, port int32) gatewayv1.BackendRef {
+	return gatewayv1.BackendRef{
+		BackendObjectReference: gatewayv1.BackendObjectReference{
+			Name: gatewayv1.ObjectName(service),
+			Port: new(gatewayv1.PortNumber(port)),
+		},
+	}
+}
+
+var testHTTPListener = gatewayv1.Listener{
+	Name:     "http",
+	Port:     80,
+	Protocol: gatewayv1.HTTPProtocolType,
+}
+
+func findGatewayVirtualServerConfiguration(t *testing.T, changes []ResourceChange) *VirtualServerConfiguration {
+	t.Helper()
+
+	for _, c := range changes {
+		if vsc, ok := c.Resource.(*VirtualServerConfiguration); ok && c.Op == AddOrUpdate && vsc.Gateway != nil {
+			return vsc
+		}
+	}
+
+	t.Fatalf("no VirtualServerConfiguration for a Gateway among the changes %v", changes)
+	return nil
+}
+
+func findGatewayTransportServerConfiguration(t *testing.T, changes []ResourceChange) *TransportServerConfiguration {
+	t.Helper()
+
+	for _, c := range changes {
+		if tsc, ok := c.Resource.(*TransportServerConfiguration); ok && c.Op == AddOrUpdate && tsc.Gateway != nil {
+			return tsc
+		}
+	}
+
+	t.Fatalf("no TransportServerConfiguration for a Gateway among the changes %v", changes)
+	return nil
+}
+
+func getRouteCondition(t *testing.T, c *Configuration, routeKey string, condType gatewayv1.RouteConditionType) *metav1.Condition {
+	t.Helper()
+
+	parents := c.GetGatewayRouteStatuses()[routeKey]
+	if len(parents) != 1 {
+		t.Fatalf("expected 1 parent status for %s, got %d", routeKey, len(parents))
+	}
+
+	cond := meta.FindStatusCondition(parents[0].Conditions, string(condType))
+	if cond == nil {
+		t.Fatalf("condition %s not found for %s", condType, routeKey)
+	}
+
+	return cond
+}
+
+func TestAddOrUpdateHTTPRouteTranslatesToVirtualServer(t *testing.T) {
+	t.Parallel()
+	c := createTestGatewayConfiguration(t)
+
+	changes, problems := c.AddOrUpdateGateway(createTestGateway("gateway", testHTTPListener))
+	if len(changes) != 0 || len(problems) != 0 {
+		t.Fatalf("AddOrUpdateGateway() returned unexpected changes %v or problems %v", changes, problems)
+	}
+
+	route := createTestHTTPRoute("default", "cafe", "cafe.example.com", "/tea",
+		createTestHTTPBackendRef("tea-svc", 80, 80),
+		createTestHTTPBackendRef("tea-svc-v2", 80, 20),
+	)
+
+	changes, problems = c.AddOrUpdateHTTPRoute(route)
+	if len(problems) != 0 {
+		t.Fatalf("AddOrUpdateHTTPRoute() returned unexpected problems %v", problems)
+	}
+
+	vsc := findGatewayVirtualServerConfiguration(t, changes)
+	vs := vsc.VirtualServer
+
+	if vs.Spec.Host != "cafe.example.com" {
+		t.Errorf("got host %q, want %q", vs.Spec.Host, "cafe.example.com")
+	}
+	if vs.Spec.TLS != nil {
+		t.Errorf("got TLS %v for a host served only by an HTTP listener", vs.Spec.TLS)
+	}
+	if len(vs.Spec.Upstreams) != 2 {
+		t.Fatalf("got %d upstreams, want 2", len(vs.Spec.Upstreams))
+	}
+	for _, u := range vs.Spec.Upstreams {
+		if u.Port != 80 {
+			t.Errorf("got port %d for upstream %s, want 80", u.Port, u.Name)
+		}
+	}
+	if len(vs.Spec.Routes) != 1 {
+		t.Fatalf("got %d routes, want 1", len(vs.Spec.Routes))
+	}
+
+	r := vs.Spec.Routes[0]
+	if r.Path != "/tea" {
+		t.Errorf("got path %q, want %q", r.Path, "/tea")
+	}
+	if len(r.Splits) != 2 || r.Splits[0].Weight+r.Splits[1].Weight != 100 {
+		t.Errorf("got splits %v, want 2 splits with the total weight of 100", r.Splits)
+	}
+
+	if cond := getRouteCondition(t, c, "HTTPRoute/default/cafe", gatewayv1.RouteConditionAccepted); cond.Status != metav1.ConditionTrue {
+		t.Errorf("got Accepted condition %v, want True", cond)
+	}
+	if cond := getRouteCondition(t, c, "HTTPRoute/default/cafe", gatewayv1.RouteConditionResolvedRefs); cond.Status != metav1.ConditionTrue {
+		t.Errorf("got ResolvedRefs condition %v, want True", cond)
+	}
+
+	status := c.GetGatewayStatuses()["default/gateway"]
+	if len(status.Listeners) != 1 || status.Listeners[0].AttachedRoutes != 1 {
+		t.Errorf("got listener statuses %v, want 1 listener with 1 attached route", status.Listeners)
+	}
+
+	changes, problems = c.DeleteHTTPRoute("default/cafe")
+	if len(problems) != 0 {
+		t.Fatalf("DeleteHTTPRoute() returned unexpected problems %v", problems)
+	}
+	if len(changes) != 1 || changes[0].Op != Delete {
+		t.Errorf("DeleteHTTPRoute() returned unexpected changes %v", changes)
+	}
+}
+
+func TestAddOrUpdateHTTPRouteWithHTTPSListener(t *testing.T) {
+	t.Parallel()
+	c := createTestGatewayConfiguration(t)
+
+	c.AddOrUpdateGateway(createTestGateway("gateway", gatewayv1.Listener{
+		Name:     "https",
+		Port:     443,
+		Protocol: gatewayv1.HTTPSProtocolType,
+		TLS: &gatewayv1.ListenerTLSConfig{
+			Mode:            new(gatewayv1.TLSModeTerminate),
+			CertificateRefs: []gatewayv1.SecretObjectReference{{Name: "cafe-secret"}},
+		},
+	}))
+
+	changes, _ := c.AddOrUpdateHTTPRoute(createTestHTTPRoute("default", "cafe", "cafe.example.com", "/", createTestHTTPBackendRef("tea-svc", 80, 1)))
+
+	vs := findGatewayVirtualServerConfiguration(t, changes).VirtualServer
+
+	expectedTLS := &conf_v1.TLS{
+		Secret:   "cafe-secret",
+		Redirect: &conf_v1.TLSRedirect{Enable: true},
+	}
+	if diff := cmp.Diff(expectedTLS, vs.Spec.TLS); diff != "" {
+		t.Errorf("unexpected TLS (-want +got):\n%s", diff)
+	}
+}
+
+func TestHTTPRouteLosesHostToOlderVirtualServer(t *testing.T) {
+	t.Parallel()
+	c := createTestGatewayConfiguration(t)
+
+	vs := createTestVirtualServer("virtualserver", "cafe.example.com")
+	vs.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+	c.AddOrUpdateVirtualServer(vs)
+
+	c.AddOrUpdateGateway(createTestGateway("gateway", testHTTPListener))
+	_, problems := c.AddOrUpdateHTTPRoute(createTestHTTPRoute("default", "cafe", "cafe.example.com", "/", createTestHTTPBackendRef("tea-svc", 80, 1)))
+
+	if len(problems) != 1 {
+		t.Fatalf("got problems %v, want 1 problem", problems)
+	}
+	if _, ok := problems[0].Object.(*gatewayv1.Gateway); !ok {
+		t.Errorf("got problem for %T, want the Gateway", problems[0].Object)
+	}
+
+	status := c.GetGatewayStatuses()["default/gateway"]
+	cond := meta.FindStatusCondition(status.Listeners[0].Conditions, string(gatewayv1.ListenerConditionConflicted))
+	if cond == nil || cond.Status != metav1.ConditionTrue || cond.Reason != string(gatewayv1.ListenerReasonHostnameConflict) {
+		t.Errorf("got Conflicted condition %v, want True with the reason HostnameConflict", cond)
+	}
+}
+
+func TestHTTPRouteNotAttached(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		msg            string
+		listener       gatewayv1.Listener
+		routeNamespace string
+		expectedReason gatewayv1.RouteConditionReason
+	}{
+		{
+			msg:            "route from another namespace",
+			listener:       testHTTPListener,
+			routeNamespace: "cafe",
+			expectedReason: gatewayv1.RouteReasonNotAllowedByListeners,
+		},
+		{
+			msg: "no matching hostname",
+			listener: gatewayv1.Listener{
+				Name:     "http",
+				Port:     80,
+				Protocol: gatewayv1.HTTPProtocolType,
+				Hostname: new(gatewayv1.Hostname("*.example.org")),
+			},
+			routeNamespace: "default",
+			expectedReason: gatewayv1.RouteReasonNoMatchingListenerHostname,
+		},
+		{
+			msg: "listener port not in GlobalConfiguration",
+			listener: gatewayv1.Listener{
+				Name:     "http",
+				Port:     8080,
+				Protocol: gatewayv1.HTTPProtocolType,
+			},
+			routeNamespace: "default",
+			expectedReason: gatewayv1.RouteReasonNotAllowedByListeners,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.msg, func(t *testing.T) {
+			t.Parallel()
+			c := createTestGatewayConfiguration(t)
+
+			c.AddOrUpdateGateway(createTestGateway("gateway", test.listener))
+			changes, _ := c.AddOrUpdateHTTPRoute(createTestHTTPRoute(test.routeNamespace, "cafe", "cafe.example.com", "/", createTestHTTPBackendRef("tea-svc", 80, 1)))
+			if len(changes) != 0 {
+				t.Errorf("AddOrUpdateHTTPRoute() returned unexpected changes %v", changes)
+			}
+
+			cond := getRouteCondition(t, c, "HTTPRoute/"+test.routeNamespace+"/cafe", gatewayv1.RouteConditionAccepted)
+			if cond.Status != metav1.ConditionFalse || cond.Reason != string(test.expectedReason) {
+				t.Errorf("got Accepted condition %v, want False with the reason %s", cond, test.expectedReason)
+			}
+		})
+	}
+}
+
+func TestAddOrUpdateGatewayWithWrongClass(t *testing.T) {
+	t.Parallel()
+	c := createTestGatewayConfiguration(t)
+
+	gw := createTestGateway("gateway", testHTTPListener)
+	gw.Spec.GatewayClassName = "other"
+	c.AddOrUpdateGateway(gw)
+
+	changes, _ := c.AddOrUpdateHTTPRoute(createTestHTTPRoute("default", "cafe", "cafe.example.com", "/", createTestHTTPBackendRef("tea-svc", 80, 1)))
+	if len(changes) != 0 {
+		t.Errorf("AddOrUpdateHTTPRoute() returned unexpected changes %v", changes)
+	}
+	if statuses := c.GetGatewayStatuses(); len(statuses) != 0 {
+		t.Errorf("GetGatewayStatuses() returned unexpected statuses %v", statuses)
+	}
+	if parents := c.GetGatewayRouteStatuses()["HTTPRoute/default/cafe"]; len(parents) != 0 {
+		t.Errorf("GetGatewayRouteStatuses() returned unexpected parents %v", parents)
+	}
+}
+
+func TestAddOrUpdateTLSRouteTranslatesToTransportServer(t *testing.T) {
+	t.Parallel()
+	c := createTestGatewayConfiguration(t)
+
+	c.AddOrUpdateGateway(createTestGateway("gateway", gatewayv1.Listener{
+		Name:     "tls",
+		Port:     443,
+		Protocol: gatewayv1.TLSProtocolType,
+		TLS:      &gatewayv1.ListenerTLSConfig{Mode: new(gatewayv1.TLSModePassthrough)},
+	}))
+
+	route := &gatewayv1.TLSRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "default",
+			Name:              "secure-app",
+			CreationTimestamp: metav1.Now(),
+		},
+		Spec: gatewayv1.TLSRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{{Name: "gateway"}},
+			},
+			Hostnames: []gatewayv1.Hostname{"app.example.com"},
+			Rules: []gatewayv1.TLSRouteRule{
+				{BackendRefs: []gatewayv1.BackendRef{createTestBackendRef("secure-app", 8443)}},
+			},
+		},
+	}
+
+	changes, problems := c.AddOrUpdateTLSRoute(route)
+	if len(problems) != 0 {
+		t.Fatalf("AddOrUpdateTLSRoute() returned unexpected problems %v", problems)
+	}
+
+	ts := findGatewayTransportServerConfiguration(t, changes).TransportServer
+	if ts.Spec.Host != "app.example.com" {
+		t.Errorf("got host %q, want %q", ts.Spec.Host, "app.example.com")
+	}
+	if ts.Spec.Listener.Name != conf_v1.TLSPassthroughListenerName {
+		t.Errorf("got listener %q, want %q", ts.Spec.Listener.Name, conf_v1.TLSPassthroughListenerName)
+	}
+	if len(ts.Spec.Upstreams) != 1 || ts.Spec.Upstreams[0].Service != "secure-app" || ts.Spec.Upstreams[0].Port != 8443 {
+		t.Errorf("got upstreams %v, want the secure-app service on port 8443", ts.Spec.Upstreams)
+	}
+}
+
+func TestAddOrUpdateTCPRouteTranslatesToTransportServer(t *testing.T) {
+	t.Parallel()
+	c := createTestGatewayConfiguration(t)
+
+	addOrUpdateGlobalConfiguration(t, c, []conf_v1.Listener{
+		{
+			Name:     "tcp-5432",
+			Port:     5432,
+			Protocol: "TCP",
+		},
+	}, noChanges, noProblems)
+
+	c.AddOrUpdateGateway(createTestGateway("gateway", gatewayv1.Listener{
+		Name:     "postgres",
+		Port:     5432,
+		Protocol: gatewayv1.TCPProtocolType,
+	}))
+
+	route := &gatewayv1alpha2.TCPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "default",
+			Name:              "postgres",
+			CreationTimestamp: metav1.Now(),
+		},
+		Spec: gatewayv1alpha2.TCPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{{Name: "gateway"}},
+			},
+			Rules: []gatewayv1alpha2.TCPRouteRule{
+				{BackendRefs: []gatewayv1.BackendRef{createTestBackendRef("postgres", 5432)}},
+			},
+		},
+	}
+
+	changes, problems := c.AddOrUpdateTCPRoute(route)
+	if len(problems) != 0 {
+		t.Fatalf("AddOrUpdateTCPRoute() returned unexpected problems %v", problems)
+	}
+
+	tsc := findGatewayTransportServerConfiguration(t, changes)
+	if tsc.TransportServer.Spec.Listener.Name != "tcp-5432" || tsc.ListenerPort != 5432 {
+		t.Errorf("got listener %q on port %d, want tcp-5432 on port 5432", tsc.TransportServer.Spec.Listener.Name, tsc.ListenerPort)
+	}
+
+	changes, _ = c.DeleteTCPRoute("default/postgres")
+	if len(changes) != 1 || changes[0].Op != Delete {
+		t.Errorf("DeleteTCPRoute() returned unexpected changes %v", changes)
+	}
+}
+
+func TestIntersectGatewayHostnames(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		msg              string
+		listenerHostname *gatewayv1.Hostname
+		routeHostnames   []gatewayv1.Hostname
+		expected         []string
+	}{
+		{
+			msg:            "no listener hostname",
+			routeHostnames: []gatewayv1.Hostname{"cafe.example.com", "tea.example.com"},
+			expected:       []string{"cafe.example.com", "tea.example.com"},
+		},
+		{
+			msg:              "no route hostnames",
+			listenerHostname: new(gatewayv1.Hostname("cafe.example.com")),
+			expected:         []string{"cafe.example.com"},
+		},
+		{
+			msg:              "wildcard listener hostname",
+			listenerHostname: new(gatewayv1.Hostname("*.example.com")),
+			routeHostnames:   []gatewayv1.Hostname{"cafe.example.com", "example.com", "cafe.example.org"},
+			expected:         []string{"cafe.example.com"},
+		},
+		{
+			msg:              "wildcard route hostname",
+			listenerHostname: new(gatewayv1.Hostname("cafe.example.com")),
+			routeHostnames:   []gatewayv1.Hostname{"*.example.com"},
+			expected:         []string{"cafe.example.com"},
+		},
+		{
+			msg:              "no match",
+			listenerHostname: new(gatewayv1.Hostname("cafe.example.com")),
+			routeHostnames:   []gatewayv1.Hostname{"tea.example.com"},
+			expected:         nil,
+		},
+	}
+
+	for _, test := range tests {
+		result := intersectGatewayHostnames(test.listenerHostname, test.routeHostnames)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("intersectGatewayHostnames() returned unexpected result for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
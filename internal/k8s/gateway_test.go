@@ -0,0 +1,55 @@
+package k8s
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestMergeRouteParentStatuses(t *testing.T) {
+	t.Parallel()
+
+	transitionTime := metav1.Date(2024, 1, 1, 0, 0, 0, 0, metav1.Now().Location())
+
+	otherParent := gatewayv1.RouteParentStatus{
+		ParentRef:      gatewayv1.ParentReference{Name: "other-gateway"},
+		ControllerName: "example.com/other-controller",
+	}
+	staleParent := gatewayv1.RouteParentStatus{
+		ParentRef:      gatewayv1.ParentReference{Name: "deleted-gateway"},
+		ControllerName: IngressControllerName,
+	}
+	existingParent := gatewayv1.RouteParentStatus{
+		ParentRef:      gatewayv1.ParentReference{Name: "gateway"},
+		ControllerName: IngressControllerName,
+		Conditions: []metav1.Condition{
+			{
+				Type:               string(gatewayv1.RouteConditionAccepted),
+				Status:             metav1.ConditionTrue,
+				Reason:             string(gatewayv1.RouteReasonAccepted),
+				Message:            "The route is accepted",
+				LastTransitionTime: transitionTime,
+			},
+		},
+	}
+
+	existing := []gatewayv1.RouteParentStatus{otherParent, staleParent, existingParent}
+	ours := []gatewayv1.RouteParentStatus{
+		{
+			ParentRef:      gatewayv1.ParentReference{Name: "gateway"},
+			ControllerName: IngressControllerName,
+			Conditions: []metav1.Condition{
+				newGatewayCondition(string(gatewayv1.RouteConditionAccepted), true, string(gatewayv1.RouteReasonAccepted), "The route is accepted", 0),
+			},
+		},
+	}
+
+	expected := []gatewayv1.RouteParentStatus{otherParent, existingParent}
+
+	result := mergeRouteParentStatuses(existing, ours)
+	if diff := cmp.Diff(expected, result); diff != "" {
+		t.Errorf("mergeRouteParentStatuses() returned unexpected result (-want +got):\n%s", diff)
+	}
+}
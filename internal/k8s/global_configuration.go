@@ -74,6 +74,8 @@ func (lbc *LoadBalancerController) syncGlobalConfiguration(task task) {
 	var problems []ConfigurationProblem
 	var validationErr error
 
+	previousDefaultPolicies := lbc.configuration.GetDefaultPolicies()
+
 	if !gcExists {
 		nl.Debugf(lbc.Logger, "Deleting GlobalConfiguration: %v\n", key)
 
@@ -83,10 +85,20 @@ func (lbc *LoadBalancerController) syncGlobalConfiguration(task task) {
 
 		gc := obj.(*conf_v1.GlobalConfiguration)
 		changes, problems, validationErr = lbc.configuration.AddOrUpdateGlobalConfiguration(gc)
+
+		if lbc.configuration.GetDefaultPolicies().hasNamespaceSelector() {
+			if err := lbc.startDefaultPoliciesNamespaceWatcher(createDefaultPoliciesNamespaceHandlers(lbc)); err != nil {
+				nl.Errorf(lbc.Logger, "Failed to watch the namespaces of the default policies: %v", err)
+			}
+		}
 	}
 
 	updateErr := lbc.processChangesFromGlobalConfiguration(changes)
 
+	if resources := lbc.configuration.FindResourcesForDefaultPolicies(previousDefaultPolicies); len(resources) > 0 {
+		lbc.updatePolicyResources(resources)
+	}
+
 	if gcExists {
 		eventTitle := nl.EventReasonUpdated
 		eventType := api_v1.EventTypeNormal
@@ -61,7 +61,86 @@ func (lbc *LoadBalancerController) addNamespaceHandler(handlers cache.ResourceEv
 	return nil
 }
 
+// createDefaultPoliciesNamespaceHandlers builds the handler funcs for the namespaces whose labels select the default policies
+func createDefaultPoliciesNamespaceHandlers(lbc *LoadBalancerController) cache.ResourceEventHandlerFuncs {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			lbc.AddSyncQueue(obj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			ns, isNs := obj.(*api_v1.Namespace)
+			if !isNs {
+				deletedState, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					nl.Debugf(lbc.Logger, "Error received unexpected object: %v", obj)
+					return
+				}
+				ns, ok = deletedState.Obj.(*api_v1.Namespace)
+				if !ok {
+					nl.Debugf(lbc.Logger, "Error DeletedFinalStateUnknown contained non-Namespace object: %v", deletedState.Obj)
+					return
+				}
+			}
+			lbc.AddSyncQueue(ns)
+		},
+		UpdateFunc: func(old, cur interface{}) {
+			if !reflect.DeepEqual(old.(*api_v1.Namespace).Labels, cur.(*api_v1.Namespace).Labels) {
+				nl.Debugf(lbc.Logger, "Labels of Namespace %v changed, syncing", cur.(*api_v1.Namespace).Name)
+				lbc.AddSyncQueue(cur)
+			}
+		},
+	}
+}
+
+// startDefaultPoliciesNamespaceWatcher starts watching the labels of all namespaces when the default policies
+// have a namespace selector. The watcher is started on demand and is not part of the cache syncs,
+// so that the Ingress Controller doesn't require the permission to list the namespaces of the cluster
+// unless the namespace selectors are used. The labels of the namespaces are synced as the namespaces are added.
+func (lbc *LoadBalancerController) startDefaultPoliciesNamespaceWatcher(handlers cache.ResourceEventHandlerFuncs) error {
+	if lbc.namespaceLister != nil {
+		return nil
+	}
+
+	nsInformer := informers.NewSharedInformerFactory(lbc.client, lbc.resync).Core().V1().Namespaces().Informer()
+	if _, err := nsInformer.AddEventHandler(handlers); err != nil {
+		return fmt.Errorf("failed to add Namespace event handler: %w", err)
+	}
+	lbc.namespaceLister = nsInformer.GetStore()
+
+	go nsInformer.Run(lbc.ctx.Done())
+	return nil
+}
+
+// syncNamespaceLabels updates the labels of the namespace that select the default policies
+// and updates the resources whose default policies changed.
+func (lbc *LoadBalancerController) syncNamespaceLabels(task task) {
+	obj, exists, err := lbc.namespaceLister.GetByKey(task.Key)
+	if err != nil {
+		lbc.syncQueue.Requeue(task, err)
+		return
+	}
+
+	var nsLabels map[string]string
+	if exists {
+		nsLabels = obj.(*api_v1.Namespace).Labels
+		if nsLabels == nil {
+			nsLabels = map[string]string{}
+		}
+	}
+
+	if resources := lbc.configuration.UpdateNamespaceLabels(task.Key, nsLabels); len(resources) > 0 {
+		lbc.updatePolicyResources(resources)
+	}
+}
+
 func (lbc *LoadBalancerController) syncNamespace(task task) {
+	if lbc.namespaceLister != nil {
+		lbc.syncNamespaceLabels(task)
+	}
+	if lbc.namespaceLabeledLister == nil {
+		return
+	}
+
 	key := task.Key
 	// process namespace and add to / remove from watched namespace list
 	_, exists, err := lbc.namespaceLabeledLister.GetByKey(key)
@@ -79,6 +79,7 @@ func (lbc *LoadBalancerController) syncPolicy(task task) {
 		if err != nil {
 			msg := fmt.Sprintf("Policy %v/%v is invalid and was rejected: %v", pol.Namespace, pol.Name, err)
 			lbc.recorder.Event(pol, api_v1.EventTypeWarning, nl.EventReasonRejected, msg)
+			lbc.recordResourceState(policy, key, conf_v1.StateInvalid, nil)
 
 			if lbc.reportCustomResourceStatusEnabled() {
 				// Defer policy status updates during startup to avoid serial
@@ -97,6 +98,7 @@ func (lbc *LoadBalancerController) syncPolicy(task task) {
 		} else {
 			msg := fmt.Sprintf("Policy %v/%v was added or updated", pol.Namespace, pol.Name)
 			lbc.recorder.Event(pol, api_v1.EventTypeNormal, nl.EventReasonAddedOrUpdated, msg)
+			lbc.recordResourceState(policy, key, conf_v1.StateValid, nil)
 
 			if lbc.reportCustomResourceStatusEnabled() {
 				// Defer policy status updates during startup to avoid serial
@@ -113,6 +115,8 @@ func (lbc *LoadBalancerController) syncPolicy(task task) {
 				}
 			}
 		}
+	} else {
+		lbc.deleteResourceState(policy, key)
 	}
 
 	// it is safe to ignore the error
@@ -122,8 +126,8 @@ func (lbc *LoadBalancerController) syncPolicy(task task) {
 	// for the auth service can be correlated back to VirtualServers that use it.
 	if polExists && lbc.HasCorrectIngressClass(obj) {
 		pol := obj.(*conf_v1.Policy)
-		if pol.Spec.ExternalAuth != nil && pol.Spec.ExternalAuth.AuthServiceName != "" {
-			lbc.configuration.UpdatePolicyServiceRef(namespace, name, pol.Spec.ExternalAuth.AuthServiceName)
+		if exAuth := configs.GetExternalAuthForPolicy(pol); exAuth != nil && exAuth.AuthServiceName != "" {
+			lbc.configuration.UpdatePolicyServiceRef(namespace, name, exAuth.AuthServiceName)
 		} else {
 			lbc.configuration.DeletePolicyServiceRef(namespace, name)
 		}
@@ -137,14 +141,15 @@ func (lbc *LoadBalancerController) syncPolicy(task task) {
 	// Note: if we ever support all policy types on all resources, this loop can be removed.
 	for _, res := range resources {
 		switch impl := res.(type) {
-		// We only check for Ingress resources because VirtualServer and VirtualServerRoute support all policy types.
+		// We only check for Ingress and TransportServer resources because VirtualServer and VirtualServerRoute support all policy types.
 		// If Ingress support for a policy type is added in the future, the policy Spec must also be added in IsPolicySupportedOnIngress() in internal/configs/policy.go.
 		case *IngressConfiguration:
 			if !polExists {
 				continue
 			}
 			pol := obj.(*conf_v1.Policy)
-			if !configs.IsPolicySupportedOnIngress(pol) {
+			// The default policies of the types not supported on Ingress are skipped without an error.
+			if !configs.IsPolicySupportedOnIngress(pol) && isPolicyReferencedByIngressAnnotations(namespace, name, impl.Ingress) {
 				msg := fmt.Sprintf("Policy %s/%s has unsupported type on Ingress resource %s/%s",
 					pol.Namespace, pol.Name, impl.Ingress.Namespace, impl.Ingress.Name)
 				nl.Error(lbc.Logger, msg)
@@ -152,15 +157,33 @@ func (lbc *LoadBalancerController) syncPolicy(task task) {
 				// The reload still proceeds so that generatePolicies() surfaces the error
 				// (ErrorReturn 500) consistently regardless of which path triggered the sync.
 			}
+		case *TransportServerConfiguration:
+			if !polExists {
+				continue
+			}
+			pol := obj.(*conf_v1.Policy)
+			if !configs.IsPolicySupportedOnTransportServer(pol) {
+				msg := fmt.Sprintf("Policy %s/%s has unsupported type on TransportServer resource %s/%s",
+					pol.Namespace, pol.Name, impl.TransportServer.Namespace, impl.TransportServer.Name)
+				nl.Error(lbc.Logger, msg)
+				lbc.recorder.Event(impl.TransportServer, api_v1.EventTypeWarning, nl.EventReasonRejected, msg)
+			}
 		default:
 			continue
 		}
 	}
 
+	lbc.updatePolicyResources(resources)
+
+	// Note: updating the status of a policy based on a reload is not needed.
+}
+
+// updatePolicyResources updates the configuration, the status and the events of the resources whose policies changed.
+func (lbc *LoadBalancerController) updatePolicyResources(resources []Resource) {
 	resourceExes := lbc.createExtendedResources(resources)
 
-	// Only VirtualServers and Ingresses support policies
-	if len(resourceExes.VirtualServerExes) == 0 && len(resourceExes.IngressExes) == 0 && len(resourceExes.MergeableIngresses) == 0 {
+	// Only VirtualServers, Ingresses and TransportServers support policies
+	if len(resourceExes.VirtualServerExes) == 0 && len(resourceExes.IngressExes) == 0 && len(resourceExes.MergeableIngresses) == 0 && len(resourceExes.TransportServerExes) == 0 {
 		return
 	}
 
@@ -173,6 +196,9 @@ func (lbc *LoadBalancerController) syncPolicy(task task) {
 	var mergeableIngressWarnings configs.Warnings
 	mergeableIngressErrors := make(map[string]error)
 
+	var transportServerWarnings configs.Warnings
+	var transportServerErr error
+
 	if len(resourceExes.VirtualServerExes) > 0 {
 		warnings, updateErr := lbc.configurator.AddOrUpdateVirtualServers(resourceExes.VirtualServerExes)
 		virtualServerWarnings = mergeWarningsMaps(virtualServerWarnings, warnings)
@@ -181,6 +207,14 @@ func (lbc *LoadBalancerController) syncPolicy(task task) {
 		}
 	}
 
+	if len(resourceExes.TransportServerExes) > 0 {
+		warnings, updateErr := lbc.configurator.AddOrUpdateTransportServers(resourceExes.TransportServerExes)
+		transportServerWarnings = mergeWarningsMaps(transportServerWarnings, warnings)
+		if updateErr != nil {
+			transportServerErr = updateErr
+		}
+	}
+
 	if len(resourceExes.IngressExes) > 0 {
 		warnings, updateErr := lbc.configurator.AddOrUpdateIngresses(resourceExes.IngressExes)
 		ingressWarnings = mergeWarningsMaps(ingressWarnings, warnings)
@@ -205,11 +239,14 @@ func (lbc *LoadBalancerController) syncPolicy(task task) {
 	var virtualServerResources []Resource
 	var ingressResources []Resource
 	var mergeableIngressResources []Resource
+	var transportServerResources []Resource
 
 	for _, res := range resourcesWithWarnings {
 		switch impl := res.(type) {
 		case *VirtualServerConfiguration:
 			virtualServerResources = append(virtualServerResources, res)
+		case *TransportServerConfiguration:
+			transportServerResources = append(transportServerResources, res)
 		case *IngressConfiguration:
 			if impl.IsMaster {
 				mergeableIngressResources = append(mergeableIngressResources, res)
@@ -221,11 +258,10 @@ func (lbc *LoadBalancerController) syncPolicy(task task) {
 
 	lbc.updateResourcesStatusAndEvents(virtualServerResources, virtualServerWarnings, virtualServerErr)
 	lbc.updateResourcesStatusAndEvents(ingressResources, ingressWarnings, ingressErr)
+	lbc.updateResourcesStatusAndEvents(transportServerResources, transportServerWarnings, transportServerErr)
 	for _, mergeableIngressResource := range mergeableIngressResources {
 		ingressCfg := mergeableIngressResource.(*IngressConfiguration)
 		mergeableIngressErr := mergeableIngressErrors[getResourceKey(&ingressCfg.Ingress.ObjectMeta)]
 		lbc.updateResourcesStatusAndEvents([]Resource{mergeableIngressResource}, mergeableIngressWarnings, mergeableIngressErr)
 	}
-
-	// Note: updating the status of a policy based on a reload is not needed.
 }
@@ -97,7 +97,15 @@ func (rc *secretReferenceChecker) IsReferencedByTransportServer(secretNamespace
 		return false
 	}
 
-	if ts.Spec.TLS != nil && ts.Spec.TLS.Secret == secretName {
+	if ts.Spec.TLS == nil {
+		return false
+	}
+
+	if ts.Spec.TLS.Secret == secretName {
+		return true
+	}
+
+	if ts.Spec.TLS.MTLS != nil && ts.Spec.TLS.MTLS.ClientCertSecret == secretName {
 		return true
 	}
 
@@ -150,14 +158,17 @@ func (rc *serviceReferenceChecker) IsReferencedByMinion(svcNamespace string, svc
 }
 
 func (rc *serviceReferenceChecker) IsReferencedByVirtualServer(svcNamespace string, svcName string, vs *conf_v1.VirtualServer) bool {
-	if vs.Namespace == svcNamespace {
-		for _, u := range vs.Spec.Upstreams {
-			if rc.hasClusterIP && u.UseClusterIP {
-				continue
-			}
-			if u.Service == svcName || u.Backup == svcName {
-				return true
-			}
+	for _, u := range vs.Spec.Upstreams {
+		if rc.hasClusterIP && u.UseClusterIP {
+			continue
+		}
+		// the service of an upstream can be referenced as namespace/name
+		namespace, name := configs.ParseServiceReference(u.Service, vs.Namespace)
+		if namespace == svcNamespace && name == svcName {
+			return true
+		}
+		if vs.Namespace == svcNamespace && u.Backup == svcName {
+			return true
 		}
 	}
 
@@ -232,13 +243,26 @@ func (rc *serviceReferenceChecker) IsReferencedByTransportServer(svcNamespace st
 	return false
 }
 
-type policyReferenceChecker struct{}
+// policyReferenceChecker also treats the default policies of the GlobalConfiguration applied to a resource as its references.
+type policyReferenceChecker struct {
+	defaultPolicies *defaultPolicies
+}
 
-func newPolicyReferenceChecker() *policyReferenceChecker {
-	return &policyReferenceChecker{}
+func newPolicyReferenceChecker(defaultPolicies *defaultPolicies) *policyReferenceChecker {
+	return &policyReferenceChecker{
+		defaultPolicies: defaultPolicies,
+	}
 }
 
 func (rc *policyReferenceChecker) IsReferencedByIngress(policyNamespace string, policyName string, ing *networking.Ingress) bool {
+	if isPolicyReferencedByIngressAnnotations(policyNamespace, policyName, ing) {
+		return true
+	}
+
+	return isPolicyReferenced(rc.defaultPolicies.forIngress(ing), ing.Namespace, policyNamespace, policyName)
+}
+
+func isPolicyReferencedByIngressAnnotations(policyNamespace string, policyName string, ing *networking.Ingress) bool {
 	for _, annotation := range []string{configs.PoliciesAnnotation, configs.PoliciesAnnotationPlus} {
 		if value, exists := ing.Annotations[annotation]; exists {
 			for _, p := range strings.Split(value, ",") {
@@ -268,7 +292,7 @@ func (rc *policyReferenceChecker) IsReferencedByVirtualServer(policyNamespace st
 		}
 	}
 
-	return false
+	return isPolicyReferenced(rc.defaultPolicies.forVirtualServer(vs), vs.Namespace, policyNamespace, policyName)
 }
 
 func (rc *policyReferenceChecker) IsReferencedByVirtualServerRoute(policyNamespace string, policyName string, vsr *conf_v1.VirtualServerRoute) bool {
@@ -278,11 +302,11 @@ func (rc *policyReferenceChecker) IsReferencedByVirtualServerRoute(policyNamespa
 		}
 	}
 
-	return false
+	return isPolicyReferenced(rc.defaultPolicies.forVirtualServerRoute(vsr), vsr.Namespace, policyNamespace, policyName)
 }
 
-func (rc *policyReferenceChecker) IsReferencedByTransportServer(_ string, _ string, _ *conf_v1.TransportServer) bool {
-	return false
+func (rc *policyReferenceChecker) IsReferencedByTransportServer(policyNamespace string, policyName string, ts *conf_v1.TransportServer) bool {
+	return isPolicyReferenced(ts.Spec.Policies, ts.Namespace, policyNamespace, policyName)
 }
 
 // appProtectResourceReferenceChecker is a reference checker for AppProtect related resources.
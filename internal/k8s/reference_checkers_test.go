@@ -352,6 +352,25 @@ func TestSecretIsReferencedByTransportServer(t *testing.T) {
 			expected:        false,
 			msg:             "tls secret is referenced but in another namespace",
 		},
+		{
+			ts: &conf_v1.TransportServer{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "default",
+				},
+				Spec: conf_v1.TransportServerSpec{
+					TLS: &conf_v1.TransportServerTLS{
+						Secret: "test-secret",
+						MTLS: &conf_v1.TransportServerMTLS{
+							ClientCertSecret: "test-ca-secret",
+						},
+					},
+				},
+			},
+			secretNamespace: "default",
+			secretName:      "test-ca-secret",
+			expected:        true,
+			msg:             "client certificate secret is referenced",
+		},
 		{
 			ts: &conf_v1.TransportServer{
 				ObjectMeta: v1.ObjectMeta{
@@ -558,7 +577,7 @@ func TestPolicyIsReferencedByIngress(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		rc := newPolicyReferenceChecker()
+		rc := newPolicyReferenceChecker(newDefaultPolicies())
 
 		result := rc.IsReferencedByIngress(test.policyNamespace, test.policyName, test.ing)
 		if result != test.expected {
@@ -747,7 +766,7 @@ func TestPolicyIsReferencedByMinion(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		rc := newPolicyReferenceChecker()
+		rc := newPolicyReferenceChecker(newDefaultPolicies())
 
 		result := rc.IsReferencedByMinion(test.policyNamespace, test.policyName, test.ing)
 		if result != test.expected {
@@ -1082,6 +1101,51 @@ func TestServiceIsReferencedByVirtualServerAndVirtualServerRoutes(t *testing.T)
 	}
 }
 
+func TestServiceWithNamespaceIsReferencedByVirtualServer(t *testing.T) {
+	t.Parallel()
+	vs := &conf_v1.VirtualServer{
+		ObjectMeta: v1.ObjectMeta{
+			Namespace: "default",
+		},
+		Spec: conf_v1.VirtualServerSpec{
+			Upstreams: []conf_v1.Upstream{
+				{
+					Service: "tea/tea-svc",
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		serviceNamespace string
+		serviceName      string
+		expected         bool
+		msg              string
+	}{
+		{
+			serviceNamespace: "tea",
+			serviceName:      "tea-svc",
+			expected:         true,
+			msg:              "service from another namespace is referenced in an upstream",
+		},
+		{
+			serviceNamespace: "default",
+			serviceName:      "tea-svc",
+			expected:         false,
+			msg:              "wrong namespace for service in an upstream",
+		},
+	}
+
+	for _, test := range tests {
+		rc := newServiceReferenceChecker(false, nil)
+
+		result := rc.IsReferencedByVirtualServer(test.serviceNamespace, test.serviceName, vs)
+		if result != test.expected {
+			t.Errorf("IsReferencedByVirtualServer() returned %v but expected %v for the case of %s", result, test.expected, test.msg)
+		}
+	}
+}
+
 func TestServiceIsReferencedByTransportServer(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -1234,11 +1298,50 @@ func TestBackupServiceIsReferencedByTransportServer(t *testing.T) {
 
 func TestPolicyIsReferencedByTransportServers(t *testing.T) {
 	t.Parallel()
-	rc := newPolicyReferenceChecker()
+	ts := &conf_v1.TransportServer{
+		ObjectMeta: v1.ObjectMeta{
+			Namespace: "default",
+		},
+		Spec: conf_v1.TransportServerSpec{
+			Policies: []conf_v1.PolicyReference{
+				{
+					Name: "test-policy",
+				},
+			},
+		},
+	}
+	tests := []struct {
+		policyNamespace string
+		policyName      string
+		expected        bool
+		msg             string
+	}{
+		{
+			policyNamespace: "default",
+			policyName:      "test-policy",
+			expected:        true,
+			msg:             "policy is referenced",
+		},
+		{
+			policyNamespace: "some-namespace",
+			policyName:      "test-policy",
+			expected:        false,
+			msg:             "wrong namespace for policy",
+		},
+		{
+			policyNamespace: "default",
+			policyName:      "some-policy",
+			expected:        false,
+			msg:             "wrong name for policy",
+		},
+	}
 
-	result := rc.IsReferencedByTransportServer("", "", nil)
-	if result {
-		t.Error("IsReferencedByTransportServer() returned true but expected false")
+	rc := newPolicyReferenceChecker(newDefaultPolicies())
+	for _, test := range tests {
+		result := rc.IsReferencedByTransportServer(test.policyNamespace, test.policyName, ts)
+		if result != test.expected {
+			t.Errorf("IsReferencedByTransportServer() returned %v but expected %v for the case of %s", result, test.expected, test.msg)
+		}
 	}
 }
 
@@ -1432,7 +1535,7 @@ func TestPolicyIsReferencedByVirtualServerAndVirtualServerRoute(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		rc := newPolicyReferenceChecker()
+		rc := newPolicyReferenceChecker(newDefaultPolicies())
 
 		result := rc.IsReferencedByVirtualServer(test.policyNamespace, test.policyName, test.vs)
 		if result != test.expected {
@@ -2301,3 +2404,33 @@ func TestExternalAuthServiceIsReferencedByVirtualServerRoute(t *testing.T) {
 		}
 	}
 }
+
+func TestPolicyIsReferencedByDefaultPolicies(t *testing.T) {
+	t.Parallel()
+	rc := newPolicyReferenceChecker(&defaultPolicies{
+		policies: []conf_v1.DefaultPolicies{
+			{
+				Policies:   []conf_v1.PolicyReference{{Name: "waf", Namespace: "security"}},
+				Namespaces: []string{"default"},
+			},
+		},
+	})
+
+	ing := &networking.Ingress{ObjectMeta: v1.ObjectMeta{Namespace: "default"}}
+	if !rc.IsReferencedByIngress("security", "waf", ing) {
+		t.Error("IsReferencedByIngress() returned false for a default policy")
+	}
+	if !rc.IsReferencedByMinion("security", "waf", ing) {
+		t.Error("IsReferencedByMinion() returned false for a default policy")
+	}
+
+	vs := &conf_v1.VirtualServer{ObjectMeta: v1.ObjectMeta{Namespace: "default"}}
+	if !rc.IsReferencedByVirtualServer("security", "waf", vs) {
+		t.Error("IsReferencedByVirtualServer() returned false for a default policy")
+	}
+
+	vsr := &conf_v1.VirtualServerRoute{ObjectMeta: v1.ObjectMeta{Namespace: "other"}}
+	if rc.IsReferencedByVirtualServerRoute("security", "waf", vsr) {
+		t.Error("IsReferencedByVirtualServerRoute() returned true for a default policy of another namespace")
+	}
+}
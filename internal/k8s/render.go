@@ -0,0 +1,281 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/nginx/kubernetes-ingress/internal/configs"
+	"github.com/nginx/kubernetes-ingress/internal/k8s/appprotect"
+	"github.com/nginx/kubernetes-ingress/internal/k8s/appprotectdos"
+	"github.com/nginx/kubernetes-ingress/internal/k8s/secrets"
+	nl "github.com/nginx/kubernetes-ingress/internal/logger"
+	"github.com/nginx/kubernetes-ingress/internal/metrics/collectors"
+	conf_v1 "github.com/nginx/kubernetes-ingress/pkg/apis/configuration/v1"
+	"github.com/nginx/kubernetes-ingress/pkg/apis/configuration/validation"
+	api_v1 "k8s.io/api/core/v1"
+	discovery_v1 "k8s.io/api/discovery/v1"
+	networking "k8s.io/api/networking/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+)
+
+// RenderInput holds the input needed to call Render.
+type RenderInput struct {
+	NginxConfigurator            *configs.Configurator
+	Objects                      []runtime.Object
+	ConfigMap                    *api_v1.ConfigMap
+	IngressClass                 string
+	IsNginxPlus                  bool
+	EnableOIDC                   bool
+	InternalRoutesEnabled        bool
+	IsTLSPassthroughEnabled      bool
+	SnippetsEnabled              bool
+	CertManagerEnabled           bool
+	IsIPV6Disabled               bool
+	IsDirectiveAutoadjustEnabled bool
+	AllowEmptyIngressHost        bool
+	VirtualServerValidator       *validation.VirtualServerValidator
+	GlobalConfigurationValidator *validation.GlobalConfigurationValidator
+	TransportServerValidator     *validation.TransportServerValidator
+	Logger                       *slog.Logger
+}
+
+// RenderWarning is a Warning event reported for a resource while rendering the configuration.
+type RenderWarning struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Reason    string
+	Message   string
+}
+
+func (w RenderWarning) String() string {
+	if w.Namespace == "" {
+		return fmt.Sprintf("%s %s: %s: %s", w.Kind, w.Name, w.Reason, w.Message)
+	}
+	return fmt.Sprintf("%s %s/%s: %s: %s", w.Kind, w.Namespace, w.Name, w.Reason, w.Message)
+}
+
+// Render generates the NGINX configuration for the given objects without connecting to a cluster.
+// The objects are processed the same way the Ingress Controller processes them during startup, and the configuration
+// is written through the NginxManager of the NginxConfigurator. Objects of the kinds the Ingress Controller doesn't
+// watch are ignored. Render returns the Warning events that would have been reported for the resources, sorted by resource.
+func Render(input RenderInput) ([]RenderWarning, error) {
+	recorder := &renderEventRecorder{}
+
+	lbc := &LoadBalancerController{
+		configurator:              input.NginxConfigurator,
+		Logger:                    input.Logger,
+		ctx:                       nl.ContextWithLogger(context.Background(), input.Logger),
+		recorder:                  recorder,
+		ingressClass:              input.IngressClass,
+		isNginxPlus:               input.IsNginxPlus,
+		enableOIDC:                input.EnableOIDC,
+		areCustomResourcesEnabled: true,
+		internalRoutesEnabled:     input.InternalRoutesEnabled,
+		// Status updates require a leader. With leader election enabled and no leader elector,
+		// the resource statuses are never written.
+		isLeaderElectionEnabled:      true,
+		globalConfigurationValidator: input.GlobalConfigurationValidator,
+		transportServerValidator:     input.TransportServerValidator,
+		metricsCollector:             collectors.NewControllerFakeCollector(),
+		configMap:                    input.ConfigMap,
+		endpointSliceWarnings:        make(map[string]bool),
+		certificateExpiryWarnings:    make(map[string]certificateExpiryWarning),
+		namespacedInformers:          make(map[string]*namespacedInformer),
+		metadata: controllerMetadata{
+			pod: &api_v1.Pod{
+				ObjectMeta: meta_v1.ObjectMeta{
+					OwnerReferences: []meta_v1.OwnerReference{{Kind: "Deployment", Name: "nginx-ingress"}},
+				},
+			},
+		},
+	}
+	lbc.syncQueue = newTaskQueue(lbc.Logger, lbc.sync)
+	lbc.configuration = NewConfiguration(
+		lbc.HasCorrectIngressClass,
+		input.IsNginxPlus,
+		false,
+		false,
+		input.InternalRoutesEnabled,
+		input.VirtualServerValidator,
+		input.GlobalConfigurationValidator,
+		input.TransportServerValidator,
+		input.IsTLSPassthroughEnabled,
+		input.SnippetsEnabled,
+		input.CertManagerEnabled,
+		input.IsIPV6Disabled,
+		input.IsDirectiveAutoadjustEnabled,
+		input.AllowEmptyIngressHost,
+	)
+	lbc.appProtectConfiguration = appprotect.NewConfiguration(lbc.Logger)
+	lbc.dosConfiguration = appprotectdos.NewConfiguration(false)
+	lbc.secretStore = secrets.NewLocalSecretStore(lbc.configurator)
+	// only the expired certificates are reported, the thresholds of the expiring certificates depend on the time of the rendering
+	lbc.certificateInspector = secrets.NewCertificateInspector(lbc.secretStore, nil)
+
+	// All objects are stored in a single informer for all namespaces, like when the Ingress Controller watches the whole cluster.
+	nsi := &namespacedInformer{
+		ingressLister:             storeToIngressLister{Store: cache.NewStore(cache.MetaNamespaceKeyFunc)},
+		svcLister:                 cache.NewStore(cache.MetaNamespaceKeyFunc),
+		endpointSliceLister:       storeToEndpointSliceLister{Store: cache.NewStore(cache.MetaNamespaceKeyFunc)},
+		podLister:                 indexerToPodLister{Indexer: cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})},
+		secretLister:              cache.NewStore(cache.MetaNamespaceKeyFunc),
+		virtualServerLister:       cache.NewStore(cache.MetaNamespaceKeyFunc),
+		virtualServerRouteLister:  cache.NewStore(cache.MetaNamespaceKeyFunc),
+		transportServerLister:     cache.NewStore(cache.MetaNamespaceKeyFunc),
+		policyLister:              cache.NewStore(cache.MetaNamespaceKeyFunc),
+		isSecretsEnabledNamespace: true,
+		areCustomResourcesEnabled: true,
+	}
+	lbc.namespacedInformers[""] = nsi
+
+	var tasks []task
+	var gcKey string
+
+	for _, obj := range input.Objects {
+		var store cache.Store
+		var k kind
+		var queued bool
+
+		switch obj.(type) {
+		case *networking.Ingress:
+			store, k, queued = nsi.ingressLister.Store, ingress, true
+		case *api_v1.Service:
+			store = nsi.svcLister
+		case *discovery_v1.EndpointSlice:
+			store = nsi.endpointSliceLister.Store
+		case *api_v1.Pod:
+			store = nsi.podLister.Indexer
+		case *api_v1.Secret:
+			store = nsi.secretLister
+		case *conf_v1.VirtualServer:
+			store, k, queued = nsi.virtualServerLister, virtualserver, true
+		case *conf_v1.VirtualServerRoute:
+			store, k, queued = nsi.virtualServerRouteLister, virtualServerRoute, true
+		case *conf_v1.TransportServer:
+			store, k, queued = nsi.transportServerLister, transportserver, true
+		case *conf_v1.Policy:
+			store, k, queued = nsi.policyLister, policy, true
+		case *conf_v1.GlobalConfiguration:
+			if lbc.globalConfigurationLister != nil {
+				return nil, fmt.Errorf("only one GlobalConfiguration is supported")
+			}
+			lbc.globalConfigurationLister = cache.NewStore(cache.MetaNamespaceKeyFunc)
+			store, k, queued = lbc.globalConfigurationLister, globalConfiguration, true
+		default:
+			nl.Debugf(lbc.Logger, "Ignoring object of unsupported type %T", obj)
+			continue
+		}
+
+		if err := store.Add(obj); err != nil {
+			return nil, err
+		}
+
+		if !queued {
+			continue
+		}
+
+		key, err := keyFunc(obj)
+		if err != nil {
+			return nil, err
+		}
+		if k == globalConfiguration {
+			gcKey = key
+			continue
+		}
+		tasks = append(tasks, task{Kind: k, Key: key})
+	}
+
+	lbc.preSyncSecrets()
+
+	// The GlobalConfiguration defines the listeners of VirtualServers and TransportServers, so it must be processed first.
+	if gcKey != "" {
+		lbc.syncGlobalConfiguration(task{Kind: globalConfiguration, Key: gcKey})
+	}
+
+	// Policies must be processed before the resources that reference them so that their validation events are reported.
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return tasks[i].Kind == policy && tasks[j].Kind != policy
+	})
+	for _, t := range tasks {
+		switch t.Kind {
+		case ingress:
+			lbc.syncIngress(t)
+		case virtualserver:
+			lbc.syncVirtualServer(t)
+		case virtualServerRoute:
+			lbc.syncVirtualServerRoute(t)
+		case transportserver:
+			lbc.syncTransportServer(t)
+		case policy:
+			lbc.syncPolicy(t)
+		}
+	}
+
+	// See the startup sequence in sync().
+
+	_, problems := lbc.configuration.CompleteStartup()
+	lbc.processProblems(problems)
+	lbc.updateAllConfigs()
+
+	return recorder.warnings(), nil
+}
+
+// renderEventRecorder is an EventRecorder that keeps the Warning events.
+type renderEventRecorder struct {
+	mu     sync.Mutex
+	events []RenderWarning
+}
+
+func (r *renderEventRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	if eventtype != api_v1.EventTypeWarning {
+		return
+	}
+
+	w := RenderWarning{
+		Kind:    reflect.Indirect(reflect.ValueOf(object)).Type().Name(),
+		Reason:  reason,
+		Message: message,
+	}
+	if obj, ok := object.(meta_v1.Object); ok {
+		w.Namespace = obj.GetNamespace()
+		w.Name = obj.GetName()
+	}
+
+	r.mu.Lock()
+	r.events = append(r.events, w)
+	r.mu.Unlock()
+}
+
+func (r *renderEventRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.Event(object, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (r *renderEventRecorder) AnnotatedEventf(object runtime.Object, _ map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.Eventf(object, eventtype, reason, messageFmt, args...)
+}
+
+func (r *renderEventRecorder) warnings() []RenderWarning {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	warnings := make([]RenderWarning, len(r.events))
+	copy(warnings, r.events)
+	sort.SliceStable(warnings, func(i, j int) bool {
+		if warnings[i].Kind != warnings[j].Kind {
+			return warnings[i].Kind < warnings[j].Kind
+		}
+		if warnings[i].Namespace != warnings[j].Namespace {
+			return warnings[i].Namespace < warnings[j].Namespace
+		}
+		return warnings[i].Name < warnings[j].Name
+	})
+
+	return warnings
+}
@@ -0,0 +1,100 @@
+package k8s
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	nl "github.com/nginx/kubernetes-ingress/internal/logger"
+	"github.com/nginx/kubernetes-ingress/internal/nginx"
+	conf_v1 "github.com/nginx/kubernetes-ingress/pkg/apis/configuration/v1"
+	"github.com/nginx/kubernetes-ingress/pkg/apis/configuration/validation"
+	api_v1 "k8s.io/api/core/v1"
+	discovery_v1 "k8s.io/api/discovery/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+
+	outputDir := t.TempDir()
+	logger := nl.LoggerFromContext(context.Background())
+	manager := nginx.NewRenderManager(outputDir, logger)
+
+	objects := []runtime.Object{
+		&conf_v1.VirtualServer{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "cafe", Namespace: "default"},
+			Spec: conf_v1.VirtualServerSpec{
+				Host:     "cafe.example.com",
+				Policies: []conf_v1.PolicyReference{{Name: "invalid-rate-limit"}},
+				Upstreams: []conf_v1.Upstream{
+					{Name: "tea", Service: "tea-svc", Port: 80},
+				},
+				Routes: []conf_v1.Route{
+					{Path: "/tea", Action: &conf_v1.Action{Pass: "tea"}},
+				},
+			},
+		},
+		&conf_v1.Policy{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "invalid-rate-limit", Namespace: "default"},
+			Spec: conf_v1.PolicySpec{
+				RateLimit: &conf_v1.RateLimit{Rate: "invalid"},
+			},
+		},
+		&api_v1.Service{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "tea-svc", Namespace: "default"},
+			Spec: api_v1.ServiceSpec{
+				Ports: []api_v1.ServicePort{{Name: "http", Port: 80, TargetPort: intstr.FromInt32(8080)}},
+			},
+		},
+		&discovery_v1.EndpointSlice{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "tea-svc-abcde",
+				Namespace: "default",
+				Labels:    map[string]string{discovery_v1.LabelServiceName: "tea-svc"},
+			},
+			Endpoints: []discovery_v1.Endpoint{
+				{Addresses: []string{"10.0.0.1"}, Conditions: discovery_v1.EndpointConditions{Ready: new(true)}},
+			},
+			Ports: []discovery_v1.EndpointPort{{Name: new("http"), Port: new(int32(8080))}},
+		},
+	}
+
+	warnings, err := Render(RenderInput{
+		NginxConfigurator:      createTestPolicySyncConfigurator(t, manager),
+		Objects:                objects,
+		IngressClass:           "nginx",
+		VirtualServerValidator: validation.NewVirtualServerValidator(),
+		Logger:                 logger,
+	})
+	if err != nil {
+		t.Fatalf("Render() returned unexpected error: %v", err)
+	}
+	if err := manager.Err(); err != nil {
+		t.Fatalf("RenderManager.Err() returned unexpected error: %v", err)
+	}
+
+	expectedWarnings := []string{"Policy", "VirtualServer"}
+	var warningKinds []string
+	for _, w := range warnings {
+		warningKinds = append(warningKinds, w.Kind)
+	}
+	if diff := cmp.Diff(expectedWarnings, warningKinds); diff != "" {
+		t.Errorf("Render() returned unexpected warnings (-want +got):\n%s\n%v", diff, warnings)
+	}
+
+	content, err := os.ReadFile(filepath.Join(outputDir, "conf.d", "vs_default_cafe.conf"))
+	if err != nil {
+		t.Fatalf("failed to read the rendered VirtualServer config: %v", err)
+	}
+	for _, expected := range []string{"server_name cafe.example.com;", "server 10.0.0.1:8080"} {
+		if !strings.Contains(string(content), expected) {
+			t.Errorf("rendered VirtualServer config doesn't contain %q", expected)
+		}
+	}
+}
@@ -0,0 +1,345 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"maps"
+	"reflect"
+	"time"
+
+	"github.com/nginx/kubernetes-ingress/internal/configs"
+	nl "github.com/nginx/kubernetes-ingress/internal/logger"
+	conf_v1 "github.com/nginx/kubernetes-ingress/pkg/apis/configuration/v1"
+	"github.com/nginx/nginx-plus-go-client/v3/client"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// rolloutSyncInterval is the interval between the checks of the rollouts of the VirtualServer routes.
+const rolloutSyncInterval = 10 * time.Second
+
+// upstreamStats holds the cumulative response counters and the cumulative response time of an upstream.
+type upstreamStats struct {
+	responses    uint64
+	responses5xx uint64
+	// responseTime is the total response time of the responses, computed from the average response times of the peers
+	responseTime time.Duration
+}
+
+// upstreamStatsGetter returns the stats of the upstreams by their names.
+type upstreamStatsGetter interface {
+	getUpstreamStats(ctx context.Context) (map[string]upstreamStats, error)
+}
+
+// plusUpstreamStatsGetter gets the stats of the upstreams from the NGINX Plus API.
+type plusUpstreamStatsGetter struct {
+	plusClient *client.NginxClient
+}
+
+func (g *plusUpstreamStatsGetter) getUpstreamStats(ctx context.Context) (map[string]upstreamStats, error) {
+	upstreams, err := g.plusClient.GetUpstreams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make(map[string]upstreamStats)
+	for name, u := range *upstreams {
+		var s upstreamStats
+		for _, p := range u.Peers {
+			s.responses += p.Responses.Total
+			s.responses5xx += p.Responses.Responses5xx
+			s.responseTime += time.Duration(p.ResponseTime*p.Responses.Total) * time.Millisecond
+		}
+		stats[name] = s
+	}
+
+	return stats, nil
+}
+
+// rolloutAnalysis holds the responses of the canary upstream since the previous check.
+// The error rate and the average response time are computed only from the responses since the previous check,
+// so that they are not hidden by the responses of the previous steps.
+type rolloutAnalysis struct {
+	responses    uint64
+	errorRate    float64
+	responseTime time.Duration
+}
+
+// analyzeUpstreamStats returns the analysis of the responses between the previous and the current stats.
+// The counters are reset when NGINX reloads, in which case the current stats are used as is.
+func analyzeUpstreamStats(prev upstreamStats, cur upstreamStats) rolloutAnalysis {
+	if cur.responses < prev.responses || cur.responses5xx < prev.responses5xx {
+		prev = upstreamStats{}
+	}
+
+	a := rolloutAnalysis{
+		responses: cur.responses - prev.responses,
+	}
+	if a.responses > 0 {
+		a.errorRate = float64(cur.responses5xx-prev.responses5xx) * 100 / float64(a.responses)
+		if cur.responseTime > prev.responseTime {
+			a.responseTime = (cur.responseTime - prev.responseTime) / time.Duration(a.responses)
+		}
+	}
+
+	return a
+}
+
+// checkRolloutThresholds returns a message if the analysis exceeds the thresholds of the rollout.
+// The thresholds are not checked if the canary upstream didn't respond since the previous check.
+func checkRolloutThresholds(rollout *conf_v1.Rollout, a rolloutAnalysis) string {
+	if a.responses == 0 {
+		return ""
+	}
+
+	if rollout.MaxErrorRate != nil && a.errorRate > float64(*rollout.MaxErrorRate) {
+		return fmt.Sprintf("error rate %.1f%% exceeded the maximum error rate %d%%", a.errorRate, *rollout.MaxErrorRate)
+	}
+
+	if rollout.MaxResponseTime != "" {
+		maxResponseTime, err := configs.ParseTimeDuration(rollout.MaxResponseTime)
+		if err == nil && a.responseTime > maxResponseTime {
+			return fmt.Sprintf("response time %v exceeded the maximum response time %v", a.responseTime, maxResponseTime)
+		}
+	}
+
+	return ""
+}
+
+// rolloutRevision returns the hash of the rollout and the actions of the splits of the route.
+// The weights are excluded, because the rollout changes them.
+func rolloutRevision(route conf_v1.Route) string {
+	var actions []*conf_v1.Action
+	for _, s := range route.Splits {
+		actions = append(actions, s.Action)
+	}
+
+	data, _ := json.Marshal(struct {
+		Rollout *conf_v1.Rollout
+		Actions []*conf_v1.Action
+	}{route.Rollout, actions})
+
+	h := fnv.New32a()
+	_, _ = h.Write(data)
+	return fmt.Sprintf("%08x", h.Sum32())
+}
+
+// progressRollout returns the next status of the rollout of the route.
+// A new revision starts the rollout from its first step. A rollout moves to the next step when the duration of the
+// current step passes, and it is rolled back when the analysis exceeds the thresholds.
+func progressRollout(route conf_v1.Route, status *conf_v1.RolloutStatus, a rolloutAnalysis, now time.Time) conf_v1.RolloutStatus {
+	rollout := route.Rollout
+	revision := rolloutRevision(route)
+
+	if status == nil || status.Revision != revision {
+		return conf_v1.RolloutStatus{
+			Path:          route.Path,
+			Revision:      revision,
+			Phase:         conf_v1.RolloutPhaseProgressing,
+			Weight:        rollout.Steps[0].Weight,
+			StepStartTime: meta_v1.NewTime(now),
+		}
+	}
+
+	next := *status
+	next.Path = route.Path
+	if next.Phase != conf_v1.RolloutPhaseProgressing {
+		return next
+	}
+
+	if msg := checkRolloutThresholds(rollout, a); msg != "" {
+		next.Phase = conf_v1.RolloutPhaseRolledBack
+		next.Weight = 0
+		next.Message = msg
+		return next
+	}
+
+	if next.Step >= len(rollout.Steps) {
+		next.Step = len(rollout.Steps) - 1
+	}
+	step := rollout.Steps[next.Step]
+
+	var duration time.Duration
+	if step.Duration != "" {
+		var err error
+		duration, err = configs.ParseTimeDuration(step.Duration)
+		if err != nil {
+			return next
+		}
+	}
+	if now.Before(next.StepStartTime.Add(duration)) {
+		return next
+	}
+
+	if next.Step == len(rollout.Steps)-1 {
+		next.Phase = conf_v1.RolloutPhaseCompleted
+		return next
+	}
+
+	next.Step++
+	next.Weight = rollout.Steps[next.Step].Weight
+	next.StepStartTime = meta_v1.NewTime(now)
+	return next
+}
+
+func findRolloutStatus(rollouts []conf_v1.RolloutStatus, path string) *conf_v1.RolloutStatus {
+	for i := range rollouts {
+		if rollouts[i].Path == path {
+			return &rollouts[i]
+		}
+	}
+	return nil
+}
+
+// getRolloutStatuses returns the next statuses of the rollouts of the routes of the VirtualServer.
+func getRolloutStatuses(vs *conf_v1.VirtualServer, statuses []conf_v1.RolloutStatus, prevStats map[string]upstreamStats, stats map[string]upstreamStats, now time.Time) []conf_v1.RolloutStatus {
+	upstreamNamer := configs.NewUpstreamNamerForVirtualServer(vs)
+
+	var rollouts []conf_v1.RolloutStatus
+	for _, route := range vs.Spec.Routes {
+		if route.Rollout == nil || len(route.Rollout.Steps) == 0 || len(route.Splits) != 2 {
+			continue
+		}
+
+		var a rolloutAnalysis
+		if action := route.Splits[1].Action; action != nil {
+			upstream := upstreamNamer.GetNameForUpstreamFromAction(action)
+			prev, prevExists := prevStats[upstream]
+			cur, curExists := stats[upstream]
+			if prevExists && curExists {
+				a = analyzeUpstreamStats(prev, cur)
+			}
+		}
+
+		rollouts = append(rollouts, progressRollout(route, findRolloutStatus(statuses, route.Path), a, now))
+	}
+
+	return rollouts
+}
+
+// getRolloutWeightsFromStatuses returns the weights of the rollouts of the routes of the VirtualServer by the paths of
+// the routes. The statuses of the rollouts of the previous revisions of the routes are ignored.
+func getRolloutWeightsFromStatuses(vs *conf_v1.VirtualServer, rollouts []conf_v1.RolloutStatus) map[string]int {
+	var weights map[string]int
+	for _, route := range vs.Spec.Routes {
+		if route.Rollout == nil || len(route.Splits) != 2 {
+			continue
+		}
+		status := findRolloutStatus(rollouts, route.Path)
+		if status == nil || status.Revision != rolloutRevision(route) {
+			continue
+		}
+		if weights == nil {
+			weights = make(map[string]int)
+		}
+		weights[route.Path] = status.Weight
+	}
+	return weights
+}
+
+// getRolloutStatusesOfVirtualServer returns the statuses of the rollouts of the VirtualServer kept by the controller.
+// Before the controller syncs the rollouts, the statuses are taken from the status of the VirtualServer.
+func (lbc *LoadBalancerController) getRolloutStatusesOfVirtualServer(vs *conf_v1.VirtualServer) []conf_v1.RolloutStatus {
+	if rollouts, exists := lbc.rolloutStatuses[getResourceKey(&vs.ObjectMeta)]; exists {
+		return rollouts
+	}
+	return vs.Status.Rollouts
+}
+
+// getRolloutWeights returns the weights of the rollouts of the routes of the VirtualServer by the paths of the routes.
+func (lbc *LoadBalancerController) getRolloutWeights(vs *conf_v1.VirtualServer) map[string]int {
+	return getRolloutWeightsFromStatuses(vs, lbc.getRolloutStatusesOfVirtualServer(vs))
+}
+
+func (lbc *LoadBalancerController) runRollouts(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			// the sync runs in the sync queue, because it updates the configuration of the VirtualServers
+			lbc.syncQueue.queue.Add(task{Kind: rollout})
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// syncRollouts moves the rollouts of the VirtualServer routes through their steps and applies their weights.
+// The rollouts are kept by the controller and are never written to the spec of the VirtualServers.
+// Only the leader moves the rollouts and reports them in the status of the VirtualServers;
+// the other replicas apply the weights of the rollouts reported in the status.
+func (lbc *LoadBalancerController) syncRollouts() {
+	if !lbc.IsNginxReady() {
+		return
+	}
+
+	isLeader := lbc.reportCustomResourceStatusEnabled()
+	var prevStats, stats map[string]upstreamStats
+	if isLeader {
+		var err error
+		stats, err = lbc.rolloutStatsGetter.getUpstreamStats(lbc.ctx)
+		if err != nil {
+			nl.Errorf(lbc.Logger, "error getting upstream stats for rollouts: %v", err)
+			return
+		}
+		prevStats = lbc.rolloutStats
+		lbc.rolloutStats = stats
+	}
+
+	now := time.Now()
+	rolloutStatuses := make(map[string][]conf_v1.RolloutStatus)
+	for _, r := range lbc.configuration.GetResourcesWithFilter(resourceFilter{VirtualServers: true}) {
+		vsCfg := r.(*VirtualServerConfiguration)
+
+		obj, exists, err := lbc.getNamespacedInformer(vsCfg.VirtualServer.Namespace).virtualServerLister.Get(vsCfg.VirtualServer)
+		if err != nil || !exists {
+			continue
+		}
+		vs := obj.(*conf_v1.VirtualServer)
+
+		rollouts := vs.Status.Rollouts
+		if isLeader {
+			rollouts = getRolloutStatuses(vsCfg.VirtualServer, lbc.getRolloutStatusesOfVirtualServer(vs), prevStats, stats, now)
+		}
+		if len(rollouts) == 0 && len(vs.Status.Rollouts) == 0 {
+			continue
+		}
+
+		key := getResourceKey(&vs.ObjectMeta)
+		weights := getRolloutWeightsFromStatuses(vsCfg.VirtualServer, rollouts)
+		if !maps.Equal(weights, lbc.getRolloutWeights(vsCfg.VirtualServer)) {
+			for path, weight := range weights {
+				nl.Infof(lbc.Logger, "Setting weight of rollout of route %v of VirtualServer %v to %v", path, key, weight)
+			}
+			lbc.rolloutStatuses[key] = rollouts
+			vsEx := lbc.createVirtualServerEx(vsCfg.VirtualServer, vsCfg.VirtualServerRoutes, vsCfg.VirtualServerRouteSelectors)
+			if _, err := lbc.configurator.UpdateVirtualServerRolloutWeights(vsEx); err != nil {
+				nl.Errorf(lbc.Logger, "error updating weights of rollouts of VirtualServer %v: %v", key, err)
+			}
+		}
+		rolloutStatuses[key] = rollouts
+
+		if isLeader {
+			if err := lbc.updateVirtualServerRolloutStatuses(vs, rollouts); err != nil {
+				nl.Errorf(lbc.Logger, "error updating rollouts of VirtualServer %v: %v", key, err)
+			}
+		}
+	}
+	lbc.rolloutStatuses = rolloutStatuses
+}
+
+// updateVirtualServerRolloutStatuses updates the rollout statuses of the VirtualServer.
+func (lbc *LoadBalancerController) updateVirtualServerRolloutStatuses(vs *conf_v1.VirtualServer, rollouts []conf_v1.RolloutStatus) error {
+	if reflect.DeepEqual(vs.Status.Rollouts, rollouts) {
+		return nil
+	}
+
+	vsCopy := vs.DeepCopy()
+	vsCopy.Status.Rollouts = rollouts
+
+	_, err := lbc.confClient.K8sV1().VirtualServers(vsCopy.Namespace).UpdateStatus(context.TODO(), vsCopy, meta_v1.UpdateOptions{})
+	return err
+}
@@ -0,0 +1,336 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nginx/kubernetes-ingress/internal/k8s/secrets"
+	nl "github.com/nginx/kubernetes-ingress/internal/logger"
+	"github.com/nginx/kubernetes-ingress/internal/metrics/collectors"
+	"github.com/nginx/kubernetes-ingress/internal/nginx"
+	conf_v1 "github.com/nginx/kubernetes-ingress/pkg/apis/configuration/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+)
+
+func createTestRolloutRoute() conf_v1.Route {
+	return conf_v1.Route{
+		Path: "/tea",
+		Splits: []conf_v1.Split{
+			{Weight: 100, Action: &conf_v1.Action{Pass: "tea-v1"}},
+			{Weight: 0, Action: &conf_v1.Action{Pass: "tea-v2"}},
+		},
+		Rollout: &conf_v1.Rollout{
+			Steps: []conf_v1.RolloutStep{
+				{Weight: 10, Duration: "1m"},
+				{Weight: 50, Duration: "1m"},
+				{Weight: 100},
+			},
+			MaxErrorRate:    new(5),
+			MaxResponseTime: "500ms",
+		},
+	}
+}
+
+func TestProgressRollout(t *testing.T) {
+	t.Parallel()
+
+	route := createTestRolloutRoute()
+	revision := rolloutRevision(route)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	healthy := rolloutAnalysis{responses: 100, errorRate: 1, responseTime: 100 * time.Millisecond}
+
+	tests := []struct {
+		status   *conf_v1.RolloutStatus
+		analysis rolloutAnalysis
+		now      time.Time
+		expected conf_v1.RolloutStatus
+		msg      string
+	}{
+		{
+			status: nil,
+			now:    start,
+			expected: conf_v1.RolloutStatus{
+				Path: "/tea", Revision: revision, Phase: conf_v1.RolloutPhaseProgressing, Weight: 10, StepStartTime: meta_v1.NewTime(start),
+			},
+			msg: "new rollout",
+		},
+		{
+			status: &conf_v1.RolloutStatus{
+				Path: "/tea", Revision: "outdated", Phase: conf_v1.RolloutPhaseRolledBack, Step: 1, Weight: 0, StepStartTime: meta_v1.NewTime(start),
+			},
+			now: start.Add(time.Hour),
+			expected: conf_v1.RolloutStatus{
+				Path: "/tea", Revision: revision, Phase: conf_v1.RolloutPhaseProgressing, Weight: 10, StepStartTime: meta_v1.NewTime(start.Add(time.Hour)),
+			},
+			msg: "new revision restarts the rollout",
+		},
+		{
+			status: &conf_v1.RolloutStatus{
+				Path: "/tea", Revision: revision, Phase: conf_v1.RolloutPhaseProgressing, Weight: 10, StepStartTime: meta_v1.NewTime(start),
+			},
+			analysis: healthy,
+			now:      start.Add(30 * time.Second),
+			expected: conf_v1.RolloutStatus{
+				Path: "/tea", Revision: revision, Phase: conf_v1.RolloutPhaseProgressing, Weight: 10, StepStartTime: meta_v1.NewTime(start),
+			},
+			msg: "step in progress",
+		},
+		{
+			status: &conf_v1.RolloutStatus{
+				Path: "/tea", Revision: revision, Phase: conf_v1.RolloutPhaseProgressing, Weight: 10, StepStartTime: meta_v1.NewTime(start),
+			},
+			analysis: healthy,
+			now:      start.Add(time.Minute),
+			expected: conf_v1.RolloutStatus{
+				Path: "/tea", Revision: revision, Phase: conf_v1.RolloutPhaseProgressing, Step: 1, Weight: 50, StepStartTime: meta_v1.NewTime(start.Add(time.Minute)),
+			},
+			msg: "next step",
+		},
+		{
+			status: &conf_v1.RolloutStatus{
+				Path: "/tea", Revision: revision, Phase: conf_v1.RolloutPhaseProgressing, Step: 2, Weight: 100, StepStartTime: meta_v1.NewTime(start),
+			},
+			analysis: healthy,
+			now:      start.Add(10 * time.Second),
+			expected: conf_v1.RolloutStatus{
+				Path: "/tea", Revision: revision, Phase: conf_v1.RolloutPhaseCompleted, Step: 2, Weight: 100, StepStartTime: meta_v1.NewTime(start),
+			},
+			msg: "last step completes the rollout",
+		},
+		{
+			status: &conf_v1.RolloutStatus{
+				Path: "/tea", Revision: revision, Phase: conf_v1.RolloutPhaseProgressing, Step: 1, Weight: 50, StepStartTime: meta_v1.NewTime(start),
+			},
+			analysis: rolloutAnalysis{responses: 100, errorRate: 10, responseTime: 100 * time.Millisecond},
+			now:      start.Add(10 * time.Second),
+			expected: conf_v1.RolloutStatus{
+				Path: "/tea", Revision: revision, Phase: conf_v1.RolloutPhaseRolledBack, Step: 1, Weight: 0, StepStartTime: meta_v1.NewTime(start),
+				Message: "error rate 10.0% exceeded the maximum error rate 5%",
+			},
+			msg: "error rate breach",
+		},
+		{
+			status: &conf_v1.RolloutStatus{
+				Path: "/tea", Revision: revision, Phase: conf_v1.RolloutPhaseProgressing, Step: 1, Weight: 50, StepStartTime: meta_v1.NewTime(start),
+			},
+			analysis: rolloutAnalysis{responses: 100, responseTime: time.Second},
+			now:      start.Add(10 * time.Second),
+			expected: conf_v1.RolloutStatus{
+				Path: "/tea", Revision: revision, Phase: conf_v1.RolloutPhaseRolledBack, Step: 1, Weight: 0, StepStartTime: meta_v1.NewTime(start),
+				Message: "response time 1s exceeded the maximum response time 500ms",
+			},
+			msg: "response time breach",
+		},
+		{
+			status: &conf_v1.RolloutStatus{
+				Path: "/tea", Revision: revision, Phase: conf_v1.RolloutPhaseProgressing, Step: 1, Weight: 50, StepStartTime: meta_v1.NewTime(start),
+			},
+			analysis: rolloutAnalysis{errorRate: 100},
+			now:      start.Add(10 * time.Second),
+			expected: conf_v1.RolloutStatus{
+				Path: "/tea", Revision: revision, Phase: conf_v1.RolloutPhaseProgressing, Step: 1, Weight: 50, StepStartTime: meta_v1.NewTime(start),
+			},
+			msg: "no responses",
+		},
+		{
+			status: &conf_v1.RolloutStatus{
+				Path: "/tea", Revision: revision, Phase: conf_v1.RolloutPhaseRolledBack, Step: 1, Weight: 0, StepStartTime: meta_v1.NewTime(start),
+			},
+			analysis: healthy,
+			now:      start.Add(time.Hour),
+			expected: conf_v1.RolloutStatus{
+				Path: "/tea", Revision: revision, Phase: conf_v1.RolloutPhaseRolledBack, Step: 1, Weight: 0, StepStartTime: meta_v1.NewTime(start),
+			},
+			msg: "rolled back rollout",
+		},
+	}
+
+	for _, test := range tests {
+		result := progressRollout(route, test.status, test.analysis, test.now)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("progressRollout() mismatch for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestRolloutRevision(t *testing.T) {
+	t.Parallel()
+
+	route := createTestRolloutRoute()
+	revision := rolloutRevision(route)
+
+	weightsChanged := createTestRolloutRoute()
+	weightsChanged.Splits[0].Weight = 50
+	weightsChanged.Splits[1].Weight = 50
+	if result := rolloutRevision(weightsChanged); result != revision {
+		t.Errorf("rolloutRevision() returned %q for changed weights, expected %q", result, revision)
+	}
+
+	actionChanged := createTestRolloutRoute()
+	actionChanged.Splits[1].Action.Pass = "tea-v3"
+	if result := rolloutRevision(actionChanged); result == revision {
+		t.Errorf("rolloutRevision() returned the same revision %q for a changed action", result)
+	}
+
+	stepsChanged := createTestRolloutRoute()
+	stepsChanged.Rollout.Steps[0].Weight = 20
+	if result := rolloutRevision(stepsChanged); result == revision {
+		t.Errorf("rolloutRevision() returned the same revision %q for changed steps", result)
+	}
+}
+
+func TestAnalyzeUpstreamStats(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		prev     upstreamStats
+		cur      upstreamStats
+		expected rolloutAnalysis
+		msg      string
+	}{
+		{
+			prev:     upstreamStats{responses: 100, responses5xx: 10},
+			cur:      upstreamStats{responses: 200, responses5xx: 15, responseTime: 2 * time.Second},
+			expected: rolloutAnalysis{responses: 100, errorRate: 5, responseTime: 20 * time.Millisecond},
+			msg:      "new responses",
+		},
+		{
+			prev:     upstreamStats{responses: 10000, responseTime: 100 * time.Second},
+			cur:      upstreamStats{responses: 10100, responseTime: 150 * time.Second},
+			expected: rolloutAnalysis{responses: 100, responseTime: 500 * time.Millisecond},
+			msg:      "slow new responses after fast previous responses",
+		},
+		{
+			prev:     upstreamStats{responses: 100, responses5xx: 10},
+			cur:      upstreamStats{responses: 100, responses5xx: 10},
+			expected: rolloutAnalysis{},
+			msg:      "no new responses",
+		},
+		{
+			prev:     upstreamStats{responses: 100, responses5xx: 10},
+			cur:      upstreamStats{responses: 50, responses5xx: 25},
+			expected: rolloutAnalysis{responses: 50, errorRate: 50},
+			msg:      "reset counters",
+		},
+	}
+
+	for _, test := range tests {
+		result := analyzeUpstreamStats(test.prev, test.cur)
+		if diff := cmp.Diff(test.expected, result, cmp.AllowUnexported(rolloutAnalysis{})); diff != "" {
+			t.Errorf("analyzeUpstreamStats() mismatch for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestGetRolloutWeightsFromStatuses(t *testing.T) {
+	t.Parallel()
+
+	route := createTestRolloutRoute()
+	vs := &conf_v1.VirtualServer{
+		Spec: conf_v1.VirtualServerSpec{
+			Routes: []conf_v1.Route{route},
+		},
+	}
+	tests := []struct {
+		rollouts []conf_v1.RolloutStatus
+		expected map[string]int
+		msg      string
+	}{
+		{
+			rollouts: []conf_v1.RolloutStatus{{Path: "/tea", Revision: rolloutRevision(route), Weight: 10}},
+			expected: map[string]int{"/tea": 10},
+			msg:      "current revision",
+		},
+		{
+			rollouts: []conf_v1.RolloutStatus{{Path: "/tea", Revision: "previous", Weight: 10}},
+			expected: nil,
+			msg:      "previous revision",
+		},
+		{
+			rollouts: nil,
+			expected: nil,
+			msg:      "no rollouts",
+		},
+	}
+
+	for _, test := range tests {
+		result := getRolloutWeightsFromStatuses(vs, test.rollouts)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("getRolloutWeightsFromStatuses() mismatch for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+type fakeUpstreamStatsGetter struct{}
+
+func (g *fakeUpstreamStatsGetter) getUpstreamStats(_ context.Context) (map[string]upstreamStats, error) {
+	return map[string]upstreamStats{}, nil
+}
+
+func TestRolloutsSyncInSyncQueue(t *testing.T) {
+	t.Parallel()
+
+	route := createTestRolloutRoute()
+	vs := &conf_v1.VirtualServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "cafe",
+			Namespace: "default",
+		},
+		Spec: conf_v1.VirtualServerSpec{
+			Host: "cafe.example.com",
+			Upstreams: []conf_v1.Upstream{
+				{Name: "tea-v1", Service: "tea-svc-v1", Port: 80},
+				{Name: "tea-v2", Service: "tea-svc-v2", Port: 80},
+			},
+			Routes: []conf_v1.Route{route},
+		},
+	}
+
+	vsLister := cache.NewStore(cache.MetaNamespaceKeyFunc)
+	if err := vsLister.Add(vs); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	lbc := &LoadBalancerController{
+		ctx:                ctx,
+		configurator:       createTestPolicySyncConfigurator(t, nginx.NewFakeManager("/etc/nginx")),
+		configuration:      createTestConfiguration(),
+		recorder:           record.NewFakeRecorder(100),
+		secretStore:        secrets.NewEmptyFakeSecretsStore(),
+		metricsCollector:   collectors.NewControllerFakeCollector(),
+		rolloutStatsGetter: &fakeUpstreamStatsGetter{},
+		rolloutStatuses:    make(map[string][]conf_v1.RolloutStatus),
+		isNginxReady:       true,
+		namespacedInformers: map[string]*namespacedInformer{
+			"default": {
+				virtualServerLister: vsLister,
+				svcLister:           cache.NewStore(cache.MetaNamespaceKeyFunc),
+			},
+		},
+		Logger: nl.LoggerFromContext(ctx),
+	}
+	// the status of the rollouts isn't reported, so that the test doesn't need a client
+	lbc.isLeaderElectionEnabled = true
+	lbc.syncQueue = newTaskQueue(lbc.Logger, lbc.sync)
+	go lbc.syncQueue.Run(time.Millisecond, ctx.Done())
+	go lbc.runRollouts(ctx, time.Millisecond)
+
+	for i := range 50 {
+		// the status of the VirtualServer changes the weight of the rollout, which the rollout sync applies
+		vsWithStatus := vs.DeepCopy()
+		vsWithStatus.Status.Rollouts = []conf_v1.RolloutStatus{{Path: "/tea", Revision: rolloutRevision(route), Weight: i % 100}}
+		if err := vsLister.Update(vsWithStatus); err != nil {
+			t.Fatal(err)
+		}
+		lbc.syncQueue.Enqueue(vsWithStatus)
+		time.Sleep(time.Millisecond)
+	}
+
+	lbc.syncQueue.Shutdown()
+}
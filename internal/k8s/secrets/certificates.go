@@ -0,0 +1,118 @@
+package secrets
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"slices"
+	"time"
+
+	api_v1 "k8s.io/api/core/v1"
+)
+
+// CertificateExpiry holds the expiry of the certificate of a TLS or CA secret that expires first.
+type CertificateExpiry struct {
+	NotAfter time.Time
+	Subject  string
+}
+
+// GetCertificateExpiry returns the expiry of the certificate of the TLS or CA secret that expires first.
+// For TLS secrets, the certificate chain and the optional CA bundle are inspected,
+// for CA secrets, all the certificates of the CA bundle used for client mTLS and upstream TLS verification.
+// The second return value is false if the secret holds no certificates.
+func GetCertificateExpiry(secret *api_v1.Secret) (CertificateExpiry, bool) {
+	var certFields []string
+	switch secret.Type {
+	case api_v1.SecretTypeTLS:
+		certFields = []string{api_v1.TLSCertKey, CAKey}
+	case SecretTypeCA:
+		certFields = []string{CAKey}
+	default:
+		return CertificateExpiry{}, false
+	}
+
+	var expiry CertificateExpiry
+	found := false
+	for _, field := range certFields {
+		rest := secret.Data[field]
+		for {
+			var block *pem.Block
+			block, rest = pem.Decode(rest)
+			if block == nil {
+				break
+			}
+			if block.Type != "CERTIFICATE" {
+				continue
+			}
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				continue
+			}
+			if !found || cert.NotAfter.Before(expiry.NotAfter) {
+				expiry = CertificateExpiry{NotAfter: cert.NotAfter, Subject: cert.Subject.String()}
+				found = true
+			}
+		}
+	}
+
+	return expiry, found
+}
+
+// CertificateInspector inspects the expiry of the certificates of the TLS and CA secrets of a SecretStore.
+type CertificateInspector struct {
+	store      SecretStore
+	thresholds []time.Duration
+}
+
+// NewCertificateInspector creates a new CertificateInspector.
+// The thresholds are the durations before the expiry of a certificate at which the certificate is expiring.
+func NewCertificateInspector(store SecretStore, thresholds []time.Duration) *CertificateInspector {
+	sorted := slices.Clone(thresholds)
+	slices.Sort(sorted)
+
+	return &CertificateInspector{
+		store:      store,
+		thresholds: sorted,
+	}
+}
+
+// Inspect returns the expiry of the certificates of the secret with the given key <namespace/name>.
+// The second return value is false if the secret doesn't exist or holds no certificates.
+func (ci *CertificateInspector) Inspect(key string) (CertificateExpiry, bool) {
+	secretRef, exists := ci.store.GetSecretReferenceMap()[key]
+	if !exists || secretRef.Secret == nil {
+		return CertificateExpiry{}, false
+	}
+
+	return GetCertificateExpiry(secretRef.Secret)
+}
+
+// InspectAll returns the expiries of the certificates of all the secrets of the store that hold certificates.
+// The expiries are mapped by the keys of the secrets <namespace/name>.
+func (ci *CertificateInspector) InspectAll() map[string]CertificateExpiry {
+	expiries := make(map[string]CertificateExpiry)
+	for key := range ci.store.GetSecretReferenceMap() {
+		if expiry, ok := ci.Inspect(key); ok {
+			expiries[key] = expiry
+		}
+	}
+
+	return expiries
+}
+
+// Threshold returns the shortest threshold the certificate reached at the given time,
+// or zero if the certificate expired. The second return value is false if the certificate
+// is valid for longer than all the thresholds.
+func (ci *CertificateInspector) Threshold(expiry CertificateExpiry, now time.Time) (time.Duration, bool) {
+	remaining := expiry.NotAfter.Sub(now)
+	if remaining <= 0 {
+		return 0, true
+	}
+
+	for _, threshold := range ci.thresholds {
+		if remaining <= threshold {
+			return threshold, true
+		}
+	}
+
+	return 0, false
+}
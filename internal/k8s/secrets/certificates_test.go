@@ -0,0 +1,184 @@
+package secrets
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	api_v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func createTestCertificate(t *testing.T, commonName string, notAfter time.Time) []byte {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestGetCertificateExpiry(t *testing.T) {
+	t.Parallel()
+	leafNotAfter := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	intermediateNotAfter := time.Date(2029, 6, 1, 0, 0, 0, 0, time.UTC)
+	caNotAfter := time.Date(2028, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	leaf := createTestCertificate(t, "cafe.example.com", leafNotAfter)
+	intermediate := createTestCertificate(t, "intermediate", intermediateNotAfter)
+	ca := createTestCertificate(t, "ca", caNotAfter)
+
+	tests := []struct {
+		secret   *api_v1.Secret
+		expected CertificateExpiry
+		found    bool
+		msg      string
+	}{
+		{
+			secret: &api_v1.Secret{
+				Type: api_v1.SecretTypeTLS,
+				Data: map[string][]byte{api_v1.TLSCertKey: leaf},
+			},
+			expected: CertificateExpiry{NotAfter: leafNotAfter, Subject: "CN=cafe.example.com"},
+			found:    true,
+			msg:      "TLS secret",
+		},
+		{
+			secret: &api_v1.Secret{
+				Type: api_v1.SecretTypeTLS,
+				Data: map[string][]byte{api_v1.TLSCertKey: append(append([]byte{}, leaf...), intermediate...)},
+			},
+			expected: CertificateExpiry{NotAfter: intermediateNotAfter, Subject: "CN=intermediate"},
+			found:    true,
+			msg:      "TLS secret with a certificate chain",
+		},
+		{
+			secret: &api_v1.Secret{
+				Type: api_v1.SecretTypeTLS,
+				Data: map[string][]byte{api_v1.TLSCertKey: leaf, CAKey: ca},
+			},
+			expected: CertificateExpiry{NotAfter: caNotAfter, Subject: "CN=ca"},
+			found:    true,
+			msg:      "TLS secret with a CA bundle",
+		},
+		{
+			secret: &api_v1.Secret{
+				Type: SecretTypeCA,
+				Data: map[string][]byte{CAKey: append(append([]byte{}, leaf...), ca...)},
+			},
+			expected: CertificateExpiry{NotAfter: caNotAfter, Subject: "CN=ca"},
+			found:    true,
+			msg:      "CA secret with a bundle",
+		},
+		{
+			secret: &api_v1.Secret{
+				Type: SecretTypeCA,
+				Data: map[string][]byte{CAKey: []byte("not a certificate")},
+			},
+			found: false,
+			msg:   "CA secret without certificates",
+		},
+		{
+			secret: &api_v1.Secret{
+				Type: SecretTypeJWK,
+				Data: map[string][]byte{JWTKeyKey: leaf},
+			},
+			found: false,
+			msg:   "JWK secret",
+		},
+	}
+
+	for _, test := range tests {
+		expiry, found := GetCertificateExpiry(test.secret)
+		if found != test.found {
+			t.Errorf("GetCertificateExpiry() returned found %v, expected %v for the case of %s", found, test.found, test.msg)
+		}
+		if !expiry.NotAfter.Equal(test.expected.NotAfter) || expiry.Subject != test.expected.Subject {
+			t.Errorf("GetCertificateExpiry() returned %v, expected %v for the case of %s", expiry, test.expected, test.msg)
+		}
+	}
+}
+
+func TestCertificateInspector(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	store := NewFakeSecretsStore(map[string]*SecretReference{
+		"default/cafe-secret": {
+			Secret: &api_v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cafe-secret"},
+				Type:       api_v1.SecretTypeTLS,
+				Data:       map[string][]byte{api_v1.TLSCertKey: createTestCertificate(t, "cafe.example.com", now.Add(100*time.Hour))},
+			},
+		},
+		"default/jwk-secret": {
+			Secret: &api_v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "jwk-secret"},
+				Type:       SecretTypeJWK,
+			},
+		},
+	})
+	inspector := NewCertificateInspector(store, []time.Duration{24 * time.Hour, 720 * time.Hour, 168 * time.Hour})
+
+	expiries := inspector.InspectAll()
+	if len(expiries) != 1 {
+		t.Fatalf("InspectAll() returned %v, expected the expiry of the default/cafe-secret only", expiries)
+	}
+	expiry := expiries["default/cafe-secret"]
+
+	tests := []struct {
+		now               time.Time
+		expectedThreshold time.Duration
+		expectedExpiring  bool
+		msg               string
+	}{
+		{
+			now:              now.Add(-1000 * time.Hour),
+			expectedExpiring: false,
+			msg:              "valid longer than the thresholds",
+		},
+		{
+			now:               now,
+			expectedThreshold: 168 * time.Hour,
+			expectedExpiring:  true,
+			msg:               "expiring in 100 hours",
+		},
+		{
+			now:               now.Add(90 * time.Hour),
+			expectedThreshold: 24 * time.Hour,
+			expectedExpiring:  true,
+			msg:               "expiring in 10 hours",
+		},
+		{
+			now:               now.Add(100 * time.Hour),
+			expectedThreshold: 0,
+			expectedExpiring:  true,
+			msg:               "expired",
+		},
+	}
+
+	for _, test := range tests {
+		threshold, expiring := inspector.Threshold(expiry, test.now)
+		if threshold != test.expectedThreshold || expiring != test.expectedExpiring {
+			t.Errorf("Threshold() returned %v, %v, expected %v, %v for the case of %s", threshold, expiring, test.expectedThreshold, test.expectedExpiring, test.msg)
+		}
+	}
+}
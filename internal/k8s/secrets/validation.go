@@ -1,11 +1,19 @@
 package secrets
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
 	"encoding/pem"
 	"fmt"
+	"maps"
 	"regexp"
+	"slices"
+	"strings"
+	"time"
 
 	api_v1 "k8s.io/api/core/v1"
 )
@@ -37,6 +45,9 @@ const SecretTypeHtpasswd api_v1.SecretType = "nginx.org/htpasswd" // #nosec G101
 // SecretTypeAPIKey contains a list of client ID and key for API key authorization.. #nosec G101
 const SecretTypeAPIKey api_v1.SecretType = "nginx.org/apikey" // #nosec G101
 
+// SecretTypeHMAC contains a list of key ID and shared key for HMAC signature verification. #nosec G101
+const SecretTypeHMAC api_v1.SecretType = "nginx.org/hmac" // #nosec G101
+
 // SecretTypeLicense contains the license.jwt required for NGINX Plus. #nosec G101
 const SecretTypeLicense api_v1.SecretType = "nginx.com/license" // #nosec G101
 
@@ -121,12 +132,116 @@ func ValidateAPIKeySecret(secret *api_v1.Secret) error {
 		return fmt.Errorf("APIKey secret must be of the type %v", SecretTypeAPIKey)
 	}
 
+	_, err := ParseAPIKeyClients(secret.Data)
+	return err
+}
+
+// APIKeyClient holds the hashed API key and the metadata of a client of an APIKey secret.
+type APIKeyClient struct {
+	ID        string
+	Name      string
+	HashedKey string    // hex encoded SHA-256 hash of the API key
+	Expires   time.Time // zero if the API key doesn't expire
+	Routes    []string  // path prefixes the API key is allowed for, all paths if empty
+}
+
+// apiKeyEntry is the JSON form of the value of the data field of an APIKey secret,
+// which holds either the API key or its hash, together with the metadata of the client.
+type apiKeyEntry struct {
+	Key     *string  `json:"key"`
+	SHA256  string   `json:"sha256"`
+	Name    string   `json:"name"`
+	Expires string   `json:"expires"`
+	Routes  []string `json:"routes"`
+}
+
+var (
+	apiKeyHashRegexp       = regexp.MustCompile(`^[0-9a-fA-F]{64}$`)
+	apiKeyClientNameRegexp = regexp.MustCompile(`^[\w .@-]*$`)
+	apiKeyRouteRegexp      = regexp.MustCompile(`^/[^\s,"$\\{};]*$`)
+)
+
+// ParseAPIKeyClients parses the data of an APIKey secret. The key of every data field is the ID of the client,
+// and the value is either the API key, or a JSON object with the API key ("key") or its SHA-256 hash ("sha256"),
+// and optionally the name of the client ("name"), the expiry time of the API key in RFC 3339 format ("expires")
+// and the path prefixes the API key is allowed for ("routes").
+// The clients are sorted by their ID.
+func ParseAPIKeyClients(data map[string][]byte) ([]APIKeyClient, error) {
+	clients := make([]APIKeyClient, 0, len(data))
 	uniqueKeys := make(map[string]bool)
-	for _, key := range secret.Data {
-		if uniqueKeys[string(key)] {
-			return fmt.Errorf("API Keys cannot be repeated")
+	for _, id := range slices.Sorted(maps.Keys(data)) {
+		client, err := parseAPIKeyClient(id, data[id])
+		if err != nil {
+			return nil, fmt.Errorf("API Key of the client %v is invalid: %w", id, err)
+		}
+		if uniqueKeys[client.HashedKey] {
+			return nil, fmt.Errorf("API Keys cannot be repeated")
+		}
+		uniqueKeys[client.HashedKey] = true
+		clients = append(clients, client)
+	}
+	return clients, nil
+}
+
+func parseAPIKeyClient(id string, value []byte) (APIKeyClient, error) {
+	var entry apiKeyEntry
+	if !bytes.HasPrefix(bytes.TrimSpace(value), []byte("{")) || json.Unmarshal(value, &entry) != nil || (entry.Key == nil && entry.SHA256 == "") {
+		return APIKeyClient{ID: id, HashedKey: hashAPIKey(value)}, nil
+	}
+
+	client := APIKeyClient{
+		ID:     id,
+		Name:   entry.Name,
+		Routes: entry.Routes,
+	}
+	switch {
+	case entry.Key != nil && entry.SHA256 != "":
+		return APIKeyClient{}, fmt.Errorf("only one of key and sha256 can be specified")
+	case entry.Key != nil:
+		client.HashedKey = hashAPIKey([]byte(*entry.Key))
+	case !apiKeyHashRegexp.MatchString(entry.SHA256):
+		return APIKeyClient{}, fmt.Errorf("sha256 must be a hex encoded SHA-256 hash")
+	default:
+		client.HashedKey = strings.ToLower(entry.SHA256)
+	}
+
+	if !apiKeyClientNameRegexp.MatchString(entry.Name) {
+		return APIKeyClient{}, fmt.Errorf("name %q must contain only letters, digits, spaces and the characters '_', '.', '@' and '-'", entry.Name)
+	}
+	if entry.Expires != "" {
+		expires, err := time.Parse(time.RFC3339, entry.Expires)
+		if err != nil {
+			return APIKeyClient{}, fmt.Errorf("expires must be in RFC 3339 format: %w", err)
+		}
+		client.Expires = expires
+	}
+	for _, route := range entry.Routes {
+		if !apiKeyRouteRegexp.MatchString(route) {
+			return APIKeyClient{}, fmt.Errorf("route %q must start with '/' and must not contain whitespaces or the characters ',', '\"', '$', '\\', '{', '}' and ';'", route)
+		}
+	}
+	return client, nil
+}
+
+func hashAPIKey(key []byte) string {
+	h := sha256.Sum256(key)
+	return hex.EncodeToString(h[:])
+}
+
+// ValidateHMACSecret validates the secret. If it is valid, the function returns nil.
+func ValidateHMACSecret(secret *api_v1.Secret) error {
+	if secret.Type != SecretTypeHMAC {
+		return fmt.Errorf("HMAC secret must be of the type %v", SecretTypeHMAC)
+	}
+
+	if len(secret.Data) == 0 {
+		return fmt.Errorf("HMAC secret must have at least one key")
+	}
+
+	for keyID, key := range secret.Data {
+		if len(key) == 0 {
+			return fmt.Errorf("HMAC key %v cannot be empty", keyID)
 		}
-		uniqueKeys[string(key)] = true
 	}
 
 	return nil
@@ -169,6 +284,7 @@ func IsSupportedSecretType(secretType api_v1.SecretType) bool {
 		secretType == SecretTypeOIDC ||
 		secretType == SecretTypeHtpasswd ||
 		secretType == SecretTypeAPIKey ||
+		secretType == SecretTypeHMAC ||
 		secretType == SecretTypeLicense
 }
 
@@ -187,6 +303,8 @@ func ValidateSecret(secret *api_v1.Secret) error {
 		return ValidateHtpasswdSecret(secret)
 	case SecretTypeAPIKey:
 		return ValidateAPIKeySecret(secret)
+	case SecretTypeHMAC:
+		return ValidateHMACSecret(secret)
 	case SecretTypeLicense:
 		return ValidateLicenseSecret(secret)
 	}
@@ -4,7 +4,9 @@ import (
 	_ "embed"
 	"encoding/base64"
 	"testing"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
 	v1 "k8s.io/api/core/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -78,6 +80,8 @@ func TestValidateValidateAPIKeySecret(t *testing.T) {
 		Data: map[string][]byte{
 			"client1": []byte("cGFzc3dvcmQ="),
 			"client2": []byte("N2ViNDMwOGItY2Q1Yi00NDEzLWI0NTUtYjMyZmQ4OTg2MmZk"),
+			"client3": []byte(`{"sha256": "5E884898DA28047151D0E56F8DC6292773603D0D6AABBDD62A11EF721D1542D8", "name": "Partner One", "expires": "2030-01-01T00:00:00Z", "routes": ["/coffee", "/tea/"]}`),
+			"client4": []byte(`{"key": "secret4"}`),
 		},
 	}
 
@@ -87,6 +91,35 @@ func TestValidateValidateAPIKeySecret(t *testing.T) {
 	}
 }
 
+func TestParseAPIKeyClients(t *testing.T) {
+	t.Parallel()
+	data := map[string][]byte{
+		"client2": []byte(`{"sha256": "A3C024F01CCCB3B63457D848B0D2F89C1F744A3D0C3F8E9D5B2B3D2F6C1A7A4E", "name": "Partner Two", "expires": "2030-01-01T00:00:00Z", "routes": ["/coffee"]}`),
+		"client1": []byte("password"),
+	}
+	expected := []APIKeyClient{
+		{
+			ID:        "client1",
+			HashedKey: "5e884898da28047151d0e56f8dc6292773603d0d6aabbdd62a11ef721d1542d8",
+		},
+		{
+			ID:        "client2",
+			Name:      "Partner Two",
+			HashedKey: "a3c024f01cccb3b63457d848b0d2f89c1f744a3d0c3f8e9d5b2b3d2f6c1a7a4e",
+			Expires:   time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC),
+			Routes:    []string{"/coffee"},
+		},
+	}
+
+	clients, err := ParseAPIKeyClients(data)
+	if err != nil {
+		t.Fatalf("ParseAPIKeyClients() returned error %v", err)
+	}
+	if diff := cmp.Diff(expected, clients); diff != "" {
+		t.Errorf("ParseAPIKeyClients() returned unexpected result (-want +got):\n%s", diff)
+	}
+}
+
 func TestValidateValidateAPIKeyFails(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -135,6 +168,99 @@ func TestValidateValidateAPIKeyFails(t *testing.T) {
 			},
 			msg: "repeated empty API Keys for API Key secret",
 		},
+		{
+			secret: &v1.Secret{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "api-key-secret",
+					Namespace: "default",
+				},
+				Type: SecretTypeAPIKey,
+				Data: map[string][]byte{
+					"client1": []byte("cGFzc3dvcmQ="),
+					"client2": []byte(`{"key": "cGFzc3dvcmQ="}`),
+				},
+			},
+			msg: "repeated plain and JSON API Keys for API Key secret",
+		},
+		{
+			secret: &v1.Secret{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "api-key-secret",
+					Namespace: "default",
+				},
+				Type: SecretTypeAPIKey,
+				Data: map[string][]byte{
+					"client1": []byte("password"),
+					"client2": []byte(`{"sha256": "5e884898da28047151d0e56f8dc6292773603d0d6aabbdd62a11ef721d1542d8"}`),
+				},
+			},
+			msg: "repeated plain and hashed API Keys for API Key secret",
+		},
+		{
+			secret: &v1.Secret{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "api-key-secret",
+					Namespace: "default",
+				},
+				Type: SecretTypeAPIKey,
+				Data: map[string][]byte{
+					"client2": []byte(`{"key": "secret", "sha256": "5e884898da28047151d0e56f8dc6292773603d0d6aabbdd62a11ef721d1542d8"}`),
+				},
+			},
+			msg: "both key and hash for API Key secret",
+		},
+		{
+			secret: &v1.Secret{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "api-key-secret",
+					Namespace: "default",
+				},
+				Type: SecretTypeAPIKey,
+				Data: map[string][]byte{
+					"client2": []byte(`{"sha256": "5e884898"}`),
+				},
+			},
+			msg: "invalid hash for API Key secret",
+		},
+		{
+			secret: &v1.Secret{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "api-key-secret",
+					Namespace: "default",
+				},
+				Type: SecretTypeAPIKey,
+				Data: map[string][]byte{
+					"client2": []byte(`{"key": "secret", "expires": "2030-01-01"}`),
+				},
+			},
+			msg: "invalid expiry time for API Key secret",
+		},
+		{
+			secret: &v1.Secret{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "api-key-secret",
+					Namespace: "default",
+				},
+				Type: SecretTypeAPIKey,
+				Data: map[string][]byte{
+					"client2": []byte(`{"key": "secret", "routes": ["coffee"]}`),
+				},
+			},
+			msg: "invalid route for API Key secret",
+		},
+		{
+			secret: &v1.Secret{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "api-key-secret",
+					Namespace: "default",
+				},
+				Type: SecretTypeAPIKey,
+				Data: map[string][]byte{
+					"client2": []byte(`{"key": "secret", "name": "Partner \"One\""}`),
+				},
+			},
+			msg: "invalid name for API Key secret",
+		},
 	}
 
 	for _, test := range tests {
@@ -146,6 +272,79 @@ func TestValidateValidateAPIKeyFails(t *testing.T) {
 	}
 }
 
+func TestValidateHMACSecret(t *testing.T) {
+	t.Parallel()
+	secret := &v1.Secret{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "hmac-secret",
+			Namespace: "default",
+		},
+		Type: SecretTypeHMAC,
+		Data: map[string][]byte{
+			"partner1": []byte("c2VjcmV0"),
+			"partner2": []byte("secret"),
+		},
+	}
+
+	err := ValidateHMACSecret(secret)
+	if err != nil {
+		t.Errorf("ValidateHMACSecret() returned error %v", err)
+	}
+}
+
+func TestValidateHMACSecretFails(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		secret *v1.Secret
+		msg    string
+	}{
+		{
+			secret: &v1.Secret{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "hmac-secret",
+					Namespace: "default",
+				},
+				Type: SecretTypeAPIKey,
+				Data: map[string][]byte{
+					"partner1": []byte("secret"),
+				},
+			},
+			msg: "Incorrect type for HMAC secret",
+		},
+		{
+			secret: &v1.Secret{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "hmac-secret",
+					Namespace: "default",
+				},
+				Type: SecretTypeHMAC,
+			},
+			msg: "Missing keys for HMAC secret",
+		},
+		{
+			secret: &v1.Secret{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "hmac-secret",
+					Namespace: "default",
+				},
+				Type: SecretTypeHMAC,
+				Data: map[string][]byte{
+					"partner1": []byte("secret"),
+					"partner2": []byte(""),
+				},
+			},
+			msg: "empty key for HMAC secret",
+		},
+	}
+
+	for _, test := range tests {
+		err := ValidateHMACSecret(test.secret)
+		if err == nil {
+			t.Errorf("ValidateHMACSecret() returned no error for the case of %s", test.msg)
+		}
+	}
+}
+
 func TestValidateHtpasswdSecret(t *testing.T) {
 	t.Parallel()
 	secret := &v1.Secret{
@@ -715,6 +914,10 @@ func TestHasCorrectSecretType(t *testing.T) {
 			secretType: SecretTypeAPIKey,
 			expected:   true,
 		},
+		{
+			secretType: SecretTypeHMAC,
+			expected:   true,
+		},
 		{
 			secretType: "some-type",
 			expected:   false,
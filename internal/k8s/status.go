@@ -14,6 +14,7 @@ import (
 	k8s_nginx "github.com/nginx/kubernetes-ingress/pkg/client/clientset/versioned"
 	api_v1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	typednetworking "k8s.io/client-go/kubernetes/typed/networking/v1"
 
@@ -73,6 +74,11 @@ func (su *statusUpdater) UpdateExternalEndpointsForResource(r Resource) error {
 
 		return su.BulkUpdateIngressStatus(ings)
 	case *VirtualServerConfiguration:
+		if impl.Gateway != nil {
+			// the addresses of the Gateways are reported by updateGatewayAPIStatuses
+			return nil
+		}
+
 		failed := false
 
 		err := su.updateVirtualServerExternalEndpoints(impl.VirtualServer)
@@ -441,11 +447,14 @@ func (su *statusUpdater) UpdateTransportServerStatus(ts *conf_v1.TransportServer
 		return nil
 	}
 
-	if !hasTsStatusChanged(tsLatest.(*conf_v1.TransportServer), state, reason, message) {
+	tsCopy := tsLatest.(*conf_v1.TransportServer).DeepCopy()
+	conditionsChanged := setStatusConditions(&tsCopy.Status.Conditions, tsCopy.Generation, state, reason, message)
+
+	if !hasTsStatusChanged(tsCopy, state, reason, message) && !conditionsChanged && tsCopy.Status.ObservedGeneration == tsCopy.Generation {
 		return nil
 	}
 
-	tsCopy := tsLatest.(*conf_v1.TransportServer).DeepCopy()
+	tsCopy.Status.ObservedGeneration = tsCopy.Generation
 	tsCopy.Status.State = state
 	tsCopy.Status.Reason = reason
 	tsCopy.Status.Message = message
@@ -489,11 +498,13 @@ func (su *statusUpdater) UpdateVirtualServerStatus(vs *conf_v1.VirtualServer, st
 	}
 
 	vsCopy := vsLatest.(*conf_v1.VirtualServer).DeepCopy()
+	conditionsChanged := setStatusConditions(&vsCopy.Status.Conditions, vsCopy.Generation, state, reason, message)
 
-	if !su.hasVsStatusChanged(vsCopy, state, reason, message) {
+	if !su.hasVsStatusChanged(vsCopy, state, reason, message) && !conditionsChanged && vsCopy.Status.ObservedGeneration == vsCopy.Generation {
 		return nil
 	}
 
+	vsCopy.Status.ObservedGeneration = vsCopy.Generation
 	vsCopy.Status.State = state
 	vsCopy.Status.Reason = reason
 	vsCopy.Status.Message = message
@@ -555,11 +566,13 @@ func (su *statusUpdater) UpdateVirtualServerRouteStatusWithReferencedBy(vsr *con
 	}
 
 	vsrCopy := vsrLatest.(*conf_v1.VirtualServerRoute).DeepCopy()
+	conditionsChanged := setStatusConditions(&vsrCopy.Status.Conditions, vsrCopy.Generation, state, reason, message)
 
-	if !su.hasVsrStatusChanged(vsrCopy, state, reason, message, referencedByString) {
+	if !su.hasVsrStatusChanged(vsrCopy, state, reason, message, referencedByString) && !conditionsChanged && vsrCopy.Status.ObservedGeneration == vsrCopy.Generation {
 		return nil
 	}
 
+	vsrCopy.Status.ObservedGeneration = vsrCopy.Generation
 	vsrCopy.Status.State = state
 	vsrCopy.Status.Reason = reason
 	vsrCopy.Status.Message = message
@@ -594,11 +607,13 @@ func (su *statusUpdater) UpdateVirtualServerRouteStatus(vsr *conf_v1.VirtualServ
 	}
 
 	vsrCopy := vsrLatest.(*conf_v1.VirtualServerRoute).DeepCopy()
+	conditionsChanged := setStatusConditions(&vsrCopy.Status.Conditions, vsrCopy.Generation, state, reason, message)
 
-	if !su.hasVsrStatusChanged(vsrCopy, state, reason, message, "") {
+	if !su.hasVsrStatusChanged(vsrCopy, state, reason, message, "") && !conditionsChanged && vsrCopy.Status.ObservedGeneration == vsrCopy.Generation {
 		return nil
 	}
 
+	vsrCopy.Status.ObservedGeneration = vsrCopy.Generation
 	vsrCopy.Status.State = state
 	vsrCopy.Status.Reason = reason
 	vsrCopy.Status.Message = message
@@ -707,12 +722,14 @@ func (su *statusUpdater) UpdatePolicyStatus(pol *conf_v1.Policy, state string, r
 		return nil
 	}
 
-	polCopy := polLatest.(*conf_v1.Policy)
+	polCopy := polLatest.(*conf_v1.Policy).DeepCopy()
+	conditionsChanged := setStatusConditions(&polCopy.Status.Conditions, polCopy.Generation, state, reason, message)
 
-	if !hasPolicyStatusChanged(polCopy, state, reason, message) {
+	if !hasPolicyStatusChanged(polCopy, state, reason, message) && !conditionsChanged && polCopy.Status.ObservedGeneration == polCopy.Generation {
 		return nil
 	}
 
+	polCopy.Status.ObservedGeneration = polCopy.Generation
 	polCopy.Status.State = state
 	polCopy.Status.Reason = reason
 	polCopy.Status.Message = message
@@ -740,3 +757,50 @@ func (su *statusUpdater) retryUpdatePolicyStatus(polCopy *conf_v1.Policy) error
 
 	return nil
 }
+
+// maxConditionMessageLength is the maximum length of the message of a condition allowed by the API server.
+const maxConditionMessageLength = 32768
+
+// setStatusConditions sets the Accepted, ResolvedRefs, Programmed and Ready conditions derived from the state and
+// the reason of the status of a resource. The transition time of a condition is only updated when its status changes.
+// It returns true if any of the conditions changed.
+func setStatusConditions(conditions *[]metav1.Condition, generation int64, state string, reason string, message string) bool {
+	if len(message) > maxConditionMessageLength {
+		message = message[:maxConditionMessageLength]
+	}
+
+	accepted := metav1.Condition{Status: metav1.ConditionTrue, Reason: conf_v1.ConditionReasonAccepted}
+	resolvedRefs := metav1.Condition{Status: metav1.ConditionTrue, Reason: conf_v1.ConditionReasonResolvedRefs}
+	programmed := metav1.Condition{Status: metav1.ConditionTrue, Reason: conf_v1.ConditionReasonProgrammed}
+	ready := metav1.Condition{Status: metav1.ConditionTrue, Reason: conf_v1.ConditionReasonReady}
+
+	switch {
+	case reason == nl.EventReasonAddedOrUpdatedWithWarning || reason == "UpdatedWithWarning":
+		resolvedRefs = metav1.Condition{Status: metav1.ConditionFalse, Reason: conf_v1.ConditionReasonUnresolvedRefs, Message: message}
+		ready = metav1.Condition{Status: metav1.ConditionFalse, Reason: conf_v1.ConditionReasonUnresolvedRefs, Message: message}
+	case reason == nl.EventReasonAddedOrUpdatedWithError || reason == nl.EventReasonUpdatedWithError:
+		programmed = metav1.Condition{Status: metav1.ConditionFalse, Reason: conf_v1.ConditionReasonInvalid, Message: message}
+		ready = metav1.Condition{Status: metav1.ConditionFalse, Reason: conf_v1.ConditionReasonInvalid, Message: message}
+	case state != conf_v1.StateValid:
+		// the resource was rejected or ignored, for example, because of a validation error or a host conflict
+		accepted = metav1.Condition{Status: metav1.ConditionFalse, Reason: conf_v1.ConditionReasonRejected, Message: message}
+		resolvedRefs = metav1.Condition{Status: metav1.ConditionUnknown, Reason: conf_v1.ConditionReasonRejected}
+		programmed = metav1.Condition{Status: metav1.ConditionFalse, Reason: conf_v1.ConditionReasonRejected}
+		ready = metav1.Condition{Status: metav1.ConditionFalse, Reason: conf_v1.ConditionReasonRejected, Message: message}
+	}
+
+	accepted.Type = conf_v1.ConditionTypeAccepted
+	resolvedRefs.Type = conf_v1.ConditionTypeResolvedRefs
+	programmed.Type = conf_v1.ConditionTypeProgrammed
+	ready.Type = conf_v1.ConditionTypeReady
+
+	changed := false
+	for _, cond := range []metav1.Condition{accepted, resolvedRefs, programmed, ready} {
+		cond.ObservedGeneration = generation
+		if meta.SetStatusCondition(conditions, cond) {
+			changed = true
+		}
+	}
+
+	return changed
+}
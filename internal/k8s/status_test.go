@@ -9,12 +9,14 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	nic_glog "github.com/nginx/kubernetes-ingress/internal/logger/glog"
 	"github.com/nginx/kubernetes-ingress/internal/logger/levels"
 	conf_v1 "github.com/nginx/kubernetes-ingress/pkg/apis/configuration/v1"
 	fake_v1 "github.com/nginx/kubernetes-ingress/pkg/client/clientset/versioned/fake"
 	v1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -70,9 +72,15 @@ func TestUpdateTransportServerStatus(t *testing.T) {
 		State:   "after status",
 		Reason:  "after reason",
 		Message: "after message",
+		Conditions: []meta_v1.Condition{
+			{Type: "Accepted", Status: meta_v1.ConditionFalse, Reason: "Rejected", Message: "after message"},
+			{Type: "ResolvedRefs", Status: meta_v1.ConditionUnknown, Reason: "Rejected"},
+			{Type: "Programmed", Status: meta_v1.ConditionFalse, Reason: "Rejected"},
+			{Type: "Ready", Status: meta_v1.ConditionFalse, Reason: "Rejected", Message: "after message"},
+		},
 	}
 
-	if diff := cmp.Diff(expectedStatus, updatedTs.Status); diff != "" {
+	if diff := cmp.Diff(expectedStatus, updatedTs.Status, cmpopts.IgnoreFields(meta_v1.Condition{}, "LastTransitionTime")); diff != "" {
 		t.Errorf("Unexpected status (-want +got):\n%s", diff)
 	}
 }
@@ -90,6 +98,7 @@ func TestUpdateTransportServerStatusIgnoreNoChange(t *testing.T) {
 			Message: "same message",
 		},
 	}
+	setStatusConditions(&ts.Status.Conditions, ts.Generation, "same status", "same reason", "same message")
 
 	fakeClient := fake_v1.NewClientset(
 		&conf_v1.TransportServerList{
@@ -946,3 +955,117 @@ func TestHasPolicyStatusChanged(t *testing.T) {
 		}
 	}
 }
+
+func TestSetStatusConditions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		state    string
+		reason   string
+		expected map[string]meta_v1.ConditionStatus
+		msg      string
+	}{
+		{
+			state:  conf_v1.StateValid,
+			reason: "AddedOrUpdated",
+			expected: map[string]meta_v1.ConditionStatus{
+				"Accepted":     meta_v1.ConditionTrue,
+				"ResolvedRefs": meta_v1.ConditionTrue,
+				"Programmed":   meta_v1.ConditionTrue,
+				"Ready":        meta_v1.ConditionTrue,
+			},
+			msg: "valid resource",
+		},
+		{
+			state:  conf_v1.StateWarning,
+			reason: "AddedOrUpdatedWithWarning",
+			expected: map[string]meta_v1.ConditionStatus{
+				"Accepted":     meta_v1.ConditionTrue,
+				"ResolvedRefs": meta_v1.ConditionFalse,
+				"Programmed":   meta_v1.ConditionTrue,
+				"Ready":        meta_v1.ConditionFalse,
+			},
+			msg: "resource with warnings",
+		},
+		{
+			state:  conf_v1.StateInvalid,
+			reason: "AddedOrUpdatedWithError",
+			expected: map[string]meta_v1.ConditionStatus{
+				"Accepted":     meta_v1.ConditionTrue,
+				"ResolvedRefs": meta_v1.ConditionTrue,
+				"Programmed":   meta_v1.ConditionFalse,
+				"Ready":        meta_v1.ConditionFalse,
+			},
+			msg: "resource not applied because of a reload error",
+		},
+		{
+			state:  conf_v1.StateInvalid,
+			reason: "Rejected",
+			expected: map[string]meta_v1.ConditionStatus{
+				"Accepted":     meta_v1.ConditionFalse,
+				"ResolvedRefs": meta_v1.ConditionUnknown,
+				"Programmed":   meta_v1.ConditionFalse,
+				"Ready":        meta_v1.ConditionFalse,
+			},
+			msg: "rejected resource",
+		},
+		{
+			state:  conf_v1.StateWarning,
+			reason: "Ignored",
+			expected: map[string]meta_v1.ConditionStatus{
+				"Accepted":     meta_v1.ConditionFalse,
+				"ResolvedRefs": meta_v1.ConditionUnknown,
+				"Programmed":   meta_v1.ConditionFalse,
+				"Ready":        meta_v1.ConditionFalse,
+			},
+			msg: "ignored resource",
+		},
+	}
+
+	for _, test := range tests {
+		var conditions []meta_v1.Condition
+		changed := setStatusConditions(&conditions, 2, test.state, test.reason, "message")
+		if !changed {
+			t.Errorf("setStatusConditions() returned false for the first update for the case of %s", test.msg)
+		}
+
+		result := make(map[string]meta_v1.ConditionStatus)
+		for _, c := range conditions {
+			result[c.Type] = c.Status
+			if c.ObservedGeneration != 2 {
+				t.Errorf("setStatusConditions() set observedGeneration %d for %s condition, expected 2 for the case of %s", c.ObservedGeneration, c.Type, test.msg)
+			}
+		}
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("setStatusConditions() mismatch for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+
+		if setStatusConditions(&conditions, 2, test.state, test.reason, "message") {
+			t.Errorf("setStatusConditions() returned true for an unchanged status for the case of %s", test.msg)
+		}
+	}
+}
+
+func TestSetStatusConditionsKeepsTransitionTime(t *testing.T) {
+	t.Parallel()
+
+	transitionTime := meta_v1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+	conditions := []meta_v1.Condition{
+		{Type: "Accepted", Status: meta_v1.ConditionTrue, Reason: "Accepted", ObservedGeneration: 1, LastTransitionTime: transitionTime},
+	}
+
+	if !setStatusConditions(&conditions, 2, conf_v1.StateValid, "AddedOrUpdated", "message") {
+		t.Fatal("setStatusConditions() returned false for a new generation")
+	}
+
+	accepted := meta.FindStatusCondition(conditions, "Accepted")
+	if accepted == nil {
+		t.Fatal("setStatusConditions() removed the Accepted condition")
+	}
+	if !accepted.LastTransitionTime.Equal(&transitionTime) {
+		t.Errorf("setStatusConditions() changed the transition time of the Accepted condition to %v, expected %v", accepted.LastTransitionTime, transitionTime)
+	}
+	if accepted.ObservedGeneration != 2 {
+		t.Errorf("setStatusConditions() set observedGeneration %d, expected 2", accepted.ObservedGeneration)
+	}
+}
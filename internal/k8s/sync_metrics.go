@@ -0,0 +1,92 @@
+package k8s
+
+import (
+	"strings"
+	"time"
+)
+
+// the outcomes of the syncs reported in the metrics, from the least to the most severe
+const (
+	syncOutcomeValid   = "valid"
+	syncOutcomeWarning = "warning"
+	syncOutcomeInvalid = "invalid"
+	syncOutcomeError   = "error"
+)
+
+// the triggers of the NGINX reloads reported in the metrics
+const (
+	reloadTriggerEndpoints = "endpoints"
+	reloadTriggerConfig    = "config"
+	reloadTriggerSecret    = "secret"
+	reloadTriggerBatch     = "batch"
+)
+
+var syncOutcomeSeverity = map[string]int{
+	syncOutcomeValid:   0,
+	syncOutcomeWarning: 1,
+	syncOutcomeInvalid: 2,
+	syncOutcomeError:   3,
+}
+
+// syncMetrics holds the state of the sync in progress for the controller metrics
+type syncMetrics struct {
+	start    time.Time
+	outcome  string
+	requeues uint64
+	reloads  uint64
+}
+
+// startSyncMetrics resets the outcome of the sync and takes the snapshots of the counters
+// that decide the outcome and the reload triggers of the sync.
+func (lbc *LoadBalancerController) startSyncMetrics() {
+	lbc.syncMetrics = syncMetrics{
+		start:    time.Now(),
+		outcome:  syncOutcomeValid,
+		requeues: lbc.syncQueue.requeues.Load(),
+		reloads:  lbc.configurator.ReloadsCount(),
+	}
+}
+
+// recordSyncReloads reports the NGINX reloads performed since the last call with the given trigger.
+func (lbc *LoadBalancerController) recordSyncReloads(trigger string) {
+	reloads := lbc.configurator.ReloadsCount()
+	for range reloads - lbc.syncMetrics.reloads {
+		lbc.metricsCollector.IncReloads(trigger)
+	}
+	lbc.syncMetrics.reloads = reloads
+}
+
+// finishSyncMetrics reports the duration and the outcome of the sync of the task.
+// A sync that requeued its task is reported with the error outcome.
+func (lbc *LoadBalancerController) finishSyncMetrics(task task) {
+	if lbc.syncQueue.requeues.Load() != lbc.syncMetrics.requeues {
+		lbc.recordSyncOutcome(syncOutcomeError)
+	}
+	lbc.metricsCollector.ObserveSync(task.Kind.String(), lbc.syncMetrics.outcome, time.Since(lbc.syncMetrics.start))
+}
+
+// recordSyncOutcome keeps the most severe outcome of the sync in progress.
+func (lbc *LoadBalancerController) recordSyncOutcome(outcome string) {
+	if syncOutcomeSeverity[outcome] > syncOutcomeSeverity[lbc.syncMetrics.outcome] {
+		lbc.syncMetrics.outcome = outcome
+	}
+}
+
+// recordResourceState reports the state of the resource and accounts it in the outcome of the sync in progress.
+// The state is one of the states of the custom resources, for example Valid.
+func (lbc *LoadBalancerController) recordResourceState(k kind, key string, state string, operationErr error) {
+	outcome := strings.ToLower(state)
+	if outcome == "" {
+		return
+	}
+	lbc.metricsCollector.SetResourceState(k.String(), key, outcome)
+	if operationErr != nil {
+		outcome = syncOutcomeError
+	}
+	lbc.recordSyncOutcome(outcome)
+}
+
+// deleteResourceState stops reporting the state of the resource.
+func (lbc *LoadBalancerController) deleteResourceState(k kind, key string) {
+	lbc.metricsCollector.DeleteResourceState(k.String(), key)
+}
@@ -3,6 +3,7 @@ package k8s
 import (
 	"fmt"
 	"log/slog"
+	"sync/atomic"
 	"time"
 
 	"github.com/nginx/kubernetes-ingress/pkg/apis/dos/v1beta1"
@@ -17,6 +18,8 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/util/workqueue"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 )
 
 // taskQueue manages a work queue through an independent worker that
@@ -30,6 +33,8 @@ type taskQueue struct {
 	workerDone chan struct{}
 	// logger
 	logger *slog.Logger
+	// requeues counts the requeued tasks
+	requeues atomic.Uint64
 }
 
 // newTaskQueue creates a new task queue with the given sync function.
@@ -69,6 +74,7 @@ func (tq *taskQueue) Enqueue(obj interface{}) {
 // Requeue adds the task to the queue again and logs the given error
 func (tq *taskQueue) Requeue(task task, err error) {
 	nl.Errorf(tq.logger, "Requeuing %v, err %v", task.Key, err)
+	tq.requeues.Add(1)
 	tq.queue.Add(task)
 }
 
@@ -81,6 +87,7 @@ func (tq *taskQueue) Len() int {
 // RequeueAfter adds the task to the queue after the given duration
 func (tq *taskQueue) RequeueAfter(t task, err error, after time.Duration) {
 	nl.Errorf(tq.logger, "Requeuing %v after %s, err %v", t.Key, after.String(), err)
+	tq.requeues.Add(1)
 	go func(t task, after time.Duration) {
 		time.Sleep(after)
 		tq.queue.Add(t)
@@ -130,8 +137,55 @@ const (
 	appProtectDosLogConf
 	appProtectDosProtectedResource
 	ingressLink
+	gatewayClass
+	gateway
+	httpRoute
+	grpcRoute
+	tlsRoute
+	tcpRoute
+	// certificateExpiry is the periodic check of the expiry of the certificates of the secrets
+	certificateExpiry
+	// rollout is the periodic sync of the rollouts of the VirtualServer routes
+	rollout
 )
 
+var kindNames = map[kind]string{
+	ingress:                        "ingress",
+	endpointslice:                  "endpointslice",
+	configMap:                      "configmap",
+	secret:                         "secret",
+	service:                        "service",
+	namespace:                      "namespace",
+	virtualserver:                  "virtualserver",
+	virtualServerRoute:             "virtualserverroute",
+	globalConfiguration:            "globalconfiguration",
+	transportserver:                "transportserver",
+	policy:                         "policy",
+	appProtectPolicy:               "appprotectpolicy",
+	appProtectLogConf:              "appprotectlogconf",
+	appProtectUserSig:              "appprotectusersig",
+	appProtectDosPolicy:            "appprotectdospolicy",
+	appProtectDosLogConf:           "appprotectdoslogconf",
+	appProtectDosProtectedResource: "dosprotectedresource",
+	ingressLink:                    "ingresslink",
+	gatewayClass:                   "gatewayclass",
+	gateway:                        "gateway",
+	httpRoute:                      "httproute",
+	grpcRoute:                      "grpcroute",
+	tlsRoute:                       "tlsroute",
+	tcpRoute:                       "tcproute",
+	certificateExpiry:              "certificateexpiry",
+	rollout:                        "rollout",
+}
+
+// String returns the lowercase name of the kind, for example virtualserverroute
+func (k kind) String() string {
+	if name, ok := kindNames[k]; ok {
+		return name
+	}
+	return fmt.Sprintf("kind(%d)", int(k))
+}
+
 // task is an element of a taskQueue
 type task struct {
 	Kind kind
@@ -166,6 +220,18 @@ func newTask(key string, obj interface{}) (task, error) {
 		k = transportserver
 	case *v1beta1.DosProtectedResource:
 		k = appProtectDosProtectedResource
+	case *gatewayv1.GatewayClass:
+		k = gatewayClass
+	case *gatewayv1.Gateway:
+		k = gateway
+	case *gatewayv1.HTTPRoute:
+		k = httpRoute
+	case *gatewayv1.GRPCRoute:
+		k = grpcRoute
+	case *gatewayv1.TLSRoute:
+		k = tlsRoute
+	case *gatewayv1alpha2.TCPRoute:
+		k = tcpRoute
 	case *unstructured.Unstructured:
 		if objectKind := obj.(*unstructured.Unstructured).GetKind(); objectKind == appprotect.PolicyGVK.Kind {
 			k = appProtectPolicy
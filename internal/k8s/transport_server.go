@@ -79,6 +79,7 @@ func (lbc *LoadBalancerController) syncTransportServer(task task) {
 	if !tsExists {
 		nl.Debugf(lbc.Logger, "Deleting TransportServer: %v\n", key)
 		changes, problems = lbc.configuration.DeleteTransportServer(key)
+		lbc.deleteResourceState(transportserver, key)
 	} else {
 		nl.Debugf(lbc.Logger, "Adding or Updating TransportServer: %v\n", key)
 		ts := obj.(*conf_v1.TransportServer)
@@ -118,6 +119,7 @@ func (lbc *LoadBalancerController) updateTransportServerStatusAndEventsOnDelete(
 
 		msg := fmt.Sprintf("TransportServer %s was rejected %s", getResourceKey(&tsConfig.TransportServer.ObjectMeta), eventWarningMessage)
 		lbc.recorder.Event(tsConfig.TransportServer, eventType, eventTitle, msg)
+		lbc.recordResourceState(transportserver, getResourceKey(&tsConfig.TransportServer.ObjectMeta), state, deleteErr)
 
 		if lbc.reportCustomResourceStatusEnabled() {
 			err := lbc.statusUpdater.UpdateTransportServerStatus(tsConfig.TransportServer, state, eventTitle, msg)
@@ -129,6 +131,14 @@ func (lbc *LoadBalancerController) updateTransportServerStatusAndEventsOnDelete(
 }
 
 func (lbc *LoadBalancerController) updateTransportServerStatusAndEvents(tsConfig *TransportServerConfiguration, warnings configs.Warnings, operationErr error) {
+	if tsConfig.Gateway != nil {
+		key := getResourceKey(&tsConfig.TransportServer.ObjectMeta)
+		lbc.updateGatewayResourceStatusAndEvents(tsConfig.Gateway, key, tsConfig.Warnings, warnings[tsConfig.TransportServer], operationErr)
+		return
+	}
+
+	warnings = lbc.withCertificateExpiryWarnings(warnings, tsConfig, tsConfig.TransportServer)
+
 	eventTitle := nl.EventReasonAddedOrUpdated
 	eventType := api_v1.EventTypeNormal
 	eventWarningMessage := ""
@@ -157,6 +167,7 @@ func (lbc *LoadBalancerController) updateTransportServerStatusAndEvents(tsConfig
 
 	msg := fmt.Sprintf("Configuration for %v was added or updated %s", getResourceKey(&tsConfig.TransportServer.ObjectMeta), eventWarningMessage)
 	lbc.recorder.Event(tsConfig.TransportServer, eventType, eventTitle, msg)
+	lbc.recordResourceState(transportserver, getResourceKey(&tsConfig.TransportServer.ObjectMeta), state, operationErr)
 
 	if lbc.reportCustomResourceStatusEnabled() {
 		// Defer TS status updates during startup to avoid serial API calls
@@ -259,6 +270,22 @@ func (lbc *LoadBalancerController) createTransportServerEx(transportServer *conf
 		scrtRefs[scrtKey] = scrtRef
 	}
 
+	if transportServer.Spec.TLS != nil && transportServer.Spec.TLS.MTLS != nil && transportServer.Spec.TLS.MTLS.ClientCertSecret != "" {
+		scrtKey := transportServer.Namespace + "/" + transportServer.Spec.TLS.MTLS.ClientCertSecret
+
+		scrtRef := lbc.secretStore.GetSecret(scrtKey)
+		if scrtRef.Error != nil {
+			nl.Warnf(lbc.Logger, "Error trying to get the client certificate secret %v for TransportServer %v: %v", scrtKey, transportServer.Name, scrtRef.Error)
+		}
+
+		scrtRefs[scrtKey] = scrtRef
+	}
+
+	policies, policyErrors := lbc.getPolicies(transportServer.Spec.Policies, transportServer.Namespace)
+	for _, err := range policyErrors {
+		nl.Warnf(lbc.Logger, "Error getting policy for TransportServer %s/%s: %v", transportServer.Namespace, transportServer.Name, err)
+	}
+
 	return &configs.TransportServerEx{
 		ListenerPort:     listenerPort,
 		IPv4:             ipv4,
@@ -269,6 +296,7 @@ func (lbc *LoadBalancerController) createTransportServerEx(transportServer *conf
 		ExternalNameSvcs: externalNameSvcs,
 		DisableIPV6:      disableIPV6,
 		SecretRefs:       scrtRefs,
+		Policies:         createPolicyMap(policies),
 	}
 }
 
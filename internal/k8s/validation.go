@@ -429,6 +429,10 @@ var (
 			validateCommaSeparatedList,
 			validatePolicyNames,
 		},
+		configs.DisableDefaultPoliciesAnnotation: {
+			validateRequiredAnnotation,
+			validateBoolAnnotation,
+		},
 	}
 	annotationNames = sortedAnnotationNames(annotationValidations)
 )
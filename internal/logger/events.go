@@ -5,6 +5,8 @@ const (
 	EventReasonAddedOrUpdatedWithError   = "AddedOrUpdatedWithError"   //nolint:revive
 	EventReasonAddedOrUpdatedWithWarning = "AddedOrUpdatedWithWarning" //nolint:revive
 	EventReasonBadConfig                 = "BadConfig"                 //nolint:revive
+	EventReasonCertificateExpired        = "CertificateExpired"        //nolint:revive
+	EventReasonCertificateExpiring       = "CertificateExpiring"       //nolint:revive
 	EventReasonCreateDNSEndpoint         = "CreateDNSEndpoint"         //nolint:revive
 	EventReasonCreateCertificate         = "CreateCertificate"         //nolint:revive
 	EventReasonDeleteCertificate         = "DeleteCertificate"         //nolint:revive
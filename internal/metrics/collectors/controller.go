@@ -1,8 +1,17 @@
 package collectors
 
-import "github.com/prometheus/client_golang/prometheus"
+import (
+	"strings"
+	"sync"
+	"time"
 
-var labelNamesController = []string{"type"}
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	labelNamesController = []string{"type"}
+	syncDurationBuckets  = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+)
 
 // ControllerCollector is an interface for the metrics of the Controller
 type ControllerCollector interface {
@@ -10,6 +19,12 @@ type ControllerCollector interface {
 	SetVirtualServers(count int)
 	SetVirtualServerRoutes(count int)
 	SetTransportServers(tlsPassthroughCount, tcpCount, udpCount int)
+	ObserveSync(kind string, outcome string, duration time.Duration)
+	SetResourceState(kind string, key string, state string)
+	DeleteResourceState(kind string, key string)
+	IncReloads(trigger string)
+	SetCertificateExpiry(secretKey string, notAfter time.Time, resources []string)
+	DeleteCertificateExpiry(secretKey string)
 	Register(registry *prometheus.Registry) error
 }
 
@@ -20,6 +35,22 @@ type ControllerMetricsCollector struct {
 	virtualServersTotal      prometheus.Gauge
 	virtualServerRoutesTotal prometheus.Gauge
 	transportServersTotal    *prometheus.GaugeVec
+	syncDuration             *prometheus.HistogramVec
+	syncsTotal               *prometheus.CounterVec
+	resourceStates           *prometheus.GaugeVec
+	reloadsTotal             *prometheus.CounterVec
+	// states maps the kinds of the resources to the states of the resources by their keys
+	states      map[string]map[string]string
+	statesMutex sync.Mutex
+	// certificateExpiries maps the keys of the secrets to the expiries of their certificates
+	certificateExpiries      map[string]certificateExpiry
+	certificateExpiryDesc    *prometheus.Desc
+	certificateExpiriesMutex sync.Mutex
+}
+
+type certificateExpiry struct {
+	notAfter  time.Time
+	resources []string
 }
 
 // NewControllerMetricsCollector creates a new ControllerMetricsCollector
@@ -73,6 +104,51 @@ func NewControllerMetricsCollector(crdsEnabled bool, constLabels map[string]stri
 		virtualServersTotal:      vsResTotal,
 		virtualServerRoutesTotal: vsrResTotal,
 		transportServersTotal:    tsResTotal,
+		syncDuration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:        "sync_duration_seconds",
+				Namespace:   metricsNamespace,
+				Help:        "Duration in seconds of the syncs of the resources by kind",
+				ConstLabels: constLabels,
+				Buckets:     syncDurationBuckets,
+			},
+			[]string{"kind"},
+		),
+		syncsTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name:        "syncs_total",
+				Namespace:   metricsNamespace,
+				Help:        "Number of syncs of the resources by kind and outcome",
+				ConstLabels: constLabels,
+			},
+			[]string{"kind", "outcome"},
+		),
+		resourceStates: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name:        "resource_states",
+				Namespace:   metricsNamespace,
+				Help:        "Number of handled resources by kind and state",
+				ConstLabels: constLabels,
+			},
+			[]string{"kind", "state"},
+		),
+		reloadsTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name:        "sync_reloads_total",
+				Namespace:   metricsNamespace,
+				Help:        "Number of NGINX reloads by the trigger of the reload",
+				ConstLabels: constLabels,
+			},
+			[]string{"trigger"},
+		),
+		states:              make(map[string]map[string]string),
+		certificateExpiries: make(map[string]certificateExpiry),
+		certificateExpiryDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "", "certificate_expiry_seconds"),
+			"Number of seconds until the expiry of the TLS or CA certificate of a secret, by the resources referencing the secret",
+			[]string{"secret_namespace", "secret_name", "resource_type", "resource_namespace", "resource_name"},
+			constLabels,
+		),
 	}
 
 	// if we don't set to 0 metrics with the label type, the metrics will not be created initially
@@ -119,9 +195,98 @@ func (cc *ControllerMetricsCollector) SetTransportServers(tlsPassthroughCount, t
 	cc.transportServersTotal.WithLabelValues("udp").Set(float64(udpCount))
 }
 
+// ObserveSync records the duration and the outcome of a sync of a resource of the given kind
+func (cc *ControllerMetricsCollector) ObserveSync(kind string, outcome string, duration time.Duration) {
+	cc.syncDuration.WithLabelValues(kind).Observe(duration.Seconds())
+	cc.syncsTotal.WithLabelValues(kind, outcome).Inc()
+}
+
+// SetResourceState sets the state of the resource with the given kind and key
+func (cc *ControllerMetricsCollector) SetResourceState(kind string, key string, state string) {
+	cc.statesMutex.Lock()
+	defer cc.statesMutex.Unlock()
+	if _, ok := cc.states[kind]; !ok {
+		cc.states[kind] = make(map[string]string)
+	}
+	cc.states[kind][key] = state
+	cc.updateResourceStates(kind)
+}
+
+// DeleteResourceState deletes the state of the resource with the given kind and key
+func (cc *ControllerMetricsCollector) DeleteResourceState(kind string, key string) {
+	cc.statesMutex.Lock()
+	defer cc.statesMutex.Unlock()
+	if _, ok := cc.states[kind][key]; !ok {
+		return
+	}
+	delete(cc.states[kind], key)
+	cc.updateResourceStates(kind)
+}
+
+// updateResourceStates updates the resource states gauge of the given kind.
+// The states without the resources are kept with the value 0.
+func (cc *ControllerMetricsCollector) updateResourceStates(kind string) {
+	counts := make(map[string]int)
+	for _, state := range cc.states[kind] {
+		counts[state]++
+	}
+	cc.resourceStates.DeletePartialMatch(prometheus.Labels{"kind": kind})
+	for state, count := range counts {
+		cc.resourceStates.WithLabelValues(kind, state).Set(float64(count))
+	}
+}
+
+// IncReloads increments the counter of NGINX reloads for the given trigger
+func (cc *ControllerMetricsCollector) IncReloads(trigger string) {
+	cc.reloadsTotal.WithLabelValues(trigger).Inc()
+}
+
+// SetCertificateExpiry sets the expiry of the certificate of the secret with the given key <namespace/name>.
+// The resources referencing the secret are identified by the keys in the resource_type/namespace/name format.
+func (cc *ControllerMetricsCollector) SetCertificateExpiry(secretKey string, notAfter time.Time, resources []string) {
+	cc.certificateExpiriesMutex.Lock()
+	defer cc.certificateExpiriesMutex.Unlock()
+	cc.certificateExpiries[secretKey] = certificateExpiry{notAfter: notAfter, resources: resources}
+}
+
+// DeleteCertificateExpiry deletes the expiry of the certificate of the secret with the given key
+func (cc *ControllerMetricsCollector) DeleteCertificateExpiry(secretKey string) {
+	cc.certificateExpiriesMutex.Lock()
+	defer cc.certificateExpiriesMutex.Unlock()
+	delete(cc.certificateExpiries, secretKey)
+}
+
+// collectCertificateExpiries sends the seconds until the expiry of the certificates, computed at the time of the collection.
+// A secret not referenced by any resource, for example the default server secret, is sent with empty resource labels.
+func (cc *ControllerMetricsCollector) collectCertificateExpiries(ch chan<- prometheus.Metric) {
+	cc.certificateExpiriesMutex.Lock()
+	defer cc.certificateExpiriesMutex.Unlock()
+	for secretKey, expiry := range cc.certificateExpiries {
+		secretNamespace, secretName, _ := strings.Cut(secretKey, "/")
+		seconds := time.Until(expiry.notAfter).Seconds()
+		resources := expiry.resources
+		if len(resources) == 0 {
+			resources = []string{"//"}
+		}
+		for _, resource := range resources {
+			labelValues := strings.SplitN(resource, "/", 3)
+			if len(labelValues) != 3 {
+				continue
+			}
+			ch <- prometheus.MustNewConstMetric(cc.certificateExpiryDesc, prometheus.GaugeValue, seconds,
+				secretNamespace, secretName, labelValues[0], labelValues[1], labelValues[2])
+		}
+	}
+}
+
 // Describe implements prometheus.Collector interface Describe method
 func (cc *ControllerMetricsCollector) Describe(ch chan<- *prometheus.Desc) {
 	cc.ingressesTotal.Describe(ch)
+	cc.syncDuration.Describe(ch)
+	cc.syncsTotal.Describe(ch)
+	cc.resourceStates.Describe(ch)
+	cc.reloadsTotal.Describe(ch)
+	ch <- cc.certificateExpiryDesc
 	if cc.crdsEnabled {
 		cc.virtualServersTotal.Describe(ch)
 		cc.virtualServerRoutesTotal.Describe(ch)
@@ -132,6 +297,11 @@ func (cc *ControllerMetricsCollector) Describe(ch chan<- *prometheus.Desc) {
 // Collect implements the prometheus.Collector interface Collect method
 func (cc *ControllerMetricsCollector) Collect(ch chan<- prometheus.Metric) {
 	cc.ingressesTotal.Collect(ch)
+	cc.syncDuration.Collect(ch)
+	cc.syncsTotal.Collect(ch)
+	cc.resourceStates.Collect(ch)
+	cc.reloadsTotal.Collect(ch)
+	cc.collectCertificateExpiries(ch)
 	if cc.crdsEnabled {
 		cc.virtualServersTotal.Collect(ch)
 		cc.virtualServerRoutesTotal.Collect(ch)
@@ -166,3 +336,21 @@ func (cc *ControllerFakeCollector) SetVirtualServerRoutes(_ int) {}
 
 // SetTransportServers implements a fake SetTransportServers
 func (cc *ControllerFakeCollector) SetTransportServers(int, int, int) {}
+
+// ObserveSync implements a fake ObserveSync
+func (cc *ControllerFakeCollector) ObserveSync(string, string, time.Duration) {}
+
+// SetResourceState implements a fake SetResourceState
+func (cc *ControllerFakeCollector) SetResourceState(string, string, string) {}
+
+// DeleteResourceState implements a fake DeleteResourceState
+func (cc *ControllerFakeCollector) DeleteResourceState(string, string) {}
+
+// IncReloads implements a fake IncReloads
+func (cc *ControllerFakeCollector) IncReloads(string) {}
+
+// SetCertificateExpiry implements a fake SetCertificateExpiry
+func (cc *ControllerFakeCollector) SetCertificateExpiry(string, time.Time, []string) {}
+
+// DeleteCertificateExpiry implements a fake DeleteCertificateExpiry
+func (cc *ControllerFakeCollector) DeleteCertificateExpiry(string) {}
@@ -0,0 +1,107 @@
+package collectors
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestControllerMetricsCollectorResourceStates(t *testing.T) {
+	t.Parallel()
+	c := NewControllerMetricsCollector(true, nil)
+	c.SetResourceState("virtualserver", "default/cafe", "valid")
+	c.SetResourceState("virtualserver", "default/tea", "valid")
+	c.SetResourceState("virtualserver", "default/coffee", "warning")
+	c.SetResourceState("ingress", "default/cafe-ingress", "invalid")
+
+	c.SetResourceState("virtualserver", "default/coffee", "valid")
+	c.DeleteResourceState("virtualserver", "default/tea")
+	c.DeleteResourceState("ingress", "default/unknown")
+
+	expected := `
+# HELP nginx_ingress_controller_resource_states Number of handled resources by kind and state
+# TYPE nginx_ingress_controller_resource_states gauge
+nginx_ingress_controller_resource_states{kind="ingress",state="invalid"} 1
+nginx_ingress_controller_resource_states{kind="virtualserver",state="valid"} 2
+`
+	if err := testutil.CollectAndCompare(c.resourceStates, strings.NewReader(expected)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestControllerMetricsCollectorSyncsAndReloads(t *testing.T) {
+	t.Parallel()
+	c := NewControllerMetricsCollector(true, nil)
+	c.ObserveSync("virtualserver", "valid", 10*time.Millisecond)
+	c.ObserveSync("virtualserver", "valid", 20*time.Millisecond)
+	c.ObserveSync("secret", "error", time.Second)
+	c.IncReloads("endpoints")
+	c.IncReloads("batch")
+	c.IncReloads("batch")
+
+	expectedSyncs := `
+# HELP nginx_ingress_controller_syncs_total Number of syncs of the resources by kind and outcome
+# TYPE nginx_ingress_controller_syncs_total counter
+nginx_ingress_controller_syncs_total{kind="secret",outcome="error"} 1
+nginx_ingress_controller_syncs_total{kind="virtualserver",outcome="valid"} 2
+`
+	if err := testutil.CollectAndCompare(c.syncsTotal, strings.NewReader(expectedSyncs)); err != nil {
+		t.Error(err)
+	}
+	if count := testutil.CollectAndCount(c.syncDuration); count != 2 {
+		t.Errorf("want 2 sync duration series, got %d", count)
+	}
+
+	expectedReloads := `
+# HELP nginx_ingress_controller_sync_reloads_total Number of NGINX reloads by the trigger of the reload
+# TYPE nginx_ingress_controller_sync_reloads_total counter
+nginx_ingress_controller_sync_reloads_total{trigger="batch"} 2
+nginx_ingress_controller_sync_reloads_total{trigger="endpoints"} 1
+`
+	if err := testutil.CollectAndCompare(c.reloadsTotal, strings.NewReader(expectedReloads)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestControllerMetricsCollectorCertificateExpiries(t *testing.T) {
+	t.Parallel()
+	c := NewControllerMetricsCollector(true, nil)
+	c.SetCertificateExpiry("default/cafe-secret", time.Now().Add(time.Hour), []string{"virtualserver/default/cafe", "ingress/default/cafe-ingress"})
+	c.SetCertificateExpiry("nginx-ingress/default-server-secret", time.Now().Add(-time.Hour), nil)
+	c.SetCertificateExpiry("default/tea-secret", time.Now(), []string{"transportserver/default/tea"})
+	c.DeleteCertificateExpiry("default/tea-secret")
+
+	metrics := make(chan prometheus.Metric, 10)
+	c.collectCertificateExpiries(metrics)
+	close(metrics)
+
+	expiries := make(map[string]float64)
+	for m := range metrics {
+		var metric dto.Metric
+		if err := m.Write(&metric); err != nil {
+			t.Fatal(err)
+		}
+		labels := make(map[string]string)
+		for _, l := range metric.GetLabel() {
+			labels[l.GetName()] = l.GetValue()
+		}
+		key := strings.Join([]string{labels["secret_namespace"], labels["secret_name"], labels["resource_type"], labels["resource_namespace"], labels["resource_name"]}, "/")
+		expiries[key] = metric.GetGauge().GetValue()
+	}
+
+	if len(expiries) != 3 {
+		t.Fatalf("want 3 series, got %v", expiries)
+	}
+	for _, key := range []string{"default/cafe-secret/virtualserver/default/cafe", "default/cafe-secret/ingress/default/cafe-ingress"} {
+		if v, ok := expiries[key]; !ok || v <= 3500 || v > 3600 {
+			t.Errorf("want about 3600 seconds for %s, got %v", key, v)
+		}
+	}
+	if v, ok := expiries["nginx-ingress/default-server-secret///"]; !ok || v > -3600 {
+		t.Errorf("want about -3600 seconds for the secret without resources, got %v", v)
+	}
+}
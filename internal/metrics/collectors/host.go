@@ -0,0 +1,188 @@
+package collectors
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+
+	nl "github.com/nginx/kubernetes-ingress/internal/logger"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const hostMetricsSeparator = "nginx_host_metrics:"
+
+var labelNamesHost = []string{"resource_type", "resource_namespace", "resource_name", "upstream", "route", "code"}
+
+// HostCollector is an interface for the request metrics of the Ingress, VirtualServer and VirtualServerRoute resources
+type HostCollector interface {
+	RecordRequest(string)
+	DeleteMetrics([]string)
+	Register(*prometheus.Registry) error
+}
+
+// HostMetricsCollector implements the HostCollector interface and prometheus.Collector interface
+type HostMetricsCollector struct {
+	requestsTotal         *prometheus.CounterVec
+	requestDuration       *prometheus.HistogramVec
+	metricsPublishedMap   metricsPublishedMap
+	metricsPublishedMutex sync.Mutex
+	logger                *slog.Logger
+}
+
+type hostSyslogMsg struct {
+	ResourceType      string `json:"resourceType"`
+	ResourceNamespace string `json:"resourceNamespace"`
+	ResourceName      string `json:"resourceName"`
+	Upstream          string `json:"upstream"`
+	Route             string `json:"route"`
+	Status            string `json:"status"`
+	RequestTime       string `json:"requestTime"`
+}
+
+type hostMetric struct {
+	labelValues []string
+	duration    float64
+}
+
+// NewHostMetricsCollector creates a new HostMetricsCollector
+func NewHostMetricsCollector(ctx context.Context, constLabels map[string]string) *HostMetricsCollector {
+	return &HostMetricsCollector{
+		requestsTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name:        "host_requests_total",
+				Namespace:   metricsNamespace,
+				Help:        "Number of client requests of the Ingress, VirtualServer and VirtualServerRoute resources",
+				ConstLabels: constLabels,
+			},
+			labelNamesHost,
+		),
+		requestDuration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:        "host_request_duration_seconds",
+				Namespace:   metricsNamespace,
+				Help:        "Bucketed processing times of the client requests of the Ingress, VirtualServer and VirtualServerRoute resources",
+				ConstLabels: constLabels,
+				Buckets:     prometheus.DefBuckets,
+			},
+			labelNamesHost,
+		),
+		metricsPublishedMap: make(metricsPublishedMap),
+		logger:              nl.LoggerFromContext(ctx),
+	}
+}
+
+// RecordRequest parses the syslog message and records the request in the metrics of its resource
+func (c *HostMetricsCollector) RecordRequest(syslogMsg string) {
+	hm, err := parseHostMessage(syslogMsg)
+	if err != nil {
+		nl.Debugf(c.logger, "could not parse syslog message: %v", err)
+		return
+	}
+	c.requestsTotal.WithLabelValues(hm.labelValues...).Inc()
+	c.requestDuration.WithLabelValues(hm.labelValues...).Observe(hm.duration)
+	c.updateMetricsPublished(hm.labelValues)
+}
+
+// DeleteMetrics deletes all metrics published for the given resources.
+// The resources are identified by the keys in the resource_type/namespace/name format.
+func (c *HostMetricsCollector) DeleteMetrics(resources []string) {
+	for _, resource := range resources {
+		for _, labelValues := range c.listAndDeleteMetricsPublished(resource) {
+			c.requestsTotal.DeleteLabelValues(labelValues...)
+			c.requestDuration.DeleteLabelValues(labelValues...)
+		}
+	}
+}
+
+func (c *HostMetricsCollector) updateMetricsPublished(labelValues []string) {
+	c.metricsPublishedMutex.Lock()
+	defer c.metricsPublishedMutex.Unlock()
+	key := strings.Join(labelValues[:3], "/")
+	if _, ok := c.metricsPublishedMap[key]; !ok {
+		c.metricsPublishedMap[key] = make(metricsSet)
+	}
+	c.metricsPublishedMap[key][strings.Join(labelValues, "\x00")] = struct{}{}
+}
+
+func (c *HostMetricsCollector) listAndDeleteMetricsPublished(key string) (metricsPublished [][]string) {
+	c.metricsPublishedMutex.Lock()
+	defer c.metricsPublishedMutex.Unlock()
+	for labelValues := range c.metricsPublishedMap[key] {
+		metricsPublished = append(metricsPublished, strings.Split(labelValues, "\x00"))
+	}
+	delete(c.metricsPublishedMap, key)
+	return metricsPublished
+}
+
+// parseHostMessage returns the label values and the request time of the request in the syslog message.
+// The status is reduced to its class, for example 5xx, to keep the number of the series bounded.
+func parseHostMessage(msg string) (hostMetric, error) {
+	_, info, found := strings.Cut(msg, hostMetricsSeparator)
+	if !found {
+		return hostMetric{}, fmt.Errorf("wrong message format: %s, expected message to contain \"%s\"", msg, hostMetricsSeparator)
+	}
+	var sm hostSyslogMsg
+	if err := json.Unmarshal([]byte(info), &sm); err != nil {
+		return hostMetric{}, fmt.Errorf("could not unmarshal %s: %w", msg, err)
+	}
+	if sm.ResourceType == "" || sm.ResourceNamespace == "" || sm.ResourceName == "" {
+		return hostMetric{}, fmt.Errorf("no resource in message %s", msg)
+	}
+	status, err := strconv.Atoi(sm.Status)
+	if err != nil || status < 100 || status > 599 {
+		return hostMetric{}, fmt.Errorf("invalid status %q in message %s", sm.Status, msg)
+	}
+	duration, err := strconv.ParseFloat(sm.RequestTime, 64)
+	if err != nil {
+		return hostMetric{}, fmt.Errorf("could not parse float from request time %s: %w", sm.RequestTime, err)
+	}
+	return hostMetric{
+		labelValues: []string{
+			sm.ResourceType,
+			sm.ResourceNamespace,
+			sm.ResourceName,
+			sm.Upstream,
+			sm.Route,
+			fmt.Sprintf("%dxx", status/100),
+		},
+		duration: duration,
+	}, nil
+}
+
+// Describe implements prometheus.Collector interface Describe method
+func (c *HostMetricsCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.requestsTotal.Describe(ch)
+	c.requestDuration.Describe(ch)
+}
+
+// Collect implements the prometheus.Collector interface Collect method
+func (c *HostMetricsCollector) Collect(ch chan<- prometheus.Metric) {
+	c.requestsTotal.Collect(ch)
+	c.requestDuration.Collect(ch)
+}
+
+// Register registers all the metrics of the collector
+func (c *HostMetricsCollector) Register(registry *prometheus.Registry) error {
+	return registry.Register(c)
+}
+
+// HostFakeCollector is a fake collector that implements the HostCollector interface
+type HostFakeCollector struct{}
+
+// NewHostFakeCollector creates a fake collector that implements the HostCollector interface
+func NewHostFakeCollector() *HostFakeCollector {
+	return &HostFakeCollector{}
+}
+
+// RecordRequest implements a fake RecordRequest
+func (c *HostFakeCollector) RecordRequest(string) {}
+
+// DeleteMetrics implements a fake DeleteMetrics
+func (c *HostFakeCollector) DeleteMetrics([]string) {}
+
+// Register implements a fake Register
+func (c *HostFakeCollector) Register(_ *prometheus.Registry) error { return nil }
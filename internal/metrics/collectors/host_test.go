@@ -0,0 +1,95 @@
+package collectors
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestParseHostMessage(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		msg         string
+		expectedErr bool
+		expected    hostMetric
+	}{
+		{
+			msg: `nginx_host_metrics: {"resourceType":"virtualserver", "resourceNamespace":"default", "resourceName":"cafe", "upstream":"vs_default_cafe_tea", "route":"/tea", "status":"503", "requestTime":"0.012"}`,
+			expected: hostMetric{
+				labelValues: []string{"virtualserver", "default", "cafe", "vs_default_cafe_tea", "/tea", "5xx"},
+				duration:    0.012,
+			},
+		},
+		{
+			msg: `nginx_host_metrics: {"resourceType":"ingress", "resourceNamespace":"default", "resourceName":"cafe-ingress", "upstream":"", "route":"~ \"\\.jpg$\"", "status":"200", "requestTime":"0.000"}`,
+			expected: hostMetric{
+				labelValues: []string{"ingress", "default", "cafe-ingress", "", `~ "\.jpg$"`, "2xx"},
+				duration:    0,
+			},
+		},
+		{
+			msg:         `nginx_host_metrics: {"resourceType":"", "resourceNamespace":"", "resourceName":"", "upstream":"", "route":"", "status":"404", "requestTime":"0.000"}`,
+			expectedErr: true,
+		},
+		{
+			msg:         `nginx_host_metrics: {"resourceType":"ingress", "resourceNamespace":"default", "resourceName":"cafe-ingress", "upstream":"", "route":"/", "status":"999", "requestTime":"0.000"}`,
+			expectedErr: true,
+		},
+		{
+			msg:         `nginx_host_metrics: {"resourceType":"ingress", "resourceNamespace":"default", "resourceName":"cafe-ingress", "upstream":"", "route":"/", "status":"200", "requestTime":"-"}`,
+			expectedErr: true,
+		},
+		{
+			msg:         `nginx: {"upstreamAddress":"10.0.0.1", "upstreamResponseTime":"0.003", "proxyHost":"upstream-1", "upstreamStatus": "200"}`,
+			expectedErr: true,
+		},
+		{
+			msg:         `nginx_host_metrics: {"badJson}`,
+			expectedErr: true,
+		},
+	}
+	for _, test := range tests {
+		actual, err := parseHostMessage(test.msg)
+		if test.expectedErr {
+			if err == nil {
+				t.Errorf("parseHostMessage should return an error for the case of %s, got nil", test.msg)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("parseHostMessage returned an unexpected error for the case of %s: %v", test.msg, err)
+		}
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("parseHostMessage returned: %v, expected: %v for the case of %s", actual, test.expected, test.msg)
+		}
+	}
+}
+
+func TestHostMetricsCollectorDeleteMetrics(t *testing.T) {
+	t.Parallel()
+	c := NewHostMetricsCollector(context.Background(), nil)
+	c.RecordRequest(`nginx_host_metrics: {"resourceType":"virtualserver", "resourceNamespace":"default", "resourceName":"cafe", "upstream":"vs_default_cafe_tea", "route":"/tea", "status":"200", "requestTime":"0.010"}`)
+	c.RecordRequest(`nginx_host_metrics: {"resourceType":"virtualserver", "resourceNamespace":"default", "resourceName":"cafe", "upstream":"vs_default_cafe_tea", "route":"/tea", "status":"502", "requestTime":"0.020"}`)
+	c.RecordRequest(`nginx_host_metrics: {"resourceType":"ingress", "resourceNamespace":"default", "resourceName":"cafe-ingress", "upstream":"default-cafe-ingress-cafe.example.com-coffee-svc-80", "route":"/coffee", "status":"200", "requestTime":"0.030"}`)
+
+	if count := testutil.CollectAndCount(c.requestsTotal); count != 3 {
+		t.Fatalf("want 3 series before the deletion, got %d", count)
+	}
+
+	c.DeleteMetrics([]string{"virtualserver/default/cafe"})
+
+	expected := `
+# HELP nginx_ingress_controller_host_requests_total Number of client requests of the Ingress, VirtualServer and VirtualServerRoute resources
+# TYPE nginx_ingress_controller_host_requests_total counter
+nginx_ingress_controller_host_requests_total{code="2xx",resource_name="cafe-ingress",resource_namespace="default",resource_type="ingress",route="/coffee",upstream="default-cafe-ingress-cafe.example.com-coffee-svc-80"} 1
+`
+	if err := testutil.CollectAndCompare(c.requestsTotal, strings.NewReader(expected)); err != nil {
+		t.Error(err)
+	}
+	if count := testutil.CollectAndCount(c.requestDuration); count != 1 {
+		t.Errorf("want 1 histogram series after the deletion, got %d", count)
+	}
+}
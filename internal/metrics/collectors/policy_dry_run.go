@@ -0,0 +1,93 @@
+package collectors
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	nl "github.com/nginx/kubernetes-ingress/internal/logger"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const policyDryRunSeparator = "nginx_policy_dry_run:"
+
+var labelNamesPolicyDryRun = []string{"policy_namespace", "policy_name"}
+
+// PolicyDryRunCollector is an interface for the metrics of the policies in the dry run mode
+type PolicyDryRunCollector interface {
+	RecordRejections(string)
+	Register(*prometheus.Registry) error
+}
+
+// PolicyDryRunMetricsCollector implements the PolicyDryRunCollector interface and prometheus.Collector interface
+type PolicyDryRunMetricsCollector struct {
+	rejectionsTotal *prometheus.CounterVec
+	logger          *slog.Logger
+}
+
+type policyDryRunMsg struct {
+	Policies string `json:"policies"`
+}
+
+// NewPolicyDryRunMetricsCollector creates a new PolicyDryRunMetricsCollector
+func NewPolicyDryRunMetricsCollector(ctx context.Context, constLabels map[string]string) *PolicyDryRunMetricsCollector {
+	return &PolicyDryRunMetricsCollector{
+		rejectionsTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name:        "policy_dry_run_rejections_total",
+				Namespace:   metricsNamespace,
+				Help:        "Number of requests that the policies in the dry run mode would reject",
+				ConstLabels: constLabels,
+			},
+			labelNamesPolicyDryRun,
+		),
+		logger: nl.LoggerFromContext(ctx),
+	}
+}
+
+// RecordRejections parses the syslog message and increments the rejections of every policy in it
+func (c *PolicyDryRunMetricsCollector) RecordRejections(syslogMsg string) {
+	policies, err := parsePolicyDryRunMessage(syslogMsg)
+	if err != nil {
+		nl.Debugf(c.logger, "could not parse syslog message: %v", err)
+		return
+	}
+	for _, p := range policies {
+		namespace, name, found := strings.Cut(p, "/")
+		if !found {
+			nl.Debugf(c.logger, "invalid policy %q in syslog message: %s", p, syslogMsg)
+			continue
+		}
+		c.rejectionsTotal.WithLabelValues(namespace, name).Inc()
+	}
+}
+
+// parsePolicyDryRunMessage returns the keys (namespace/name) of the policies in the syslog message
+func parsePolicyDryRunMessage(msg string) ([]string, error) {
+	_, info, found := strings.Cut(msg, policyDryRunSeparator)
+	if !found {
+		return nil, fmt.Errorf("wrong message format: %s, expected message to contain \"%s\"", msg, policyDryRunSeparator)
+	}
+	var sm policyDryRunMsg
+	if err := json.Unmarshal([]byte(info), &sm); err != nil {
+		return nil, fmt.Errorf("could not unmarshal %s: %w", msg, err)
+	}
+	return strings.Fields(sm.Policies), nil
+}
+
+// Describe implements prometheus.Collector interface Describe method
+func (c *PolicyDryRunMetricsCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.rejectionsTotal.Describe(ch)
+}
+
+// Collect implements the prometheus.Collector interface Collect method
+func (c *PolicyDryRunMetricsCollector) Collect(ch chan<- prometheus.Metric) {
+	c.rejectionsTotal.Collect(ch)
+}
+
+// Register registers all the metrics of the collector
+func (c *PolicyDryRunMetricsCollector) Register(registry *prometheus.Registry) error {
+	return registry.Register(c)
+}
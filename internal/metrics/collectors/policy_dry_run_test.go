@@ -0,0 +1,47 @@
+package collectors
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParsePolicyDryRunMessage(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		msg         string
+		expectedErr bool
+		expected    []string
+	}{
+		{
+			msg:      `nginx_policy_dry_run: {"host":"cafe.example.com", "policies":"default/allow-list"}`,
+			expected: []string{"default/allow-list"},
+		},
+		{
+			msg:      `nginx_policy_dry_run: {"host":"cafe.example.com", "policies":"default/allow-list nginx-ingress/jwt-policy"}`,
+			expected: []string{"default/allow-list", "nginx-ingress/jwt-policy"},
+		},
+		{
+			msg:         `nginx: {"upstreamAddress":"10.0.0.1", "upstreamResponseTime":"0.003", "proxyHost":"upstream-1", "upstreamStatus": "200"}`,
+			expectedErr: true,
+		},
+		{
+			msg:         `nginx_policy_dry_run: {"badJson}`,
+			expectedErr: true,
+		},
+	}
+	for _, test := range tests {
+		actual, err := parsePolicyDryRunMessage(test.msg)
+		if test.expectedErr {
+			if err == nil {
+				t.Errorf("parsePolicyDryRunMessage should return an error for the case of %s, got nil", test.msg)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("parsePolicyDryRunMessage returned an unexpected error for the case of %s: %v", test.msg, err)
+		}
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("parsePolicyDryRunMessage returned: %v, expected: %v for the case of %s", actual, test.expected, test.msg)
+		}
+	}
+}
@@ -0,0 +1,353 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	nl "github.com/nginx/kubernetes-ingress/internal/logger"
+)
+
+const nginxSyslogSeparator = "nginx:"
+
+const (
+	// outlierDetectionUpdateDelay is the delay of the update of the upstreams after a server is ejected or re-admitted,
+	// so that the changes of the servers of all the upstreams during the delay are applied with a single update.
+	outlierDetectionUpdateDelay = time.Second
+	// outlierDetectionMinUpdateInterval is the minimum interval between the updates of the upstreams,
+	// which limits the reloads of NGINX when upstream servers are flapping.
+	outlierDetectionMinUpdateInterval = 10 * time.Second
+	// outlierDetectionReadmissionSteps is the number of steps of the gradual re-admission of an ejected server.
+	// At every step, the weight of the server is increased by one, up to the weight of the other servers of the upstream.
+	outlierDetectionReadmissionSteps = 4
+)
+
+// OutlierDetectionConfig defines the outlier detection of an upstream.
+type OutlierDetectionConfig struct {
+	ConsecutiveErrors  int
+	EjectionTime       time.Duration
+	MaxEjectionPercent int
+	Servers            []string
+}
+
+// OutlierDetector ejects the upstream servers that return consecutive 5xx responses or time out.
+// It reads the upstream status of the requests from the syslog messages logged by nginx.
+// The ejected servers are re-admitted gradually after the ejection time: during the ejection time,
+// their weight is increased in steps up to the weight of the other servers of the upstream.
+// The changes of the servers are batched and passed to the update handler at most once per minimum update interval.
+type OutlierDetector struct {
+	upstreams         map[string]*outlierDetectionUpstream
+	updateHandler     func(upstreams []string)
+	pendingUpdates    map[string]bool
+	updateTimer       *time.Timer
+	lastUpdate        time.Time
+	updateDelay       time.Duration
+	minUpdateInterval time.Duration
+	logger            *slog.Logger
+	mu                sync.Mutex
+}
+
+type outlierDetectionUpstream struct {
+	config  OutlierDetectionConfig
+	errors  map[string]int
+	ejected map[string]*ejection
+}
+
+// ejection is the ejection of an upstream server, which lasts until the server is fully re-admitted.
+type ejection struct {
+	timer *time.Timer
+	// step is the step of the gradual re-admission of the server, zero while the server is ejected
+	step int
+}
+
+type upstreamStatusMsg struct {
+	ProxyHost      string `json:"proxyHost"`
+	UpstreamAddr   string `json:"upstreamAddress"`
+	UpstreamStatus string `json:"upstreamStatus"`
+}
+
+// NewOutlierDetector creates a new OutlierDetector.
+func NewOutlierDetector(ctx context.Context) *OutlierDetector {
+	return &OutlierDetector{
+		upstreams:         make(map[string]*outlierDetectionUpstream),
+		pendingUpdates:    make(map[string]bool),
+		updateDelay:       outlierDetectionUpdateDelay,
+		minUpdateInterval: outlierDetectionMinUpdateInterval,
+		logger:            nl.LoggerFromContext(ctx),
+	}
+}
+
+// SetUpdateHandler sets the function which is called with the sorted names of the upstreams
+// when servers of the upstreams are ejected or re-admitted.
+func (d *OutlierDetector) SetUpdateHandler(handler func(upstreams []string)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.updateHandler = handler
+}
+
+// UpdateUpstream adds or updates the outlier detection of the upstream.
+// The state of the servers that are no longer in the upstream is removed.
+func (d *OutlierDetector) UpdateUpstream(name string, config OutlierDetectionConfig) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	u, exists := d.upstreams[name]
+	if !exists {
+		u = &outlierDetectionUpstream{
+			errors:  make(map[string]int),
+			ejected: make(map[string]*ejection),
+		}
+		d.upstreams[name] = u
+	}
+	u.config = config
+
+	for server := range u.errors {
+		if !slices.Contains(config.Servers, server) {
+			delete(u.errors, server)
+		}
+	}
+	for server, e := range u.ejected {
+		if !slices.Contains(config.Servers, server) {
+			e.timer.Stop()
+			delete(u.ejected, server)
+		}
+	}
+}
+
+// DeleteUpstream deletes the outlier detection of the upstream.
+func (d *OutlierDetector) DeleteUpstream(name string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	u, exists := d.upstreams[name]
+	if !exists {
+		return
+	}
+	for _, e := range u.ejected {
+		e.timer.Stop()
+	}
+	delete(d.upstreams, name)
+	delete(d.pendingUpdates, name)
+}
+
+// EjectedServers returns the sorted ejected servers of the upstream.
+// The servers that are being re-admitted are not ejected.
+func (d *OutlierDetector) EjectedServers(name string) []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	u, exists := d.upstreams[name]
+	if !exists {
+		return nil
+	}
+
+	var servers []string
+	for server, e := range u.ejected {
+		if e.step == 0 {
+			servers = append(servers, server)
+		}
+	}
+	slices.Sort(servers)
+	return servers
+}
+
+// ServerWeights returns the weights of the servers of the upstream while servers of the upstream are being re-admitted.
+// The servers that are being re-admitted have a lower weight than the other servers.
+// It returns nil if no servers of the upstream are being re-admitted.
+func (d *OutlierDetector) ServerWeights(name string) map[string]int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	u, exists := d.upstreams[name]
+	if !exists {
+		return nil
+	}
+
+	var weights map[string]int
+	for server, e := range u.ejected {
+		if e.step == 0 {
+			continue
+		}
+		if weights == nil {
+			weights = make(map[string]int)
+			for _, s := range u.config.Servers {
+				weights[s] = outlierDetectionReadmissionSteps
+			}
+		}
+		weights[server] = e.step
+	}
+	return weights
+}
+
+// HandleMessage counts the consecutive errors of the upstream servers from the syslog message
+// and ejects the servers that reach the consecutive errors of the upstream.
+func (d *OutlierDetector) HandleMessage(msg string) {
+	upstream, results, err := parseUpstreamStatusMessage(msg)
+	if err != nil {
+		nl.Debugf(d.logger, "could not parse syslog message for outlier detection: %v", err)
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	u, exists := d.upstreams[upstream]
+	if !exists {
+		return
+	}
+
+	for _, r := range results {
+		if !slices.Contains(u.config.Servers, r.server) {
+			continue
+		}
+		if !r.failed {
+			u.errors[r.server] = 0
+			continue
+		}
+		u.errors[r.server]++
+		if u.errors[r.server] >= u.config.ConsecutiveErrors && d.canEject(u, r.server) {
+			d.eject(upstream, u, r.server)
+		}
+	}
+}
+
+func (d *OutlierDetector) canEject(u *outlierDetectionUpstream, server string) bool {
+	if e, exists := u.ejected[server]; exists && e.step == 0 {
+		return false
+	}
+
+	ejected := 0
+	for _, e := range u.ejected {
+		if e.step == 0 {
+			ejected++
+		}
+	}
+	maxEjected := max(1, len(u.config.Servers)*u.config.MaxEjectionPercent/100)
+	return ejected < maxEjected
+}
+
+// eject ejects the server of the upstream. A server that is being re-admitted is ejected again.
+// The caller must hold the lock.
+func (d *OutlierDetector) eject(upstream string, u *outlierDetectionUpstream, server string) {
+	nl.Infof(d.logger, "Ejecting server %v of upstream %v for %v after %v consecutive errors", server, upstream, u.config.EjectionTime, u.errors[server])
+
+	if prev, exists := u.ejected[server]; exists {
+		prev.timer.Stop()
+	}
+	e := &ejection{}
+	e.timer = time.AfterFunc(u.config.EjectionTime, func() {
+		d.readmit(upstream, server, e)
+	})
+	u.ejected[server] = e
+	u.errors[server] = 0
+	d.scheduleUpdate(upstream)
+}
+
+// readmit increases the weight of the ejected server by one step. After the last step, the server is fully re-admitted.
+func (d *OutlierDetector) readmit(upstream string, server string, e *ejection) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	u, exists := d.upstreams[upstream]
+	if !exists || u.ejected[server] != e {
+		return
+	}
+
+	e.step++
+	if e.step >= outlierDetectionReadmissionSteps {
+		nl.Infof(d.logger, "Re-admitted server %v of upstream %v", server, upstream)
+		delete(u.ejected, server)
+	} else {
+		if e.step == 1 {
+			nl.Infof(d.logger, "Re-admitting server %v of upstream %v gradually", server, upstream)
+		}
+		e.timer = time.AfterFunc(u.config.EjectionTime/outlierDetectionReadmissionSteps, func() {
+			d.readmit(upstream, server, e)
+		})
+	}
+	d.scheduleUpdate(upstream)
+}
+
+// scheduleUpdate adds the upstream to the pending updates and schedules the update, unless it is already scheduled.
+// The update is delayed until the minimum update interval since the last update passes. The caller must hold the lock.
+func (d *OutlierDetector) scheduleUpdate(upstream string) {
+	d.pendingUpdates[upstream] = true
+	if d.updateTimer != nil {
+		return
+	}
+
+	delay := max(d.updateDelay, time.Until(d.lastUpdate.Add(d.minUpdateInterval)))
+	d.updateTimer = time.AfterFunc(delay, d.update)
+}
+
+// update passes the upstreams of the pending updates to the update handler.
+func (d *OutlierDetector) update() {
+	d.mu.Lock()
+	upstreams := slices.Sorted(maps.Keys(d.pendingUpdates))
+	clear(d.pendingUpdates)
+	d.updateTimer = nil
+	d.lastUpdate = time.Now()
+	handler := d.updateHandler
+	d.mu.Unlock()
+
+	if len(upstreams) > 0 && handler != nil {
+		handler(upstreams)
+	}
+}
+
+type upstreamResult struct {
+	server string
+	failed bool
+}
+
+// parseUpstreamStatusMessage returns the upstream and the result of every upstream server
+// the request was passed to. A 5xx status, including 504 for timeouts, is considered as failed.
+func parseUpstreamStatusMessage(msg string) (string, []upstreamResult, error) {
+	msgParts := strings.Split(msg, nginxSyslogSeparator)
+	if len(msgParts) != 2 {
+		return "", nil, fmt.Errorf("wrong message format: %s, expected message to start with \"%s\"", msg, nginxSyslogSeparator)
+	}
+	var sm upstreamStatusMsg
+	if err := json.Unmarshal([]byte(msgParts[1]), &sm); err != nil {
+		return "", nil, fmt.Errorf("could not unmarshal %s: %w", msg, err)
+	}
+
+	servers := splitUpstreamVariable(sm.UpstreamAddr)
+	statuses := splitUpstreamVariable(sm.UpstreamStatus)
+	if len(servers) != len(statuses) {
+		return "", nil, errors.New("the number of upstream addresses doesn't match the number of upstream statuses")
+	}
+
+	var results []upstreamResult
+	for i, server := range servers {
+		code, err := strconv.Atoi(statuses[i])
+		if err != nil {
+			// the status is "-" when the response was not received, for example, when the client closed the connection
+			continue
+		}
+		results = append(results, upstreamResult{server: server, failed: code >= 500})
+	}
+
+	return sm.ProxyHost, results, nil
+}
+
+// splitUpstreamVariable splits the values of an $upstream_* variable. The values for different servers are separated
+// by commas, and the values for the servers of different internal redirects are separated by colons.
+func splitUpstreamVariable(value string) []string {
+	var values []string
+	for _, group := range strings.Split(value, " : ") {
+		for _, v := range strings.Split(group, ",") {
+			values = append(values, strings.TrimSpace(v))
+		}
+	}
+	return values
+}
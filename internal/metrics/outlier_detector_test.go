@@ -0,0 +1,218 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func newTestOutlierDetectorMessage(server string, status string) string {
+	return fmt.Sprintf(`nginx: {"upstreamAddress":"%s", "upstreamResponseTime":"0.003", "proxyHost":"vs_default_cafe_tea", "upstreamStatus": "%s"}`, server, status)
+}
+
+func TestParseUpstreamStatusMessage(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		msg              string
+		expectedUpstream string
+		expectedResults  []upstreamResult
+	}{
+		{
+			msg:              `nginx: {"upstreamAddress":"10.0.0.1:80", "upstreamResponseTime":"0.003", "proxyHost":"vs_default_cafe_tea", "upstreamStatus": "200"}`,
+			expectedUpstream: "vs_default_cafe_tea",
+			expectedResults:  []upstreamResult{{server: "10.0.0.1:80"}},
+		},
+		{
+			msg:              `nginx: {"upstreamAddress":"10.0.0.1:80, 10.0.0.2:80 : 10.0.0.3:80", "upstreamResponseTime":"1.0, 0.1 : 0.1", "proxyHost":"vs_default_cafe_tea", "upstreamStatus": "504, 502 : 200"}`,
+			expectedUpstream: "vs_default_cafe_tea",
+			expectedResults: []upstreamResult{
+				{server: "10.0.0.1:80", failed: true},
+				{server: "10.0.0.2:80", failed: true},
+				{server: "10.0.0.3:80"},
+			},
+		},
+		{
+			msg:              `nginx: {"upstreamAddress":"10.0.0.1:80", "upstreamResponseTime":"-", "proxyHost":"vs_default_cafe_tea", "upstreamStatus": "-"}`,
+			expectedUpstream: "vs_default_cafe_tea",
+		},
+	}
+
+	for _, test := range tests {
+		upstream, results, err := parseUpstreamStatusMessage(test.msg)
+		if err != nil {
+			t.Errorf("parseUpstreamStatusMessage(%q) returned unexpected error: %v", test.msg, err)
+		}
+		if upstream != test.expectedUpstream {
+			t.Errorf("parseUpstreamStatusMessage(%q) returned upstream %q, expected %q", test.msg, upstream, test.expectedUpstream)
+		}
+		if diff := cmp.Diff(test.expectedResults, results, cmp.AllowUnexported(upstreamResult{})); diff != "" {
+			t.Errorf("parseUpstreamStatusMessage(%q) mismatch (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestParseUpstreamStatusMessageFails(t *testing.T) {
+	t.Parallel()
+	tests := []string{
+		`wrong format`,
+		`nginx: {"upstreamAddress":`,
+		`nginx: {"upstreamAddress":"10.0.0.1:80, 10.0.0.2:80", "upstreamResponseTime":"0.1", "proxyHost":"vs_default_cafe_tea", "upstreamStatus": "502"}`,
+	}
+
+	for _, test := range tests {
+		_, _, err := parseUpstreamStatusMessage(test)
+		if err == nil {
+			t.Errorf("parseUpstreamStatusMessage(%q) returned no error", test)
+		}
+	}
+}
+
+func TestOutlierDetectorEjectsServers(t *testing.T) {
+	t.Parallel()
+
+	d := NewOutlierDetector(context.Background())
+	// the update is passed to the handler by the test
+	d.updateDelay = time.Hour
+	updates := make(chan []string, 10)
+	d.SetUpdateHandler(func(upstreams []string) {
+		updates <- upstreams
+	})
+	d.UpdateUpstream("vs_default_cafe_tea", OutlierDetectionConfig{
+		ConsecutiveErrors:  2,
+		EjectionTime:       time.Hour,
+		MaxEjectionPercent: 50,
+		Servers:            []string{"10.0.0.1:80", "10.0.0.2:80", "10.0.0.3:80", "10.0.0.4:80"},
+	})
+
+	d.HandleMessage(newTestOutlierDetectorMessage("10.0.0.1:80", "502"))
+	d.HandleMessage(newTestOutlierDetectorMessage("10.0.0.1:80", "200"))
+	d.HandleMessage(newTestOutlierDetectorMessage("10.0.0.1:80", "502"))
+	if ejected := d.EjectedServers("vs_default_cafe_tea"); len(ejected) != 0 {
+		t.Fatalf("EjectedServers() returned %v after non-consecutive errors, expected no servers", ejected)
+	}
+
+	for _, server := range []string{"10.0.0.1:80", "10.0.0.2:80", "10.0.0.3:80"} {
+		d.HandleMessage(newTestOutlierDetectorMessage(server, "504"))
+		d.HandleMessage(newTestOutlierDetectorMessage(server, "500"))
+	}
+
+	expected := []string{"10.0.0.1:80", "10.0.0.2:80"}
+	if diff := cmp.Diff(expected, d.EjectedServers("vs_default_cafe_tea")); diff != "" {
+		t.Errorf("EjectedServers() mismatch (-want +got):\n%s", diff)
+	}
+
+	d.update()
+	if len(updates) != 1 {
+		t.Fatalf("OutlierDetector called the update handler %d times, expected 1 batched update", len(updates))
+	}
+	if diff := cmp.Diff([]string{"vs_default_cafe_tea"}, <-updates); diff != "" {
+		t.Errorf("OutlierDetector update mismatch (-want +got):\n%s", diff)
+	}
+
+	d.UpdateUpstream("vs_default_cafe_tea", OutlierDetectionConfig{
+		ConsecutiveErrors:  2,
+		EjectionTime:       time.Hour,
+		MaxEjectionPercent: 50,
+		Servers:            []string{"10.0.0.2:80", "10.0.0.3:80", "10.0.0.4:80"},
+	})
+	if diff := cmp.Diff([]string{"10.0.0.2:80"}, d.EjectedServers("vs_default_cafe_tea")); diff != "" {
+		t.Errorf("EjectedServers() after removing a server mismatch (-want +got):\n%s", diff)
+	}
+
+	d.DeleteUpstream("vs_default_cafe_tea")
+	if ejected := d.EjectedServers("vs_default_cafe_tea"); len(ejected) != 0 {
+		t.Errorf("EjectedServers() returned %v for a deleted upstream, expected no servers", ejected)
+	}
+}
+
+func TestOutlierDetectorLimitsUpdates(t *testing.T) {
+	t.Parallel()
+
+	d := NewOutlierDetector(context.Background())
+	d.updateDelay = time.Millisecond
+	d.minUpdateInterval = time.Hour
+	updates := make(chan []string, 10)
+	d.SetUpdateHandler(func(upstreams []string) {
+		updates <- upstreams
+	})
+	for _, upstream := range []string{"vs_default_cafe_tea", "vs_default_cafe_coffee"} {
+		d.UpdateUpstream(upstream, OutlierDetectionConfig{
+			ConsecutiveErrors: 1,
+			EjectionTime:      time.Hour,
+			Servers:           []string{"10.0.0.1:80", "10.0.0.2:80"},
+		})
+	}
+
+	d.HandleMessage(newTestOutlierDetectorMessage("10.0.0.1:80", "503"))
+	select {
+	case got := <-updates:
+		if diff := cmp.Diff([]string{"vs_default_cafe_tea"}, got); diff != "" {
+			t.Errorf("OutlierDetector update mismatch (-want +got):\n%s", diff)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("OutlierDetector didn't update the upstream after the ejection")
+	}
+
+	d.HandleMessage(`nginx: {"upstreamAddress":"10.0.0.1:80", "upstreamResponseTime":"0.003", "proxyHost":"vs_default_cafe_coffee", "upstreamStatus": "503"}`)
+	select {
+	case got := <-updates:
+		t.Fatalf("OutlierDetector updated the upstreams %v before the minimum update interval", got)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	d.update()
+	if diff := cmp.Diff([]string{"vs_default_cafe_coffee"}, <-updates); diff != "" {
+		t.Errorf("OutlierDetector update mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestOutlierDetectorReadmitsServersGradually(t *testing.T) {
+	t.Parallel()
+
+	d := NewOutlierDetector(context.Background())
+	d.updateDelay = 0
+	d.minUpdateInterval = 0
+	d.UpdateUpstream("vs_default_cafe_tea", OutlierDetectionConfig{
+		ConsecutiveErrors: 1,
+		EjectionTime:      40 * time.Millisecond,
+		Servers:           []string{"10.0.0.1:80", "10.0.0.2:80"},
+	})
+
+	d.HandleMessage(newTestOutlierDetectorMessage("10.0.0.1:80", "503"))
+	if diff := cmp.Diff([]string{"10.0.0.1:80"}, d.EjectedServers("vs_default_cafe_tea")); diff != "" {
+		t.Errorf("EjectedServers() mismatch (-want +got):\n%s", diff)
+	}
+	if weights := d.ServerWeights("vs_default_cafe_tea"); weights != nil {
+		t.Errorf("ServerWeights() returned %v for an ejected server, expected nil", weights)
+	}
+
+	seenWeights := make(map[int]bool)
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		weights := d.ServerWeights("vs_default_cafe_tea")
+		if weights != nil {
+			if weights["10.0.0.2:80"] != outlierDetectionReadmissionSteps {
+				t.Fatalf("ServerWeights() returned %v, expected the weight %d for the other server", weights, outlierDetectionReadmissionSteps)
+			}
+			seenWeights[weights["10.0.0.1:80"]] = true
+		} else if len(seenWeights) > 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("OutlierDetector didn't re-admit the server after the ejection time")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	for w := range seenWeights {
+		if w < 1 || w >= outlierDetectionReadmissionSteps {
+			t.Errorf("ServerWeights() returned the weight %d for the re-admitted server, expected a weight from 1 to %d", w, outlierDetectionReadmissionSteps-1)
+		}
+	}
+	if ejected := d.EjectedServers("vs_default_cafe_tea"); len(ejected) != 0 {
+		t.Errorf("EjectedServers() returned %v after the re-admission, expected no servers", ejected)
+	}
+}
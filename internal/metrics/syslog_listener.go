@@ -7,7 +7,6 @@ import (
 	"net"
 
 	nl "github.com/nginx/kubernetes-ingress/internal/logger"
-	"github.com/nginx/kubernetes-ingress/internal/metrics/collectors"
 )
 
 // SyslogListener is an interface for syslog metrics listener
@@ -17,50 +16,56 @@ type SyslogListener interface {
 	Stop()
 }
 
-// LatencyMetricsListener implements the SyslogListener interface
-type LatencyMetricsListener struct {
-	conn      *net.UnixConn
-	addr      string
-	collector collectors.LatencyCollector
-	logger    *slog.Logger
+// SyslogMessageHandler handles a syslog message logged by nginx
+type SyslogMessageHandler func(msg string)
+
+// NginxSyslogListener implements the SyslogListener interface
+type NginxSyslogListener struct {
+	conn     *net.UnixConn
+	addr     string
+	handlers []SyslogMessageHandler
+	logger   *slog.Logger
 }
 
-// NewLatencyMetricsListener returns a LatencyMetricsListener that listens over a unix socket
-// for syslog messages from nginx.
-func NewLatencyMetricsListener(ctx context.Context, sockPath string, c collectors.LatencyCollector) SyslogListener {
+// NewNginxSyslogListener returns a NginxSyslogListener that listens over a unix socket
+// for syslog messages from nginx and passes every message to the handlers.
+func NewNginxSyslogListener(ctx context.Context, sockPath string, handlers ...SyslogMessageHandler) SyslogListener {
 	l := nl.LoggerFromContext(ctx)
-	nl.Infof(l, "Starting latency metrics server listening on: %s", sockPath)
+	nl.Infof(l, "Starting syslog metrics server listening on: %s", sockPath)
 	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{
 		Name: sockPath,
 		Net:  "unixgram",
 	})
 	if err != nil {
-		nl.Errorf(l, "Failed to create latency metrics listener: %v. Latency metrics will not be collected.", err)
+		nl.Errorf(l, "Failed to create syslog metrics listener: %v. Latency metrics will not be collected and outlier detection will not eject servers.", err)
 		return NewSyslogFakeServer()
 	}
-	return &LatencyMetricsListener{conn: conn, addr: sockPath, collector: c, logger: l}
+	return &NginxSyslogListener{conn: conn, addr: sockPath, handlers: handlers, logger: l}
 }
 
 // Run reads from the unix connection until an unrecoverable error occurs or the connection is closed.
-func (l LatencyMetricsListener) Run() {
+func (l NginxSyslogListener) Run() {
 	buffer := make([]byte, 1024)
 	for {
 		n, err := l.conn.Read(buffer)
 		if err != nil {
 			if !isErrorRecoverable(err) {
-				nl.Info(l.logger, "Stopping latency metrics listener")
+				nl.Info(l.logger, "Stopping syslog metrics listener")
 				return
 			}
 		}
-		go l.collector.RecordLatency(string(buffer[:n]))
+		msg := string(buffer[:n])
+		for _, handler := range l.handlers {
+			go handler(msg)
+		}
 	}
 }
 
 // Stop closes the unix connection of the listener.
-func (l LatencyMetricsListener) Stop() {
+func (l NginxSyslogListener) Stop() {
 	err := l.conn.Close()
 	if err != nil {
-		nl.Errorf(l.logger, "error closing latency metrics unix connection: %v", err)
+		nl.Errorf(l.logger, "error closing syslog metrics unix connection: %v", err)
 	}
 }
 
@@ -0,0 +1,111 @@
+package nginx
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+
+	nl "github.com/nginx/kubernetes-ingress/internal/logger"
+)
+
+// RenderManager is a Manager that writes the NGINX configuration files to an output directory
+// instead of /etc/nginx. It never starts or reloads NGINX and doesn't write the contents of secrets,
+// so that the rendered configuration can be safely stored and compared. The paths referenced in the generated
+// configuration are the paths used by the Ingress Controller at runtime.
+type RenderManager struct {
+	*FakeManager
+	outputPath string
+	logger     *slog.Logger
+
+	mu   sync.Mutex
+	errs []error
+}
+
+// NewRenderManager creates a RenderManager that writes the configuration files to outputPath.
+func NewRenderManager(outputPath string, logger *slog.Logger) *RenderManager {
+	fm := NewFakeManager("/etc/nginx")
+	fm.logger = logger
+
+	return &RenderManager{
+		FakeManager: fm,
+		outputPath:  outputPath,
+		logger:      logger,
+	}
+}
+
+// Err returns the errors that occurred while writing the configuration files.
+func (rm *RenderManager) Err() error {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	return errors.Join(rm.errs...)
+}
+
+func (rm *RenderManager) writeFile(filename string, content []byte) error {
+	nl.Debugf(rm.logger, "Writing config to %v", filename)
+
+	err := os.MkdirAll(filepath.Dir(filename), 0o755)
+	if err == nil {
+		err = os.WriteFile(filename, content, 0o644) //nolint:gosec // the rendered configuration doesn't include secrets
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to write config to %v: %w", filename, err)
+		rm.mu.Lock()
+		rm.errs = append(rm.errs, err)
+		rm.mu.Unlock()
+	}
+
+	return err
+}
+
+func (rm *RenderManager) deleteFile(filename string) {
+	nl.Debugf(rm.logger, "Deleting config from %v", filename)
+
+	if err := os.Remove(filename); err != nil && !os.IsNotExist(err) {
+		nl.Warnf(rm.logger, "Failed to delete config from %v: %v", filename, err)
+	}
+}
+
+// CreateMainConfig writes the main NGINX configuration file to the output directory.
+func (rm *RenderManager) CreateMainConfig(content []byte) (bool, error) {
+	return true, rm.writeFile(path.Join(rm.outputPath, "nginx.conf"), content)
+}
+
+// CreateConfig writes a configuration file to the conf.d folder of the output directory.
+func (rm *RenderManager) CreateConfig(name string, content []byte) (bool, error) {
+	return true, rm.writeFile(path.Join(rm.outputPath, "conf.d", name+".conf"), content)
+}
+
+// DeleteConfig deletes a configuration file from the conf.d folder of the output directory.
+func (rm *RenderManager) DeleteConfig(name string) {
+	rm.deleteFile(path.Join(rm.outputPath, "conf.d", name+".conf"))
+}
+
+// CreateStreamConfig writes a configuration file to the stream-conf.d folder of the output directory.
+func (rm *RenderManager) CreateStreamConfig(name string, content []byte) (bool, error) {
+	return true, rm.writeFile(path.Join(rm.outputPath, "stream-conf.d", name+".conf"), content)
+}
+
+// DeleteStreamConfig deletes a configuration file from the stream-conf.d folder of the output directory.
+func (rm *RenderManager) DeleteStreamConfig(name string) {
+	rm.deleteFile(path.Join(rm.outputPath, "stream-conf.d", name+".conf"))
+}
+
+// CreateOIDCConfig writes a configuration file to the oidc-conf.d folder of the output directory.
+func (rm *RenderManager) CreateOIDCConfig(name string, content []byte) bool {
+	return rm.writeFile(path.Join(rm.outputPath, "oidc-conf.d", name+".conf"), content) == nil
+}
+
+// DeleteOIDCConfig deletes a configuration file from the oidc-conf.d folder of the output directory.
+func (rm *RenderManager) DeleteOIDCConfig(name string) {
+	rm.deleteFile(path.Join(rm.outputPath, "oidc-conf.d", name+".conf"))
+}
+
+// CreateTLSPassthroughHostsConfig writes the TLS Passthrough hosts configuration file to the output directory.
+func (rm *RenderManager) CreateTLSPassthroughHostsConfig(content []byte) bool {
+	return rm.writeFile(path.Join(rm.outputPath, "tls-passthrough-hosts.conf"), content) == nil
+}
@@ -48,8 +48,10 @@ var configMapFilteredKeys = []string{
 	"access-log-off",
 	"log-format",
 	"log-format-escaping",
+	"log-format-preset",
 	"stream-log-format",
 	"stream-log-format-escaping",
+	"stream-log-format-preset",
 	"default-server-access-log-off",
 	"default-server-return",
 	"proxy-buffering",
@@ -0,0 +1,145 @@
+// Package webhook provides the validating admission webhook for the resources of the Ingress Controller.
+package webhook
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	nl "github.com/nginx/kubernetes-ingress/internal/logger"
+	admission_v1 "k8s.io/api/admission/v1"
+	v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ValidatePath is the path of the validating admission webhook endpoint.
+const ValidatePath = "/validate"
+
+// maxRequestSize limits the size of admission reviews. The API server limits the size of the objects to 3MiB.
+const maxRequestSize = 4 << 20
+
+// Validator validates the object of an admission request.
+type Validator interface {
+	Validate(req *admission_v1.AdmissionRequest) error
+}
+
+// RunServer starts the validating admission webhook server.
+func RunServer(port int, validator Validator, secret *v1.Secret, logger *slog.Logger) {
+	addr := fmt.Sprintf(":%s", strconv.Itoa(port))
+	ws, err := NewServer(addr, validator, secret, logger)
+	if err != nil {
+		nl.Fatal(logger, err)
+	}
+	nl.Infof(logger, "Starting admission webhook listener on: %v%v", addr, ValidatePath)
+	nl.Fatal(logger, ws.ListenAndServe())
+}
+
+// Server holds data required for running the validating admission webhook server.
+type Server struct {
+	Server    *http.Server
+	Validator Validator
+	Logger    *slog.Logger
+}
+
+// NewServer creates a Server. The API server calls admission webhooks only over HTTPS,
+// so the server is always configured with the TLS certificate and key of the secret.
+func NewServer(addr string, validator Validator, secret *v1.Secret, logger *slog.Logger) (*Server, error) {
+	if secret == nil {
+		return nil, errors.New("admission webhook requires a TLS secret")
+	}
+	tlsCert, err := makeCert(secret)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create TLS cert: %w", err)
+	}
+
+	return &Server{
+		Server: &http.Server{
+			Addr:         addr,
+			ReadTimeout:  10 * time.Second,
+			WriteTimeout: 10 * time.Second,
+			TLSConfig: &tls.Config{
+				Certificates: []tls.Certificate{tlsCert},
+				MinVersion:   tls.VersionTLS12,
+			},
+		},
+		Validator: validator,
+		Logger:    logger,
+	}, nil
+}
+
+// ListenAndServe starts the webhook server.
+func (ws *Server) ListenAndServe() error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST "+ValidatePath, ws.Review)
+	ws.Server.Handler = mux
+	return ws.Server.ListenAndServeTLS("", "")
+}
+
+// Review handles an AdmissionReview request and responds whether the object of the request is allowed.
+func (ws *Server) Review(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestSize))
+	if err != nil {
+		nl.Errorf(ws.Logger, "error reading admission review: %v", err)
+		http.Error(w, "error reading admission review", http.StatusBadRequest)
+		return
+	}
+
+	var review admission_v1.AdmissionReview
+	if err := json.Unmarshal(body, &review); err != nil || review.Request == nil {
+		nl.Errorf(ws.Logger, "error decoding admission review: %v", err)
+		http.Error(w, "error decoding admission review", http.StatusBadRequest)
+		return
+	}
+
+	review.Response = ws.admit(review.Request)
+	review.Request = nil
+
+	data, err := json.Marshal(review)
+	if err != nil {
+		nl.Errorf(ws.Logger, "error marshaling admission review: %v", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if _, err = w.Write(data); err != nil {
+		nl.Errorf(ws.Logger, "error writing admission review: %v", err)
+	}
+}
+
+func (ws *Server) admit(req *admission_v1.AdmissionRequest) *admission_v1.AdmissionResponse {
+	resp := &admission_v1.AdmissionResponse{
+		UID:     req.UID,
+		Allowed: true,
+	}
+
+	if err := ws.Validator.Validate(req); err != nil {
+		nl.Debugf(ws.Logger, "Rejected %s %s/%s: %v", req.Kind.Kind, req.Namespace, req.Name, err)
+		resp.Allowed = false
+		resp.Result = &meta_v1.Status{
+			Status:  meta_v1.StatusFailure,
+			Code:    http.StatusUnprocessableEntity,
+			Reason:  meta_v1.StatusReasonInvalid,
+			Message: fmt.Sprintf("%s %s/%s is invalid: %v", req.Kind.Kind, req.Namespace, req.Name, err),
+		}
+	}
+
+	return resp
+}
+
+func makeCert(s *v1.Secret) (tls.Certificate, error) {
+	cert, ok := s.Data[v1.TLSCertKey]
+	if !ok {
+		return tls.Certificate{}, errors.New("missing tls cert")
+	}
+	key, ok := s.Data[v1.TLSPrivateKeyKey]
+	if !ok {
+		return tls.Certificate{}, errors.New("missing tls key")
+	}
+	return tls.X509KeyPair(cert, key)
+}
@@ -0,0 +1,121 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	nl "github.com/nginx/kubernetes-ingress/internal/logger"
+	admission_v1 "k8s.io/api/admission/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type fakeValidator struct {
+	err error
+}
+
+func (fv fakeValidator) Validate(_ *admission_v1.AdmissionRequest) error {
+	return fv.err
+}
+
+func newTestServer(validator Validator) *Server {
+	return &Server{
+		Validator: validator,
+		Logger:    nl.LoggerFromContext(context.Background()),
+	}
+}
+
+func newTestReview(t *testing.T) []byte {
+	t.Helper()
+
+	review := admission_v1.AdmissionReview{
+		TypeMeta: meta_v1.TypeMeta{APIVersion: "admission.k8s.io/v1", Kind: "AdmissionReview"},
+		Request: &admission_v1.AdmissionRequest{
+			UID:       "705ab4f5-6393-11e8-b7cc-42010a800002",
+			Kind:      meta_v1.GroupVersionKind{Group: "k8s.nginx.org", Version: "v1", Kind: "VirtualServer"},
+			Namespace: "default",
+			Name:      "cafe",
+			Operation: admission_v1.Create,
+		},
+	}
+	data, err := json.Marshal(review)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestReview(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		validator     Validator
+		expectAllowed bool
+	}{
+		{
+			name:          "valid object",
+			validator:     fakeValidator{},
+			expectAllowed: true,
+		},
+		{
+			name:          "invalid object",
+			validator:     fakeValidator{err: errors.New("spec.host: Required value")},
+			expectAllowed: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ws := newTestServer(test.validator)
+			req := httptest.NewRequest(http.MethodPost, ValidatePath, bytes.NewReader(newTestReview(t)))
+			rec := httptest.NewRecorder()
+
+			ws.Review(rec, req)
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("Review() returned status %d, expected %d", rec.Code, http.StatusOK)
+			}
+
+			var review admission_v1.AdmissionReview
+			if err := json.Unmarshal(rec.Body.Bytes(), &review); err != nil {
+				t.Fatalf("failed to decode the response: %v", err)
+			}
+			if review.Response == nil {
+				t.Fatal("Review() returned no response")
+			}
+			if review.Response.UID != "705ab4f5-6393-11e8-b7cc-42010a800002" {
+				t.Errorf("Review() returned response with UID %q, expected the UID of the request", review.Response.UID)
+			}
+			if review.Response.Allowed != test.expectAllowed {
+				t.Errorf("Review() returned Allowed=%v, expected %v", review.Response.Allowed, test.expectAllowed)
+			}
+			if !test.expectAllowed && (review.Response.Result == nil || review.Response.Result.Message == "") {
+				t.Error("Review() returned no message for a rejected object")
+			}
+			if review.Request != nil {
+				t.Error("Review() returned the request in the response")
+			}
+		})
+	}
+}
+
+func TestReviewFailsForInvalidRequest(t *testing.T) {
+	t.Parallel()
+
+	ws := newTestServer(fakeValidator{})
+	req := httptest.NewRequest(http.MethodPost, ValidatePath, bytes.NewReader([]byte(`{"kind": "AdmissionReview"}`)))
+	rec := httptest.NewRecorder()
+
+	ws.Review(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Review() returned status %d, expected %d", rec.Code, http.StatusBadRequest)
+	}
+}
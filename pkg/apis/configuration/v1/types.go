@@ -20,6 +20,41 @@ const (
 	TLSPassthroughListenerProtocol = "TLS_PASSTHROUGH"
 )
 
+const (
+	// ConditionTypeAccepted indicates whether the resource has been validated and accepted by the Ingress Controller.
+	ConditionTypeAccepted = "Accepted"
+	// ConditionTypeResolvedRefs indicates whether all references of the resource have been resolved without warnings.
+	ConditionTypeResolvedRefs = "ResolvedRefs"
+	// ConditionTypeProgrammed indicates whether the configuration of the resource has been applied to NGINX.
+	ConditionTypeProgrammed = "Programmed"
+	// ConditionTypeReady indicates whether the resource is accepted, programmed and has all references resolved.
+	ConditionTypeReady = "Ready"
+
+	// ConditionReasonAccepted is used with the Accepted condition when the resource has been accepted.
+	ConditionReasonAccepted = "Accepted"
+	// ConditionReasonRejected is used when the resource has been rejected or ignored by the Ingress Controller.
+	ConditionReasonRejected = "Rejected"
+	// ConditionReasonResolvedRefs is used with the ResolvedRefs condition when the references have been resolved.
+	ConditionReasonResolvedRefs = "ResolvedRefs"
+	// ConditionReasonUnresolvedRefs is used when the configuration of the resource has been generated with warnings.
+	ConditionReasonUnresolvedRefs = "UnresolvedRefs"
+	// ConditionReasonProgrammed is used with the Programmed condition when the configuration has been applied.
+	ConditionReasonProgrammed = "Programmed"
+	// ConditionReasonInvalid is used when NGINX failed to apply the configuration of the resource.
+	ConditionReasonInvalid = "Invalid"
+	// ConditionReasonReady is used with the Ready condition when the resource is ready.
+	ConditionReasonReady = "Ready"
+)
+
+const (
+	// RolloutPhaseProgressing is used when the rollout moves through its steps.
+	RolloutPhaseProgressing = "Progressing"
+	// RolloutPhaseCompleted is used when the rollout has completed its last step.
+	RolloutPhaseCompleted = "Completed"
+	// RolloutPhaseRolledBack is used when the rollout has been rolled back after a breach of its thresholds.
+	RolloutPhaseRolledBack = "RolledBack"
+)
+
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // +kubebuilder:validation:Optional
@@ -72,6 +107,8 @@ type VirtualServerSpec struct {
 	ExternalDNS ExternalDNS `json:"externalDNS"`
 	// InternalRoute allows for the configuration of internal routing.
 	InternalRoute bool `json:"internalRoute"`
+	// Disables the default policies of the GlobalConfiguration for the VirtualServer. Only the default policies that allow opting out are disabled.
+	DisableDefaultPolicies bool `json:"disableDefaultPolicies"`
 }
 
 // VirtualServerListener references a custom http and/or https listener defined in GlobalConfiguration.
@@ -186,6 +223,21 @@ type Upstream struct {
 	Backup string `json:"backup"`
 	// The port of the backup service. The backup port is required if the backup service name is provided. The port must fall into the range 1..65535.
 	BackupPort *uint16 `json:"backupPort"`
+	// The outlier detection configuration for the Upstream. Upstream servers that return a run of 5xx responses or time out are ejected from the upstream for a period of time. Ejected servers are re-admitted gradually: with NGINX Plus during the slow-start of the upstream, which defaults to 30s; with NGINX by increasing their weight in steps, with at most one reload every 10 seconds.
+	OutlierDetection *OutlierDetection `json:"outlierDetection"`
+}
+
+// OutlierDetection defines the ejection of upstream servers that return consecutive errors.
+// With NGINX Plus, the outlier detection is implemented with an active health check of the upstream, and ejected servers are re-admitted gradually during the slow-start of the upstream, which defaults to 30s. With NGINX, the Ingress Controller must be started with the -enable-outlier-detection command-line argument. It re-admits ejected servers gradually by increasing their weight in steps for the ejection time, and applies the ejections and re-admissions of the servers of all upstreams with at most one reload every 10 seconds.
+type OutlierDetection struct {
+	// The number of consecutive 5xx responses or timeouts after which an upstream server is ejected. The default is 5.
+	ConsecutiveErrors int `json:"consecutiveErrors"`
+	// The time during which an ejected upstream server doesn't receive requests. The default is 30s.
+	EjectionTime string `json:"ejectionTime"`
+	// The maximum percentage of the upstream servers that can be ejected at the same time. At least one server can always be ejected. Must fall into the range 0..100. The default is 10. Note: this field is not supported with NGINX Plus, because the servers are ejected by the active health checks of NGINX Plus.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	MaxEjectionPercent *int `json:"maxEjectionPercent"`
 }
 
 // UpstreamBuffers defines Buffer Configuration for an Upstream.
@@ -295,6 +347,28 @@ type Route struct {
 	AddHeaderInherit string `json:"add-header-inherit"`
 	// A reference to a DosProtectedResource, setting this enables DOS protection of the VirtualServer route.
 	Dos string `json:"dos"`
+	// The progressive rollout of the traffic from the first to the second split of the route. Requires exactly 2 splits. Supported only in the routes of VirtualServer and only in NGINX Plus. The weight of the current step, reported in the status of the VirtualServer, overrides the weights of the splits.
+	Rollout *Rollout `json:"rollout"`
+}
+
+// Rollout defines a progressive rollout that moves the weights of the splits of a route through steps.
+// The Ingress Controller applies the weight of the current step, reported in the status of the VirtualServer, instead of the weights of the splits, and doesn't change the spec of the VirtualServer. The splits of the route always change their weights without reloading NGINX, as with the -weight-changes-dynamic-reload command-line argument.
+// A new rollout starts when the rollout or the actions of the splits change.
+type Rollout struct {
+	// The steps of the rollout. Must include at least one step.
+	Steps []RolloutStep `json:"steps"`
+	// The maximum percentage of 5xx responses of the upstream of the second split. If the percentage is exceeded, the rollout is rolled back and all traffic is sent to the first split. Must fall into the range 0..100. By default, the error rate is not checked.
+	MaxErrorRate *int `json:"maxErrorRate"`
+	// The maximum average response time of the upstream of the second split. If the response time is exceeded, the rollout is rolled back and all traffic is sent to the first split. By default, the response time is not checked.
+	MaxResponseTime string `json:"maxResponseTime"`
+}
+
+// RolloutStep defines a step of a Rollout.
+type RolloutStep struct {
+	// The weight of the second split during the step. The first split receives the rest of the traffic. Must fall into the range 0..100.
+	Weight int `json:"weight"`
+	// The duration of the step. The rollout moves to the next step after the duration if the thresholds are not exceeded. Required for all steps except the last one.
+	Duration string `json:"duration"`
 }
 
 // Action defines an action.
@@ -339,6 +413,48 @@ type ActionProxy struct {
 	RequestHeaders *ProxyRequestHeaders `json:"requestHeaders"`
 	// The response headers modifications.
 	ResponseHeaders *ProxyResponseHeaders `json:"responseHeaders"`
+	// Mirrors (shadows) the requests to another upstream. The responses of the mirror upstream are ignored.
+	Mirror *ActionMirror `json:"mirror"`
+	// The retry policy of the requests. Overrides the next-upstream, next-upstream-tries and next-upstream-timeout fields of the upstream.
+	Retry *RetryPolicy `json:"retry"`
+}
+
+// RetryPolicy defines the retries of the requests passed to an upstream.
+type RetryPolicy struct {
+	// The conditions in which a request is passed to the next upstream server. Possible values: error, timeout, 5xx, gateway-error and non-idempotent. Connection resets by the upstream server are handled by the error condition. The 5xx condition includes the 500, 502, 503 and 504 responses, and the gateway-error condition includes the 502, 503 and 504 responses. The non-idempotent condition enables retries of requests with a non-idempotent method (POST, LOCK, PATCH) and must be combined with other conditions. The default is error and timeout.
+	Conditions []string `json:"conditions"`
+	// The number of tries for passing a request to the next upstream server, including the first try. The default is 0 (not limited).
+	Tries int `json:"tries"`
+	// The time allowed to pass a request to the next upstream server, including all tries. The default is 0 (not limited).
+	Timeout string `json:"timeout"`
+	// The timeout of each try. Sets the connect, send and read timeouts of the proxied requests. By default, the timeouts of the upstream are used.
+	PerTryTimeout string `json:"perTryTimeout"`
+	// Limits the retries when many requests fail at the same time.
+	Budget *RetryBudget `json:"budget"`
+}
+
+// RetryBudget limits the number of retries relative to the number of recent requests.
+// The budget is not a percentage of the in-flight requests, because NGINX doesn't expose the retries in progress:
+// the retries and the requests to the route are counted over the last 10 seconds.
+type RetryBudget struct {
+	// The maximum number of retries as a percentage of the requests to the route over the last 10 seconds. The requests are counted when they are received, and the retries when the responses are sent. Requests over the budget are not retried. Must fall into the range 1..100.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	PercentOfRecentRequests int `json:"percentOfRecentRequests"`
+	// The number of retries over the last 10 seconds that are allowed regardless of the percentage. The default is 3.
+	MinRetries *int `json:"minRetries"`
+}
+
+// ActionMirror defines the mirroring of requests in an ActionProxy.
+type ActionMirror struct {
+	// The name of the upstream which the mirrored requests will be sent to. The upstream with that name must be defined in the resource.
+	Upstream string `json:"upstream"`
+	// The percentage of requests to mirror. Must fall into the range 0..100. The default is 100.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Percentage *int `json:"percentage"`
+	// Enables mirroring of the client request body. The default is true.
+	RequestBody *bool `json:"requestBody"`
 }
 
 // ProxyRequestHeaders defines the request headers manipulation in an ActionProxy.
@@ -468,6 +584,32 @@ type VirtualServerStatus struct {
 	Reason            string             `json:"reason"`
 	Message           string             `json:"message"`
 	ExternalEndpoints []ExternalEndpoint `json:"externalEndpoints,omitempty"`
+	// The generation of the resource that the status was computed for.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// The conditions of the resource: Accepted, ResolvedRefs, Programmed and Ready.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// The status of the rollouts of the routes.
+	Rollouts []RolloutStatus `json:"rollouts,omitempty"`
+}
+
+// RolloutStatus defines the status of the Rollout of a route.
+type RolloutStatus struct {
+	// The path of the route.
+	Path string `json:"path"`
+	// The revision of the rollout and the splits of the route that the status was computed for.
+	Revision string `json:"revision"`
+	// The phase of the rollout: Progressing, Completed or RolledBack.
+	Phase string `json:"phase"`
+	// The index of the current step.
+	Step int `json:"step"`
+	// The weight of the second split.
+	Weight int `json:"weight"`
+	// The time when the current step started.
+	StepStartTime metav1.Time `json:"stepStartTime"`
+	// The reason of the rollback.
+	Message string `json:"message,omitempty"`
 }
 
 // ExternalEndpoint defines the IP/ Hostname and ports used to connect to this resource.
@@ -518,6 +660,8 @@ type VirtualServerRouteSpec struct {
 	Upstreams []Upstream `json:"upstreams"`
 	// A list of subroutes.
 	Subroutes []Route `json:"subroutes"`
+	// Disables the default policies of the GlobalConfiguration for the VirtualServerRoute. Only the default policies that allow opting out are disabled.
+	DisableDefaultPolicies bool `json:"disableDefaultPolicies"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -550,6 +694,12 @@ type VirtualServerRouteStatus struct {
 	ReferencedBy string `json:"referencedBy"`
 	// Defines the IPs, hostnames and ports used to connect to this resource.
 	ExternalEndpoints []ExternalEndpoint `json:"externalEndpoints,omitempty"`
+	// The generation of the resource that the status was computed for.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// The conditions of the resource: Accepted, ResolvedRefs, Programmed and Ready.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +genclient
@@ -569,6 +719,21 @@ type GlobalConfiguration struct {
 type GlobalConfigurationSpec struct {
 	// Listeners field of the GlobalConfigurationSpec resource
 	Listeners []Listener `json:"listeners"`
+	// A list of policies applied by default to the VirtualServer, VirtualServerRoute and Ingress resources of the selected namespaces.
+	DefaultPolicies []DefaultPolicies `json:"defaultPolicies,omitempty"`
+}
+
+// DefaultPolicies defines policies applied by default to the resources of the selected namespaces.
+// If neither namespaces nor namespaceSelector are set, the policies are applied in all namespaces.
+type DefaultPolicies struct {
+	// A list of policies. A policy without a namespace is looked up in the namespace of the resource.
+	Policies []PolicyReference `json:"policies"`
+	// The namespaces of the resources the policies are applied to.
+	Namespaces []string `json:"namespaces"`
+	// Selects the namespaces of the resources the policies are applied to by their labels. Unless the namespaces are watched with the -watch-namespace-label command-line argument, the Ingress Controller requires the permission to list and watch all the namespaces of the cluster.
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector"`
+	// Allows the resources to opt out of the policies. VirtualServer and VirtualServerRoute resources opt out with the disableDefaultPolicies field, Ingress resources with the nginx.org/disable-default-policies annotation.
+	AllowOptOut bool `json:"allowOptOut"`
 }
 
 // Listener defines a listener.
@@ -639,11 +804,29 @@ type TransportServerSpec struct {
 	SessionParameters *SessionParameters `json:"sessionParameters"`
 	// The action to perform for a request.
 	Action *TransportServerAction `json:"action"`
+	// A list of policies. Supported policies are accessControl and connectionLimit.
+	Policies []PolicyReference `json:"policies"`
 }
 
 // TransportServerTLS defines TransportServerTLS configuration for a TransportServer.
 type TransportServerTLS struct {
 	Secret string `json:"secret"`
+	// The client certificate verification. Requires the secret.
+	MTLS *TransportServerMTLS `json:"mtls"`
+}
+
+// TransportServerMTLS defines the client certificate verification of a TransportServer.
+type TransportServerMTLS struct {
+	// The name of the Kubernetes secret that stores the CA certificate. It must be in the same namespace as the TransportServer resource. The secret must be of the type nginx.org/ca, and the certificate must be stored in the secret under the key ca.crt, otherwise the secret will be rejected as invalid.
+	ClientCertSecret string `json:"clientCertSecret"`
+	// The file name of the Certificate Revocation List. NGINX Ingress Controller will look for this file in /etc/nginx/secrets
+	CrlFileName string `json:"crlFileName"`
+	// Verification for the client. Possible values are "on", "optional" and "optional_no_ca". The default is "on".
+	VerifyClient string `json:"verifyClient"`
+	// Sets the verification depth in the client certificates chain. The default is 1.
+	VerifyDepth *int `json:"verifyDepth"`
+	// Sends the PROXY protocol v2 header with the TLS details of the client, such as the common name of the client certificate and the result of the verification, to the upstream servers. The header is sent before the first data of the client. The default is false.
+	ProxyProtocol bool `json:"proxyProtocol"`
 }
 
 // TransportServerListener defines a listener for a TransportServer.
@@ -742,6 +925,12 @@ type TransportServerStatus struct {
 	Reason string `json:"reason"`
 	// The message of the current state of the resource. It can contain more detailed information about the reason.
 	Message string `json:"message"`
+	// The generation of the resource that the status was computed for.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// The conditions of the resource: Accepted, ResolvedRefs, Programmed and Ready.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -779,6 +968,12 @@ type PolicyStatus struct {
 	Reason string `json:"reason"`
 	// The message of the current state of the resource. It can contain more detailed information about the reason.
 	Message string `json:"message"`
+	// The generation of the resource that the status was computed for.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// The conditions of the resource: Accepted, ResolvedRefs, Programmed and Ready.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // PolicySpec is the spec of the Policy resource.
@@ -806,12 +1001,24 @@ type PolicySpec struct {
 	WAF *WAF `json:"waf"`
 	// The API Key policy configures NGINX to authorize requests which provide a valid API Key in a specified header or query param.
 	APIKey *APIKey `json:"apiKey"`
+	// The HMAC policy verifies the HMAC-SHA256 signatures of the requests signed with shared keys.
+	HMAC *HMAC `json:"hmac"`
 	// The Cache Key defines a cache policy for proxy caching
 	Cache *Cache `json:"cache"`
 	// The CORS policy configures Cross-Origin Resource Sharing headers
 	CORS *CORS `json:"cors"`
+	// The headers policy modifies the request headers passed to the upstreams and the response headers sent to the clients.
+	Headers *Headers `json:"headers"`
 	// The ExternalAuth policy configures NGINX to authenticate client requests using an external authentication server, which can be used for example with the oauth2-proxy or any custom authentication server.
 	ExternalAuth *ExternalAuth `json:"externalAuth"`
+	// The introspection policy configures NGINX Plus to authenticate client requests with opaque access tokens using the OAuth 2.0 token introspection endpoint of an identity provider.
+	Introspection *Introspection `json:"introspection"`
+	// The connection limit policy limits the number of simultaneous connections per client IP address. Supported only in TransportServer.
+	ConnectionLimit *ConnectionLimit `json:"connectionLimit"`
+	// The geo policy allows or denies requests by the country, continent or autonomous system of the client IP address.
+	Geo *Geo `json:"geo"`
+	// The tracing policy enables the OpenTelemetry tracing of the requests. Requires the otel-exporter-endpoint ConfigMap key.
+	Tracing *Tracing `json:"tracing"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -829,6 +1036,68 @@ type PolicyList struct {
 type AccessControl struct {
 	Allow []string `json:"allow"`
 	Deny  []string `json:"deny"`
+	// Enables the dry run mode. In this mode, the requests that the policy would reject are not rejected, but reported in the $policy_dry_run variable and counted in the policy_dry_run_rejections_total metric. Not supported on TransportServer resources.
+	DryRun bool `json:"dryRun"`
+}
+
+// Geo defines a geo policy, which allows or denies requests by the country, continent or autonomous system of the client IP address.
+// The country and continent are looked up in the MaxMind database configured with the geoip2-country-db ConfigMap key,
+// and the autonomous system in the database configured with the geoip2-asn-db ConfigMap key.
+// The databases must be mounted into the Ingress Controller pod, for example, from a persistent volume, because they exceed the size limit of a ConfigMap.
+// The NGINX Plus images include the GeoIP2 module; with NGINX, an image with the ngx_http_geoip2_module dynamic module is required.
+type Geo struct {
+	// The countries, continents and autonomous systems of the allowed clients. The requests from other clients are rejected.
+	Allow *GeoMatch `json:"allow"`
+	// The countries, continents and autonomous systems of the denied clients.
+	Deny *GeoMatch `json:"deny"`
+	// The status code of the response to the rejected requests. The default is 403.
+	RejectCode *int `json:"rejectCode"`
+}
+
+// GeoMatch defines the countries, continents and autonomous systems matched by a geo policy.
+type GeoMatch struct {
+	// ISO 3166-1 alpha-2 country codes, for example, US.
+	Countries []string `json:"countries"`
+	// Two-letter continent codes, for example, EU.
+	Continents []string `json:"continents"`
+	// Autonomous system numbers, for example, 64496.
+	ASNs []int `json:"asns"`
+}
+
+// Tracing defines a tracing policy, which enables the OpenTelemetry tracing of the requests.
+// The spans are exported to the exporter configured with the otel-exporter-endpoint ConfigMap key.
+type Tracing struct {
+	// The percentage of the requests that are traced, for example, 10% or 0.5%. The default is 100%.
+	// +kubebuilder:validation:Pattern=`^(100|[0-9]{1,2}(\.[0-9]{1,2})?)%$`
+	SampleRatio string `json:"sampleRatio"`
+	// The name of the spans. Can contain NGINX variables. The default is the name of the location.
+	SpanName string `json:"spanName"`
+	// The custom attributes of the spans.
+	SpanAttributes []TracingSpanAttribute `json:"spanAttributes"`
+	// The propagation of the trace context. w3c extracts the trace context from the traceparent and tracestate headers of the requests and passes it to the upstream in the same headers.
+	// b3 passes the trace context to the upstream in the b3 header. extract only extracts the trace context from the traceparent and tracestate headers of the requests. The default is w3c.
+	// +kubebuilder:validation:Enum=w3c;b3;extract
+	Propagation string `json:"propagation"`
+}
+
+// TracingSpanAttribute defines a custom attribute of the spans.
+type TracingSpanAttribute struct {
+	// The name of the attribute, for example, app.tenant.
+	Name string `json:"name"`
+	// The value of the attribute. Can contain NGINX variables.
+	Value string `json:"value"`
+}
+
+// ConnectionLimit defines a connection limit policy.
+type ConnectionLimit struct {
+	// The maximum number of simultaneous connections from a client IP address.
+	Connections int `json:"connections"`
+	// Size of the shared memory zone. Only positive values are allowed. Allowed suffixes are k or m, if none are present k is assumed.
+	ZoneSize string `json:"zoneSize"`
+	// Enables the dry run mode. In this mode, the number of connections is not limited, but the number of excessive connections is accounted as usual in the shared memory zone.
+	DryRun *bool `json:"dryRun"`
+	// Sets the desired logging level for cases when the server limits the number of connections. Allowed values are info, notice, warn or error. Default is error.
+	LogLevel string `json:"logLevel"`
 }
 
 // RateLimit defines a rate limit policy.
@@ -922,6 +1191,28 @@ type JWTAuth struct {
 	// +kubebuilder:validation:Minimum=0
 	// +kubebuilder:default:=1
 	SSLVerifyDepth *int `json:"sslVerifyDepth"`
+	// The claims of the JWT that must match for the request to be authorized.
+	Require *JWTRequire `json:"require"`
+	// Enables the dry run mode. In this mode, the requests that the policy would reject are not rejected, but reported in the $policy_dry_run variable and counted in the policy_dry_run_rejections_total metric.
+	DryRun bool `json:"dryRun"`
+}
+
+// JWTRequire defines the claims of the JWT that must match for the request to be authorized.
+type JWTRequire struct {
+	// The claims that must match. The request is authorized only if all the claims match.
+	Claims []JWTClaimRequirement `json:"claims"`
+	// The status code that is returned when the claims don't match. Accepted values are 401 and 403. The default is 403.
+	Code *int `json:"code"`
+}
+
+// JWTClaimRequirement defines a claim of the JWT that must match.
+type JWTClaimRequirement struct {
+	// The name of the claim. Nested claims should be separated by ".", for example, realm_access.roles.
+	Name string `json:"name"`
+	// The claim must be equal to any of the values. An array claim must contain any of the values.
+	Values []string `json:"values"`
+	// The claim must match the regular expression. The elements of an array claim are matched as a comma-separated list.
+	Regex string `json:"regex"`
 }
 
 // BasicAuth holds HTTP Basic authentication configuration
@@ -930,6 +1221,8 @@ type BasicAuth struct {
 	Realm string `json:"realm"`
 	// The name of the Kubernetes secret that stores the Htpasswd configuration. It must be in the same namespace as the Policy resource. The secret must be of the type nginx.org/htpasswd, and the config must be stored in the secret under the key htpasswd, otherwise the secret will be rejected as invalid.
 	Secret string `json:"secret"`
+	// Enables the dry run mode. In this mode, the requests that the policy would reject are not rejected, but reported in the $policy_dry_run variable and counted in the policy_dry_run_rejections_total metric.
+	DryRun bool `json:"dryRun"`
 }
 
 // The IngressMTLS policy configures client certificate verification.
@@ -980,8 +1273,13 @@ type OIDC struct {
 	ClientSecret string `json:"clientSecret"`
 	// List of OpenID Connect scopes. The scope openid always needs to be present and others can be added concatenating them with a + sign, for example openid+profile+email, openid+email+userDefinedScope. The default is openid.
 	Scope string `json:"scope"`
-	// Allows overriding the default redirect URI. The default is /_codexch.
+	// Allows overriding the default redirect URI. Every OIDC policy of a host must have a different redirect URI. The default is /_codexch.
 	RedirectURI string `json:"redirectURI"`
+	// Allows overriding the default logout URI. Every OIDC policy of a host must have a different logout URI. The default is /logout.
+	LogoutURI string `json:"logoutURI"`
+	// The name of the cookie that stores the session of the user. The cookies that store the nonce and the requested URI during the login are named after it with the _nonce and _redir suffixes. Every OIDC policy of a host must have a different session cookie. The default is auth_token.
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9_]+$`
+	SessionCookie string `json:"sessionCookie"`
 	// URL provided by your OpenID Connect provider to request the end user be logged out.
 	EndSessionEndpoint string `json:"endSessionEndpoint"`
 	// URI to redirect to after the logout has been performed. Requires endSessionEndpoint. The default is /_logout.
@@ -1004,6 +1302,26 @@ type OIDC struct {
 	// +kubebuilder:validation:Minimum=0
 	// +kubebuilder:default:=1
 	SSLVerifyDepth *int `json:"sslVerifyDepth"`
+	// The claims of the ID token that are passed to the upstream in request headers.
+	ClaimHeaders []OIDCClaimHeader `json:"claimHeaders"`
+	// Restricts the access to the users that are members of the allowed groups.
+	Groups *OIDCGroups `json:"groups"`
+}
+
+// OIDCClaimHeader defines a claim of the ID token that is passed to the upstream in a request header.
+type OIDCClaimHeader struct {
+	// The name of the claim. Nested claims should be separated by ".", for example, realm_access.roles. The elements of an array claim are separated by commas.
+	Claim string `json:"claim"`
+	// The name of the request header.
+	Header string `json:"header"`
+}
+
+// OIDCGroups defines the groups of the users that are allowed to access the resources. The requests of the other users are rejected with the 403 status code.
+type OIDCGroups struct {
+	// The name of the claim of the ID token that contains the groups of the user. Nested claims should be separated by ".". The default is groups.
+	Claim string `json:"claim"`
+	// The allowed groups. The user must be a member of any of the groups.
+	Allow []string `json:"allow"`
 }
 
 // The WAF policy configures NGINX Plus to secure client requests using App Protect WAF policies.
@@ -1037,7 +1355,49 @@ type APIKey struct {
 	// The location of the API Key. For example, $http_auth, $arg_apikey, $cookie_auth. Accepted variables are $http_, $arg_, $cookie_.
 	SuppliedIn *SuppliedIn `json:"suppliedIn"`
 	// The key to which the API key is applied. Can contain text, variables, or a combination of them. Accepted variables are $http_, $arg_, $cookie_.
+	// The keys of the data of the Secret are the IDs of the clients. The value is either the API key of the client, or a JSON object with the API key (key) or its SHA-256 hash (sha256), and optionally the name of the client (name), the expiry time of the API key in RFC 3339 format (expires) and the path prefixes the API key is allowed for (routes).
 	ClientSecret string `json:"clientSecret"`
+	// The name of the request header that passes the ID of the authenticated client to the upstream. The ID is also available in the $apikey_client_name variable.
+	ClientIDHeader string `json:"clientIDHeader,omitempty"`
+	// Enables the dry run mode. In this mode, the requests that the policy would reject are not rejected, but reported in the $policy_dry_run variable and counted in the policy_dry_run_rejections_total metric.
+	DryRun bool `json:"dryRun"`
+}
+
+// HMAC defines an HMAC policy, which verifies the HMAC-SHA256 signature of the request computed with a shared key
+// over the canonical string of the request. The requests with a missing or invalid signature, or a timestamp outside
+// of the allowed clock skew, are rejected.
+type HMAC struct {
+	// +kubebuilder:validation:Required
+	// The name of the Kubernetes secret that stores the shared keys. It must be in the same namespace as the Policy resource. The secret must be of the type nginx.org/hmac, and every key of the secret is a key ID with the shared key as the value.
+	Secret string `json:"secret"`
+
+	// +kubebuilder:validation:Optional
+	// The request header with the ID of the key used to sign the request. If not specified, the signature is verified with every key of the secret.
+	KeyIDHeader string `json:"keyIDHeader,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// The request header with the signature, as a hex or base64 encoded value, optionally prefixed with sha256=. The default is X-Signature.
+	SignatureHeader string `json:"signatureHeader,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// The request header with the time the request was signed, as a Unix timestamp in seconds. The default is X-Timestamp.
+	TimestampHeader string `json:"timestampHeader,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// The template of the signed canonical string. The template can reference {method}, {uri}, {timestamp} and {body}, and must reference {body}. The default is {timestamp}.{body}.
+	CanonicalString string `json:"canonicalString,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// The allowed difference between the timestamp of the request and the time of NGINX. The default is 5m.
+	ClockSkew string `json:"clockSkew,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// The maximum size of the signed request body. The requests with a larger body are rejected with the 413 status code. The default is 1m.
+	MaxBodySize string `json:"maxBodySize,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// The status code returned for the requests that fail the verification. The default is 401.
+	RejectCode *int `json:"rejectCode,omitempty"`
 }
 
 // SuppliedIn defines the locations API Key should be supplied in.
@@ -1267,6 +1627,62 @@ type CORS struct {
 	MaxAge *int `json:"maxAge,omitempty"`
 }
 
+// Headers defines a policy that modifies the request headers passed to the upstreams and the response headers sent to the clients.
+// The headers policies of the VirtualServer spec apply to every route. The headers policies of a route or a subroute are applied after them,
+// and the requestHeaders and responseHeaders of the action proxy are applied last. A header modified at a later level replaces the modifications of the same header at the earlier levels.
+type Headers struct {
+	// +kubebuilder:validation:Optional
+	// Request defines the modifications of the request headers passed to the upstreams.
+	Request *RequestHeadersModifier `json:"request,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Response defines the modifications of the response headers sent to the clients.
+	Response *ResponseHeadersModifier `json:"response,omitempty"`
+}
+
+// RequestHeadersModifier defines the modifications of the request headers.
+type RequestHeadersModifier struct {
+	// +kubebuilder:validation:Optional
+	// Set replaces the values of the headers.
+	Set []Header `json:"set,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Add appends the values to the values of the headers, separated by a comma. The headers that are not present are set to the values.
+	Add []Header `json:"add,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Remove removes the headers.
+	Remove []string `json:"remove,omitempty"`
+}
+
+// ResponseHeadersModifier defines the modifications of the response headers.
+type ResponseHeadersModifier struct {
+	// +kubebuilder:validation:Optional
+	// Set replaces the headers of the upstream responses.
+	Set []ResponseHeader `json:"set,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Add adds the headers to the responses. The headers of the upstream responses are kept.
+	Add []ResponseHeader `json:"add,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Remove removes the headers of the upstream responses.
+	Remove []string `json:"remove,omitempty"`
+}
+
+// ResponseHeader defines a response header of a headers policy.
+type ResponseHeader struct {
+	Header `json:",inline"`
+
+	// +kubebuilder:validation:Optional
+	// If set to true, the header is applied regardless of the response status code. By default, the header is applied only to the 200, 201, 204, 206, 301, 302, 303, 304, 307 and 308 responses.
+	Always bool `json:"always,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Codes restricts the header to the responses with the status codes, which can be exact codes, for example 404, or classes of codes, for example 5xx. Setting the codes implies always.
+	Codes []string `json:"codes,omitempty"`
+}
+
 // ExternalAuth defines an external authentication policy for authenticating client requests using an external authentication server, which can be used for example with the oauth2-proxy or any custom authentication server that requires redirection for authentication.
 type ExternalAuth struct {
 	// +kubebuilder:validation:Required
@@ -1323,4 +1739,87 @@ type ExternalAuth struct {
 	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9]([-a-zA-Z0-9]*[a-zA-Z0-9])?(\.[a-zA-Z0-9]([-a-zA-Z0-9]*[a-zA-Z0-9])?)*$`
 	// SNIName sets the server name used for SNI and certificate verification when connecting to the external authentication server over TLS. If not specified, defaults to <service-name>.<namespace>.svc derived from authServiceName.
 	SNIName string `json:"sniName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// GRPC enables the gRPC mode, in which the requests are authorized by an external authorization server that implements the Envoy envoy.service.auth.v3.Authorization gRPC API, for example Open Policy Agent. The authURI field is ignored in the gRPC mode. Denied requests are rejected with the 401 or 403 status code; the headers and the body of the denied responses are not supported.
+	GRPC *ExternalAuthGRPC `json:"grpc,omitempty"`
+}
+
+// ExternalAuthGRPC defines the gRPC mode of the external auth policy.
+type ExternalAuthGRPC struct {
+	// +kubebuilder:validation:Optional
+	// AllowedUpstreamHeaders are the request headers that the external authorization server can set or remove in its OK responses. The headers are sent to the upstreams with the values from the OK responses. Other header mutations of the OK responses are ignored.
+	AllowedUpstreamHeaders []string `json:"allowedUpstreamHeaders,omitempty"`
+}
+
+// Introspection defines an OAuth 2.0 token introspection policy for authenticating client requests with opaque access tokens.
+// The bearer token is sent to the introspection endpoint of the identity provider, and the results for the active tokens are cached.
+type Introspection struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^/.*$`
+	// AuthURI is the URI of the introspection endpoint to which the token will be sent, for example /oauth2/introspect.
+	AuthURI string `json:"authURI"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^([a-z0-9]([-a-z0-9]*[a-z0-9])?\/)?[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// AuthServiceName is the name of the Kubernetes service of the introspection endpoint. It can be in the same namespace as the Policy resource or in a different namespace specified as <namespace>/<service>.
+	AuthServiceName string `json:"authServiceName"`
+
+	// +kubebuilder:validation:Optional
+	// AuthServicePorts are the ports of the Kubernetes service of the introspection endpoint. If not specified, the first port of the service is used.
+	AuthServicePorts []int `json:"authServicePorts,omitempty"`
+
+	// +kubebuilder:validation:Required
+	// The client ID used to authenticate to the introspection endpoint.
+	ClientID string `json:"clientID"`
+
+	// +kubebuilder:validation:Required
+	// The name of the Kubernetes secret that stores the client secret used to authenticate to the introspection endpoint. It must be in the same namespace as the Policy resource. The secret must be of the type nginx.org/oidc, and the secret under the key client-secret.
+	ClientSecret string `json:"clientSecret"`
+
+	// +kubebuilder:validation:Optional
+	// The time the results for the active tokens are cached. The default is 1m.
+	CacheTimeout string `json:"cacheTimeout,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// The size of the keyval zone for the cached results. The default is 1m.
+	CacheZoneSize string `json:"cacheZoneSize,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// The claims of the introspection response to pass to the upstream as request headers.
+	Headers []IntrospectionHeader `json:"headers,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=false
+	// SSLEnabled enables HTTPS when sending requests to the introspection endpoint. Default is false.
+	SSLEnabled bool `json:"sslEnabled"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=false
+	// SSLVerify enables verification of the introspection endpoint's SSL certificate. Default is false.
+	SSLVerify bool `json:"sslVerify"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default:=1
+	// SSLVerifyDepth sets the verification depth in the introspection endpoint certificates chain. Default is 1.
+	SSLVerifyDepth *int `json:"sslVerifyDepth,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^([a-z0-9]([-a-z0-9]*[a-z0-9])?\/)?[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// TrustedCertSecret is the name of the Kubernetes secret that stores the CA certificate for the introspection endpoint certificate verification. It can be in the same namespace as the Policy resource or in a different namespace specified as <namespace>/<secret>. The secret must be of the type nginx.org/ca.
+	TrustedCertSecret string `json:"trustedCertSecret,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9]([-a-zA-Z0-9]*[a-zA-Z0-9])?(\.[a-zA-Z0-9]([-a-zA-Z0-9]*[a-zA-Z0-9])?)*$`
+	// SNIName sets the server name used for SNI and certificate verification when connecting to the introspection endpoint over TLS. If not specified, defaults to <service-name>.<namespace>.svc derived from authServiceName.
+	SNIName string `json:"sniName,omitempty"`
+}
+
+// IntrospectionHeader defines a request header set to a claim of the introspection response.
+type IntrospectionHeader struct {
+	// The name of the request header.
+	Name string `json:"name"`
+	// The name of the claim. The nested claims are separated by dots, for example realm_access.roles. The values of the array claims are joined with commas.
+	Claim string `json:"claim"`
 }
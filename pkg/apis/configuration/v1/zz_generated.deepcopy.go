@@ -89,6 +89,32 @@ func (in *Action) DeepCopy() *Action {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActionMirror) DeepCopyInto(out *ActionMirror) {
+	*out = *in
+	if in.Percentage != nil {
+		in, out := &in.Percentage, &out.Percentage
+		*out = new(int)
+		**out = **in
+	}
+	if in.RequestBody != nil {
+		in, out := &in.RequestBody, &out.RequestBody
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActionMirror.
+func (in *ActionMirror) DeepCopy() *ActionMirror {
+	if in == nil {
+		return nil
+	}
+	out := new(ActionMirror)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ActionProxy) DeepCopyInto(out *ActionProxy) {
 	*out = *in
@@ -102,6 +128,16 @@ func (in *ActionProxy) DeepCopyInto(out *ActionProxy) {
 		*out = new(ProxyResponseHeaders)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Mirror != nil {
+		in, out := &in.Mirror, &out.Mirror
+		*out = new(ActionMirror)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(RetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -382,6 +418,58 @@ func (in *Condition) DeepCopy() *Condition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectionLimit) DeepCopyInto(out *ConnectionLimit) {
+	*out = *in
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionLimit.
+func (in *ConnectionLimit) DeepCopy() *ConnectionLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectionLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DefaultPolicies) DeepCopyInto(out *DefaultPolicies) {
+	*out = *in
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]PolicyReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefaultPolicies.
+func (in *DefaultPolicies) DeepCopy() *DefaultPolicies {
+	if in == nil {
+		return nil
+	}
+	out := new(DefaultPolicies)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EgressMTLS) DeepCopyInto(out *EgressMTLS) {
 	*out = *in
@@ -486,6 +574,11 @@ func (in *ExternalAuth) DeepCopyInto(out *ExternalAuth) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.GRPC != nil {
+		in, out := &in.GRPC, &out.GRPC
+		*out = new(ExternalAuthGRPC)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -499,6 +592,27 @@ func (in *ExternalAuth) DeepCopy() *ExternalAuth {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalAuthGRPC) DeepCopyInto(out *ExternalAuthGRPC) {
+	*out = *in
+	if in.AllowedUpstreamHeaders != nil {
+		in, out := &in.AllowedUpstreamHeaders, &out.AllowedUpstreamHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalAuthGRPC.
+func (in *ExternalAuthGRPC) DeepCopy() *ExternalAuthGRPC {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalAuthGRPC)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalDNS) DeepCopyInto(out *ExternalDNS) {
 	*out = *in
@@ -543,6 +657,68 @@ func (in *ExternalEndpoint) DeepCopy() *ExternalEndpoint {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Geo) DeepCopyInto(out *Geo) {
+	*out = *in
+	if in.Allow != nil {
+		in, out := &in.Allow, &out.Allow
+		*out = new(GeoMatch)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Deny != nil {
+		in, out := &in.Deny, &out.Deny
+		*out = new(GeoMatch)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RejectCode != nil {
+		in, out := &in.RejectCode, &out.RejectCode
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Geo.
+func (in *Geo) DeepCopy() *Geo {
+	if in == nil {
+		return nil
+	}
+	out := new(Geo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GeoMatch) DeepCopyInto(out *GeoMatch) {
+	*out = *in
+	if in.Countries != nil {
+		in, out := &in.Countries, &out.Countries
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Continents != nil {
+		in, out := &in.Continents, &out.Continents
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ASNs != nil {
+		in, out := &in.ASNs, &out.ASNs
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GeoMatch.
+func (in *GeoMatch) DeepCopy() *GeoMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(GeoMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GlobalConfiguration) DeepCopyInto(out *GlobalConfiguration) {
 	*out = *in
@@ -611,6 +787,13 @@ func (in *GlobalConfigurationSpec) DeepCopyInto(out *GlobalConfigurationSpec) {
 		*out = make([]Listener, len(*in))
 		copy(*out, *in)
 	}
+	if in.DefaultPolicies != nil {
+		in, out := &in.DefaultPolicies, &out.DefaultPolicies
+		*out = make([]DefaultPolicies, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -624,6 +807,27 @@ func (in *GlobalConfigurationSpec) DeepCopy() *GlobalConfigurationSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HMAC) DeepCopyInto(out *HMAC) {
+	*out = *in
+	if in.RejectCode != nil {
+		in, out := &in.RejectCode, &out.RejectCode
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HMAC.
+func (in *HMAC) DeepCopy() *HMAC {
+	if in == nil {
+		return nil
+	}
+	out := new(HMAC)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Header) DeepCopyInto(out *Header) {
 	*out = *in
@@ -640,6 +844,32 @@ func (in *Header) DeepCopy() *Header {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Headers) DeepCopyInto(out *Headers) {
+	*out = *in
+	if in.Request != nil {
+		in, out := &in.Request, &out.Request
+		*out = new(RequestHeadersModifier)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Response != nil {
+		in, out := &in.Response, &out.Response
+		*out = new(ResponseHeadersModifier)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Headers.
+func (in *Headers) DeepCopy() *Headers {
+	if in == nil {
+		return nil
+	}
+	out := new(Headers)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HealthCheck) DeepCopyInto(out *HealthCheck) {
 	*out = *in
@@ -692,6 +922,53 @@ func (in *IngressMTLS) DeepCopy() *IngressMTLS {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Introspection) DeepCopyInto(out *Introspection) {
+	*out = *in
+	if in.AuthServicePorts != nil {
+		in, out := &in.AuthServicePorts, &out.AuthServicePorts
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]IntrospectionHeader, len(*in))
+		copy(*out, *in)
+	}
+	if in.SSLVerifyDepth != nil {
+		in, out := &in.SSLVerifyDepth, &out.SSLVerifyDepth
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Introspection.
+func (in *Introspection) DeepCopy() *Introspection {
+	if in == nil {
+		return nil
+	}
+	out := new(Introspection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntrospectionHeader) DeepCopyInto(out *IntrospectionHeader) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntrospectionHeader.
+func (in *IntrospectionHeader) DeepCopy() *IntrospectionHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(IntrospectionHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *JWTAuth) DeepCopyInto(out *JWTAuth) {
 	*out = *in
@@ -700,6 +977,11 @@ func (in *JWTAuth) DeepCopyInto(out *JWTAuth) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.Require != nil {
+		in, out := &in.Require, &out.Require
+		*out = new(JWTRequire)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -713,6 +995,27 @@ func (in *JWTAuth) DeepCopy() *JWTAuth {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTClaimRequirement) DeepCopyInto(out *JWTClaimRequirement) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTClaimRequirement.
+func (in *JWTClaimRequirement) DeepCopy() *JWTClaimRequirement {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTClaimRequirement)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *JWTCondition) DeepCopyInto(out *JWTCondition) {
 	*out = *in
@@ -729,6 +1032,34 @@ func (in *JWTCondition) DeepCopy() *JWTCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTRequire) DeepCopyInto(out *JWTRequire) {
+	*out = *in
+	if in.Claims != nil {
+		in, out := &in.Claims, &out.Claims
+		*out = make([]JWTClaimRequirement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Code != nil {
+		in, out := &in.Code, &out.Code
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTRequire.
+func (in *JWTRequire) DeepCopy() *JWTRequire {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTRequire)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Listener) DeepCopyInto(out *Listener) {
 	*out = *in
@@ -791,20 +1122,88 @@ func (in *OIDC) DeepCopyInto(out *OIDC) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.SSLVerifyDepth != nil {
-		in, out := &in.SSLVerifyDepth, &out.SSLVerifyDepth
+	if in.SSLVerifyDepth != nil {
+		in, out := &in.SSLVerifyDepth, &out.SSLVerifyDepth
+		*out = new(int)
+		**out = **in
+	}
+	if in.ClaimHeaders != nil {
+		in, out := &in.ClaimHeaders, &out.ClaimHeaders
+		*out = make([]OIDCClaimHeader, len(*in))
+		copy(*out, *in)
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = new(OIDCGroups)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDC.
+func (in *OIDC) DeepCopy() *OIDC {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDC)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDCClaimHeader) DeepCopyInto(out *OIDCClaimHeader) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCClaimHeader.
+func (in *OIDCClaimHeader) DeepCopy() *OIDCClaimHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDCClaimHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDCGroups) DeepCopyInto(out *OIDCGroups) {
+	*out = *in
+	if in.Allow != nil {
+		in, out := &in.Allow, &out.Allow
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCGroups.
+func (in *OIDCGroups) DeepCopy() *OIDCGroups {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDCGroups)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutlierDetection) DeepCopyInto(out *OutlierDetection) {
+	*out = *in
+	if in.MaxEjectionPercent != nil {
+		in, out := &in.MaxEjectionPercent, &out.MaxEjectionPercent
 		*out = new(int)
 		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDC.
-func (in *OIDC) DeepCopy() *OIDC {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutlierDetection.
+func (in *OutlierDetection) DeepCopy() *OutlierDetection {
 	if in == nil {
 		return nil
 	}
-	out := new(OIDC)
+	out := new(OutlierDetection)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -815,7 +1214,7 @@ func (in *Policy) DeepCopyInto(out *Policy) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -934,6 +1333,11 @@ func (in *PolicySpec) DeepCopyInto(out *PolicySpec) {
 		*out = new(APIKey)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.HMAC != nil {
+		in, out := &in.HMAC, &out.HMAC
+		*out = new(HMAC)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Cache != nil {
 		in, out := &in.Cache, &out.Cache
 		*out = new(Cache)
@@ -944,11 +1348,36 @@ func (in *PolicySpec) DeepCopyInto(out *PolicySpec) {
 		*out = new(CORS)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = new(Headers)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ExternalAuth != nil {
 		in, out := &in.ExternalAuth, &out.ExternalAuth
 		*out = new(ExternalAuth)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Introspection != nil {
+		in, out := &in.Introspection, &out.Introspection
+		*out = new(Introspection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConnectionLimit != nil {
+		in, out := &in.ConnectionLimit, &out.ConnectionLimit
+		*out = new(ConnectionLimit)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Geo != nil {
+		in, out := &in.Geo, &out.Geo
+		*out = new(Geo)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tracing != nil {
+		in, out := &in.Tracing, &out.Tracing
+		*out = new(Tracing)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -965,6 +1394,13 @@ func (in *PolicySpec) DeepCopy() *PolicySpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PolicyStatus) DeepCopyInto(out *PolicyStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1152,6 +1588,200 @@ func (in *RateLimitCondition) DeepCopy() *RateLimitCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequestHeadersModifier) DeepCopyInto(out *RequestHeadersModifier) {
+	*out = *in
+	if in.Set != nil {
+		in, out := &in.Set, &out.Set
+		*out = make([]Header, len(*in))
+		copy(*out, *in)
+	}
+	if in.Add != nil {
+		in, out := &in.Add, &out.Add
+		*out = make([]Header, len(*in))
+		copy(*out, *in)
+	}
+	if in.Remove != nil {
+		in, out := &in.Remove, &out.Remove
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequestHeadersModifier.
+func (in *RequestHeadersModifier) DeepCopy() *RequestHeadersModifier {
+	if in == nil {
+		return nil
+	}
+	out := new(RequestHeadersModifier)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResponseHeader) DeepCopyInto(out *ResponseHeader) {
+	*out = *in
+	out.Header = in.Header
+	if in.Codes != nil {
+		in, out := &in.Codes, &out.Codes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResponseHeader.
+func (in *ResponseHeader) DeepCopy() *ResponseHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(ResponseHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResponseHeadersModifier) DeepCopyInto(out *ResponseHeadersModifier) {
+	*out = *in
+	if in.Set != nil {
+		in, out := &in.Set, &out.Set
+		*out = make([]ResponseHeader, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Add != nil {
+		in, out := &in.Add, &out.Add
+		*out = make([]ResponseHeader, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Remove != nil {
+		in, out := &in.Remove, &out.Remove
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResponseHeadersModifier.
+func (in *ResponseHeadersModifier) DeepCopy() *ResponseHeadersModifier {
+	if in == nil {
+		return nil
+	}
+	out := new(ResponseHeadersModifier)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryBudget) DeepCopyInto(out *RetryBudget) {
+	*out = *in
+	if in.MinRetries != nil {
+		in, out := &in.MinRetries, &out.MinRetries
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryBudget.
+func (in *RetryBudget) DeepCopy() *RetryBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryPolicy) DeepCopyInto(out *RetryPolicy) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Budget != nil {
+		in, out := &in.Budget, &out.Budget
+		*out = new(RetryBudget)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryPolicy.
+func (in *RetryPolicy) DeepCopy() *RetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Rollout) DeepCopyInto(out *Rollout) {
+	*out = *in
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]RolloutStep, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxErrorRate != nil {
+		in, out := &in.MaxErrorRate, &out.MaxErrorRate
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Rollout.
+func (in *Rollout) DeepCopy() *Rollout {
+	if in == nil {
+		return nil
+	}
+	out := new(Rollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutStatus) DeepCopyInto(out *RolloutStatus) {
+	*out = *in
+	in.StepStartTime.DeepCopyInto(&out.StepStartTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStatus.
+func (in *RolloutStatus) DeepCopy() *RolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutStep) DeepCopyInto(out *RolloutStep) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStep.
+func (in *RolloutStep) DeepCopy() *RolloutStep {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Route) DeepCopyInto(out *Route) {
 	*out = *in
@@ -1191,6 +1821,11 @@ func (in *Route) DeepCopyInto(out *Route) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(Rollout)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1346,13 +1981,50 @@ func (in *TLSRedirect) DeepCopy() *TLSRedirect {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Tracing) DeepCopyInto(out *Tracing) {
+	*out = *in
+	if in.SpanAttributes != nil {
+		in, out := &in.SpanAttributes, &out.SpanAttributes
+		*out = make([]TracingSpanAttribute, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Tracing.
+func (in *Tracing) DeepCopy() *Tracing {
+	if in == nil {
+		return nil
+	}
+	out := new(Tracing)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TracingSpanAttribute) DeepCopyInto(out *TracingSpanAttribute) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracingSpanAttribute.
+func (in *TracingSpanAttribute) DeepCopy() *TracingSpanAttribute {
+	if in == nil {
+		return nil
+	}
+	out := new(TracingSpanAttribute)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TransportServer) DeepCopyInto(out *TransportServer) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -1460,6 +2132,27 @@ func (in *TransportServerListener) DeepCopy() *TransportServerListener {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransportServerMTLS) DeepCopyInto(out *TransportServerMTLS) {
+	*out = *in
+	if in.VerifyDepth != nil {
+		in, out := &in.VerifyDepth, &out.VerifyDepth
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransportServerMTLS.
+func (in *TransportServerMTLS) DeepCopy() *TransportServerMTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(TransportServerMTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TransportServerMatch) DeepCopyInto(out *TransportServerMatch) {
 	*out = *in
@@ -1482,7 +2175,7 @@ func (in *TransportServerSpec) DeepCopyInto(out *TransportServerSpec) {
 	if in.TLS != nil {
 		in, out := &in.TLS, &out.TLS
 		*out = new(TransportServerTLS)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	out.Listener = in.Listener
 	if in.Upstreams != nil {
@@ -1507,6 +2200,11 @@ func (in *TransportServerSpec) DeepCopyInto(out *TransportServerSpec) {
 		*out = new(TransportServerAction)
 		**out = **in
 	}
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]PolicyReference, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1523,6 +2221,13 @@ func (in *TransportServerSpec) DeepCopy() *TransportServerSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TransportServerStatus) DeepCopyInto(out *TransportServerStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1539,6 +2244,11 @@ func (in *TransportServerStatus) DeepCopy() *TransportServerStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TransportServerTLS) DeepCopyInto(out *TransportServerTLS) {
 	*out = *in
+	if in.MTLS != nil {
+		in, out := &in.MTLS, &out.MTLS
+		*out = new(TransportServerMTLS)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1644,6 +2354,11 @@ func (in *Upstream) DeepCopyInto(out *Upstream) {
 		*out = new(uint16)
 		**out = **in
 	}
+	if in.OutlierDetection != nil {
+		in, out := &in.OutlierDetection, &out.OutlierDetection
+		*out = new(OutlierDetection)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1923,6 +2638,13 @@ func (in *VirtualServerRouteStatus) DeepCopyInto(out *VirtualServerRouteStatus)
 		*out = make([]ExternalEndpoint, len(*in))
 		copy(*out, *in)
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1990,6 +2712,20 @@ func (in *VirtualServerStatus) DeepCopyInto(out *VirtualServerStatus) {
 		*out = make([]ExternalEndpoint, len(*in))
 		copy(*out, *in)
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Rollouts != nil {
+		in, out := &in.Rollouts, &out.Rollouts
+		*out = make([]RolloutStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
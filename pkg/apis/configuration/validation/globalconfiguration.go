@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	conf_v1 "github.com/nginx/kubernetes-ingress/pkg/apis/configuration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -46,7 +47,62 @@ func (gcv *GlobalConfigurationValidator) ValidateGlobalConfiguration(globalConfi
 func (gcv *GlobalConfigurationValidator) validateGlobalConfigurationSpec(spec *conf_v1.GlobalConfigurationSpec, fieldPath *field.Path) field.ErrorList {
 	validListeners, err := gcv.getValidListeners(spec.Listeners, fieldPath.Child("listeners"))
 	spec.Listeners = validListeners
-	return err
+
+	validDefaultPolicies, defaultPoliciesErr := getValidDefaultPolicies(spec.DefaultPolicies, fieldPath.Child("defaultPolicies"))
+	spec.DefaultPolicies = validDefaultPolicies
+
+	return append(err, defaultPoliciesErr...)
+}
+
+// getValidDefaultPolicies returns the valid default policies. The invalid ones are reported and left out,
+// so that they are not applied to any resources.
+func getValidDefaultPolicies(defaultPolicies []conf_v1.DefaultPolicies, fieldPath *field.Path) ([]conf_v1.DefaultPolicies, field.ErrorList) {
+	allErrs := field.ErrorList{}
+	var validDefaultPolicies []conf_v1.DefaultPolicies
+
+	for i, dp := range defaultPolicies {
+		errs := validateDefaultPolicies(dp, fieldPath.Index(i))
+		if len(errs) > 0 {
+			allErrs = append(allErrs, errs...)
+			continue
+		}
+		validDefaultPolicies = append(validDefaultPolicies, dp)
+	}
+
+	return validDefaultPolicies, allErrs
+}
+
+func validateDefaultPolicies(dp conf_v1.DefaultPolicies, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(dp.Policies) == 0 {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("policies"), "must specify at least one policy"))
+	}
+	allErrs = append(allErrs, validatePolicies(dp.Policies, fieldPath.Child("policies"), "")...)
+
+	if len(dp.Namespaces) > 0 && dp.NamespaceSelector != nil {
+		allErrs = append(allErrs, field.Forbidden(fieldPath, "namespaces and namespaceSelector cannot be used together"))
+	}
+
+	namespaces := sets.Set[string]{}
+	for i, ns := range dp.Namespaces {
+		idxPath := fieldPath.Child("namespaces").Index(i)
+		for _, msg := range validation.IsDNS1123Label(ns) {
+			allErrs = append(allErrs, field.Invalid(idxPath, ns, msg))
+		}
+		if namespaces.Has(ns) {
+			allErrs = append(allErrs, field.Duplicate(idxPath, ns))
+		}
+		namespaces.Insert(ns)
+	}
+
+	if dp.NamespaceSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(dp.NamespaceSelector); err != nil {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("namespaceSelector"), dp.NamespaceSelector, err.Error()))
+		}
+	}
+
+	return allErrs
 }
 
 func (gcv *GlobalConfigurationValidator) getValidListeners(listeners []conf_v1.Listener, fieldPath *field.Path) ([]conf_v1.Listener, field.ErrorList) {
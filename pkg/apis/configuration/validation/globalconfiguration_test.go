@@ -6,6 +6,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 
 	conf_v1 "github.com/nginx/kubernetes-ingress/pkg/apis/configuration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
@@ -755,3 +756,131 @@ func TestValidateListenerProtocol_PassesOnUDPListenerUsingSamePortAsHTTPListener
 		t.Errorf("validateListeners() returned errors %v for valid input", allErrs)
 	}
 }
+
+func TestValidateDefaultPolicies_PassesOnValidInput(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		dp  conf_v1.DefaultPolicies
+		msg string
+	}{
+		{
+			dp: conf_v1.DefaultPolicies{
+				Policies: []conf_v1.PolicyReference{{Name: "waf", Namespace: "security"}, {Name: "rate-limit"}},
+			},
+			msg: "policies for all namespaces",
+		},
+		{
+			dp: conf_v1.DefaultPolicies{
+				Policies:    []conf_v1.PolicyReference{{Name: "waf", Namespace: "security"}},
+				Namespaces:  []string{"tenant-a", "tenant-b"},
+				AllowOptOut: true,
+			},
+			msg: "policies for namespaces",
+		},
+		{
+			dp: conf_v1.DefaultPolicies{
+				Policies: []conf_v1.PolicyReference{{Name: "waf", Namespace: "security"}},
+				NamespaceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"tenant": "true"},
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: "team", Operator: metav1.LabelSelectorOpIn, Values: []string{"a", "b"}},
+					},
+				},
+			},
+			msg: "policies for namespaces selected by labels",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateDefaultPolicies(test.dp, field.NewPath("defaultPolicies"))
+		if len(allErrs) > 0 {
+			t.Errorf("validateDefaultPolicies() returned errors %v for valid input for the case of %s", allErrs, test.msg)
+		}
+	}
+}
+
+func TestValidateDefaultPolicies_FailsOnInvalidInput(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		dp  conf_v1.DefaultPolicies
+		msg string
+	}{
+		{
+			dp: conf_v1.DefaultPolicies{
+				Namespaces: []string{"tenant-a"},
+			},
+			msg: "no policies",
+		},
+		{
+			dp: conf_v1.DefaultPolicies{
+				Policies: []conf_v1.PolicyReference{{Name: "waf", Namespace: "security"}, {Name: "waf", Namespace: "security"}},
+			},
+			msg: "duplicated policies",
+		},
+		{
+			dp: conf_v1.DefaultPolicies{
+				Policies:   []conf_v1.PolicyReference{{Name: "waf", Namespace: "security"}},
+				Namespaces: []string{"Tenant_A"},
+			},
+			msg: "invalid namespace",
+		},
+		{
+			dp: conf_v1.DefaultPolicies{
+				Policies:   []conf_v1.PolicyReference{{Name: "waf", Namespace: "security"}},
+				Namespaces: []string{"tenant-a", "tenant-a"},
+			},
+			msg: "duplicated namespace",
+		},
+		{
+			dp: conf_v1.DefaultPolicies{
+				Policies:          []conf_v1.PolicyReference{{Name: "waf", Namespace: "security"}},
+				Namespaces:        []string{"tenant-a"},
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "true"}},
+			},
+			msg: "namespaces and namespaceSelector",
+		},
+		{
+			dp: conf_v1.DefaultPolicies{
+				Policies: []conf_v1.PolicyReference{{Name: "waf", Namespace: "security"}},
+				NamespaceSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: "team", Operator: "Unknown"},
+					},
+				},
+			},
+			msg: "invalid namespaceSelector",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateDefaultPolicies(test.dp, field.NewPath("defaultPolicies"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateDefaultPolicies() returned no errors for invalid input for the case of %s", test.msg)
+		}
+	}
+}
+
+func TestValidateGlobalConfiguration_LeavesOutInvalidDefaultPolicies(t *testing.T) {
+	t.Parallel()
+	valid := conf_v1.DefaultPolicies{
+		Policies: []conf_v1.PolicyReference{{Name: "waf", Namespace: "security"}},
+	}
+	globalConfiguration := conf_v1.GlobalConfiguration{
+		Spec: conf_v1.GlobalConfigurationSpec{
+			DefaultPolicies: []conf_v1.DefaultPolicies{
+				valid,
+				{Namespaces: []string{"tenant-a"}},
+			},
+		},
+	}
+
+	gcv := createGlobalConfigurationValidator()
+
+	err := gcv.ValidateGlobalConfiguration(&globalConfiguration)
+	if err == nil {
+		t.Error("ValidateGlobalConfiguration() returned no error for invalid default policies")
+	}
+	if diff := cmp.Diff([]conf_v1.DefaultPolicies{valid}, globalConfiguration.Spec.DefaultPolicies); diff != "" {
+		t.Errorf("ValidateGlobalConfiguration() left unexpected default policies (-want +got):\n%s", diff)
+	}
+}
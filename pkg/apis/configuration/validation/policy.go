@@ -5,10 +5,12 @@ import (
 	"net"
 	"net/url"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"unicode"
 
+	"github.com/nginx/kubernetes-ingress/internal/configs"
 	validation2 "github.com/nginx/kubernetes-ingress/internal/validation"
 	v1 "github.com/nginx/kubernetes-ingress/pkg/apis/configuration/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -105,6 +107,19 @@ func policyFields() []policyFieldValidator {
 				return validateExternalAuth(s.ExternalAuth, p.Child("externalAuth"), cfg.EnableSnippets)
 			},
 		},
+		{
+			name:  "introspection",
+			isSet: func(s *v1.PolicySpec) bool { return s.Introspection != nil },
+			gateCheck: func(p *field.Path, cfg PolicyValidationConfig) (field.ErrorList, bool) {
+				if !cfg.IsPlus {
+					return field.ErrorList{field.Forbidden(p.Child("introspection"), "introspection is only supported in NGINX Plus")}, true
+				}
+				return nil, false
+			},
+			validate: func(s *v1.PolicySpec, p *field.Path, _ PolicyValidationConfig) field.ErrorList {
+				return validateIntrospection(s.Introspection, p.Child("introspection"))
+			},
+		},
 		{
 			name:  "oidc",
 			isSet: func(s *v1.PolicySpec) bool { return s.OIDC != nil },
@@ -131,6 +146,13 @@ func policyFields() []policyFieldValidator {
 				return validateAPIKey(s.APIKey, p.Child("apiKey"))
 			},
 		},
+		{
+			name:  "hmac",
+			isSet: func(s *v1.PolicySpec) bool { return s.HMAC != nil },
+			validate: func(s *v1.PolicySpec, p *field.Path, _ PolicyValidationConfig) field.ErrorList {
+				return validateHMAC(s.HMAC, p.Child("hmac"))
+			},
+		},
 		{
 			name:  "waf",
 			isSet: func(s *v1.PolicySpec) bool { return s.WAF != nil },
@@ -163,6 +185,34 @@ func policyFields() []policyFieldValidator {
 				return validateCORS(s.CORS, p.Child("cors"))
 			},
 		},
+		{
+			name:  "headers",
+			isSet: func(s *v1.PolicySpec) bool { return s.Headers != nil },
+			validate: func(s *v1.PolicySpec, p *field.Path, cfg PolicyValidationConfig) field.ErrorList {
+				return validateHeadersPolicy(s.Headers, p.Child("headers"), cfg.IsPlus)
+			},
+		},
+		{
+			name:  "connectionLimit",
+			isSet: func(s *v1.PolicySpec) bool { return s.ConnectionLimit != nil },
+			validate: func(s *v1.PolicySpec, p *field.Path, _ PolicyValidationConfig) field.ErrorList {
+				return validateConnectionLimit(s.ConnectionLimit, p.Child("connectionLimit"))
+			},
+		},
+		{
+			name:  "geo",
+			isSet: func(s *v1.PolicySpec) bool { return s.Geo != nil },
+			validate: func(s *v1.PolicySpec, p *field.Path, _ PolicyValidationConfig) field.ErrorList {
+				return validateGeo(s.Geo, p.Child("geo"))
+			},
+		},
+		{
+			name:  "tracing",
+			isSet: func(s *v1.PolicySpec) bool { return s.Tracing != nil },
+			validate: func(s *v1.PolicySpec, p *field.Path, cfg PolicyValidationConfig) field.ErrorList {
+				return validateTracing(s.Tracing, p.Child("tracing"), cfg.IsPlus)
+			},
+		},
 	}
 }
 
@@ -189,9 +239,9 @@ func validatePolicySpec(spec *v1.PolicySpec, fieldPath *field.Path, cfg PolicyVa
 	}
 
 	if fieldCount != 1 {
-		msg := "must specify exactly one of: `accessControl`, `rateLimit`, `ingressMTLS`, `egressMTLS`, `basicAuth`, `apiKey`, `cache`, `cors`, `externalAuth`"
+		msg := "must specify exactly one of: `accessControl`, `rateLimit`, `ingressMTLS`, `egressMTLS`, `basicAuth`, `apiKey`, `hmac`, `cache`, `cors`, `headers`, `externalAuth`, `connectionLimit`, `tracing`"
 		if cfg.IsPlus {
-			msg = fmt.Sprint(msg, ", `jwt`, `oidc`, `waf`")
+			msg = fmt.Sprint(msg, ", `jwt`, `oidc`, `waf`, `introspection`, `geo`")
 		}
 		allErrs = append(allErrs, field.Invalid(fieldPath, "", msg))
 	}
@@ -264,6 +314,112 @@ func validateRateLimit(rateLimit *v1.RateLimit, fieldPath *field.Path, isPlus bo
 	return allErrs
 }
 
+var (
+	geoCountryRegexp = regexp.MustCompile(`^[A-Z]{2}$`)
+	geoContinents    = []string{"AF", "AN", "AS", "EU", "NA", "OC", "SA"}
+)
+
+func validateGeo(geo *v1.Geo, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if (geo.Allow == nil) == (geo.Deny == nil) {
+		allErrs = append(allErrs, field.Invalid(fieldPath, "", "must specify exactly one of: `allow` or `deny`"))
+	}
+	if geo.Allow != nil {
+		allErrs = append(allErrs, validateGeoMatch(geo.Allow, fieldPath.Child("allow"))...)
+	}
+	if geo.Deny != nil {
+		allErrs = append(allErrs, validateGeoMatch(geo.Deny, fieldPath.Child("deny"))...)
+	}
+	if geo.RejectCode != nil && (*geo.RejectCode < 400 || *geo.RejectCode > 599) {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("rejectCode"), *geo.RejectCode,
+			"must be within the range [400-599]"))
+	}
+
+	return allErrs
+}
+
+func validateGeoMatch(match *v1.GeoMatch, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(match.Countries) == 0 && len(match.Continents) == 0 && len(match.ASNs) == 0 {
+		allErrs = append(allErrs, field.Required(fieldPath, "must specify at least one of: `countries`, `continents` or `asns`"))
+	}
+	for i, country := range match.Countries {
+		if !geoCountryRegexp.MatchString(country) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("countries").Index(i), country, "must be an ISO 3166-1 alpha-2 country code in upper case, for example, US"))
+		}
+	}
+	for i, continent := range match.Continents {
+		if !slices.Contains(geoContinents, continent) {
+			allErrs = append(allErrs, field.NotSupported(fieldPath.Child("continents").Index(i), continent, geoContinents))
+		}
+	}
+	for i, asn := range match.ASNs {
+		if asn <= 0 {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("asns").Index(i), asn, "must be a positive autonomous system number"))
+		}
+	}
+
+	return allErrs
+}
+
+const (
+	tracingSampleRatioFmt    = `(100|[0-9]{1,2}(\.[0-9]{1,2})?)%`
+	tracingSampleRatioErrMsg = "must be a percentage between 0% and 100% with up to two decimal places"
+
+	tracingSpanAttributeNameFmt    = `[a-zA-Z][a-zA-Z0-9_.-]*`
+	tracingSpanAttributeNameErrMsg = "a valid span attribute name must start with a letter and consist of alphanumeric characters, '_', '.' or '-'"
+)
+
+var (
+	tracingSampleRatioRegexp       = regexp.MustCompile("^" + tracingSampleRatioFmt + "$")
+	tracingSpanAttributeNameRegexp = regexp.MustCompile("^" + tracingSpanAttributeNameFmt + "$")
+	tracingPropagations            = []string{"w3c", "b3", "extract"}
+)
+
+func validateTracing(tracing *v1.Tracing, fieldPath *field.Path, isPlus bool) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if tracing.SampleRatio != "" && !tracingSampleRatioRegexp.MatchString(tracing.SampleRatio) {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("sampleRatio"), tracing.SampleRatio,
+			validation.RegexError(tracingSampleRatioErrMsg, tracingSampleRatioFmt, "10%", "0.5%")))
+	}
+	if tracing.SpanName != "" {
+		allErrs = append(allErrs, validateEscapedStringWithVariables(tracing.SpanName, fieldPath.Child("spanName"),
+			actionProxyHeaderSpecialVariables, actionProxyHeaderVariables, isPlus)...)
+	}
+	names := sets.Set[string]{}
+	for i, attr := range tracing.SpanAttributes {
+		attrPath := fieldPath.Child("spanAttributes").Index(i)
+		if !tracingSpanAttributeNameRegexp.MatchString(attr.Name) {
+			allErrs = append(allErrs, field.Invalid(attrPath.Child("name"), attr.Name,
+				validation.RegexError(tracingSpanAttributeNameErrMsg, tracingSpanAttributeNameFmt, "app.tenant", "user_id")))
+		} else if names.Has(attr.Name) {
+			allErrs = append(allErrs, field.Duplicate(attrPath.Child("name"), attr.Name))
+		}
+		names.Insert(attr.Name)
+		allErrs = append(allErrs, validateEscapedStringWithVariables(attr.Value, attrPath.Child("value"),
+			actionProxyHeaderSpecialVariables, actionProxyHeaderVariables, isPlus)...)
+	}
+	if tracing.Propagation != "" && !slices.Contains(tracingPropagations, tracing.Propagation) {
+		allErrs = append(allErrs, field.NotSupported(fieldPath.Child("propagation"), tracing.Propagation, tracingPropagations))
+	}
+
+	return allErrs
+}
+
+func validateConnectionLimit(connectionLimit *v1.ConnectionLimit, fieldPath *field.Path) field.ErrorList {
+	allErrs := validatePositiveInt(connectionLimit.Connections, fieldPath.Child("connections"))
+	allErrs = append(allErrs, validateRateLimitZoneSize(connectionLimit.ZoneSize, fieldPath.Child("zoneSize"))...)
+
+	if connectionLimit.LogLevel != "" {
+		allErrs = append(allErrs, validateRateLimitLogLevel(connectionLimit.LogLevel, fieldPath.Child("logLevel"))...)
+	}
+
+	return allErrs
+}
+
 // validateJWT validates JWT Policy according the rules specified in documentation
 // for using [jwt] local k8s secrets and using [jwks] from remote location.
 //
@@ -276,6 +432,10 @@ func validateJWT(jwt *v1.JWTAuth, fieldPath *field.Path) field.ErrorList {
 	}
 	allErrs := validateRealm(jwt.Realm, fieldPath.Child("realm"))
 
+	if jwt.Require != nil {
+		allErrs = append(allErrs, validateJWTRequire(jwt.Require, fieldPath.Child("require"))...)
+	}
+
 	// Use either JWT Secret or JWKS URI, they are mutually exclusive.
 	if jwt.Secret == "" && jwt.JwksURI == "" {
 		return append(allErrs, field.Required(fieldPath.Child("secret"), "either Secret or JwksURI must be present"))
@@ -415,6 +575,31 @@ func validateOIDC(oidc *v1.OIDC, fieldPath *field.Path) field.ErrorList {
 	if oidc.RedirectURI != "" {
 		allErrs = append(allErrs, validatePath(oidc.RedirectURI, fieldPath.Child("redirectURI"))...)
 	}
+	if oidc.LogoutURI != "" {
+		allErrs = append(allErrs, validatePath(oidc.LogoutURI, fieldPath.Child("logoutURI"))...)
+	}
+	if oidc.LogoutURI != "" || oidc.RedirectURI != "" {
+		redirectURI, logoutURI := "/_codexch", "/logout"
+		if oidc.RedirectURI != "" {
+			redirectURI = oidc.RedirectURI
+		}
+		if oidc.LogoutURI != "" {
+			logoutURI = oidc.LogoutURI
+		}
+		if logoutURI == redirectURI {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("logoutURI"), logoutURI, "must be different from redirectURI"))
+		}
+	}
+	if oidc.SessionCookie != "" && !oidcSessionCookieRegexp.MatchString(oidc.SessionCookie) {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("sessionCookie"), oidc.SessionCookie,
+			validation.RegexError(oidcSessionCookieErrMsg, oidcSessionCookieFmt, "auth_token", "app_session")))
+	}
+	for i, h := range oidc.ClaimHeaders {
+		allErrs = append(allErrs, validateOIDCClaimHeader(h, fieldPath.Child("claimHeaders").Index(i))...)
+	}
+	if oidc.Groups != nil {
+		allErrs = append(allErrs, validateOIDCGroups(oidc.Groups, fieldPath.Child("groups"))...)
+	}
 	if oidc.EndSessionEndpoint != "" {
 		allErrs = append(allErrs, validateURL(oidc.EndSessionEndpoint, fieldPath.Child("endSessionEndpoint"))...)
 	}
@@ -435,6 +620,50 @@ func validateOIDC(oidc *v1.OIDC, fieldPath *field.Path) field.ErrorList {
 	return append(allErrs, validateClientID(oidc.ClientID, fieldPath.Child("clientID"))...)
 }
 
+const (
+	oidcSessionCookieFmt    = `[A-Za-z0-9_]+`
+	oidcSessionCookieErrMsg = "a valid session cookie name must consist of alphanumeric characters or '_'"
+)
+
+var oidcSessionCookieRegexp = regexp.MustCompile("^" + oidcSessionCookieFmt + "$")
+
+func validateOIDCClaimHeader(h v1.OIDCClaimHeader, fieldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if h.Claim == "" {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("claim"), ""))
+	} else if !jwtClaimNameRegexp.MatchString(h.Claim) {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("claim"), h.Claim, jwtClaimNameErrMsg))
+	}
+
+	if h.Header == "" {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("header"), ""))
+	} else {
+		allErrs = append(allErrs, validateHeaderName(h.Header, fieldPath.Child("header"))...)
+	}
+
+	return allErrs
+}
+
+func validateOIDCGroups(groups *v1.OIDCGroups, fieldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if groups.Claim != "" && !jwtClaimNameRegexp.MatchString(groups.Claim) {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("claim"), groups.Claim, jwtClaimNameErrMsg))
+	}
+
+	if len(groups.Allow) == 0 {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("allow"), "must specify at least one group"))
+	}
+	for i, group := range groups.Allow {
+		if !jwtClaimValueRegexp.MatchString(group) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("allow").Index(i), group, jwtClaimValueErrMsg))
+		}
+	}
+
+	return allErrs
+}
+
 func validateAPIKey(apiKey *v1.APIKey, fieldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -475,6 +704,84 @@ func validateAPIKey(apiKey *v1.APIKey, fieldPath *field.Path) field.ErrorList {
 		allErrs = append(allErrs, validateSecretName(apiKey.ClientSecret, fieldPath.Child("clientSecret"))...)
 	}
 
+	if apiKey.ClientIDHeader != "" {
+		for _, msg := range validation.IsHTTPHeaderName(apiKey.ClientIDHeader) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("clientIDHeader"), apiKey.ClientIDHeader, msg))
+		}
+	}
+
+	return allErrs
+}
+
+var (
+	hmacCanonicalStringPlaceholderRegexp = regexp.MustCompile(`{[^{}]*}`)
+	hmacCanonicalStringPlaceholders      = map[string]bool{"{method}": true, "{uri}": true, "{timestamp}": true, "{body}": true}
+)
+
+func validateHMAC(hmac *v1.HMAC, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if hmac.Secret == "" {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("secret"), "secret cannot be empty"))
+	} else {
+		allErrs = append(allErrs, validateSecretName(hmac.Secret, fieldPath.Child("secret"))...)
+	}
+
+	headers := []struct {
+		name  string
+		value string
+	}{
+		{"keyIDHeader", hmac.KeyIDHeader},
+		{"signatureHeader", hmac.SignatureHeader},
+		{"timestampHeader", hmac.TimestampHeader},
+	}
+	for _, h := range headers {
+		if h.value == "" {
+			continue
+		}
+		for _, msg := range validation.IsHTTPHeaderName(h.value) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child(h.name), h.value, msg))
+		}
+	}
+
+	if hmac.CanonicalString != "" {
+		allErrs = append(allErrs, validateHMACCanonicalString(hmac.CanonicalString, fieldPath.Child("canonicalString"))...)
+	}
+
+	allErrs = append(allErrs, validateTime(hmac.ClockSkew, fieldPath.Child("clockSkew"))...)
+	allErrs = append(allErrs, validateSize(hmac.MaxBodySize, fieldPath.Child("maxBodySize"))...)
+
+	if hmac.RejectCode != nil && (*hmac.RejectCode < 400 || *hmac.RejectCode > 599) {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("rejectCode"), *hmac.RejectCode,
+			"must be within the range [400-599]"))
+	}
+
+	return allErrs
+}
+
+// validateHMACCanonicalString validates the template of the canonical string. The template is passed to njs
+// in a quoted nginx parameter, so the characters which would be interpreted by nginx are not allowed.
+func validateHMACCanonicalString(canonicalString string, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if strings.ContainsAny(canonicalString, "$\\\"") {
+		allErrs = append(allErrs, field.Invalid(fieldPath, canonicalString, `must not contain '$', '\' or '"' characters`))
+	}
+
+	hasBody := false
+	for _, placeholder := range hmacCanonicalStringPlaceholderRegexp.FindAllString(canonicalString, -1) {
+		if !hmacCanonicalStringPlaceholders[placeholder] {
+			allErrs = append(allErrs, field.Invalid(fieldPath, canonicalString,
+				fmt.Sprintf("references an unsupported placeholder %s; supported placeholders are {method}, {uri}, {timestamp} and {body}", placeholder)))
+		}
+		if placeholder == "{body}" {
+			hasBody = true
+		}
+	}
+	if !hasBody {
+		allErrs = append(allErrs, field.Invalid(fieldPath, canonicalString, "must reference {body}"))
+	}
+
 	return allErrs
 }
 
@@ -940,6 +1247,81 @@ func validateRateLimitKey(key string, fieldPath *field.Path, isPlus bool) field.
 
 var jwtTokenSpecialVariables = []string{"arg_", "http_", "cookie_"}
 
+var validJWTRequireCodes = map[int]bool{
+	401: true,
+	403: true,
+}
+
+func validateJWTRequire(require *v1.JWTRequire, fieldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if len(require.Claims) == 0 {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("claims"), "must specify at least one claim"))
+	}
+	for i, claim := range require.Claims {
+		allErrs = append(allErrs, validateJWTClaimRequirement(claim, fieldPath.Child("claims").Index(i))...)
+	}
+
+	if require.Code != nil && !validJWTRequireCodes[*require.Code] {
+		allErrs = append(allErrs, field.NotSupported(fieldPath.Child("code"), *require.Code, []string{"401", "403"}))
+	}
+
+	return allErrs
+}
+
+const (
+	jwtClaimNameFmt    = `[^$\s"'\\{};.]+(\.[^$\s"'\\{};.]+)*`
+	jwtClaimNameErrMsg = `must not contain whitespace, '$', '"', ''', '\', '{', '}' or ';', and nested claims must be separated by a single '.'`
+)
+
+var jwtClaimNameRegexp = regexp.MustCompile("^" + jwtClaimNameFmt + "$")
+
+const (
+	jwtClaimValueFmt    = `[^$\s"'\\{};,]+`
+	jwtClaimValueErrMsg = `must not contain whitespace, '$', '"', ''', '\', '{', '}', ';' or ','`
+)
+
+var jwtClaimValueRegexp = regexp.MustCompile("^" + jwtClaimValueFmt + "$")
+
+func validateJWTClaimRequirement(claim v1.JWTClaimRequirement, fieldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if claim.Name == "" {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("name"), ""))
+	} else if !jwtClaimNameRegexp.MatchString(claim.Name) {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("name"), claim.Name, jwtClaimNameErrMsg))
+	}
+
+	if len(claim.Values) == 0 && claim.Regex == "" {
+		return append(allErrs, field.Required(fieldPath, "must specify exactly one of: `values`, `regex`"))
+	}
+	if len(claim.Values) > 0 && claim.Regex != "" {
+		return append(allErrs, field.Forbidden(fieldPath, "must specify exactly one of: `values`, `regex`"))
+	}
+
+	for i, value := range claim.Values {
+		if !jwtClaimValueRegexp.MatchString(value) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("values").Index(i), value, jwtClaimValueErrMsg))
+		}
+	}
+
+	if claim.Regex != "" {
+		allErrs = append(allErrs, validateJWTClaimRegex(claim.Regex, fieldPath.Child("regex"))...)
+	}
+
+	return allErrs
+}
+
+func validateJWTClaimRegex(regex string, fieldPath *field.Path) field.ErrorList {
+	if strings.ContainsAny(regex, `"'`) || strings.HasSuffix(regex, `\`) {
+		return field.ErrorList{field.Invalid(fieldPath, regex, `must not contain '"' or ''' or end with '\'`)}
+	}
+	if _, err := regexp.Compile(regex); err != nil {
+		return field.ErrorList{field.Invalid(fieldPath, regex, fmt.Sprintf("must be a valid regular expression: %v", err))}
+	}
+	return nil
+}
+
 func validateJWTToken(token string, fieldPath *field.Path) field.ErrorList {
 	if token == "" {
 		return nil
@@ -1317,6 +1699,85 @@ func containsDangerousChars(value string) bool {
 	return false
 }
 
+const (
+	headersStatusCodeFmt    = `[1-5]([0-9]{2}|xx)`
+	headersStatusCodeErrMsg = "must be a status code, for example 404, or a class of status codes, for example 5xx"
+)
+
+var headersStatusCodeRegexp = regexp.MustCompile("^" + headersStatusCodeFmt + "$")
+
+func validateHeadersPolicy(headers *v1.Headers, fieldPath *field.Path, isPlus bool) field.ErrorList {
+	if headers.Request == nil && headers.Response == nil {
+		return field.ErrorList{field.Required(fieldPath, "must specify at least one of: `request`, `response`")}
+	}
+
+	allErrs := field.ErrorList{}
+
+	if r := headers.Request; r != nil {
+		reqPath := fieldPath.Child("request")
+		names := sets.Set[string]{}
+		for i, h := range r.Set {
+			allErrs = append(allErrs, validateHeadersPolicyRequestHeaderName(h.Name, reqPath.Child("set").Index(i).Child("name"), names)...)
+			allErrs = append(allErrs, validateEscapedStringWithVariables(h.Value, reqPath.Child("set").Index(i).Child("value"),
+				actionProxyHeaderSpecialVariables, actionProxyHeaderVariables, isPlus)...)
+		}
+		for i, h := range r.Add {
+			allErrs = append(allErrs, validateHeadersPolicyRequestHeaderName(h.Name, reqPath.Child("add").Index(i).Child("name"), names)...)
+			allErrs = append(allErrs, validateEscapedStringWithVariables(h.Value, reqPath.Child("add").Index(i).Child("value"),
+				actionProxyHeaderSpecialVariables, actionProxyHeaderVariables, isPlus)...)
+		}
+		for i, name := range r.Remove {
+			allErrs = append(allErrs, validateHeadersPolicyRequestHeaderName(name, reqPath.Child("remove").Index(i), names)...)
+		}
+	}
+
+	if r := headers.Response; r != nil {
+		respPath := fieldPath.Child("response")
+		names := sets.Set[string]{}
+		for i, h := range r.Set {
+			allErrs = append(allErrs, validateHeadersPolicyResponseHeader(h, respPath.Child("set").Index(i), names, isPlus)...)
+		}
+		for i, h := range r.Add {
+			allErrs = append(allErrs, validateHeadersPolicyResponseHeader(h, respPath.Child("add").Index(i), names, isPlus)...)
+		}
+		for i, name := range r.Remove {
+			allErrs = append(allErrs, validateHeadersPolicyHeaderName(name, respPath.Child("remove").Index(i), names)...)
+		}
+	}
+
+	return allErrs
+}
+
+// validateHeadersPolicyHeaderName validates the name of a header and checks that the header is modified only once.
+func validateHeadersPolicyHeaderName(name string, fieldPath *field.Path, names sets.Set[string]) field.ErrorList {
+	allErrs := validateHeaderName(name, fieldPath)
+	if names.Has(strings.ToLower(name)) {
+		allErrs = append(allErrs, field.Duplicate(fieldPath, name))
+	}
+	names.Insert(strings.ToLower(name))
+	return allErrs
+}
+
+func validateHeadersPolicyRequestHeaderName(name string, fieldPath *field.Path, names sets.Set[string]) field.ErrorList {
+	allErrs := validateHeadersPolicyHeaderName(name, fieldPath, names)
+	if strings.EqualFold(name, "Host") {
+		allErrs = append(allErrs, field.Forbidden(fieldPath, "the Host header can only be set in the requestHeaders of the action proxy"))
+	}
+	return allErrs
+}
+
+func validateHeadersPolicyResponseHeader(h v1.ResponseHeader, fieldPath *field.Path, names sets.Set[string], isPlus bool) field.ErrorList {
+	allErrs := validateHeadersPolicyHeaderName(h.Name, fieldPath.Child("name"), names)
+	allErrs = append(allErrs, validateEscapedStringWithVariables(h.Value, fieldPath.Child("value"),
+		actionProxyHeaderSpecialVariables, actionProxyHeaderVariables, isPlus)...)
+	for i, code := range h.Codes {
+		if !headersStatusCodeRegexp.MatchString(code) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("codes").Index(i), code, headersStatusCodeErrMsg))
+		}
+	}
+	return allErrs
+}
+
 func validateExternalAuth(externalAuth *v1.ExternalAuth, fieldPath *field.Path, enableSnippets bool) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -1360,6 +1821,69 @@ func validateExternalAuth(externalAuth *v1.ExternalAuth, fieldPath *field.Path,
 	// Validate SSL fields
 	allErrs = append(allErrs, validateExternalAuthSSLFields(externalAuth, fieldPath)...)
 
+	if externalAuth.GRPC != nil {
+		allErrs = append(allErrs, validateExternalAuthGRPC(externalAuth.GRPC, fieldPath.Child("grpc"))...)
+	}
+
+	return allErrs
+}
+
+func validateExternalAuthGRPC(grpc *v1.ExternalAuthGRPC, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	headerNames := sets.Set[string]{}
+	for i, h := range grpc.AllowedUpstreamHeaders {
+		idxPath := fieldPath.Child("allowedUpstreamHeaders").Index(i)
+		allErrs = append(allErrs, validateHeaderName(h, idxPath)...)
+		if headerNames.Has(strings.ToLower(h)) {
+			allErrs = append(allErrs, field.Duplicate(idxPath, h))
+		}
+		headerNames.Insert(strings.ToLower(h))
+	}
+
+	return allErrs
+}
+
+func validateIntrospection(introspection *v1.Introspection, fieldPath *field.Path) field.ErrorList {
+	allErrs := validateExternalAuth(configs.IntrospectionExternalAuth(introspection), fieldPath, false)
+
+	if introspection.ClientID == "" {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("clientID"), ""))
+	} else {
+		allErrs = append(allErrs, validateClientID(introspection.ClientID, fieldPath.Child("clientID"))...)
+		if strings.Contains(introspection.ClientID, ":") {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("clientID"), introspection.ClientID, "must not contain ':'"))
+		}
+	}
+
+	if introspection.ClientSecret == "" {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("clientSecret"), ""))
+	} else {
+		allErrs = append(allErrs, validateSecretName(introspection.ClientSecret, fieldPath.Child("clientSecret"))...)
+	}
+
+	if introspection.CacheTimeout != "" {
+		allErrs = append(allErrs, validateTime(introspection.CacheTimeout, fieldPath.Child("cacheTimeout"))...)
+	}
+
+	if introspection.CacheZoneSize != "" {
+		allErrs = append(allErrs, validateSize(introspection.CacheZoneSize, fieldPath.Child("cacheZoneSize"))...)
+	}
+
+	headerNames := sets.Set[string]{}
+	for i, h := range introspection.Headers {
+		idxPath := fieldPath.Child("headers").Index(i)
+		allErrs = append(allErrs, validateHeaderName(h.Name, idxPath.Child("name"))...)
+		if headerNames.Has(strings.ToLower(h.Name)) {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), h.Name))
+		}
+		headerNames.Insert(strings.ToLower(h.Name))
+
+		if !jwtClaimNameRegexp.MatchString(h.Claim) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("claim"), h.Claim, jwtClaimNameErrMsg))
+		}
+	}
+
 	return allErrs
 }
 
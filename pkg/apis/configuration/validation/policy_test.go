@@ -535,6 +535,19 @@ func TestValidatePolicy_PassesOnValidInput(t *testing.T) {
 			cfg: PolicyValidationConfig{IsPlus: true, EnableAppProtect: true},
 			msg: "use WAF(plus only) policy",
 		},
+		{
+			policy: &v1.Policy{
+				Spec: v1.PolicySpec{
+					Geo: &v1.Geo{
+						Deny: &v1.GeoMatch{
+							Countries: []string{"KP"},
+						},
+					},
+				},
+			},
+			cfg: PolicyValidationConfig{},
+			msg: "use geo policy on OSS",
+		},
 	}
 	for _, test := range tests {
 		err := ValidatePolicy(test.policy, test.cfg)
@@ -558,6 +571,20 @@ func TestValidatePolicy_FailsOnInvalidInput(t *testing.T) {
 			cfg: PolicyValidationConfig{},
 			msg: "empty policy spec",
 		},
+		{
+			policy: &v1.Policy{
+				Spec: v1.PolicySpec{
+					Introspection: &v1.Introspection{
+						AuthURI:         "/oauth2/introspect",
+						AuthServiceName: "idp",
+						ClientID:        "client",
+						ClientSecret:    "introspection-secret",
+					},
+				},
+			},
+			cfg: PolicyValidationConfig{},
+			msg: "introspection policy with NGINX",
+		},
 		{
 			policy: &v1.Policy{
 				Spec: v1.PolicySpec{
@@ -765,6 +792,72 @@ func TestValidateAccessControl_FailsOnInvalidInput(t *testing.T) {
 	}
 }
 
+func TestValidateConnectionLimit_PassesOnValidInput(t *testing.T) {
+	t.Parallel()
+	validInput := []*v1.ConnectionLimit{
+		{
+			Connections: 10,
+			ZoneSize:    "10M",
+		},
+		{
+			Connections: 1,
+			ZoneSize:    "64k",
+			DryRun:      new(true),
+			LogLevel:    "warn",
+		},
+	}
+
+	for _, input := range validInput {
+		allErrs := validateConnectionLimit(input, field.NewPath("connectionLimit"))
+		if len(allErrs) > 0 {
+			t.Errorf("validateConnectionLimit(%+v) returned errors %v for valid input", input, allErrs)
+		}
+	}
+}
+
+func TestValidateConnectionLimit_FailsOnInvalidInput(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		connectionLimit *v1.ConnectionLimit
+		msg             string
+	}{
+		{
+			connectionLimit: &v1.ConnectionLimit{
+				ZoneSize: "10M",
+			},
+			msg: "missing connections",
+		},
+		{
+			connectionLimit: &v1.ConnectionLimit{
+				Connections: 10,
+			},
+			msg: "missing zone size",
+		},
+		{
+			connectionLimit: &v1.ConnectionLimit{
+				Connections: 10,
+				ZoneSize:    "10k",
+			},
+			msg: "zone size too small",
+		},
+		{
+			connectionLimit: &v1.ConnectionLimit{
+				Connections: 10,
+				ZoneSize:    "10M",
+				LogLevel:    "debug",
+			},
+			msg: "invalid log level",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateConnectionLimit(test.connectionLimit, field.NewPath("connectionLimit"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateConnectionLimit() returned no errors for invalid input for the case of %s", test.msg)
+		}
+	}
+}
+
 func TestValidateRateLimit_PassesOnValidInput(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -1310,6 +1403,131 @@ func TestValidateRateLimitLogLevel_FailsOnInvalidInput(t *testing.T) {
 	}
 }
 
+func TestValidateJWTRequire_PassesOnValidInput(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		require *v1.JWTRequire
+		msg     string
+	}{
+		{
+			require: &v1.JWTRequire{
+				Claims: []v1.JWTClaimRequirement{
+					{Name: "aud", Values: []string{"api.example.com"}},
+				},
+			},
+			msg: "exact match",
+		},
+		{
+			require: &v1.JWTRequire{
+				Claims: []v1.JWTClaimRequirement{
+					{Name: "realm_access.roles", Values: []string{"admin", "editor"}},
+					{Name: "iss", Regex: "^https://idp\\.example\\.com/realms/[a-z]+$"},
+				},
+				Code: new(401),
+			},
+			msg: "nested claim, any-of match, regex match and custom code",
+		},
+	}
+	for _, test := range tests {
+		allErrs := validateJWTRequire(test.require, field.NewPath("require"))
+		if len(allErrs) != 0 {
+			t.Errorf("validateJWTRequire() returned errors %v for valid input for the case of %v", allErrs, test.msg)
+		}
+	}
+}
+
+func TestValidateJWTRequire_FailsOnInvalidInput(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		require *v1.JWTRequire
+		msg     string
+	}{
+		{
+			require: &v1.JWTRequire{},
+			msg:     "no claims",
+		},
+		{
+			require: &v1.JWTRequire{
+				Claims: []v1.JWTClaimRequirement{
+					{Values: []string{"admin"}},
+				},
+			},
+			msg: "missing claim name",
+		},
+		{
+			require: &v1.JWTRequire{
+				Claims: []v1.JWTClaimRequirement{
+					{Name: "realm_access..roles", Values: []string{"admin"}},
+				},
+			},
+			msg: "empty nested claim name",
+		},
+		{
+			require: &v1.JWTRequire{
+				Claims: []v1.JWTClaimRequirement{
+					{Name: "$roles", Values: []string{"admin"}},
+				},
+			},
+			msg: "invalid claim name",
+		},
+		{
+			require: &v1.JWTRequire{
+				Claims: []v1.JWTClaimRequirement{
+					{Name: "roles"},
+				},
+			},
+			msg: "missing values and regex",
+		},
+		{
+			require: &v1.JWTRequire{
+				Claims: []v1.JWTClaimRequirement{
+					{Name: "roles", Values: []string{"admin"}, Regex: "^admin$"},
+				},
+			},
+			msg: "both values and regex",
+		},
+		{
+			require: &v1.JWTRequire{
+				Claims: []v1.JWTClaimRequirement{
+					{Name: "roles", Values: []string{"admin,editor"}},
+				},
+			},
+			msg: "invalid value",
+		},
+		{
+			require: &v1.JWTRequire{
+				Claims: []v1.JWTClaimRequirement{
+					{Name: "roles", Regex: "(admin"},
+				},
+			},
+			msg: "invalid regex",
+		},
+		{
+			require: &v1.JWTRequire{
+				Claims: []v1.JWTClaimRequirement{
+					{Name: "roles", Regex: `"admin"`},
+				},
+			},
+			msg: "regex with quotes",
+		},
+		{
+			require: &v1.JWTRequire{
+				Claims: []v1.JWTClaimRequirement{
+					{Name: "roles", Values: []string{"admin"}},
+				},
+				Code: new(500),
+			},
+			msg: "invalid code",
+		},
+	}
+	for _, test := range tests {
+		allErrs := validateJWTRequire(test.require, field.NewPath("require"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateJWTRequire() returned no errors for invalid input for the case of %v", test.msg)
+		}
+	}
+}
+
 func TestValidateJWTToken_PassesOnValidInput(t *testing.T) {
 	t.Parallel()
 	validTests := []struct {
@@ -1688,6 +1906,30 @@ func TestValidateOIDC_PassesOnValidOIDC(t *testing.T) {
 			},
 			msg: "no post logout redirect URI",
 		},
+		{
+			oidc: &v1.OIDC{
+				AuthEndpoint:          "http://127.0.0.1:8080/realms/master/protocol/openid-connect/auth",
+				TokenEndpoint:         "http://127.0.0.1:8080/realms/master/protocol/openid-connect/token",
+				JWKSURI:               "http://127.0.0.1:8080/realms/master/protocol/openid-connect/certs",
+				EndSessionEndpoint:    "http://127.0.0.1:8080/realms/master/protocol/openid-connect/logout",
+				PostLogoutRedirectURI: "/_logout",
+				ClientID:              "client",
+				ClientSecret:          "secret",
+				Scope:                 "openid",
+				RedirectURI:           "/admin/_codexch",
+				LogoutURI:             "/admin/logout",
+				SessionCookie:         "admin_token",
+				ClaimHeaders: []v1.OIDCClaimHeader{
+					{Claim: "email", Header: "X-Email"},
+					{Claim: "address.country", Header: "X-Country"},
+				},
+				Groups: &v1.OIDCGroups{
+					Claim: "roles",
+					Allow: []string{"admins", "operators"},
+				},
+			},
+			msg: "custom logout URI, session cookie, claim headers and groups",
+		},
 	}
 
 	for _, test := range tests {
@@ -1873,155 +2115,590 @@ func TestValidateOIDC_FailsOnInvalidOIDC(t *testing.T) {
 				Scope:                 "openid",
 				AccessTokenEnable:     true,
 			},
-			fieldPath: "oidc.clientID",
-			msg:       "invalid chars in clientID",
+			fieldPath: "oidc.clientID",
+			msg:       "invalid chars in clientID",
+		},
+		{
+			oidc: &v1.OIDC{
+				AuthEndpoint:          "http://127.0.0.1:8080/realms/master/protocol/openid-connect/auth",
+				AuthExtraArgs:         []string{"foo;bar"},
+				TokenEndpoint:         "http://127.0.0.1:8080/realms/master/protocol/openid-connect/token",
+				JWKSURI:               "http://127.0.0.1:8080/realms/master/protocol/openid-connect/certs",
+				EndSessionEndpoint:    "http://127.0.0.1:8080/realms/master/protocol/openid-connect/logout",
+				PostLogoutRedirectURI: "/_logout",
+				RedirectURI:           "/_codexch",
+				ClientID:              "foobar",
+				ClientSecret:          "secret",
+				Scope:                 "openid",
+			},
+			fieldPath: "oidc.authExtraArgs",
+			msg:       "invalid chars in authExtraArgs",
+		},
+		{
+			oidc: &v1.OIDC{
+				AuthEndpoint:          "http://127.0.0.1:8080/realms/master/protocol/openid-connect/auth",
+				TokenEndpoint:         "http://127.0.0.1:8080/realms/master/protocol/openid-connect/token",
+				JWKSURI:               "http://127.0.0.1:8080/realms/master/protocol/openid-connect/certs",
+				EndSessionEndpoint:    "http://127.0.0.1:8080/realms/master/protocol/openid-connect/logout",
+				PostLogoutRedirectURI: "/_logout",
+				RedirectURI:           "/_codexch", ClientID: "foobar",
+				ClientSecret:      "secret",
+				Scope:             "openid",
+				ZoneSyncLeeway:    new(-1),
+				AccessTokenEnable: true,
+			},
+			fieldPath: "oidc.zoneSyncLeeway",
+			msg:       "invalid zoneSyncLeeway value",
+		},
+		{
+			oidc: &v1.OIDC{
+				AuthEndpoint:          "http://127.0.0.1:8080/realms/master/protocol/openid-connect/auth",
+				TokenEndpoint:         "http://127.0.0.1:8080/realms/master/protocol/openid-connect/token",
+				JWKSURI:               "http://127.0.0.1:8080/realms/master/protocol/openid-connect/certs",
+				EndSessionEndpoint:    "http://127.0.0.1:8080/realms/master/protocol/openid-connect/logout",
+				PostLogoutRedirectURI: "/_logout",
+				ClientID:              "client",
+				ClientSecret:          "secret",
+				Scope:                 "openid",
+				LogoutURI:             "/_codexch",
+			},
+			fieldPath: "oidc.logoutURI",
+			msg:       "logout URI same as the default redirect URI",
+		},
+		{
+			oidc: &v1.OIDC{
+				AuthEndpoint:          "http://127.0.0.1:8080/realms/master/protocol/openid-connect/auth",
+				TokenEndpoint:         "http://127.0.0.1:8080/realms/master/protocol/openid-connect/token",
+				JWKSURI:               "http://127.0.0.1:8080/realms/master/protocol/openid-connect/certs",
+				EndSessionEndpoint:    "http://127.0.0.1:8080/realms/master/protocol/openid-connect/logout",
+				PostLogoutRedirectURI: "/_logout",
+				ClientID:              "client",
+				ClientSecret:          "secret",
+				Scope:                 "openid",
+				RedirectURI:           "/callback",
+				LogoutURI:             "/callback",
+			},
+			fieldPath: "oidc.logoutURI",
+			msg:       "logout URI same as redirect URI",
+		},
+		{
+			oidc: &v1.OIDC{
+				AuthEndpoint:          "http://127.0.0.1:8080/realms/master/protocol/openid-connect/auth",
+				TokenEndpoint:         "http://127.0.0.1:8080/realms/master/protocol/openid-connect/token",
+				JWKSURI:               "http://127.0.0.1:8080/realms/master/protocol/openid-connect/certs",
+				EndSessionEndpoint:    "http://127.0.0.1:8080/realms/master/protocol/openid-connect/logout",
+				PostLogoutRedirectURI: "/_logout",
+				ClientID:              "client",
+				ClientSecret:          "secret",
+				Scope:                 "openid",
+				SessionCookie:         "auth-token",
+			},
+			fieldPath: "oidc.sessionCookie",
+			msg:       "invalid session cookie",
+		},
+		{
+			oidc: &v1.OIDC{
+				AuthEndpoint:          "http://127.0.0.1:8080/realms/master/protocol/openid-connect/auth",
+				TokenEndpoint:         "http://127.0.0.1:8080/realms/master/protocol/openid-connect/token",
+				JWKSURI:               "http://127.0.0.1:8080/realms/master/protocol/openid-connect/certs",
+				EndSessionEndpoint:    "http://127.0.0.1:8080/realms/master/protocol/openid-connect/logout",
+				PostLogoutRedirectURI: "/_logout",
+				ClientID:              "client",
+				ClientSecret:          "secret",
+				Scope:                 "openid",
+				ClaimHeaders:          []v1.OIDCClaimHeader{{Header: "X-Email"}},
+			},
+			fieldPath: "oidc.claimHeaders[0].claim",
+			msg:       "missing claim of claim header",
+		},
+		{
+			oidc: &v1.OIDC{
+				AuthEndpoint:          "http://127.0.0.1:8080/realms/master/protocol/openid-connect/auth",
+				TokenEndpoint:         "http://127.0.0.1:8080/realms/master/protocol/openid-connect/token",
+				JWKSURI:               "http://127.0.0.1:8080/realms/master/protocol/openid-connect/certs",
+				EndSessionEndpoint:    "http://127.0.0.1:8080/realms/master/protocol/openid-connect/logout",
+				PostLogoutRedirectURI: "/_logout",
+				ClientID:              "client",
+				ClientSecret:          "secret",
+				Scope:                 "openid",
+				ClaimHeaders:          []v1.OIDCClaimHeader{{Claim: "email", Header: "X Email"}},
+			},
+			fieldPath: "oidc.claimHeaders[0].header",
+			msg:       "invalid header of claim header",
+		},
+		{
+			oidc: &v1.OIDC{
+				AuthEndpoint:          "http://127.0.0.1:8080/realms/master/protocol/openid-connect/auth",
+				TokenEndpoint:         "http://127.0.0.1:8080/realms/master/protocol/openid-connect/token",
+				JWKSURI:               "http://127.0.0.1:8080/realms/master/protocol/openid-connect/certs",
+				EndSessionEndpoint:    "http://127.0.0.1:8080/realms/master/protocol/openid-connect/logout",
+				PostLogoutRedirectURI: "/_logout",
+				ClientID:              "client",
+				ClientSecret:          "secret",
+				Scope:                 "openid",
+				Groups:                &v1.OIDCGroups{},
+			},
+			fieldPath: "oidc.groups.allow",
+			msg:       "no allowed groups",
+		},
+		{
+			oidc: &v1.OIDC{
+				AuthEndpoint:          "http://127.0.0.1:8080/realms/master/protocol/openid-connect/auth",
+				TokenEndpoint:         "http://127.0.0.1:8080/realms/master/protocol/openid-connect/token",
+				JWKSURI:               "http://127.0.0.1:8080/realms/master/protocol/openid-connect/certs",
+				EndSessionEndpoint:    "http://127.0.0.1:8080/realms/master/protocol/openid-connect/logout",
+				PostLogoutRedirectURI: "/_logout",
+				ClientID:              "client",
+				ClientSecret:          "secret",
+				Scope:                 "openid",
+				Groups:                &v1.OIDCGroups{Allow: []string{"admins\""}},
+			},
+			fieldPath: "oidc.groups.allow[0]",
+			msg:       "invalid allowed group",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.msg, func(t *testing.T) {
+			t.Parallel()
+			allErrs := validateOIDC(test.oidc, field.NewPath("oidc"))
+			if len(allErrs) == 0 {
+				t.Errorf("validateOIDC() returned no errors for invalid input for the case of %v", test.msg)
+			} else if allErrs[0].Field != test.fieldPath {
+				t.Errorf("validateOIDC() returned error on wrong field for the case of %v, want %v, got %v", test.msg, test.fieldPath, allErrs[0].Field)
+			}
+			t.Log(allErrs)
+		})
+	}
+}
+
+func TestValidateAPIKeyPolicy_PassOnValidInput(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		apiKey *v1.APIKey
+		msg    string
+	}{
+		{
+			apiKey: &v1.APIKey{
+				SuppliedIn: &v1.SuppliedIn{
+					Header: []string{
+						"X-API-Key",
+					},
+				},
+				ClientSecret: "secret",
+			},
+		},
+		{
+			apiKey: &v1.APIKey{
+				SuppliedIn: &v1.SuppliedIn{
+					Header: []string{
+						"X-API-Key",
+					},
+				},
+				ClientSecret:   "secret",
+				ClientIDHeader: "X-Client-Id",
+			},
+			msg: "client ID header",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateAPIKey(test.apiKey, field.NewPath("apiKey"))
+		if len(allErrs) != 0 {
+			t.Errorf("validateAPIKey() returned errors %v for valid input for the case of %v", allErrs, test.msg)
+		}
+	}
+}
+
+func TestValidateAPIKeyPolicy_FailsOnInvalidInput(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		apiKey *v1.APIKey
+		msg    string
+	}{
+		{
+			apiKey: &v1.APIKey{
+				SuppliedIn: &v1.SuppliedIn{
+					Query: []string{
+						"api_key",
+					},
+				},
+			},
+			msg: "missing secret",
+		},
+		{
+			apiKey: &v1.APIKey{
+				SuppliedIn:   &v1.SuppliedIn{},
+				ClientSecret: "secret",
+			},
+			msg: "both  header and query are missing",
+		},
+		{
+			apiKey: &v1.APIKey{
+				SuppliedIn: &v1.SuppliedIn{
+					Header: []string{
+						`api.key"`,
+					},
+				},
+				ClientSecret: "secret",
+			},
+			msg: "invalid header",
+		},
+		{
+			apiKey: &v1.APIKey{
+				SuppliedIn: &v1.SuppliedIn{
+					Query: []string{
+						`api_key\`,
+					},
+				},
+				ClientSecret: "secret",
+			},
+			msg: "invalid query",
+		},
+		{
+			apiKey: &v1.APIKey{
+				SuppliedIn: &v1.SuppliedIn{
+					Query: []string{
+						`api_key`,
+					},
+				},
+				ClientSecret: "secret_1",
+			},
+			msg: "invalid secret name",
+		},
+		{
+			apiKey: &v1.APIKey{
+				ClientSecret: "secret_1",
+			},
+			msg: "no suppliedIn provided",
+		},
+		{
+			apiKey: &v1.APIKey{
+				SuppliedIn: &v1.SuppliedIn{
+					Header: []string{
+						"X-API-Key",
+					},
+				},
+				ClientSecret:   "secret",
+				ClientIDHeader: "X-Client Id",
+			},
+			msg: "invalid client ID header",
+		},
+
+		{
+			apiKey: nil, msg: "no apikey provided",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateAPIKey(test.apiKey, field.NewPath("apiKey"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateAPIKey() returned no errors for invalid input for the case of %v", test.msg)
+		}
+	}
+}
+
+func TestValidateHMACPolicy_PassesOnValidInput(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		hmac *v1.HMAC
+		msg  string
+	}{
+		{
+			hmac: &v1.HMAC{
+				Secret: "hmac-secret",
+			},
+			msg: "only secret",
+		},
+		{
+			hmac: &v1.HMAC{
+				Secret:          "hmac-secret",
+				KeyIDHeader:     "X-Key-Id",
+				SignatureHeader: "X-Hub-Signature-256",
+				TimestampHeader: "X-Request-Timestamp",
+				CanonicalString: "{method}:{uri}:{timestamp}:{body}",
+				ClockSkew:       "30s",
+				MaxBodySize:     "512k",
+				RejectCode:      new(403),
+			},
+			msg: "all fields",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateHMAC(test.hmac, field.NewPath("hmac"))
+		if len(allErrs) != 0 {
+			t.Errorf("validateHMAC() returned errors %v for valid input for the case of %v", allErrs, test.msg)
+		}
+	}
+}
+
+func TestValidateHMACPolicy_FailsOnInvalidInput(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		hmac *v1.HMAC
+		msg  string
+	}{
+		{
+			hmac: &v1.HMAC{},
+			msg:  "missing secret",
+		},
+		{
+			hmac: &v1.HMAC{
+				Secret: "hmac_secret",
+			},
+			msg: "invalid secret name",
+		},
+		{
+			hmac: &v1.HMAC{
+				Secret:          "hmac-secret",
+				SignatureHeader: "X Signature",
+			},
+			msg: "invalid signature header",
+		},
+		{
+			hmac: &v1.HMAC{
+				Secret:          "hmac-secret",
+				CanonicalString: "{timestamp}.{payload}",
+			},
+			msg: "canonical string with an unsupported placeholder",
+		},
+		{
+			hmac: &v1.HMAC{
+				Secret:          "hmac-secret",
+				CanonicalString: "{method}:{uri}:{timestamp}",
+			},
+			msg: "canonical string without body",
+		},
+		{
+			hmac: &v1.HMAC{
+				Secret:          "hmac-secret",
+				CanonicalString: "$host:{body}",
+			},
+			msg: "canonical string with a variable",
+		},
+		{
+			hmac: &v1.HMAC{
+				Secret:    "hmac-secret",
+				ClockSkew: "5 minutes",
+			},
+			msg: "invalid clock skew",
+		},
+		{
+			hmac: &v1.HMAC{
+				Secret:      "hmac-secret",
+				MaxBodySize: "1g",
+			},
+			msg: "invalid max body size",
+		},
+		{
+			hmac: &v1.HMAC{
+				Secret:     "hmac-secret",
+				RejectCode: new(302),
+			},
+			msg: "invalid reject code",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateHMAC(test.hmac, field.NewPath("hmac"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateHMAC() returned no errors for invalid input for the case of %v", test.msg)
+		}
+	}
+}
+
+func TestValidateGeoPolicy_PassesOnValidInput(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		geo *v1.Geo
+		msg string
+	}{
+		{
+			geo: &v1.Geo{
+				Deny: &v1.GeoMatch{
+					Countries: []string{"KP", "IR"},
+				},
+			},
+			msg: "deny countries",
+		},
+		{
+			geo: &v1.Geo{
+				Allow: &v1.GeoMatch{
+					Countries:  []string{"US"},
+					Continents: []string{"EU"},
+					ASNs:       []int{13335},
+				},
+				RejectCode: new(451),
+			},
+			msg: "allow all lists with reject code",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateGeo(test.geo, field.NewPath("geo"))
+		if len(allErrs) != 0 {
+			t.Errorf("validateGeo() returned errors %v for valid input for the case of %v", allErrs, test.msg)
+		}
+	}
+}
+
+func TestValidateGeoPolicy_FailsOnInvalidInput(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		geo *v1.Geo
+		msg string
+	}{
+		{
+			geo: &v1.Geo{},
+			msg: "neither allow nor deny",
+		},
+		{
+			geo: &v1.Geo{
+				Allow: &v1.GeoMatch{Countries: []string{"US"}},
+				Deny:  &v1.GeoMatch{Countries: []string{"CA"}},
+			},
+			msg: "both allow and deny",
+		},
+		{
+			geo: &v1.Geo{
+				Deny: &v1.GeoMatch{},
+			},
+			msg: "empty deny",
+		},
+		{
+			geo: &v1.Geo{
+				Deny: &v1.GeoMatch{Countries: []string{"us"}},
+			},
+			msg: "lower case country",
+		},
+		{
+			geo: &v1.Geo{
+				Deny: &v1.GeoMatch{Countries: []string{"USA"}},
+			},
+			msg: "alpha-3 country",
 		},
 		{
-			oidc: &v1.OIDC{
-				AuthEndpoint:          "http://127.0.0.1:8080/realms/master/protocol/openid-connect/auth",
-				AuthExtraArgs:         []string{"foo;bar"},
-				TokenEndpoint:         "http://127.0.0.1:8080/realms/master/protocol/openid-connect/token",
-				JWKSURI:               "http://127.0.0.1:8080/realms/master/protocol/openid-connect/certs",
-				EndSessionEndpoint:    "http://127.0.0.1:8080/realms/master/protocol/openid-connect/logout",
-				PostLogoutRedirectURI: "/_logout",
-				RedirectURI:           "/_codexch",
-				ClientID:              "foobar",
-				ClientSecret:          "secret",
-				Scope:                 "openid",
+			geo: &v1.Geo{
+				Deny: &v1.GeoMatch{Continents: []string{"Europe"}},
 			},
-			fieldPath: "oidc.authExtraArgs",
-			msg:       "invalid chars in authExtraArgs",
+			msg: "invalid continent",
 		},
 		{
-			oidc: &v1.OIDC{
-				AuthEndpoint:          "http://127.0.0.1:8080/realms/master/protocol/openid-connect/auth",
-				TokenEndpoint:         "http://127.0.0.1:8080/realms/master/protocol/openid-connect/token",
-				JWKSURI:               "http://127.0.0.1:8080/realms/master/protocol/openid-connect/certs",
-				EndSessionEndpoint:    "http://127.0.0.1:8080/realms/master/protocol/openid-connect/logout",
-				PostLogoutRedirectURI: "/_logout",
-				RedirectURI:           "/_codexch", ClientID: "foobar",
-				ClientSecret:      "secret",
-				Scope:             "openid",
-				ZoneSyncLeeway:    new(-1),
-				AccessTokenEnable: true,
+			geo: &v1.Geo{
+				Deny: &v1.GeoMatch{ASNs: []int{0}},
 			},
-			fieldPath: "oidc.zoneSyncLeeway",
-			msg:       "invalid zoneSyncLeeway value",
+			msg: "invalid asn",
+		},
+		{
+			geo: &v1.Geo{
+				Deny:       &v1.GeoMatch{Countries: []string{"US"}},
+				RejectCode: new(302),
+			},
+			msg: "invalid reject code",
 		},
 	}
 
 	for _, test := range tests {
-		t.Run(test.msg, func(t *testing.T) {
-			t.Parallel()
-			allErrs := validateOIDC(test.oidc, field.NewPath("oidc"))
-			if len(allErrs) == 0 {
-				t.Errorf("validateOIDC() returned no errors for invalid input for the case of %v", test.msg)
-			} else if allErrs[0].Field != test.fieldPath {
-				t.Errorf("validateOIDC() returned error on wrong field for the case of %v, want %v, got %v", test.msg, test.fieldPath, allErrs[0].Field)
-			}
-			t.Log(allErrs)
-		})
+		allErrs := validateGeo(test.geo, field.NewPath("geo"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateGeo() returned no errors for invalid input for the case of %v", test.msg)
+		}
 	}
 }
 
-func TestValidateAPIKeyPolicy_PassOnValidInput(t *testing.T) {
+func TestValidateTracingPolicy_PassesOnValidInput(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
-		apiKey *v1.APIKey
-		msg    string
+		tracing *v1.Tracing
+		msg     string
 	}{
 		{
-			apiKey: &v1.APIKey{
-				SuppliedIn: &v1.SuppliedIn{
-					Header: []string{
-						"X-API-Key",
-					},
+			tracing: &v1.Tracing{},
+			msg:     "empty tracing",
+		},
+		{
+			tracing: &v1.Tracing{
+				SampleRatio: "12.5%",
+				SpanName:    "${request_method} ${request_uri}",
+				SpanAttributes: []v1.TracingSpanAttribute{
+					{Name: "app.tenant", Value: "${http_x_tenant}"},
+					{Name: "team", Value: "payments"},
 				},
-				ClientSecret: "secret",
+				Propagation: "b3",
+			},
+			msg: "all fields",
+		},
+		{
+			tracing: &v1.Tracing{
+				SampleRatio: "100%",
+				Propagation: "extract",
 			},
+			msg: "full sample ratio",
 		},
 	}
 
 	for _, test := range tests {
-		allErrs := validateAPIKey(test.apiKey, field.NewPath("apiKey"))
+		allErrs := validateTracing(test.tracing, field.NewPath("tracing"), false)
 		if len(allErrs) != 0 {
-			t.Errorf("validateAPIKey() returned errors %v for valid input for the case of %v", allErrs, test.msg)
+			t.Errorf("validateTracing() returned errors %v for valid input for the case of %v", allErrs, test.msg)
 		}
 	}
 }
 
-func TestValidateAPIKeyPolicy_FailsOnInvalidInput(t *testing.T) {
+func TestValidateTracingPolicy_FailsOnInvalidInput(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
-		apiKey *v1.APIKey
-		msg    string
+		tracing *v1.Tracing
+		msg     string
 	}{
 		{
-			apiKey: &v1.APIKey{
-				SuppliedIn: &v1.SuppliedIn{
-					Query: []string{
-						"api_key",
-					},
-				},
-			},
-			msg: "missing secret",
+			tracing: &v1.Tracing{SampleRatio: "10"},
+			msg:     "sample ratio without percent",
 		},
 		{
-			apiKey: &v1.APIKey{
-				SuppliedIn:   &v1.SuppliedIn{},
-				ClientSecret: "secret",
-			},
-			msg: "both  header and query are missing",
+			tracing: &v1.Tracing{SampleRatio: "101%"},
+			msg:     "sample ratio above 100",
 		},
 		{
-			apiKey: &v1.APIKey{
-				SuppliedIn: &v1.SuppliedIn{
-					Header: []string{
-						`api.key"`,
-					},
-				},
-				ClientSecret: "secret",
-			},
-			msg: "invalid header",
+			tracing: &v1.Tracing{SpanName: "${unknown_variable}"},
+			msg:     "span name with unsupported variable",
 		},
 		{
-			apiKey: &v1.APIKey{
-				SuppliedIn: &v1.SuppliedIn{
-					Query: []string{
-						`api_key\`,
-					},
+			tracing: &v1.Tracing{
+				SpanAttributes: []v1.TracingSpanAttribute{
+					{Name: "1tenant", Value: "a"},
 				},
-				ClientSecret: "secret",
 			},
-			msg: "invalid query",
+			msg: "invalid span attribute name",
 		},
 		{
-			apiKey: &v1.APIKey{
-				SuppliedIn: &v1.SuppliedIn{
-					Query: []string{
-						`api_key`,
-					},
+			tracing: &v1.Tracing{
+				SpanAttributes: []v1.TracingSpanAttribute{
+					{Name: "tenant", Value: "a"},
+					{Name: "tenant", Value: "b"},
 				},
-				ClientSecret: "secret_1",
 			},
-			msg: "invalid secret name",
+			msg: "duplicate span attribute name",
 		},
 		{
-			apiKey: &v1.APIKey{
-				ClientSecret: "secret_1",
+			tracing: &v1.Tracing{
+				SpanAttributes: []v1.TracingSpanAttribute{
+					{Name: "tenant", Value: `a"b`},
+				},
 			},
-			msg: "no suppliedIn provided",
+			msg: "unescaped quote in span attribute value",
 		},
-
 		{
-			apiKey: nil, msg: "no apikey provided",
+			tracing: &v1.Tracing{Propagation: "jaeger"},
+			msg:     "unsupported propagation",
 		},
 	}
 
 	for _, test := range tests {
-		allErrs := validateAPIKey(test.apiKey, field.NewPath("apiKey"))
+		allErrs := validateTracing(test.tracing, field.NewPath("tracing"), false)
 		if len(allErrs) == 0 {
-			t.Errorf("validateAPIKey() returned no errors for invalid input for the case of %v", test.msg)
+			t.Errorf("validateTracing() returned no errors for invalid input for the case of %v", test.msg)
 		}
 	}
 }
@@ -3499,6 +4176,26 @@ func TestValidateExternalAuth_PassesOnValidInput(t *testing.T) {
 			},
 			msg: "authSigninRedirectBasePath with valid path should pass",
 		},
+		{
+			name: "valid grpc with allowedUpstreamHeaders",
+			externalAuth: &v1.ExternalAuth{
+				AuthURI:         "/",
+				AuthServiceName: "opa",
+				GRPC: &v1.ExternalAuthGRPC{
+					AllowedUpstreamHeaders: []string{"X-User", "X-Roles"},
+				},
+			},
+			msg: "grpc mode with allowed upstream headers",
+		},
+		{
+			name: "valid grpc without allowedUpstreamHeaders",
+			externalAuth: &v1.ExternalAuth{
+				AuthURI:         "/",
+				AuthServiceName: "opa",
+				GRPC:            &v1.ExternalAuthGRPC{},
+			},
+			msg: "grpc mode without allowed upstream headers",
+		},
 	}
 
 	for _, test := range tests {
@@ -3672,6 +4369,30 @@ func TestValidateExternalAuth_FailsOnInvalidInput(t *testing.T) {
 			msg:      "authSigninRedirectBasePath with curly braces should fail",
 			errCount: 1,
 		},
+		{
+			name: "invalid grpc allowedUpstreamHeaders name",
+			externalAuth: &v1.ExternalAuth{
+				AuthURI:         "/",
+				AuthServiceName: "opa",
+				GRPC: &v1.ExternalAuthGRPC{
+					AllowedUpstreamHeaders: []string{"X User"},
+				},
+			},
+			msg:      "grpc allowedUpstreamHeaders with an invalid header name should fail",
+			errCount: 1,
+		},
+		{
+			name: "duplicate grpc allowedUpstreamHeaders",
+			externalAuth: &v1.ExternalAuth{
+				AuthURI:         "/",
+				AuthServiceName: "opa",
+				GRPC: &v1.ExternalAuthGRPC{
+					AllowedUpstreamHeaders: []string{"X-User", "x-user"},
+				},
+			},
+			msg:      "grpc allowedUpstreamHeaders with duplicate header names should fail",
+			errCount: 1,
+		},
 	}
 
 	for _, test := range tests {
@@ -3689,6 +4410,253 @@ func TestValidateExternalAuth_FailsOnInvalidInput(t *testing.T) {
 	}
 }
 
+func TestValidateIntrospection_PassesOnValidInput(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		introspection *v1.Introspection
+		msg           string
+	}{
+		{
+			introspection: &v1.Introspection{
+				AuthURI:         "/oauth2/introspect",
+				AuthServiceName: "idp",
+				ClientID:        "client",
+				ClientSecret:    "introspection-secret",
+			},
+			msg: "required fields only",
+		},
+		{
+			introspection: &v1.Introspection{
+				AuthURI:          "/oauth2/introspect",
+				AuthServiceName:  "idp-ns/idp",
+				AuthServicePorts: []int{8443},
+				ClientID:         "client",
+				ClientSecret:     "introspection-secret",
+				CacheTimeout:     "30s",
+				CacheZoneSize:    "10m",
+				Headers: []v1.IntrospectionHeader{
+					{Name: "X-User", Claim: "sub"},
+					{Name: "X-Roles", Claim: "realm_access.roles"},
+				},
+				SSLEnabled:        true,
+				SSLVerify:         true,
+				SSLVerifyDepth:    new(2),
+				TrustedCertSecret: "idp-ns/idp-ca",
+				SNIName:           "idp.example.com",
+			},
+			msg: "all fields",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateIntrospection(test.introspection, field.NewPath("introspection"))
+		if len(allErrs) > 0 {
+			t.Errorf("validateIntrospection() returned errors %v for valid input for the case of %v", allErrs, test.msg)
+		}
+	}
+}
+
+func TestValidateIntrospection_FailsOnInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	valid := v1.Introspection{
+		AuthURI:         "/oauth2/introspect",
+		AuthServiceName: "idp",
+		ClientID:        "client",
+		ClientSecret:    "introspection-secret",
+	}
+
+	tests := []struct {
+		modify func(*v1.Introspection)
+		msg    string
+	}{
+		{
+			modify: func(i *v1.Introspection) { i.AuthURI = "" },
+			msg:    "missing authURI",
+		},
+		{
+			modify: func(i *v1.Introspection) { i.AuthServiceName = "Invalid_Service" },
+			msg:    "invalid authServiceName",
+		},
+		{
+			modify: func(i *v1.Introspection) { i.ClientID = "" },
+			msg:    "missing clientID",
+		},
+		{
+			modify: func(i *v1.Introspection) { i.ClientID = "client:id" },
+			msg:    "clientID with a colon",
+		},
+		{
+			modify: func(i *v1.Introspection) { i.ClientSecret = "" },
+			msg:    "missing clientSecret",
+		},
+		{
+			modify: func(i *v1.Introspection) { i.ClientSecret = "Invalid_Secret" },
+			msg:    "invalid clientSecret",
+		},
+		{
+			modify: func(i *v1.Introspection) { i.CacheTimeout = "1 minute" },
+			msg:    "invalid cacheTimeout",
+		},
+		{
+			modify: func(i *v1.Introspection) { i.CacheZoneSize = "1g" },
+			msg:    "invalid cacheZoneSize",
+		},
+		{
+			modify: func(i *v1.Introspection) { i.Headers = []v1.IntrospectionHeader{{Name: "X User", Claim: "sub"}} },
+			msg:    "invalid header name",
+		},
+		{
+			modify: func(i *v1.Introspection) {
+				i.Headers = []v1.IntrospectionHeader{{Name: "X-User", Claim: "sub"}, {Name: "x-user", Claim: "email"}}
+			},
+			msg: "duplicate header name",
+		},
+		{
+			modify: func(i *v1.Introspection) { i.Headers = []v1.IntrospectionHeader{{Name: "X-User", Claim: "$sub"}} },
+			msg:    "invalid claim",
+		},
+		{
+			modify: func(i *v1.Introspection) { i.SSLVerify = true },
+			msg:    "sslVerify without sslEnabled",
+		},
+	}
+
+	for _, test := range tests {
+		introspection := valid
+		test.modify(&introspection)
+		allErrs := validateIntrospection(&introspection, field.NewPath("introspection"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateIntrospection() returned no errors for invalid input for the case of %v", test.msg)
+		}
+	}
+}
+
+func TestValidateHeadersPolicy_PassesOnValidInput(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		headers *v1.Headers
+		msg     string
+	}{
+		{
+			headers: &v1.Headers{
+				Request: &v1.RequestHeadersModifier{
+					Set:    []v1.Header{{Name: "X-Forwarded-Client", Value: "${remote_addr}"}},
+					Add:    []v1.Header{{Name: "X-Tags", Value: "internal"}},
+					Remove: []string{"X-Debug"},
+				},
+			},
+			msg: "request headers",
+		},
+		{
+			headers: &v1.Headers{
+				Response: &v1.ResponseHeadersModifier{
+					Set: []v1.ResponseHeader{
+						{Header: v1.Header{Name: "X-Frame-Options", Value: "DENY"}, Always: true},
+						{Header: v1.Header{Name: "Cache-Control", Value: "no-store"}, Codes: []string{"404", "5xx"}},
+					},
+					Add:    []v1.ResponseHeader{{Header: v1.Header{Name: "Content-Security-Policy", Value: "default-src 'self'"}}},
+					Remove: []string{"Server"},
+				},
+			},
+			msg: "response headers",
+		},
+		{
+			headers: &v1.Headers{
+				Request:  &v1.RequestHeadersModifier{Set: []v1.Header{{Name: "X-User", Value: "${http_x_user}"}}},
+				Response: &v1.ResponseHeadersModifier{Set: []v1.ResponseHeader{{Header: v1.Header{Name: "X-User", Value: "${http_x_user}"}}}},
+			},
+			msg: "same header in request and response",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateHeadersPolicy(test.headers, field.NewPath("headers"), false)
+		if len(allErrs) > 0 {
+			t.Errorf("validateHeadersPolicy() returned errors %v for valid input for the case of %v", allErrs, test.msg)
+		}
+	}
+}
+
+func TestValidateHeadersPolicy_FailsOnInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		headers *v1.Headers
+		msg     string
+	}{
+		{
+			headers: &v1.Headers{},
+			msg:     "no request or response",
+		},
+		{
+			headers: &v1.Headers{
+				Request: &v1.RequestHeadersModifier{Set: []v1.Header{{Name: "X User", Value: "value"}}},
+			},
+			msg: "invalid request header name",
+		},
+		{
+			headers: &v1.Headers{
+				Request: &v1.RequestHeadersModifier{Set: []v1.Header{{Name: "X-User", Value: "$remote_addr"}}},
+			},
+			msg: "variable without curly braces",
+		},
+		{
+			headers: &v1.Headers{
+				Request: &v1.RequestHeadersModifier{Add: []v1.Header{{Name: "X-User", Value: "${unknown}"}}},
+			},
+			msg: "unknown variable",
+		},
+		{
+			headers: &v1.Headers{
+				Request: &v1.RequestHeadersModifier{
+					Set:    []v1.Header{{Name: "X-User", Value: "value"}},
+					Remove: []string{"x-user"},
+				},
+			},
+			msg: "request header set and removed",
+		},
+		{
+			headers: &v1.Headers{
+				Request: &v1.RequestHeadersModifier{Set: []v1.Header{{Name: "Host", Value: "example.com"}}},
+			},
+			msg: "Host request header",
+		},
+		{
+			headers: &v1.Headers{
+				Response: &v1.ResponseHeadersModifier{
+					Set: []v1.ResponseHeader{{Header: v1.Header{Name: "X-Frame-Options", Value: "DENY"}}},
+					Add: []v1.ResponseHeader{{Header: v1.Header{Name: "X-Frame-Options", Value: "SAMEORIGIN"}}},
+				},
+			},
+			msg: "response header set and added",
+		},
+		{
+			headers: &v1.Headers{
+				Response: &v1.ResponseHeadersModifier{
+					Set: []v1.ResponseHeader{{Header: v1.Header{Name: "Cache-Control", Value: "no-store"}, Codes: []string{"4x"}}},
+				},
+			},
+			msg: "invalid status code",
+		},
+		{
+			headers: &v1.Headers{
+				Response: &v1.ResponseHeadersModifier{Remove: []string{"Server;"}},
+			},
+			msg: "invalid removed response header name",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateHeadersPolicy(test.headers, field.NewPath("headers"), false)
+		if len(allErrs) == 0 {
+			t.Errorf("validateHeadersPolicy() returned no errors for invalid input for the case of %v", test.msg)
+		}
+	}
+}
+
 func TestValidateExternalAuth_EdgeCases(t *testing.T) {
 	t.Parallel()
 
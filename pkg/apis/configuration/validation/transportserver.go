@@ -31,6 +31,7 @@ func NewTransportServerValidator(tlsPassthrough bool, snippetsEnabled bool, isPl
 // ValidateTransportServer validates a TransportServer.
 func (tsv *TransportServerValidator) ValidateTransportServer(transportServer *conf_v1.TransportServer) error {
 	allErrs := tsv.validateTransportServerSpec(&transportServer.Spec, field.NewPath("spec"))
+	allErrs = append(allErrs, validatePolicies(transportServer.Spec.Policies, field.NewPath("spec").Child("policies"), transportServer.Namespace)...)
 	return allErrs.ToAggregate()
 }
 
@@ -76,18 +77,43 @@ func validateTLS(
 		return nil
 	}
 
-	if hostSpecified {
-		if tls == nil || tls.Secret == "" {
-			return field.ErrorList{field.Required(fieldPath, "must specify spec.tls.secret when host is specified, and the TransportServer is not using the TLS Passthrough listener")}
-		}
-		return validateSecretName(tls.Secret, fieldPath.Child("secret"))
+	if hostSpecified && (tls == nil || tls.Secret == "") {
+		return field.ErrorList{field.Required(fieldPath, "must specify spec.tls.secret when host is specified, and the TransportServer is not using the TLS Passthrough listener")}
 	}
 
-	if tls != nil && tls.Secret != "" {
-		return validateSecretName(tls.Secret, fieldPath.Child("secret"))
+	if tls == nil {
+		return nil
 	}
 
-	return nil
+	var allErrs field.ErrorList
+	if tls.Secret != "" {
+		allErrs = append(allErrs, validateSecretName(tls.Secret, fieldPath.Child("secret"))...)
+	}
+
+	if tls.MTLS != nil {
+		if tls.Secret == "" {
+			allErrs = append(allErrs, field.Required(fieldPath.Child("secret"), "must specify spec.tls.secret when mtls is specified"))
+		}
+		allErrs = append(allErrs, validateTransportServerMTLS(tls.MTLS, fieldPath.Child("mtls"))...)
+	}
+
+	return allErrs
+}
+
+func validateTransportServerMTLS(mtls *conf_v1.TransportServerMTLS, fieldPath *field.Path) field.ErrorList {
+	if mtls.ClientCertSecret == "" {
+		return field.ErrorList{field.Required(fieldPath.Child("clientCertSecret"), "")}
+	}
+	allErrs := validateSecretName(mtls.ClientCertSecret, fieldPath.Child("clientCertSecret"))
+	if mtls.VerifyClient == "off" {
+		allErrs = append(allErrs, field.NotSupported(fieldPath.Child("verifyClient"), mtls.VerifyClient, []string{"on", "optional", "optional_no_ca"}))
+	} else {
+		allErrs = append(allErrs, validateIngressMTLSVerifyClient(mtls.VerifyClient, fieldPath.Child("verifyClient"))...)
+	}
+	if mtls.VerifyDepth != nil {
+		allErrs = append(allErrs, validatePositiveIntOrZero(*mtls.VerifyDepth, fieldPath.Child("verifyDepth"))...)
+	}
+	return allErrs
 }
 
 func validateSnippets(serverSnippet string, fieldPath *field.Path, snippetsEnabled bool) field.ErrorList {
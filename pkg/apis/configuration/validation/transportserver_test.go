@@ -78,6 +78,41 @@ func TestValidateTransportServer_BackupService(t *testing.T) {
 	}
 }
 
+func TestValidateTransportServer_Policies(t *testing.T) {
+	t.Parallel()
+
+	ts := makeTransportServer()
+	ts.Spec.Policies = []conf_v1.PolicyReference{
+		{Name: "allow-list"},
+		{Name: "connection-limit", Namespace: "policies"},
+	}
+
+	tsv := createTransportServerValidator()
+
+	err := tsv.ValidateTransportServer(&ts)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestValidateTransportServer_FailsOnDuplicatePolicies(t *testing.T) {
+	t.Parallel()
+
+	ts := makeTransportServer()
+	ts.Namespace = "default"
+	ts.Spec.Policies = []conf_v1.PolicyReference{
+		{Name: "allow-list"},
+		{Name: "allow-list", Namespace: "default"},
+	}
+
+	tsv := createTransportServerValidator()
+
+	err := tsv.ValidateTransportServer(&ts)
+	if err == nil {
+		t.Error("want error on duplicate policies")
+	}
+}
+
 func TestValidateTransportServer_FailsOnMissingBackupName(t *testing.T) {
 	t.Parallel()
 
@@ -1151,6 +1186,29 @@ func TestValidateTsTLS(t *testing.T) {
 			isTLSPassthrough: false,
 			hostSpecified:    false,
 		},
+		{
+			tls: &conf_v1.TransportServerTLS{
+				Secret: "my-secret",
+				MTLS: &conf_v1.TransportServerMTLS{
+					ClientCertSecret: "my-ca-secret",
+				},
+			},
+			isTLSPassthrough: false,
+			hostSpecified:    true,
+		},
+		{
+			tls: &conf_v1.TransportServerTLS{
+				Secret: "my-secret",
+				MTLS: &conf_v1.TransportServerMTLS{
+					ClientCertSecret: "my-ca-secret",
+					CrlFileName:      "default-my-ca-secret-ca.crl",
+					VerifyClient:     "optional",
+					VerifyDepth:      new(2),
+				},
+			},
+			isTLSPassthrough: false,
+			hostSpecified:    false,
+		},
 	}
 
 	for _, tc := range validTestCases {
@@ -1196,6 +1254,45 @@ func TestValidateTsTLS_FailsOnInvalidInput(t *testing.T) {
 			isTLSPassthrough: false,
 			hostSpecified:    true,
 		},
+		{
+			tls: &conf_v1.TransportServerTLS{
+				MTLS: &conf_v1.TransportServerMTLS{
+					ClientCertSecret: "my-ca-secret",
+				},
+			},
+			isTLSPassthrough: false,
+			hostSpecified:    false,
+		},
+		{
+			tls: &conf_v1.TransportServerTLS{
+				Secret: "my-secret",
+				MTLS:   &conf_v1.TransportServerMTLS{},
+			},
+			isTLSPassthrough: false,
+			hostSpecified:    true,
+		},
+		{
+			tls: &conf_v1.TransportServerTLS{
+				Secret: "my-secret",
+				MTLS: &conf_v1.TransportServerMTLS{
+					ClientCertSecret: "my-ca-secret",
+					VerifyClient:     "off",
+				},
+			},
+			isTLSPassthrough: false,
+			hostSpecified:    true,
+		},
+		{
+			tls: &conf_v1.TransportServerTLS{
+				Secret: "my-secret",
+				MTLS: &conf_v1.TransportServerMTLS{
+					ClientCertSecret: "my-ca-secret",
+					VerifyDepth:      new(-1),
+				},
+			},
+			isTLSPassthrough: false,
+			hostSpecified:    true,
+		},
 	}
 
 	for _, test := range invalidTLSes {
@@ -403,6 +403,25 @@ func validateUpstreamHealthCheck(hc *v1.HealthCheck, typeName string, fieldPath
 	return allErrs
 }
 
+func validateOutlierDetection(od *v1.OutlierDetection, fieldPath *field.Path, isPlus bool) field.ErrorList {
+	if od == nil {
+		return nil
+	}
+
+	allErrs := validatePositiveIntOrZero(od.ConsecutiveErrors, fieldPath.Child("consecutiveErrors"))
+	allErrs = append(allErrs, validateTime(od.EjectionTime, fieldPath.Child("ejectionTime"))...)
+
+	if od.MaxEjectionPercent != nil {
+		if isPlus {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("maxEjectionPercent"), "is not supported with NGINX Plus, because the servers are ejected by the active health checks of NGINX Plus"))
+		} else if *od.MaxEjectionPercent < 0 || *od.MaxEjectionPercent > 100 {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("maxEjectionPercent"), *od.MaxEjectionPercent, "must be in the range 0..100"))
+		}
+	}
+
+	return allErrs
+}
+
 func validateGrpcHealthCheck(hc *v1.HealthCheck, typeName string, fieldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -656,6 +675,7 @@ func (vsv *VirtualServerValidator) validateUpstreams(upstreams []v1.Upstream, fi
 		allErrs = append(allErrs, validatePositiveIntOrZeroFromPointer(u.MaxConns, idxPath.Child("max-conns"))...)
 		allErrs = append(allErrs, validateOffset(u.ClientMaxBodySize, idxPath.Child("client-max-body-size"))...)
 		allErrs = append(allErrs, validateUpstreamHealthCheck(u.HealthCheck, u.Type, idxPath.Child("healthCheck"))...)
+		allErrs = append(allErrs, validateOutlierDetection(u.OutlierDetection, idxPath.Child("outlierDetection"), vsv.isPlus)...)
 		allErrs = append(allErrs, validateTime(u.SlowStart, idxPath.Child("slow-start"))...)
 		allErrs = append(allErrs, validateBuffer(u.ProxyBuffers, idxPath.Child("buffers"))...)
 		allErrs = append(allErrs, validateSize(u.ProxyBufferSize, idxPath.Child("buffer-size"))...)
@@ -919,6 +939,49 @@ func (vsv *VirtualServerValidator) validateRoute(route v1.Route, fieldPath *fiel
 
 	allErrs = append(allErrs, validateDos(vsv.isDosEnabled, route.Dos, fieldPath.Child("dos"))...)
 
+	if route.Rollout != nil {
+		if isRouteFieldForbidden {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("rollout"), "is not allowed in VirtualServerRoute"))
+		} else {
+			allErrs = append(allErrs, vsv.validateRollout(route.Rollout, route.Splits, fieldPath.Child("rollout"))...)
+		}
+	}
+
+	return allErrs
+}
+
+func (vsv *VirtualServerValidator) validateRollout(rollout *v1.Rollout, splits []v1.Split, fieldPath *field.Path) field.ErrorList {
+	if !vsv.isPlus {
+		return field.ErrorList{field.Forbidden(fieldPath, "rollout is only supported in NGINX Plus")}
+	}
+
+	allErrs := field.ErrorList{}
+
+	if len(splits) != 2 {
+		allErrs = append(allErrs, field.Invalid(fieldPath, "", "requires exactly 2 splits"))
+	}
+
+	if len(rollout.Steps) == 0 {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("steps"), "must include at least one step"))
+	}
+
+	for i, step := range rollout.Steps {
+		idxPath := fieldPath.Child("steps").Index(i)
+		if step.Weight < 0 || step.Weight > 100 {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("weight"), step.Weight, "must be in the range 0..100"))
+		}
+		if step.Duration == "" && i != len(rollout.Steps)-1 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("duration"), "is required for all steps except the last one"))
+		}
+		allErrs = append(allErrs, validateTime(step.Duration, idxPath.Child("duration"))...)
+	}
+
+	if rollout.MaxErrorRate != nil && (*rollout.MaxErrorRate < 0 || *rollout.MaxErrorRate > 100) {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("maxErrorRate"), *rollout.MaxErrorRate, "must be in the range 0..100"))
+	}
+
+	allErrs = append(allErrs, validateTime(rollout.MaxResponseTime, fieldPath.Child("maxResponseTime"))...)
+
 	return allErrs
 }
 
@@ -1203,6 +1266,64 @@ func (vsv *VirtualServerValidator) validateActionProxy(p *v1.ActionProxy, fieldP
 		allErrs = append(allErrs, validateActionProxyRewritePath(p.RewritePath, fieldPath.Child("rewritePath"))...)
 	}
 
+	if p.Mirror != nil {
+		allErrs = append(allErrs, validateActionMirror(p.Mirror, fieldPath.Child("mirror"), upstreamNames)...)
+	}
+
+	if p.Retry != nil {
+		allErrs = append(allErrs, validateRetryPolicy(p.Retry, fieldPath.Child("retry"))...)
+	}
+
+	return allErrs
+}
+
+var validRetryConditions = map[string]bool{
+	"error":          true,
+	"timeout":        true,
+	"5xx":            true,
+	"gateway-error":  true,
+	"non-idempotent": true,
+}
+
+func validateRetryPolicy(r *v1.RetryPolicy, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	conditions := sets.Set[string]{}
+	for i, c := range r.Conditions {
+		idxPath := fieldPath.Child("conditions").Index(i)
+		if !validRetryConditions[c] {
+			allErrs = append(allErrs, field.NotSupported(idxPath, c, sets.List(sets.KeySet(validRetryConditions))))
+		} else if conditions.Has(c) {
+			allErrs = append(allErrs, field.Duplicate(idxPath, c))
+		}
+		conditions.Insert(c)
+	}
+	if conditions.Len() == 1 && conditions.Has("non-idempotent") {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("conditions"), r.Conditions, "non-idempotent must be combined with other conditions"))
+	}
+
+	allErrs = append(allErrs, validatePositiveIntOrZero(r.Tries, fieldPath.Child("tries"))...)
+	allErrs = append(allErrs, validateTime(r.Timeout, fieldPath.Child("timeout"))...)
+	allErrs = append(allErrs, validateTime(r.PerTryTimeout, fieldPath.Child("perTryTimeout"))...)
+
+	if r.Budget != nil {
+		budgetPath := fieldPath.Child("budget")
+		if r.Budget.PercentOfRecentRequests < 1 || r.Budget.PercentOfRecentRequests > 100 {
+			allErrs = append(allErrs, field.Invalid(budgetPath.Child("percentOfRecentRequests"), r.Budget.PercentOfRecentRequests, "must be in the range 1..100"))
+		}
+		allErrs = append(allErrs, validatePositiveIntOrZeroFromPointer(r.Budget.MinRetries, budgetPath.Child("minRetries"))...)
+	}
+
+	return allErrs
+}
+
+func validateActionMirror(m *v1.ActionMirror, fieldPath *field.Path, upstreamNames sets.Set[string]) field.ErrorList {
+	allErrs := validateReferencedUpstream(m.Upstream, fieldPath.Child("upstream"), upstreamNames)
+
+	if m.Percentage != nil && (*m.Percentage < 0 || *m.Percentage > 100) {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("percentage"), *m.Percentage, "must be in the range 0..100"))
+	}
+
 	return allErrs
 }
 
@@ -1584,17 +1705,20 @@ func isArgumentName(value string) []string {
 // validVariableNames includes NGINX variables allowed to be used in conditions.
 // Not all NGINX variables are allowed. The full list of NGINX variables is at https://nginx.org/en/docs/varindex.html
 var validVariableNames = map[string]bool{
-	"$args":           true,
-	"$http2":          true,
-	"$https":          true,
-	"$remote_addr":    true,
-	"$remote_port":    true,
-	"$query_string":   true,
-	"$request":        true,
-	"$request_body":   true,
-	"$request_uri":    true,
-	"$request_method": true,
-	"$scheme":         true,
+	"$args":                  true,
+	"$http2":                 true,
+	"$https":                 true,
+	"$remote_addr":           true,
+	"$remote_port":           true,
+	"$query_string":          true,
+	"$request":               true,
+	"$request_body":          true,
+	"$request_uri":           true,
+	"$request_method":        true,
+	"$scheme":                true,
+	"$geoip2_country_code":   true,
+	"$geoip2_continent_code": true,
+	"$geoip2_asn":            true,
 }
 
 func validateVariableName(name string, fieldPath *field.Path) field.ErrorList {
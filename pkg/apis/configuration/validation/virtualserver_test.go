@@ -2,6 +2,7 @@ package validation
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 
 	v1 "github.com/nginx/kubernetes-ingress/pkg/apis/configuration/v1"
@@ -2205,6 +2206,7 @@ func TestValidateVariableName(t *testing.T) {
 	t.Parallel()
 	validNames := []string{
 		"$request_method",
+		"$geoip2_country_code",
 	}
 
 	for _, name := range validNames {
@@ -4000,6 +4002,278 @@ func TestValidateActionProxyFails(t *testing.T) {
 	}
 }
 
+func TestValidateActionMirror(t *testing.T) {
+	t.Parallel()
+	upstreamNames := map[string]sets.Empty{
+		"upstream1": {},
+		"upstream2": {},
+	}
+	tests := []*v1.ActionMirror{
+		{
+			Upstream: "upstream2",
+		},
+		{
+			Upstream:    "upstream2",
+			Percentage:  new(0),
+			RequestBody: new(false),
+		},
+		{
+			Upstream:   "upstream1",
+			Percentage: new(100),
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateActionMirror(test, field.NewPath("mirror"), upstreamNames)
+		if len(allErrs) != 0 {
+			t.Errorf("validateActionMirror(%+v) returned errors for valid input: %v", test, allErrs)
+		}
+	}
+}
+
+func TestValidateActionMirrorFails(t *testing.T) {
+	t.Parallel()
+	upstreamNames := map[string]sets.Empty{
+		"upstream1": {},
+	}
+	tests := []struct {
+		mirror *v1.ActionMirror
+		msg    string
+	}{
+		{
+			mirror: &v1.ActionMirror{},
+			msg:    "missing upstream",
+		},
+		{
+			mirror: &v1.ActionMirror{
+				Upstream: "upstream2",
+			},
+			msg: "non-existing upstream",
+		},
+		{
+			mirror: &v1.ActionMirror{
+				Upstream:   "upstream1",
+				Percentage: new(101),
+			},
+			msg: "percentage above 100",
+		},
+		{
+			mirror: &v1.ActionMirror{
+				Upstream:   "upstream1",
+				Percentage: new(-1),
+			},
+			msg: "negative percentage",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateActionMirror(test.mirror, field.NewPath("mirror"), upstreamNames)
+		if len(allErrs) == 0 {
+			t.Errorf("validateActionMirror() returned no errors for invalid input for the case of %s", test.msg)
+		}
+	}
+}
+
+func TestValidateRetryPolicy(t *testing.T) {
+	t.Parallel()
+	tests := []*v1.RetryPolicy{
+		{},
+		{
+			Conditions: []string{"error", "timeout", "5xx", "gateway-error", "non-idempotent"},
+		},
+		{
+			Conditions:    []string{"gateway-error"},
+			Tries:         3,
+			Timeout:       "10s",
+			PerTryTimeout: "2s",
+			Budget: &v1.RetryBudget{
+				PercentOfRecentRequests: 20,
+			},
+		},
+		{
+			Budget: &v1.RetryBudget{
+				PercentOfRecentRequests: 100,
+				MinRetries:              new(0),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateRetryPolicy(test, field.NewPath("retry"))
+		if len(allErrs) != 0 {
+			t.Errorf("validateRetryPolicy(%+v) returned errors for valid input: %v", test, allErrs)
+		}
+	}
+}
+
+func TestValidateRetryPolicyFails(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		retry *v1.RetryPolicy
+		msg   string
+	}{
+		{
+			retry: &v1.RetryPolicy{
+				Conditions: []string{"http_502"},
+			},
+			msg: "invalid condition",
+		},
+		{
+			retry: &v1.RetryPolicy{
+				Conditions: []string{"reset"},
+			},
+			msg: "unsupported reset condition",
+		},
+		{
+			retry: &v1.RetryPolicy{
+				Conditions: []string{"5xx", "5xx"},
+			},
+			msg: "duplicate condition",
+		},
+		{
+			retry: &v1.RetryPolicy{
+				Conditions: []string{"non-idempotent"},
+			},
+			msg: "only non-idempotent condition",
+		},
+		{
+			retry: &v1.RetryPolicy{
+				Tries: -1,
+			},
+			msg: "negative tries",
+		},
+		{
+			retry: &v1.RetryPolicy{
+				Timeout: "5 seconds",
+			},
+			msg: "invalid timeout",
+		},
+		{
+			retry: &v1.RetryPolicy{
+				PerTryTimeout: "-1s",
+			},
+			msg: "invalid per try timeout",
+		},
+		{
+			retry: &v1.RetryPolicy{
+				Budget: &v1.RetryBudget{},
+			},
+			msg: "missing budget percent",
+		},
+		{
+			retry: &v1.RetryPolicy{
+				Budget: &v1.RetryBudget{
+					PercentOfRecentRequests: 101,
+				},
+			},
+			msg: "budget percent above 100",
+		},
+		{
+			retry: &v1.RetryPolicy{
+				Budget: &v1.RetryBudget{
+					PercentOfRecentRequests: 20,
+					MinRetries:              new(-1),
+				},
+			},
+			msg: "negative budget min retries",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateRetryPolicy(test.retry, field.NewPath("retry"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateRetryPolicy() returned no errors for invalid input for the case of %s", test.msg)
+		}
+	}
+}
+
+func TestValidateOutlierDetection(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		od     *v1.OutlierDetection
+		isPlus bool
+	}{
+		{
+			od: &v1.OutlierDetection{},
+		},
+		{
+			od: &v1.OutlierDetection{
+				ConsecutiveErrors:  3,
+				EjectionTime:       "1m",
+				MaxEjectionPercent: new(50),
+			},
+		},
+		{
+			od: &v1.OutlierDetection{
+				ConsecutiveErrors: 3,
+				EjectionTime:      "1m",
+			},
+			isPlus: true,
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateOutlierDetection(test.od, field.NewPath("outlierDetection"), test.isPlus)
+		if len(allErrs) != 0 {
+			t.Errorf("validateOutlierDetection(%+v, %v) returned errors for valid input: %v", test.od, test.isPlus, allErrs)
+		}
+	}
+}
+
+func TestValidateOutlierDetectionFails(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		od     *v1.OutlierDetection
+		isPlus bool
+		msg    string
+	}{
+		{
+			od: &v1.OutlierDetection{
+				ConsecutiveErrors: -1,
+			},
+			msg: "negative consecutive errors",
+		},
+		{
+			od: &v1.OutlierDetection{
+				EjectionTime: "30 seconds",
+			},
+			msg: "invalid ejection time",
+		},
+		{
+			od: &v1.OutlierDetection{
+				MaxEjectionPercent: new(101),
+			},
+			msg: "max ejection percent above 100",
+		},
+		{
+			od: &v1.OutlierDetection{
+				MaxEjectionPercent: new(50),
+			},
+			isPlus: true,
+			msg:    "max ejection percent with NGINX Plus",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateOutlierDetection(test.od, field.NewPath("outlierDetection"), test.isPlus)
+		if len(allErrs) == 0 {
+			t.Errorf("validateOutlierDetection() returned no errors for invalid input for the case of %s", test.msg)
+		}
+	}
+}
+
+func TestValidateOutlierDetectionMaxEjectionPercentWithNGINXPlus(t *testing.T) {
+	t.Parallel()
+	od := &v1.OutlierDetection{
+		MaxEjectionPercent: new(50),
+	}
+
+	allErrs := validateOutlierDetection(od, field.NewPath("outlierDetection"), true)
+	if len(allErrs) != 1 || !strings.Contains(allErrs[0].Error(), "is not supported with NGINX Plus") {
+		t.Errorf("validateOutlierDetection() returned %v, expected a single error that the field is not supported with NGINX Plus", allErrs)
+	}
+}
+
 func TestValidateActionProxyRewritePath(t *testing.T) {
 	t.Parallel()
 	tests := []string{"/rewrite", "/rewrite", `/$2`}
@@ -5014,3 +5288,103 @@ func TestValidateAddHeaderInherit_InvalidValues(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateRollout(t *testing.T) {
+	t.Parallel()
+	splits := []v1.Split{
+		{Weight: 100, Action: &v1.Action{Pass: "tea-v1"}},
+		{Weight: 0, Action: &v1.Action{Pass: "tea-v2"}},
+	}
+	rollouts := []*v1.Rollout{
+		{
+			Steps: []v1.RolloutStep{{Weight: 100}},
+		},
+		{
+			Steps: []v1.RolloutStep{
+				{Weight: 10, Duration: "5m"},
+				{Weight: 50, Duration: "10m"},
+				{Weight: 100},
+			},
+			MaxErrorRate:    new(5),
+			MaxResponseTime: "500ms",
+		},
+	}
+
+	vsv := &VirtualServerValidator{isPlus: true}
+	for _, rollout := range rollouts {
+		allErrs := vsv.validateRollout(rollout, splits, field.NewPath("rollout"))
+		if len(allErrs) > 0 {
+			t.Errorf("validateRollout(%+v) returned errors %v for valid input", rollout, allErrs)
+		}
+	}
+}
+
+func TestValidateRolloutFails(t *testing.T) {
+	t.Parallel()
+	splits := []v1.Split{
+		{Weight: 100, Action: &v1.Action{Pass: "tea-v1"}},
+		{Weight: 0, Action: &v1.Action{Pass: "tea-v2"}},
+	}
+	tests := []struct {
+		rollout *v1.Rollout
+		splits  []v1.Split
+		isPlus  bool
+		msg     string
+	}{
+		{
+			rollout: &v1.Rollout{Steps: []v1.RolloutStep{{Weight: 100}}},
+			splits:  splits,
+			msg:     "rollout with NGINX",
+		},
+		{
+			rollout: &v1.Rollout{Steps: []v1.RolloutStep{{Weight: 100}}},
+			splits:  append(splits, v1.Split{Action: &v1.Action{Pass: "tea-v3"}}),
+			isPlus:  true,
+			msg:     "three splits",
+		},
+		{
+			rollout: &v1.Rollout{},
+			splits:  splits,
+			isPlus:  true,
+			msg:     "no steps",
+		},
+		{
+			rollout: &v1.Rollout{Steps: []v1.RolloutStep{{Weight: 101}}},
+			splits:  splits,
+			isPlus:  true,
+			msg:     "weight above 100",
+		},
+		{
+			rollout: &v1.Rollout{Steps: []v1.RolloutStep{{Weight: 10}, {Weight: 100}}},
+			splits:  splits,
+			isPlus:  true,
+			msg:     "missing duration",
+		},
+		{
+			rollout: &v1.Rollout{Steps: []v1.RolloutStep{{Weight: 10, Duration: "5 minutes"}, {Weight: 100}}},
+			splits:  splits,
+			isPlus:  true,
+			msg:     "invalid duration",
+		},
+		{
+			rollout: &v1.Rollout{Steps: []v1.RolloutStep{{Weight: 100}}, MaxErrorRate: new(-1)},
+			splits:  splits,
+			isPlus:  true,
+			msg:     "negative max error rate",
+		},
+		{
+			rollout: &v1.Rollout{Steps: []v1.RolloutStep{{Weight: 100}}, MaxResponseTime: "1 second"},
+			splits:  splits,
+			isPlus:  true,
+			msg:     "invalid max response time",
+		},
+	}
+
+	for _, test := range tests {
+		vsv := &VirtualServerValidator{isPlus: test.isPlus}
+		allErrs := vsv.validateRollout(test.rollout, test.splits, field.NewPath("rollout"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateRollout() returned no errors for invalid input for the case of %s", test.msg)
+		}
+	}
+}
@@ -9,6 +9,8 @@ package v1
 type AccessControlApplyConfiguration struct {
 	Allow []string `json:"allow,omitempty"`
 	Deny  []string `json:"deny,omitempty"`
+	// Enables the dry run mode. In this mode, the requests that the policy would reject are not rejected, but reported in the $policy_dry_run variable and counted in the policy_dry_run_rejections_total metric. Not supported on TransportServer resources.
+	DryRun *bool `json:"dryRun,omitempty"`
 }
 
 // AccessControlApplyConfiguration constructs a declarative configuration of the AccessControl type for use with
@@ -36,3 +38,11 @@ func (b *AccessControlApplyConfiguration) WithDeny(values ...string) *AccessCont
 	}
 	return b
 }
+
+// WithDryRun sets the DryRun field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DryRun field is set to the value of the last call.
+func (b *AccessControlApplyConfiguration) WithDryRun(value bool) *AccessControlApplyConfiguration {
+	b.DryRun = &value
+	return b
+}
@@ -15,6 +15,10 @@ type ActionProxyApplyConfiguration struct {
 	RequestHeaders *ProxyRequestHeadersApplyConfiguration `json:"requestHeaders,omitempty"`
 	// The response headers modifications.
 	ResponseHeaders *ProxyResponseHeadersApplyConfiguration `json:"responseHeaders,omitempty"`
+	// Mirrors (shadows) the requests to another upstream. The responses of the mirror upstream are ignored.
+	Mirror *ActionMirrorApplyConfiguration `json:"mirror,omitempty"`
+	// The retry policy of the requests. Overrides the next-upstream, next-upstream-tries and next-upstream-timeout fields of the upstream.
+	Retry *RetryPolicyApplyConfiguration `json:"retry,omitempty"`
 }
 
 // ActionProxyApplyConfiguration constructs a declarative configuration of the ActionProxy type for use with
@@ -54,3 +58,19 @@ func (b *ActionProxyApplyConfiguration) WithResponseHeaders(value *ProxyResponse
 	b.ResponseHeaders = value
 	return b
 }
+
+// WithMirror sets the Mirror field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Mirror field is set to the value of the last call.
+func (b *ActionProxyApplyConfiguration) WithMirror(value *ActionMirrorApplyConfiguration) *ActionProxyApplyConfiguration {
+	b.Mirror = value
+	return b
+}
+
+// WithRetry sets the Retry field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Retry field is set to the value of the last call.
+func (b *ActionProxyApplyConfiguration) WithRetry(value *RetryPolicyApplyConfiguration) *ActionProxyApplyConfiguration {
+	b.Retry = value
+	return b
+}
@@ -10,7 +10,12 @@ type APIKeyApplyConfiguration struct {
 	// The location of the API Key. For example, $http_auth, $arg_apikey, $cookie_auth. Accepted variables are $http_, $arg_, $cookie_.
 	SuppliedIn *SuppliedInApplyConfiguration `json:"suppliedIn,omitempty"`
 	// The key to which the API key is applied. Can contain text, variables, or a combination of them. Accepted variables are $http_, $arg_, $cookie_.
+	// The keys of the data of the Secret are the IDs of the clients. The value is either the API key of the client, or a JSON object with the API key (key) or its SHA-256 hash (sha256), and optionally the name of the client (name), the expiry time of the API key in RFC 3339 format (expires) and the path prefixes the API key is allowed for (routes).
 	ClientSecret *string `json:"clientSecret,omitempty"`
+	// The name of the request header that passes the ID of the authenticated client to the upstream. The ID is also available in the $apikey_client_name variable.
+	ClientIDHeader *string `json:"clientIDHeader,omitempty"`
+	// Enables the dry run mode. In this mode, the requests that the policy would reject are not rejected, but reported in the $policy_dry_run variable and counted in the policy_dry_run_rejections_total metric.
+	DryRun *bool `json:"dryRun,omitempty"`
 }
 
 // APIKeyApplyConfiguration constructs a declarative configuration of the APIKey type for use with
@@ -34,3 +39,19 @@ func (b *APIKeyApplyConfiguration) WithClientSecret(value string) *APIKeyApplyCo
 	b.ClientSecret = &value
 	return b
 }
+
+// WithClientIDHeader sets the ClientIDHeader field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ClientIDHeader field is set to the value of the last call.
+func (b *APIKeyApplyConfiguration) WithClientIDHeader(value string) *APIKeyApplyConfiguration {
+	b.ClientIDHeader = &value
+	return b
+}
+
+// WithDryRun sets the DryRun field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DryRun field is set to the value of the last call.
+func (b *APIKeyApplyConfiguration) WithDryRun(value bool) *APIKeyApplyConfiguration {
+	b.DryRun = &value
+	return b
+}
@@ -11,6 +11,8 @@ type BasicAuthApplyConfiguration struct {
 	Realm *string `json:"realm,omitempty"`
 	// The name of the Kubernetes secret that stores the Htpasswd configuration. It must be in the same namespace as the Policy resource. The secret must be of the type nginx.org/htpasswd, and the config must be stored in the secret under the key htpasswd, otherwise the secret will be rejected as invalid.
 	Secret *string `json:"secret,omitempty"`
+	// Enables the dry run mode. In this mode, the requests that the policy would reject are not rejected, but reported in the $policy_dry_run variable and counted in the policy_dry_run_rejections_total metric.
+	DryRun *bool `json:"dryRun,omitempty"`
 }
 
 // BasicAuthApplyConfiguration constructs a declarative configuration of the BasicAuth type for use with
@@ -34,3 +36,11 @@ func (b *BasicAuthApplyConfiguration) WithSecret(value string) *BasicAuthApplyCo
 	b.Secret = &value
 	return b
 }
+
+// WithDryRun sets the DryRun field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DryRun field is set to the value of the last call.
+func (b *BasicAuthApplyConfiguration) WithDryRun(value bool) *BasicAuthApplyConfiguration {
+	b.DryRun = &value
+	return b
+}
@@ -0,0 +1,68 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	metav1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
+// DefaultPoliciesApplyConfiguration represents a declarative configuration of the DefaultPolicies type for use
+// with apply.
+//
+// DefaultPolicies defines policies applied by default to the resources of the selected namespaces.
+// If neither namespaces nor namespaceSelector are set, the policies are applied in all namespaces.
+type DefaultPoliciesApplyConfiguration struct {
+	// A list of policies. A policy without a namespace is looked up in the namespace of the resource.
+	Policies []PolicyReferenceApplyConfiguration `json:"policies,omitempty"`
+	// The namespaces of the resources the policies are applied to.
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Selects the namespaces of the resources the policies are applied to by their labels. Unless the namespaces are watched with the -watch-namespace-label command-line argument, the Ingress Controller requires the permission to list and watch all the namespaces of the cluster.
+	NamespaceSelector *metav1.LabelSelectorApplyConfiguration `json:"namespaceSelector,omitempty"`
+	// Allows the resources to opt out of the policies. VirtualServer and VirtualServerRoute resources opt out with the disableDefaultPolicies field, Ingress resources with the nginx.org/disable-default-policies annotation.
+	AllowOptOut *bool `json:"allowOptOut,omitempty"`
+}
+
+// DefaultPoliciesApplyConfiguration constructs a declarative configuration of the DefaultPolicies type for use with
+// apply.
+func DefaultPolicies() *DefaultPoliciesApplyConfiguration {
+	return &DefaultPoliciesApplyConfiguration{}
+}
+
+// WithPolicies adds the given value to the Policies field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Policies field.
+func (b *DefaultPoliciesApplyConfiguration) WithPolicies(values ...*PolicyReferenceApplyConfiguration) *DefaultPoliciesApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithPolicies")
+		}
+		b.Policies = append(b.Policies, *values[i])
+	}
+	return b
+}
+
+// WithNamespaces adds the given value to the Namespaces field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Namespaces field.
+func (b *DefaultPoliciesApplyConfiguration) WithNamespaces(values ...string) *DefaultPoliciesApplyConfiguration {
+	for i := range values {
+		b.Namespaces = append(b.Namespaces, values[i])
+	}
+	return b
+}
+
+// WithNamespaceSelector sets the NamespaceSelector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NamespaceSelector field is set to the value of the last call.
+func (b *DefaultPoliciesApplyConfiguration) WithNamespaceSelector(value *metav1.LabelSelectorApplyConfiguration) *DefaultPoliciesApplyConfiguration {
+	b.NamespaceSelector = value
+	return b
+}
+
+// WithAllowOptOut sets the AllowOptOut field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AllowOptOut field is set to the value of the last call.
+func (b *DefaultPoliciesApplyConfiguration) WithAllowOptOut(value bool) *DefaultPoliciesApplyConfiguration {
+	b.AllowOptOut = &value
+	return b
+}
@@ -29,6 +29,8 @@ type ExternalAuthApplyConfiguration struct {
 	TrustedCertSecret *string `json:"trustedCertSecret,omitempty"`
 	// SNIName sets the server name used for SNI and certificate verification when connecting to the external authentication server over TLS. If not specified, defaults to <service-name>.<namespace>.svc derived from authServiceName.
 	SNIName *string `json:"sniName,omitempty"`
+	// GRPC enables the gRPC mode, in which the requests are authorized by an external authorization server that implements the Envoy envoy.service.auth.v3.Authorization gRPC API, for example Open Policy Agent. The authURI field is ignored in the gRPC mode. Denied requests are rejected with the 401 or 403 status code; the headers and the body of the denied responses are not supported.
+	GRPC *ExternalAuthGRPCApplyConfiguration `json:"grpc,omitempty"`
 }
 
 // ExternalAuthApplyConfiguration constructs a declarative configuration of the ExternalAuth type for use with
@@ -126,3 +128,11 @@ func (b *ExternalAuthApplyConfiguration) WithSNIName(value string) *ExternalAuth
 	b.SNIName = &value
 	return b
 }
+
+// WithGRPC sets the GRPC field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the GRPC field is set to the value of the last call.
+func (b *ExternalAuthApplyConfiguration) WithGRPC(value *ExternalAuthGRPCApplyConfiguration) *ExternalAuthApplyConfiguration {
+	b.GRPC = value
+	return b
+}
@@ -0,0 +1,52 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// GeoMatchApplyConfiguration represents a declarative configuration of the GeoMatch type for use
+// with apply.
+//
+// GeoMatch defines the countries, continents and autonomous systems matched by a geo policy.
+type GeoMatchApplyConfiguration struct {
+	// ISO 3166-1 alpha-2 country codes, for example, US.
+	Countries []string `json:"countries,omitempty"`
+	// Two-letter continent codes, for example, EU.
+	Continents []string `json:"continents,omitempty"`
+	// Autonomous system numbers, for example, 64496.
+	ASNs []int `json:"asns,omitempty"`
+}
+
+// GeoMatchApplyConfiguration constructs a declarative configuration of the GeoMatch type for use with
+// apply.
+func GeoMatch() *GeoMatchApplyConfiguration {
+	return &GeoMatchApplyConfiguration{}
+}
+
+// WithCountries adds the given value to the Countries field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Countries field.
+func (b *GeoMatchApplyConfiguration) WithCountries(values ...string) *GeoMatchApplyConfiguration {
+	for i := range values {
+		b.Countries = append(b.Countries, values[i])
+	}
+	return b
+}
+
+// WithContinents adds the given value to the Continents field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Continents field.
+func (b *GeoMatchApplyConfiguration) WithContinents(values ...string) *GeoMatchApplyConfiguration {
+	for i := range values {
+		b.Continents = append(b.Continents, values[i])
+	}
+	return b
+}
+
+// WithASNs adds the given value to the ASNs field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ASNs field.
+func (b *GeoMatchApplyConfiguration) WithASNs(values ...int) *GeoMatchApplyConfiguration {
+	for i := range values {
+		b.ASNs = append(b.ASNs, values[i])
+	}
+	return b
+}
@@ -9,6 +9,8 @@ package v1
 type GlobalConfigurationSpecApplyConfiguration struct {
 	// Listeners field of the GlobalConfigurationSpec resource
 	Listeners []ListenerApplyConfiguration `json:"listeners,omitempty"`
+	// A list of policies applied by default to the VirtualServer, VirtualServerRoute and Ingress resources of the selected namespaces.
+	DefaultPolicies []DefaultPoliciesApplyConfiguration `json:"defaultPolicies,omitempty"`
 }
 
 // GlobalConfigurationSpecApplyConfiguration constructs a declarative configuration of the GlobalConfigurationSpec type for use with
@@ -29,3 +31,16 @@ func (b *GlobalConfigurationSpecApplyConfiguration) WithListeners(values ...*Lis
 	}
 	return b
 }
+
+// WithDefaultPolicies adds the given value to the DefaultPolicies field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the DefaultPolicies field.
+func (b *GlobalConfigurationSpecApplyConfiguration) WithDefaultPolicies(values ...*DefaultPoliciesApplyConfiguration) *GlobalConfigurationSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithDefaultPolicies")
+		}
+		b.DefaultPolicies = append(b.DefaultPolicies, *values[i])
+	}
+	return b
+}
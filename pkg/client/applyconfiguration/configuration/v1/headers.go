@@ -0,0 +1,38 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// HeadersApplyConfiguration represents a declarative configuration of the Headers type for use
+// with apply.
+//
+// Headers defines a policy that modifies the request headers passed to the upstreams and the response headers sent to the clients.
+// The headers policies of the VirtualServer spec apply to every route. The headers policies of a route or a subroute are applied after them,
+// and the requestHeaders and responseHeaders of the action proxy are applied last. A header modified at a later level replaces the modifications of the same header at the earlier levels.
+type HeadersApplyConfiguration struct {
+	// Request defines the modifications of the request headers passed to the upstreams.
+	Request *RequestHeadersModifierApplyConfiguration `json:"request,omitempty"`
+	// Response defines the modifications of the response headers sent to the clients.
+	Response *ResponseHeadersModifierApplyConfiguration `json:"response,omitempty"`
+}
+
+// HeadersApplyConfiguration constructs a declarative configuration of the Headers type for use with
+// apply.
+func Headers() *HeadersApplyConfiguration {
+	return &HeadersApplyConfiguration{}
+}
+
+// WithRequest sets the Request field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Request field is set to the value of the last call.
+func (b *HeadersApplyConfiguration) WithRequest(value *RequestHeadersModifierApplyConfiguration) *HeadersApplyConfiguration {
+	b.Request = value
+	return b
+}
+
+// WithResponse sets the Response field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Response field is set to the value of the last call.
+func (b *HeadersApplyConfiguration) WithResponse(value *ResponseHeadersModifierApplyConfiguration) *HeadersApplyConfiguration {
+	b.Response = value
+	return b
+}
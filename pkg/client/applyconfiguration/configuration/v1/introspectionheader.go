@@ -0,0 +1,36 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// IntrospectionHeaderApplyConfiguration represents a declarative configuration of the IntrospectionHeader type for use
+// with apply.
+//
+// IntrospectionHeader defines a request header set to a claim of the introspection response.
+type IntrospectionHeaderApplyConfiguration struct {
+	// The name of the request header.
+	Name *string `json:"name,omitempty"`
+	// The name of the claim. The nested claims are separated by dots, for example realm_access.roles. The values of the array claims are joined with commas.
+	Claim *string `json:"claim,omitempty"`
+}
+
+// IntrospectionHeaderApplyConfiguration constructs a declarative configuration of the IntrospectionHeader type for use with
+// apply.
+func IntrospectionHeader() *IntrospectionHeaderApplyConfiguration {
+	return &IntrospectionHeaderApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *IntrospectionHeaderApplyConfiguration) WithName(value string) *IntrospectionHeaderApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithClaim sets the Claim field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Claim field is set to the value of the last call.
+func (b *IntrospectionHeaderApplyConfiguration) WithClaim(value string) *IntrospectionHeaderApplyConfiguration {
+	b.Claim = &value
+	return b
+}
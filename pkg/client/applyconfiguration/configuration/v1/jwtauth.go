@@ -27,6 +27,10 @@ type JWTAuthApplyConfiguration struct {
 	TrustedCertSecret *string `json:"trustedCertSecret,omitempty"`
 	// Sets the verification depth in the JWKS server certificates chain. The default is 1.
 	SSLVerifyDepth *int `json:"sslVerifyDepth,omitempty"`
+	// The claims of the JWT that must match for the request to be authorized.
+	Require *JWTRequireApplyConfiguration `json:"require,omitempty"`
+	// Enables the dry run mode. In this mode, the requests that the policy would reject are not rejected, but reported in the $policy_dry_run variable and counted in the policy_dry_run_rejections_total metric.
+	DryRun *bool `json:"dryRun,omitempty"`
 }
 
 // JWTAuthApplyConfiguration constructs a declarative configuration of the JWTAuth type for use with
@@ -114,3 +118,19 @@ func (b *JWTAuthApplyConfiguration) WithSSLVerifyDepth(value int) *JWTAuthApplyC
 	b.SSLVerifyDepth = &value
 	return b
 }
+
+// WithRequire sets the Require field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Require field is set to the value of the last call.
+func (b *JWTAuthApplyConfiguration) WithRequire(value *JWTRequireApplyConfiguration) *JWTAuthApplyConfiguration {
+	b.Require = value
+	return b
+}
+
+// WithDryRun sets the DryRun field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DryRun field is set to the value of the last call.
+func (b *JWTAuthApplyConfiguration) WithDryRun(value bool) *JWTAuthApplyConfiguration {
+	b.DryRun = &value
+	return b
+}
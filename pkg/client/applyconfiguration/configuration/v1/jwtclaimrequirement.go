@@ -0,0 +1,48 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// JWTClaimRequirementApplyConfiguration represents a declarative configuration of the JWTClaimRequirement type for use
+// with apply.
+//
+// JWTClaimRequirement defines a claim of the JWT that must match.
+type JWTClaimRequirementApplyConfiguration struct {
+	// The name of the claim. Nested claims should be separated by ".", for example, realm_access.roles.
+	Name *string `json:"name,omitempty"`
+	// The claim must be equal to any of the values. An array claim must contain any of the values.
+	Values []string `json:"values,omitempty"`
+	// The claim must match the regular expression. The elements of an array claim are matched as a comma-separated list.
+	Regex *string `json:"regex,omitempty"`
+}
+
+// JWTClaimRequirementApplyConfiguration constructs a declarative configuration of the JWTClaimRequirement type for use with
+// apply.
+func JWTClaimRequirement() *JWTClaimRequirementApplyConfiguration {
+	return &JWTClaimRequirementApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *JWTClaimRequirementApplyConfiguration) WithName(value string) *JWTClaimRequirementApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithValues adds the given value to the Values field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Values field.
+func (b *JWTClaimRequirementApplyConfiguration) WithValues(values ...string) *JWTClaimRequirementApplyConfiguration {
+	for i := range values {
+		b.Values = append(b.Values, values[i])
+	}
+	return b
+}
+
+// WithRegex sets the Regex field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Regex field is set to the value of the last call.
+func (b *JWTClaimRequirementApplyConfiguration) WithRegex(value string) *JWTClaimRequirementApplyConfiguration {
+	b.Regex = &value
+	return b
+}
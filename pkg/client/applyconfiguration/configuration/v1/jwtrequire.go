@@ -0,0 +1,41 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// JWTRequireApplyConfiguration represents a declarative configuration of the JWTRequire type for use
+// with apply.
+//
+// JWTRequire defines the claims of the JWT that must match for the request to be authorized.
+type JWTRequireApplyConfiguration struct {
+	// The claims that must match. The request is authorized only if all the claims match.
+	Claims []JWTClaimRequirementApplyConfiguration `json:"claims,omitempty"`
+	// The status code that is returned when the claims don't match. Accepted values are 401 and 403. The default is 403.
+	Code *int `json:"code,omitempty"`
+}
+
+// JWTRequireApplyConfiguration constructs a declarative configuration of the JWTRequire type for use with
+// apply.
+func JWTRequire() *JWTRequireApplyConfiguration {
+	return &JWTRequireApplyConfiguration{}
+}
+
+// WithClaims adds the given value to the Claims field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Claims field.
+func (b *JWTRequireApplyConfiguration) WithClaims(values ...*JWTClaimRequirementApplyConfiguration) *JWTRequireApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithClaims")
+		}
+		b.Claims = append(b.Claims, *values[i])
+	}
+	return b
+}
+
+// WithCode sets the Code field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Code field is set to the value of the last call.
+func (b *JWTRequireApplyConfiguration) WithCode(value int) *JWTRequireApplyConfiguration {
+	b.Code = &value
+	return b
+}
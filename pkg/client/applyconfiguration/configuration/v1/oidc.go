@@ -19,8 +19,12 @@ type OIDCApplyConfiguration struct {
 	ClientSecret *string `json:"clientSecret,omitempty"`
 	// List of OpenID Connect scopes. The scope openid always needs to be present and others can be added concatenating them with a + sign, for example openid+profile+email, openid+email+userDefinedScope. The default is openid.
 	Scope *string `json:"scope,omitempty"`
-	// Allows overriding the default redirect URI. The default is /_codexch.
+	// Allows overriding the default redirect URI. Every OIDC policy of a host must have a different redirect URI. The default is /_codexch.
 	RedirectURI *string `json:"redirectURI,omitempty"`
+	// Allows overriding the default logout URI. Every OIDC policy of a host must have a different logout URI. The default is /logout.
+	LogoutURI *string `json:"logoutURI,omitempty"`
+	// The name of the cookie that stores the session of the user. The cookies that store the nonce and the requested URI during the login are named after it with the _nonce and _redir suffixes. Every OIDC policy of a host must have a different session cookie. The default is auth_token.
+	SessionCookie *string `json:"sessionCookie,omitempty"`
 	// URL provided by your OpenID Connect provider to request the end user be logged out.
 	EndSessionEndpoint *string `json:"endSessionEndpoint,omitempty"`
 	// URI to redirect to after the logout has been performed. Requires endSessionEndpoint. The default is /_logout.
@@ -39,6 +43,10 @@ type OIDCApplyConfiguration struct {
 	TrustedCertSecret *string `json:"trustedCertSecret,omitempty"`
 	// Sets the verification depth in the IDP server certificates chain. The default is 1.
 	SSLVerifyDepth *int `json:"sslVerifyDepth,omitempty"`
+	// The claims of the ID token that are passed to the upstream in request headers.
+	ClaimHeaders []OIDCClaimHeaderApplyConfiguration `json:"claimHeaders,omitempty"`
+	// Restricts the access to the users that are members of the allowed groups.
+	Groups *OIDCGroupsApplyConfiguration `json:"groups,omitempty"`
 }
 
 // OIDCApplyConfiguration constructs a declarative configuration of the OIDC type for use with
@@ -103,6 +111,22 @@ func (b *OIDCApplyConfiguration) WithRedirectURI(value string) *OIDCApplyConfigu
 	return b
 }
 
+// WithLogoutURI sets the LogoutURI field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LogoutURI field is set to the value of the last call.
+func (b *OIDCApplyConfiguration) WithLogoutURI(value string) *OIDCApplyConfiguration {
+	b.LogoutURI = &value
+	return b
+}
+
+// WithSessionCookie sets the SessionCookie field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SessionCookie field is set to the value of the last call.
+func (b *OIDCApplyConfiguration) WithSessionCookie(value string) *OIDCApplyConfiguration {
+	b.SessionCookie = &value
+	return b
+}
+
 // WithEndSessionEndpoint sets the EndSessionEndpoint field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the EndSessionEndpoint field is set to the value of the last call.
@@ -176,3 +200,24 @@ func (b *OIDCApplyConfiguration) WithSSLVerifyDepth(value int) *OIDCApplyConfigu
 	b.SSLVerifyDepth = &value
 	return b
 }
+
+// WithClaimHeaders adds the given value to the ClaimHeaders field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ClaimHeaders field.
+func (b *OIDCApplyConfiguration) WithClaimHeaders(values ...*OIDCClaimHeaderApplyConfiguration) *OIDCApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithClaimHeaders")
+		}
+		b.ClaimHeaders = append(b.ClaimHeaders, *values[i])
+	}
+	return b
+}
+
+// WithGroups sets the Groups field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Groups field is set to the value of the last call.
+func (b *OIDCApplyConfiguration) WithGroups(value *OIDCGroupsApplyConfiguration) *OIDCApplyConfiguration {
+	b.Groups = value
+	return b
+}
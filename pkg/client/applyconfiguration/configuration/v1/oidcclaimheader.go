@@ -0,0 +1,36 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// OIDCClaimHeaderApplyConfiguration represents a declarative configuration of the OIDCClaimHeader type for use
+// with apply.
+//
+// OIDCClaimHeader defines a claim of the ID token that is passed to the upstream in a request header.
+type OIDCClaimHeaderApplyConfiguration struct {
+	// The name of the claim. Nested claims should be separated by ".", for example, realm_access.roles. The elements of an array claim are separated by commas.
+	Claim *string `json:"claim,omitempty"`
+	// The name of the request header.
+	Header *string `json:"header,omitempty"`
+}
+
+// OIDCClaimHeaderApplyConfiguration constructs a declarative configuration of the OIDCClaimHeader type for use with
+// apply.
+func OIDCClaimHeader() *OIDCClaimHeaderApplyConfiguration {
+	return &OIDCClaimHeaderApplyConfiguration{}
+}
+
+// WithClaim sets the Claim field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Claim field is set to the value of the last call.
+func (b *OIDCClaimHeaderApplyConfiguration) WithClaim(value string) *OIDCClaimHeaderApplyConfiguration {
+	b.Claim = &value
+	return b
+}
+
+// WithHeader sets the Header field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Header field is set to the value of the last call.
+func (b *OIDCClaimHeaderApplyConfiguration) WithHeader(value string) *OIDCClaimHeaderApplyConfiguration {
+	b.Header = &value
+	return b
+}
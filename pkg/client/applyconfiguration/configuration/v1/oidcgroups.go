@@ -0,0 +1,38 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// OIDCGroupsApplyConfiguration represents a declarative configuration of the OIDCGroups type for use
+// with apply.
+//
+// OIDCGroups defines the groups of the users that are allowed to access the resources. The requests of the other users are rejected with the 403 status code.
+type OIDCGroupsApplyConfiguration struct {
+	// The name of the claim of the ID token that contains the groups of the user. Nested claims should be separated by ".". The default is groups.
+	Claim *string `json:"claim,omitempty"`
+	// The allowed groups. The user must be a member of any of the groups.
+	Allow []string `json:"allow,omitempty"`
+}
+
+// OIDCGroupsApplyConfiguration constructs a declarative configuration of the OIDCGroups type for use with
+// apply.
+func OIDCGroups() *OIDCGroupsApplyConfiguration {
+	return &OIDCGroupsApplyConfiguration{}
+}
+
+// WithClaim sets the Claim field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Claim field is set to the value of the last call.
+func (b *OIDCGroupsApplyConfiguration) WithClaim(value string) *OIDCGroupsApplyConfiguration {
+	b.Claim = &value
+	return b
+}
+
+// WithAllow adds the given value to the Allow field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Allow field.
+func (b *OIDCGroupsApplyConfiguration) WithAllow(values ...string) *OIDCGroupsApplyConfiguration {
+	for i := range values {
+		b.Allow = append(b.Allow, values[i])
+	}
+	return b
+}
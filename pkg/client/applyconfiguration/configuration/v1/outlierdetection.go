@@ -0,0 +1,47 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// OutlierDetectionApplyConfiguration represents a declarative configuration of the OutlierDetection type for use
+// with apply.
+//
+// OutlierDetection defines the ejection of upstream servers that return consecutive errors.
+// With NGINX Plus, the outlier detection is implemented with an active health check of the upstream, and ejected servers are re-admitted gradually during the slow-start of the upstream, which defaults to 30s. With NGINX, the Ingress Controller must be started with the -enable-outlier-detection command-line argument. It re-admits ejected servers gradually by increasing their weight in steps for the ejection time, and applies the ejections and re-admissions of the servers of all upstreams with at most one reload every 10 seconds.
+type OutlierDetectionApplyConfiguration struct {
+	// The number of consecutive 5xx responses or timeouts after which an upstream server is ejected. The default is 5.
+	ConsecutiveErrors *int `json:"consecutiveErrors,omitempty"`
+	// The time during which an ejected upstream server doesn't receive requests. The default is 30s.
+	EjectionTime *string `json:"ejectionTime,omitempty"`
+	// The maximum percentage of the upstream servers that can be ejected at the same time. At least one server can always be ejected. Must fall into the range 0..100. The default is 10. Note: this field is not supported with NGINX Plus, because the servers are ejected by the active health checks of NGINX Plus.
+	MaxEjectionPercent *int `json:"maxEjectionPercent,omitempty"`
+}
+
+// OutlierDetectionApplyConfiguration constructs a declarative configuration of the OutlierDetection type for use with
+// apply.
+func OutlierDetection() *OutlierDetectionApplyConfiguration {
+	return &OutlierDetectionApplyConfiguration{}
+}
+
+// WithConsecutiveErrors sets the ConsecutiveErrors field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ConsecutiveErrors field is set to the value of the last call.
+func (b *OutlierDetectionApplyConfiguration) WithConsecutiveErrors(value int) *OutlierDetectionApplyConfiguration {
+	b.ConsecutiveErrors = &value
+	return b
+}
+
+// WithEjectionTime sets the EjectionTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the EjectionTime field is set to the value of the last call.
+func (b *OutlierDetectionApplyConfiguration) WithEjectionTime(value string) *OutlierDetectionApplyConfiguration {
+	b.EjectionTime = &value
+	return b
+}
+
+// WithMaxEjectionPercent sets the MaxEjectionPercent field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxEjectionPercent field is set to the value of the last call.
+func (b *OutlierDetectionApplyConfiguration) WithMaxEjectionPercent(value int) *OutlierDetectionApplyConfiguration {
+	b.MaxEjectionPercent = &value
+	return b
+}
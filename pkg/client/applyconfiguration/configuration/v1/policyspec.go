@@ -29,12 +29,24 @@ type PolicySpecApplyConfiguration struct {
 	WAF *WAFApplyConfiguration `json:"waf,omitempty"`
 	// The API Key policy configures NGINX to authorize requests which provide a valid API Key in a specified header or query param.
 	APIKey *APIKeyApplyConfiguration `json:"apiKey,omitempty"`
+	// The HMAC policy verifies the HMAC-SHA256 signatures of the requests signed with shared keys.
+	HMAC *HMACApplyConfiguration `json:"hmac,omitempty"`
 	// The Cache Key defines a cache policy for proxy caching
 	Cache *CacheApplyConfiguration `json:"cache,omitempty"`
 	// The CORS policy configures Cross-Origin Resource Sharing headers
 	CORS *CORSApplyConfiguration `json:"cors,omitempty"`
+	// The headers policy modifies the request headers passed to the upstreams and the response headers sent to the clients.
+	Headers *HeadersApplyConfiguration `json:"headers,omitempty"`
 	// The ExternalAuth policy configures NGINX to authenticate client requests using an external authentication server, which can be used for example with the oauth2-proxy or any custom authentication server.
 	ExternalAuth *ExternalAuthApplyConfiguration `json:"externalAuth,omitempty"`
+	// The introspection policy configures NGINX Plus to authenticate client requests with opaque access tokens using the OAuth 2.0 token introspection endpoint of an identity provider.
+	Introspection *IntrospectionApplyConfiguration `json:"introspection,omitempty"`
+	// The connection limit policy limits the number of simultaneous connections per client IP address. Supported only in TransportServer.
+	ConnectionLimit *ConnectionLimitApplyConfiguration `json:"connectionLimit,omitempty"`
+	// The geo policy allows or denies requests by the country, continent or autonomous system of the client IP address.
+	Geo *GeoApplyConfiguration `json:"geo,omitempty"`
+	// The tracing policy enables the OpenTelemetry tracing of the requests. Requires the otel-exporter-endpoint ConfigMap key.
+	Tracing *TracingApplyConfiguration `json:"tracing,omitempty"`
 }
 
 // PolicySpecApplyConfiguration constructs a declarative configuration of the PolicySpec type for use with
@@ -123,6 +135,14 @@ func (b *PolicySpecApplyConfiguration) WithAPIKey(value *APIKeyApplyConfiguratio
 	return b
 }
 
+// WithHMAC sets the HMAC field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the HMAC field is set to the value of the last call.
+func (b *PolicySpecApplyConfiguration) WithHMAC(value *HMACApplyConfiguration) *PolicySpecApplyConfiguration {
+	b.HMAC = value
+	return b
+}
+
 // WithCache sets the Cache field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Cache field is set to the value of the last call.
@@ -139,6 +159,14 @@ func (b *PolicySpecApplyConfiguration) WithCORS(value *CORSApplyConfiguration) *
 	return b
 }
 
+// WithHeaders sets the Headers field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Headers field is set to the value of the last call.
+func (b *PolicySpecApplyConfiguration) WithHeaders(value *HeadersApplyConfiguration) *PolicySpecApplyConfiguration {
+	b.Headers = value
+	return b
+}
+
 // WithExternalAuth sets the ExternalAuth field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the ExternalAuth field is set to the value of the last call.
@@ -146,3 +174,35 @@ func (b *PolicySpecApplyConfiguration) WithExternalAuth(value *ExternalAuthApply
 	b.ExternalAuth = value
 	return b
 }
+
+// WithIntrospection sets the Introspection field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Introspection field is set to the value of the last call.
+func (b *PolicySpecApplyConfiguration) WithIntrospection(value *IntrospectionApplyConfiguration) *PolicySpecApplyConfiguration {
+	b.Introspection = value
+	return b
+}
+
+// WithConnectionLimit sets the ConnectionLimit field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ConnectionLimit field is set to the value of the last call.
+func (b *PolicySpecApplyConfiguration) WithConnectionLimit(value *ConnectionLimitApplyConfiguration) *PolicySpecApplyConfiguration {
+	b.ConnectionLimit = value
+	return b
+}
+
+// WithGeo sets the Geo field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Geo field is set to the value of the last call.
+func (b *PolicySpecApplyConfiguration) WithGeo(value *GeoApplyConfiguration) *PolicySpecApplyConfiguration {
+	b.Geo = value
+	return b
+}
+
+// WithTracing sets the Tracing field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Tracing field is set to the value of the last call.
+func (b *PolicySpecApplyConfiguration) WithTracing(value *TracingApplyConfiguration) *PolicySpecApplyConfiguration {
+	b.Tracing = value
+	return b
+}
@@ -2,6 +2,10 @@
 
 package v1
 
+import (
+	metav1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
 // PolicyStatusApplyConfiguration represents a declarative configuration of the PolicyStatus type for use
 // with apply.
 //
@@ -13,6 +17,10 @@ type PolicyStatusApplyConfiguration struct {
 	Reason *string `json:"reason,omitempty"`
 	// The message of the current state of the resource. It can contain more detailed information about the reason.
 	Message *string `json:"message,omitempty"`
+	// The generation of the resource that the status was computed for.
+	ObservedGeneration *int64 `json:"observedGeneration,omitempty"`
+	// The conditions of the resource: Accepted, ResolvedRefs, Programmed and Ready.
+	Conditions []metav1.ConditionApplyConfiguration `json:"conditions,omitempty"`
 }
 
 // PolicyStatusApplyConfiguration constructs a declarative configuration of the PolicyStatus type for use with
@@ -44,3 +52,24 @@ func (b *PolicyStatusApplyConfiguration) WithMessage(value string) *PolicyStatus
 	b.Message = &value
 	return b
 }
+
+// WithObservedGeneration sets the ObservedGeneration field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ObservedGeneration field is set to the value of the last call.
+func (b *PolicyStatusApplyConfiguration) WithObservedGeneration(value int64) *PolicyStatusApplyConfiguration {
+	b.ObservedGeneration = &value
+	return b
+}
+
+// WithConditions adds the given value to the Conditions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Conditions field.
+func (b *PolicyStatusApplyConfiguration) WithConditions(values ...*metav1.ConditionApplyConfiguration) *PolicyStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithConditions")
+		}
+		b.Conditions = append(b.Conditions, *values[i])
+	}
+	return b
+}
@@ -0,0 +1,55 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// ResponseHeaderApplyConfiguration represents a declarative configuration of the ResponseHeader type for use
+// with apply.
+//
+// ResponseHeader defines a response header of a headers policy.
+type ResponseHeaderApplyConfiguration struct {
+	HeaderApplyConfiguration `json:",inline"`
+	// If set to true, the header is applied regardless of the response status code. By default, the header is applied only to the 200, 201, 204, 206, 301, 302, 303, 304, 307 and 308 responses.
+	Always *bool `json:"always,omitempty"`
+	// Codes restricts the header to the responses with the status codes, which can be exact codes, for example 404, or classes of codes, for example 5xx. Setting the codes implies always.
+	Codes []string `json:"codes,omitempty"`
+}
+
+// ResponseHeaderApplyConfiguration constructs a declarative configuration of the ResponseHeader type for use with
+// apply.
+func ResponseHeader() *ResponseHeaderApplyConfiguration {
+	return &ResponseHeaderApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *ResponseHeaderApplyConfiguration) WithName(value string) *ResponseHeaderApplyConfiguration {
+	b.HeaderApplyConfiguration.Name = &value
+	return b
+}
+
+// WithValue sets the Value field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Value field is set to the value of the last call.
+func (b *ResponseHeaderApplyConfiguration) WithValue(value string) *ResponseHeaderApplyConfiguration {
+	b.HeaderApplyConfiguration.Value = &value
+	return b
+}
+
+// WithAlways sets the Always field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Always field is set to the value of the last call.
+func (b *ResponseHeaderApplyConfiguration) WithAlways(value bool) *ResponseHeaderApplyConfiguration {
+	b.Always = &value
+	return b
+}
+
+// WithCodes adds the given value to the Codes field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Codes field.
+func (b *ResponseHeaderApplyConfiguration) WithCodes(values ...string) *ResponseHeaderApplyConfiguration {
+	for i := range values {
+		b.Codes = append(b.Codes, values[i])
+	}
+	return b
+}
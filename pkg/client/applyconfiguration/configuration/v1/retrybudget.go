@@ -0,0 +1,38 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// RetryBudgetApplyConfiguration represents a declarative configuration of the RetryBudget type for use
+// with apply.
+//
+// RetryBudget limits the number of retries relative to the number of recent requests.
+// The budget is not a percentage of the in-flight requests, because NGINX doesn't expose the retries in progress:
+// the retries and the requests to the route are counted over the last 10 seconds.
+type RetryBudgetApplyConfiguration struct {
+	// The maximum number of retries as a percentage of the requests to the route over the last 10 seconds. The requests are counted when they are received, and the retries when the responses are sent. Requests over the budget are not retried. Must fall into the range 1..100.
+	PercentOfRecentRequests *int `json:"percentOfRecentRequests,omitempty"`
+	// The number of retries over the last 10 seconds that are allowed regardless of the percentage. The default is 3.
+	MinRetries *int `json:"minRetries,omitempty"`
+}
+
+// RetryBudgetApplyConfiguration constructs a declarative configuration of the RetryBudget type for use with
+// apply.
+func RetryBudget() *RetryBudgetApplyConfiguration {
+	return &RetryBudgetApplyConfiguration{}
+}
+
+// WithPercentOfRecentRequests sets the PercentOfRecentRequests field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PercentOfRecentRequests field is set to the value of the last call.
+func (b *RetryBudgetApplyConfiguration) WithPercentOfRecentRequests(value int) *RetryBudgetApplyConfiguration {
+	b.PercentOfRecentRequests = &value
+	return b
+}
+
+// WithMinRetries sets the MinRetries field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MinRetries field is set to the value of the last call.
+func (b *RetryBudgetApplyConfiguration) WithMinRetries(value int) *RetryBudgetApplyConfiguration {
+	b.MinRetries = &value
+	return b
+}
@@ -0,0 +1,68 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// RetryPolicyApplyConfiguration represents a declarative configuration of the RetryPolicy type for use
+// with apply.
+//
+// RetryPolicy defines the retries of the requests passed to an upstream.
+type RetryPolicyApplyConfiguration struct {
+	// The conditions in which a request is passed to the next upstream server. Possible values: error, timeout, 5xx, gateway-error and non-idempotent. Connection resets by the upstream server are handled by the error condition. The 5xx condition includes the 500, 502, 503 and 504 responses, and the gateway-error condition includes the 502, 503 and 504 responses. The non-idempotent condition enables retries of requests with a non-idempotent method (POST, LOCK, PATCH) and must be combined with other conditions. The default is error and timeout.
+	Conditions []string `json:"conditions,omitempty"`
+	// The number of tries for passing a request to the next upstream server, including the first try. The default is 0 (not limited).
+	Tries *int `json:"tries,omitempty"`
+	// The time allowed to pass a request to the next upstream server, including all tries. The default is 0 (not limited).
+	Timeout *string `json:"timeout,omitempty"`
+	// The timeout of each try. Sets the connect, send and read timeouts of the proxied requests. By default, the timeouts of the upstream are used.
+	PerTryTimeout *string `json:"perTryTimeout,omitempty"`
+	// Limits the retries when many requests fail at the same time.
+	Budget *RetryBudgetApplyConfiguration `json:"budget,omitempty"`
+}
+
+// RetryPolicyApplyConfiguration constructs a declarative configuration of the RetryPolicy type for use with
+// apply.
+func RetryPolicy() *RetryPolicyApplyConfiguration {
+	return &RetryPolicyApplyConfiguration{}
+}
+
+// WithConditions adds the given value to the Conditions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Conditions field.
+func (b *RetryPolicyApplyConfiguration) WithConditions(values ...string) *RetryPolicyApplyConfiguration {
+	for i := range values {
+		b.Conditions = append(b.Conditions, values[i])
+	}
+	return b
+}
+
+// WithTries sets the Tries field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Tries field is set to the value of the last call.
+func (b *RetryPolicyApplyConfiguration) WithTries(value int) *RetryPolicyApplyConfiguration {
+	b.Tries = &value
+	return b
+}
+
+// WithTimeout sets the Timeout field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Timeout field is set to the value of the last call.
+func (b *RetryPolicyApplyConfiguration) WithTimeout(value string) *RetryPolicyApplyConfiguration {
+	b.Timeout = &value
+	return b
+}
+
+// WithPerTryTimeout sets the PerTryTimeout field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PerTryTimeout field is set to the value of the last call.
+func (b *RetryPolicyApplyConfiguration) WithPerTryTimeout(value string) *RetryPolicyApplyConfiguration {
+	b.PerTryTimeout = &value
+	return b
+}
+
+// WithBudget sets the Budget field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Budget field is set to the value of the last call.
+func (b *RetryPolicyApplyConfiguration) WithBudget(value *RetryBudgetApplyConfiguration) *RetryPolicyApplyConfiguration {
+	b.Budget = value
+	return b
+}
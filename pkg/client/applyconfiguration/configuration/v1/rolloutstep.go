@@ -0,0 +1,36 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// RolloutStepApplyConfiguration represents a declarative configuration of the RolloutStep type for use
+// with apply.
+//
+// RolloutStep defines a step of a Rollout.
+type RolloutStepApplyConfiguration struct {
+	// The weight of the second split during the step. The first split receives the rest of the traffic. Must fall into the range 0..100.
+	Weight *int `json:"weight,omitempty"`
+	// The duration of the step. The rollout moves to the next step after the duration if the thresholds are not exceeded. Required for all steps except the last one.
+	Duration *string `json:"duration,omitempty"`
+}
+
+// RolloutStepApplyConfiguration constructs a declarative configuration of the RolloutStep type for use with
+// apply.
+func RolloutStep() *RolloutStepApplyConfiguration {
+	return &RolloutStepApplyConfiguration{}
+}
+
+// WithWeight sets the Weight field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Weight field is set to the value of the last call.
+func (b *RolloutStepApplyConfiguration) WithWeight(value int) *RolloutStepApplyConfiguration {
+	b.Weight = &value
+	return b
+}
+
+// WithDuration sets the Duration field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Duration field is set to the value of the last call.
+func (b *RolloutStepApplyConfiguration) WithDuration(value string) *RolloutStepApplyConfiguration {
+	b.Duration = &value
+	return b
+}
@@ -33,6 +33,8 @@ type RouteApplyConfiguration struct {
 	AddHeaderInherit *string `json:"add-header-inherit,omitempty"`
 	// A reference to a DosProtectedResource, setting this enables DOS protection of the VirtualServer route.
 	Dos *string `json:"dos,omitempty"`
+	// The progressive rollout of the traffic from the first to the second split of the route. Requires exactly 2 splits. Supported only in the routes of VirtualServer and only in NGINX Plus. The weight of the current step, reported in the status of the VirtualServer, overrides the weights of the splits.
+	Rollout *RolloutApplyConfiguration `json:"rollout,omitempty"`
 }
 
 // RouteApplyConfiguration constructs a declarative configuration of the Route type for use with
@@ -148,3 +150,11 @@ func (b *RouteApplyConfiguration) WithDos(value string) *RouteApplyConfiguration
 	b.Dos = &value
 	return b
 }
+
+// WithRollout sets the Rollout field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Rollout field is set to the value of the last call.
+func (b *RouteApplyConfiguration) WithRollout(value *RolloutApplyConfiguration) *RouteApplyConfiguration {
+	b.Rollout = value
+	return b
+}
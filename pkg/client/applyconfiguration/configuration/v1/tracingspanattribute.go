@@ -0,0 +1,36 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// TracingSpanAttributeApplyConfiguration represents a declarative configuration of the TracingSpanAttribute type for use
+// with apply.
+//
+// TracingSpanAttribute defines a custom attribute of the spans.
+type TracingSpanAttributeApplyConfiguration struct {
+	// The name of the attribute, for example, app.tenant.
+	Name *string `json:"name,omitempty"`
+	// The value of the attribute. Can contain NGINX variables.
+	Value *string `json:"value,omitempty"`
+}
+
+// TracingSpanAttributeApplyConfiguration constructs a declarative configuration of the TracingSpanAttribute type for use with
+// apply.
+func TracingSpanAttribute() *TracingSpanAttributeApplyConfiguration {
+	return &TracingSpanAttributeApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *TracingSpanAttributeApplyConfiguration) WithName(value string) *TracingSpanAttributeApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithValue sets the Value field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Value field is set to the value of the last call.
+func (b *TracingSpanAttributeApplyConfiguration) WithValue(value string) *TracingSpanAttributeApplyConfiguration {
+	b.Value = &value
+	return b
+}
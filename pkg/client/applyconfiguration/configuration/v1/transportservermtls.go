@@ -0,0 +1,66 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// TransportServerMTLSApplyConfiguration represents a declarative configuration of the TransportServerMTLS type for use
+// with apply.
+//
+// TransportServerMTLS defines the client certificate verification of a TransportServer.
+type TransportServerMTLSApplyConfiguration struct {
+	// The name of the Kubernetes secret that stores the CA certificate. It must be in the same namespace as the TransportServer resource. The secret must be of the type nginx.org/ca, and the certificate must be stored in the secret under the key ca.crt, otherwise the secret will be rejected as invalid.
+	ClientCertSecret *string `json:"clientCertSecret,omitempty"`
+	// The file name of the Certificate Revocation List. NGINX Ingress Controller will look for this file in /etc/nginx/secrets
+	CrlFileName *string `json:"crlFileName,omitempty"`
+	// Verification for the client. Possible values are "on", "optional" and "optional_no_ca". The default is "on".
+	VerifyClient *string `json:"verifyClient,omitempty"`
+	// Sets the verification depth in the client certificates chain. The default is 1.
+	VerifyDepth *int `json:"verifyDepth,omitempty"`
+	// Sends the PROXY protocol v2 header with the TLS details of the client, such as the common name of the client certificate and the result of the verification, to the upstream servers. The header is sent before the first data of the client. The default is false.
+	ProxyProtocol *bool `json:"proxyProtocol,omitempty"`
+}
+
+// TransportServerMTLSApplyConfiguration constructs a declarative configuration of the TransportServerMTLS type for use with
+// apply.
+func TransportServerMTLS() *TransportServerMTLSApplyConfiguration {
+	return &TransportServerMTLSApplyConfiguration{}
+}
+
+// WithClientCertSecret sets the ClientCertSecret field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ClientCertSecret field is set to the value of the last call.
+func (b *TransportServerMTLSApplyConfiguration) WithClientCertSecret(value string) *TransportServerMTLSApplyConfiguration {
+	b.ClientCertSecret = &value
+	return b
+}
+
+// WithCrlFileName sets the CrlFileName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CrlFileName field is set to the value of the last call.
+func (b *TransportServerMTLSApplyConfiguration) WithCrlFileName(value string) *TransportServerMTLSApplyConfiguration {
+	b.CrlFileName = &value
+	return b
+}
+
+// WithVerifyClient sets the VerifyClient field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the VerifyClient field is set to the value of the last call.
+func (b *TransportServerMTLSApplyConfiguration) WithVerifyClient(value string) *TransportServerMTLSApplyConfiguration {
+	b.VerifyClient = &value
+	return b
+}
+
+// WithVerifyDepth sets the VerifyDepth field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the VerifyDepth field is set to the value of the last call.
+func (b *TransportServerMTLSApplyConfiguration) WithVerifyDepth(value int) *TransportServerMTLSApplyConfiguration {
+	b.VerifyDepth = &value
+	return b
+}
+
+// WithProxyProtocol sets the ProxyProtocol field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ProxyProtocol field is set to the value of the last call.
+func (b *TransportServerMTLSApplyConfiguration) WithProxyProtocol(value bool) *TransportServerMTLSApplyConfiguration {
+	b.ProxyProtocol = &value
+	return b
+}
@@ -27,6 +27,8 @@ type TransportServerSpecApplyConfiguration struct {
 	SessionParameters *SessionParametersApplyConfiguration `json:"sessionParameters,omitempty"`
 	// The action to perform for a request.
 	Action *TransportServerActionApplyConfiguration `json:"action,omitempty"`
+	// A list of policies. Supported policies are accessControl and connectionLimit.
+	Policies []PolicyReferenceApplyConfiguration `json:"policies,omitempty"`
 }
 
 // TransportServerSpecApplyConfiguration constructs a declarative configuration of the TransportServerSpec type for use with
@@ -119,3 +121,16 @@ func (b *TransportServerSpecApplyConfiguration) WithAction(value *TransportServe
 	b.Action = value
 	return b
 }
+
+// WithPolicies adds the given value to the Policies field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Policies field.
+func (b *TransportServerSpecApplyConfiguration) WithPolicies(values ...*PolicyReferenceApplyConfiguration) *TransportServerSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithPolicies")
+		}
+		b.Policies = append(b.Policies, *values[i])
+	}
+	return b
+}
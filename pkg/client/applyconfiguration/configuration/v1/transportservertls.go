@@ -8,6 +8,8 @@ package v1
 // TransportServerTLS defines TransportServerTLS configuration for a TransportServer.
 type TransportServerTLSApplyConfiguration struct {
 	Secret *string `json:"secret,omitempty"`
+	// The client certificate verification. Requires the secret.
+	MTLS *TransportServerMTLSApplyConfiguration `json:"mtls,omitempty"`
 }
 
 // TransportServerTLSApplyConfiguration constructs a declarative configuration of the TransportServerTLS type for use with
@@ -23,3 +25,11 @@ func (b *TransportServerTLSApplyConfiguration) WithSecret(value string) *Transpo
 	b.Secret = &value
 	return b
 }
+
+// WithMTLS sets the MTLS field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MTLS field is set to the value of the last call.
+func (b *TransportServerTLSApplyConfiguration) WithMTLS(value *TransportServerMTLSApplyConfiguration) *TransportServerTLSApplyConfiguration {
+	b.MTLS = value
+	return b
+}
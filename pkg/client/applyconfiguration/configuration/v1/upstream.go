@@ -71,6 +71,8 @@ type UpstreamApplyConfiguration struct {
 	Backup *string `json:"backup,omitempty"`
 	// The port of the backup service. The backup port is required if the backup service name is provided. The port must fall into the range 1..65535.
 	BackupPort *uint16 `json:"backupPort,omitempty"`
+	// The outlier detection configuration for the Upstream. Upstream servers that return a run of 5xx responses or time out are ejected from the upstream for a period of time. Ejected servers are re-admitted gradually: with NGINX Plus during the slow-start of the upstream, which defaults to 30s; with NGINX by increasing their weight in steps, with at most one reload every 10 seconds.
+	OutlierDetection *OutlierDetectionApplyConfiguration `json:"outlierDetection,omitempty"`
 }
 
 // UpstreamApplyConfiguration constructs a declarative configuration of the Upstream type for use with
@@ -332,3 +334,11 @@ func (b *UpstreamApplyConfiguration) WithBackupPort(value uint16) *UpstreamApply
 	b.BackupPort = &value
 	return b
 }
+
+// WithOutlierDetection sets the OutlierDetection field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the OutlierDetection field is set to the value of the last call.
+func (b *UpstreamApplyConfiguration) WithOutlierDetection(value *OutlierDetectionApplyConfiguration) *UpstreamApplyConfiguration {
+	b.OutlierDetection = value
+	return b
+}
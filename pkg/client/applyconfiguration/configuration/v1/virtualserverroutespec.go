@@ -15,6 +15,8 @@ type VirtualServerRouteSpecApplyConfiguration struct {
 	Upstreams []UpstreamApplyConfiguration `json:"upstreams,omitempty"`
 	// A list of subroutes.
 	Subroutes []RouteApplyConfiguration `json:"subroutes,omitempty"`
+	// Disables the default policies of the GlobalConfiguration for the VirtualServerRoute. Only the default policies that allow opting out are disabled.
+	DisableDefaultPolicies *bool `json:"disableDefaultPolicies,omitempty"`
 }
 
 // VirtualServerRouteSpecApplyConfiguration constructs a declarative configuration of the VirtualServerRouteSpec type for use with
@@ -64,3 +66,11 @@ func (b *VirtualServerRouteSpecApplyConfiguration) WithSubroutes(values ...*Rout
 	}
 	return b
 }
+
+// WithDisableDefaultPolicies sets the DisableDefaultPolicies field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DisableDefaultPolicies field is set to the value of the last call.
+func (b *VirtualServerRouteSpecApplyConfiguration) WithDisableDefaultPolicies(value bool) *VirtualServerRouteSpecApplyConfiguration {
+	b.DisableDefaultPolicies = &value
+	return b
+}
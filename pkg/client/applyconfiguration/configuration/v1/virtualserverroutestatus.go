@@ -2,6 +2,10 @@
 
 package v1
 
+import (
+	metav1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
 // VirtualServerRouteStatusApplyConfiguration represents a declarative configuration of the VirtualServerRouteStatus type for use
 // with apply.
 //
@@ -17,6 +21,10 @@ type VirtualServerRouteStatusApplyConfiguration struct {
 	ReferencedBy *string `json:"referencedBy,omitempty"`
 	// Defines the IPs, hostnames and ports used to connect to this resource.
 	ExternalEndpoints []ExternalEndpointApplyConfiguration `json:"externalEndpoints,omitempty"`
+	// The generation of the resource that the status was computed for.
+	ObservedGeneration *int64 `json:"observedGeneration,omitempty"`
+	// The conditions of the resource: Accepted, ResolvedRefs, Programmed and Ready.
+	Conditions []metav1.ConditionApplyConfiguration `json:"conditions,omitempty"`
 }
 
 // VirtualServerRouteStatusApplyConfiguration constructs a declarative configuration of the VirtualServerRouteStatus type for use with
@@ -69,3 +77,24 @@ func (b *VirtualServerRouteStatusApplyConfiguration) WithExternalEndpoints(value
 	}
 	return b
 }
+
+// WithObservedGeneration sets the ObservedGeneration field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ObservedGeneration field is set to the value of the last call.
+func (b *VirtualServerRouteStatusApplyConfiguration) WithObservedGeneration(value int64) *VirtualServerRouteStatusApplyConfiguration {
+	b.ObservedGeneration = &value
+	return b
+}
+
+// WithConditions adds the given value to the Conditions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Conditions field.
+func (b *VirtualServerRouteStatusApplyConfiguration) WithConditions(values ...*metav1.ConditionApplyConfiguration) *VirtualServerRouteStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithConditions")
+		}
+		b.Conditions = append(b.Conditions, *values[i])
+	}
+	return b
+}
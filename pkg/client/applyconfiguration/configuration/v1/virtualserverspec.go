@@ -35,6 +35,8 @@ type VirtualServerSpecApplyConfiguration struct {
 	ExternalDNS *ExternalDNSApplyConfiguration `json:"externalDNS,omitempty"`
 	// InternalRoute allows for the configuration of internal routing.
 	InternalRoute *bool `json:"internalRoute,omitempty"`
+	// Disables the default policies of the GlobalConfiguration for the VirtualServer. Only the default policies that allow opting out are disabled.
+	DisableDefaultPolicies *bool `json:"disableDefaultPolicies,omitempty"`
 }
 
 // VirtualServerSpecApplyConfiguration constructs a declarative configuration of the VirtualServerSpec type for use with
@@ -169,3 +171,11 @@ func (b *VirtualServerSpecApplyConfiguration) WithInternalRoute(value bool) *Vir
 	b.InternalRoute = &value
 	return b
 }
+
+// WithDisableDefaultPolicies sets the DisableDefaultPolicies field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DisableDefaultPolicies field is set to the value of the last call.
+func (b *VirtualServerSpecApplyConfiguration) WithDisableDefaultPolicies(value bool) *VirtualServerSpecApplyConfiguration {
+	b.DisableDefaultPolicies = &value
+	return b
+}
@@ -2,6 +2,10 @@
 
 package v1
 
+import (
+	metav1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
 // VirtualServerStatusApplyConfiguration represents a declarative configuration of the VirtualServerStatus type for use
 // with apply.
 //
@@ -11,6 +15,12 @@ type VirtualServerStatusApplyConfiguration struct {
 	Reason            *string                              `json:"reason,omitempty"`
 	Message           *string                              `json:"message,omitempty"`
 	ExternalEndpoints []ExternalEndpointApplyConfiguration `json:"externalEndpoints,omitempty"`
+	// The generation of the resource that the status was computed for.
+	ObservedGeneration *int64 `json:"observedGeneration,omitempty"`
+	// The conditions of the resource: Accepted, ResolvedRefs, Programmed and Ready.
+	Conditions []metav1.ConditionApplyConfiguration `json:"conditions,omitempty"`
+	// The status of the rollouts of the routes.
+	Rollouts []RolloutStatusApplyConfiguration `json:"rollouts,omitempty"`
 }
 
 // VirtualServerStatusApplyConfiguration constructs a declarative configuration of the VirtualServerStatus type for use with
@@ -55,3 +65,37 @@ func (b *VirtualServerStatusApplyConfiguration) WithExternalEndpoints(values ...
 	}
 	return b
 }
+
+// WithObservedGeneration sets the ObservedGeneration field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ObservedGeneration field is set to the value of the last call.
+func (b *VirtualServerStatusApplyConfiguration) WithObservedGeneration(value int64) *VirtualServerStatusApplyConfiguration {
+	b.ObservedGeneration = &value
+	return b
+}
+
+// WithConditions adds the given value to the Conditions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Conditions field.
+func (b *VirtualServerStatusApplyConfiguration) WithConditions(values ...*metav1.ConditionApplyConfiguration) *VirtualServerStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithConditions")
+		}
+		b.Conditions = append(b.Conditions, *values[i])
+	}
+	return b
+}
+
+// WithRollouts adds the given value to the Rollouts field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Rollouts field.
+func (b *VirtualServerStatusApplyConfiguration) WithRollouts(values ...*RolloutStatusApplyConfiguration) *VirtualServerStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithRollouts")
+		}
+		b.Rollouts = append(b.Rollouts, *values[i])
+	}
+	return b
+}
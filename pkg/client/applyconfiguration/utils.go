@@ -48,6 +48,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &applyconfigurationconfigurationv1.AccessControlApplyConfiguration{}
 	case configurationv1.SchemeGroupVersion.WithKind("Action"):
 		return &applyconfigurationconfigurationv1.ActionApplyConfiguration{}
+	case configurationv1.SchemeGroupVersion.WithKind("ActionMirror"):
+		return &applyconfigurationconfigurationv1.ActionMirrorApplyConfiguration{}
 	case configurationv1.SchemeGroupVersion.WithKind("ActionProxy"):
 		return &applyconfigurationconfigurationv1.ActionProxyApplyConfiguration{}
 	case configurationv1.SchemeGroupVersion.WithKind("ActionRedirect"):
@@ -72,8 +74,12 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &applyconfigurationconfigurationv1.CertManagerApplyConfiguration{}
 	case configurationv1.SchemeGroupVersion.WithKind("Condition"):
 		return &applyconfigurationconfigurationv1.ConditionApplyConfiguration{}
+	case configurationv1.SchemeGroupVersion.WithKind("ConnectionLimit"):
+		return &applyconfigurationconfigurationv1.ConnectionLimitApplyConfiguration{}
 	case configurationv1.SchemeGroupVersion.WithKind("CORS"):
 		return &applyconfigurationconfigurationv1.CORSApplyConfiguration{}
+	case configurationv1.SchemeGroupVersion.WithKind("DefaultPolicies"):
+		return &applyconfigurationconfigurationv1.DefaultPoliciesApplyConfiguration{}
 	case configurationv1.SchemeGroupVersion.WithKind("EgressMTLS"):
 		return &applyconfigurationconfigurationv1.EgressMTLSApplyConfiguration{}
 	case configurationv1.SchemeGroupVersion.WithKind("ErrorPage"):
@@ -84,30 +90,54 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &applyconfigurationconfigurationv1.ErrorPageReturnApplyConfiguration{}
 	case configurationv1.SchemeGroupVersion.WithKind("ExternalAuth"):
 		return &applyconfigurationconfigurationv1.ExternalAuthApplyConfiguration{}
+	case configurationv1.SchemeGroupVersion.WithKind("ExternalAuthGRPC"):
+		return &applyconfigurationconfigurationv1.ExternalAuthGRPCApplyConfiguration{}
 	case configurationv1.SchemeGroupVersion.WithKind("ExternalDNS"):
 		return &applyconfigurationconfigurationv1.ExternalDNSApplyConfiguration{}
 	case configurationv1.SchemeGroupVersion.WithKind("ExternalEndpoint"):
 		return &applyconfigurationconfigurationv1.ExternalEndpointApplyConfiguration{}
+	case configurationv1.SchemeGroupVersion.WithKind("Geo"):
+		return &applyconfigurationconfigurationv1.GeoApplyConfiguration{}
+	case configurationv1.SchemeGroupVersion.WithKind("GeoMatch"):
+		return &applyconfigurationconfigurationv1.GeoMatchApplyConfiguration{}
 	case configurationv1.SchemeGroupVersion.WithKind("GlobalConfiguration"):
 		return &applyconfigurationconfigurationv1.GlobalConfigurationApplyConfiguration{}
 	case configurationv1.SchemeGroupVersion.WithKind("GlobalConfigurationSpec"):
 		return &applyconfigurationconfigurationv1.GlobalConfigurationSpecApplyConfiguration{}
 	case configurationv1.SchemeGroupVersion.WithKind("Header"):
 		return &applyconfigurationconfigurationv1.HeaderApplyConfiguration{}
+	case configurationv1.SchemeGroupVersion.WithKind("Headers"):
+		return &applyconfigurationconfigurationv1.HeadersApplyConfiguration{}
 	case configurationv1.SchemeGroupVersion.WithKind("HealthCheck"):
 		return &applyconfigurationconfigurationv1.HealthCheckApplyConfiguration{}
+	case configurationv1.SchemeGroupVersion.WithKind("HMAC"):
+		return &applyconfigurationconfigurationv1.HMACApplyConfiguration{}
 	case configurationv1.SchemeGroupVersion.WithKind("IngressMTLS"):
 		return &applyconfigurationconfigurationv1.IngressMTLSApplyConfiguration{}
+	case configurationv1.SchemeGroupVersion.WithKind("Introspection"):
+		return &applyconfigurationconfigurationv1.IntrospectionApplyConfiguration{}
+	case configurationv1.SchemeGroupVersion.WithKind("IntrospectionHeader"):
+		return &applyconfigurationconfigurationv1.IntrospectionHeaderApplyConfiguration{}
 	case configurationv1.SchemeGroupVersion.WithKind("JWTAuth"):
 		return &applyconfigurationconfigurationv1.JWTAuthApplyConfiguration{}
+	case configurationv1.SchemeGroupVersion.WithKind("JWTClaimRequirement"):
+		return &applyconfigurationconfigurationv1.JWTClaimRequirementApplyConfiguration{}
 	case configurationv1.SchemeGroupVersion.WithKind("JWTCondition"):
 		return &applyconfigurationconfigurationv1.JWTConditionApplyConfiguration{}
+	case configurationv1.SchemeGroupVersion.WithKind("JWTRequire"):
+		return &applyconfigurationconfigurationv1.JWTRequireApplyConfiguration{}
 	case configurationv1.SchemeGroupVersion.WithKind("Listener"):
 		return &applyconfigurationconfigurationv1.ListenerApplyConfiguration{}
 	case configurationv1.SchemeGroupVersion.WithKind("Match"):
 		return &applyconfigurationconfigurationv1.MatchApplyConfiguration{}
 	case configurationv1.SchemeGroupVersion.WithKind("OIDC"):
 		return &applyconfigurationconfigurationv1.OIDCApplyConfiguration{}
+	case configurationv1.SchemeGroupVersion.WithKind("OIDCClaimHeader"):
+		return &applyconfigurationconfigurationv1.OIDCClaimHeaderApplyConfiguration{}
+	case configurationv1.SchemeGroupVersion.WithKind("OIDCGroups"):
+		return &applyconfigurationconfigurationv1.OIDCGroupsApplyConfiguration{}
+	case configurationv1.SchemeGroupVersion.WithKind("OutlierDetection"):
+		return &applyconfigurationconfigurationv1.OutlierDetectionApplyConfiguration{}
 	case configurationv1.SchemeGroupVersion.WithKind("Policy"):
 		return &applyconfigurationconfigurationv1.PolicyApplyConfiguration{}
 	case configurationv1.SchemeGroupVersion.WithKind("PolicyReference"):
@@ -126,6 +156,22 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &applyconfigurationconfigurationv1.RateLimitApplyConfiguration{}
 	case configurationv1.SchemeGroupVersion.WithKind("RateLimitCondition"):
 		return &applyconfigurationconfigurationv1.RateLimitConditionApplyConfiguration{}
+	case configurationv1.SchemeGroupVersion.WithKind("RequestHeadersModifier"):
+		return &applyconfigurationconfigurationv1.RequestHeadersModifierApplyConfiguration{}
+	case configurationv1.SchemeGroupVersion.WithKind("ResponseHeader"):
+		return &applyconfigurationconfigurationv1.ResponseHeaderApplyConfiguration{}
+	case configurationv1.SchemeGroupVersion.WithKind("ResponseHeadersModifier"):
+		return &applyconfigurationconfigurationv1.ResponseHeadersModifierApplyConfiguration{}
+	case configurationv1.SchemeGroupVersion.WithKind("RetryBudget"):
+		return &applyconfigurationconfigurationv1.RetryBudgetApplyConfiguration{}
+	case configurationv1.SchemeGroupVersion.WithKind("RetryPolicy"):
+		return &applyconfigurationconfigurationv1.RetryPolicyApplyConfiguration{}
+	case configurationv1.SchemeGroupVersion.WithKind("Rollout"):
+		return &applyconfigurationconfigurationv1.RolloutApplyConfiguration{}
+	case configurationv1.SchemeGroupVersion.WithKind("RolloutStatus"):
+		return &applyconfigurationconfigurationv1.RolloutStatusApplyConfiguration{}
+	case configurationv1.SchemeGroupVersion.WithKind("RolloutStep"):
+		return &applyconfigurationconfigurationv1.RolloutStepApplyConfiguration{}
 	case configurationv1.SchemeGroupVersion.WithKind("Route"):
 		return &applyconfigurationconfigurationv1.RouteApplyConfiguration{}
 	case configurationv1.SchemeGroupVersion.WithKind("SecurityLog"):
@@ -142,6 +188,10 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &applyconfigurationconfigurationv1.TLSApplyConfiguration{}
 	case configurationv1.SchemeGroupVersion.WithKind("TLSRedirect"):
 		return &applyconfigurationconfigurationv1.TLSRedirectApplyConfiguration{}
+	case configurationv1.SchemeGroupVersion.WithKind("Tracing"):
+		return &applyconfigurationconfigurationv1.TracingApplyConfiguration{}
+	case configurationv1.SchemeGroupVersion.WithKind("TracingSpanAttribute"):
+		return &applyconfigurationconfigurationv1.TracingSpanAttributeApplyConfiguration{}
 	case configurationv1.SchemeGroupVersion.WithKind("TransportServer"):
 		return &applyconfigurationconfigurationv1.TransportServerApplyConfiguration{}
 	case configurationv1.SchemeGroupVersion.WithKind("TransportServerAction"):
@@ -152,6 +202,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &applyconfigurationconfigurationv1.TransportServerListenerApplyConfiguration{}
 	case configurationv1.SchemeGroupVersion.WithKind("TransportServerMatch"):
 		return &applyconfigurationconfigurationv1.TransportServerMatchApplyConfiguration{}
+	case configurationv1.SchemeGroupVersion.WithKind("TransportServerMTLS"):
+		return &applyconfigurationconfigurationv1.TransportServerMTLSApplyConfiguration{}
 	case configurationv1.SchemeGroupVersion.WithKind("TransportServerSpec"):
 		return &applyconfigurationconfigurationv1.TransportServerSpecApplyConfiguration{}
 	case configurationv1.SchemeGroupVersion.WithKind("TransportServerStatus"):
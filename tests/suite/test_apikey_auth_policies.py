@@ -1,3 +1,4 @@
+import http.client
 from collections import namedtuple
 
 import pytest
@@ -19,13 +20,16 @@ apikey_auth_pol_valid = f"{TEST_DATA}/apikey-auth-policy/policies/apikey-policy-
 apikey_auth_pol_valid_2 = f"{TEST_DATA}/apikey-auth-policy/policies/apikey-policy-valid-2.yaml"
 apikey_auth_pol_server = f"{TEST_DATA}/apikey-auth-policy/policies/apikey-policy-server.yaml"
 apikey_auth_pol_route = f"{TEST_DATA}/apikey-auth-policy/policies/apikey-policy-vs-route.yaml"
+apikey_auth_pol_routes = f"{TEST_DATA}/apikey-auth-policy/policies/apikey-policy-routes.yaml"
 
 apikey_auth_secret_1 = f"{TEST_DATA}/apikey-auth-policy/secret/apikey-secret-1.yaml"
 apikey_auth_secret_2 = f"{TEST_DATA}/apikey-auth-policy/secret/apikey-secret-2.yaml"
 apikey_auth_secret_server = f"{TEST_DATA}/apikey-auth-policy/secret/apikey-secret-server.yaml"
 apikey_auth_secret_route = f"{TEST_DATA}/apikey-auth-policy/secret/apikey-secret-route.yaml"
+apikey_auth_secret_routes = f"{TEST_DATA}/apikey-auth-policy/secret/apikey-secret-routes.yaml"
 
 apikey_auth_vs_single_src = f"{TEST_DATA}/apikey-auth-policy/spec/virtual-server-policy-single.yaml"
+apikey_auth_vs_routes_src = f"{TEST_DATA}/apikey-auth-policy/spec/virtual-server-policy-routes.yaml"
 apikey_auth_vs_vsr_src = f"{TEST_DATA}/apikey-auth-policy/spec/vsr/virtual-server-with-vsr.yaml"
 
 vsr_1_src = f"{TEST_DATA}/apikey-auth-policy/spec/vsr/backend1-vsr.yaml"
@@ -501,3 +505,61 @@ class TestAPIKeyAuthPolicies:
         assert len(backend2_correct_query_with_correct_password_resps) > 0
         for response in backend2_correct_query_with_correct_password_resps:
             assert response.status_code == 200
+
+    def test_apikey_auth_policy_client_routes(
+        self, kube_apis, crd_ingress_controller, virtual_server_setup, test_namespace
+    ):
+        apikey_policy_details = self.setup_single_policy(
+            kube_apis,
+            virtual_server_setup.namespace,
+            apikey_auth_secret_routes,
+            apikey_auth_pol_routes,
+            virtual_server_setup.vs_host,
+        )
+
+        delete_and_create_vs_from_yaml(
+            kube_apis.custom_objects,
+            virtual_server_setup.vs_name,
+            apikey_auth_vs_routes_src,
+            virtual_server_setup.namespace,
+        )
+
+        wait_until_all_pods_are_ready(kube_apis.v1, test_namespace)
+        wait_before_test()
+
+        # the key of the client is allowed only for the /backend1 route
+        headers = {"host": apikey_policy_details.vs_host, apikey_policy_details.headers[0]: "routes-password"}
+        # the paths are sent as they are, because HTTP clients remove the dot segments from the paths
+        paths = [
+            "/backend1",
+            "/backend2",
+            "/backend1/../backend2",
+            "/backend1/..%2Fbackend2",
+            "/backend1%2F..%2Fbackend2",
+        ]
+        status_codes = {}
+        for path in paths:
+            conn = http.client.HTTPConnection(
+                virtual_server_setup.public_endpoint.public_ip, virtual_server_setup.public_endpoint.port
+            )
+            conn.request("GET", path, headers=headers)
+            status_codes[path] = conn.getresponse().status
+            conn.close()
+
+        delete_policy(kube_apis.custom_objects, apikey_policy_details.policy_name, test_namespace)
+        delete_secret(kube_apis.v1, apikey_policy_details.secret_name, test_namespace)
+
+        delete_and_create_vs_from_yaml(
+            kube_apis.custom_objects,
+            virtual_server_setup.vs_name,
+            std_vs_src,
+            virtual_server_setup.namespace,
+        )
+
+        assert status_codes == {
+            "/backend1": 200,
+            "/backend2": 403,
+            "/backend1/../backend2": 403,
+            "/backend1/..%2Fbackend2": 403,
+            "/backend1%2F..%2Fbackend2": 403,
+        }